@@ -29,6 +29,18 @@ const (
 	ApplicationResourcesTreeExperimental = "V1_1_RESOURCETREE_EXPERIMENTAL"
 
 	ApplicationFabTokenExperimental = "V1_4_FABTOKEN_EXPERIMENTAL"
+
+	// ApplicationIndexPhantomProtection is the capabilities string for the validation of the phantom reads of
+	// the queries on the secondary indexes of the state database.
+	ApplicationIndexPhantomProtection = "V1_4_INDEX_PHANTOM_PROTECTION"
+
+	// ApplicationRichQueryPhantomProtection is the capabilities string for the validation of the phantom reads
+	// of the rich queries on the state database.
+	ApplicationRichQueryPhantomProtection = "V1_4_RICH_QUERY_PHANTOM_PROTECTION"
+
+	// ApplicationStateExpiry is the capabilities string for the block-to-live of the public state keys,
+	// which are purged from the state once their block-to-live elapses.
+	ApplicationStateExpiry = "V1_4_STATE_EXPIRY"
 )
 
 // ApplicationProvider provides capabilities information for application level config.
@@ -39,6 +51,9 @@ type ApplicationProvider struct {
 	v13                     bool
 	v11PvtDataExperimental  bool
 	v14FabTokenExperimental bool
+	v14IndexPhantom         bool
+	v14RichQueryPhantom     bool
+	v14StateExpiry          bool
 }
 
 // NewApplicationProvider creates a application capabilities provider.
@@ -50,6 +65,9 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v13 = capabilities[ApplicationV1_3]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
 	_, ap.v14FabTokenExperimental = capabilities[ApplicationFabTokenExperimental]
+	_, ap.v14IndexPhantom = capabilities[ApplicationIndexPhantomProtection]
+	_, ap.v14RichQueryPhantom = capabilities[ApplicationRichQueryPhantomProtection]
+	_, ap.v14StateExpiry = capabilities[ApplicationStateExpiry]
 	return ap
 }
 
@@ -102,8 +120,8 @@ func (ap *ApplicationProvider) V1_3Validation() bool {
 
 // V2_0Validation returns true if this channel supports transaction validation
 // as introduced in v2.0. This includes:
-//  - new chaincode lifecycle
-//  - implicit per-org collections
+//   - new chaincode lifecycle
+//   - implicit per-org collections
 func (ap *ApplicationProvider) V2_0Validation() bool {
 	return false
 }
@@ -126,6 +144,24 @@ func (ap *ApplicationProvider) FabToken() bool {
 	return ap.v14FabTokenExperimental
 }
 
+// IndexPhantomProtection returns true if the phantom reads of the queries on the secondary indexes
+// of the state database are validated at commit time.
+func (ap *ApplicationProvider) IndexPhantomProtection() bool {
+	return ap.v14IndexPhantom
+}
+
+// RichQueryPhantomProtection returns true if the phantom reads of the rich queries on the state
+// database are validated at commit time.
+func (ap *ApplicationProvider) RichQueryPhantomProtection() bool {
+	return ap.v14RichQueryPhantom
+}
+
+// StateExpiry returns true if a block-to-live can be set on the public state keys, which are then
+// purged from the state at commit time once their block-to-live elapses.
+func (ap *ApplicationProvider) StateExpiry() bool {
+	return ap.v14StateExpiry
+}
+
 // HasCapability returns true if the capability is supported by this binary.
 func (ap *ApplicationProvider) HasCapability(capability string) bool {
 	switch capability {
@@ -142,6 +178,12 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationFabTokenExperimental:
 		return true
+	case ApplicationIndexPhantomProtection:
+		return true
+	case ApplicationRichQueryPhantomProtection:
+		return true
+	case ApplicationStateExpiry:
+		return true
 	default:
 		return false
 	}
@@ -69,6 +69,33 @@ func TestFabTokenExperimental(t *testing.T) {
 	assert.True(t, ap.FabToken())
 }
 
+func TestIndexPhantomProtection(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.IndexPhantomProtection())
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationIndexPhantomProtection: {},
+	})
+	assert.True(t, ap.IndexPhantomProtection())
+}
+
+func TestRichQueryPhantomProtection(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.RichQueryPhantomProtection())
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationRichQueryPhantomProtection: {},
+	})
+	assert.True(t, ap.RichQueryPhantomProtection())
+}
+
+func TestStateExpiry(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.StateExpiry())
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationStateExpiry: {},
+	})
+	assert.True(t, ap.StateExpiry())
+}
+
 func TestHasCapability(t *testing.T) {
 	ap := NewApplicationProvider(map[string]*cb.Capability{})
 	assert.True(t, ap.HasCapability(ApplicationV1_1))
@@ -76,5 +103,8 @@ func TestHasCapability(t *testing.T) {
 	assert.True(t, ap.HasCapability(ApplicationV1_3))
 	assert.True(t, ap.HasCapability(ApplicationPvtDataExperimental))
 	assert.True(t, ap.HasCapability(ApplicationResourcesTreeExperimental))
+	assert.True(t, ap.HasCapability(ApplicationIndexPhantomProtection))
+	assert.True(t, ap.HasCapability(ApplicationRichQueryPhantomProtection))
+	assert.True(t, ap.HasCapability(ApplicationStateExpiry))
 	assert.False(t, ap.HasCapability("default"))
 }
@@ -175,6 +175,14 @@ type ApplicationCapabilities interface {
 
 	// FabToken returns true if this channel supports FabToken functions
 	FabToken() bool
+
+	// IndexPhantomProtection returns true if this channel supports the queries on the secondary
+	// indexes of the state database, whose phantom reads are validated at commit time
+	IndexPhantomProtection() bool
+
+	// StateExpiry returns true if this channel supports the block-to-live of the public state keys,
+	// which are purged from the state once their block-to-live elapses
+	StateExpiry() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
@@ -38,17 +38,82 @@ var (
 
 	// ErrAttrNotIndexed is used to indicate that an attribute is not indexed
 	ErrAttrNotIndexed = errors.New("attribute not indexed")
+
+	// ErrTxFromSnapshot is used to indicate that a transaction was committed before the block store
+	// was bootstrapped from a snapshot and hence, only the txid and the validation code of the transaction are known
+	ErrTxFromSnapshot = errors.New("transaction was committed before the snapshot the block store was bootstrapped from")
+
+	// ErrBlockPruned is used to indicate that a requested block, or the block that contains a requested
+	// transaction, has been pruned from the block store
+	ErrBlockPruned = errors.New("block has been pruned")
 )
 
+// SnapshotInfo captures the details of the ledger snapshot that a block store is bootstrapped from
+type SnapshotInfo struct {
+	LastBlockNum      uint64
+	LastBlockHash     []byte
+	PreviousBlockHash []byte
+	// BootstrappingBlocks contains the blocks that are carried in the snapshot (e.g., the last block
+	// and the last config block). The block store keeps these blocks retrievable by number, even though
+	// these blocks are below the first block that is committed after the bootstrap
+	BootstrappingBlocks []*common.Block
+}
+
 // BlockStoreProvider provides an handle to a BlockStore
 type BlockStoreProvider interface {
 	CreateBlockStore(ledgerid string) (BlockStore, error)
 	OpenBlockStore(ledgerid string) (BlockStore, error)
 	Exists(ledgerid string) (bool, error)
 	List() ([]string, error)
+	// ImportFromSnapshot creates a block store for the given ledgerid that starts at the height
+	// captured in the snapshotInfo. The txids exported in the snapshotDir are loaded so that the
+	// duplicate txid detection continues to work for the transactions committed before the snapshot
+	ImportFromSnapshot(ledgerid string, snapshotDir string, snapshotInfo *SnapshotInfo) error
 	Close()
 }
 
+// BlockStoreVerifier is implemented by a BlockStoreProvider that supports verifying
+// the integrity of a block store while the block store is not opened
+type BlockStoreVerifier interface {
+	// Verify verifies the block store for the given ledgerid and reports the detected corruptions
+	Verify(ledgerid string) (*VerificationResult, error)
+}
+
+// BlockStoreRollbacker is implemented by a BlockStoreProvider that supports rolling back
+// a block store to an earlier block while the block store is not opened
+type BlockStoreRollbacker interface {
+	// Rollback removes the blocks above the given block number from the block store for the given ledgerid
+	Rollback(ledgerid string, blockNum uint64) error
+}
+
+// BlockStoreRemover is implemented by a BlockStoreProvider that supports removing
+// a block store while the block store is not opened
+type BlockStoreRemover interface {
+	// Remove removes the block store for the given ledgerid, including the block files and the block index.
+	// Removing a block store that does not exist is a noop
+	Remove(ledgerid string) error
+}
+
+// VerificationResult contains the results of verifying the integrity of a block store
+type VerificationResult struct {
+	// Height is the height of the block store as per the blocks present in the block store
+	Height uint64
+	// Corruptions contains the detected corruptions. The block store is found intact, if empty
+	Corruptions []*Corruption
+}
+
+// Corruption describes a corruption detected in a block store
+type Corruption struct {
+	// Location identifies where the corruption is detected (e.g., a block file and an offset in the file)
+	Location string
+	// Description describes the corruption
+	Description string
+}
+
+func (c *Corruption) String() string {
+	return c.Location + ": " + c.Description
+}
+
 // BlockStore - an interface for persisting and retrieving blocks
 // An implementation of this interface is expected to take an argument
 // of type `IndexConfig` which configures the block store on what items should be indexed
@@ -62,5 +127,11 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// ExportTxIds exports all the txids present in the block store into a file in the snapshotDir
+	// and returns a map of the file name to the hash of the file content
+	ExportTxIds(snapshotDir string) (map[string][]byte, error)
+	// Prune removes the blocks below the retainFromBlockNum. The blocks are pruned at the granularity of
+	// block files and hence, a few blocks below the retainFromBlockNum may be retained
+	Prune(retainFromBlockNum uint64) error
 	Shutdown()
 }
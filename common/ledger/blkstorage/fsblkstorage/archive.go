@@ -0,0 +1,393 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+const fetchedBlockfilesDir = "fetchedFromArchive"
+
+var archivingInfoKey = []byte("archivingInfo")
+
+// Archive is a cold storage to which the block files that are no longer appended to are moved, in order to keep
+// the disk usage of a peer small. The archived block files are fetched back on demand for serving the queries on
+// the blocks and the transactions that they contain. An object store can be plugged in by implementing this interface
+type Archive interface {
+	// Put stores the content of a block file under the given name. Put may be invoked again for a block
+	// file that has already been stored (e.g., after a crash) and is expected to overwrite the previous content
+	Put(ledgerID, fileName string, content io.Reader) error
+	// Get returns the content of the block file stored under the given name
+	Get(ledgerID, fileName string) (io.ReadCloser, error)
+}
+
+// NewDirArchive constructs an `Archive` that stores the block files in the given directory, in a sub-directory
+// per ledger. The directory is typically a mount of a network or an otherwise cheaper storage
+func NewDirArchive(dir string) Archive {
+	return &dirArchive{dir}
+}
+
+type dirArchive struct {
+	dir string
+}
+
+// Put implements the corresponding function in the interface `Archive`
+func (a *dirArchive) Put(ledgerID, fileName string, content io.Reader) error {
+	ledgerDir := filepath.Join(a.dir, ledgerID)
+	if _, err := util.CreateDirIfMissing(ledgerDir); err != nil {
+		return errors.Wrapf(err, "error creating archive dir [%s]", ledgerDir)
+	}
+	return writeFileAtomically(filepath.Join(ledgerDir, fileName), content)
+}
+
+// Get implements the corresponding function in the interface `Archive`
+func (a *dirArchive) Get(ledgerID, fileName string) (io.ReadCloser, error) {
+	filePath := filepath.Join(a.dir, ledgerID, fileName)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening archived block file [%s]", filePath)
+	}
+	return file, nil
+}
+
+// archiver moves the block files of a ledger to the archive and fetches the archived block files back into a
+// local cache. The block files below the firstLocalFileNum have been moved to the archive
+type archiver struct {
+	ledgerID          string
+	rootDir           string
+	cacheDir          string
+	conf              *ArchiveConf
+	db                *leveldbhelper.DBHandle
+	lock              sync.RWMutex
+	firstLocalFileNum int
+	// fetchedFileNums contains the numbers of the cached block files, the least recently used first
+	fetchedFileNums []int
+	// pinCounts contains the number of ongoing reads of the cached block files. A pinned block file is not
+	// evicted from the cache and, if removed from the cache meanwhile, it is deleted once no longer pinned
+	pinCounts   map[int]int
+	triggerChan chan struct{}
+	doneChan    chan struct{}
+}
+
+func newArchiver(ledgerID, rootDir string, conf *ArchiveConf, db *leveldbhelper.DBHandle) (*archiver, error) {
+	firstLocalFileNum, err := loadArchivingInfo(db)
+	if err != nil {
+		return nil, err
+	}
+	// a crash may have happened after the archiving info was saved but before the archived files were removed
+	if err := removeLocalBlockfilesBelow(rootDir, firstLocalFileNum); err != nil {
+		return nil, err
+	}
+	cacheDir := filepath.Join(rootDir, fetchedBlockfilesDir)
+	if err := os.RemoveAll(cacheDir); err != nil {
+		return nil, errors.Wrapf(err, "error removing dir [%s]", cacheDir)
+	}
+	if _, err := util.CreateDirIfMissing(cacheDir); err != nil {
+		return nil, errors.Wrapf(err, "error creating dir [%s]", cacheDir)
+	}
+	return &archiver{
+		ledgerID:          ledgerID,
+		rootDir:           rootDir,
+		cacheDir:          cacheDir,
+		conf:              conf,
+		db:                db,
+		firstLocalFileNum: firstLocalFileNum,
+		pinCounts:         map[int]int{},
+		triggerChan:       make(chan struct{}, 1),
+		doneChan:          make(chan struct{}),
+	}, nil
+}
+
+// trigger schedules an archiving round, unless one is already pending
+func (a *archiver) trigger() {
+	select {
+	case a.triggerChan <- struct{}{}:
+	default:
+	}
+}
+
+// stop stops the goroutine that archives the block files and waits for the ongoing archiving round, if any
+func (a *archiver) stop() {
+	close(a.triggerChan)
+	<-a.doneChan
+}
+
+// runArchiver archives the block files, whenever an archiving round is triggered, until the archiver is stopped
+func (mgr *blockfileMgr) runArchiver() {
+	defer close(mgr.archiver.doneChan)
+	for range mgr.archiver.triggerChan {
+		if err := mgr.archiveBlockfiles(); err != nil {
+			logger.Errorf("Error while archiving the block files of ledger [%s]: %s", mgr.archiver.ledgerID, err)
+		}
+	}
+}
+
+// archiveBlockfiles moves to the archive the block files that are not among the most recent block files
+// configured to be retained locally. The pruned block files are not archived
+func (mgr *blockfileMgr) archiveBlockfiles() error {
+	mgr.pruneLock.Lock()
+	defer mgr.pruneLock.Unlock()
+
+	mgr.cpInfoCond.L.Lock()
+	currentFileNum := mgr.cpInfo.latestFileChunkSuffixNum
+	mgr.cpInfoCond.L.Unlock()
+
+	fileNum := mgr.archiver.getFirstLocalFileNum()
+	if mgr.pruningInfo != nil && fileNum < mgr.pruningInfo.firstFileSuffixNum {
+		fileNum = mgr.pruningInfo.firstFileSuffixNum
+	}
+	for ; fileNum <= currentFileNum-mgr.archiver.conf.LocalBlockfiles; fileNum++ {
+		if err := mgr.archiver.archiveFile(fileNum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveFile stores the block file in the archive, records that the block file is archived, and
+// then removes the local block file
+func (a *archiver) archiveFile(fileNum int) error {
+	filePath := deriveBlockfilePath(a.rootDir, fileNum)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "error opening block file [%s]", filePath)
+	}
+	defer file.Close()
+	if err := a.conf.Archive.Put(a.ledgerID, deriveBlockfileName(fileNum), file); err != nil {
+		return errors.WithMessage(err, "error while storing the block file in the archive")
+	}
+	if err := a.db.Put(archivingInfoKey, proto.EncodeVarint(uint64(fileNum+1)), true); err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.firstLocalFileNum = fileNum + 1
+	if err := os.Remove(filePath); err != nil {
+		return errors.Wrapf(err, "error while removing the archived block file [%s]", filePath)
+	}
+	logger.Infof("Moved block file [%s] to the archive", filePath)
+	return nil
+}
+
+func (a *archiver) getFirstLocalFileNum() int {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.firstLocalFileNum
+}
+
+func (a *archiver) isArchived(fileNum int) bool {
+	return fileNum < a.getFirstLocalFileNum()
+}
+
+// withBlockfileDir invokes the function f with the directory that contains the given block file. If the block file
+// has been archived, the block file is fetched back from the archive into the local cache, if not cached already.
+// The block file is neither archived nor evicted from the cache while f is being invoked. The archive is accessed
+// without holding the lock, so that a slow fetch does not block the reads of the other block files
+func (a *archiver) withBlockfileDir(fileNum int, f func(dir string) error) (err error) {
+	a.lock.RLock()
+	if fileNum >= a.firstLocalFileNum {
+		defer a.lock.RUnlock()
+		return f(a.rootDir)
+	}
+	a.lock.RUnlock()
+
+	if err := a.fetchAndPin(fileNum); err != nil {
+		return err
+	}
+	defer func() {
+		if unpinErr := a.unpin(fileNum); err == nil {
+			err = unpinErr
+		}
+	}()
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return f(a.cacheDir)
+}
+
+// fetchAndPin pins the block file in the cache, after fetching it from the archive if it is not cached already.
+// The block file is downloaded to a temporary file without holding the lock and is moved into the cache under
+// the write lock. Concurrent fetches of the same block file may both download it, with the same result
+func (a *archiver) fetchAndPin(fileNum int) error {
+	a.lock.Lock()
+	cached := a.touch(fileNum)
+	if cached {
+		a.pinCounts[fileNum]++
+	}
+	a.lock.Unlock()
+	if cached {
+		return nil
+	}
+
+	fileName := deriveBlockfileName(fileNum)
+	logger.Debugf("Fetching block file [%s] from the archive", fileName)
+	content, err := a.conf.Archive.Get(a.ledgerID, fileName)
+	if err != nil {
+		return errors.WithMessage(err, "error while fetching the block file from the archive")
+	}
+	defer content.Close()
+	filePath := deriveBlockfilePath(a.cacheDir, fileNum)
+	tmpFilePath, err := writeTempFile(filePath, content)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFilePath)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if !a.touch(fileNum) {
+		if err := os.Rename(tmpFilePath, filePath); err != nil {
+			return errors.Wrapf(err, "error renaming file [%s] to [%s]", tmpFilePath, filePath)
+		}
+		a.fetchedFileNums = append(a.fetchedFileNums, fileNum)
+	}
+	a.pinCounts[fileNum]++
+	return a.shrinkCache()
+}
+
+// touch marks the block file as the most recently used one and returns whether the block file is cached.
+// The caller is expected to hold the write lock
+func (a *archiver) touch(fileNum int) bool {
+	for i, n := range a.fetchedFileNums {
+		if n == fileNum {
+			a.fetchedFileNums = append(append(a.fetchedFileNums[:i:i], a.fetchedFileNums[i+1:]...), fileNum)
+			return true
+		}
+	}
+	return false
+}
+
+// unpin releases a pin acquired by fetchAndPin and evicts the block files that exceed the size of the cache
+func (a *archiver) unpin(fileNum int) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.pinCounts[fileNum]--
+	if a.pinCounts[fileNum] > 0 {
+		return nil
+	}
+	delete(a.pinCounts, fileNum)
+	if !a.isCached(fileNum) {
+		// the block file was removed from the cache while being read
+		if err := a.deleteCachedFile(fileNum); err != nil {
+			return err
+		}
+	}
+	return a.shrinkCache()
+}
+
+// shrinkCache evicts the least recently used block files that are not pinned, as long as the cache exceeds its size.
+// The caller is expected to hold the write lock
+func (a *archiver) shrinkCache() error {
+	for i := 0; i < len(a.fetchedFileNums) && len(a.fetchedFileNums) > a.conf.FetchedBlockfilesCacheSize; {
+		fileNum := a.fetchedFileNums[i]
+		if a.pinCounts[fileNum] > 0 {
+			i++
+			continue
+		}
+		if err := a.removeFromCache(fileNum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evict removes the block file from the cache, if the block file is cached
+func (a *archiver) evict(fileNum int) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.removeFromCache(fileNum)
+}
+
+func (a *archiver) isCached(fileNum int) bool {
+	for _, n := range a.fetchedFileNums {
+		if n == fileNum {
+			return true
+		}
+	}
+	return false
+}
+
+// removeFromCache removes the block file from the cache. The file is deleted right away unless the block file is
+// pinned, in which case the file is deleted when the block file is unpinned. The caller is expected to hold the write lock
+func (a *archiver) removeFromCache(fileNum int) error {
+	for i, n := range a.fetchedFileNums {
+		if n != fileNum {
+			continue
+		}
+		a.fetchedFileNums = append(a.fetchedFileNums[:i:i], a.fetchedFileNums[i+1:]...)
+		if a.pinCounts[fileNum] > 0 {
+			return nil
+		}
+		return a.deleteCachedFile(fileNum)
+	}
+	return nil
+}
+
+func (a *archiver) deleteCachedFile(fileNum int) error {
+	filePath := deriveBlockfilePath(a.cacheDir, fileNum)
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error while removing the cached block file [%s]", filePath)
+	}
+	return nil
+}
+
+// writeFileAtomically writes the content to a temporary file in the same directory and renames the temporary
+// file once the content is synced, so that a partially written file is never visible under the given path
+func writeFileAtomically(filePath string, content io.Reader) error {
+	tmpFilePath, err := writeTempFile(filePath, content)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFilePath)
+	return errors.Wrapf(os.Rename(tmpFilePath, filePath), "error renaming file [%s] to [%s]", tmpFilePath, filePath)
+}
+
+// writeTempFile writes and syncs the content to a temporary file in the directory of the given path and
+// returns the path of the temporary file, which is removed if an error occurs
+func writeTempFile(filePath string, content io.Reader) (string, error) {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+".tmp")
+	if err != nil {
+		return "", errors.Wrapf(err, "error creating temporary file for [%s]", filePath)
+	}
+	if _, err := io.Copy(tmpFile, content); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return "", errors.Wrapf(err, "error writing file [%s]", tmpFile.Name())
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return "", errors.Wrapf(err, "error syncing file [%s]", tmpFile.Name())
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name())
+		return "", errors.Wrapf(err, "error closing file [%s]", tmpFile.Name())
+	}
+	return tmpFile.Name(), nil
+}
+
+// loadArchivingInfo returns the number of the first block file that has not been archived
+func loadArchivingInfo(db *leveldbhelper.DBHandle) (int, error) {
+	b, err := db.Get(archivingInfoKey)
+	if err != nil || b == nil {
+		return 0, err
+	}
+	firstLocalFileNum, n := proto.DecodeVarint(b)
+	if n == 0 {
+		return 0, errors.New("error while decoding the archiving info")
+	}
+	return int(firstLocalFileNum), nil
+}
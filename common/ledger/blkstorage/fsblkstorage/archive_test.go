@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveBlockfiles(t *testing.T) {
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	require.NoError(t, err)
+	// each block file holds two blocks
+	conf := NewConfWithArchive(testPath(), len(blockBytes)*5/2, &ArchiveConf{Archive: NewDirArchive(archiveDir)})
+	assert.Equal(t, 1, conf.archiveConf.LocalBlockfiles)
+	assert.Equal(t, 1, conf.archiveConf.FetchedBlockfilesCacheSize)
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+
+	store, err := env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	for _, b := range blocks {
+		require.NoError(t, store.AddBlock(b))
+	}
+	mgr := store.(*fsBlockStore).fileMgr
+	require.NoError(t, mgr.archiveBlockfiles())
+	currentFileNum := mgr.cpInfo.latestFileChunkSuffixNum
+	assert.Equal(t, currentFileNum, mgr.archiver.getFirstLocalFileNum())
+	rootDir := conf.getLedgerBlockDir("ledger1")
+	for fileNum := 0; fileNum < currentFileNum; fileNum++ {
+		_, err := os.Stat(deriveBlockfilePath(rootDir, fileNum))
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(filepath.Join(archiveDir, "ledger1", deriveBlockfileName(fileNum)))
+		assert.NoError(t, err)
+	}
+
+	// the archived blocks and transactions are fetched back transparently
+	b, err := store.RetrieveBlockByNumber(1)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[1], b))
+	txID, err := extractTxID(blocks[2].Data.Data[0])
+	require.NoError(t, err)
+	txEnv, err := store.RetrieveTxByID(txID)
+	require.NoError(t, err)
+	expectedTxEnv, err := putils.GetEnvelopeFromBlock(blocks[2].Data.Data[0])
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(expectedTxEnv, txEnv))
+	itr, err := store.RetrieveBlocks(0)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		result, err := itr.Next()
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(blocks[i], result.(*common.Block)))
+	}
+	itr.Close()
+	// the fetched block files are evicted from the cache beyond the cache size
+	assert.Len(t, mgr.archiver.fetchedFileNums, 1)
+	cachedFiles, err := ioutil.ReadDir(filepath.Join(rootDir, fetchedBlockfilesDir))
+	require.NoError(t, err)
+	assert.Len(t, cachedFiles, 1)
+
+	// pruning does not need the archived block files to be present locally
+	require.NoError(t, store.Prune(4))
+	_, err = store.RetrieveBlockByNumber(4)
+	assert.NoError(t, err)
+	store.Shutdown()
+
+	// simulate a crash after the archiving info was saved but before the archived file was removed
+	lastArchivedFileNum := currentFileNum - 1
+	require.NoError(t, ioutil.WriteFile(deriveBlockfilePath(rootDir, lastArchivedFileNum), []byte("archived-file"), 0644))
+	env.provider.Close()
+	env = newTestEnv(t, conf)
+	store, err = env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	_, err = os.Stat(deriveBlockfilePath(rootDir, lastArchivedFileNum))
+	assert.True(t, os.IsNotExist(err))
+	b, err = store.RetrieveBlockByNumber(5)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[5], b))
+}
+
+func TestDirArchive(t *testing.T) {
+	archiveDir, err := ioutil.TempDir("", "fsblkstorage-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	archive := NewDirArchive(archiveDir)
+	_, err = archive.Get("ledger1", "blockfile_000000")
+	assert.Contains(t, err.Error(), "error opening archived block file")
+	require.NoError(t, archive.Put("ledger1", "blockfile_000000", strings.NewReader("content")))
+	// a block file can be stored again
+	require.NoError(t, archive.Put("ledger1", "blockfile_000000", strings.NewReader("new-content")))
+	content, err := archive.Get("ledger1", "blockfile_000000")
+	require.NoError(t, err)
+	defer content.Close()
+	b, err := ioutil.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new-content"), b)
+	files, err := ioutil.ReadDir(filepath.Join(archiveDir, "ledger1"))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestArchiverCachePinning(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsblkstorage-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	archive := NewDirArchive(filepath.Join(dir, "archive"))
+	for fileNum := 0; fileNum < 3; fileNum++ {
+		require.NoError(t, archive.Put("ledger1", deriveBlockfileName(fileNum), strings.NewReader("content")))
+	}
+	cacheDir := filepath.Join(dir, fetchedBlockfilesDir)
+	require.NoError(t, os.MkdirAll(cacheDir, 0755))
+	a := &archiver{
+		ledgerID:          "ledger1",
+		rootDir:           dir,
+		cacheDir:          cacheDir,
+		conf:              &ArchiveConf{Archive: archive, FetchedBlockfilesCacheSize: 1},
+		firstLocalFileNum: 3,
+		pinCounts:         map[int]int{},
+	}
+	cached := func(fileNum int) bool {
+		_, err := os.Stat(deriveBlockfilePath(cacheDir, fileNum))
+		return err == nil
+	}
+
+	// the pinned block files are retained beyond the cache size
+	require.NoError(t, a.fetchAndPin(0))
+	require.NoError(t, a.fetchAndPin(1))
+	assert.True(t, cached(0))
+	assert.True(t, cached(1))
+
+	// a pinned block file that is removed from the cache is deleted once unpinned
+	require.NoError(t, a.evict(0))
+	assert.True(t, cached(0))
+	require.NoError(t, a.unpin(0))
+	assert.False(t, cached(0))
+
+	// the least recently used block files that are not pinned are evicted
+	require.NoError(t, a.unpin(1))
+	assert.True(t, cached(1))
+	require.NoError(t, a.withBlockfileDir(2, func(dir string) error {
+		assert.Equal(t, cacheDir, dir)
+		assert.True(t, cached(2))
+		return nil
+	}))
+	assert.False(t, cached(1))
+	assert.True(t, cached(2))
+	assert.Equal(t, []int{2}, a.fetchedFileNums)
+	assert.Empty(t, a.pinCounts)
+}
+
+type blockingArchive struct {
+	Archive
+	getStarted chan struct{}
+	release    chan struct{}
+}
+
+func (a *blockingArchive) Get(ledgerID, fileName string) (io.ReadCloser, error) {
+	close(a.getStarted)
+	<-a.release
+	return a.Archive.Get(ledgerID, fileName)
+}
+
+func TestArchiverFetchDoesNotBlockReads(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsblkstorage-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	archive := &blockingArchive{
+		Archive:    NewDirArchive(filepath.Join(dir, "archive")),
+		getStarted: make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+	require.NoError(t, archive.Put("ledger1", deriveBlockfileName(0), strings.NewReader("content")))
+	cacheDir := filepath.Join(dir, fetchedBlockfilesDir)
+	require.NoError(t, os.MkdirAll(cacheDir, 0755))
+	a := &archiver{
+		ledgerID:          "ledger1",
+		rootDir:           dir,
+		cacheDir:          cacheDir,
+		conf:              &ArchiveConf{Archive: archive, FetchedBlockfilesCacheSize: 1},
+		firstLocalFileNum: 1,
+		pinCounts:         map[int]int{},
+	}
+
+	fetchErr := make(chan error, 1)
+	go func() {
+		fetchErr <- a.withBlockfileDir(0, func(dir string) error {
+			_, err := os.Stat(deriveBlockfilePath(dir, 0))
+			return err
+		})
+	}()
+	<-archive.getStarted
+	// the local block files can be read while an archived block file is being fetched
+	require.NoError(t, a.withBlockfileDir(1, func(dir string) error {
+		assert.Equal(t, a.rootDir, dir)
+		return nil
+	}))
+	close(archive.release)
+	assert.NoError(t, <-fetchErr)
+}
@@ -34,12 +34,15 @@ type blockfileStream struct {
 // it starts from a given file offset and continues with the next
 // file segment until the end of the last segment (`endFileNum`)
 type blockStream struct {
-	rootDir           string
+	openFileStream    blockfileStreamOpener
 	currentFileNum    int
 	endFileNum        int
 	currentFileStream *blockfileStream
 }
 
+// blockfileStreamOpener opens a blockfileStream on the given block file, starting from the given offset
+type blockfileStreamOpener func(fileNum int, startOffset int64) (*blockfileStream, error)
+
 // blockPlacementInfo captures the information related
 // to block's placement in the file.
 type blockPlacementInfo struct {
@@ -145,12 +148,12 @@ func (s *blockfileStream) close() error {
 ///////////////////////////////////
 // blockStream functions
 ////////////////////////////////////
-func newBlockStream(rootDir string, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
-	startFileStream, err := newBlockfileStream(rootDir, startFileNum, startOffset)
+func newBlockStream(openFileStream blockfileStreamOpener, startFileNum int, startOffset int64, endFileNum int) (*blockStream, error) {
+	startFileStream, err := openFileStream(startFileNum, startOffset)
 	if err != nil {
 		return nil, err
 	}
-	return &blockStream{rootDir, startFileNum, endFileNum, startFileStream}, nil
+	return &blockStream{openFileStream, startFileNum, endFileNum, startFileStream}, nil
 }
 
 func (s *blockStream) moveToNextBlockfileStream() error {
@@ -159,7 +162,7 @@ func (s *blockStream) moveToNextBlockfileStream() error {
 		return err
 	}
 	s.currentFileNum++
-	if s.currentFileStream, err = newBlockfileStream(s.rootDir, s.currentFileNum, 0); err != nil {
+	if s.currentFileStream, err = s.openFileStream(s.currentFileNum, 0); err != nil {
 		return err
 	}
 	return nil
@@ -122,7 +122,7 @@ func testBlockStream(t *testing.T, numFiles int) {
 		w.addBlocks(blocks)
 		blockfileMgr.moveToNextFile()
 	}
-	s, err := newBlockStream(blockfileMgr.rootDir, 0, 0, numFiles-1)
+	s, err := newBlockStream(blockfileMgr.openBlockfileStream, 0, 0, numFiles-1)
 	defer s.close()
 	assert.NoError(t, err, "Error in constructing new block stream")
 	blockCount := 0
@@ -44,6 +44,15 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	// bootstrappingSnapshotInfo is nil unless the block store was bootstrapped from a snapshot
+	bootstrappingSnapshotInfo *blkstorage.SnapshotInfo
+	// pruningInfo is nil unless the block files have been pruned
+	pruningInfo *pruningInfo
+	// archiver is nil unless the block files are configured to be archived
+	archiver *archiver
+	// pruneLock serializes the pruning and the archiving of the block files
+	pruneLock  sync.Mutex
+	bcInfoLock sync.Mutex
 }
 
 /*
@@ -98,6 +107,40 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	// Instantiate the manager, i.e. blockFileMgr structure
 	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
 
+	// If the block store was bootstrapped from a snapshot, the first block in the block files
+	// is the block next to the last block in the snapshot
+	bootstrappingSnapshotInfo, err := loadBootstrappingSnapshotInfo(indexStore)
+	if err != nil {
+		panic(fmt.Sprintf("Could not get bootstrapping snapshot info from db: %s", err))
+	}
+	mgr.bootstrappingSnapshotInfo = bootstrappingSnapshotInfo
+
+	// If the block files have been pruned, the first block in the block files is the first block retained by the pruning
+	pruningInfo, err := loadPruningInfo(indexStore)
+	if err != nil {
+		panic(fmt.Sprintf("Could not get pruning info from db: %s", err))
+	}
+	mgr.pruningInfo = pruningInfo
+	if pruningInfo != nil {
+		// a crash may have happened after the pruning info was saved but before the pruned files were removed
+		if err := removeLocalBlockfilesBelow(rootDir, pruningInfo.firstFileSuffixNum); err != nil {
+			panic(fmt.Sprintf("Could not remove pruned block files: %s", err))
+		}
+	}
+	firstBlockNumInFiles := computeFirstBlockNumInFiles(bootstrappingSnapshotInfo, pruningInfo)
+
+	// a crash may have happened after a rollback saved the rolled back checkpoint info but before the block files were truncated
+	if err := completeRollback(rootDir, indexStore); err != nil {
+		panic(fmt.Sprintf("Could not complete the rollback of the block files: %s", err))
+	}
+
+	// If the block files are archived, the block files that have been moved to the archive are fetched back on demand
+	if conf.archiveConf != nil {
+		if mgr.archiver, err = newArchiver(id, rootDir, conf.archiveConf, indexStore); err != nil {
+			panic(fmt.Sprintf("Could not initialize the archiving of the block files: %s", err))
+		}
+	}
+
 	// cp = checkpointInfo, retrieve from the database the file suffix or number of where blocks were stored.
 	// It also retrieves the current size of that file and the last block number that was written to that file.
 	// At init checkpointInfo:latestFileChunkSuffixNum=[0], latestFileChunksize=[0], lastBlockNumber=[0]
@@ -113,7 +156,7 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		logger.Debugf("Info constructed by scanning the blocks dir = %s", spew.Sdump(cpInfo))
 	} else {
 		logger.Debug(`Synching block information from block storage (if needed)`)
-		syncCPInfoFromFS(rootDir, cpInfo)
+		syncCPInfoFromFS(rootDir, cpInfo, firstBlockNumInFiles)
 	}
 	err = mgr.saveCurrentInfo(cpInfo, true)
 	if err != nil {
@@ -148,7 +191,16 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 	bcInfo := &common.BlockchainInfo{
 		Height:            0,
 		CurrentBlockHash:  nil,
-		PreviousBlockHash: nil}
+		PreviousBlockHash: nil,
+		FirstBlockNum:     firstBlockNumInFiles}
+
+	if cpInfo.isChainEmpty && bootstrappingSnapshotInfo != nil {
+		bcInfo = &common.BlockchainInfo{
+			Height:            bootstrappingSnapshotInfo.LastBlockNum + 1,
+			CurrentBlockHash:  bootstrappingSnapshotInfo.LastBlockHash,
+			PreviousBlockHash: bootstrappingSnapshotInfo.PreviousBlockHash,
+			FirstBlockNum:     firstBlockNumInFiles}
+	}
 
 	if !cpInfo.isChainEmpty {
 		//If start up is a restart of an existing storage, sync the index from block storage and update BlockchainInfo for external API's
@@ -162,9 +214,14 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		bcInfo = &common.BlockchainInfo{
 			Height:            cpInfo.lastBlockNumber + 1,
 			CurrentBlockHash:  lastBlockHash,
-			PreviousBlockHash: previousBlockHash}
+			PreviousBlockHash: previousBlockHash,
+			FirstBlockNum:     firstBlockNumInFiles}
 	}
 	mgr.bcInfo.Store(bcInfo)
+	if mgr.archiver != nil {
+		go mgr.runArchiver()
+		mgr.archiver.trigger()
+	}
 	return mgr
 }
 
@@ -172,7 +229,7 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 // the file of where the last block was written.  Also retrieves contains the
 // last block number that was written.  At init
 //checkpointInfo:latestFileChunkSuffixNum=[0], latestFileChunksize=[0], lastBlockNumber=[0]
-func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
+func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo, firstBlockNumInFiles uint64) {
 	logger.Debugf("Starting checkpoint=%s", cpInfo)
 	//Checks if the file suffix of where the last block was written exists
 	filePath := deriveBlockfilePath(rootDir, cpInfo.latestFileChunkSuffixNum)
@@ -200,7 +257,7 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 	}
 	//Updates the checkpoint info for the actual last block number stored and it's end location
 	if cpInfo.isChainEmpty {
-		cpInfo.lastBlockNumber = uint64(numBlocks - 1)
+		cpInfo.lastBlockNumber = firstBlockNumInFiles + uint64(numBlocks-1)
 	} else {
 		cpInfo.lastBlockNumber += uint64(numBlocks)
 	}
@@ -208,11 +265,37 @@ func syncCPInfoFromFS(rootDir string, cpInfo *checkpointInfo) {
 	logger.Debugf("Checkpoint after updates by scanning the last file segment:%s", cpInfo)
 }
 
+// computeFirstBlockNumInFiles returns the number of the block that is expected to be the first block
+// in the block files. This is zero unless the block store was bootstrapped from a snapshot or the
+// block files have been pruned
+func computeFirstBlockNumInFiles(snapshotInfo *blkstorage.SnapshotInfo, pruningInfo *pruningInfo) uint64 {
+	switch {
+	case pruningInfo != nil:
+		return pruningInfo.firstBlockNum
+	case snapshotInfo != nil:
+		return snapshotInfo.LastBlockNum + 1
+	default:
+		return 0
+	}
+}
+
+// firstBlockNumInFiles returns the number of the first block present in the block files
+func (mgr *blockfileMgr) firstBlockNumInFiles() uint64 {
+	return mgr.getBlockchainInfo().FirstBlockNum
+}
+
 func deriveBlockfilePath(rootDir string, suffixNum int) string {
-	return rootDir + "/" + blockfilePrefix + fmt.Sprintf("%06d", suffixNum)
+	return rootDir + "/" + deriveBlockfileName(suffixNum)
+}
+
+func deriveBlockfileName(suffixNum int) string {
+	return blockfilePrefix + fmt.Sprintf("%06d", suffixNum)
 }
 
 func (mgr *blockfileMgr) close() {
+	if mgr.archiver != nil {
+		mgr.archiver.stop()
+	}
 	mgr.currentFileWriter.close()
 }
 
@@ -235,6 +318,9 @@ func (mgr *blockfileMgr) moveToNextFile() {
 	}
 	mgr.currentFileWriter = nextFileWriter
 	mgr.updateCheckpoint(cpInfo)
+	if mgr.archiver != nil {
+		mgr.archiver.trigger()
+	}
 }
 
 func (mgr *blockfileMgr) addBlock(block *common.Block) error {
@@ -344,6 +430,11 @@ func (mgr *blockfileMgr) syncIndex() error {
 	//get the last file that blocks were added to using the checkpoint info
 	endFileNum := mgr.cpInfo.latestFileChunkSuffixNum
 	startingBlockNum := uint64(0)
+	//the files below the first file retained by the pruning are not present
+	if mgr.pruningInfo != nil {
+		startFileNum = mgr.pruningInfo.firstFileSuffixNum
+		startingBlockNum = mgr.pruningInfo.firstBlockNum
+	}
 
 	//if the index stored in the db has value, update the index information with those values
 	if !indexEmpty {
@@ -368,7 +459,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 
 	//open a blockstream to the file location that was stored in the index
 	var stream *blockStream
-	if stream, err = newBlockStream(mgr.rootDir, startFileNum, int64(startOffset), endFileNum); err != nil {
+	if stream, err = newBlockStream(mgr.openBlockfileStream, startFileNum, int64(startOffset), endFileNum); err != nil {
 		return err
 	}
 	var blockBytes []byte
@@ -440,11 +531,14 @@ func (mgr *blockfileMgr) updateCheckpoint(cpInfo *checkpointInfo) {
 }
 
 func (mgr *blockfileMgr) updateBlockchainInfo(latestBlockHash []byte, latestBlock *common.Block) {
+	mgr.bcInfoLock.Lock()
+	defer mgr.bcInfoLock.Unlock()
 	currentBCInfo := mgr.getBlockchainInfo()
 	newBCInfo := &common.BlockchainInfo{
 		Height:            currentBCInfo.Height + 1,
 		CurrentBlockHash:  latestBlockHash,
-		PreviousBlockHash: latestBlock.Header.PreviousHash}
+		PreviousBlockHash: latestBlock.Header.PreviousHash,
+		FirstBlockNum:     currentBCInfo.FirstBlockNum}
 
 	mgr.bcInfo.Store(newBCInfo)
 }
@@ -466,6 +560,10 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
 
+	if blockNum < mgr.firstBlockNumInFiles() {
+		return mgr.retrieveBlockBelowFiles(blockNum)
+	}
+
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
 		return nil, err
@@ -477,7 +575,12 @@ func (mgr *blockfileMgr) retrieveBlockByTxID(txID string) (*common.Block, error)
 	logger.Debugf("retrieveBlockByTxID() - txID = [%s]", txID)
 
 	loc, err := mgr.index.getBlockLocByTxID(txID)
-
+	if err == blkstorage.ErrNotFoundInIndex {
+		// the entry is removed from the index when the block is pruned
+		if _, txLocErr := mgr.index.getTxLoc(txID); txLocErr == blkstorage.ErrBlockPruned {
+			return nil, txLocErr
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -507,6 +610,10 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 }
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
+	if startNum < mgr.firstBlockNumInFiles() {
+		return nil, errors.WithMessage(mgr.errForBlockBelowFiles(startNum), fmt.Sprintf(
+			"cannot serve blocks from block [%d], the first block available is [%d]", startNum, mgr.firstBlockNumInFiles()))
+	}
 	return newBlockItr(mgr, startNum), nil
 }
 
@@ -552,7 +659,7 @@ func (mgr *blockfileMgr) fetchTransactionEnvelope(lp *fileLocPointer) (*common.E
 }
 
 func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
-	stream, err := newBlockfileStream(mgr.rootDir, lp.fileSuffixNum, int64(lp.offset))
+	stream, err := mgr.openBlockfileStream(lp.fileSuffixNum, int64(lp.offset))
 	if err != nil {
 		return nil, err
 	}
@@ -565,8 +672,12 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
-	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
-	reader, err := newBlockfileReader(filePath)
+	var reader *blockfileReader
+	err := mgr.withBlockfileDir(lp.fileSuffixNum, func(dir string) error {
+		var err error
+		reader, err = newBlockfileReader(deriveBlockfilePath(dir, lp.fileSuffixNum))
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -578,11 +689,34 @@ func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
 	return b, nil
 }
 
+// openBlockfileStream opens a stream on the given block file, starting from the given offset
+func (mgr *blockfileMgr) openBlockfileStream(fileNum int, startOffset int64) (*blockfileStream, error) {
+	var stream *blockfileStream
+	err := mgr.withBlockfileDir(fileNum, func(dir string) error {
+		var err error
+		stream, err = newBlockfileStream(dir, fileNum, startOffset)
+		return err
+	})
+	return stream, err
+}
+
+// withBlockfileDir invokes the function f with the directory that contains the given block file
+func (mgr *blockfileMgr) withBlockfileDir(fileNum int, f func(dir string) error) error {
+	if mgr.archiver == nil {
+		return f(mgr.rootDir)
+	}
+	return mgr.archiver.withBlockfileDir(fileNum, f)
+}
+
 //Get the current checkpoint information that is stored in the database
 func (mgr *blockfileMgr) loadCurrentInfo() (*checkpointInfo, error) {
+	return loadCheckpointInfo(mgr.db)
+}
+
+func loadCheckpointInfo(db *leveldbhelper.DBHandle) (*checkpointInfo, error) {
 	var b []byte
 	var err error
-	if b, err = mgr.db.Get(blkMgrInfoKey); b == nil || err != nil {
+	if b, err = db.Get(blkMgrInfoKey); b == nil || err != nil {
 		return nil, err
 	}
 	i := &checkpointInfo{}
@@ -42,6 +42,7 @@ type index interface {
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	isAttributeIndexed(attribute blkstorage.IndexableAttr) bool
 }
 
 type blockIdxInfo struct {
@@ -185,7 +186,7 @@ func (index *blockIndex) markDuplicateTxids(blockIdxInfo *blockIdxInfo) error {
 		}
 
 		loc, err := index.getTxLoc(txid)
-		if loc != nil { // txid is duplicate of a previous tx in the index
+		if loc != nil || err == blkstorage.ErrTxFromSnapshot || err == blkstorage.ErrBlockPruned { // txid is duplicate of a previous tx in the index
 			txIdxInfo.isDuplicate = true
 			continue
 		}
@@ -240,6 +241,12 @@ func (index *blockIndex) getTxLoc(txID string) (*fileLocPointer, error) {
 	if b == nil {
 		return nil, blkstorage.ErrNotFoundInIndex
 	}
+	if len(b) == 0 {
+		return nil, blkstorage.ErrTxFromSnapshot
+	}
+	if bytes.Equal(b, txIDPrunedMarker) {
+		return nil, blkstorage.ErrBlockPruned
+	}
 	txFLP := &fileLocPointer{}
 	txFLP.unmarshal(b)
 	return txFLP, nil
@@ -297,6 +304,11 @@ func (index *blockIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidati
 	return result, nil
 }
 
+func (index *blockIndex) isAttributeIndexed(attribute blkstorage.IndexableAttr) bool {
+	_, ok := index.indexItemsMap[attribute]
+	return ok
+}
+
 func constructBlockNumKey(blockNum uint64) []byte {
 	blkNumBytes := util.EncodeOrderPreservingVarUint64(blockNum)
 	return append([]byte{blockNumIdxKeyPrefix}, blkNumBytes...)
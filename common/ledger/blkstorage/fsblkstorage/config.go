@@ -30,6 +30,19 @@ const (
 type Conf struct {
 	blockStorageDir  string
 	maxBlockfileSize int
+	archiveConf      *ArchiveConf
+}
+
+// ArchiveConf encapsulates the configurations for archiving the block files
+type ArchiveConf struct {
+	// Archive is the cold storage to which the block files are moved
+	Archive Archive
+	// LocalBlockfiles is the number of the most recent block files (including the block file
+	// that is being appended to) that are not archived. The minimum value is 1
+	LocalBlockfiles int
+	// FetchedBlockfilesCacheSize is the maximum number of the block files fetched back from the
+	// archive that are cached locally. The minimum value is 1
+	FetchedBlockfilesCacheSize int
 }
 
 // NewConf constructs new `Conf`.
@@ -38,7 +51,21 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir, maxBlockfileSize, nil}
+}
+
+// NewConfWithArchive constructs new `Conf` for the block stores that move their old block files to an archive
+func NewConfWithArchive(blockStorageDir string, maxBlockfileSize int, archiveConf *ArchiveConf) *Conf {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	archiveConfCopy := *archiveConf
+	if archiveConfCopy.LocalBlockfiles < 1 {
+		archiveConfCopy.LocalBlockfiles = 1
+	}
+	if archiveConfCopy.FetchedBlockfilesCacheSize < 1 {
+		archiveConfCopy.FetchedBlockfilesCacheSize = 1
+	}
+	conf.archiveConf = &archiveConfCopy
+	return conf
 }
 
 func (conf *Conf) getIndexDir() string {
@@ -80,6 +80,17 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// ExportTxIds exports all the txids present in the block store into the snapshot file in the given dir.
+// The caller is expected to ensure that no block is committed while the export is in progress
+func (store *fsBlockStore) ExportTxIds(snapshotDir string) (map[string][]byte, error) {
+	return store.fileMgr.exportTxIds(snapshotDir)
+}
+
+// Prune removes the block files that contain only the blocks below the retainFromBlockNum
+func (store *fsBlockStore) Prune(retainFromBlockNum uint64) error {
+	return store.fileMgr.prune(retainFromBlockNum)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
@@ -17,9 +17,12 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"os"
+
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
 )
 
 // FsBlockstoreProvider provides handle to block storage - this is not thread-safe
@@ -48,6 +51,19 @@ func (p *FsBlockstoreProvider) OpenBlockStore(ledgerid string) (blkstorage.Block
 	return newFsBlockStore(ledgerid, p.conf, p.indexConfig, indexStoreHandle), nil
 }
 
+// ImportFromSnapshot initializes the block store for the given ledgerid from the txids exported in the snapshotDir.
+// The block store is expected to be opened via function `OpenBlockStore` after a successful import
+func (p *FsBlockstoreProvider) ImportFromSnapshot(ledgerid string, snapshotDir string, snapshotInfo *blkstorage.SnapshotInfo) error {
+	exists, err := p.Exists(ledgerid)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Errorf("block store for ledger [%s] already exists", ledgerid)
+	}
+	return importTxIDsFromSnapshot(snapshotDir, snapshotInfo, p.indexConfig, p.leveldbProvider.GetDBHandle(ledgerid))
+}
+
 // Exists tells whether the BlockStore with given id exists
 func (p *FsBlockstoreProvider) Exists(ledgerid string) (bool, error) {
 	exists, _, err := util.FileExists(p.conf.getLedgerBlockDir(ledgerid))
@@ -59,6 +75,31 @@ func (p *FsBlockstoreProvider) List() ([]string, error) {
 	return util.ListSubdirs(p.conf.getChainsDir())
 }
 
+// Remove implements the function in the interface `blkstorage.BlockStoreRemover`. The block index is removed before
+// the block files, so that the block store continues to be reported as existing until the removal completes. The block
+// files that have been moved to an archive are not removed from the archive
+func (p *FsBlockstoreProvider) Remove(ledgerid string) error {
+	if err := p.leveldbProvider.GetDBHandle(ledgerid).DeleteAll(); err != nil {
+		return errors.WithMessage(err, "error while removing the block index")
+	}
+	rootDir := p.conf.getLedgerBlockDir(ledgerid)
+	if err := os.RemoveAll(rootDir); err != nil {
+		return errors.Wrapf(err, "error while removing the block files dir [%s]", rootDir)
+	}
+	return nil
+}
+
+// DiskUsage implements the function in the interface `leveldbhelper.StoreMaintainer`. Only the block index is
+// accounted for; the block files are not kept in leveldb
+func (p *FsBlockstoreProvider) DiskUsage(ledgerid string) (uint64, error) {
+	return p.leveldbProvider.GetDBHandle(ledgerid).DiskUsage()
+}
+
+// Compact implements the function in the interface `leveldbhelper.StoreMaintainer` by compacting the block index
+func (p *FsBlockstoreProvider) Compact(ledgerid string) error {
+	return p.leveldbProvider.GetDBHandle(ledgerid).Compact()
+}
+
 // Close closes the FsBlockstoreProvider
 func (p *FsBlockstoreProvider) Close() {
 	p.leveldbProvider.Close()
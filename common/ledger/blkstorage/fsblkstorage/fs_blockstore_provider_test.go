@@ -147,6 +147,47 @@ func TestBlockStoreProvider(t *testing.T) {
 
 }
 
+func TestRemoveBlockStore(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	blocks := testutil.ConstructTestBlocks(t, 3)
+	for _, ledgerid := range []string{"ledger1", "ledger2"} {
+		store, err := provider.OpenBlockStore(ledgerid)
+		assert.NoError(t, err)
+		for _, b := range blocks {
+			assert.NoError(t, store.AddBlock(b))
+		}
+		store.Shutdown()
+	}
+
+	assert.NoError(t, provider.Remove("ledger1"))
+	exists, err := provider.Exists("ledger1")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	storeNames, err := provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ledger2"}, storeNames)
+	// removing a block store that does not exist is a noop
+	assert.NoError(t, provider.Remove("ledger1"))
+
+	// a block store with the same id starts afresh
+	store, err := provider.OpenBlockStore("ledger1")
+	assert.NoError(t, err)
+	bcInfo, err := store.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), bcInfo.Height)
+	_, err = store.RetrieveBlockByHash(blocks[1].Header.Hash())
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	store.Shutdown()
+
+	store, err = provider.OpenBlockStore("ledger2")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	checkBlocks(t, blocks, store)
+}
+
 func constructLedgerid(id int) string {
 	return fmt.Sprintf("ledger_%d", id)
 }
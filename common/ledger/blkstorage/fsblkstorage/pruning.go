@@ -0,0 +1,259 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var (
+	pruningInfoKey = []byte("pruningInfo")
+	// txIDPrunedMarker is stored against a txid in the txid index if the block that contains the transaction has been pruned.
+	// A valid file location pointer is never a single byte, so the marker cannot be confused with a location in the block files
+	txIDPrunedMarker = []byte{0}
+)
+
+// pruningInfo records the first block file and the first block that are retained after pruning
+type pruningInfo struct {
+	firstFileSuffixNum int
+	firstBlockNum      uint64
+}
+
+// prune removes the block files that contain only the blocks below the retainFromBlockNum. The files are pruned one
+// at a time, starting from the oldest file. For each file, the entries of the contained blocks are removed from the
+// index and the pruning info is updated in a single batch before the file is removed from the file system. The txids
+// (along with the validation codes) of the pruned transactions are retained in the index so that the duplicate txid
+// detection continues to work. The file that is currently being appended to is never pruned
+func (mgr *blockfileMgr) prune(retainFromBlockNum uint64) error {
+	mgr.pruneLock.Lock()
+	defer mgr.pruneLock.Unlock()
+
+	bcInfo := mgr.getBlockchainInfo()
+	if retainFromBlockNum >= bcInfo.Height {
+		return errors.Errorf("cannot prune blocks below [%d] as the height of the block store is [%d]", retainFromBlockNum, bcInfo.Height)
+	}
+	if retainFromBlockNum <= bcInfo.FirstBlockNum {
+		logger.Debugf("No blocks to prune below [%d], the first block in the block files is [%d]", retainFromBlockNum, bcInfo.FirstBlockNum)
+		return nil
+	}
+	lastBlock, err := mgr.retrieveBlockByNumber(bcInfo.Height - 1)
+	if err != nil {
+		return err
+	}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return err
+	}
+
+	mgr.cpInfoCond.L.Lock()
+	currentFileNum := mgr.cpInfo.latestFileChunkSuffixNum
+	mgr.cpInfoCond.L.Unlock()
+
+	fileNum := 0
+	if mgr.pruningInfo != nil {
+		fileNum = mgr.pruningInfo.firstFileSuffixNum
+	}
+	for ; fileNum < currentFileNum; fileNum++ {
+		pruned, err := mgr.pruneFile(fileNum, retainFromBlockNum, lastConfigBlockNum)
+		if err != nil {
+			return err
+		}
+		if !pruned {
+			break
+		}
+	}
+	logger.Infof("Pruned the blocks below [%d], the first block in the block files is [%d]", retainFromBlockNum, mgr.firstBlockNumInFiles())
+	return nil
+}
+
+// pruneFile removes the given block file, if the file contains only the blocks below the retainFromBlockNum.
+// If the last config block is contained in the file, the block is retained in the db
+func (mgr *blockfileMgr) pruneFile(fileNum int, retainFromBlockNum, lastConfigBlockNum uint64) (bool, error) {
+	stream, err := mgr.openBlockfileStream(fileNum, 0)
+	if err != nil {
+		return false, err
+	}
+	defer stream.close()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	nextBlockNum := mgr.firstBlockNumInFiles()
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err != nil {
+			return false, err
+		}
+		if blockBytes == nil {
+			break
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return false, err
+		}
+		blockNum := info.blockHeader.Number
+		if blockNum >= retainFromBlockNum {
+			return false, nil
+		}
+		if err := mgr.addPrunedBlockUpdates(fileNum, info, batch); err != nil {
+			return false, err
+		}
+		if blockNum == lastConfigBlockNum {
+			block, err := deserializeBlock(blockBytes)
+			if err != nil {
+				return false, err
+			}
+			retainedBlockBytes, err := proto.Marshal(block)
+			if err != nil {
+				return false, errors.Wrap(err, "error while marshalling the last config block")
+			}
+			batch.Put(constructBootstrappingBlockKey(blockNum), retainedBlockBytes)
+		}
+		nextBlockNum = blockNum + 1
+	}
+
+	newPruningInfo := &pruningInfo{firstFileSuffixNum: fileNum + 1, firstBlockNum: nextBlockNum}
+	pruningInfoBytes, err := newPruningInfo.marshal()
+	if err != nil {
+		return false, err
+	}
+	batch.Put(pruningInfoKey, pruningInfoBytes)
+	if err := mgr.db.WriteBatch(batch, true); err != nil {
+		return false, err
+	}
+	mgr.pruningInfo = newPruningInfo
+	mgr.updateFirstBlockNumInFiles(nextBlockNum)
+
+	filePath := deriveBlockfilePath(mgr.rootDir, fileNum)
+	if mgr.archiver != nil && mgr.archiver.isArchived(fileNum) {
+		// the copy in the archive is retained, the archive is expected to manage the retention of its content
+		if err := mgr.archiver.evict(fileNum); err != nil {
+			return false, err
+		}
+	} else if err := os.Remove(filePath); err != nil {
+		return false, errors.Wrapf(err, "error while removing the pruned block file [%s]", filePath)
+	}
+	logger.Debugf("Pruned block file [%s]", filePath)
+	return true, nil
+}
+
+func (mgr *blockfileMgr) updateFirstBlockNumInFiles(firstBlockNum uint64) {
+	mgr.bcInfoLock.Lock()
+	defer mgr.bcInfoLock.Unlock()
+	currentBCInfo := mgr.getBlockchainInfo()
+	newBCInfo := &common.BlockchainInfo{
+		Height:            currentBCInfo.Height,
+		CurrentBlockHash:  currentBCInfo.CurrentBlockHash,
+		PreviousBlockHash: currentBCInfo.PreviousBlockHash,
+		FirstBlockNum:     firstBlockNum}
+	mgr.bcInfo.Store(newBCInfo)
+}
+
+// retrieveBlockBelowFiles returns a block that is below the first block in the block files. Such a block is
+// available only if the block was carried in the snapshot that the block store was bootstrapped from or if the
+// block was the last config block at the time of pruning
+func (mgr *blockfileMgr) retrieveBlockBelowFiles(blockNum uint64) (*common.Block, error) {
+	block, err := mgr.retrieveBootstrappingBlock(blockNum)
+	if err == blkstorage.ErrNotFoundInIndex {
+		return nil, mgr.errForBlockBelowFiles(blockNum)
+	}
+	return block, err
+}
+
+// errForBlockBelowFiles returns the error for a block that is below the first block in the block files
+func (mgr *blockfileMgr) errForBlockBelowFiles(blockNum uint64) error {
+	if mgr.bootstrappingSnapshotInfo != nil && blockNum <= mgr.bootstrappingSnapshotInfo.LastBlockNum {
+		return blkstorage.ErrNotFoundInIndex
+	}
+	return blkstorage.ErrBlockPruned
+}
+
+// addPrunedBlockUpdates adds to the batch the updates that remove the entries of a pruned block from the index.
+// The txid entries are replaced with a marker, only if the entries point to the pruned block file (i.e., the
+// transactions are not duplicates of the transactions in an earlier block)
+func (mgr *blockfileMgr) addPrunedBlockUpdates(fileNum int, info *serializedBlockInfo, batch *leveldbhelper.UpdateBatch) error {
+	blockNum := info.blockHeader.Number
+	batch.Delete(constructBlockNumKey(blockNum))
+	batch.Delete(constructBlockHashKey(info.blockHeader.Hash()))
+	for txNum, txOffset := range info.txOffsets {
+		batch.Delete(constructBlockNumTranNumKey(blockNum, uint64(txNum)))
+		if !mgr.index.isAttributeIndexed(blkstorage.IndexableAttrTxID) {
+			continue
+		}
+		txLoc, err := mgr.index.getTxLoc(txOffset.txID)
+		if err == blkstorage.ErrNotFoundInIndex || err == blkstorage.ErrTxFromSnapshot || err == blkstorage.ErrBlockPruned {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if txLoc.fileSuffixNum != fileNum {
+			continue
+		}
+		batch.Put(constructTxIDKey(txOffset.txID), txIDPrunedMarker)
+		batch.Delete(constructBlockTxIDKey(txOffset.txID))
+	}
+	return nil
+}
+
+// loadPruningInfo returns the pruning info. A nil value is returned if the block files have never been pruned
+func loadPruningInfo(db *leveldbhelper.DBHandle) (*pruningInfo, error) {
+	b, err := db.Get(pruningInfoKey)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	info := &pruningInfo{}
+	if err := info.unmarshal(b); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// removeLocalBlockfilesBelow removes the block files below the given file number, if any of these is still present.
+// The block files are removed in the descending order of the file numbers, until a block file is found missing
+func removeLocalBlockfilesBelow(rootDir string, fileNum int) error {
+	for fileNum--; fileNum >= 0; fileNum-- {
+		filePath := deriveBlockfilePath(rootDir, fileNum)
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			return nil
+		}
+		logger.Infof("Removing the block file [%s]", filePath)
+		if err := os.Remove(filePath); err != nil {
+			return errors.Wrapf(err, "error while removing the block file [%s]", filePath)
+		}
+	}
+	return nil
+}
+
+func (i *pruningInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.firstFileSuffixNum)); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeVarint(i.firstBlockNum); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *pruningInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	firstFileSuffixNum, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.firstFileSuffixNum = int(firstFileSuffixNum)
+	if i.firstBlockNum, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneBlocks(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	require.NoError(t, err)
+	// each block file holds two blocks
+	env := newTestEnv(t, NewConf(testPath(), len(blockBytes)*5/2))
+	defer env.Cleanup()
+
+	store, err := env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	for _, b := range blocks {
+		require.NoError(t, store.AddBlock(b))
+	}
+	err = store.Prune(10)
+	assert.EqualError(t, err, "cannot prune blocks below [10] as the height of the block store is [10]")
+
+	require.NoError(t, store.Prune(5))
+	bcInfo, err := store.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), bcInfo.Height)
+	firstBlockNum := bcInfo.FirstBlockNum
+	assert.True(t, firstBlockNum > 1 && firstBlockNum <= 5, "unexpected first block num [%d]", firstBlockNum)
+	rootDir := env.provider.conf.getLedgerBlockDir("ledger1")
+	_, err = os.Stat(deriveBlockfilePath(rootDir, 0))
+	assert.True(t, os.IsNotExist(err))
+
+	// the last config block (the genesis block) is retained while the other pruned blocks are not available
+	b, err := store.RetrieveBlockByNumber(0)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[0], b))
+	_, err = store.RetrieveBlockByNumber(1)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	_, err = store.RetrieveBlockByHash(blocks[1].Header.Hash())
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	_, err = store.RetrieveBlocks(1)
+	assert.EqualError(t, err, fmt.Sprintf(
+		"cannot serve blocks from block [1], the first block available is [%d]: block has been pruned", firstBlockNum))
+
+	// the txids of the pruned blocks are known only by their validation codes
+	prunedTxID, err := extractTxID(blocks[1].Data.Data[0])
+	require.NoError(t, err)
+	_, err = store.RetrieveTxByID(prunedTxID)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	_, err = store.RetrieveBlockByTxID(prunedTxID)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	code, err := store.RetrieveTxValidationCodeByTxID(prunedTxID)
+	require.NoError(t, err)
+	assert.Equal(t, peer.TxValidationCode_VALID, code)
+
+	// the retained blocks are available
+	itr, err := store.RetrieveBlocks(firstBlockNum)
+	require.NoError(t, err)
+	for i := firstBlockNum; i < 10; i++ {
+		result, err := itr.Next()
+		require.NoError(t, err)
+		assert.Equal(t, blocks[i], result.(*common.Block))
+	}
+	itr.Close()
+	retainedTxID, err := extractTxID(blocks[9].Data.Data[0])
+	require.NoError(t, err)
+	_, err = store.RetrieveTxByID(retainedTxID)
+	assert.NoError(t, err)
+
+	// pruning below the first block in the files is a noop
+	require.NoError(t, store.Prune(1))
+
+	// a txid from a pruned block is detected as duplicate
+	block10 := testutil.ConstructBlockWithTxid(t, 10, blocks[9].Header.Hash(),
+		[][]byte{[]byte("results")}, []string{prunedTxID}, false)
+	require.NoError(t, store.AddBlock(block10))
+	_, err = store.RetrieveTxByID(prunedTxID)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	store.Shutdown()
+
+	// simulate a crash after the pruning info was saved but before the last pruned file was removed
+	lastPrunedFileNum := store.(*fsBlockStore).fileMgr.pruningInfo.firstFileSuffixNum - 1
+	require.NoError(t, ioutil.WriteFile(deriveBlockfilePath(rootDir, lastPrunedFileNum), []byte("pruned-file"), 0644))
+	env.provider.Close()
+	env = newTestEnv(t, env.provider.conf)
+	store, err = env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	_, err = os.Stat(deriveBlockfilePath(rootDir, lastPrunedFileNum))
+	assert.True(t, os.IsNotExist(err))
+	bcInfo, err = store.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), bcInfo.Height)
+	assert.Equal(t, firstBlockNum, bcInfo.FirstBlockNum)
+	b, err = store.RetrieveBlockByNumber(10)
+	require.NoError(t, err)
+	assert.Equal(t, block10, b)
+}
+
+func TestPruningInfoMarshalling(t *testing.T) {
+	info := &pruningInfo{firstFileSuffixNum: 3, firstBlockNum: 25}
+	b, err := info.marshal()
+	require.NoError(t, err)
+	unmarshalledInfo := &pruningInfo{}
+	require.NoError(t, unmarshalledInfo.unmarshal(b))
+	assert.Equal(t, info, unmarshalledInfo)
+}
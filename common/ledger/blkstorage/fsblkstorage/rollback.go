@@ -0,0 +1,265 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+var rollbackInfoKey = []byte("rollbackInfo")
+
+// rollbackInfo records the block file and the size of the block file up to which the block files are retained by a rollback
+type rollbackInfo struct {
+	fileSuffixNum int
+	fileSize      int
+}
+
+// Rollback implements the function in the interface `blkstorage.BlockStoreRollbacker`. The blocks above the given
+// block number are removed from the block files and the entries of these blocks are removed from the block index.
+// The updates to the block index, the rolled back checkpoint info, and the rollback info are saved in a single batch
+// before the block files are truncated. If a crash happens before the truncation completes, the truncation is completed
+// when the block store is opened next time
+func (p *FsBlockstoreProvider) Rollback(ledgerid string, blockNum uint64) error {
+	exists, err := p.Exists(ledgerid)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("block store for ledger [%s] does not exist", ledgerid)
+	}
+	mgr := newBlockfileMgr(ledgerid, p.conf, p.indexConfig, p.leveldbProvider.GetDBHandle(ledgerid))
+	defer mgr.close()
+	return mgr.rollback(blockNum)
+}
+
+func (mgr *blockfileMgr) rollback(blockNum uint64) error {
+	mgr.pruneLock.Lock()
+	defer mgr.pruneLock.Unlock()
+
+	bcInfo := mgr.getBlockchainInfo()
+	if blockNum+1 >= bcInfo.Height {
+		return errors.Errorf("cannot roll back to block [%d] as the height of the block store is [%d]", blockNum, bcInfo.Height)
+	}
+	if blockNum < bcInfo.FirstBlockNum {
+		return errors.Errorf("cannot roll back to block [%d] as the first block in the block files is [%d]", blockNum, bcInfo.FirstBlockNum)
+	}
+	blockLoc, err := mgr.index.getBlockLocByBlockNum(blockNum)
+	if err != nil {
+		return errors.WithMessage(err, "error while locating the block to roll back to")
+	}
+	if mgr.archiver != nil && mgr.archiver.isArchived(blockLoc.fileSuffixNum) {
+		return errors.Errorf("cannot roll back to block [%d] as the block file [%s] that contains the block has been archived",
+			blockNum, deriveBlockfileName(blockLoc.fileSuffixNum))
+	}
+
+	// the block files are retained up to the end of the block to roll back to
+	retainedFileSize, err := mgr.endOffsetOfBlock(blockLoc)
+	if err != nil {
+		return err
+	}
+	firstRemovedLoc := &fileLocPointer{
+		fileSuffixNum: blockLoc.fileSuffixNum,
+		locPointer:    locPointer{offset: int(retainedFileSize)},
+	}
+
+	mgr.cpInfoCond.L.Lock()
+	currentFileNum := mgr.cpInfo.latestFileChunkSuffixNum
+	mgr.cpInfoCond.L.Unlock()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	if err := mgr.addRolledBackBlocksUpdates(firstRemovedLoc, currentFileNum, batch); err != nil {
+		return err
+	}
+	batch.Put(indexCheckpointKey, encodeBlockNum(blockNum))
+
+	newCPInfo := &checkpointInfo{
+		latestFileChunkSuffixNum: blockLoc.fileSuffixNum,
+		latestFileChunksize:      int(retainedFileSize),
+		isChainEmpty:             false,
+		lastBlockNumber:          blockNum,
+	}
+	cpInfoBytes, err := newCPInfo.marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(blkMgrInfoKey, cpInfoBytes)
+	info := &rollbackInfo{fileSuffixNum: newCPInfo.latestFileChunkSuffixNum, fileSize: newCPInfo.latestFileChunksize}
+	infoBytes, err := info.marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(rollbackInfoKey, infoBytes)
+	if err := mgr.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+
+	mgr.currentFileWriter.close()
+	if err := completeRollback(mgr.rootDir, mgr.db); err != nil {
+		return err
+	}
+	if mgr.currentFileWriter, err = newBlockfileWriter(deriveBlockfilePath(mgr.rootDir, newCPInfo.latestFileChunkSuffixNum)); err != nil {
+		return err
+	}
+	mgr.updateCheckpoint(newCPInfo)
+	blockHeader, err := mgr.retrieveBlockHeaderByNumber(blockNum)
+	if err != nil {
+		return err
+	}
+	mgr.bcInfoLock.Lock()
+	defer mgr.bcInfoLock.Unlock()
+	mgr.bcInfo.Store(&common.BlockchainInfo{
+		Height:            blockNum + 1,
+		CurrentBlockHash:  blockHeader.Hash(),
+		PreviousBlockHash: blockHeader.PreviousHash,
+		FirstBlockNum:     bcInfo.FirstBlockNum})
+	logger.Infof("Rolled back the block store to block [%d], the height of the block store is [%d]", blockNum, blockNum+1)
+	return nil
+}
+
+// endOffsetOfBlock returns the offset in the block file at which the block at the given location ends
+func (mgr *blockfileMgr) endOffsetOfBlock(blockLoc *fileLocPointer) (int64, error) {
+	stream, err := mgr.openBlockfileStream(blockLoc.fileSuffixNum, int64(blockLoc.offset))
+	if err != nil {
+		return 0, err
+	}
+	defer stream.close()
+	blockBytes, err := stream.nextBlockBytes()
+	if err != nil {
+		return 0, err
+	}
+	if blockBytes == nil {
+		return 0, errors.Errorf("no block found in the block file [%s] at offset [%d]",
+			deriveBlockfileName(blockLoc.fileSuffixNum), blockLoc.offset)
+	}
+	return stream.currentOffset, nil
+}
+
+// addRolledBackBlocksUpdates adds to the batch the updates that remove the entries of all the blocks from the index,
+// starting from the given location in the block files up to the end of the given last block file
+func (mgr *blockfileMgr) addRolledBackBlocksUpdates(firstRemovedLoc *fileLocPointer, lastFileNum int, batch *leveldbhelper.UpdateBatch) error {
+	stream, err := newBlockStream(mgr.openBlockfileStream, firstRemovedLoc.fileSuffixNum, int64(firstRemovedLoc.offset), lastFileNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			return nil
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		if err := mgr.addRolledBackBlockUpdates(firstRemovedLoc, info, batch); err != nil {
+			return err
+		}
+	}
+}
+
+// addRolledBackBlockUpdates adds to the batch the updates that remove the entries of a rolled back block from the index.
+// The txid entries are removed only if the entries point to the rolled back part of the block files (i.e., the
+// transactions are not duplicates of the transactions in a retained block)
+func (mgr *blockfileMgr) addRolledBackBlockUpdates(firstRemovedLoc *fileLocPointer, info *serializedBlockInfo, batch *leveldbhelper.UpdateBatch) error {
+	blockNum := info.blockHeader.Number
+	batch.Delete(constructBlockNumKey(blockNum))
+	batch.Delete(constructBlockHashKey(info.blockHeader.Hash()))
+	for txNum, txOffset := range info.txOffsets {
+		batch.Delete(constructBlockNumTranNumKey(blockNum, uint64(txNum)))
+		if !mgr.index.isAttributeIndexed(blkstorage.IndexableAttrTxID) {
+			continue
+		}
+		txLoc, err := mgr.index.getTxLoc(txOffset.txID)
+		if err == blkstorage.ErrNotFoundInIndex || err == blkstorage.ErrTxFromSnapshot || err == blkstorage.ErrBlockPruned {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if isBefore(txLoc, firstRemovedLoc) {
+			continue
+		}
+		batch.Delete(constructTxIDKey(txOffset.txID))
+		batch.Delete(constructBlockTxIDKey(txOffset.txID))
+		batch.Delete(constructTxValidationCodeIDKey(txOffset.txID))
+	}
+	return nil
+}
+
+// completeRollback truncates the block files as recorded in the rollback info, if any, and then removes the rollback
+// info. The block files after the retained block file are removed in the descending order of the file numbers so
+// that an interrupted removal does not leave a gap in the block files
+func completeRollback(rootDir string, db *leveldbhelper.DBHandle) error {
+	info, err := loadRollbackInfo(db)
+	if err != nil || info == nil {
+		return err
+	}
+	lastFileNum, err := retrieveLastFileSuffix(rootDir)
+	if err != nil {
+		return err
+	}
+	for fileNum := lastFileNum; fileNum > info.fileSuffixNum; fileNum-- {
+		filePath := deriveBlockfilePath(rootDir, fileNum)
+		logger.Infof("Removing the rolled back block file [%s]", filePath)
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error while removing the rolled back block file [%s]", filePath)
+		}
+	}
+	filePath := deriveBlockfilePath(rootDir, info.fileSuffixNum)
+	if err := os.Truncate(filePath, int64(info.fileSize)); err != nil {
+		return errors.Wrapf(err, "error while truncating the block file [%s]", filePath)
+	}
+	return db.Delete(rollbackInfoKey, true)
+}
+
+// loadRollbackInfo returns the rollback info. A nil value is returned if no rollback is pending completion
+func loadRollbackInfo(db *leveldbhelper.DBHandle) (*rollbackInfo, error) {
+	b, err := db.Get(rollbackInfoKey)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	info := &rollbackInfo{}
+	if err := info.unmarshal(b); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (i *rollbackInfo) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(uint64(i.fileSuffixNum)); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeVarint(uint64(i.fileSize)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (i *rollbackInfo) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	fileSuffixNum, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	fileSize, err := buffer.DecodeVarint()
+	if err != nil {
+		return err
+	}
+	i.fileSuffixNum = int(fileSuffixNum)
+	i.fileSize = int(fileSize)
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollback(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	require.NoError(t, err)
+	// each block file holds two blocks
+	env := newTestEnv(t, NewConf(testPath(), len(blockBytes)*5/2))
+	defer env.Cleanup()
+	var rollbacker blkstorage.BlockStoreRollbacker = env.provider
+
+	store, err := env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	for _, b := range blocks[:6] {
+		require.NoError(t, store.AddBlock(b))
+	}
+	// the transaction in block 6 is a duplicate of the transaction in block 2
+	retainedTxID, err := extractTxID(blocks[2].Data.Data[0])
+	require.NoError(t, err)
+	block6 := testutil.ConstructBlockWithTxid(t, 6, blocks[5].Header.Hash(),
+		[][]byte{[]byte("results")}, []string{retainedTxID}, false)
+	require.NoError(t, store.AddBlock(block6))
+	block7 := testutil.ConstructBlockWithTxid(t, 7, block6.Header.Hash(),
+		[][]byte{[]byte("results")}, []string{"rolledBackTxID"}, false)
+	require.NoError(t, store.AddBlock(block7))
+	store.Shutdown()
+
+	assert.EqualError(t, rollbacker.Rollback("ledger1", 7), "cannot roll back to block [7] as the height of the block store is [8]")
+	assert.EqualError(t, rollbacker.Rollback("non-existing-ledger", 2), "block store for ledger [non-existing-ledger] does not exist")
+
+	rootDir := env.provider.conf.getLedgerBlockDir("ledger1")
+	lastFileNumBeforeRollback, err := retrieveLastFileSuffix(rootDir)
+	require.NoError(t, err)
+	require.NoError(t, rollbacker.Rollback("ledger1", 2))
+	lastFileNum, err := retrieveLastFileSuffix(rootDir)
+	require.NoError(t, err)
+	assert.True(t, lastFileNum < lastFileNumBeforeRollback)
+	cpInfo, err := loadCheckpointInfo(env.provider.leveldbProvider.GetDBHandle("ledger1"))
+	require.NoError(t, err)
+	assert.Equal(t, lastFileNum, cpInfo.latestFileChunkSuffixNum)
+	assert.Equal(t, uint64(2), cpInfo.lastBlockNumber)
+
+	store, err = env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	bcInfo, err := store.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), bcInfo.Height)
+	assert.Equal(t, blocks[2].Header.Hash(), bcInfo.CurrentBlockHash)
+	_, err = store.RetrieveBlockByNumber(3)
+	assert.Error(t, err)
+	_, err = store.RetrieveBlockByHash(block7.Header.Hash())
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	_, err = store.RetrieveTxByID("rolledBackTxID")
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	// the entry of the duplicate txid points to the retained block
+	b, err := store.RetrieveBlockByTxID(retainedTxID)
+	require.NoError(t, err)
+	assert.Equal(t, blocks[2], b)
+
+	// the rolled back blocks can be added again
+	for _, b := range blocks[3:6] {
+		require.NoError(t, store.AddBlock(b))
+	}
+	require.NoError(t, store.AddBlock(block6))
+	require.NoError(t, store.AddBlock(block7))
+	b, err = store.RetrieveBlockByTxID("rolledBackTxID")
+	require.NoError(t, err)
+	assert.Equal(t, block7, b)
+	b, err = store.RetrieveBlockByTxID(retainedTxID)
+	require.NoError(t, err)
+	assert.Equal(t, blocks[2], b)
+	store.Shutdown()
+
+	result, err := env.provider.Verify("ledger1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), result.Height)
+	assert.Empty(t, result.Corruptions)
+}
+
+func TestRollbackCompletedAtStartup(t *testing.T) {
+	blocks := testutil.ConstructTestBlocks(t, 6)
+	blockBytes, _, err := serializeBlock(blocks[1])
+	require.NoError(t, err)
+	env := newTestEnv(t, NewConf(testPath(), len(blockBytes)*5/2))
+	defer env.Cleanup()
+
+	store, err := env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	for _, b := range blocks {
+		require.NoError(t, store.AddBlock(b))
+	}
+	store.Shutdown()
+	require.NoError(t, env.provider.Rollback("ledger1", 1))
+
+	// simulate a crash after the rollback info was saved but before the block files were truncated
+	rootDir := env.provider.conf.getLedgerBlockDir("ledger1")
+	db := env.provider.leveldbProvider.GetDBHandle("ledger1")
+	cpInfo, err := loadCheckpointInfo(db)
+	require.NoError(t, err)
+	info := &rollbackInfo{fileSuffixNum: cpInfo.latestFileChunkSuffixNum, fileSize: cpInfo.latestFileChunksize}
+	infoBytes, err := info.marshal()
+	require.NoError(t, err)
+	require.NoError(t, db.Put(rollbackInfoKey, infoBytes, true))
+	f, err := os.OpenFile(deriveBlockfilePath(rootDir, info.fileSuffixNum), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("rolled-back-block"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	rolledBackFilePath := deriveBlockfilePath(rootDir, info.fileSuffixNum+1)
+	require.NoError(t, ioutil.WriteFile(rolledBackFilePath, []byte("rolled-back-file"), 0644))
+
+	store, err = env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	bcInfo, err := store.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), bcInfo.Height)
+	assert.Equal(t, int64(info.fileSize), getFileInfoOrPanic(rootDir, info.fileSuffixNum).Size())
+	_, err = os.Stat(rolledBackFilePath)
+	assert.True(t, os.IsNotExist(err))
+	rbInfo, err := loadRollbackInfo(db)
+	require.NoError(t, err)
+	assert.Nil(t, rbInfo)
+	require.NoError(t, store.AddBlock(blocks[2]))
+}
+
+func TestRollbackInfoMarshalling(t *testing.T) {
+	info := &rollbackInfo{fileSuffixNum: 3, fileSize: 2500}
+	b, err := info.marshal()
+	require.NoError(t, err)
+	unmarshalled := &rollbackInfo{}
+	require.NoError(t, unmarshalled.unmarshal(b))
+	assert.Equal(t, info, unmarshalled)
+}
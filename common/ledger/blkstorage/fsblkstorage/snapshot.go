@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotDataFormat    = byte(1)
+	snapshotTxIDsFileName = "txids.data"
+	// maxTxIDsInImportBatch limits the number of txids that are loaded in a single write batch
+	// while bootstrapping a block store from a snapshot
+	maxTxIDsInImportBatch = 10000
+)
+
+var (
+	bootstrappingSnapshotInfoKey = []byte("bootstrappingSnapshotInfo")
+	bootstrappingBlockKeyPrefix  = []byte("bootstrappingBlock")
+	// txIDFromSnapshotMarker is stored against a txid in the txid index if the txid is loaded from a snapshot
+	txIDFromSnapshotMarker = []byte{}
+)
+
+// exportTxIds exports all the txids (along with the validation codes) present in the index into
+// the snapshot file 'txids.data'. The caller is expected to ensure that no block is committed
+// while the export is in progress
+func (mgr *blockfileMgr) exportTxIds(dir string) (map[string][]byte, error) {
+	if !mgr.index.isAttributeIndexed(blkstorage.IndexableAttrTxID) ||
+		!mgr.index.isAttributeIndexed(blkstorage.IndexableAttrTxValidationCode) {
+		return nil, errors.New("txids and validation codes are required to be indexed in the block store for exporting txids")
+	}
+	dataFile, err := snapshot.CreateFile(filepath.Join(dir, snapshotTxIDsFileName), snapshotDataFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer dataFile.Close()
+
+	itr := mgr.db.GetIterator([]byte{txIDIdxKeyPrefix}, []byte{txIDIdxKeyPrefix + 1})
+	defer itr.Release()
+	numTxIDs := uint64(0)
+	for itr.Next() {
+		if err := itr.Error(); err != nil {
+			return nil, errors.Wrap(err, "internal leveldb error while iterating for txids")
+		}
+		txID := string(itr.Key()[1:])
+		validationCode, err := mgr.index.getTxValidationCodeByTxID(txID)
+		if err != nil {
+			return nil, err
+		}
+		if err := dataFile.EncodeString(txID); err != nil {
+			return nil, err
+		}
+		if err := dataFile.EncodeUVarint(uint64(validationCode)); err != nil {
+			return nil, err
+		}
+		numTxIDs++
+	}
+	// the number of txids is written at the end of the file as the count is not known in advance
+	if err := dataFile.EncodeString(""); err != nil {
+		return nil, err
+	}
+	if err := dataFile.EncodeUVarint(numTxIDs); err != nil {
+		return nil, err
+	}
+	hash, err := dataFile.Done()
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Exported [%d] txids to the snapshot file [%s]", numTxIDs, snapshotTxIDsFileName)
+	return map[string][]byte{snapshotTxIDsFileName: hash}, nil
+}
+
+// importTxIDsFromSnapshot loads the txids from the snapshot file and initializes the block store
+// such that the next block expected by the block store is the block after the last block of the snapshot
+func importTxIDsFromSnapshot(
+	snapshotDir string,
+	snapshotInfo *blkstorage.SnapshotInfo,
+	indexConfig *blkstorage.IndexConfig,
+	db *leveldbhelper.DBHandle) error {
+
+	idx, err := newBlockIndex(indexConfig, db)
+	if err != nil {
+		return err
+	}
+	if !idx.isAttributeIndexed(blkstorage.IndexableAttrTxID) ||
+		!idx.isAttributeIndexed(blkstorage.IndexableAttrTxValidationCode) {
+		return errors.New("txids and validation codes are required to be indexed in the block store for bootstrapping from a snapshot")
+	}
+	dataFile, err := snapshot.OpenFile(filepath.Join(snapshotDir, snapshotTxIDsFileName), snapshotDataFormat)
+	if err != nil {
+		return err
+	}
+	defer dataFile.Close()
+
+	batch := leveldbhelper.NewUpdateBatch()
+	numTxIDs := uint64(0)
+	for {
+		txID, err := dataFile.DecodeString()
+		if err != nil {
+			return err
+		}
+		validationCode, err := dataFile.DecodeUVarInt()
+		if err != nil {
+			return err
+		}
+		if txID == "" {
+			if validationCode != numTxIDs {
+				return errors.Errorf("unexpected number of txids in the snapshot file. Expected = [%d], found = [%d]", validationCode, numTxIDs)
+			}
+			break
+		}
+		batch.Put(constructTxIDKey(txID), txIDFromSnapshotMarker)
+		batch.Put(constructTxValidationCodeIDKey(txID), []byte{byte(validationCode)})
+		numTxIDs++
+		if batch.Len() >= maxTxIDsInImportBatch {
+			if err := db.WriteBatch(batch, true); err != nil {
+				return err
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+
+	for _, block := range snapshotInfo.BootstrappingBlocks {
+		blockBytes, err := proto.Marshal(block)
+		if err != nil {
+			return errors.Wrap(err, "error while marshalling bootstrapping block")
+		}
+		batch.Put(constructBootstrappingBlockKey(block.Header.Number), blockBytes)
+	}
+	snapshotInfoBytes, err := marshalBootstrappingSnapshotInfo(snapshotInfo)
+	if err != nil {
+		return err
+	}
+	batch.Put(bootstrappingSnapshotInfoKey, snapshotInfoBytes)
+	cpInfo := &checkpointInfo{
+		isChainEmpty:    true,
+		lastBlockNumber: snapshotInfo.LastBlockNum,
+	}
+	cpInfoBytes, err := cpInfo.marshal()
+	if err != nil {
+		return err
+	}
+	batch.Put(blkMgrInfoKey, cpInfoBytes)
+	if err := db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	logger.Infof("Imported [%d] txids from the snapshot. Block store bootstrapped at block [%d]", numTxIDs, snapshotInfo.LastBlockNum)
+	return nil
+}
+
+// loadBootstrappingSnapshotInfo returns the info of the snapshot that the block store was bootstrapped from.
+// A nil value is returned if the block store was created from a genesis block
+func loadBootstrappingSnapshotInfo(db *leveldbhelper.DBHandle) (*blkstorage.SnapshotInfo, error) {
+	b, err := db.Get(bootstrappingSnapshotInfoKey)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return unmarshalBootstrappingSnapshotInfo(b)
+}
+
+// retrieveBootstrappingBlock returns the block with the given number, if the block was carried in the
+// snapshot that the block store was bootstrapped from
+func (mgr *blockfileMgr) retrieveBootstrappingBlock(blockNum uint64) (*common.Block, error) {
+	b, err := mgr.db.Get(constructBootstrappingBlockKey(blockNum))
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, blkstorage.ErrNotFoundInIndex
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(b, block); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling bootstrapping block")
+	}
+	return block, nil
+}
+
+func constructBootstrappingBlockKey(blockNum uint64) []byte {
+	return append(append([]byte{}, bootstrappingBlockKeyPrefix...), util.EncodeOrderPreservingVarUint64(blockNum)...)
+}
+
+func marshalBootstrappingSnapshotInfo(info *blkstorage.SnapshotInfo) ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	if err := buffer.EncodeVarint(info.LastBlockNum); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeRawBytes(info.LastBlockHash); err != nil {
+		return nil, err
+	}
+	if err := buffer.EncodeRawBytes(info.PreviousBlockHash); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func unmarshalBootstrappingSnapshotInfo(b []byte) (*blkstorage.SnapshotInfo, error) {
+	buffer := proto.NewBuffer(b)
+	info := &blkstorage.SnapshotInfo{}
+	var err error
+	if info.LastBlockNum, err = buffer.DecodeVarint(); err != nil {
+		return nil, err
+	}
+	if info.LastBlockHash, err = buffer.DecodeRawBytes(true); err != nil {
+		return nil, err
+	}
+	if info.PreviousBlockHash, err = buffer.DecodeRawBytes(true); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
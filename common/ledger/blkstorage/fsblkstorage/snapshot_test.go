@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAndImportTxIds(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "fsblkstorage-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	store, err := env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	for _, b := range blocks {
+		require.NoError(t, store.AddBlock(b))
+	}
+	fileHashes, err := store.ExportTxIds(snapshotDir)
+	require.NoError(t, err)
+	require.Contains(t, fileHashes, snapshotTxIDsFileName)
+
+	lastBlock := blocks[len(blocks)-1]
+	snapshotInfo := &blkstorage.SnapshotInfo{
+		LastBlockNum:        lastBlock.Header.Number,
+		LastBlockHash:       lastBlock.Header.Hash(),
+		PreviousBlockHash:   lastBlock.Header.PreviousHash,
+		BootstrappingBlocks: []*common.Block{blocks[0], lastBlock},
+	}
+	require.NoError(t, env.provider.ImportFromSnapshot("bootstrappedLedger", snapshotDir, snapshotInfo))
+	bootstrappedStore, err := env.provider.OpenBlockStore("bootstrappedLedger")
+	require.NoError(t, err)
+	defer bootstrappedStore.Shutdown()
+
+	bcInfo, err := bootstrappedStore.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, &common.BlockchainInfo{
+		Height:            5,
+		CurrentBlockHash:  lastBlock.Header.Hash(),
+		PreviousBlockHash: lastBlock.Header.PreviousHash,
+		FirstBlockNum:     5,
+	}, bcInfo)
+
+	// blocks carried in the snapshot are retrievable and others are not
+	b, err := bootstrappedStore.RetrieveBlockByNumber(0)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[0], b))
+	b, err = bootstrappedStore.RetrieveBlockByNumber(4)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(lastBlock, b))
+	_, err = bootstrappedStore.RetrieveBlockByNumber(2)
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+
+	// txids from the snapshot are known with their validation codes
+	for _, block := range blocks {
+		for i := range block.Data.Data {
+			txID, err := extractTxID(block.Data.Data[i])
+			require.NoError(t, err)
+			_, err = bootstrappedStore.RetrieveTxByID(txID)
+			assert.Equal(t, blkstorage.ErrTxFromSnapshot, err)
+			code, err := bootstrappedStore.RetrieveTxValidationCodeByTxID(txID)
+			require.NoError(t, err)
+			assert.Equal(t, peer.TxValidationCode_VALID, code)
+		}
+	}
+
+	// next blocks can be added to the bootstrapped store and a txid from the snapshot is detected as duplicate
+	duplicateTxID, err := extractTxID(blocks[1].Data.Data[0])
+	require.NoError(t, err)
+	block5 := testutil.ConstructBlockWithTxid(t, 5, lastBlock.Header.Hash(),
+		[][]byte{[]byte("results1"), []byte("results2")}, []string{duplicateTxID, "newTxID"}, false)
+	block6 := testutil.ConstructBlock(t, 6, block5.Header.Hash(), [][]byte{[]byte("results3")}, false)
+	require.NoError(t, bootstrappedStore.AddBlock(block5))
+	require.NoError(t, bootstrappedStore.AddBlock(block6))
+	b, err = bootstrappedStore.RetrieveBlockByNumber(5)
+	require.NoError(t, err)
+	assert.Equal(t, block5, b)
+	_, err = bootstrappedStore.RetrieveTxByID(duplicateTxID)
+	assert.Equal(t, blkstorage.ErrTxFromSnapshot, err)
+	_, err = bootstrappedStore.RetrieveTxByID("newTxID")
+	assert.NoError(t, err)
+	bcInfo, err = bootstrappedStore.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), bcInfo.Height)
+
+	// restart preserves the bootstrapped state
+	bootstrappedStore.Shutdown()
+	env.provider.Close()
+	env = newTestEnv(t, env.provider.conf)
+	bootstrappedStore, err = env.provider.OpenBlockStore("bootstrappedLedger")
+	require.NoError(t, err)
+	bcInfo, err = bootstrappedStore.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), bcInfo.Height)
+	defer bootstrappedStore.Shutdown()
+	b, err = bootstrappedStore.RetrieveBlockByNumber(0)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[0], b))
+	b, err = bootstrappedStore.RetrieveBlockByNumber(6)
+	require.NoError(t, err)
+	assert.Equal(t, block6, b)
+}
+
+func TestImportTxIdsErrors(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "fsblkstorage-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	err = env.provider.ImportFromSnapshot("ledger1", snapshotDir, &blkstorage.SnapshotInfo{})
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+
+	store, err := env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	defer store.Shutdown()
+	err = env.provider.ImportFromSnapshot("ledger1", snapshotDir, &blkstorage.SnapshotInfo{})
+	assert.EqualError(t, err, "block store for ledger [ledger1] already exists")
+}
@@ -0,0 +1,288 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+// Verify implements the function in the interface `blkstorage.BlockStoreVerifier`. The blocks in the block files
+// (including the archived block files, if any) are scanned for the continuity of the block numbers, the hash chain,
+// and the data hashes. The entries in the block index for the blocks and the transactions are cross-checked with the
+// locations of the blocks and the transactions in the block files. The block store is not modified by the verification
+func (p *FsBlockstoreProvider) Verify(ledgerid string) (*blkstorage.VerificationResult, error) {
+	exists, err := p.Exists(ledgerid)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("block store for ledger [%s] does not exist", ledgerid)
+	}
+	v, err := newVerifier(ledgerid, p.conf, p.indexConfig, p.leveldbProvider.GetDBHandle(ledgerid))
+	if err != nil {
+		return nil, err
+	}
+	defer v.cleanup()
+	return v.verify()
+}
+
+type verifier struct {
+	rootDir        string
+	index          *blockIndex
+	openFileStream blockfileStreamOpener
+	cleanup        func()
+	cpInfo         *checkpointInfo
+	snapshotInfo   *blkstorage.SnapshotInfo
+	pruningInfo    *pruningInfo
+	indexEmpty     bool
+	lastIndexed    uint64
+
+	nextBlockNum      uint64
+	previousBlockHash []byte
+	corruptions       []*blkstorage.Corruption
+}
+
+func newVerifier(ledgerid string, conf *Conf, indexConfig *blkstorage.IndexConfig, db *leveldbhelper.DBHandle) (*verifier, error) {
+	rootDir := conf.getLedgerBlockDir(ledgerid)
+	v := &verifier{
+		rootDir: rootDir,
+		openFileStream: func(fileNum int, startOffset int64) (*blockfileStream, error) {
+			return newBlockfileStream(rootDir, fileNum, startOffset)
+		},
+		cleanup: func() {},
+	}
+	var err error
+	if v.index, err = newBlockIndex(indexConfig, db); err != nil {
+		return nil, err
+	}
+	if v.cpInfo, err = loadCheckpointInfo(db); err != nil {
+		return nil, err
+	}
+	if v.snapshotInfo, err = loadBootstrappingSnapshotInfo(db); err != nil {
+		return nil, err
+	}
+	if v.pruningInfo, err = loadPruningInfo(db); err != nil {
+		return nil, err
+	}
+	v.lastIndexed, err = v.index.getLastBlockIndexed()
+	switch {
+	case err == errIndexEmpty:
+		v.indexEmpty = true
+	case err != nil:
+		return nil, err
+	}
+	if conf.archiveConf == nil {
+		return v, nil
+	}
+
+	// the archived block files are fetched into a temporary dir, so as to leave the cache of the block store untouched
+	firstLocalFileNum, err := loadArchivingInfo(db)
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := ioutil.TempDir("", "verify-"+ledgerid)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating temporary dir for the archived block files")
+	}
+	a := &archiver{
+		ledgerID:          ledgerid,
+		rootDir:           rootDir,
+		cacheDir:          cacheDir,
+		conf:              conf.archiveConf,
+		db:                db,
+		firstLocalFileNum: firstLocalFileNum,
+		pinCounts:         map[int]int{},
+	}
+	v.openFileStream = func(fileNum int, startOffset int64) (*blockfileStream, error) {
+		var stream *blockfileStream
+		err := a.withBlockfileDir(fileNum, func(dir string) error {
+			var err error
+			stream, err = newBlockfileStream(dir, fileNum, startOffset)
+			return err
+		})
+		return stream, err
+	}
+	v.cleanup = func() { os.RemoveAll(cacheDir) }
+	return v, nil
+}
+
+func (v *verifier) verify() (*blkstorage.VerificationResult, error) {
+	firstFileNum := 0
+	if v.pruningInfo != nil {
+		firstFileNum = v.pruningInfo.firstFileSuffixNum
+	}
+	lastFileNum := -1
+	if v.cpInfo != nil {
+		lastFileNum = v.cpInfo.latestFileChunkSuffixNum
+	} else {
+		var err error
+		if lastFileNum, err = retrieveLastFileSuffix(v.rootDir); err != nil {
+			return nil, err
+		}
+	}
+	v.nextBlockNum = computeFirstBlockNumInFiles(v.snapshotInfo, v.pruningInfo)
+	if v.snapshotInfo != nil && v.pruningInfo == nil {
+		v.previousBlockHash = v.snapshotInfo.LastBlockHash
+	}
+
+	for fileNum := firstFileNum; fileNum <= lastFileNum; fileNum++ {
+		v.verifyFile(fileNum, fileNum == lastFileNum)
+	}
+
+	height := v.nextBlockNum
+	if v.cpInfo != nil && !v.cpInfo.isChainEmpty && v.cpInfo.lastBlockNumber >= height {
+		v.addCorruption("block files", fmt.Sprintf(
+			"block [%d] is recorded as the last block in the checkpoint info while the height of the block files is [%d]",
+			v.cpInfo.lastBlockNumber, height))
+	}
+	if !v.indexEmpty && v.lastIndexed >= height {
+		v.addCorruption("block index", fmt.Sprintf(
+			"block [%d] is recorded as the last indexed block while the height of the block files is [%d]",
+			v.lastIndexed, height))
+	}
+	return &blkstorage.VerificationResult{Height: height, Corruptions: v.corruptions}, nil
+}
+
+// verifyFile verifies the blocks in the given block file. The verification of a block file stops at the first
+// block that cannot be read, as the boundaries of the subsequent blocks in the block file cannot be determined
+func (v *verifier) verifyFile(fileNum int, isLastFile bool) {
+	filePath := deriveBlockfilePath(v.rootDir, fileNum)
+	stream, err := v.openFileStream(fileNum, 0)
+	if err != nil {
+		v.addCorruption(fmt.Sprintf("block file [%s]", filePath), fmt.Sprintf("block file cannot be opened: %s", err))
+		return
+	}
+	defer stream.close()
+	for {
+		offset := stream.currentOffset
+		location := fmt.Sprintf("block file [%s], offset [%d]", filePath, offset)
+		blockBytes, placementInfo, err := nextBlockBytesForVerification(stream)
+		if err == ErrUnexpectedEndOfBlockfile && isLastFile && v.cpInfo != nil && offset >= int64(v.cpInfo.latestFileChunksize) {
+			logger.Infof("Found a partially written block at the end of the block file [%s], at offset [%d]. "+
+				"The partial block is removed when the peer is started", filePath, offset)
+			return
+		}
+		if err != nil {
+			v.addCorruption(location, fmt.Sprintf("block cannot be read: %s", err))
+			return
+		}
+		if blockBytes == nil {
+			return
+		}
+		if !v.verifyBlock(location, placementInfo, blockBytes) {
+			return
+		}
+	}
+}
+
+// nextBlockBytesForVerification reads the next block from the stream. The stream panics on the bytes
+// that cannot be decoded as the length of a block, which the verifier reports as a corruption
+func nextBlockBytesForVerification(stream *blockfileStream) (blockBytes []byte, placementInfo *blockPlacementInfo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("%s", r)
+		}
+	}()
+	return stream.nextBlockBytesAndPlacementInfo()
+}
+
+func (v *verifier) verifyBlock(location string, placementInfo *blockPlacementInfo, blockBytes []byte) bool {
+	block, err := deserializeBlock(blockBytes)
+	if err != nil {
+		v.addCorruption(location, fmt.Sprintf("block cannot be deserialized: %s", err))
+		return false
+	}
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		v.addCorruption(location, fmt.Sprintf("block cannot be deserialized: %s", err))
+		return false
+	}
+	blockNum := block.Header.Number
+	if blockNum != v.nextBlockNum {
+		v.addCorruption(location, fmt.Sprintf("expected block [%d], found block [%d]", v.nextBlockNum, blockNum))
+	}
+	if v.previousBlockHash != nil && !bytes.Equal(block.Header.PreviousHash, v.previousBlockHash) {
+		v.addCorruption(location, fmt.Sprintf("previous hash in the header of block [%d] does not match the hash of the previous block", blockNum))
+	}
+	if !bytes.Equal(block.Header.DataHash, block.Data.Hash()) {
+		v.addCorruption(location, fmt.Sprintf("data hash in the header of block [%d] does not match the hash of the block data", blockNum))
+	}
+	if !v.indexEmpty && blockNum <= v.lastIndexed {
+		v.verifyIndexEntries(location, placementInfo, info)
+	}
+	v.nextBlockNum = blockNum + 1
+	v.previousBlockHash = block.Header.Hash()
+	return true
+}
+
+// verifyIndexEntries cross-checks the entries in the block index for the block and its transactions
+func (v *verifier) verifyIndexEntries(location string, placementInfo *blockPlacementInfo, info *serializedBlockInfo) {
+	blockNum := info.blockHeader.Number
+	blockFLP := &fileLocPointer{
+		fileSuffixNum: placementInfo.fileNum,
+		locPointer:    locPointer{offset: int(placementInfo.blockStartOffset)},
+	}
+	if v.index.isAttributeIndexed(blkstorage.IndexableAttrBlockNum) {
+		flp, err := v.index.getBlockLocByBlockNum(blockNum)
+		v.verifyIndexEntry(location, fmt.Sprintf("block number [%d]", blockNum), blockFLP, flp, err)
+	}
+	if v.index.isAttributeIndexed(blkstorage.IndexableAttrBlockHash) {
+		flp, err := v.index.getBlockLocByHash(info.blockHeader.Hash())
+		v.verifyIndexEntry(location, fmt.Sprintf("hash of block [%d]", blockNum), blockFLP, flp, err)
+	}
+
+	numBytesToShift := int(placementInfo.blockBytesOffset - placementInfo.blockStartOffset)
+	for txNum, txOffset := range info.txOffsets {
+		txFLP := newFileLocationPointer(placementInfo.fileNum, int(placementInfo.blockStartOffset),
+			&locPointer{offset: txOffset.loc.offset + numBytesToShift, bytesLength: txOffset.loc.bytesLength})
+		if v.index.isAttributeIndexed(blkstorage.IndexableAttrBlockNumTranNum) {
+			flp, err := v.index.getTXLocByBlockNumTranNum(blockNum, uint64(txNum))
+			v.verifyIndexEntry(location, fmt.Sprintf("transaction [%d] in block [%d]", txNum, blockNum), txFLP, flp, err)
+		}
+		if !v.index.isAttributeIndexed(blkstorage.IndexableAttrTxID) {
+			continue
+		}
+		flp, err := v.index.getTxLoc(txOffset.txID)
+		if err == blkstorage.ErrTxFromSnapshot || err == blkstorage.ErrBlockPruned {
+			// the txid is a duplicate of a transaction that is not present in the block files
+			continue
+		}
+		if err == nil && isBefore(flp, txFLP) {
+			// the txid is a duplicate of an earlier transaction
+			continue
+		}
+		v.verifyIndexEntry(location, fmt.Sprintf("txid [%s]", txOffset.txID), txFLP, flp, err)
+	}
+}
+
+func (v *verifier) verifyIndexEntry(location, entry string, expected, actual *fileLocPointer, err error) {
+	switch {
+	case err == blkstorage.ErrNotFoundInIndex:
+		v.addCorruption(location, fmt.Sprintf("entry for %s is missing in the block index", entry))
+	case err != nil:
+		v.addCorruption(location, fmt.Sprintf("entry for %s cannot be read from the block index: %s", entry, err))
+	case *actual != *expected:
+		v.addCorruption(location, fmt.Sprintf("entry for %s in the block index points to [%s] instead of [%s]", entry, actual, expected))
+	}
+}
+
+func (v *verifier) addCorruption(location, description string) {
+	logger.Warningf("Corruption detected in %s: %s", location, description)
+	v.corruptions = append(v.corruptions, &blkstorage.Corruption{Location: location, Description: description})
+}
+
+func isBefore(flp1, flp2 *fileLocPointer) bool {
+	return flp1.fileSuffixNum < flp2.fileSuffixNum ||
+		(flp1.fileSuffixNum == flp2.fileSuffixNum && flp1.offset < flp2.offset)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	_, err := env.provider.Verify("ledger1")
+	assert.EqualError(t, err, "block store for ledger [ledger1] does not exist")
+
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	store, err := env.provider.OpenBlockStore("ledger1")
+	require.NoError(t, err)
+	for _, b := range blocks {
+		require.NoError(t, store.AddBlock(b))
+	}
+	index := store.(*fsBlockStore).fileMgr.index.(*blockIndex)
+	txLoc, err := index.getTXLocByBlockNumTranNum(2, 0)
+	require.NoError(t, err)
+	store.Shutdown()
+
+	result, err := env.provider.Verify("ledger1")
+	require.NoError(t, err)
+	assert.Equal(t, &blkstorage.VerificationResult{Height: 5}, result)
+
+	// alter the last byte of the first transaction in block 2, which is a part of the signature in the envelope
+	blockfilePath := deriveBlockfilePath(env.provider.conf.getLedgerBlockDir("ledger1"), 0)
+	file, err := os.OpenFile(blockfilePath, os.O_RDWR, 0600)
+	require.NoError(t, err)
+	lastByte := make([]byte, 1)
+	_, err = file.ReadAt(lastByte, int64(txLoc.offset+txLoc.bytesLength-1))
+	require.NoError(t, err)
+	_, err = file.WriteAt([]byte{lastByte[0] + 1}, int64(txLoc.offset+txLoc.bytesLength-1))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	// remove the entry for block 3 from the block number index
+	require.NoError(t, index.db.Delete(constructBlockNumKey(3), true))
+	block3Loc, err := index.getBlockLocByHash(blocks[3].Header.Hash())
+	require.NoError(t, err)
+
+	result, err = env.provider.Verify("ledger1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), result.Height)
+	block2Loc, err := index.getBlockLocByHash(blocks[2].Header.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, []*blkstorage.Corruption{
+		{
+			Location:    fmt.Sprintf("block file [%s], offset [%d]", blockfilePath, block2Loc.offset),
+			Description: "data hash in the header of block [2] does not match the hash of the block data",
+		},
+		{
+			Location:    fmt.Sprintf("block file [%s], offset [%d]", blockfilePath, block3Loc.offset),
+			Description: "entry for block number [3] is missing in the block index",
+		},
+	}, result.Corruptions)
+}
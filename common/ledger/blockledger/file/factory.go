@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/pkg/errors"
 )
 
 type fileLedgerFactory struct {
@@ -60,6 +61,26 @@ func (flf *fileLedgerFactory) ChainIDs() []string {
 	return chainIDs
 }
 
+// Remove shuts down the ledger of the given chainID, if it is open, and removes its block store
+func (flf *fileLedgerFactory) Remove(chainID string) error {
+	remover, ok := flf.blkstorageProvider.(blkstorage.BlockStoreRemover)
+	if !ok {
+		return errors.New("the block storage provider does not support removing ledgers")
+	}
+
+	flf.mutex.Lock()
+	defer flf.mutex.Unlock()
+
+	if ledger, ok := flf.ledgers[chainID].(*FileLedger); ok {
+		if blockStore, ok := ledger.blockStore.(blkstorage.BlockStore); ok {
+			blockStore.Shutdown()
+		}
+	}
+	delete(flf.ledgers, chainID)
+
+	return remover.Remove(chainID)
+}
+
 // Close releases all resources acquired by the factory
 func (flf *fileLedgerFactory) Close() {
 	flf.blkstorageProvider.Close()
@@ -19,6 +19,7 @@ package fileledger
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -50,6 +51,10 @@ func (mbsp *mockBlockStoreProvider) List() ([]string, error) {
 	return mbsp.list, mbsp.error
 }
 
+func (mbsp *mockBlockStoreProvider) ImportFromSnapshot(ledgerid string, snapshotDir string, snapshotInfo *blkstorage.SnapshotInfo) error {
+	return mbsp.error
+}
+
 func (mbsp *mockBlockStoreProvider) Close() {
 }
 
@@ -90,3 +95,26 @@ func TestMultiReinitialization(t *testing.T) {
 	assert.Equal(t, 3, len(flf.ChainIDs()), "Expected chain to be recovered")
 	flf.Close()
 }
+
+func TestRemove(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hyperledger_fabric")
+	assert.NoError(t, err, "Error creating temp dir: %s", err)
+	defer os.RemoveAll(dir)
+
+	flf := New(dir)
+	defer flf.Close()
+	_, err = flf.GetOrCreate("foo")
+	assert.NoError(t, err, "Error creating chain")
+	_, err = flf.GetOrCreate("bar")
+	assert.NoError(t, err, "Error creating chain")
+
+	assert.NoError(t, flf.(blockledger.Remover).Remove("foo"))
+	assert.Equal(t, []string{"bar"}, flf.ChainIDs(), "Expected chain to be removed")
+	assert.NoError(t, flf.(blockledger.Remover).Remove("missing"), "Expected removing a missing chain to be a noop")
+
+	flf = &fileLedgerFactory{
+		blkstorageProvider: &mockBlockStoreProvider{},
+		ledgers:            make(map[string]blockledger.ReadWriter),
+	}
+	assert.EqualError(t, flf.(blockledger.Remover).Remove("foo"), "the block storage provider does not support removing ledgers")
+}
@@ -67,7 +67,11 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 	var startingBlockNumber uint64
 	switch start := startPosition.Type.(type) {
 	case *ab.SeekPosition_Oldest:
-		startingBlockNumber = 0
+		info, err := fl.blockStore.GetBlockchainInfo()
+		if err != nil {
+			logger.Panic(err)
+		}
+		startingBlockNumber = info.FirstBlockNum
 	case *ab.SeekPosition_Newest:
 		info, err := fl.blockStore.GetBlockchainInfo()
 		if err != nil {
@@ -77,8 +81,16 @@ func (fl *FileLedger) Iterator(startPosition *ab.SeekPosition) (blockledger.Iter
 		startingBlockNumber = newestBlockNumber
 	case *ab.SeekPosition_Specified:
 		startingBlockNumber = start.Specified.Number
-		height := fl.Height()
-		if startingBlockNumber > height {
+		info, err := fl.blockStore.GetBlockchainInfo()
+		if err != nil {
+			logger.Panic(err)
+		}
+		if startingBlockNumber > info.Height {
+			return &blockledger.NotFoundErrorIterator{}, 0
+		}
+		if startingBlockNumber < info.FirstBlockNum {
+			logger.Warningf("Requested block [%d] is not available, the first block available in the ledger is [%d]",
+				startingBlockNumber, info.FirstBlockNum)
 			return &blockledger.NotFoundErrorIterator{}, 0
 		}
 	default:
@@ -107,6 +107,14 @@ func (mbs *mockBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return mbs.txValidationCode, mbs.defaultError
 }
 
+func (mbs *mockBlockStore) ExportTxIds(snapshotDir string) (map[string][]byte, error) {
+	return nil, mbs.defaultError
+}
+
+func (mbs *mockBlockStore) Prune(retainFromBlockNum uint64) error {
+	return mbs.defaultError
+}
+
 func (*mockBlockStore) Shutdown() {
 }
 
@@ -233,6 +241,34 @@ func TestBlockedRetrieval(t *testing.T) {
 	assert.Equal(t, uint64(2), block.Header.Number, "Expected to successfully retrieve the third block")
 }
 
+func TestRetrievalOfPrunedBlocks(t *testing.T) {
+	resultsIterator := &mockBlockStoreIterator{}
+	resultsIterator.On("Close").Return()
+	fl := &FileLedger{
+		blockStore: &mockBlockStore{
+			blockchainInfo:  &cb.BlockchainInfo{Height: uint64(20), FirstBlockNum: uint64(10)},
+			resultsIterator: resultsIterator,
+		},
+		signal: make(chan struct{}),
+	}
+
+	it, _ := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 5}}})
+	defer it.Close()
+	assert.IsType(t, &blockledger.NotFoundErrorIterator{}, it, "Expected Not Found Error if seek number is below the first block available")
+	_, status := it.Next()
+	assert.Equal(t, cb.Status_NOT_FOUND, status)
+
+	it, num := fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 10}}})
+	defer it.Close()
+	assert.IsType(t, &fileLedgerIterator{}, it)
+	assert.Equal(t, uint64(10), num)
+
+	it, num = fl.Iterator(&ab.SeekPosition{Type: &ab.SeekPosition_Oldest{}})
+	defer it.Close()
+	assert.IsType(t, &fileLedgerIterator{}, it)
+	assert.Equal(t, uint64(10), num, "Expected the oldest position to start at the first block available")
+}
+
 func TestBlockstoreError(t *testing.T) {
 	// Since this test only ensures failed GetBlockchainInfo
 	// is properly handled. We don't bother creating fully
@@ -24,6 +24,13 @@ type Factory interface {
 	Close()
 }
 
+// Remover is implemented by a Factory that supports removing a ledger, along with its blocks
+type Remover interface {
+	// Remove closes the ledger of the given chainID, if it is open, and removes it.
+	// Removing a ledger that does not exist is a noop
+	Remove(chainID string) error
+}
+
 // Iterator is useful for a chain Reader to stream blocks as they are created
 type Iterator interface {
 	// Next blocks until there is a new block available, or returns an error if
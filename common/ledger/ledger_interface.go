@@ -55,3 +55,9 @@ type QueryResult interface{}
 
 // PrunePolicy - a general interface for supporting different pruning policies
 type PrunePolicy interface{}
+
+// BlockRetentionPolicy - a PrunePolicy that prunes the blocks below RetainFromBlockNum. The blocks are pruned
+// at the granularity of block files and hence, a few blocks below RetainFromBlockNum may be retained
+type BlockRetentionPolicy struct {
+	RetainFromBlockNum uint64
+}
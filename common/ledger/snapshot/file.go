@@ -0,0 +1,209 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// NewHashFunc returns the hash function that is used for computing the hashes of the snapshot files
+func NewHashFunc() hash.Hash {
+	return sha256.New()
+}
+
+// FileWriter writes to a ledger snapshot file. The content written to the file is passed through
+// a hasher so that the hash of the file can be made available to the caller once the writing is done
+type FileWriter struct {
+	file              *os.File
+	hasher            hash.Hash
+	bufWriter         *bufio.Writer
+	multiWriter       io.Writer
+	varintReusableBuf []byte
+}
+
+// CreateFile creates a new file for exporting the ledger snapshot data.
+// This function returns an error if the file already exists. The `dataformat` is the first byte
+// written to the file and allows the consumer of the file to make sure that the file content is
+// in the expected format
+func CreateFile(filePath string, dataformat byte) (*FileWriter, error) {
+	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while creating the snapshot file: %s", filePath)
+	}
+	hasher := NewHashFunc()
+	bufWriter := bufio.NewWriter(file)
+	w := &FileWriter{
+		file:              file,
+		hasher:            hasher,
+		bufWriter:         bufWriter,
+		multiWriter:       io.MultiWriter(bufWriter, hasher),
+		varintReusableBuf: make([]byte, binary.MaxVarintLen64),
+	}
+	if err := w.EncodeBytes([]byte{dataformat}); err != nil {
+		w.Close()
+		return nil, err
+	}
+	return w, nil
+}
+
+// EncodeString encodes and appends the string to the data file
+func (w *FileWriter) EncodeString(str string) error {
+	return w.EncodeBytes([]byte(str))
+}
+
+// EncodeBytes encodes the length of the byte slice followed by the bytes and appends them to the data file
+func (w *FileWriter) EncodeBytes(b []byte) error {
+	if err := w.EncodeUVarint(uint64(len(b))); err != nil {
+		return err
+	}
+	if _, err := w.multiWriter.Write(b); err != nil {
+		return errors.Wrapf(err, "error while writing data to the snapshot file: %s", w.file.Name())
+	}
+	return nil
+}
+
+// EncodeUVarint encodes a uint64 as varint and appends it to the data file
+func (w *FileWriter) EncodeUVarint(u uint64) error {
+	n := binary.PutUvarint(w.varintReusableBuf, u)
+	if _, err := w.multiWriter.Write(w.varintReusableBuf[:n]); err != nil {
+		return errors.Wrapf(err, "error while writing data to the snapshot file: %s", w.file.Name())
+	}
+	return nil
+}
+
+// EncodeProtoMessage marshals the given proto message and appends it to the data file
+func (w *FileWriter) EncodeProtoMessage(m proto.Message) error {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling proto message to write to the snapshot file: %s", w.file.Name())
+	}
+	return w.EncodeBytes(b)
+}
+
+// Done closes the data file and returns the hash of the content written to the file
+func (w *FileWriter) Done() ([]byte, error) {
+	if err := w.bufWriter.Flush(); err != nil {
+		return nil, errors.Wrapf(err, "error while flushing to the snapshot file: %s", w.file.Name())
+	}
+	if err := w.file.Sync(); err != nil {
+		return nil, errors.Wrapf(err, "error while syncing the snapshot file: %s", w.file.Name())
+	}
+	if err := w.file.Close(); err != nil {
+		return nil, errors.Wrapf(err, "error while closing the snapshot file: %s", w.file.Name())
+	}
+	return w.hasher.Sum(nil), nil
+}
+
+// Close closes the underlying file, if not already done. A consumer can invoke this function if the consumer
+// encountered some error and simply wants to abandon the snapshot file creation (typically, intended to be used in a defer statement)
+func (w *FileWriter) Close() error {
+	if w == nil {
+		return nil
+	}
+	return errors.Wrapf(w.file.Close(), "error while closing the snapshot file: %s", w.file.Name())
+}
+
+// FileReader reads from a ledger snapshot file. This is expected to be used for loading the ledger snapshot
+// data during bootstrapping a channel from a snapshot
+type FileReader struct {
+	file      *os.File
+	bufReader *bufio.Reader
+	bytesBuf  []byte
+}
+
+// OpenFile opens a snapshot file and verifies that the first byte of the file
+// matches the expected data format
+func OpenFile(filePath string, expectedDataFormat byte) (*FileReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file: %s", filePath)
+	}
+	r := &FileReader{
+		file:      file,
+		bufReader: bufio.NewReader(file),
+	}
+	dataFormat, err := r.DecodeBytes()
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	if len(dataFormat) != 1 || dataFormat[0] != expectedDataFormat {
+		r.Close()
+		return nil, errors.Errorf("unexpected data format in the snapshot file [%s]. Expected = [%x], found = [%x]",
+			filePath, expectedDataFormat, dataFormat)
+	}
+	return r, nil
+}
+
+// DecodeString reads and decodes a string
+func (r *FileReader) DecodeString() (string, error) {
+	b, err := r.decodeBytesIntoReusableBuf()
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// DecodeBytes reads and decodes bytes. The returned slice is owned by the caller
+func (r *FileReader) DecodeBytes() ([]byte, error) {
+	b, err := r.decodeBytesIntoReusableBuf()
+	if err != nil {
+		return nil, err
+	}
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c, nil
+}
+
+// DecodeUVarInt reads a varint encoded uint64
+func (r *FileReader) DecodeUVarInt() (uint64, error) {
+	val, err := binary.ReadUvarint(r.bufReader)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error while reading an unsigned varint from the snapshot file: %s", r.file.Name())
+	}
+	return val, nil
+}
+
+// DecodeProtoMessage reads bytes and unmarshals them into the supplied proto message
+func (r *FileReader) DecodeProtoMessage(m proto.Message) error {
+	b, err := r.decodeBytesIntoReusableBuf()
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(proto.Unmarshal(b, m), "error while unmarshalling proto message from the snapshot file: %s", r.file.Name())
+}
+
+// Close closes the file
+func (r *FileReader) Close() error {
+	if r == nil {
+		return nil
+	}
+	return errors.Wrapf(r.file.Close(), "error while closing the snapshot file: %s", r.file.Name())
+}
+
+func (r *FileReader) decodeBytesIntoReusableBuf() ([]byte, error) {
+	bytesLen, err := r.DecodeUVarInt()
+	if err != nil {
+		return nil, err
+	}
+	if cap(r.bytesBuf) < int(bytesLen) {
+		r.bytesBuf = make([]byte, bytesLen)
+	}
+	r.bytesBuf = r.bytesBuf[:bytesLen]
+	if _, err := io.ReadFull(r.bufReader, r.bytesBuf); err != nil {
+		return nil, errors.Wrapf(err, "error while reading bytes from the snapshot file: %s", r.file.Name())
+	}
+	return r.bytesBuf, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCreateAndRead(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "testfile")
+
+	fileWriter, err := CreateFile(filePath, byte(7))
+	require.NoError(t, err)
+	require.NoError(t, fileWriter.EncodeString("Hi there"))
+	require.NoError(t, fileWriter.EncodeBytes([]byte("How are you?")))
+	require.NoError(t, fileWriter.EncodeUVarint(uint64(25)))
+	require.NoError(t, fileWriter.EncodeProtoMessage(&common.BlockHeader{Number: 10}))
+	fileHash, err := fileWriter.Done()
+	require.NoError(t, err)
+
+	fileContent, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	hasher := NewHashFunc()
+	hasher.Write(fileContent)
+	assert.Equal(t, hasher.Sum(nil), fileHash)
+
+	fileReader, err := OpenFile(filePath, byte(7))
+	require.NoError(t, err)
+	defer fileReader.Close()
+
+	str, err := fileReader.DecodeString()
+	require.NoError(t, err)
+	assert.Equal(t, "Hi there", str)
+
+	b, err := fileReader.DecodeBytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("How are you?"), b)
+
+	number, err := fileReader.DecodeUVarInt()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(25), number)
+
+	header := &common.BlockHeader{}
+	require.NoError(t, fileReader.DecodeProtoMessage(header))
+	assert.True(t, proto.Equal(&common.BlockHeader{Number: 10}, header))
+
+	_, err = fileReader.DecodeBytes()
+	assert.Error(t, err)
+}
+
+func TestFileCreateErrors(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshot-file")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	filePath := filepath.Join(testDir, "testfile")
+
+	fileWriter, err := CreateFile(filePath, byte(1))
+	require.NoError(t, err)
+	_, err = fileWriter.Done()
+	require.NoError(t, err)
+
+	_, err = CreateFile(filePath, byte(1))
+	assert.Contains(t, err.Error(), "error while creating the snapshot file")
+
+	_, err = OpenFile(filePath, byte(2))
+	assert.EqualError(t, err, "unexpected data format in the snapshot file ["+filePath+"]. Expected = [2], found = [01]")
+
+	_, err = OpenFile(filepath.Join(testDir, "non-existent-file"), byte(1))
+	assert.Contains(t, err.Error(), "error while opening the snapshot file")
+}
@@ -48,6 +48,11 @@ func NewBlockGenerator(t *testing.T, ledgerID string, signTxs bool) (*BlockGener
 	return &BlockGenerator{1, gb.GetHeader().Hash(), signTxs, t}, gb
 }
 
+// NewBlockGeneratorFromGenesis instantiates new BlockGenerator for testing that generates the blocks following the given genesis block
+func NewBlockGeneratorFromGenesis(t *testing.T, gb *common.Block, signTxs bool) *BlockGenerator {
+	return &BlockGenerator{1, gb.GetHeader().Hash(), signTxs, t}
+}
+
 // NextBlock constructs next block in sequence that includes a number of transactions - one per simulationResults
 func (bg *BlockGenerator) NextBlock(simulationResults [][]byte) *common.Block {
 	block := ConstructBlock(bg.t, bg.blockNum, bg.previousHash, simulationResults, bg.signTxs)
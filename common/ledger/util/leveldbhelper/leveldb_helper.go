@@ -164,3 +164,27 @@ func (dbInst *DB) WriteBatch(batch *leveldb.Batch, sync bool) error {
 	}
 	return nil
 }
+
+// SizeOf returns the approximate number of bytes used on the file system by the keys between the startKey (inclusive)
+// and the endKey (exclusive). The data that is not yet flushed from the memtable to the files is not accounted for
+func (dbInst *DB) SizeOf(startKey []byte, endKey []byte) (uint64, error) {
+	dbInst.mutex.RLock()
+	defer dbInst.mutex.RUnlock()
+	sizes, err := dbInst.db.SizeOf([]goleveldbutil.Range{{Start: startKey, Limit: endKey}})
+	if err != nil {
+		return 0, errors.Wrap(err, "error while computing the size of the leveldb range")
+	}
+	return uint64(sizes.Sum()), nil
+}
+
+// Compact compacts the underlying storage for the keys between the startKey (inclusive) and the endKey (exclusive).
+// In particular, the deleted and the overwritten values are discarded. The db remains available for the reads and
+// the writes while the compaction is in progress
+func (dbInst *DB) Compact(startKey []byte, endKey []byte) error {
+	dbInst.mutex.RLock()
+	defer dbInst.mutex.RUnlock()
+	if err := dbInst.db.CompactRange(goleveldbutil.Range{Start: startKey, Limit: endKey}); err != nil {
+		return errors.Wrap(err, "error while compacting the leveldb range")
+	}
+	return nil
+}
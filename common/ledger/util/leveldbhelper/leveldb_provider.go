@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
@@ -27,6 +28,9 @@ import (
 var dbNameKeySep = []byte{0x00}
 var lastKeyIndicator = byte(0x01)
 
+// maxBatchSizeForDeleteAll is the max number of keys deleted in a single batch by the function DeleteAll
+var maxBatchSizeForDeleteAll = 1000
+
 // Provider enables to use a single leveldb as multiple logical leveldbs
 type Provider struct {
 	db        *DB
@@ -79,6 +83,28 @@ func (h *DBHandle) Delete(key []byte, sync bool) error {
 	return h.db.Delete(constructLevelKey(h.dbName, key), sync)
 }
 
+// DeleteAll deletes all the keys that belong to the named db. The keys are deleted in multiple batches and
+// hence, a crash during the deletion may leave a part of the keys in the db
+func (h *DBHandle) DeleteAll() error {
+	itr := h.GetIterator(nil, nil)
+	defer itr.Release()
+	levelBatch := &leveldb.Batch{}
+	for itr.Next() {
+		levelBatch.Delete(itr.Iterator.Key())
+		if levelBatch.Len() < maxBatchSizeForDeleteAll {
+			continue
+		}
+		if err := h.db.WriteBatch(levelBatch, true); err != nil {
+			return err
+		}
+		levelBatch.Reset()
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "internal leveldb error while iterating over the keys of db [%s]", h.dbName)
+	}
+	return h.db.WriteBatch(levelBatch, true)
+}
+
 // WriteBatch writes a batch in an atomic way
 func (h *DBHandle) WriteBatch(batch *UpdateBatch, sync bool) error {
 	if len(batch.KVs) == 0 {
@@ -113,6 +139,35 @@ func (h *DBHandle) GetIterator(startKey []byte, endKey []byte) *Iterator {
 	return &Iterator{h.db.GetIterator(sKey, eKey)}
 }
 
+// DiskUsage returns the approximate number of bytes used on the file system by the keys that belong to the named db
+func (h *DBHandle) DiskUsage() (uint64, error) {
+	sKey, eKey := h.keyRange()
+	return h.db.SizeOf(sKey, eKey)
+}
+
+// Compact compacts the underlying storage for the keys that belong to the named db
+func (h *DBHandle) Compact() error {
+	sKey, eKey := h.keyRange()
+	logger.Debugf("Compacting range [%#v] - [%#v]", sKey, eKey)
+	return h.db.Compact(sKey, eKey)
+}
+
+func (h *DBHandle) keyRange() ([]byte, []byte) {
+	sKey := constructLevelKey(h.dbName, nil)
+	eKey := constructLevelKey(h.dbName, nil)
+	eKey[len(eKey)-1] = lastKeyIndicator
+	return sKey, eKey
+}
+
+// StoreMaintainer is implemented by a store provider that keeps the data of each ledger in a named db
+// of a shared leveldb and supports reporting the disk usage of, and compacting, the data of a ledger
+type StoreMaintainer interface {
+	// DiskUsage returns the approximate number of bytes used on the file system by the data of the given ledger
+	DiskUsage(ledgerID string) (uint64, error)
+	// Compact compacts the data of the given ledger while the store remains available
+	Compact(ledgerID string) error
+}
+
 // UpdateBatch encloses the details of multiple `updates`
 type UpdateBatch struct {
 	KVs map[string][]byte
@@ -54,6 +54,63 @@ func TestIterator(t *testing.T) {
 	checkItrResults(t, itr3, createTestKeys(0, 19), createTestValues("db2", 0, 19))
 }
 
+func TestDeleteAll(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	defer func(size int) { maxBatchSizeForDeleteAll = size }(maxBatchSizeForDeleteAll)
+	maxBatchSizeForDeleteAll = 3
+
+	db1 := p.GetDBHandle("db1")
+	db2 := p.GetDBHandle("db2")
+	for i := 0; i < 10; i++ {
+		db1.Put([]byte(createTestKey(i)), []byte(createTestValue("db1", i)), false)
+		db2.Put([]byte(createTestKey(i)), []byte(createTestValue("db2", i)), false)
+	}
+
+	assert.NoError(t, db1.DeleteAll())
+	itr1 := db1.GetIterator(nil, nil)
+	defer itr1.Release()
+	assert.False(t, itr1.Next())
+	itr2 := db2.GetIterator(nil, nil)
+	defer itr2.Release()
+	checkItrResults(t, itr2, createTestKeys(0, 9), createTestValues("db2", 0, 9))
+
+	// deleting all the keys of an empty db is a no-op
+	assert.NoError(t, db1.DeleteAll())
+}
+
+func TestDiskUsageAndCompact(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	db1 := p.GetDBHandle("db1")
+	db2 := p.GetDBHandle("db2")
+	value := make([]byte, 1024)
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, db1.Put([]byte(createTestKey(i)), value, false))
+	}
+	// the keys are accounted for once they are flushed from the memtable, which the compaction does
+	assert.NoError(t, db1.Compact())
+	usage1, err := db1.DiskUsage()
+	assert.NoError(t, err)
+	assert.True(t, usage1 > 0)
+	usage2, err := db2.DiskUsage()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), usage2)
+
+	assert.NoError(t, db1.DeleteAll())
+	assert.NoError(t, db1.Compact())
+	usageAfterDelete, err := db1.DiskUsage()
+	assert.NoError(t, err)
+	assert.True(t, usageAfterDelete < usage1)
+	itr := db1.GetIterator(nil, nil)
+	defer itr.Release()
+	assert.False(t, itr.Next())
+}
+
 func TestBatchedUpdates(t *testing.T) {
 	env := newTestProviderEnv(t, testDBPath)
 	defer env.cleanup()
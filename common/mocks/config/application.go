@@ -48,6 +48,8 @@ type MockApplicationCapabilities struct {
 	V1_3ValidationRv             bool
 	V2_0ValidationRv             bool
 	FabTokenRv                   bool
+	IndexPhantomProtectionRv     bool
+	StateExpiryRv                bool
 }
 
 func (mac *MockApplicationCapabilities) Supported() error {
@@ -97,3 +99,11 @@ func (mac *MockApplicationCapabilities) V2_0Validation() bool {
 func (mac *MockApplicationCapabilities) FabToken() bool {
 	return mac.FabTokenRv
 }
+
+func (mac *MockApplicationCapabilities) IndexPhantomProtection() bool {
+	return mac.IndexPhantomProtectionRv
+}
+
+func (mac *MockApplicationCapabilities) StateExpiry() bool {
+	return mac.StateExpiryRv
+}
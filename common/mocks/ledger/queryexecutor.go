@@ -67,6 +67,10 @@ func (m *MockQueryExecutor) ExecuteQueryWithMetadata(namespace, query string, me
 	return nil, nil
 }
 
+func (m *MockQueryExecutor) GetStateByIndex(namespace, indexName string, values []interface{}) (commonledger.ResultsIterator, error) {
+	return nil, nil
+}
+
 func (m *MockQueryExecutor) GetPrivateData(namespace, collection, key string) ([]byte, error) {
 	return nil, nil
 }
@@ -87,10 +91,18 @@ func (m *MockQueryExecutor) GetPrivateDataRangeScanIterator(namespace, collectio
 	return nil, nil
 }
 
+func (m *MockQueryExecutor) GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	return nil, nil
+}
+
 func (m *MockQueryExecutor) ExecuteQueryOnPrivateData(namespace, collection, query string) (commonledger.ResultsIterator, error) {
 	return nil, nil
 }
 
+func (m *MockQueryExecutor) ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	return nil, nil
+}
+
 func (m *MockQueryExecutor) Done() {
 }
 
@@ -79,6 +79,7 @@ func (d *defaultACLProvider) initialize() {
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
 	d.pResourcePolicyMap[resources.Cscc_JoinChain] = ""
+	d.pResourcePolicyMap[resources.Cscc_UnjoinChain] = ""
 	d.pResourcePolicyMap[resources.Cscc_GetChannels] = ""
 
 	//c resources
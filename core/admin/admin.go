@@ -13,6 +13,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
@@ -34,13 +35,17 @@ type AccessControlEvaluator interface {
 	Evaluate(signatureSet []*common.SignedData) error
 }
 
+// StateIndexManagerProvider returns the StateIndexManager of the ledger of the given channel
+type StateIndexManagerProvider func(channelID string) (ledger.StateIndexManager, error)
+
 // NewAdminServer creates and returns a Admin service instance.
-func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
+func NewAdminServer(ace AccessControlEvaluator, stateIndexManagers StateIndexManagerProvider) *ServerAdmin {
 	s := &ServerAdmin{
 		v: &validator{
 			ace: ace,
 		},
-		specAtStartup: flogging.Global.Spec(),
+		specAtStartup:      flogging.Global.Spec(),
+		stateIndexManagers: stateIndexManagers,
 	}
 	return s
 }
@@ -49,7 +54,8 @@ func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
 type ServerAdmin struct {
 	v requestValidator
 
-	specAtStartup string
+	specAtStartup      string
+	stateIndexManagers StateIndexManagerProvider
 }
 
 func (s *ServerAdmin) GetStatus(ctx context.Context, env *common.Envelope) (*pb.ServerStatus, error) {
@@ -140,3 +146,80 @@ func (s *ServerAdmin) SetLogSpec(ctx context.Context, env *common.Envelope) (*pb
 	}
 	return logResponse, nil
 }
+
+func (s *ServerAdmin) AddStateIndex(ctx context.Context, env *common.Envelope) (*pb.StateIndexResponse, error) {
+	request, indexManager, err := s.stateIndexRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	stateIndex, err := indexManager.AddStateIndex(request.Chaincode, request.Collection, request.IndexDefinition)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error adding index to chaincode [%s] on channel [%s]: %s", request.Chaincode, request.ChannelId, err)
+	}
+	return &pb.StateIndexResponse{Indexes: []*pb.StateIndex{toStateIndexProto(stateIndex)}}, nil
+}
+
+func (s *ServerAdmin) ListStateIndexes(ctx context.Context, env *common.Envelope) (*pb.StateIndexResponse, error) {
+	request, indexManager, err := s.stateIndexRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	stateIndexes, err := indexManager.ListStateIndexes(request.Chaincode, request.Collection)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error listing indexes of chaincode [%s] on channel [%s]: %s", request.Chaincode, request.ChannelId, err)
+	}
+	response := &pb.StateIndexResponse{}
+	for _, stateIndex := range stateIndexes {
+		response.Indexes = append(response.Indexes, toStateIndexProto(stateIndex))
+	}
+	return response, nil
+}
+
+func (s *ServerAdmin) DropStateIndex(ctx context.Context, env *common.Envelope) (*pb.StateIndexResponse, error) {
+	request, indexManager, err := s.stateIndexRequest(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	if err := indexManager.DropStateIndex(request.Chaincode, request.Collection, request.DesignDoc, request.IndexName); err != nil {
+		return nil, status.Errorf(codes.Internal, "error dropping index [%s] of chaincode [%s] on channel [%s]: %s", request.IndexName, request.Chaincode, request.ChannelId, err)
+	}
+	return &pb.StateIndexResponse{}, nil
+}
+
+// stateIndexRequest validates the given envelope and returns the StateIndexRequest it carries
+// along with the StateIndexManager of the channel targeted by the request
+func (s *ServerAdmin) stateIndexRequest(ctx context.Context, env *common.Envelope) (*pb.StateIndexRequest, ledger.StateIndexManager, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, nil, err
+	}
+	request := op.GetStateIndexReq()
+	if request == nil {
+		return nil, nil, errors.New("request is nil")
+	}
+	if request.ChannelId == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "channel ID must be provided")
+	}
+	if s.stateIndexManagers == nil {
+		return nil, nil, status.Error(codes.Unimplemented, "managing state indexes is not supported by this peer")
+	}
+	indexManager, err := s.stateIndexManagers(request.ChannelId)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.NotFound, "error getting the state index manager of channel [%s]: %s", request.ChannelId, err)
+	}
+	return request, indexManager, nil
+}
+
+func toStateIndexProto(stateIndex *ledger.StateIndex) *pb.StateIndex {
+	buildStatus := pb.StateIndex_READY
+	if stateIndex.Building {
+		buildStatus = pb.StateIndex_BUILDING
+	}
+	return &pb.StateIndex{
+		DesignDoc:     stateIndex.DesignDoc,
+		Name:          stateIndex.Name,
+		Definition:    stateIndex.Definition,
+		BuildStatus:   buildStatus,
+		BuildProgress: int32(stateIndex.BuildProgress),
+	}
+}
@@ -12,9 +12,11 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/testutil"
 	"github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -36,7 +38,7 @@ func (v *mockValidator) validate(ctx context.Context, env *common.Envelope) (*pb
 }
 
 func TestGetStatus(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -46,7 +48,7 @@ func TestGetStatus(t *testing.T) {
 }
 
 func TestStartServer(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	mv.On("validate").Return(nil, nil).Once()
@@ -56,10 +58,10 @@ func TestStartServer(t *testing.T) {
 }
 
 func TestForbidden(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
-	mv.On("validate").Return(nil, accessDenied).Times(7)
+	mv.On("validate").Return(nil, accessDenied).Times(10)
 
 	ctx := context.Background()
 	status, err := adminServer.GetStatus(ctx, nil)
@@ -85,10 +87,122 @@ func TestForbidden(t *testing.T) {
 
 	_, err = adminServer.StartServer(ctx, nil)
 	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.AddStateIndex(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.ListStateIndexes(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+
+	_, err = adminServer.DropStateIndex(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+}
+
+type mockStateIndexManager struct {
+	indexes []*ledger.StateIndex
+	err     error
+}
+
+func (m *mockStateIndexManager) AddStateIndex(chaincodeName, collection string, indexDefinition []byte) (*ledger.StateIndex, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	stateIndex := &ledger.StateIndex{DesignDoc: "indexOwnerDoc", Name: "indexOwner", Definition: string(indexDefinition), Building: true, BuildProgress: 40}
+	m.indexes = append(m.indexes, stateIndex)
+	return stateIndex, nil
+}
+
+func (m *mockStateIndexManager) ListStateIndexes(chaincodeName, collection string) ([]*ledger.StateIndex, error) {
+	return m.indexes, m.err
+}
+
+func (m *mockStateIndexManager) DropStateIndex(chaincodeName, collection, designDoc, indexName string) error {
+	m.indexes = nil
+	return m.err
+}
+
+func TestStateIndexCalls(t *testing.T) {
+	indexManager := &mockStateIndexManager{}
+	adminServer := NewAdminServer(nil, func(channelID string) (ledger.StateIndexManager, error) {
+		if channelID != "mychannel" {
+			return nil, errors.Errorf("ledger [%s] is not opened", channelID)
+		}
+		return indexManager, nil
+	})
+	adminServer.v = &mockValidator{}
+	mv := adminServer.v.(*mockValidator)
+
+	wrapStateIndexRequest := func(r *pb.StateIndexRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{
+			Content: &pb.AdminOperation_StateIndexReq{
+				StateIndexReq: r,
+			},
+		}
+	}
+	ctx := context.Background()
+	indexDefinition := `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner"}`
+
+	mv.On("validate").Return(wrapStateIndexRequest(&pb.StateIndexRequest{
+		ChannelId:       "mychannel",
+		Chaincode:       "marbles",
+		IndexDefinition: []byte(indexDefinition),
+	}), nil).Once()
+	resp, err := adminServer.AddStateIndex(ctx, nil)
+	assert.NoError(t, err)
+	expectedIndex := &pb.StateIndex{
+		DesignDoc:     "indexOwnerDoc",
+		Name:          "indexOwner",
+		Definition:    indexDefinition,
+		BuildStatus:   pb.StateIndex_BUILDING,
+		BuildProgress: 40,
+	}
+	assert.Equal(t, []*pb.StateIndex{expectedIndex}, resp.Indexes)
+
+	indexManager.indexes[0].Building = false
+	indexManager.indexes[0].BuildProgress = 0
+	mv.On("validate").Return(wrapStateIndexRequest(&pb.StateIndexRequest{ChannelId: "mychannel", Chaincode: "marbles"}), nil).Once()
+	resp, err = adminServer.ListStateIndexes(ctx, nil)
+	assert.NoError(t, err)
+	expectedIndex.BuildStatus = pb.StateIndex_READY
+	expectedIndex.BuildProgress = 0
+	assert.Equal(t, []*pb.StateIndex{expectedIndex}, resp.Indexes)
+
+	mv.On("validate").Return(wrapStateIndexRequest(&pb.StateIndexRequest{
+		ChannelId: "mychannel",
+		Chaincode: "marbles",
+		DesignDoc: "indexOwnerDoc",
+		IndexName: "indexOwner",
+	}), nil).Once()
+	resp, err = adminServer.DropStateIndex(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Indexes)
+	assert.Empty(t, indexManager.indexes)
+
+	mv.On("validate").Return(wrapStateIndexRequest(nil), nil).Once()
+	_, err = adminServer.ListStateIndexes(ctx, nil)
+	assert.EqualError(t, err, "request is nil")
+
+	mv.On("validate").Return(wrapStateIndexRequest(&pb.StateIndexRequest{Chaincode: "marbles"}), nil).Once()
+	_, err = adminServer.ListStateIndexes(ctx, nil)
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = channel ID must be provided")
+
+	mv.On("validate").Return(wrapStateIndexRequest(&pb.StateIndexRequest{ChannelId: "otherchannel", Chaincode: "marbles"}), nil).Once()
+	_, err = adminServer.ListStateIndexes(ctx, nil)
+	assert.EqualError(t, err, "rpc error: code = NotFound desc = error getting the state index manager of channel [otherchannel]: ledger [otherchannel] is not opened")
+
+	indexManager.err = errors.New("the state database does not support managing indexes on demand")
+	mv.On("validate").Return(wrapStateIndexRequest(&pb.StateIndexRequest{ChannelId: "mychannel", Chaincode: "marbles", IndexDefinition: []byte(indexDefinition)}), nil).Once()
+	_, err = adminServer.AddStateIndex(ctx, nil)
+	assert.EqualError(t, err, "rpc error: code = Internal desc = error adding index to chaincode [marbles] on channel [mychannel]: the state database does not support managing indexes on demand")
+
+	adminServer.stateIndexManagers = nil
+	mv.On("validate").Return(wrapStateIndexRequest(&pb.StateIndexRequest{ChannelId: "mychannel", Chaincode: "marbles"}), nil).Once()
+	_, err = adminServer.ListStateIndexes(ctx, nil)
+	assert.EqualError(t, err, "rpc error: code = Unimplemented desc = managing state indexes is not supported by this peer")
 }
 
 func TestLoggingCalls(t *testing.T) {
-	adminServer := NewAdminServer(nil)
+	adminServer := NewAdminServer(nil, nil)
 	adminServer.v = &mockValidator{}
 	mv := adminServer.v.(*mockValidator)
 	flogging.MustGetLogger("test")
@@ -86,6 +86,14 @@ func (meqe *mockExecQuerySimulator) GetHistoryForKey(namespace, query string) (c
 	return meqe.commonQuery(namespace, query)
 }
 
+func (meqe *mockExecQuerySimulator) GetPrivateDataHistoryForKey(namespace, collection, query string) (commonledger.ResultsIterator, error) {
+	return meqe.commonQuery(namespace, query)
+}
+
+func (meqe *mockExecQuerySimulator) GetHistoryForKeyWithOptions(namespace, query string, options *ledger.HistoryQueryOptions) (ledger.QueryResultsIterator, error) {
+	return nil, fmt.Errorf("GetHistoryForKeyWithOptions not implemented")
+}
+
 func (meqe *mockExecQuerySimulator) ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error) {
 	return meqe.commonQuery(namespace, query)
 }
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
@@ -27,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -209,6 +211,8 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandlePutState)
 	case pb.ChaincodeMessage_DEL_STATE:
 		go h.HandleTransaction(msg, h.HandleDelState)
+	case pb.ChaincodeMessage_DEL_STATE_BY_RANGE:
+		go h.HandleTransaction(msg, h.HandleDelStateByRange)
 	case pb.ChaincodeMessage_INVOKE_CHAINCODE:
 		go h.HandleTransaction(msg, h.HandleInvokeChaincode)
 
@@ -218,6 +222,8 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetStateByRange)
 	case pb.ChaincodeMessage_GET_QUERY_RESULT:
 		go h.HandleTransaction(msg, h.HandleGetQueryResult)
+	case pb.ChaincodeMessage_GET_STATE_BY_INDEX:
+		go h.HandleTransaction(msg, h.HandleGetStateByIndex)
 	case pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:
 		go h.HandleTransaction(msg, h.HandleGetHistoryForKey)
 	case pb.ChaincodeMessage_QUERY_STATE_NEXT:
@@ -579,6 +585,30 @@ func (h *Handler) checkMetadataCap(msg *pb.ChaincodeMessage) error {
 	return nil
 }
 
+func (h *Handler) checkIndexQueryCap(msg *pb.ChaincodeMessage) error {
+	ac, exists := h.AppConfig.GetApplicationConfig(msg.ChannelId)
+	if !exists {
+		return errors.Errorf("application config does not exist for %s", msg.ChannelId)
+	}
+
+	if !ac.Capabilities().IndexPhantomProtection() {
+		return errors.New("queries on secondary indexes are not enabled")
+	}
+	return nil
+}
+
+func (h *Handler) checkStateExpiryCap(msg *pb.ChaincodeMessage) error {
+	ac, exists := h.AppConfig.GetApplicationConfig(msg.ChannelId)
+	if !exists {
+		return errors.Errorf("application config does not exist for %s", msg.ChannelId)
+	}
+
+	if !ac.Capabilities().StateExpiry() {
+		return errors.New("block-to-live of public state keys is not enabled")
+	}
+	return nil
+}
+
 func errorIfCreatorHasNoReadPermission(chaincodeName, collection string, txContext *TransactionContext) error {
 	rwPermission, err := getReadWritePermission(chaincodeName, collection, txContext)
 	if err != nil {
@@ -762,8 +792,23 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 		if err := errorIfCreatorHasNoReadPermission(chaincodeName, collection, txContext); err != nil {
 			return nil, err
 		}
-		rangeIter, err = txContext.TXSimulator.GetPrivateDataRangeScanIterator(chaincodeName, collection,
-			getStateByRange.StartKey, getStateByRange.EndKey)
+		if isMetadataSetForPagination(metadata) {
+			paginationInfo, err = createPaginationInfoFromMetadata(metadata, totalReturnLimit, pb.ChaincodeMessage_GET_STATE_BY_RANGE)
+			if err != nil {
+				return nil, err
+			}
+			isPaginated = true
+
+			startKey := getStateByRange.StartKey
+			if metadata.Bookmark != "" {
+				startKey = metadata.Bookmark
+			}
+			rangeIter, err = txContext.TXSimulator.GetPrivateDataRangeScanIteratorWithMetadata(chaincodeName, collection,
+				startKey, getStateByRange.EndKey, paginationInfo)
+		} else {
+			rangeIter, err = txContext.TXSimulator.GetPrivateDataRangeScanIterator(chaincodeName, collection,
+				getStateByRange.StartKey, getStateByRange.EndKey)
+		}
 	} else if isMetadataSetForPagination(metadata) {
 		paginationInfo, err = createPaginationInfoFromMetadata(metadata, totalReturnLimit, pb.ChaincodeMessage_GET_STATE_BY_RANGE)
 		if err != nil {
@@ -804,6 +849,54 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payloadBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles query to ledger on a secondary index of the state database
+func (h *Handler) HandleGetStateByIndex(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	// the query is recorded in the read-write set for the validation of its phantom reads,
+	// which the peers of the channel perform only once the capability is enabled
+	err := h.checkIndexQueryCap(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	getStateByIndex := &pb.GetStateByIndex{}
+	err = proto.Unmarshal(msg.Payload, getStateByIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	var values []interface{}
+	if len(getStateByIndex.Values) != 0 {
+		if err := json.Unmarshal(getStateByIndex.Values, &values); err != nil {
+			return nil, errors.Wrap(err, "invalid values for the index")
+		}
+	}
+
+	iterID := h.UUIDGenerator.New()
+	chaincodeName := h.ChaincodeName()
+	totalReturnLimit := calculateTotalReturnLimit(nil)
+
+	indexIter, err := txContext.TXSimulator.GetStateByIndex(chaincodeName, getStateByIndex.IndexName, values)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	txContext.InitializeQueryContext(iterID, indexIter)
+
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, indexIter, iterID, false, totalReturnLimit)
+	if err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, errors.WithStack(err)
+	}
+
+	payloadBytes, err := proto.Marshal(payload)
+	if err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	chaincodeLogger.Debugf("Got keys and values. Sending %s", pb.ChaincodeMessage_RESPONSE)
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: payloadBytes, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles query to ledger for query state next
 func (h *Handler) HandleQueryStateNext(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	queryStateNext := &pb.QueryStateNext{}
@@ -886,7 +979,17 @@ func (h *Handler) HandleGetQueryResult(msg *pb.ChaincodeMessage, txContext *Tran
 		if err := errorIfCreatorHasNoReadPermission(chaincodeName, collection, txContext); err != nil {
 			return nil, err
 		}
-		executeIter, err = txContext.TXSimulator.ExecuteQueryOnPrivateData(chaincodeName, collection, getQueryResult.Query)
+		if isMetadataSetForPagination(metadata) {
+			paginationInfo, err = createPaginationInfoFromMetadata(metadata, totalReturnLimit, pb.ChaincodeMessage_GET_QUERY_RESULT)
+			if err != nil {
+				return nil, err
+			}
+			isPaginated = true
+			executeIter, err = txContext.TXSimulator.ExecuteQueryOnPrivateDataWithMetadata(chaincodeName, collection,
+				getQueryResult.Query, paginationInfo)
+		} else {
+			executeIter, err = txContext.TXSimulator.ExecuteQueryOnPrivateData(chaincodeName, collection, getQueryResult.Query)
+		}
 	} else if isMetadataSetForPagination(metadata) {
 		paginationInfo, err = createPaginationInfoFromMetadata(metadata, totalReturnLimit, pb.ChaincodeMessage_GET_QUERY_RESULT)
 		if err != nil {
@@ -932,15 +1035,51 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
-	historyIter, err := txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	metadata, err := getQueryMetadataFromBytes(getHistoryForKey.Metadata)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
-	totalReturnLimit := calculateTotalReturnLimit(nil)
+	totalReturnLimit := calculateTotalReturnLimit(metadata)
+
+	var historyIter commonledger.ResultsIterator
+	isPaginated := false
+
+	collection := getHistoryForKey.Collection
+	if isCollectionSet(collection) {
+		if txContext.IsInitTransaction {
+			return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+		}
+		if isHistoryQueryOptionsSet(getHistoryForKey) || isMetadataSetForPagination(metadata) {
+			return nil, errors.New("history query options and pagination are not supported for private data")
+		}
+		if err := errorIfCreatorHasNoReadPermission(chaincodeName, collection, txContext); err != nil {
+			return nil, err
+		}
+		historyIter, err = txContext.HistoryQueryExecutor.GetPrivateDataHistoryForKey(chaincodeName, collection, getHistoryForKey.Key)
+	} else if isHistoryQueryOptionsSet(getHistoryForKey) || isMetadataSetForPagination(metadata) {
+		options := &ledger.HistoryQueryOptions{
+			StartBlockNum: getHistoryForKey.StartBlockNum,
+			StartTxNum:    getHistoryForKey.StartTxNum,
+			EndBlockNum:   getHistoryForKey.EndBlockNum,
+			EndTxNum:      getHistoryForKey.EndTxNum,
+			Descending:    getHistoryForKey.Descending,
+		}
+		if isMetadataSetForPagination(metadata) {
+			isPaginated = true
+			options.Limit = totalReturnLimit
+			options.Bookmark = metadata.Bookmark
+		}
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKeyWithOptions(chaincodeName, getHistoryForKey.Key, options)
+	} else {
+		historyIter, err = txContext.HistoryQueryExecutor.GetHistoryForKey(chaincodeName, getHistoryForKey.Key)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 
 	txContext.InitializeQueryContext(iterID, historyIter)
-	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, false, totalReturnLimit)
+	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, historyIter, iterID, isPaginated, totalReturnLimit)
 	if err != nil {
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
@@ -960,6 +1099,11 @@ func isCollectionSet(collection string) bool {
 	return collection != ""
 }
 
+func isHistoryQueryOptionsSet(getHistoryForKey *pb.GetHistoryForKey) bool {
+	return getHistoryForKey.StartBlockNum != 0 || getHistoryForKey.StartTxNum != 0 ||
+		getHistoryForKey.EndBlockNum != 0 || getHistoryForKey.EndTxNum != 0 || getHistoryForKey.Descending
+}
+
 func isMetadataSetForPagination(metadata *pb.QueryMetadata) bool {
 	if metadata == nil {
 		return false
@@ -1085,20 +1229,32 @@ func (h *Handler) HandlePutStateMetadata(msg *pb.ChaincodeMessage, txContext *Tr
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
-	metadata := make(map[string][]byte)
-	metadata[putStateMetadata.Metadata.Metakey] = putStateMetadata.Metadata.Value
-
 	chaincodeName := h.ChaincodeName()
 	collection := putStateMetadata.Collection
 	if isCollectionSet(collection) {
 		if txContext.IsInitTransaction {
 			return nil, errors.New("private data APIs are not allowed in chaincode Init()")
 		}
+		if putStateMetadata.Metadata.Metakey == blockToLiveMetakey {
+			return nil, errors.New("block-to-live can only be set on public state, private data expires as per the BlockToLive of the collection")
+		}
 		if err := errorIfCreatorHasNoWritePermission(chaincodeName, collection, txContext); err != nil {
 			return nil, err
 		}
+		metadata := make(map[string][]byte)
+		metadata[putStateMetadata.Metadata.Metakey] = putStateMetadata.Metadata.Value
 		err = txContext.TXSimulator.SetPrivateDataMetadata(chaincodeName, collection, putStateMetadata.Key, metadata)
 	} else {
+		if putStateMetadata.Metadata.Metakey == blockToLiveMetakey {
+			if err := h.checkStateExpiryCap(msg); err != nil {
+				return nil, err
+			}
+		}
+		var metadata map[string][]byte
+		metadata, err = mergeStateMetadata(chaincodeName, putStateMetadata.Key, putStateMetadata.Metadata, txContext)
+		if err != nil {
+			return nil, err
+		}
 		err = txContext.TXSimulator.SetStateMetadata(chaincodeName, putStateMetadata.Key, metadata)
 	}
 	if err != nil {
@@ -1108,6 +1264,39 @@ func (h *Handler) HandlePutStateMetadata(msg *pb.ChaincodeMessage, txContext *Tr
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// blockToLiveMetakey is the metadata entry that carries the block-to-live of a public state key,
+// encoded as a varint. The ledger purges the key when the block-to-live elapses
+var blockToLiveMetakey = pb.MetaDataKeys_BLOCK_TO_LIVE.String()
+
+// mergeStateMetadata merges the metadata entry with the metadata of the public state key, either set
+// earlier in the transaction or committed, so that setting one entry (e.g., the validation parameter)
+// does not clear the other entries (e.g., the block-to-live). An entry with an empty value is removed
+func mergeStateMetadata(chaincodeName, key string, entry *pb.StateMetadata, txContext *TransactionContext) (map[string][]byte, error) {
+	if entry.Metakey == blockToLiveMetakey && len(entry.Value) != 0 {
+		if _, n := proto.DecodeVarint(entry.Value); n == 0 || n != len(entry.Value) {
+			return nil, errors.Errorf("invalid block-to-live value [%x] for key [%s]", entry.Value, key)
+		}
+	}
+	existing, ok := txContext.GetStateMetadata(key)
+	if !ok {
+		var err error
+		if existing, err = txContext.TXSimulator.GetStateMetadata(chaincodeName, key); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	metadata := make(map[string][]byte, len(existing)+1)
+	for name, value := range existing {
+		metadata[name] = value
+	}
+	if len(entry.Value) == 0 {
+		delete(metadata, entry.Metakey)
+	} else {
+		metadata[entry.Metakey] = entry.Value
+	}
+	txContext.PutStateMetadata(key, metadata)
+	return metadata, nil
+}
+
 func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	delState := &pb.DelState{}
 	err := proto.Unmarshal(msg.Payload, delState)
@@ -1136,6 +1325,56 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// Handles requests to delete all the public state keys in a range
+func (h *Handler) HandleDelStateByRange(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	delStateByRange := &pb.DelStateByRange{}
+	err := proto.Unmarshal(msg.Payload, delStateByRange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	keys, err := h.collectKeysInRange(chaincodeName, delStateByRange.StartKey, delStateByRange.EndKey, txContext)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if err := txContext.TXSimulator.DeleteState(chaincodeName, key); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	chaincodeLogger.Debugf("[%s] deleted %d keys in range [%s, %s)", shorttxid(msg.Txid), len(keys), delStateByRange.StartKey, delStateByRange.EndKey)
+
+	// Send response msg back to chaincode.
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// collectKeysInRange returns the keys in the range, bounded by the total query limit. The range query
+// is recorded in the read set so that a key added to the range concurrently invalidates the transaction
+func (h *Handler) collectKeysInRange(chaincodeName, startKey, endKey string, txContext *TransactionContext) ([]string, error) {
+	iter, err := txContext.TXSimulator.GetStateRangeScanIterator(chaincodeName, startKey, endKey)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer iter.Close()
+
+	totalQueryLimit := ledgerconfig.GetTotalQueryLimit()
+	var keys []string
+	for {
+		result, err := iter.Next()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if result == nil {
+			return keys, nil
+		}
+		if len(keys) == totalQueryLimit {
+			return nil, errors.Errorf("range [%s, %s) contains more than %d keys, which is the total query limit", startKey, endKey, totalQueryLimit)
+		}
+		keys = append(keys, result.(*queryresult.KV).Key)
+	}
+}
+
 // Handles requests that modify ledger state
 func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	chaincodeLogger.Debugf("[%s] C-call-C", shorttxid(msg.Txid))
@@ -20,11 +20,14 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 )
 
 var _ = Describe("Handler", func() {
@@ -90,7 +93,7 @@ var _ = Describe("Handler", func() {
 
 		fakeApplicationConfigRetriever = &fake.ApplicationConfigRetriever{}
 		applicationCapability := &config.MockApplication{
-			CapabilitiesRv: &config.MockApplicationCapabilities{KeyLevelEndorsementRv: true},
+			CapabilitiesRv: &config.MockApplicationCapabilities{KeyLevelEndorsementRv: true, IndexPhantomProtectionRv: true, StateExpiryRv: true},
 		}
 		fakeApplicationConfigRetriever.GetApplicationConfigReturns(applicationCapability, true)
 
@@ -736,6 +739,121 @@ var _ = Describe("Handler", func() {
 					Expect(err).To(MatchError("king-kong"))
 				})
 			})
+
+			Context("when the key has committed metadata", func() {
+				BeforeEach(func() {
+					fakeTxSimulator.GetStateMetadataReturns(map[string][]byte{
+						"BLOCK_TO_LIVE":     proto.EncodeVarint(10),
+						"put-state-metakey": []byte("committed-value"),
+					}, nil)
+				})
+
+				It("merges the entry with the committed metadata", func() {
+					_, err := handler.HandlePutStateMetadata(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTxSimulator.GetStateMetadataCallCount()).To(Equal(1))
+					ccname, key := fakeTxSimulator.GetStateMetadataArgsForCall(0)
+					Expect(ccname).To(Equal("cc-instance-name"))
+					Expect(key).To(Equal("put-state-key"))
+					_, _, value := fakeTxSimulator.SetStateMetadataArgsForCall(0)
+					Expect(value).To(Equal(map[string][]byte{
+						"BLOCK_TO_LIVE":     proto.EncodeVarint(10),
+						"put-state-metakey": []byte("put-state-metadata-value"),
+					}))
+				})
+
+				It("removes an entry that is set with an empty value", func() {
+					request.Metadata = &pb.StateMetadata{Metakey: "BLOCK_TO_LIVE"}
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+
+					_, err = handler.HandlePutStateMetadata(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+					_, _, value := fakeTxSimulator.SetStateMetadataArgsForCall(0)
+					Expect(value).To(Equal(map[string][]byte{
+						"put-state-metakey": []byte("committed-value"),
+					}))
+				})
+
+				It("merges with the metadata set earlier in the transaction", func() {
+					_, err := handler.HandlePutStateMetadata(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					request.Metadata = &pb.StateMetadata{Metakey: "BLOCK_TO_LIVE", Value: proto.EncodeVarint(20)}
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+					_, err = handler.HandlePutStateMetadata(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTxSimulator.GetStateMetadataCallCount()).To(Equal(1))
+					Expect(fakeTxSimulator.SetStateMetadataCallCount()).To(Equal(2))
+					_, _, value := fakeTxSimulator.SetStateMetadataArgsForCall(1)
+					Expect(value).To(Equal(map[string][]byte{
+						"BLOCK_TO_LIVE":     proto.EncodeVarint(20),
+						"put-state-metakey": []byte("put-state-metadata-value"),
+					}))
+				})
+			})
+
+			Context("when GetStateMetadata fails", func() {
+				BeforeEach(func() {
+					fakeTxSimulator.GetStateMetadataReturns(nil, errors.New("mothra"))
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandlePutStateMetadata(incomingMessage, txContext)
+					Expect(err).To(MatchError("mothra"))
+				})
+			})
+
+			Context("when the block-to-live value is invalid", func() {
+				BeforeEach(func() {
+					request.Metadata = &pb.StateMetadata{Metakey: "BLOCK_TO_LIVE", Value: []byte{0xff}}
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandlePutStateMetadata(incomingMessage, txContext)
+					Expect(err).To(MatchError("invalid block-to-live value [ff] for key [put-state-key]"))
+					Expect(fakeTxSimulator.SetStateMetadataCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the block-to-live of public state keys is not enabled", func() {
+				BeforeEach(func() {
+					request.Metadata = &pb.StateMetadata{Metakey: "BLOCK_TO_LIVE", Value: proto.EncodeVarint(10)}
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+
+					applicationCapability := &config.MockApplication{
+						CapabilitiesRv: &config.MockApplicationCapabilities{KeyLevelEndorsementRv: true, StateExpiryRv: false},
+					}
+					fakeApplicationConfigRetriever.GetApplicationConfigReturns(applicationCapability, true)
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandlePutStateMetadata(incomingMessage, txContext)
+					Expect(err).To(MatchError("block-to-live of public state keys is not enabled"))
+					Expect(fakeTxSimulator.SetStateMetadataCallCount()).To(Equal(0))
+				})
+
+				It("allows the other metadata entries", func() {
+					request.Metadata = &pb.StateMetadata{Metakey: "put-state-metakey", Value: []byte("put-state-metadata-value")}
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+
+					_, err = handler.HandlePutStateMetadata(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeTxSimulator.SetStateMetadataCallCount()).To(Equal(1))
+				})
+			})
 		})
 
 		Context("when the collection is provided", func() {
@@ -761,6 +879,20 @@ var _ = Describe("Handler", func() {
 				}))
 			})
 
+			Context("when the block-to-live is set on private data", func() {
+				BeforeEach(func() {
+					request.Metadata = &pb.StateMetadata{Metakey: "BLOCK_TO_LIVE", Value: proto.EncodeVarint(10)}
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandlePutStateMetadata(incomingMessage, txContext)
+					Expect(err).To(MatchError("block-to-live can only be set on public state, private data expires as per the BlockToLive of the collection"))
+				})
+			})
+
 			Context("when SetPrivateDataMetadata fails due to ledger error", func() {
 				BeforeEach(func() {
 					fakeTxSimulator.SetPrivateDataMetadataReturns(errors.New("godzilla"))
@@ -798,6 +930,125 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleDelStateByRange", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			fakeIterator    *mock.QueryResultsIterator
+		)
+
+		BeforeEach(func() {
+			request := &pb.DelStateByRange{
+				StartKey: "start-key",
+				EndKey:   "end-key",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_DEL_STATE_BY_RANGE,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			fakeIterator = &mock.QueryResultsIterator{}
+			fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: "key-1"}, nil)
+			fakeIterator.NextReturnsOnCall(1, &queryresult.KV{Key: "key-2"}, nil)
+			fakeTxSimulator.GetStateRangeScanIteratorReturns(fakeIterator, nil)
+		})
+
+		It("returns a response message", func() {
+			resp, err := handler.HandleDelStateByRange(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+		})
+
+		It("deletes the keys in the range and closes the iterator", func() {
+			_, err := handler.HandleDelStateByRange(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTxSimulator.GetStateRangeScanIteratorCallCount()).To(Equal(1))
+			ccname, startKey, endKey := fakeTxSimulator.GetStateRangeScanIteratorArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(startKey).To(Equal("start-key"))
+			Expect(endKey).To(Equal("end-key"))
+			Expect(fakeIterator.CloseCallCount()).To(Equal(1))
+
+			Expect(fakeTxSimulator.DeleteStateCallCount()).To(Equal(2))
+			ccname, key := fakeTxSimulator.DeleteStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("key-1"))
+			_, key = fakeTxSimulator.DeleteStateArgsForCall(1)
+			Expect(key).To(Equal("key-2"))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleDelStateByRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when getting the range iterator fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateRangeScanIteratorReturns(nil, errors.New("peach"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleDelStateByRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("peach"))
+			})
+		})
+
+		Context("when iterating the range fails", func() {
+			BeforeEach(func() {
+				fakeIterator.NextReturnsOnCall(1, nil, errors.New("plum"))
+			})
+
+			It("returns an error without deleting any key", func() {
+				_, err := handler.HandleDelStateByRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("plum"))
+				Expect(fakeTxSimulator.DeleteStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the range contains more keys than the total query limit", func() {
+			BeforeEach(func() {
+				viper.Set("ledger.state.totalQueryLimit", 3)
+				fakeIterator.NextReturns(&queryresult.KV{Key: "key-n"}, nil)
+			})
+
+			AfterEach(func() {
+				viper.Set("ledger.state.totalQueryLimit", nil)
+			})
+
+			It("returns an error without deleting any key", func() {
+				_, err := handler.HandleDelStateByRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("range [start-key, end-key) contains more than 3 keys, which is the total query limit"))
+				Expect(fakeTxSimulator.DeleteStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when DeleteState returns an error", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.DeleteStateReturns(errors.New("apricot"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleDelStateByRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("apricot"))
+			})
+		})
+	})
+
 	Describe("HandleDelState", func() {
 		var incomingMessage *pb.ChaincodeMessage
 		var request *pb.DelState
@@ -1552,6 +1803,54 @@ var _ = Describe("Handler", func() {
 					Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
 				})
 			})
+
+			Context("and pagination metadata is set", func() {
+				BeforeEach(func() {
+					metadata, err := proto.Marshal(&pb.QueryMetadata{PageSize: 10, Bookmark: "bookmark-key"})
+					Expect(err).NotTo(HaveOccurred())
+					request.Metadata = metadata
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+
+					fakeTxSimulator.GetPrivateDataRangeScanIteratorWithMetadataReturns(fakeIterator, nil)
+				})
+
+				It("calls GetPrivateDataRangeScanIteratorWithMetadata starting from the bookmark", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTxSimulator.GetPrivateDataRangeScanIteratorCallCount()).To(Equal(0))
+					Expect(fakeTxSimulator.GetPrivateDataRangeScanIteratorWithMetadataCallCount()).To(Equal(1))
+					ccname, collection, startKey, endKey, metadata := fakeTxSimulator.GetPrivateDataRangeScanIteratorWithMetadataArgsForCall(0)
+					Expect(ccname).To(Equal("cc-instance-name"))
+					Expect(collection).To(Equal("collection-name"))
+					Expect(startKey).To(Equal("bookmark-key"))
+					Expect(endKey).To(Equal("get-state-end-key"))
+					Expect(metadata).To(Equal(map[string]interface{}{"limit": int32(10)}))
+				})
+
+				It("builds a paginated query response", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+					_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+					Expect(isPaginated).To(BeTrue())
+					Expect(totalReturnLimit).To(Equal(int32(10)))
+				})
+
+				Context("and GetPrivateDataRangeScanIteratorWithMetadata fails", func() {
+					BeforeEach(func() {
+						fakeTxSimulator.GetPrivateDataRangeScanIteratorWithMetadataReturns(nil, errors.New("onion rings"))
+					})
+
+					It("returns the error", func() {
+						_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+						Expect(err).To(MatchError("onion rings"))
+					})
+				})
+			})
 		})
 
 		Context("when unmarshalling the request fails", func() {
@@ -1608,6 +1907,139 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetStateByIndex", func() {
+		var (
+			incomingMessage       *pb.ChaincodeMessage
+			request               *pb.GetStateByIndex
+			expectedResponse      *pb.ChaincodeMessage
+			fakeIterator          *mock.QueryResultsIterator
+			expectedQueryResponse *pb.QueryResponse
+		)
+
+		BeforeEach(func() {
+			request = &pb.GetStateByIndex{
+				IndexName: "index-name",
+				Values:    []byte(`["tom",1]`),
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_BY_INDEX,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			fakeIterator = &mock.QueryResultsIterator{}
+			fakeTxSimulator.GetStateByIndexReturns(fakeIterator, nil)
+
+			expectedQueryResponse = &pb.QueryResponse{
+				Results: nil,
+				HasMore: true,
+				Id:      "query-response-id",
+			}
+			fakeQueryResponseBuilder.BuildQueryResponseReturns(expectedQueryResponse, nil)
+
+			expectedPayload, err := proto.Marshal(expectedQueryResponse)
+			Expect(err).NotTo(HaveOccurred())
+
+			expectedResponse = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				Payload:   expectedPayload,
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("calls GetStateByIndex on the transaction simulator with the decoded values", func() {
+			_, err := handler.HandleGetStateByIndex(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTxSimulator.GetStateByIndexCallCount()).To(Equal(1))
+			ccname, indexName, values := fakeTxSimulator.GetStateByIndexArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(indexName).To(Equal("index-name"))
+			Expect(values).To(Equal([]interface{}{"tom", float64(1)}))
+		})
+
+		It("initializes a query context and returns the response message", func() {
+			resp, err := handler.HandleGetStateByIndex(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(expectedResponse))
+
+			iter := txContext.GetQueryIterator("generated-query-id")
+			Expect(iter).To(Equal(fakeIterator))
+		})
+
+		Context("when getting the app config fails", func() {
+			BeforeEach(func() {
+				fakeApplicationConfigRetriever.GetApplicationConfigReturns(nil, false)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateByIndex(incomingMessage, txContext)
+				Expect(err).To(MatchError("application config does not exist for channel-id"))
+				Expect(fakeTxSimulator.GetStateByIndexCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the queries on secondary indexes are not enabled", func() {
+			BeforeEach(func() {
+				applicationCapability := &config.MockApplication{
+					CapabilitiesRv: &config.MockApplicationCapabilities{IndexPhantomProtectionRv: false},
+				}
+				fakeApplicationConfigRetriever.GetApplicationConfigReturns(applicationCapability, true)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateByIndex(incomingMessage, txContext)
+				Expect(err).To(MatchError("queries on secondary indexes are not enabled"))
+				Expect(fakeTxSimulator.GetStateByIndexCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the values are not a JSON array", func() {
+			BeforeEach(func() {
+				request.Values = []byte(`{"owner":"tom"}`)
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateByIndex(incomingMessage, txContext)
+				Expect(err).To(MatchError(ContainSubstring("invalid values for the index")))
+				Expect(fakeTxSimulator.GetStateByIndexCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when GetStateByIndex fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateByIndexReturns(nil, errors.New("mushrooms"))
+			})
+
+			It("returns the error", func() {
+				_, err := handler.HandleGetStateByIndex(incomingMessage, txContext)
+				Expect(err).To(MatchError("mushrooms"))
+			})
+		})
+
+		Context("when building the query response fails", func() {
+			BeforeEach(func() {
+				fakeQueryResponseBuilder.BuildQueryResponseReturns(nil, errors.New("peppers"))
+			})
+
+			It("cleans up the query context and returns the error", func() {
+				_, err := handler.HandleGetStateByIndex(incomingMessage, txContext)
+				Expect(err).To(MatchError("peppers"))
+
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).To(BeNil())
+			})
+		})
+	})
+
 	Describe("HandleQueryStateNext", func() {
 		var (
 			fakeIterator          *mock.QueryResultsIterator
@@ -1865,6 +2297,45 @@ var _ = Describe("Handler", func() {
 				fakeTxSimulator.ExecuteQueryOnPrivateDataReturns(fakeIterator, nil)
 			})
 
+			Context("and pagination metadata is set", func() {
+				BeforeEach(func() {
+					metadata, err := proto.Marshal(&pb.QueryMetadata{PageSize: 10, Bookmark: "query-bookmark"})
+					Expect(err).NotTo(HaveOccurred())
+					request.Metadata = metadata
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+
+					fakeTxSimulator.ExecuteQueryOnPrivateDataWithMetadataReturns(fakeIterator, nil)
+				})
+
+				It("calls ExecuteQueryOnPrivateDataWithMetadata with the page size and the bookmark", func() {
+					_, err := handler.HandleGetQueryResult(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeTxSimulator.ExecuteQueryOnPrivateDataCallCount()).To(Equal(0))
+					Expect(fakeTxSimulator.ExecuteQueryOnPrivateDataWithMetadataCallCount()).To(Equal(1))
+					ccname, collection, query, metadata := fakeTxSimulator.ExecuteQueryOnPrivateDataWithMetadataArgsForCall(0)
+					Expect(ccname).To(Equal("cc-instance-name"))
+					Expect(collection).To(Equal("collection-name"))
+					Expect(query).To(Equal("query-result"))
+					Expect(metadata).To(Equal(map[string]interface{}{
+						"bookmark": "query-bookmark",
+						"limit":    int32(10),
+					}))
+				})
+
+				It("builds a paginated query response", func() {
+					_, err := handler.HandleGetQueryResult(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+					_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+					Expect(isPaginated).To(BeTrue())
+					Expect(totalReturnLimit).To(Equal(int32(10)))
+				})
+			})
+
 			It("calls ExecuteQueryOnPrivateDataon the transaction simulator", func() {
 				_, err := handler.HandleGetQueryResult(incomingMessage, txContext)
 				Expect(err).NotTo(HaveOccurred())
@@ -2066,6 +2537,164 @@ var _ = Describe("Handler", func() {
 			Expect(iterID).To(Equal("generated-query-id"))
 		})
 
+		Context("when collection is set", func() {
+			BeforeEach(func() {
+				request.Collection = "collection-name"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeCollectionStore.RetrieveReadWritePermissionReturns(true, false, nil)
+				fakeHistoryQueryExecutor.GetPrivateDataHistoryForKeyReturns(fakeIterator, nil)
+			})
+
+			It("calls GetPrivateDataHistoryForKey on the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyCallCount()).To(Equal(0))
+				Expect(fakeHistoryQueryExecutor.GetPrivateDataHistoryForKeyCallCount()).To(Equal(1))
+				ccname, collection, key := fakeHistoryQueryExecutor.GetPrivateDataHistoryForKeyArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(collection).To(Equal("collection-name"))
+				Expect(key).To(Equal("history-key"))
+
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).To(Equal(fakeIterator))
+			})
+
+			Context("and the history query executor fails", func() {
+				BeforeEach(func() {
+					fakeHistoryQueryExecutor.GetPrivateDataHistoryForKeyReturns(nil, errors.New("calzone"))
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("calzone"))
+				})
+			})
+
+			Context("and the creator does not have read access permission", func() {
+				BeforeEach(func() {
+					fakeCollectionStore.RetrieveReadWritePermissionReturns(false, false, nil)
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("tx creator does not have read access" +
+						" permission on privatedata in chaincodeName:cc-instance-name" +
+						" collectionName: collection-name"))
+				})
+			})
+
+			Context("and the transaction is an Init transaction", func() {
+				BeforeEach(func() {
+					txContext.IsInitTransaction = true
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+				})
+			})
+
+			Context("and history query options are set", func() {
+				BeforeEach(func() {
+					request.Descending = true
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("history query options and pagination are not supported for private data"))
+				})
+			})
+		})
+
+		Context("when history query options are set", func() {
+			BeforeEach(func() {
+				request.StartBlockNum = 2
+				request.StartTxNum = 1
+				request.EndBlockNum = 5
+				request.Descending = true
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsReturns(fakeIterator, nil)
+			})
+
+			It("calls GetHistoryForKeyWithOptions on the history query executor", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyCallCount()).To(Equal(0))
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsCallCount()).To(Equal(1))
+				ccname, key, options := fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsArgsForCall(0)
+				Expect(ccname).To(Equal("cc-instance-name"))
+				Expect(key).To(Equal("history-key"))
+				Expect(options).To(Equal(&ledger.HistoryQueryOptions{
+					StartBlockNum: 2,
+					StartTxNum:    1,
+					EndBlockNum:   5,
+					Descending:    true,
+				}))
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, iter, _, isPaginated, _ := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(iter).To(Equal(fakeIterator))
+				Expect(isPaginated).To(BeFalse())
+			})
+
+			Context("and the history query executor fails", func() {
+				BeforeEach(func() {
+					fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsReturns(nil, errors.New("anchovies"))
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+					Expect(err).To(MatchError("anchovies"))
+				})
+			})
+		})
+
+		Context("when pagination metadata is set", func() {
+			BeforeEach(func() {
+				metadata, err := proto.Marshal(&pb.QueryMetadata{PageSize: 5, Bookmark: "3:1"})
+				Expect(err).NotTo(HaveOccurred())
+				request.Metadata = metadata
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsReturns(fakeIterator, nil)
+			})
+
+			It("calls GetHistoryForKeyWithOptions with the page size and the bookmark", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsCallCount()).To(Equal(1))
+				_, _, options := fakeHistoryQueryExecutor.GetHistoryForKeyWithOptionsArgsForCall(0)
+				Expect(options).To(Equal(&ledger.HistoryQueryOptions{
+					Limit:    5,
+					Bookmark: "3:1",
+				}))
+			})
+
+			It("builds a paginated query response", func() {
+				_, err := handler.HandleGetHistoryForKey(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeQueryResponseBuilder.BuildQueryResponseCallCount()).To(Equal(1))
+				_, _, _, isPaginated, totalReturnLimit := fakeQueryResponseBuilder.BuildQueryResponseArgsForCall(0)
+				Expect(isPaginated).To(BeTrue())
+				Expect(totalReturnLimit).To(Equal(int32(5)))
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
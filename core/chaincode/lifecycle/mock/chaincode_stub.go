@@ -94,6 +94,20 @@ type ChaincodeStub struct {
 		result1 []byte
 		result2 error
 	}
+	GetStateByIndexStub        func(indexName string, values ...interface{}) (shim.StateQueryIteratorInterface, error)
+	getStateByIndexMutex       sync.RWMutex
+	getStateByIndexArgsForCall []struct {
+		indexName string
+		values    []interface{}
+	}
+	getStateByIndexReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
+	getStateByIndexReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
 	PutStateStub        func(key string, value []byte) error
 	putStateMutex       sync.RWMutex
 	putStateArgsForCall []struct {
@@ -117,6 +131,55 @@ type ChaincodeStub struct {
 	delStateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DelStateByRangeStub        func(startKey string, endKey string) error
+	delStateByRangeMutex       sync.RWMutex
+	delStateByRangeArgsForCall []struct {
+		startKey string
+		endKey   string
+	}
+	delStateByRangeReturns struct {
+		result1 error
+	}
+	delStateByRangeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DelStateByPartialCompositeKeyStub        func(objectType string, keys []string) error
+	delStateByPartialCompositeKeyMutex       sync.RWMutex
+	delStateByPartialCompositeKeyArgsForCall []struct {
+		objectType string
+		keys       []string
+	}
+	delStateByPartialCompositeKeyReturns struct {
+		result1 error
+	}
+	delStateByPartialCompositeKeyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetStateExpiryStub        func(key string, blocksToLive uint64) error
+	setStateExpiryMutex       sync.RWMutex
+	setStateExpiryArgsForCall []struct {
+		key          string
+		blocksToLive uint64
+	}
+	setStateExpiryReturns struct {
+		result1 error
+	}
+	setStateExpiryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetStateExpiryStub        func(key string) (uint64, error)
+	getStateExpiryMutex       sync.RWMutex
+	getStateExpiryArgsForCall []struct {
+		key string
+	}
+	getStateExpiryReturns struct {
+		result1 uint64
+		result2 error
+	}
+	getStateExpiryReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
 	SetStateValidationParameterStub        func(key string, ep []byte) error
 	setStateValidationParameterMutex       sync.RWMutex
 	setStateValidationParameterArgsForCall []struct {
@@ -278,6 +341,24 @@ type ChaincodeStub struct {
 		result1 shim.HistoryQueryIteratorInterface
 		result2 error
 	}
+	GetHistoryForKeyWithPaginationStub        func(key string, options *shim.HistoryQueryOptions, pageSize int32, bookmark string) (shim.HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+	getHistoryForKeyWithPaginationMutex       sync.RWMutex
+	getHistoryForKeyWithPaginationArgsForCall []struct {
+		key      string
+		options  *shim.HistoryQueryOptions
+		pageSize int32
+		bookmark string
+	}
+	getHistoryForKeyWithPaginationReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
+	getHistoryForKeyWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataStub        func(collection, key string) ([]byte, error)
 	getPrivateDataMutex       sync.RWMutex
 	getPrivateDataArgsForCall []struct {
@@ -373,6 +454,25 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetPrivateDataByRangeWithPaginationStub        func(collection, startKey, endKey string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+	getPrivateDataByRangeWithPaginationMutex       sync.RWMutex
+	getPrivateDataByRangeWithPaginationArgsForCall []struct {
+		collection string
+		startKey   string
+		endKey     string
+		pageSize   int32
+		bookmark   string
+	}
+	getPrivateDataByRangeWithPaginationReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
+	getPrivateDataByRangeWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataByPartialCompositeKeyStub        func(collection, objectType string, keys []string) (shim.StateQueryIteratorInterface, error)
 	getPrivateDataByPartialCompositeKeyMutex       sync.RWMutex
 	getPrivateDataByPartialCompositeKeyArgsForCall []struct {
@@ -388,6 +488,25 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetPrivateDataByPartialCompositeKeyWithPaginationStub        func(collection string, objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+	getPrivateDataByPartialCompositeKeyWithPaginationMutex       sync.RWMutex
+	getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall []struct {
+		collection string
+		objectType string
+		keys       []string
+		pageSize   int32
+		bookmark   string
+	}
+	getPrivateDataByPartialCompositeKeyWithPaginationReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
+	getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataQueryResultStub        func(collection, query string) (shim.StateQueryIteratorInterface, error)
 	getPrivateDataQueryResultMutex       sync.RWMutex
 	getPrivateDataQueryResultArgsForCall []struct {
@@ -402,6 +521,38 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetPrivateDataQueryResultWithPaginationStub        func(collection string, query string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+	getPrivateDataQueryResultWithPaginationMutex       sync.RWMutex
+	getPrivateDataQueryResultWithPaginationArgsForCall []struct {
+		collection string
+		query      string
+		pageSize   int32
+		bookmark   string
+	}
+	getPrivateDataQueryResultWithPaginationReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
+	getPrivateDataQueryResultWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}
+	GetPrivateDataHistoryForKeyStub        func(collection, key string) (shim.HistoryQueryIteratorInterface, error)
+	getPrivateDataHistoryForKeyMutex       sync.RWMutex
+	getPrivateDataHistoryForKeyArgsForCall []struct {
+		collection string
+		key        string
+	}
+	getPrivateDataHistoryForKeyReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	getPrivateDataHistoryForKeyReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
 	GetCreatorStub        func() ([]byte, error)
 	getCreatorMutex       sync.RWMutex
 	getCreatorArgsForCall []struct{}
@@ -834,6 +985,58 @@ func (fake *ChaincodeStub) GetStateReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateByIndex(indexName string, values ...interface{}) (shim.StateQueryIteratorInterface, error) {
+	fake.getStateByIndexMutex.Lock()
+	ret, specificReturn := fake.getStateByIndexReturnsOnCall[len(fake.getStateByIndexArgsForCall)]
+	fake.getStateByIndexArgsForCall = append(fake.getStateByIndexArgsForCall, struct {
+		indexName string
+		values    []interface{}
+	}{indexName, values})
+	fake.recordInvocation("GetStateByIndex", []interface{}{indexName, values})
+	fake.getStateByIndexMutex.Unlock()
+	if fake.GetStateByIndexStub != nil {
+		return fake.GetStateByIndexStub(indexName, values...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStateByIndexReturns.result1, fake.getStateByIndexReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateByIndexCallCount() int {
+	fake.getStateByIndexMutex.RLock()
+	defer fake.getStateByIndexMutex.RUnlock()
+	return len(fake.getStateByIndexArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateByIndexArgsForCall(i int) (string, []interface{}) {
+	fake.getStateByIndexMutex.RLock()
+	defer fake.getStateByIndexMutex.RUnlock()
+	return fake.getStateByIndexArgsForCall[i].indexName, fake.getStateByIndexArgsForCall[i].values
+}
+
+func (fake *ChaincodeStub) GetStateByIndexReturns(result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.GetStateByIndexStub = nil
+	fake.getStateByIndexReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateByIndexReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.GetStateByIndexStub = nil
+	if fake.getStateByIndexReturnsOnCall == nil {
+		fake.getStateByIndexReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getStateByIndexReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) PutState(key string, value []byte) error {
 	var valueCopy []byte
 	if value != nil {
@@ -936,6 +1139,209 @@ func (fake *ChaincodeStub) DelStateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *ChaincodeStub) DelStateByRange(startKey string, endKey string) error {
+	fake.delStateByRangeMutex.Lock()
+	ret, specificReturn := fake.delStateByRangeReturnsOnCall[len(fake.delStateByRangeArgsForCall)]
+	fake.delStateByRangeArgsForCall = append(fake.delStateByRangeArgsForCall, struct {
+		startKey string
+		endKey   string
+	}{startKey, endKey})
+	fake.recordInvocation("DelStateByRange", []interface{}{startKey, endKey})
+	fake.delStateByRangeMutex.Unlock()
+	if fake.DelStateByRangeStub != nil {
+		return fake.DelStateByRangeStub(startKey, endKey)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.delStateByRangeReturns.result1
+}
+
+func (fake *ChaincodeStub) DelStateByRangeCallCount() int {
+	fake.delStateByRangeMutex.RLock()
+	defer fake.delStateByRangeMutex.RUnlock()
+	return len(fake.delStateByRangeArgsForCall)
+}
+
+func (fake *ChaincodeStub) DelStateByRangeArgsForCall(i int) (string, string) {
+	fake.delStateByRangeMutex.RLock()
+	defer fake.delStateByRangeMutex.RUnlock()
+	return fake.delStateByRangeArgsForCall[i].startKey, fake.delStateByRangeArgsForCall[i].endKey
+}
+
+func (fake *ChaincodeStub) DelStateByRangeReturns(result1 error) {
+	fake.DelStateByRangeStub = nil
+	fake.delStateByRangeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) DelStateByRangeReturnsOnCall(i int, result1 error) {
+	fake.DelStateByRangeStub = nil
+	if fake.delStateByRangeReturnsOnCall == nil {
+		fake.delStateByRangeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.delStateByRangeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKey(objectType string, keys []string) error {
+	var keysCopy []string
+	if keys != nil {
+		keysCopy = make([]string, len(keys))
+		copy(keysCopy, keys)
+	}
+	fake.delStateByPartialCompositeKeyMutex.Lock()
+	ret, specificReturn := fake.delStateByPartialCompositeKeyReturnsOnCall[len(fake.delStateByPartialCompositeKeyArgsForCall)]
+	fake.delStateByPartialCompositeKeyArgsForCall = append(fake.delStateByPartialCompositeKeyArgsForCall, struct {
+		objectType string
+		keys       []string
+	}{objectType, keysCopy})
+	fake.recordInvocation("DelStateByPartialCompositeKey", []interface{}{objectType, keysCopy})
+	fake.delStateByPartialCompositeKeyMutex.Unlock()
+	if fake.DelStateByPartialCompositeKeyStub != nil {
+		return fake.DelStateByPartialCompositeKeyStub(objectType, keys)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.delStateByPartialCompositeKeyReturns.result1
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKeyCallCount() int {
+	fake.delStateByPartialCompositeKeyMutex.RLock()
+	defer fake.delStateByPartialCompositeKeyMutex.RUnlock()
+	return len(fake.delStateByPartialCompositeKeyArgsForCall)
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKeyArgsForCall(i int) (string, []string) {
+	fake.delStateByPartialCompositeKeyMutex.RLock()
+	defer fake.delStateByPartialCompositeKeyMutex.RUnlock()
+	return fake.delStateByPartialCompositeKeyArgsForCall[i].objectType, fake.delStateByPartialCompositeKeyArgsForCall[i].keys
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKeyReturns(result1 error) {
+	fake.DelStateByPartialCompositeKeyStub = nil
+	fake.delStateByPartialCompositeKeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKeyReturnsOnCall(i int, result1 error) {
+	fake.DelStateByPartialCompositeKeyStub = nil
+	if fake.delStateByPartialCompositeKeyReturnsOnCall == nil {
+		fake.delStateByPartialCompositeKeyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.delStateByPartialCompositeKeyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) SetStateExpiry(key string, blocksToLive uint64) error {
+	fake.setStateExpiryMutex.Lock()
+	ret, specificReturn := fake.setStateExpiryReturnsOnCall[len(fake.setStateExpiryArgsForCall)]
+	fake.setStateExpiryArgsForCall = append(fake.setStateExpiryArgsForCall, struct {
+		key          string
+		blocksToLive uint64
+	}{key, blocksToLive})
+	fake.recordInvocation("SetStateExpiry", []interface{}{key, blocksToLive})
+	fake.setStateExpiryMutex.Unlock()
+	if fake.SetStateExpiryStub != nil {
+		return fake.SetStateExpiryStub(key, blocksToLive)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setStateExpiryReturns.result1
+}
+
+func (fake *ChaincodeStub) SetStateExpiryCallCount() int {
+	fake.setStateExpiryMutex.RLock()
+	defer fake.setStateExpiryMutex.RUnlock()
+	return len(fake.setStateExpiryArgsForCall)
+}
+
+func (fake *ChaincodeStub) SetStateExpiryArgsForCall(i int) (string, uint64) {
+	fake.setStateExpiryMutex.RLock()
+	defer fake.setStateExpiryMutex.RUnlock()
+	return fake.setStateExpiryArgsForCall[i].key, fake.setStateExpiryArgsForCall[i].blocksToLive
+}
+
+func (fake *ChaincodeStub) SetStateExpiryReturns(result1 error) {
+	fake.SetStateExpiryStub = nil
+	fake.setStateExpiryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) SetStateExpiryReturnsOnCall(i int, result1 error) {
+	fake.SetStateExpiryStub = nil
+	if fake.setStateExpiryReturnsOnCall == nil {
+		fake.setStateExpiryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setStateExpiryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) GetStateExpiry(key string) (uint64, error) {
+	fake.getStateExpiryMutex.Lock()
+	ret, specificReturn := fake.getStateExpiryReturnsOnCall[len(fake.getStateExpiryArgsForCall)]
+	fake.getStateExpiryArgsForCall = append(fake.getStateExpiryArgsForCall, struct {
+		key string
+	}{key})
+	fake.recordInvocation("GetStateExpiry", []interface{}{key})
+	fake.getStateExpiryMutex.Unlock()
+	if fake.GetStateExpiryStub != nil {
+		return fake.GetStateExpiryStub(key)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStateExpiryReturns.result1, fake.getStateExpiryReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateExpiryCallCount() int {
+	fake.getStateExpiryMutex.RLock()
+	defer fake.getStateExpiryMutex.RUnlock()
+	return len(fake.getStateExpiryArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateExpiryArgsForCall(i int) string {
+	fake.getStateExpiryMutex.RLock()
+	defer fake.getStateExpiryMutex.RUnlock()
+	return fake.getStateExpiryArgsForCall[i].key
+}
+
+func (fake *ChaincodeStub) GetStateExpiryReturns(result1 uint64, result2 error) {
+	fake.GetStateExpiryStub = nil
+	fake.getStateExpiryReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateExpiryReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.GetStateExpiryStub = nil
+	if fake.getStateExpiryReturnsOnCall == nil {
+		fake.getStateExpiryReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.getStateExpiryReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) SetStateValidationParameter(key string, ep []byte) error {
 	var epCopy []byte
 	if ep != nil {
@@ -1538,6 +1944,63 @@ func (fake *ChaincodeStub) GetHistoryForKeyReturnsOnCall(i int, result1 shim.His
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetHistoryForKeyWithPagination(key string, options *shim.HistoryQueryOptions, pageSize int32, bookmark string) (shim.HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithPaginationReturnsOnCall[len(fake.getHistoryForKeyWithPaginationArgsForCall)]
+	fake.getHistoryForKeyWithPaginationArgsForCall = append(fake.getHistoryForKeyWithPaginationArgsForCall, struct {
+		key      string
+		options  *shim.HistoryQueryOptions
+		pageSize int32
+		bookmark string
+	}{key, options, pageSize, bookmark})
+	fake.recordInvocation("GetHistoryForKeyWithPagination", []interface{}{key, options, pageSize, bookmark})
+	fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	if fake.GetHistoryForKeyWithPaginationStub != nil {
+		return fake.GetHistoryForKeyWithPaginationStub(key, options, pageSize, bookmark)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getHistoryForKeyWithPaginationReturns.result1, fake.getHistoryForKeyWithPaginationReturns.result2, fake.getHistoryForKeyWithPaginationReturns.result3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationCallCount() int {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationArgsForCall(i int) (string, *shim.HistoryQueryOptions, int32, string) {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	return fake.getHistoryForKeyWithPaginationArgsForCall[i].key, fake.getHistoryForKeyWithPaginationArgsForCall[i].options, fake.getHistoryForKeyWithPaginationArgsForCall[i].pageSize, fake.getHistoryForKeyWithPaginationArgsForCall[i].bookmark
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturns(result1 shim.HistoryQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	fake.getHistoryForKeyWithPaginationReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	if fake.getHistoryForKeyWithPaginationReturnsOnCall == nil {
+		fake.getHistoryForKeyWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 *pb.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getHistoryForKeyWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateData(collection string, key string) ([]byte, error) {
 	fake.getPrivateDataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
@@ -1906,6 +2369,64 @@ func (fake *ChaincodeStub) GetPrivateDataByRangeReturnsOnCall(i int, result1 shi
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPagination(collection string, startKey string, endKey string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	fake.getPrivateDataByRangeWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataByRangeWithPaginationReturnsOnCall[len(fake.getPrivateDataByRangeWithPaginationArgsForCall)]
+	fake.getPrivateDataByRangeWithPaginationArgsForCall = append(fake.getPrivateDataByRangeWithPaginationArgsForCall, struct {
+		collection string
+		startKey   string
+		endKey     string
+		pageSize   int32
+		bookmark   string
+	}{collection, startKey, endKey, pageSize, bookmark})
+	fake.recordInvocation("GetPrivateDataByRangeWithPagination", []interface{}{collection, startKey, endKey, pageSize, bookmark})
+	fake.getPrivateDataByRangeWithPaginationMutex.Unlock()
+	if fake.GetPrivateDataByRangeWithPaginationStub != nil {
+		return fake.GetPrivateDataByRangeWithPaginationStub(collection, startKey, endKey, pageSize, bookmark)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getPrivateDataByRangeWithPaginationReturns.result1, fake.getPrivateDataByRangeWithPaginationReturns.result2, fake.getPrivateDataByRangeWithPaginationReturns.result3
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPaginationCallCount() int {
+	fake.getPrivateDataByRangeWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByRangeWithPaginationMutex.RUnlock()
+	return len(fake.getPrivateDataByRangeWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPaginationArgsForCall(i int) (string, string, string, int32, string) {
+	fake.getPrivateDataByRangeWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByRangeWithPaginationMutex.RUnlock()
+	return fake.getPrivateDataByRangeWithPaginationArgsForCall[i].collection, fake.getPrivateDataByRangeWithPaginationArgsForCall[i].startKey, fake.getPrivateDataByRangeWithPaginationArgsForCall[i].endKey, fake.getPrivateDataByRangeWithPaginationArgsForCall[i].pageSize, fake.getPrivateDataByRangeWithPaginationArgsForCall[i].bookmark
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPaginationReturns(result1 shim.StateQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetPrivateDataByRangeWithPaginationStub = nil
+	fake.getPrivateDataByRangeWithPaginationReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPaginationReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetPrivateDataByRangeWithPaginationStub = nil
+	if fake.getPrivateDataByRangeWithPaginationReturnsOnCall == nil {
+		fake.getPrivateDataByRangeWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 *pb.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getPrivateDataByRangeWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKey(collection string, objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
 	var keysCopy []string
 	if keys != nil {
@@ -1964,6 +2485,69 @@ func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyReturnsOnCall(i in
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPagination(collection string, objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	var keysCopy []string
+	if keys != nil {
+		keysCopy = make([]string, len(keys))
+		copy(keysCopy, keys)
+	}
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall[len(fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall)]
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall = append(fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall, struct {
+		collection string
+		objectType string
+		keys       []string
+		pageSize   int32
+		bookmark   string
+	}{collection, objectType, keysCopy, pageSize, bookmark})
+	fake.recordInvocation("GetPrivateDataByPartialCompositeKeyWithPagination", []interface{}{collection, objectType, keysCopy, pageSize, bookmark})
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Unlock()
+	if fake.GetPrivateDataByPartialCompositeKeyWithPaginationStub != nil {
+		return fake.GetPrivateDataByPartialCompositeKeyWithPaginationStub(collection, objectType, keys, pageSize, bookmark)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getPrivateDataByPartialCompositeKeyWithPaginationReturns.result1, fake.getPrivateDataByPartialCompositeKeyWithPaginationReturns.result2, fake.getPrivateDataByPartialCompositeKeyWithPaginationReturns.result3
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPaginationCallCount() int {
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RUnlock()
+	return len(fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPaginationArgsForCall(i int) (string, string, []string, int32, string) {
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RUnlock()
+	return fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall[i].collection, fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall[i].objectType, fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall[i].keys, fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall[i].pageSize, fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall[i].bookmark
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPaginationReturns(result1 shim.StateQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetPrivateDataByPartialCompositeKeyWithPaginationStub = nil
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetPrivateDataByPartialCompositeKeyWithPaginationStub = nil
+	if fake.getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall == nil {
+		fake.getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 *pb.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateDataQueryResult(collection string, query string) (shim.StateQueryIteratorInterface, error) {
 	fake.getPrivateDataQueryResultMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataQueryResultReturnsOnCall[len(fake.getPrivateDataQueryResultArgsForCall)]
@@ -2016,6 +2600,115 @@ func (fake *ChaincodeStub) GetPrivateDataQueryResultReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPagination(collection string, query string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataQueryResultWithPaginationReturnsOnCall[len(fake.getPrivateDataQueryResultWithPaginationArgsForCall)]
+	fake.getPrivateDataQueryResultWithPaginationArgsForCall = append(fake.getPrivateDataQueryResultWithPaginationArgsForCall, struct {
+		collection string
+		query      string
+		pageSize   int32
+		bookmark   string
+	}{collection, query, pageSize, bookmark})
+	fake.recordInvocation("GetPrivateDataQueryResultWithPagination", []interface{}{collection, query, pageSize, bookmark})
+	fake.getPrivateDataQueryResultWithPaginationMutex.Unlock()
+	if fake.GetPrivateDataQueryResultWithPaginationStub != nil {
+		return fake.GetPrivateDataQueryResultWithPaginationStub(collection, query, pageSize, bookmark)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getPrivateDataQueryResultWithPaginationReturns.result1, fake.getPrivateDataQueryResultWithPaginationReturns.result2, fake.getPrivateDataQueryResultWithPaginationReturns.result3
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationCallCount() int {
+	fake.getPrivateDataQueryResultWithPaginationMutex.RLock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.RUnlock()
+	return len(fake.getPrivateDataQueryResultWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationArgsForCall(i int) (string, string, int32, string) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.RLock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.RUnlock()
+	return fake.getPrivateDataQueryResultWithPaginationArgsForCall[i].collection, fake.getPrivateDataQueryResultWithPaginationArgsForCall[i].query, fake.getPrivateDataQueryResultWithPaginationArgsForCall[i].pageSize, fake.getPrivateDataQueryResultWithPaginationArgsForCall[i].bookmark
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationReturns(result1 shim.StateQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetPrivateDataQueryResultWithPaginationStub = nil
+	fake.getPrivateDataQueryResultWithPaginationReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 *pb.QueryResponseMetadata, result3 error) {
+	fake.GetPrivateDataQueryResultWithPaginationStub = nil
+	if fake.getPrivateDataQueryResultWithPaginationReturnsOnCall == nil {
+		fake.getPrivateDataQueryResultWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 *pb.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getPrivateDataQueryResultWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *pb.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKey(collection string, key string) (shim.HistoryQueryIteratorInterface, error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataHistoryForKeyReturnsOnCall[len(fake.getPrivateDataHistoryForKeyArgsForCall)]
+	fake.getPrivateDataHistoryForKeyArgsForCall = append(fake.getPrivateDataHistoryForKeyArgsForCall, struct {
+		collection string
+		key        string
+	}{collection, key})
+	fake.recordInvocation("GetPrivateDataHistoryForKey", []interface{}{collection, key})
+	fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	if fake.GetPrivateDataHistoryForKeyStub != nil {
+		return fake.GetPrivateDataHistoryForKeyStub(collection, key)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getPrivateDataHistoryForKeyReturns.result1, fake.getPrivateDataHistoryForKeyReturns.result2
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyCallCount() int {
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
+	return len(fake.getPrivateDataHistoryForKeyArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyArgsForCall(i int) (string, string) {
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
+	return fake.getPrivateDataHistoryForKeyArgsForCall[i].collection, fake.getPrivateDataHistoryForKeyArgsForCall[i].key
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyReturns(result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.GetPrivateDataHistoryForKeyStub = nil
+	fake.getPrivateDataHistoryForKeyReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.GetPrivateDataHistoryForKeyStub = nil
+	if fake.getPrivateDataHistoryForKeyReturnsOnCall == nil {
+		fake.getPrivateDataHistoryForKeyReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getPrivateDataHistoryForKeyReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetCreator() ([]byte, error) {
 	fake.getCreatorMutex.Lock()
 	ret, specificReturn := fake.getCreatorReturnsOnCall[len(fake.getCreatorArgsForCall)]
@@ -2344,10 +3037,20 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.invokeChaincodeMutex.RUnlock()
 	fake.getStateMutex.RLock()
 	defer fake.getStateMutex.RUnlock()
+	fake.getStateByIndexMutex.RLock()
+	defer fake.getStateByIndexMutex.RUnlock()
 	fake.putStateMutex.RLock()
 	defer fake.putStateMutex.RUnlock()
 	fake.delStateMutex.RLock()
 	defer fake.delStateMutex.RUnlock()
+	fake.delStateByRangeMutex.RLock()
+	defer fake.delStateByRangeMutex.RUnlock()
+	fake.delStateByPartialCompositeKeyMutex.RLock()
+	defer fake.delStateByPartialCompositeKeyMutex.RUnlock()
+	fake.setStateExpiryMutex.RLock()
+	defer fake.setStateExpiryMutex.RUnlock()
+	fake.getStateExpiryMutex.RLock()
+	defer fake.getStateExpiryMutex.RUnlock()
 	fake.setStateValidationParameterMutex.RLock()
 	defer fake.setStateValidationParameterMutex.RUnlock()
 	fake.getStateValidationParameterMutex.RLock()
@@ -2370,6 +3073,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getQueryResultWithPaginationMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
 	fake.getPrivateDataHashMutex.RLock()
@@ -2384,10 +3089,18 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getPrivateDataValidationParameterMutex.RUnlock()
 	fake.getPrivateDataByRangeMutex.RLock()
 	defer fake.getPrivateDataByRangeMutex.RUnlock()
+	fake.getPrivateDataByRangeWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByRangeWithPaginationMutex.RUnlock()
 	fake.getPrivateDataByPartialCompositeKeyMutex.RLock()
 	defer fake.getPrivateDataByPartialCompositeKeyMutex.RUnlock()
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RUnlock()
 	fake.getPrivateDataQueryResultMutex.RLock()
 	defer fake.getPrivateDataQueryResultMutex.RUnlock()
+	fake.getPrivateDataQueryResultWithPaginationMutex.RLock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.RUnlock()
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
 	fake.getCreatorMutex.RLock()
 	defer fake.getCreatorMutex.RUnlock()
 	fake.getTransientMutex.RLock()
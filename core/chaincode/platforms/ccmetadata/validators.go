@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package ccmetadata
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
@@ -21,17 +22,25 @@ type fileValidator func(fileName string, fileBytes []byte) error
 // AllowedCharsCollectionName captures the regex pattern for a valid collection name
 const AllowedCharsCollectionName = "[A-Za-z0-9_-]+"
 
-// Currently, the only metadata expected and allowed is for META-INF/statedb/couchdb/indexes.
+// Currently, the only metadata expected and allowed is for the indexes in META-INF/statedb/couchdb/indexes,
+// META-INF/statedb/sql/indexes and META-INF/statedb/leveldb/indexes.
 var fileValidators = map[*regexp.Regexp]fileValidator{
 	regexp.MustCompile("^META-INF/statedb/couchdb/indexes/.*[.]json"):                                                couchdbIndexFileValidator,
 	regexp.MustCompile("^META-INF/statedb/couchdb/collections/" + AllowedCharsCollectionName + "/indexes/.*[.]json"): couchdbIndexFileValidator,
+	regexp.MustCompile("^META-INF/statedb/sql/indexes/.*[.]sql"):                                                     sqlIndexFileValidator,
+	regexp.MustCompile("^META-INF/statedb/sql/collections/" + AllowedCharsCollectionName + "/indexes/.*[.]sql"):      sqlIndexFileValidator,
+	regexp.MustCompile("^META-INF/statedb/leveldb/indexes/.*[.]json"):                                                leveldbIndexFileValidator,
 }
 
 var collectionNameValid = regexp.MustCompile("^" + AllowedCharsCollectionName)
 
-var fileNameValid = regexp.MustCompile("^.*[.]json")
+var fileNameValid = regexp.MustCompile("^.*[.](json|sql)")
 
-var validDatabases = []string{"couchdb"}
+var validDatabases = []string{"couchdb", "sql", "leveldb"}
+
+// sqlIndexDefinition matches the definition of an index for the SQL state database, that is of the form
+// `CREATE INDEX <name> ON state (<field>, ...)`. The fields are fully validated when the index is created
+var sqlIndexDefinition = regexp.MustCompile(`(?is)^\s*CREATE\s+INDEX\s+("[^"]+"|[A-Za-z_][A-Za-z0-9_]*)\s+ON\s+state\s*\([^()]+\)\s*;?\s*$`)
 
 // UnhandledDirectoryError is returned for metadata files in unhandled directories
 type UnhandledDirectoryError struct {
@@ -152,6 +161,49 @@ func couchdbIndexFileValidator(fileName string, fileBytes []byte) error {
 
 }
 
+// sqlIndexFileValidator implements fileValidator
+func sqlIndexFileValidator(fileName string, fileBytes []byte) error {
+	if !sqlIndexDefinition.Match(fileBytes) {
+		return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid index definition, "+
+			"expected CREATE INDEX <name> ON state (<field>, ...)", fileName)}
+	}
+	return nil
+}
+
+// leveldbIndexDefinition is the definition of a secondary index for the LevelDB state database,
+// e.g. {"name":"indexOwner","fields":["docType","owner.name"]}
+type leveldbIndexDefinition struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+// leveldbIndexFileValidator implements fileValidator
+func leveldbIndexFileValidator(fileName string, fileBytes []byte) error {
+	def := &leveldbIndexDefinition{}
+	decoder := json.NewDecoder(bytes.NewReader(fileBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(def); err != nil {
+		return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid JSON: %s", fileName, err)}
+	}
+	if def.Name == "" || strings.IndexByte(def.Name, 0x00) != -1 {
+		return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid index definition: "+
+			"the name of the index is missing or invalid", fileName)}
+	}
+	if len(def.Fields) == 0 {
+		return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid index definition: "+
+			"no fields are specified", fileName)}
+	}
+	for _, field := range def.Fields {
+		for _, name := range strings.Split(field, ".") {
+			if name == "" {
+				return &InvalidIndexContentError{fmt.Sprintf("Index metadata file [%s] is not a valid index definition: "+
+					"invalid field [%s]", fileName, field)}
+			}
+		}
+	}
+	return nil
+}
+
 // isJSON tests a string to determine if it can be parsed as valid JSON
 func isJSON(s []byte) (bool, map[string]interface{}) {
 	var js map[string]interface{}
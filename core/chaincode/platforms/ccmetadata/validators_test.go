@@ -48,6 +48,51 @@ func TestBadIndexJSON(t *testing.T) {
 	t.Log("SAMPLE ERROR STRING:", err.Error())
 }
 
+func TestSQLIndexDefinition(t *testing.T) {
+	fileName := "META-INF/statedb/sql/indexes/indexOwner.sql"
+	err := ValidateMetadataFile(fileName, []byte("CREATE INDEX indexOwner ON state (docType, owner.name);\n"))
+	assert.NoError(t, err, "Error validating a good index")
+
+	fileName = "META-INF/statedb/sql/collections/testcoll/indexes/indexOwner.sql"
+	err = ValidateMetadataFile(fileName, []byte(`create index "owner index" on state (owner)`))
+	assert.NoError(t, err, "Error validating a good index for a collection")
+
+	err = ValidateMetadataFile(fileName, []byte("CREATE INDEX indexOwner ON marbles (owner)"))
+	assert.Error(t, err, "Should have received an InvalidIndexContentError")
+	_, ok := err.(*InvalidIndexContentError)
+	assert.True(t, ok, "Should have received an InvalidIndexContentError")
+
+	err = ValidateMetadataFile("META-INF/statedb/sql/indexes/indexOwner.json", []byte("CREATE INDEX indexOwner ON state (owner)"))
+	assert.Error(t, err, "Should have received an UnhandledDirectoryError")
+	_, ok = err.(*UnhandledDirectoryError)
+	assert.True(t, ok, "Should have received an UnhandledDirectoryError")
+}
+
+func TestLevelDBIndexDefinition(t *testing.T) {
+	fileName := "META-INF/statedb/leveldb/indexes/indexOwner.json"
+	err := ValidateMetadataFile(fileName, []byte(`{"name":"indexOwner","fields":["docType","owner.name"]}`))
+	assert.NoError(t, err, "Error validating a good index")
+
+	for _, content := range []string{
+		`{"name":"indexOwner"}`,
+		`{"fields":["owner"]}`,
+		`{"name":"indexOwner","fields":["owner..name"]}`,
+		`{"name":"indexOwner","fields":["owner"],"type":"json"}`,
+		`{"index":{"fields":["owner"]}}`,
+	} {
+		err = ValidateMetadataFile(fileName, []byte(content))
+		assert.Error(t, err, "Should have received an InvalidIndexContentError for %s", content)
+		_, ok := err.(*InvalidIndexContentError)
+		assert.True(t, ok, "Should have received an InvalidIndexContentError for %s", content)
+	}
+
+	err = ValidateMetadataFile("META-INF/statedb/leveldb/collections/testcoll/indexes/indexOwner.json",
+		[]byte(`{"name":"indexOwner","fields":["owner"]}`))
+	assert.Error(t, err, "Should have received an UnhandledDirectoryError")
+	_, ok := err.(*UnhandledDirectoryError)
+	assert.True(t, ok, "Should have received an UnhandledDirectoryError")
+}
+
 func TestIndexWrongLocation(t *testing.T) {
 	testDir := filepath.Join(packageTestDir, "IndexWrongLocation")
 	cleanupDir(testDir)
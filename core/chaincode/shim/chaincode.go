@@ -10,6 +10,7 @@ package shim
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
@@ -58,6 +59,7 @@ type ChaincodeStub struct {
 	signedProposal             *pb.SignedProposal
 	proposal                   *pb.Proposal
 	validationParameterMetakey string
+	blockToLiveMetakey         string
 
 	// Additional fields extracted from the signedProposal
 	creator   []byte
@@ -396,6 +398,7 @@ func (stub *ChaincodeStub) init(handler *Handler, channelId string, txid string,
 	stub.signedProposal = signedProposal
 	stub.decorations = input.Decorations
 	stub.validationParameterMetakey = pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+	stub.blockToLiveMetakey = pb.MetaDataKeys_BLOCK_TO_LIVE.String()
 
 	// TODO: sanity check: verify that every call to init with a nil
 	// signedProposal is a legitimate one, meaning it is an internal call
@@ -503,6 +506,25 @@ func (stub *ChaincodeStub) GetQueryResult(query string) (StateQueryIteratorInter
 	return iterator, err
 }
 
+// GetStateByIndex documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByIndex(indexName string, values ...interface{}) (StateQueryIteratorInterface, error) {
+	if indexName == "" {
+		return nil, errors.New("index name must not be an empty string")
+	}
+	if values == nil {
+		values = []interface{}{}
+	}
+	valuesBytes, err := json.Marshal(values)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the values of the index")
+	}
+	response, err := stub.handler.handleGetStateByIndex(indexName, valuesBytes, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return stub.createStateQueryIterator(response), nil
+}
+
 // DelState documentation can be found in interfaces.go
 func (stub *ChaincodeStub) DelState(key string) error {
 	// Access public data by setting the collection to empty string
@@ -510,6 +532,55 @@ func (stub *ChaincodeStub) DelState(key string) error {
 	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// DelStateByRange documentation can be found in interfaces.go
+func (stub *ChaincodeStub) DelStateByRange(startKey, endKey string) error {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return err
+	}
+	return stub.handler.handleDelStateByRange(startKey, endKey, stub.ChannelId, stub.TxID)
+}
+
+// DelStateByPartialCompositeKey documentation can be found in interfaces.go
+func (stub *ChaincodeStub) DelStateByPartialCompositeKey(objectType string, attributes []string) error {
+	startKey, endKey, err := stub.createRangeKeysForPartialCompositeKey(objectType, attributes)
+	if err != nil {
+		return err
+	}
+	return stub.handler.handleDelStateByRange(startKey, endKey, stub.ChannelId, stub.TxID)
+}
+
+// SetStateExpiry documentation can be found in interfaces.go
+func (stub *ChaincodeStub) SetStateExpiry(key string, blocksToLive uint64) error {
+	var value []byte
+	if blocksToLive != 0 {
+		value = proto.EncodeVarint(blocksToLive)
+	}
+	return stub.handler.handlePutStateMetadataEntry("", key, stub.blockToLiveMetakey, value, stub.ChannelId, stub.TxID)
+}
+
+// GetStateExpiry documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateExpiry(key string) (uint64, error) {
+	md, err := stub.handler.handleGetStateMetadata("", key, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return 0, err
+	}
+	return decodeBlockToLive(md[stub.blockToLiveMetakey])
+}
+
+func decodeBlockToLive(value []byte) (uint64, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	blocksToLive, n := proto.DecodeVarint(value)
+	if n != len(value) {
+		return 0, errors.Errorf("invalid block-to-live value [%x]", value)
+	}
+	return blocksToLive, nil
+}
+
 //  ---------  private state functions  ---------
 
 // GetPrivateData documentation can be found in interfaces.go
@@ -602,6 +673,59 @@ func (stub *ChaincodeStub) GetPrivateDataQueryResult(collection, query string) (
 	return iterator, err
 }
 
+// GetPrivateDataByRangeWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetPrivateDataByRangeWithPagination(collection, startKey, endKey string, pageSize int32,
+	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if collection == "" {
+		return nil, nil, fmt.Errorf("collection must not be an empty string")
+	}
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, nil, err
+	}
+
+	metadata, err := createQueryMetadata(pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.handleGetStateByRange(collection, startKey, endKey, metadata)
+}
+
+// GetPrivateDataByPartialCompositeKeyWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPagination(collection, objectType string, keys []string,
+	pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if collection == "" {
+		return nil, nil, fmt.Errorf("collection must not be an empty string")
+	}
+
+	metadata, err := createQueryMetadata(pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	startKey, endKey, err := stub.createRangeKeysForPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.handleGetStateByRange(collection, startKey, endKey, metadata)
+}
+
+// GetPrivateDataQueryResultWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetPrivateDataQueryResultWithPagination(collection, query string, pageSize int32,
+	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if collection == "" {
+		return nil, nil, fmt.Errorf("collection must not be an empty string")
+	}
+
+	metadata, err := createQueryMetadata(pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.handleGetQueryResult(collection, query, metadata)
+}
+
 // GetPrivateDataValidationParameter documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetPrivateDataValidationParameter(collection, key string) ([]byte, error) {
 	md, err := stub.handler.handleGetStateMetadata(collection, key, stub.ChannelId, stub.TxID)
@@ -638,6 +762,21 @@ type HistoryQueryIterator struct {
 	*CommonIterator
 }
 
+// HistoryQueryOptions bounds and orders the history of a key returned by
+// GetHistoryForKeyWithPagination
+type HistoryQueryOptions struct {
+	// StartBlockNum and StartTxNum give the height (inclusive) from which the
+	// history is returned
+	StartBlockNum uint64
+	StartTxNum    uint64
+	// EndBlockNum and EndTxNum give the height (exclusive) up to which the
+	// history is returned. The history is not bounded at the end if both are zero
+	EndBlockNum uint64
+	EndTxNum    uint64
+	// Descending causes the history to be returned newest-first
+	Descending bool
+}
+
 type resultType uint8
 
 const (
@@ -707,7 +846,19 @@ func (stub *ChaincodeStub) GetStateByRange(startKey, endKey string) (StateQueryI
 
 // GetHistoryForKey documentation can be found in interfaces.go
 func (stub *ChaincodeStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	response, err := stub.handler.handleGetHistoryForKey(key, stub.ChannelId, stub.TxID)
+	response, err := stub.handler.handleGetHistoryForKey("", key, nil, nil, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}, nil
+}
+
+// GetPrivateDataHistoryForKey documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetPrivateDataHistoryForKey(collection, key string) (HistoryQueryIteratorInterface, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	response, err := stub.handler.handleGetHistoryForKey(collection, key, nil, nil, stub.ChannelId, stub.TxID)
 	if err != nil {
 		return nil, err
 	}
@@ -853,6 +1004,29 @@ func (stub *ChaincodeStub) GetQueryResultWithPagination(query string, pageSize i
 	return stub.handleGetQueryResult(collection, query, metadata)
 }
 
+// GetHistoryForKeyWithPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetHistoryForKeyWithPagination(key string, options *HistoryQueryOptions, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	metadata, err := createQueryMetadata(pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := stub.handler.handleGetHistoryForKey("", key, options, metadata, stub.ChannelId, stub.TxID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iterator := &HistoryQueryIterator{CommonIterator: &CommonIterator{stub.handler, stub.ChannelId, stub.TxID, response, 0}}
+	responseMetadata, err := createQueryResponseMetadata(response.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return iterator, responseMetadata, nil
+}
+
 func (iter *StateQueryIterator) Next() (*queryresult.KV, error) {
 	if result, err := iter.nextResult(STATE_QUERY_RESULT); err == nil {
 		return result.(*queryresult.KV), err
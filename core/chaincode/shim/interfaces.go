@@ -96,6 +96,36 @@ type ChaincodeStubInterface interface {
 	// the ledger when the transaction is validated and successfully committed.
 	DelState(key string) error
 
+	// DelStateByRange records all the keys between the startKey (inclusive)
+	// and endKey (exclusive) to be deleted in the writeset of the transaction
+	// proposal. Note that startKey and endKey can be empty string, which
+	// implies unbounded range on start or end. The range is recorded in the
+	// transaction's readset and is re-executed during validation phase, so
+	// the transaction is invalidated if a key is added to or removed from
+	// the range in the meantime. An error is returned if the number of keys
+	// in the range is greater than the totalQueryLimit (defined in core.yaml),
+	// in which case the caller should delete the keys over smaller ranges.
+	DelStateByRange(startKey, endKey string) error
+
+	// DelStateByPartialCompositeKey records all the keys that match the given
+	// partial composite key to be deleted in the writeset of the transaction
+	// proposal, in the same manner as DelStateByRange.
+	DelStateByPartialCompositeKey(objectType string, keys []string) error
+
+	// SetStateExpiry sets the block-to-live of `key`. The `key` is deleted
+	// from the ledger by all the peers once `blocksToLive` blocks have been
+	// committed after the block that last wrote `key` or its metadata, the
+	// same as the BlockToLive of a private data collection. A `blocksToLive`
+	// of zero removes the expiry. Setting the expiry, like the other metadata
+	// of `key`, introduces a read dependency on `key` in the transaction's
+	// readset, as the metadata entries already present on `key` are retained.
+	SetStateExpiry(key string, blocksToLive uint64) error
+
+	// GetStateExpiry retrieves the block-to-live of `key`, zero if `key` does
+	// not expire. Note that this will introduce a read dependency on `key` in
+	// the transaction's readset.
+	GetStateExpiry(key string) (uint64, error)
+
 	// SetStateValidationParameter sets the key-level endorsement policy for `key`.
 	SetStateValidationParameter(key string, ep []byte) error
 
@@ -199,7 +229,11 @@ type ChaincodeStubInterface interface {
 	// updated, or removed keys that impact the result set, and this would not
 	// be detected at validation/commit time.  Applications susceptible to this
 	// should therefore not use GetQueryResult as part of transactions that update
-	// ledger, and should limit use to read-only chaincode operations.
+	// ledger, and should limit use to read-only chaincode operations, unless
+	// ledger.state.richQueryPhantomProtection is enabled on the endorsing peers
+	// and the channel has the V1_4_RICH_QUERY_PHANTOM_PROTECTION capability, in
+	// which case the transaction is invalidated if a preceding transaction in
+	// the same block writes a key that matches the query.
 	GetQueryResult(query string) (StateQueryIteratorInterface, error)
 
 	// GetQueryResultWithPagination performs a "rich" query against a state database.
@@ -218,6 +252,24 @@ type ChaincodeStubInterface interface {
 	GetQueryResultWithPagination(query string, pageSize int32,
 		bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
 
+	// GetStateByIndex queries the state in the ledger on a secondary index of
+	// the state database. It is only supported for state databases that maintain
+	// secondary indexes, e.g. LevelDB, with the indexes defined in the chaincode
+	// package under META-INF/statedb/leveldb/indexes. The iterator returned
+	// iterates, in the order of the index, over the states whose values are JSON
+	// objects and whose leading indexed fields are equal to the given `values`.
+	// No values iterates over all the states in the index. However, if the number
+	// of matching states is greater than the totalQueryLimit (defined in core.yaml),
+	// this iterator cannot be used to fetch all of them (results will be limited by
+	// the totalQueryLimit).
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// GetStateByIndex requires the application capability
+	// V1_4_INDEX_PHANTOM_PROTECTION to be enabled on the channel, with which
+	// the range of the index read is validated against the index during
+	// validation phase to ensure result set has not changed since transaction
+	// endorsement (phantom reads detected).
+	GetStateByIndex(indexName string, values ...interface{}) (StateQueryIteratorInterface, error)
+
 	// GetHistoryForKey returns a history of key values across time.
 	// For each historic key update, the historic value and associated
 	// transaction id and timestamp are returned. The timestamp is the
@@ -232,6 +284,24 @@ type ChaincodeStubInterface interface {
 	// update ledger, and should limit use to read-only chaincode operations.
 	GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error)
 
+	// GetHistoryForKeyWithPagination returns a history of key values across time,
+	// same as GetHistoryForKey, bounded and ordered as per the supplied `options`.
+	// The options can bound the history by the height (block number and
+	// transaction number) of the updates, and can cause the history to be returned
+	// newest-first. A nil `options` returns the full history oldest-first.
+	// When an empty string is passed as a value to the bookmark argument, the
+	// returned iterator can be used to fetch the first `pageSize` historic key
+	// updates. When the bookmark is a non-empty string, the iterator can be used
+	// to fetch the first `pageSize` historic key updates from the bookmark.
+	// Note that only the bookmark present in a prior page of query results
+	// (ResponseMetadata) for the same key and options can be used as a value to
+	// the bookmark argument. A zero `pageSize` and an empty bookmark do not
+	// paginate the history. Same as GetHistoryForKey, phantom reads are not
+	// detected, and applications should limit the use of this call to read-only
+	// chaincode operations.
+	GetHistoryForKeyWithPagination(key string, options *HistoryQueryOptions, pageSize int32,
+		bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// GetPrivateData returns the value of the specified `key` from the specified
 	// `collection`. Note that GetPrivateData doesn't read data from the
 	// private writeset, which has not been committed to the `collection`. In
@@ -282,6 +352,21 @@ type ChaincodeStubInterface interface {
 	// has not changed since transaction endorsement (phantom reads detected).
 	GetPrivateDataByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error)
 
+	// GetPrivateDataByRangeWithPagination returns a range iterator over a set of
+	// keys in a given private collection, same as GetPrivateDataByRange, one page
+	// at a time. When an empty string is passed as a value to the bookmark argument,
+	// the returned iterator can be used to fetch the first `pageSize` keys between
+	// the startKey (inclusive) and endKey (exclusive).
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the first `pageSize` keys between the bookmark (inclusive) and endKey (exclusive).
+	// Note that only the bookmark present in a prior page of query results (ResponseMetadata)
+	// can be used as a value to the bookmark argument. Otherwise, an empty string must
+	// be passed as bookmark.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// This call is only supported in a read only transaction.
+	GetPrivateDataByRangeWithPagination(collection, startKey, endKey string, pageSize int32,
+		bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// GetPrivateDataByPartialCompositeKey queries the state in a given private
 	// collection based on a given partial composite key. This function returns
 	// an iterator which can be used to iterate over all composite keys whose prefix
@@ -294,6 +379,23 @@ type ChaincodeStubInterface interface {
 	// has not changed since transaction endorsement (phantom reads detected).
 	GetPrivateDataByPartialCompositeKey(collection, objectType string, keys []string) (StateQueryIteratorInterface, error)
 
+	// GetPrivateDataByPartialCompositeKeyWithPagination queries the state in a given
+	// private collection based on a given partial composite key, same as
+	// GetPrivateDataByPartialCompositeKey, one page at a time.
+	// When an empty string is passed as a value to the bookmark argument, the returned
+	// iterator can be used to fetch the first `pageSize` composite keys whose prefix
+	// matches the given partial composite key.
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the first `pageSize` keys between the bookmark (inclusive) and the last matching
+	// composite key.
+	// Note that only the bookmark present in a prior page of query results (ResponseMetadata)
+	// can be used as a value to the bookmark argument. Otherwise, an empty string must
+	// be passed as bookmark.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// This call is only supported in a read only transaction.
+	GetPrivateDataByPartialCompositeKeyWithPagination(collection, objectType string, keys []string,
+		pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// GetPrivateDataQueryResult performs a "rich" query against a given private
 	// collection. It is only supported for state databases that support rich query,
 	// e.g.CouchDB. The query string is in the native syntax
@@ -307,6 +409,35 @@ type ChaincodeStubInterface interface {
 	// ledger, and should limit use to read-only chaincode operations.
 	GetPrivateDataQueryResult(collection, query string) (StateQueryIteratorInterface, error)
 
+	// GetPrivateDataQueryResultWithPagination performs a "rich" query against a
+	// given private collection, same as GetPrivateDataQueryResult, one page at a time.
+	// It is only supported for state databases that support rich query, e.g., CouchDB.
+	// When an empty string is passed as a value to the bookmark argument, the returned
+	// iterator can be used to fetch the first `pageSize` of query results.
+	// When the bookmark is a non-empty string, the iterator can be used to fetch
+	// the first `pageSize` keys between the bookmark and the last key in the query result.
+	// Note that only the bookmark present in a prior page of query results (ResponseMetadata)
+	// can be used as a value to the bookmark argument. Otherwise, an empty string
+	// must be passed as bookmark.
+	// This call is only supported in a read only transaction.
+	GetPrivateDataQueryResultWithPagination(collection, query string, pageSize int32,
+		bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
+	// GetPrivateDataHistoryForKey returns a history of the values of the specified
+	// `key` in the specified private `collection` across time. For each historic
+	// key update, the hash of the value and the associated transaction id and
+	// timestamp are returned. The historic value is returned as well if the private
+	// data is available in the peer, i.e., the peer is a member of the collection
+	// and the private data has not been purged as per the `blockToLive` of the
+	// collection. GetPrivateDataHistoryForKey requires peer configuration
+	// core.ledger.history.enableHistoryDatabase to be true, and covers only the
+	// updates committed after the private data history was introduced in the peer.
+	// The query is NOT re-executed during validation phase, phantom reads are
+	// not detected. Applications should therefore not use GetPrivateDataHistoryForKey
+	// as part of transactions that update ledger, and should limit use to read-only
+	// chaincode operations.
+	GetPrivateDataHistoryForKey(collection, key string) (HistoryQueryIteratorInterface, error)
+
 	// GetCreator returns `SignatureHeader.Creator` (e.g. an identity)
 	// of the `SignedProposal`. This is the identity of the agent (or user)
 	// submitting the transaction.
@@ -63,6 +63,9 @@ type MockStub struct {
 	// stores per-key endorsement policy, first map index is the collection, second map index is the key
 	EndorsementPolicies map[string]map[string][]byte
 
+	// stores the block-to-live of the public state keys set by SetStateExpiry
+	StateExpiry map[string]uint64
+
 	// channel to store ChaincodeEvents
 	ChaincodeEventsChannel chan *pb.ChaincodeEvent
 
@@ -202,6 +205,34 @@ func (stub *MockStub) GetPrivateDataQueryResult(collection, query string) (State
 	return nil, errors.New("Not Implemented")
 }
 
+func (stub *MockStub) GetPrivateDataByRangeWithPagination(collection, startKey, endKey string, pageSize int32,
+	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("Not Implemented")
+}
+
+func (stub *MockStub) GetPrivateDataByPartialCompositeKeyWithPagination(collection, objectType string, keys []string,
+	pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("Not Implemented")
+}
+
+func (stub *MockStub) GetPrivateDataQueryResultWithPagination(collection, query string, pageSize int32,
+	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("Not Implemented")
+}
+
+// GetHistoryForKeyWithPagination function can be invoked by a chaincode to return a history of
+// key values across time, bounded, ordered, and paginated as per the supplied options.
+func (stub *MockStub) GetHistoryForKeyWithPagination(key string, options *HistoryQueryOptions, pageSize int32,
+	bookmark string) (HistoryQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+// GetPrivateDataHistoryForKey function can be invoked by a chaincode to return a history of
+// the values of a private data key across time.
+func (stub *MockStub) GetPrivateDataHistoryForKey(collection, key string) (HistoryQueryIteratorInterface, error) {
+	return nil, errors.New("not implemented")
+}
+
 // GetState retrieves the value for a given key from the ledger
 func (stub *MockStub) GetState(key string) ([]byte, error) {
 	value := stub.State[key]
@@ -273,6 +304,41 @@ func (stub *MockStub) DelState(key string) error {
 	return nil
 }
 
+// DelStateByRange deletes all the keys between the startKey (inclusive) and endKey (exclusive)
+func (stub *MockStub) DelStateByRange(startKey, endKey string) error {
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return err
+	}
+	return stub.delStateByRange(startKey, endKey)
+}
+
+// DelStateByPartialCompositeKey deletes all the keys that match the given partial composite key
+func (stub *MockStub) DelStateByPartialCompositeKey(objectType string, attributes []string) error {
+	partialCompositeKey, err := stub.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return err
+	}
+	return stub.delStateByRange(partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue))
+}
+
+func (stub *MockStub) delStateByRange(startKey, endKey string) error {
+	iter := NewMockStateRangeQueryIterator(stub, startKey, endKey)
+	var keys []string
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, kv.Key)
+	}
+	for _, key := range keys {
+		if err := stub.DelState(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (stub *MockStub) GetStateByRange(startKey, endKey string) (StateQueryIteratorInterface, error) {
 	if err := validateSimpleKeys(startKey, endKey); err != nil {
 		return nil, err
@@ -292,6 +358,13 @@ func (stub *MockStub) GetQueryResult(query string) (StateQueryIteratorInterface,
 	return nil, errors.New("not implemented")
 }
 
+// GetStateByIndex function can be invoked by a chaincode to query the state on a
+// secondary index of the state database
+func (stub *MockStub) GetStateByIndex(indexName string, values ...interface{}) (StateQueryIteratorInterface, error) {
+	// Not implemented since the mock engine does not maintain secondary indexes
+	return nil, errors.New("not implemented")
+}
+
 // GetHistoryForKey function can be invoked by a chaincode to return a history of
 // key values across time. GetHistoryForKey is intended to be used for read-only queries.
 func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
@@ -409,6 +482,21 @@ func (stub *MockStub) GetStateValidationParameter(key string) ([]byte, error) {
 	return stub.GetPrivateDataValidationParameter("", key)
 }
 
+// SetStateExpiry records the block-to-live of the key. The MockStub does not
+// simulate the commit of blocks and hence, the key is not deleted on expiry
+func (stub *MockStub) SetStateExpiry(key string, blocksToLive uint64) error {
+	if blocksToLive == 0 {
+		delete(stub.StateExpiry, key)
+		return nil
+	}
+	stub.StateExpiry[key] = blocksToLive
+	return nil
+}
+
+func (stub *MockStub) GetStateExpiry(key string) (uint64, error) {
+	return stub.StateExpiry[key], nil
+}
+
 func (stub *MockStub) SetPrivateDataValidationParameter(collection, key string, ep []byte) error {
 	m, in := stub.EndorsementPolicies[collection]
 	if !in {
@@ -439,6 +527,7 @@ func NewMockStub(name string, cc Chaincode) *MockStub {
 	s.State = make(map[string][]byte)
 	s.PvtState = make(map[string]map[string][]byte)
 	s.EndorsementPolicies = make(map[string]map[string][]byte)
+	s.StateExpiry = make(map[string]uint64)
 	s.Invokables = make(map[string]*MockStub)
 	s.Keys = list.New()
 	s.ChaincodeEventsChannel = make(chan *pb.ChaincodeEvent, 100) //define large capacity for non-blocking setEvent calls.
@@ -284,6 +284,48 @@ func TestPutEmptyState(t *testing.T) {
 
 }
 
+func TestDelStateByRange(t *testing.T) {
+	stub := NewMockStub("DelStateByRangeTest", nil)
+	stub.MockTransactionStart("init")
+	for _, key := range []string{"a", "b", "c", "d"} {
+		assert.NoError(t, stub.PutState(key, []byte(key)))
+	}
+	session1, _ := stub.CreateCompositeKey("session", []string{"user-1", "s1"})
+	session2, _ := stub.CreateCompositeKey("session", []string{"user-1", "s2"})
+	session3, _ := stub.CreateCompositeKey("session", []string{"user-2", "s3"})
+	for _, key := range []string{session1, session2, session3} {
+		assert.NoError(t, stub.PutState(key, []byte("session")))
+	}
+	stub.MockTransactionEnd("init")
+
+	stub.MockTransactionStart("delete")
+	assert.NoError(t, stub.DelStateByRange("b", "d"))
+	assert.NoError(t, stub.DelStateByPartialCompositeKey("session", []string{"user-1"}))
+	stub.MockTransactionEnd("delete")
+
+	expectedState := map[string][]byte{"a": []byte("a"), "d": []byte("d"), session3: []byte("session")}
+	assert.Equal(t, expectedState, stub.State)
+	assert.Equal(t, 3, stub.Keys.Len())
+
+	err := stub.DelStateByRange("\x00a", "b")
+	assert.EqualError(t, err, "first character of the key [\x00a] contains a null character which is not allowed")
+}
+
+func TestStateExpiry(t *testing.T) {
+	stub := NewMockStub("StateExpiryTest", nil)
+	blocksToLive, err := stub.GetStateExpiry("key")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), blocksToLive)
+
+	assert.NoError(t, stub.SetStateExpiry("key", 10))
+	blocksToLive, err = stub.GetStateExpiry("key")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), blocksToLive)
+
+	assert.NoError(t, stub.SetStateExpiry("key", 0))
+	assert.Empty(t, stub.StateExpiry)
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
@@ -314,6 +356,11 @@ func TestMockMock(t *testing.T) {
 	stub.GetArgsSlice()
 	stub.SetEvent("e", nil)
 	stub.GetHistoryForKey("k")
+	stub.GetHistoryForKeyWithPagination("k", nil, 1, "")
+	stub.GetPrivateDataHistoryForKey("c", "k")
+	stub.GetPrivateDataByRangeWithPagination("c", "start", "end", 1, "")
+	stub.GetPrivateDataByPartialCompositeKeyWithPagination("c", "o", []string{"a"}, 1, "")
+	stub.GetPrivateDataQueryResultWithPagination("c", "q", 1, "")
 	iter := &MockStateRangeQueryIterator{}
 	iter.HasNext()
 	iter.Close()
@@ -31,6 +31,11 @@ type TransactionContext struct {
 	pendingQueryResults map[string]*PendingQueryResult
 	totalReturnCount    map[string]*int32
 
+	// tracks the metadata of the public state keys set by the transaction so that
+	// the metadata entries set by multiple calls on the same key are merged
+	metadataMutex sync.Mutex
+	stateMetadata map[string]map[string][]byte
+
 	// cache used to save the result of collection acl
 	// as a transactionContext is created for every chaincode
 	// invoke (even in case of chaincode-calling-chaincode,
@@ -59,6 +64,24 @@ func (t *TransactionContext) InitializeCollectionACLCache() {
 	t.CollectionACLCache = make(CollectionACLCache)
 }
 
+// GetStateMetadata returns the metadata of the key as set earlier in the transaction, if any
+func (t *TransactionContext) GetStateMetadata(key string) (map[string][]byte, bool) {
+	t.metadataMutex.Lock()
+	defer t.metadataMutex.Unlock()
+	metadata, ok := t.stateMetadata[key]
+	return metadata, ok
+}
+
+// PutStateMetadata records the metadata of the key as set in the transaction
+func (t *TransactionContext) PutStateMetadata(key string, metadata map[string][]byte) {
+	t.metadataMutex.Lock()
+	defer t.metadataMutex.Unlock()
+	if t.stateMetadata == nil {
+		t.stateMetadata = map[string]map[string][]byte{}
+	}
+	t.stateMetadata[key] = metadata
+}
+
 func (t *TransactionContext) InitializeQueryContext(queryID string, iter commonledger.ResultsIterator) {
 	t.queryMutex.Lock()
 	if t.queryIteratorMap == nil {
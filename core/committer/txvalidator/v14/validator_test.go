@@ -1688,11 +1688,26 @@ func (exec *mockQueryExecutor) GetPrivateDataRangeScanIterator(namespace, collec
 	return args.Get(0).(ledger2.ResultsIterator), args.Error(1)
 }
 
+func (exec *mockQueryExecutor) GetStateByIndex(namespace, indexName string, values []interface{}) (ledger2.ResultsIterator, error) {
+	args := exec.Called(namespace, indexName, values)
+	return args.Get(0).(ledger2.ResultsIterator), args.Error(1)
+}
+
+func (exec *mockQueryExecutor) GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	args := exec.Called(namespace, collection, startKey, endKey, metadata)
+	return args.Get(0).(ledger.QueryResultsIterator), args.Error(1)
+}
+
 func (exec *mockQueryExecutor) ExecuteQueryOnPrivateData(namespace, collection, query string) (ledger2.ResultsIterator, error) {
 	args := exec.Called(namespace, collection, query)
 	return args.Get(0).(ledger2.ResultsIterator), args.Error(1)
 }
 
+func (exec *mockQueryExecutor) ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	args := exec.Called(namespace, collection, query, metadata)
+	return args.Get(0).(ledger.QueryResultsIterator), args.Error(1)
+}
+
 func (exec *mockQueryExecutor) Done() {
 }
 
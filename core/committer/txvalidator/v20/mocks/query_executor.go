@@ -62,6 +62,29 @@ func (_m *QueryExecutor) ExecuteQueryOnPrivateData(namespace string, collection
 	return r0, r1
 }
 
+// ExecuteQueryOnPrivateDataWithMetadata provides a mock function with given fields: namespace, collection, query, metadata
+func (_m *QueryExecutor) ExecuteQueryOnPrivateDataWithMetadata(namespace string, collection string, query string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	ret := _m.Called(namespace, collection, query, metadata)
+
+	var r0 ledger.QueryResultsIterator
+	if rf, ok := ret.Get(0).(func(string, string, string, map[string]interface{}) ledger.QueryResultsIterator); ok {
+		r0 = rf(namespace, collection, query, metadata)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ledger.QueryResultsIterator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, map[string]interface{}) error); ok {
+		r1 = rf(namespace, collection, query, metadata)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteQueryWithMetadata provides a mock function with given fields: namespace, query, metadata
 func (_m *QueryExecutor) ExecuteQueryWithMetadata(namespace string, query string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 	ret := _m.Called(namespace, query, metadata)
@@ -223,6 +246,29 @@ func (_m *QueryExecutor) GetPrivateDataRangeScanIterator(namespace string, colle
 	return r0, r1
 }
 
+// GetPrivateDataRangeScanIteratorWithMetadata provides a mock function with given fields: namespace, collection, startKey, endKey, metadata
+func (_m *QueryExecutor) GetPrivateDataRangeScanIteratorWithMetadata(namespace string, collection string, startKey string, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	ret := _m.Called(namespace, collection, startKey, endKey, metadata)
+
+	var r0 ledger.QueryResultsIterator
+	if rf, ok := ret.Get(0).(func(string, string, string, string, map[string]interface{}) ledger.QueryResultsIterator); ok {
+		r0 = rf(namespace, collection, startKey, endKey, metadata)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ledger.QueryResultsIterator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string, map[string]interface{}) error); ok {
+		r1 = rf(namespace, collection, startKey, endKey, metadata)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetState provides a mock function with given fields: namespace, key
 func (_m *QueryExecutor) GetState(namespace string, key string) ([]byte, error) {
 	ret := _m.Called(namespace, key)
@@ -246,6 +292,29 @@ func (_m *QueryExecutor) GetState(namespace string, key string) ([]byte, error)
 	return r0, r1
 }
 
+// GetStateByIndex provides a mock function with given fields: namespace, indexName, values
+func (_m *QueryExecutor) GetStateByIndex(namespace string, indexName string, values []interface{}) (commonledger.ResultsIterator, error) {
+	ret := _m.Called(namespace, indexName, values)
+
+	var r0 commonledger.ResultsIterator
+	if rf, ok := ret.Get(0).(func(string, string, []interface{}) commonledger.ResultsIterator); ok {
+		r0 = rf(namespace, indexName, values)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(commonledger.ResultsIterator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []interface{}) error); ok {
+		r1 = rf(namespace, indexName, values)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStateMetadata provides a mock function with given fields: namespace, key
 func (_m *QueryExecutor) GetStateMetadata(namespace string, key string) (map[string][]byte, error) {
 	ret := _m.Called(namespace, key)
@@ -520,6 +520,10 @@ func (ds *dynamicCapabilities) ForbidDuplicateTXIdInBlock() bool {
 	return ds.cr.Capabilities().ForbidDuplicateTXIdInBlock()
 }
 
+func (ds *dynamicCapabilities) IndexPhantomProtection() bool {
+	return ds.cr.Capabilities().IndexPhantomProtection()
+}
+
 func (ds *dynamicCapabilities) KeyLevelEndorsement() bool {
 	return ds.cr.Capabilities().KeyLevelEndorsement()
 }
@@ -532,6 +536,10 @@ func (ds *dynamicCapabilities) PrivateChannelData() bool {
 	return ds.cr.Capabilities().PrivateChannelData()
 }
 
+func (ds *dynamicCapabilities) StateExpiry() bool {
+	return ds.cr.Capabilities().StateExpiry()
+}
+
 func (ds *dynamicCapabilities) Supported() error {
 	return ds.cr.Capabilities().Supported()
 }
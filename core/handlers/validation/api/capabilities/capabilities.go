@@ -60,4 +60,12 @@ type Capabilities interface {
 
 	// FabToken returns true if fabric token function is supported.
 	FabToken() bool
+
+	// IndexPhantomProtection returns true if the queries on the secondary indexes of the
+	// state database are supported and their phantom reads are validated at commit time.
+	IndexPhantomProtection() bool
+
+	// StateExpiry returns true if the public state keys can be given a block-to-live,
+	// after which they are purged from the state.
+	StateExpiry() bool
 }
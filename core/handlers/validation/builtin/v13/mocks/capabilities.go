@@ -64,6 +64,20 @@ func (_m *Capabilities) ForbidDuplicateTXIdInBlock() bool {
 	return r0
 }
 
+// IndexPhantomProtection provides a mock function with given fields:
+func (_m *Capabilities) IndexPhantomProtection() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // KeyLevelEndorsement provides a mock function with given fields:
 func (_m *Capabilities) KeyLevelEndorsement() bool {
 	ret := _m.Called()
@@ -106,6 +120,20 @@ func (_m *Capabilities) PrivateChannelData() bool {
 	return r0
 }
 
+// StateExpiry provides a mock function with given fields:
+func (_m *Capabilities) StateExpiry() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Supported provides a mock function with given fields:
 func (_m *Capabilities) Supported() error {
 	ret := _m.Called()
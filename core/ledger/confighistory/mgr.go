@@ -28,6 +28,10 @@ const (
 type Mgr interface {
 	ledger.StateListener
 	GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetriever) ledger.ConfigHistoryRetriever
+	ExportConfigHistory(dir, ledgerID string) (map[string][]byte, error)
+	ImportConfigHistory(dir, ledgerID string) error
+	// Drop deletes the collection config history of the given ledger
+	Drop(ledgerID string) error
 	Close()
 }
 
@@ -101,6 +105,11 @@ func (m *mgr) GetRetriever(ledgerID string, ledgerInfoRetriever LedgerInfoRetrie
 	}
 }
 
+// Drop implements the function in the interface 'Mgr'
+func (m *mgr) Drop(ledgerID string) error {
+	return m.dbProvider.GetDBHandle(ledgerID).DeleteAll()
+}
+
 // Close implements the function in the interface 'Mgr'
 func (m *mgr) Close() {
 	m.dbProvider.Close()
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confighistory
+
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotFileFormat = byte(1)
+	// SnapshotDataFileName is the name of the snapshot file that contains the config history
+	SnapshotDataFileName = "confighistory.data"
+)
+
+// ExportConfigHistory implements the function in the interface 'Mgr'. All the entries of the config history
+// of the ledger are exported into the snapshot file 'confighistory.data'
+func (m *mgr) ExportConfigHistory(dir, ledgerID string) (map[string][]byte, error) {
+	dataFile, err := snapshot.CreateFile(filepath.Join(dir, SnapshotDataFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer dataFile.Close()
+
+	dbHandle := m.dbProvider.getDB(ledgerID)
+	itr := dbHandle.GetIterator([]byte(keyPrefix), []byte{keyPrefix[0] + 1})
+	defer itr.Release()
+	numEntries := uint64(0)
+	for itr.Next() {
+		if err := itr.Error(); err != nil {
+			return nil, errors.Wrap(err, "internal leveldb error while iterating for config history")
+		}
+		k := decodeCompositeKey(itr.Key())
+		if err := dataFile.EncodeString(k.ns); err != nil {
+			return nil, err
+		}
+		if err := dataFile.EncodeString(k.key); err != nil {
+			return nil, err
+		}
+		if err := dataFile.EncodeUVarint(k.blockNum); err != nil {
+			return nil, err
+		}
+		if err := dataFile.EncodeBytes(itr.Value()); err != nil {
+			return nil, err
+		}
+		numEntries++
+	}
+	// the end of the entries is marked by an empty namespace followed by the number of entries
+	if err := dataFile.EncodeString(""); err != nil {
+		return nil, err
+	}
+	if err := dataFile.EncodeUVarint(numEntries); err != nil {
+		return nil, err
+	}
+	hash, err := dataFile.Done()
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{SnapshotDataFileName: hash}, nil
+}
+
+// ImportConfigHistory implements the function in the interface 'Mgr'. The entries exported in the snapshot
+// file 'confighistory.data' are loaded in the config history of the ledger
+func (m *mgr) ImportConfigHistory(dir, ledgerID string) error {
+	dataFile, err := snapshot.OpenFile(filepath.Join(dir, SnapshotDataFileName), snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer dataFile.Close()
+
+	batch := newBatch()
+	numEntries := uint64(0)
+	for {
+		ns, err := dataFile.DecodeString()
+		if err != nil {
+			return err
+		}
+		if ns == "" {
+			expectedNumEntries, err := dataFile.DecodeUVarInt()
+			if err != nil {
+				return err
+			}
+			if expectedNumEntries != numEntries {
+				return errors.Errorf("unexpected number of entries in the config history snapshot file. Expected = [%d], found = [%d]",
+					expectedNumEntries, numEntries)
+			}
+			break
+		}
+		key, err := dataFile.DecodeString()
+		if err != nil {
+			return err
+		}
+		blockNum, err := dataFile.DecodeUVarInt()
+		if err != nil {
+			return err
+		}
+		value, err := dataFile.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		batch.add(ns, key, blockNum, value)
+		numEntries++
+	}
+	return m.dbProvider.getDB(ledgerID).writeBatch(batch, true)
+}
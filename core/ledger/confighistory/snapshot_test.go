@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confighistory
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAndImportConfigHistory(t *testing.T) {
+	dbPath := "/tmp/fabric/core/ledger/confighistory"
+	mockCCInfoProvider := &mock.DeployedChaincodeInfoProvider{}
+	env := newTestEnv(t, dbPath, mockCCInfoProvider)
+	mgr := env.mgr
+	defer env.cleanup()
+	snapshotDir, err := ioutil.TempDir("", "confighistory-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	for _, committingBlockNum := range []uint64{5, 10} {
+		testutilEquipMockCCInfoProviderToReturnDesiredCollConfig(mockCCInfoProvider, "chaincode1",
+			sampleCollectionConfigPackage("ledger1", committingBlockNum))
+		require.NoError(t, mgr.HandleStateUpdates(&ledger.StateUpdateTrigger{
+			LedgerID:           "ledger1",
+			CommittingBlockNum: committingBlockNum},
+		))
+	}
+
+	fileHashes, err := mgr.ExportConfigHistory(snapshotDir, "ledger1")
+	require.NoError(t, err)
+	assert.Contains(t, fileHashes, SnapshotDataFileName)
+	require.NoError(t, mgr.ImportConfigHistory(snapshotDir, "ledger2"))
+
+	retriever := mgr.GetRetriever("ledger2", &dummyLedgerInfoRetriever{info: &common.BlockchainInfo{Height: 20}})
+	for _, committingBlockNum := range []uint64{5, 10} {
+		retrievedConfig, err := retriever.CollectionConfigAt(committingBlockNum, "chaincode1")
+		require.NoError(t, err)
+		assert.True(t, proto.Equal(sampleCollectionConfigPackage("ledger1", committingBlockNum), retrievedConfig.CollectionConfig))
+	}
+	retrievedConfig, err := retriever.MostRecentCollectionConfigBelow(20, "chaincode1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), retrievedConfig.CommittingBlockNum)
+
+	_, err = mgr.ExportConfigHistory(snapshotDir, "ledger1")
+	assert.Contains(t, err.Error(), "error while creating the snapshot file")
+}
@@ -0,0 +1,236 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// StorageMaintainer reports the disk usage of, and compacts, the stores of the channels of a peer
+type StorageMaintainer interface {
+	// Channels returns the channels whose stores are maintained
+	Channels() ([]string, error)
+	// DiskUsage returns the approximate number of bytes used on the file system by the data of the
+	// given channel, keyed by the name of the store
+	DiskUsage(channel string) (map[string]uint64, error)
+	// Compact compacts the data of the given channel in the store with the given name
+	Compact(channel string, store string) error
+}
+
+// ChannelDiskUsage is the disk usage of the stores of a channel
+type ChannelDiskUsage struct {
+	Channel string            `json:"channel"`
+	Stores  map[string]uint64 `json:"stores"`
+	Total   uint64            `json:"total"`
+	// Compacting lists the stores whose compaction is in progress
+	Compacting []string `json:"compacting,omitempty"`
+}
+
+// DiskUsage is the response to a disk usage request
+type DiskUsage struct {
+	Channels []*ChannelDiskUsage `json:"channels"`
+}
+
+// CompactRequest is the request to compact a store of a channel
+type CompactRequest struct {
+	Channel string `json:"channel"`
+	Store   string `json:"store"`
+}
+
+// ErrorResponse is the response to a failed request
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewStorageHandler constructs a StorageHandler
+func NewStorageHandler(maintainer StorageMaintainer) *StorageHandler {
+	return &StorageHandler{
+		Maintainer: maintainer,
+		Logger:     flogging.MustGetLogger("ledger.httpadmin"),
+		compacting: map[CompactRequest]struct{}{},
+	}
+}
+
+// StorageHandler serves the disk usage of the stores of the channels on GET and starts the compaction
+// of a store of a channel on POST. As a compaction may take longer than the write timeout of the server,
+// the compaction runs in the background; its progress is reported in the disk usage of the channel
+type StorageHandler struct {
+	Maintainer StorageMaintainer
+	Logger     *flogging.FabricLogger
+
+	mutex      sync.Mutex
+	compacting map[CompactRequest]struct{}
+	// compactionDone is invoked, if set, when a compaction completes. It is used by the tests
+	compactionDone func(CompactRequest, error)
+}
+
+func (h *StorageHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		h.serveDiskUsage(resp, req)
+
+	case http.MethodPost:
+		var compactReq CompactRequest
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&compactReq); err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, err)
+			return
+		}
+		req.Body.Close()
+		h.serveCompact(resp, compactReq)
+
+	default:
+		err := fmt.Errorf("invalid request method: %s", req.Method)
+		h.sendResponse(resp, http.StatusBadRequest, err)
+	}
+}
+
+func (h *StorageHandler) serveDiskUsage(resp http.ResponseWriter, req *http.Request) {
+	channels := []string{}
+	if channel := req.URL.Query().Get("channel"); channel != "" {
+		if code, err := h.checkChannel(channel); err != nil {
+			h.sendResponse(resp, code, err)
+			return
+		}
+		channels = append(channels, channel)
+	} else {
+		var err error
+		if channels, err = h.Maintainer.Channels(); err != nil {
+			h.sendResponse(resp, http.StatusInternalServerError, err)
+			return
+		}
+		sort.Strings(channels)
+	}
+
+	diskUsage := &DiskUsage{Channels: []*ChannelDiskUsage{}}
+	for _, channel := range channels {
+		stores, err := h.Maintainer.DiskUsage(channel)
+		if err != nil {
+			h.sendResponse(resp, http.StatusInternalServerError, err)
+			return
+		}
+		channelUsage := &ChannelDiskUsage{
+			Channel:    channel,
+			Stores:     stores,
+			Compacting: h.compactingStores(channel),
+		}
+		for _, bytes := range stores {
+			channelUsage.Total += bytes
+		}
+		diskUsage.Channels = append(diskUsage.Channels, channelUsage)
+	}
+	h.sendResponse(resp, http.StatusOK, diskUsage)
+}
+
+func (h *StorageHandler) serveCompact(resp http.ResponseWriter, compactReq CompactRequest) {
+	if compactReq.Channel == "" || compactReq.Store == "" {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("channel and store must be provided"))
+		return
+	}
+	if code, err := h.checkChannel(compactReq.Channel); err != nil {
+		h.sendResponse(resp, code, err)
+		return
+	}
+	stores, err := h.Maintainer.DiskUsage(compactReq.Channel)
+	if err != nil {
+		h.sendResponse(resp, http.StatusInternalServerError, err)
+		return
+	}
+	if _, ok := stores[compactReq.Store]; !ok {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("store [%s] not found, the stores are %s", compactReq.Store, storeNames(stores)))
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if _, ok := h.compacting[compactReq]; ok {
+		err := fmt.Errorf("compaction of store [%s] of channel [%s] is already in progress", compactReq.Store, compactReq.Channel)
+		h.sendResponse(resp, http.StatusConflict, err)
+		return
+	}
+	h.compacting[compactReq] = struct{}{}
+	go h.compact(compactReq, stores[compactReq.Store])
+	h.sendResponse(resp, http.StatusAccepted, &compactReq)
+}
+
+func (h *StorageHandler) compact(compactReq CompactRequest, bytesBefore uint64) {
+	startTime := time.Now()
+	err := h.Maintainer.Compact(compactReq.Channel, compactReq.Store)
+	if err != nil {
+		h.Logger.Errorw("failed to compact store", "channel", compactReq.Channel, "store", compactReq.Store, "error", err)
+	} else {
+		var bytesAfter uint64
+		if stores, err := h.Maintainer.DiskUsage(compactReq.Channel); err == nil {
+			bytesAfter = stores[compactReq.Store]
+		}
+		h.Logger.Infow("compacted store", "channel", compactReq.Channel, "store", compactReq.Store,
+			"bytesBefore", bytesBefore, "bytesAfter", bytesAfter, "duration", time.Since(startTime))
+	}
+
+	h.mutex.Lock()
+	delete(h.compacting, compactReq)
+	h.mutex.Unlock()
+	if h.compactionDone != nil {
+		h.compactionDone(compactReq, err)
+	}
+}
+
+func (h *StorageHandler) checkChannel(channel string) (int, error) {
+	channels, err := h.Maintainer.Channels()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	for _, c := range channels {
+		if c == channel {
+			return http.StatusOK, nil
+		}
+	}
+	return http.StatusNotFound, fmt.Errorf("channel [%s] not found", channel)
+}
+
+func (h *StorageHandler) compactingStores(channel string) []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	var stores []string
+	for compactReq := range h.compacting {
+		if compactReq.Channel == channel {
+			stores = append(stores, compactReq.Store)
+		}
+	}
+	sort.Strings(stores)
+	return stores
+}
+
+func (h *StorageHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
+
+func storeNames(stores map[string]uint64) []string {
+	var names []string
+	for name := range stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
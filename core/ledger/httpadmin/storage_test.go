@@ -0,0 +1,167 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubMaintainer struct {
+	mutex      sync.Mutex
+	usage      map[string]map[string]uint64
+	compactErr error
+	// release, if set, blocks the compactions until it is closed
+	release   chan struct{}
+	compacted []CompactRequest
+}
+
+func (m *stubMaintainer) Channels() ([]string, error) {
+	var channels []string
+	for channel := range m.usage {
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+func (m *stubMaintainer) DiskUsage(channel string) (map[string]uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	stores := map[string]uint64{}
+	for store, bytes := range m.usage[channel] {
+		stores[store] = bytes
+	}
+	return stores, nil
+}
+
+func (m *stubMaintainer) Compact(channel string, store string) error {
+	if m.release != nil {
+		<-m.release
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.compacted = append(m.compacted, CompactRequest{Channel: channel, Store: store})
+	if m.compactErr != nil {
+		return m.compactErr
+	}
+	m.usage[channel][store] /= 2
+	return nil
+}
+
+func newTestHandler() (*StorageHandler, *stubMaintainer) {
+	maintainer := &stubMaintainer{
+		usage: map[string]map[string]uint64{
+			"ch1": {"state": 100, "history": 50},
+			"ch2": {"state": 10, "history": 0},
+		},
+	}
+	return NewStorageHandler(maintainer), maintainer
+}
+
+func serve(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return resp
+}
+
+func TestDiskUsage(t *testing.T) {
+	h, _ := newTestHandler()
+
+	resp := serve(h, http.MethodGet, "/storage", "")
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	diskUsage := &DiskUsage{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), diskUsage))
+	assert.Equal(t, &DiskUsage{
+		Channels: []*ChannelDiskUsage{
+			{Channel: "ch1", Stores: map[string]uint64{"state": 100, "history": 50}, Total: 150},
+			{Channel: "ch2", Stores: map[string]uint64{"state": 10, "history": 0}, Total: 10},
+		},
+	}, diskUsage)
+
+	resp = serve(h, http.MethodGet, "/storage?channel=ch2", "")
+	require.Equal(t, http.StatusOK, resp.Code)
+	diskUsage = &DiskUsage{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), diskUsage))
+	assert.Len(t, diskUsage.Channels, 1)
+	assert.Equal(t, "ch2", diskUsage.Channels[0].Channel)
+
+	resp = serve(h, http.MethodGet, "/storage?channel=ch3", "")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.JSONEq(t, `{"error":"channel [ch3] not found"}`, resp.Body.String())
+
+	resp = serve(h, http.MethodDelete, "/storage", "")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{"error":"invalid request method: DELETE"}`, resp.Body.String())
+}
+
+func TestCompact(t *testing.T) {
+	h, maintainer := newTestHandler()
+	maintainer.release = make(chan struct{})
+	done := make(chan error, 1)
+	h.compactionDone = func(compactReq CompactRequest, err error) {
+		done <- err
+	}
+
+	resp := serve(h, http.MethodPost, "/storage", `{"channel":"ch1","store":"state"}`)
+	require.Equal(t, http.StatusAccepted, resp.Code)
+	assert.JSONEq(t, `{"channel":"ch1","store":"state"}`, resp.Body.String())
+
+	// the compaction in progress is reported and a concurrent compaction of the same store is rejected
+	resp = serve(h, http.MethodGet, "/storage?channel=ch1", "")
+	diskUsage := &DiskUsage{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), diskUsage))
+	assert.Equal(t, []string{"state"}, diskUsage.Channels[0].Compacting)
+	resp = serve(h, http.MethodPost, "/storage", `{"channel":"ch1","store":"state"}`)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.JSONEq(t, `{"error":"compaction of store [state] of channel [ch1] is already in progress"}`, resp.Body.String())
+
+	close(maintainer.release)
+	assert.NoError(t, <-done)
+	assert.Equal(t, []CompactRequest{{Channel: "ch1", Store: "state"}}, maintainer.compacted)
+	resp = serve(h, http.MethodGet, "/storage?channel=ch1", "")
+	diskUsage = &DiskUsage{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), diskUsage))
+	assert.Nil(t, diskUsage.Channels[0].Compacting)
+	assert.Equal(t, uint64(50), diskUsage.Channels[0].Stores["state"])
+
+	maintainer.compactErr = errors.New("compaction-error")
+	resp = serve(h, http.MethodPost, "/storage", `{"channel":"ch1","store":"history"}`)
+	require.Equal(t, http.StatusAccepted, resp.Code)
+	assert.EqualError(t, <-done, "compaction-error")
+}
+
+func TestCompactBadRequest(t *testing.T) {
+	h, maintainer := newTestHandler()
+
+	tests := []struct {
+		body         string
+		expectedCode int
+		expectedErr  string
+	}{
+		{`{"channel":`, http.StatusBadRequest, "unexpected EOF"},
+		{`{"channel":"ch1"}`, http.StatusBadRequest, "channel and store must be provided"},
+		{`{"channel":"ch3","store":"state"}`, http.StatusNotFound, "channel [ch3] not found"},
+		{`{"channel":"ch1","store":"blocks"}`, http.StatusBadRequest, "store [blocks] not found, the stores are [history state]"},
+	}
+	for _, test := range tests {
+		resp := serve(h, http.MethodPost, "/storage", test.body)
+		assert.Equal(t, test.expectedCode, resp.Code, test.body)
+		errResp := &ErrorResponse{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), errResp))
+		assert.Equal(t, test.expectedErr, errResp.Error, test.body)
+	}
+	assert.Empty(t, maintainer.compacted)
+}
@@ -21,12 +21,16 @@ const (
 	PvtdataExpiry Category = iota
 	// MetadataPresenceIndicator maintains the bookkeeping about whether metadata is ever set for a namespace
 	MetadataPresenceIndicator
+	// PubStateExpiry represents the bookkeeping related to expiry of the public state keys that are written with a block-to-live
+	PubStateExpiry
 )
 
 // Provider provides handle to different bookkeepers for the given ledger
 type Provider interface {
 	// GetDBHandle returns a db handle that can be used for maintaining the bookkeeping of a given category
 	GetDBHandle(ledgerID string, cat Category) *leveldbhelper.DBHandle
+	// Drop deletes the bookkeeping of all the categories for the given ledger
+	Drop(ledgerID string) error
 	// Close closes the BookkeeperProvider
 	Close()
 }
@@ -46,6 +50,16 @@ func (provider *provider) GetDBHandle(ledgerID string, cat Category) *leveldbhel
 	return provider.dbProvider.GetDBHandle(fmt.Sprintf(ledgerID+"/%d", cat))
 }
 
+// Drop implements the function in the interface 'BookkeeperProvider'
+func (provider *provider) Drop(ledgerID string) error {
+	for _, cat := range []Category{PvtdataExpiry, MetadataPresenceIndicator, PubStateExpiry} {
+		if err := provider.GetDBHandle(ledgerID, cat).DeleteAll(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Close implements the function in the interface 'BookKeeperProvider'
 func (provider *provider) Close() {
 	provider.dbProvider.Close()
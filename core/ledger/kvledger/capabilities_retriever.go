@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// blockRetriever retrieves a committed block by its number
+type blockRetriever interface {
+	RetrieveBlockByNumber(blockNum uint64) (*common.Block, error)
+}
+
+// capabilitiesRetriever implements the interface txmgr.CapabilitiesRetriever.
+// The application capabilities in effect for a block are read from the config block that
+// the block refers to in its LAST_CONFIG metadata, which is either the block itself or
+// a block already committed to the block store. As the config blocks never change,
+// the capabilities of the last config block looked up are cached
+type capabilitiesRetriever struct {
+	ledgerID   string
+	blockStore blockRetriever
+
+	lock           sync.Mutex
+	configBlockNum uint64
+	capabilities   txmgr.ApplicationCapabilities
+}
+
+// ApplicationCapabilities implements function from interface txmgr.CapabilitiesRetriever
+func (r *capabilitiesRetriever) ApplicationCapabilities(block *common.Block) (txmgr.ApplicationCapabilities, error) {
+	configBlockNum, err := utils.GetLastConfigIndexFromBlock(block)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error retrieving the index of the last config block of block [%d] in ledger [%s]",
+			block.Header.Number, r.ledgerID))
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.capabilities != nil && r.configBlockNum == configBlockNum {
+		return r.capabilities, nil
+	}
+	configBlock := block
+	if configBlockNum != block.Header.Number {
+		if configBlock, err = r.blockStore.RetrieveBlockByNumber(configBlockNum); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error retrieving the config block [%d] of ledger [%s]", configBlockNum, r.ledgerID))
+		}
+	}
+	caps, err := applicationCapabilities(configBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error reading the application capabilities from the config block [%d] of ledger [%s]",
+			configBlockNum, r.ledgerID))
+	}
+	r.configBlockNum, r.capabilities = configBlockNum, caps
+	return caps, nil
+}
+
+// applicationCapabilities returns the application capabilities of the channel config carried by a config block
+func applicationCapabilities(configBlock *common.Block) (*capabilities.ApplicationProvider, error) {
+	env, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	configEnv := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling the config envelope")
+	}
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return nil, errors.New("the block does not carry a channel config")
+	}
+	caps := &common.Capabilities{}
+	if appGroup, ok := configEnv.Config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey]; ok {
+		if capsValue, ok := appGroup.Values[channelconfig.CapabilitiesKey]; ok {
+			if err := proto.Unmarshal(capsValue.Value, caps); err != nil {
+				return nil, errors.Wrap(err, "error unmarshaling the application capabilities")
+			}
+		}
+	}
+	return capabilities.NewApplicationProvider(caps.Capabilities), nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testBlockRetriever map[uint64]*common.Block
+
+func (r testBlockRetriever) RetrieveBlockByNumber(blockNum uint64) (*common.Block, error) {
+	block, ok := r[blockNum]
+	if !ok {
+		return nil, errors.Errorf("block [%d] not found", blockNum)
+	}
+	return block, nil
+}
+
+func TestCapabilitiesRetriever(t *testing.T) {
+	genesisBlock, err := configtxtest.MakeGenesisBlock("testLedger")
+	assert.NoError(t, err)
+	configBlock := testConfigBlockWithAppCapabilities(t, genesisBlock, 5, capabilities.ApplicationV1_3, capabilities.ApplicationRichQueryPhantomProtection)
+	blockStore := testBlockRetriever{0: genesisBlock, 5: configBlock}
+	retriever := &capabilitiesRetriever{ledgerID: "testLedger", blockStore: blockStore}
+
+	// the capabilities are read from the block itself if it is a config block
+	caps, err := retriever.ApplicationCapabilities(genesisBlock)
+	assert.NoError(t, err)
+	assert.False(t, caps.RichQueryPhantomProtection())
+	caps, err = retriever.ApplicationCapabilities(configBlock)
+	assert.NoError(t, err)
+	assert.True(t, caps.RichQueryPhantomProtection())
+
+	// otherwise, from the last config block
+	caps, err = retriever.ApplicationCapabilities(testBlockWithLastConfig(3, 0))
+	assert.NoError(t, err)
+	assert.False(t, caps.RichQueryPhantomProtection())
+	caps, err = retriever.ApplicationCapabilities(testBlockWithLastConfig(6, 5))
+	assert.NoError(t, err)
+	assert.True(t, caps.RichQueryPhantomProtection())
+
+	// the capabilities of the last config block looked up are cached
+	delete(blockStore, 5)
+	caps, err = retriever.ApplicationCapabilities(testBlockWithLastConfig(7, 5))
+	assert.NoError(t, err)
+	assert.True(t, caps.RichQueryPhantomProtection())
+
+	_, err = retriever.ApplicationCapabilities(testBlockWithLastConfig(8, 4))
+	assert.EqualError(t, err, "error retrieving the config block [4] of ledger [testLedger]: block [4] not found")
+
+	blockStore[4] = testBlockWithLastConfig(4, 0)
+	_, err = retriever.ApplicationCapabilities(testBlockWithLastConfig(8, 4))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error reading the application capabilities from the config block [4] of ledger [testLedger]")
+}
+
+func testConfigBlockWithAppCapabilities(t *testing.T, genesisBlock *common.Block, blockNum uint64, caps ...string) *common.Block {
+	env, err := utils.ExtractEnvelope(genesisBlock, 0)
+	assert.NoError(t, err)
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	assert.NoError(t, err)
+	configEnv := &common.ConfigEnvelope{}
+	assert.NoError(t, proto.Unmarshal(payload.Data, configEnv))
+
+	capabilitiesValue := &common.Capabilities{Capabilities: map[string]*common.Capability{}}
+	for _, c := range caps {
+		capabilitiesValue.Capabilities[c] = &common.Capability{}
+	}
+	configEnv.Config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Values[channelconfig.CapabilitiesKey] = &common.ConfigValue{
+		Value: utils.MarshalOrPanic(capabilitiesValue),
+	}
+	payload.Data = utils.MarshalOrPanic(configEnv)
+	env.Payload = utils.MarshalOrPanic(payload)
+
+	block := common.NewBlock(blockNum, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+		Value: utils.MarshalOrPanic(&common.LastConfig{Index: blockNum}),
+	})
+	return block
+}
+
+func testBlockWithLastConfig(blockNum, lastConfigBlockNum uint64) *common.Block {
+	block := common.NewBlock(blockNum, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(&common.Envelope{})}
+	block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+		Value: utils.MarshalOrPanic(&common.LastConfig{Index: lastConfigBlockNum}),
+	})
+	return block
+}
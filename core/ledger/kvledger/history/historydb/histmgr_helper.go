@@ -25,6 +25,17 @@ import (
 // CompositeKeySep is a nil byte used as a separator between different components of a composite key
 var CompositeKeySep = []byte{0x00}
 
+// pvtDataHistoryNsSep is used for deriving the namespace of the history records of the private data keys of a
+// collection. As a chaincode name cannot contain a '$', the derived namespace does not collide with a chaincode name
+const pvtDataHistoryNsSep = "$$h"
+
+// DerivePvtDataHistoryNs returns the namespace under which the history records of the private data keys of the
+// given collection are maintained. As only the hashes of the private data keys are present in a block, the history
+// records of a private data key are maintained against the hash of the key
+func DerivePvtDataHistoryNs(ns, coll string) string {
+	return ns + pvtDataHistoryNsSep + coll
+}
+
 //ConstructCompositeHistoryKey builds the History Key of namespace~key~blocknum~trannum
 // using an order preserving encoding so that history query results are ordered by height
 func ConstructCompositeHistoryKey(ns string, key string, blocknum uint64, trannum uint64) []byte {
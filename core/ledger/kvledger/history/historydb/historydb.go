@@ -19,6 +19,7 @@ package historydb
 import (
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/protos/common"
 )
@@ -27,6 +28,8 @@ import (
 type HistoryDBProvider interface {
 	// GetDBHandle returns a handle to a HistoryDB
 	GetDBHandle(id string) (HistoryDB, error)
+	// Drop drops all the data in the HistoryDB for the given id, including the savepoint
+	Drop(id string) error
 	// Close closes all the HistoryDB instances and releases any resources held by HistoryDBProvider
 	Close()
 }
@@ -34,8 +37,20 @@ type HistoryDBProvider interface {
 // HistoryDB - an interface that a history database should implement
 type HistoryDB interface {
 	NewHistoryQueryExecutor(blockStore blkstorage.BlockStore) (ledger.HistoryQueryExecutor, error)
-	Commit(block *common.Block) error
+	// Commit adds the history records for the writes of the valid transactions in the block and for the public
+	// state keys purged with the commit of the block, as their block-to-live elapsed
+	Commit(block *common.Block, purgedKeys []*txmgr.PurgedKey) error
 	GetLastSavepoint() (*version.Height, error)
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
+	// InitSavepointForSnapshot initializes the savepoint of an empty history db to the last block of the
+	// snapshot that the ledger is bootstrapped from. The history of the keys prior to the snapshot is not available
+	InitSavepointForSnapshot(savepoint *version.Height) error
+}
+
+// PvtDataRetriever retrieves the pvt data of a block. This is implemented by a block store that also maintains
+// the pvt data of the blocks, and is used by a history query executor for retrieving the values in the history
+// of a private data key
+type PvtDataRetriever interface {
+	GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error)
 }
@@ -13,11 +13,13 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("historyleveldb")
@@ -25,6 +27,10 @@ var logger = flogging.MustGetLogger("historyleveldb")
 var savePointKey = []byte{0x00}
 var emptyValue = []byte{}
 
+// purgeMarker is the value of the history record of a public state key that is purged as its block-to-live elapsed.
+// The record is placed after the records of the transactions of the block that purges the key
+var purgeMarker = []byte{0x01}
+
 // HistoryDBProvider implements interface HistoryDBProvider
 type HistoryDBProvider struct {
 	dbProvider *leveldbhelper.Provider
@@ -43,6 +49,21 @@ func (provider *HistoryDBProvider) GetDBHandle(dbName string) (historydb.History
 	return newHistoryDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
 }
 
+// Drop deletes all the keys of the named database from the underlying shared db
+func (provider *HistoryDBProvider) Drop(dbName string) error {
+	return provider.dbProvider.GetDBHandle(dbName).DeleteAll()
+}
+
+// DiskUsage implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (provider *HistoryDBProvider) DiskUsage(dbName string) (uint64, error) {
+	return provider.dbProvider.GetDBHandle(dbName).DiskUsage()
+}
+
+// Compact implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (provider *HistoryDBProvider) Compact(dbName string) error {
+	return provider.dbProvider.GetDBHandle(dbName).Compact()
+}
+
 // Close closes the underlying db
 func (provider *HistoryDBProvider) Close() {
 	provider.dbProvider.Close()
@@ -71,7 +92,7 @@ func (historyDB *historyDB) Close() {
 }
 
 // Commit implements method in HistoryDB interface
-func (historyDB *historyDB) Commit(block *common.Block) error {
+func (historyDB *historyDB) Commit(block *common.Block, purgedKeys []*txmgr.PurgedKey) error {
 
 	blockNo := block.Header.Number
 	//Set the starting tranNo to 0
@@ -141,6 +162,15 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 					// No value is required, write an empty byte array (emptyValue) since Put() of nil is not allowed
 					dbBatch.Put(compositeHistoryKey, emptyValue)
 				}
+
+				// add a history record for each write to a private data key, against the hash of the key
+				for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+					pvtDataHistoryNs := historydb.DerivePvtDataHistoryNs(ns, collHashedRwSet.CollectionName)
+					for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+						compositeHistoryKey := historydb.ConstructCompositeHistoryKey(pvtDataHistoryNs, string(kvWriteHash.KeyHash), blockNo, tranNo)
+						dbBatch.Put(compositeHistoryKey, emptyValue)
+					}
+				}
 			}
 
 		} else {
@@ -149,6 +179,13 @@ func (historyDB *historyDB) Commit(block *common.Block) error {
 		tranNo++
 	}
 
+	// add a history record for each public state key purged with the commit of the block, there
+	// is no transaction for such a record hence, the record follows the transactions of the block
+	for _, purgedKey := range purgedKeys {
+		compositeHistoryKey := historydb.ConstructCompositeHistoryKey(purgedKey.Namespace, purgedKey.Key, blockNo, tranNo)
+		dbBatch.Put(compositeHistoryKey, purgeMarker)
+	}
+
 	// add savepoint for recovery purpose
 	height := version.NewHeight(blockNo, tranNo)
 	dbBatch.Put(savePointKey, height.ToBytes())
@@ -174,7 +211,10 @@ func (historyDB *historyDB) GetLastSavepoint() (*version.Height, error) {
 	if err != nil || versionBytes == nil {
 		return nil, err
 	}
-	height, _ := version.NewHeightFromBytes(versionBytes)
+	height, _, err := version.NewHeightFromBytes(versionBytes)
+	if err != nil {
+		return nil, err
+	}
 	return height, nil
 }
 
@@ -204,8 +244,22 @@ func (historyDB *historyDB) CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtD
 		logger.Debugf("Recommitting block [%d] to history database", block.Header.Number)
 	}
 
-	if err := historyDB.Commit(block); err != nil {
+	// the keys purged with the commit of the block are known only while committing the block to the state database
+	// hence, the history records of the purges are not recovered
+	if err := historyDB.Commit(block, nil); err != nil {
 		return err
 	}
 	return nil
 }
+
+// InitSavepointForSnapshot implements method in HistoryDB interface
+func (historyDB *historyDB) InitSavepointForSnapshot(savepoint *version.Height) error {
+	existingSavepoint, err := historyDB.GetLastSavepoint()
+	if err != nil {
+		return err
+	}
+	if existingSavepoint != nil {
+		return errors.Errorf("history db for ledger [%s] is not empty", historyDB.dbName)
+	}
+	return historyDB.db.Put(savePointKey, savepoint.ToBytes(), true)
+}
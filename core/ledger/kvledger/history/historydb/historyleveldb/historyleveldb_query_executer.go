@@ -8,13 +8,18 @@ package historyleveldb
 
 import (
 	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
 
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history/historydb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	putils "github.com/hyperledger/fabric/protos/utils"
@@ -45,6 +50,65 @@ func (q *LevelHistoryDBQueryExecutor) GetHistoryForKey(namespace string, key str
 	return newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore), nil
 }
 
+// GetPrivateDataHistoryForKey implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetPrivateDataHistoryForKey(namespace, collection, key string) (commonledger.ResultsIterator, error) {
+	if !ledgerconfig.IsHistoryDBEnabled() {
+		return nil, errors.New("history database not enabled")
+	}
+
+	keyHash := ledgerutil.ComputeStringHash(key)
+	pvtDataHistoryNs := historydb.DerivePvtDataHistoryNs(namespace, collection)
+	compositeStartKey := historydb.ConstructPartialCompositeHistoryKey(pvtDataHistoryNs, string(keyHash), false)
+	compositeEndKey := historydb.ConstructPartialCompositeHistoryKey(pvtDataHistoryNs, string(keyHash), true)
+
+	// the values are retrieved only if the block store maintains the pvt data as well
+	pvtDataRetriever, _ := q.blockStore.(historydb.PvtDataRetriever)
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	return &pvtDataHistoryScanner{
+		historyScanner:   newHistoryScanner(compositeStartKey, namespace, key, dbItr, q.blockStore),
+		collection:       collection,
+		keyHash:          keyHash,
+		pvtDataRetriever: pvtDataRetriever,
+	}, nil
+}
+
+// GetHistoryForKeyWithOptions implements method in interface `ledger.HistoryQueryExecutor`
+func (q *LevelHistoryDBQueryExecutor) GetHistoryForKeyWithOptions(namespace, key string, options *ledger.HistoryQueryOptions) (ledger.QueryResultsIterator, error) {
+	if !ledgerconfig.IsHistoryDBEnabled() {
+		return nil, errors.New("history database not enabled")
+	}
+	if options == nil {
+		options = &ledger.HistoryQueryOptions{}
+	}
+
+	startBlockNum, startTxNum := options.StartBlockNum, options.StartTxNum
+	endBlockNum, endTxNum := options.EndBlockNum, options.EndTxNum
+	if options.Bookmark != "" {
+		// the bookmark is the height of the next history record to be returned
+		bookmarkBlockNum, bookmarkTxNum, err := decodeHistoryBookmark(options.Bookmark)
+		if err != nil {
+			return nil, err
+		}
+		if options.Descending {
+			endBlockNum, endTxNum = bookmarkBlockNum, bookmarkTxNum+1
+		} else {
+			startBlockNum, startTxNum = bookmarkBlockNum, bookmarkTxNum
+		}
+	}
+
+	compositePartialKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, false)
+	compositeStartKey := historydb.ConstructCompositeHistoryKey(namespace, key, startBlockNum, startTxNum)
+	compositeEndKey := historydb.ConstructPartialCompositeHistoryKey(namespace, key, true)
+	if endBlockNum != 0 || endTxNum != 0 {
+		compositeEndKey = historydb.ConstructCompositeHistoryKey(namespace, key, endBlockNum, endTxNum)
+	}
+
+	dbItr := q.historyDB.db.GetIterator(compositeStartKey, compositeEndKey)
+	scanner := newHistoryScanner(compositePartialKey, namespace, key, dbItr, q.blockStore)
+	scanner.descending = options.Descending
+	return &historyScannerWithOptions{historyScanner: scanner, limit: options.Limit}, nil
+}
+
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
 	compositePartialKey []byte //compositePartialKey includes namespace~key
@@ -52,17 +116,55 @@ type historyScanner struct {
 	key                 string
 	dbItr               iterator.Iterator
 	blockStore          blkstorage.BlockStore
+	descending          bool
+	dbItrPositioned     bool
 }
 
 func newHistoryScanner(compositePartialKey []byte, namespace string, key string,
 	dbItr iterator.Iterator, blockStore blkstorage.BlockStore) *historyScanner {
-	return &historyScanner{compositePartialKey, namespace, key, dbItr, blockStore}
+	return &historyScanner{
+		compositePartialKey: compositePartialKey,
+		namespace:           namespace,
+		key:                 key,
+		dbItr:               dbItr,
+		blockStore:          blockStore,
+	}
 }
 
 func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
+	blockNum, tranNum, ok := scanner.nextBlockNumTranNum()
+	if !ok {
+		return nil, nil
+	}
+
+	// The key was purged as its block-to-live elapsed, no transaction is associated with this history record
+	if bytes.Equal(scanner.dbItr.Value(), purgeMarker) {
+		logger.Debugf("Found purge of namespace:%s key:%s at block %d", scanner.namespace, scanner.key, blockNum)
+		return &queryresult.KeyModification{IsDelete: true}, nil
+	}
+
+	// Get the transaction from block storage that is associated with this history record
+	tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
+	queryResult, err := getKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.key)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s\n",
+		scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
+	return queryResult, nil
+}
+
+// nextBlockNumTranNum returns the block number and the transaction number of the next history record of the key.
+// A false is returned if there are no more history records for the key
+func (scanner *historyScanner) nextBlockNumTranNum() (uint64, uint64, bool) {
 	for {
-		if !scanner.dbItr.Next() {
-			return nil, nil
+		if !scanner.moveDBItr() {
+			return 0, 0, false
 		}
 		historyKey := scanner.dbItr.Key() // history key is in the form namespace~key~blocknum~trannum
 
@@ -91,64 +193,161 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 		tranNum, _ := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes[bytesConsumed:])
 		logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
 			scanner.namespace, scanner.key, blockNum, tranNum)
+		return blockNum, tranNum, true
+	}
+}
 
-		// Get the transaction from block storage that is associated with this history record
-		tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
-		if err != nil {
-			return nil, err
-		}
-
-		// Get the txid, key write value, timestamp, and delete indicator associated with this transaction
-		queryResult, err := getKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.key)
-		if err != nil {
-			return nil, err
-		}
-		logger.Debugf("Found historic key value for namespace:%s key:%s from transaction %s\n",
-			scanner.namespace, scanner.key, queryResult.(*queryresult.KeyModification).TxId)
-		return queryResult, nil
+// moveDBItr moves the db iterator to the next history record in the order of the scan
+func (scanner *historyScanner) moveDBItr() bool {
+	if !scanner.descending {
+		return scanner.dbItr.Next()
+	}
+	if !scanner.dbItrPositioned {
+		scanner.dbItrPositioned = true
+		return scanner.dbItr.Last()
 	}
+	return scanner.dbItr.Prev()
 }
 
 func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
-// getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
-func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
-	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
+// historyScannerWithOptions implements QueryResultsIterator for iterating through the history results of a key
+// as per the options of the query. A limit on the number of results is enforced and a bookmark is returned for
+// resuming the query from the next history record
+type historyScannerWithOptions struct {
+	*historyScanner
+	limit       int32
+	numReturned int32
+}
 
-	// extract action from the envelope
-	payload, err := putils.GetPayload(tranEnvelope)
+func (scanner *historyScannerWithOptions) Next() (commonledger.QueryResult, error) {
+	if scanner.limit > 0 && scanner.numReturned >= scanner.limit {
+		return nil, nil
+	}
+	queryResult, err := scanner.historyScanner.Next()
+	if err != nil || queryResult == nil {
+		return queryResult, err
+	}
+	scanner.numReturned++
+	return queryResult, nil
+}
+
+// GetBookmarkAndClose implements method in interface `ledger.QueryResultsIterator`. The bookmark is the height of the
+// next history record of the key, and is empty if there are no more history records
+func (scanner *historyScannerWithOptions) GetBookmarkAndClose() string {
+	bookmark := ""
+	if blockNum, tranNum, ok := scanner.nextBlockNumTranNum(); ok {
+		bookmark = encodeHistoryBookmark(blockNum, tranNum)
+	}
+	scanner.Close()
+	return bookmark
+}
+
+func encodeHistoryBookmark(blockNum, tranNum uint64) string {
+	return fmt.Sprintf("%d:%d", blockNum, tranNum)
+}
+
+func decodeHistoryBookmark(bookmark string) (uint64, uint64, error) {
+	parts := strings.Split(bookmark, ":")
+	if len(parts) != 2 {
+		return 0, 0, errors.Errorf("invalid bookmark [%s] for history query", bookmark)
+	}
+	blockNum, err := strconv.ParseUint(parts[0], 10, 64)
 	if err != nil {
-		return nil, err
+		return 0, 0, errors.Errorf("invalid bookmark [%s] for history query", bookmark)
+	}
+	tranNum, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid bookmark [%s] for history query", bookmark)
 	}
+	return blockNum, tranNum, nil
+}
 
-	tx, err := putils.GetTransaction(payload.Data)
+// pvtDataHistoryScanner implements ResultsIterator for iterating through the history results of a private data key.
+// Each result carries the hash of the value, and the value if the private data is available in the pvt data store
+// (i.e., the peer is a member of the collection and the private data has not been purged)
+type pvtDataHistoryScanner struct {
+	*historyScanner
+	collection       string
+	keyHash          []byte
+	pvtDataRetriever historydb.PvtDataRetriever
+}
+
+func (scanner *pvtDataHistoryScanner) Next() (commonledger.QueryResult, error) {
+	blockNum, tranNum, ok := scanner.nextBlockNumTranNum()
+	if !ok {
+		return nil, nil
+	}
+
+	tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
 	if err != nil {
 		return nil, err
 	}
 
-	_, respPayload, err := putils.GetPayloads(tx.Actions[0])
+	keyModification, err := getPvtDataKeyModificationFromTran(tranEnvelope, scanner.namespace, scanner.collection, scanner.keyHash)
 	if err != nil {
 		return nil, err
 	}
+	if !keyModification.IsDelete {
+		if keyModification.Value, err = scanner.retrieveValue(blockNum, tranNum); err != nil {
+			return nil, err
+		}
+	}
+	logger.Debugf("Found historic private data key value hash for namespace:%s collection:%s key:%s from transaction %s",
+		scanner.namespace, scanner.collection, scanner.key, keyModification.TxId)
+	return keyModification, nil
+}
 
-	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+// retrieveValue returns the value written to the private data key by the given transaction. A nil value is returned
+// if the private data is not available in the pvt data store
+func (scanner *pvtDataHistoryScanner) retrieveValue(blockNum, tranNum uint64) ([]byte, error) {
+	if scanner.pvtDataRetriever == nil {
+		return nil, nil
+	}
+	filter := ledger.NewPvtNsCollFilter()
+	filter.Add(scanner.namespace, scanner.collection)
+	txsPvtData, err := scanner.pvtDataRetriever.GetPvtDataByNum(blockNum, filter)
 	if err != nil {
 		return nil, err
 	}
+	for _, txPvtData := range txsPvtData {
+		if txPvtData.SeqInBlock != tranNum {
+			continue
+		}
+		txPvtRWSet, err := rwsetutil.TxPvtRwSetFromProtoMsg(txPvtData.WriteSet)
+		if err != nil {
+			return nil, err
+		}
+		for _, nsPvtRWSet := range txPvtRWSet.NsPvtRwSet {
+			for _, collPvtRWSet := range nsPvtRWSet.CollPvtRwSets {
+				if nsPvtRWSet.NameSpace != scanner.namespace || collPvtRWSet.CollectionName != scanner.collection {
+					continue
+				}
+				for _, kvWrite := range collPvtRWSet.KvRwSet.Writes {
+					if kvWrite.Key == scanner.key {
+						return kvWrite.Value, nil
+					}
+				}
+			}
+		}
+	}
+	return nil, nil
+}
 
-	txID := chdr.TxId
-	timestamp := chdr.Timestamp
-
-	txRWSet := &rwsetutil.TxRwSet{}
+// getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
+func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
+	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
 
-	// Get the Result from the Action and then Unmarshal
-	// it into a TxReadWriteSet using custom unmarshalling
-	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+	chdr, txRWSet, err := getTxRWSetFromTran(tranEnvelope)
+	if err != nil {
 		return nil, err
 	}
 
+	txID := chdr.TxId
+	timestamp := chdr.Timestamp
+
 	// look for the namespace and key by looping through the transaction's ReadWriteSets
 	for _, nsRWSet := range txRWSet.NsRwSets {
 		if nsRWSet.NameSpace == namespace {
@@ -165,3 +364,65 @@ func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string,
 	return nil, errors.New("namespace not found in transaction's ReadWriteSets")
 
 }
+
+// getPvtDataKeyModificationFromTran inspects a transaction for the hashed write to a given private data key.
+// The value in the returned KeyModification is not set
+func getPvtDataKeyModificationFromTran(tranEnvelope *common.Envelope, namespace, collection string, keyHash []byte) (*queryresult.KeyModification, error) {
+	chdr, txRWSet, err := getTxRWSetFromTran(tranEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace != namespace {
+			continue
+		}
+		for _, collHashedRwSet := range nsRWSet.CollHashedRwSets {
+			if collHashedRwSet.CollectionName != collection {
+				continue
+			}
+			for _, kvWriteHash := range collHashedRwSet.HashedRwSet.HashedWrites {
+				if bytes.Equal(kvWriteHash.KeyHash, keyHash) {
+					return &queryresult.KeyModification{TxId: chdr.TxId, ValueHash: kvWriteHash.ValueHash,
+						Timestamp: chdr.Timestamp, IsDelete: kvWriteHash.IsDelete}, nil
+				}
+			}
+			return nil, errors.New("key hash not found in collection's hashed writeset")
+		}
+		return nil, errors.New("collection not found in namespace's hashed ReadWriteSets")
+	}
+	return nil, errors.New("namespace not found in transaction's ReadWriteSets")
+}
+
+// getTxRWSetFromTran returns the channel header and the read-write set of a transaction
+func getTxRWSetFromTran(tranEnvelope *common.Envelope) (*common.ChannelHeader, *rwsetutil.TxRwSet, error) {
+	// extract action from the envelope
+	payload, err := putils.GetPayload(tranEnvelope)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := putils.GetTransaction(payload.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, respPayload, err := putils.GetPayloads(tx.Actions[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txRWSet := &rwsetutil.TxRwSet{}
+
+	// Get the Result from the Action and then Unmarshal
+	// it into a TxReadWriteSet using custom unmarshalling
+	if err = txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return nil, nil, err
+	}
+	return chdr, txRWSet, nil
+}
@@ -13,9 +13,13 @@ import (
 
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
@@ -47,7 +51,7 @@ func TestSavepoint(t *testing.T) {
 	assert.Equal(t, uint64(0), blockNum)
 
 	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
-	assert.NoError(t, env.testHistoryDB.Commit(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb, nil))
 	// read the savepoint, it should now exist and return a Height object with BlockNum 0
 	savepoint, err = env.testHistoryDB.GetLastSavepoint()
 	assert.NoError(t, err, "Error upon historyDatabase.GetLastSavepoint()")
@@ -61,7 +65,7 @@ func TestSavepoint(t *testing.T) {
 	simRes, _ := simulator.GetTxSimulationResults()
 	pubSimResBytes, _ := simRes.GetPubSimulationBytes()
 	block1 := bg.NextBlock([][]byte{pubSimResBytes})
-	assert.NoError(t, env.testHistoryDB.Commit(block1))
+	assert.NoError(t, env.testHistoryDB.Commit(block1, nil))
 	savepoint, err = env.testHistoryDB.GetLastSavepoint()
 	assert.NoError(t, err, "Error upon historyDatabase.GetLastSavepoint()")
 	assert.Equal(t, uint64(1), savepoint.BlockNum)
@@ -94,6 +98,23 @@ func TestSavepoint(t *testing.T) {
 	assert.Equal(t, uint64(3), blockNum)
 }
 
+func TestInitSavepointForSnapshot(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+
+	assert.NoError(t, env.testHistoryDB.InitSavepointForSnapshot(version.NewHeight(10, 0)))
+	savepoint, err := env.testHistoryDB.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(10, 0), savepoint)
+	status, blockNum, err := env.testHistoryDB.ShouldRecover(10)
+	assert.NoError(t, err)
+	assert.False(t, status)
+	assert.Equal(t, uint64(11), blockNum)
+
+	err = env.testHistoryDB.InitSavepointForSnapshot(version.NewHeight(20, 0))
+	assert.EqualError(t, err, "history db for ledger [TestHistoryDB] is not empty")
+}
+
 func TestHistory(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -105,7 +126,7 @@ func TestHistory(t *testing.T) {
 
 	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
 	assert.NoError(t, store1.AddBlock(gb))
-	assert.NoError(t, env.testHistoryDB.Commit(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb, nil))
 
 	//block1
 	txid := util2.GenerateUUID()
@@ -118,7 +139,7 @@ func TestHistory(t *testing.T) {
 	block1 := bg.NextBlock([][]byte{pubSimResBytes})
 	err = store1.AddBlock(block1)
 	assert.NoError(t, err)
-	err = env.testHistoryDB.Commit(block1)
+	err = env.testHistoryDB.Commit(block1, nil)
 	assert.NoError(t, err)
 
 	//block2 tran1
@@ -143,7 +164,7 @@ func TestHistory(t *testing.T) {
 	block2 := bg.NextBlock(simulationResults)
 	err = store1.AddBlock(block2)
 	assert.NoError(t, err)
-	err = env.testHistoryDB.Commit(block2)
+	err = env.testHistoryDB.Commit(block2, nil)
 	assert.NoError(t, err)
 
 	//block3
@@ -156,7 +177,7 @@ func TestHistory(t *testing.T) {
 	block3 := bg.NextBlock([][]byte{pubSimResBytes})
 	err = store1.AddBlock(block3)
 	assert.NoError(t, err)
-	err = env.testHistoryDB.Commit(block3)
+	err = env.testHistoryDB.Commit(block3, nil)
 	assert.NoError(t, err)
 	t.Logf("Inserted all 3 blocks")
 
@@ -193,6 +214,64 @@ func TestHistory(t *testing.T) {
 	assert.Equal(t, 4, count)
 }
 
+func TestHistoryForPurgedKey(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb, nil))
+
+	//block1
+	simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+	simulator.SetState("ns1", "key7", []byte("value1"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+	block1 := bg.NextBlock([][]byte{pubSimResBytes})
+	assert.NoError(t, store1.AddBlock(block1))
+	assert.NoError(t, env.testHistoryDB.Commit(block1, nil))
+
+	//block2 writes another key and purges key7
+	simulator, _ = env.txmgr.NewTxSimulator(util2.GenerateUUID())
+	simulator.SetState("ns1", "key8", []byte("value1"))
+	simulator.Done()
+	simRes, _ = simulator.GetTxSimulationResults()
+	pubSimResBytes, _ = simRes.GetPubSimulationBytes()
+	block2 := bg.NextBlock([][]byte{pubSimResBytes})
+	assert.NoError(t, store1.AddBlock(block2))
+	assert.NoError(t, env.testHistoryDB.Commit(block2, []*txmgr.PurgedKey{{Namespace: "ns1", Key: "key7"}}))
+
+	savepoint, err := env.testHistoryDB.GetLastSavepoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(2, 1), savepoint)
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+	itr, err := qhistory.GetHistoryForKey("ns1", "key7")
+	assert.NoError(t, err, "Error upon GetHistoryForKey()")
+	defer itr.Close()
+
+	kmod, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), kmod.(*queryresult.KeyModification).Value)
+	assert.False(t, kmod.(*queryresult.KeyModification).IsDelete)
+
+	// the purge has no transaction
+	kmod, err = itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, &queryresult.KeyModification{IsDelete: true}, kmod)
+
+	kmod, err = itr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, kmod)
+}
+
 func TestHistoryForInvalidTran(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -204,7 +283,7 @@ func TestHistoryForInvalidTran(t *testing.T) {
 
 	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
 	assert.NoError(t, store1.AddBlock(gb))
-	assert.NoError(t, env.testHistoryDB.Commit(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb, nil))
 
 	//block1
 	txid := util2.GenerateUUID()
@@ -223,7 +302,7 @@ func TestHistoryForInvalidTran(t *testing.T) {
 
 	err = store1.AddBlock(block1)
 	assert.NoError(t, err)
-	err = env.testHistoryDB.Commit(block1)
+	err = env.testHistoryDB.Commit(block1, nil)
 	assert.NoError(t, err)
 
 	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
@@ -237,6 +316,112 @@ func TestHistoryForInvalidTran(t *testing.T) {
 	assert.Nil(t, kmod)
 }
 
+func TestPrivateDataHistory(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb, nil))
+
+	pvtData := map[uint64][]*ledger.TxPvtData{}
+	commitBlock := func(update func(builder *rwsetutil.RWSetBuilder)) {
+		builder := rwsetutil.NewRWSetBuilder()
+		update(builder)
+		simRes, err := builder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		pubSimResBytes, err := simRes.GetPubSimulationBytes()
+		assert.NoError(t, err)
+		block := bg.NextBlock([][]byte{pubSimResBytes})
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block, nil))
+		pvtData[block.Header.Number] = []*ledger.TxPvtData{{SeqInBlock: 0, WriteSet: simRes.PvtSimulationResults}}
+	}
+	commitBlock(func(builder *rwsetutil.RWSetBuilder) {
+		builder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value1"))
+		builder.AddToPvtAndHashedWriteSet("ns1", "coll2", "key1", []byte("value-coll2"))
+		builder.AddToWriteSet("ns1", "key1", []byte("public-value"))
+	})
+	commitBlock(func(builder *rwsetutil.RWSetBuilder) {
+		builder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", []byte("value2"))
+	})
+	commitBlock(func(builder *rwsetutil.RWSetBuilder) {
+		builder.AddToPvtAndHashedWriteSet("ns1", "coll1", "key1", nil)
+	})
+	// the pvt data of block 2 is not available in the peer (e.g., purged)
+	delete(pvtData, 2)
+
+	retrieveHistory := func(blockStore blkstorage.BlockStore, ns, coll, key string) []*queryresult.KeyModification {
+		qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(blockStore)
+		assert.NoError(t, err)
+		itr, err := qhistory.GetPrivateDataHistoryForKey(ns, coll, key)
+		assert.NoError(t, err)
+		defer itr.Close()
+		var results []*queryresult.KeyModification
+		for {
+			kmod, err := itr.Next()
+			assert.NoError(t, err)
+			if kmod == nil {
+				return results
+			}
+			results = append(results, kmod.(*queryresult.KeyModification))
+		}
+	}
+
+	results := retrieveHistory(&blockStoreWithPvtData{store1, pvtData}, "ns1", "coll1", "key1")
+	assert.Len(t, results, 3)
+	assert.Equal(t, []byte("value1"), results[0].Value)
+	assert.Equal(t, util.ComputeHash([]byte("value1")), results[0].ValueHash)
+	assert.Nil(t, results[1].Value)
+	assert.Equal(t, util.ComputeHash([]byte("value2")), results[1].ValueHash)
+	assert.Nil(t, results[2].Value)
+	assert.Nil(t, results[2].ValueHash)
+	assert.True(t, results[2].IsDelete)
+	for _, result := range results {
+		assert.NotEmpty(t, result.TxId)
+		assert.NotNil(t, result.Timestamp)
+	}
+
+	results = retrieveHistory(&blockStoreWithPvtData{store1, pvtData}, "ns1", "coll2", "key1")
+	assert.Len(t, results, 1)
+	assert.Equal(t, []byte("value-coll2"), results[0].Value)
+
+	// only the hashes are returned if the block store does not maintain the pvt data
+	results = retrieveHistory(store1, "ns1", "coll1", "key1")
+	assert.Len(t, results, 3)
+	assert.Nil(t, results[0].Value)
+	assert.Equal(t, util.ComputeHash([]byte("value1")), results[0].ValueHash)
+
+	// the history of the public key is not affected by the private data keys
+	results = nil
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err)
+	itr, err := qhistory.GetHistoryForKey("ns1", "key1")
+	assert.NoError(t, err)
+	defer itr.Close()
+	for kmod, _ := itr.Next(); kmod != nil; kmod, _ = itr.Next() {
+		results = append(results, kmod.(*queryresult.KeyModification))
+	}
+	assert.Len(t, results, 1)
+	assert.Equal(t, []byte("public-value"), results[0].Value)
+
+	assert.Empty(t, retrieveHistory(store1, "ns1", "coll3", "key1"))
+}
+
+type blockStoreWithPvtData struct {
+	blkstorage.BlockStore
+	pvtData map[uint64][]*ledger.TxPvtData
+}
+
+func (s *blockStoreWithPvtData) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter) ([]*ledger.TxPvtData, error) {
+	return s.pvtData[blockNum], nil
+}
+
 //TestSavepoint tests that save points get written after each block and get returned via GetBlockNumfromSavepoint
 func TestHistoryDisabled(t *testing.T) {
 	env := newTestHistoryEnv(t)
@@ -256,7 +441,7 @@ func TestGenesisBlockNoError(t *testing.T) {
 	defer env.cleanup()
 	block, err := configtxtest.MakeGenesisBlock("test_chainid")
 	assert.NoError(t, err)
-	err = env.testHistoryDB.Commit(block)
+	err = env.testHistoryDB.Commit(block, nil)
 	assert.NoError(t, err)
 }
 
@@ -273,7 +458,7 @@ func TestHistoryWithKeyContainingNilBytes(t *testing.T) {
 
 	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
 	assert.NoError(t, store1.AddBlock(gb))
-	assert.NoError(t, env.testHistoryDB.Commit(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb, nil))
 
 	//block1
 	txid := util2.GenerateUUID()
@@ -285,7 +470,7 @@ func TestHistoryWithKeyContainingNilBytes(t *testing.T) {
 	block1 := bg.NextBlock([][]byte{pubSimResBytes})
 	err = store1.AddBlock(block1)
 	assert.NoError(t, err)
-	err = env.testHistoryDB.Commit(block1)
+	err = env.testHistoryDB.Commit(block1, nil)
 	assert.NoError(t, err)
 
 	//block2 tran1
@@ -311,7 +496,7 @@ func TestHistoryWithKeyContainingNilBytes(t *testing.T) {
 	block2 := bg.NextBlock(simulationResults)
 	err = store1.AddBlock(block2)
 	assert.NoError(t, err)
-	err = env.testHistoryDB.Commit(block2)
+	err = env.testHistoryDB.Commit(block2, nil)
 	assert.NoError(t, err)
 
 	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
@@ -321,6 +506,96 @@ func TestHistoryWithKeyContainingNilBytes(t *testing.T) {
 	testutilVerifyResults(t, qhistory, "ns1", "\x00key\x00\x01\x01\x15", []string{"dummyVal2"})
 }
 
+func TestHistoryWithOptions(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb, nil))
+
+	simulateTx := func(key string, value string) []byte {
+		simulator, _ := env.txmgr.NewTxSimulator(util2.GenerateUUID())
+		assert.NoError(t, simulator.SetState("ns1", key, []byte(value)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		return pubSimResBytes
+	}
+	// blocks 1 to 4 contain a write to the key each, block 5 contains two writes to the key and a write to another
+	// key <key\x00> that falls in the range of the scan
+	var blocks [][][]byte
+	for i := 1; i <= 4; i++ {
+		blocks = append(blocks, [][]byte{simulateTx("key", "value"+strconv.Itoa(i))})
+	}
+	blocks = append(blocks, [][]byte{simulateTx("key", "value5"), simulateTx("key\x00", "dummyVal"), simulateTx("key", "value6")})
+	for _, simulationResults := range blocks {
+		block := bg.NextBlock(simulationResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block, nil))
+	}
+
+	qhistory, err := env.testHistoryDB.NewHistoryQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewHistoryQueryExecutor")
+
+	retrieveValues := func(options *ledger.HistoryQueryOptions) ([]string, string) {
+		itr, err := qhistory.GetHistoryForKeyWithOptions("ns1", "key", options)
+		assert.NoError(t, err, "Error upon GetHistoryForKeyWithOptions()")
+		retrievedVals := []string{}
+		for {
+			kmod, err := itr.Next()
+			assert.NoError(t, err)
+			if kmod == nil {
+				break
+			}
+			retrievedVals = append(retrievedVals, string(kmod.(*queryresult.KeyModification).Value))
+		}
+		return retrievedVals, itr.GetBookmarkAndClose()
+	}
+
+	testCases := []struct {
+		name             string
+		options          *ledger.HistoryQueryOptions
+		expectedVals     []string
+		expectedBookmark string
+	}{
+		{"nil options", nil, []string{"value1", "value2", "value3", "value4", "value5", "value6"}, ""},
+		{"descending", &ledger.HistoryQueryOptions{Descending: true},
+			[]string{"value6", "value5", "value4", "value3", "value2", "value1"}, ""},
+		{"height range", &ledger.HistoryQueryOptions{StartBlockNum: 2, EndBlockNum: 5, EndTxNum: 2},
+			[]string{"value2", "value3", "value4", "value5"}, ""},
+		{"height range descending", &ledger.HistoryQueryOptions{StartBlockNum: 2, EndBlockNum: 5, EndTxNum: 2, Descending: true},
+			[]string{"value5", "value4", "value3", "value2"}, ""},
+		{"open start", &ledger.HistoryQueryOptions{StartBlockNum: 5, StartTxNum: 1},
+			[]string{"value6"}, ""},
+		{"first page", &ledger.HistoryQueryOptions{Limit: 4},
+			[]string{"value1", "value2", "value3", "value4"}, "5:0"},
+		{"last page", &ledger.HistoryQueryOptions{Limit: 4, Bookmark: "5:0"},
+			[]string{"value5", "value6"}, ""},
+		{"first page descending", &ledger.HistoryQueryOptions{Limit: 4, Descending: true},
+			[]string{"value6", "value5", "value4", "value3"}, "2:0"},
+		{"last page descending", &ledger.HistoryQueryOptions{Limit: 4, Descending: true, Bookmark: "2:0"},
+			[]string{"value2", "value1"}, ""},
+		{"page within height range", &ledger.HistoryQueryOptions{StartBlockNum: 2, EndBlockNum: 5, Limit: 2, Descending: true},
+			[]string{"value4", "value3"}, "2:0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			retrievedVals, bookmark := retrieveValues(tc.options)
+			assert.Equal(t, tc.expectedVals, retrievedVals)
+			assert.Equal(t, tc.expectedBookmark, bookmark)
+		})
+	}
+
+	_, err = qhistory.GetHistoryForKeyWithOptions("ns1", "key", &ledger.HistoryQueryOptions{Bookmark: "invalid"})
+	assert.EqualError(t, err, "invalid bookmark [invalid] for history query")
+}
+
 func testutilVerifyResults(t *testing.T, hqe ledger.HistoryQueryExecutor, ns, key string, expectedVals []string) {
 	itr, err := hqe.GetHistoryForKey(ns, key)
 	assert.NoError(t, err, "Error upon GetHistoryForKey()")
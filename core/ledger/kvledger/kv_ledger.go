@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
@@ -38,9 +39,12 @@ type kvLedger struct {
 	blockStore             *ledgerstorage.Store
 	txtmgmt                txmgr.TxMgr
 	historyDB              historydb.HistoryDB
+	versionedDB            privacyenabledstate.DB
+	configHistoryMgr       confighistory.Mgr
 	configHistoryRetriever ledger.ConfigHistoryRetriever
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
+	bookkeepingProvider    bookkeeping.Provider
 }
 
 // NewKVLedger constructs new `KVLedger`
@@ -58,15 +62,26 @@ func newKVLedger(
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying
 	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}}
+	l := &kvLedger{
+		ledgerID:            ledgerID,
+		blockStore:          blockStore,
+		historyDB:           historyDB,
+		versionedDB:         versionedDB,
+		configHistoryMgr:    configHistoryMgr,
+		blockAPIsRWLock:     &sync.RWMutex{},
+		bookkeepingProvider: bookkeeperProvider,
+	}
 
 	// TODO Move the function `GetChaincodeEventListener` to ledger interface and
 	// this functionality of regiserting for events to ledgermgmt package so that this
 	// is reused across other future ledger implementations
+	// The chaincode lifecycle events manager is initialized by ledgermgmt, a ledger that is opened
+	// without it (e.g., by the tests of this package) does not receive the events
 	ccEventListener := versionedDB.GetChaincodeEventListener()
-	logger.Debugf("Register state db for chaincode lifecycle events: %t", ccEventListener != nil)
-	if ccEventListener != nil {
-		cceventmgmt.GetMgr().Register(ledgerID, ccEventListener)
+	ccEventMgr := cceventmgmt.GetMgr()
+	logger.Debugf("Register state db for chaincode lifecycle events: %t", ccEventListener != nil && ccEventMgr != nil)
+	if ccEventListener != nil && ccEventMgr != nil {
+		ccEventMgr.Register(ledgerID, ccEventListener)
 	}
 	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{ledgerID, l, ccInfoProvider})
 	if err := l.initTxMgr(versionedDB, stateListeners, btlPolicy, bookkeeperProvider, ccInfoProvider); err != nil {
@@ -92,7 +107,8 @@ func newKVLedger(
 func (l *kvLedger) initTxMgr(versionedDB privacyenabledstate.DB, stateListeners []ledger.StateListener,
 	btlPolicy pvtdatapolicy.BTLPolicy, bookkeeperProvider bookkeeping.Provider, ccInfoProvider ledger.DeployedChaincodeInfoProvider) error {
 	var err error
-	l.txtmgmt, err = lockbasedtxmgr.NewLockBasedTxMgr(l.ledgerID, versionedDB, stateListeners, btlPolicy, bookkeeperProvider, ccInfoProvider)
+	l.txtmgmt, err = lockbasedtxmgr.NewLockBasedTxMgr(l.ledgerID, versionedDB, stateListeners, btlPolicy, bookkeeperProvider, ccInfoProvider,
+		&capabilitiesRetriever{ledgerID: l.ledgerID, blockStore: l.blockStore})
 	return err
 }
 
@@ -185,6 +201,7 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 	logger.Infof("Recommitting lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	var err error
 	var blockAndPvtdata *ledger.BlockAndPvtData
+	numBlocks := lastBlockNum - firstBlockNum + 1
 	for blockNumber := firstBlockNum; blockNumber <= lastBlockNum; blockNumber++ {
 		if blockAndPvtdata, err = l.GetPvtDataAndBlockByNum(blockNumber, nil); err != nil {
 			return err
@@ -194,15 +211,22 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 				return err
 			}
 		}
+		// report the progress at every 10 percent of the blocks, for tracking a long running recovery (e.g., a rebuild of the dbs)
+		if numRecommitted := blockNumber - firstBlockNum + 1; numRecommitted*10/numBlocks != (numRecommitted-1)*10/numBlocks {
+			logger.Infof("Recommitted [%d] of [%d] lost blocks (%d%%) for ledger [%s]",
+				numRecommitted, numBlocks, numRecommitted*100/numBlocks, l.ledgerID)
+		}
 	}
 	logger.Infof("Recommitted lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	return nil
 }
 
-// GetTransactionByID retrieves a transaction by id
+// GetTransactionByID retrieves a transaction by id. For a transaction that was committed before the snapshot
+// that the ledger was bootstrapped from or a transaction whose block has been pruned, the returned
+// ProcessedTransaction carries only the validation code
 func (l *kvLedger) GetTransactionByID(txID string) (*peer.ProcessedTransaction, error) {
 	tranEnv, err := l.blockStore.RetrieveTxByID(txID)
-	if err != nil {
+	if err != nil && err != blkstorage.ErrTxFromSnapshot && err != blkstorage.ErrBlockPruned {
 		return nil, err
 	}
 	txVResult, err := l.blockStore.RetrieveTxValidationCodeByTxID(txID)
@@ -266,9 +290,33 @@ func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode
 	return txValidationCode, err
 }
 
-//Prune prunes the blocks/transactions that satisfy the given policy
+//Prune prunes the blocks/transactions that satisfy the given policy. Only the policy `BlockRetentionPolicy` is
+//supported. The blocks that are not yet committed to the state db or the history db are never pruned, as these
+//blocks are required for recovering the dbs on the next start
 func (l *kvLedger) Prune(policy commonledger.PrunePolicy) error {
-	return errors.New("not yet implemented")
+	retentionPolicy, ok := policy.(*commonledger.BlockRetentionPolicy)
+	if !ok {
+		return errors.Errorf("unsupported prune policy [%T]", policy)
+	}
+	info, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if info.Height == 0 {
+		return nil
+	}
+	retainFromBlockNum := retentionPolicy.RetainFromBlockNum
+	for _, r := range []recoverable{l.txtmgmt, l.historyDB} {
+		recoverFlag, firstBlockNumToRecover, err := r.ShouldRecover(info.Height - 1)
+		if err != nil {
+			return err
+		}
+		if recoverFlag && firstBlockNumToRecover < retainFromBlockNum {
+			retainFromBlockNum = firstBlockNumToRecover
+		}
+	}
+	logger.Infof("Pruning the blocks below [%d] for ledger [%s]", retainFromBlockNum, l.ledgerID)
+	return l.blockStore.Prune(retainFromBlockNum)
 }
 
 // NewTxSimulator returns new `ledger.TxSimulator`
@@ -297,6 +345,10 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	block := pvtdataAndBlock.Block
 	blockNo := pvtdataAndBlock.Block.Header.Number
 
+	if err = l.addRetainedPvtData(pvtdataAndBlock); err != nil {
+		return err
+	}
+
 	startBlockProcessing := time.Now()
 	logger.Debugf("[%s] Validating state for block [%d]", l.ledgerID, blockNo)
 	txstatsInfo, err := l.txtmgmt.ValidateAndPrepare(pvtdataAndBlock, true)
@@ -325,7 +377,7 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	// although it has not been a bottleneck...no need to clutter the log with elapsed duration.
 	if ledgerconfig.IsHistoryDBEnabled() {
 		logger.Debugf("[%s] Committing block [%d] transactions to history database", l.ledgerID, blockNo)
-		if err := l.historyDB.Commit(block); err != nil {
+		if err := l.historyDB.Commit(block, l.txtmgmt.PurgedKeys()); err != nil {
 			panic(errors.WithMessage(err, "Error during commit to history db"))
 		}
 	}
@@ -348,6 +400,23 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	return nil
 }
 
+// addRetainedPvtData adds to the block the pvt data that is retained in the pvt data store for the block. The pvt data
+// is retained for a block that is committed again after the ledger has been rolled back, and this pvt data may no
+// longer be available from the other peers
+func (l *kvLedger) addRetainedPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) error {
+	retainedPvtData, err := l.blockStore.GetRetainedPvtData(pvtdataAndBlock.Block.Header.Number)
+	if err != nil || len(retainedPvtData) == 0 {
+		return err
+	}
+	if pvtdataAndBlock.PvtData == nil {
+		pvtdataAndBlock.PvtData = make(ledger.TxPvtDataMap)
+	}
+	for _, txPvtData := range retainedPvtData {
+		pvtdataAndBlock.PvtData[txPvtData.SeqInBlock] = txPvtData
+	}
+	return nil
+}
+
 func (l *kvLedger) updateBlockStats(
 	blockNum uint64,
 	blockProcessingTime time.Duration,
@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -34,8 +33,11 @@ var (
 	// ErrLedgerNotOpened is thrown by a CloseLedger call if a ledger with the given id has not been opened
 	ErrLedgerNotOpened = errors.New("ledger is not opened yet")
 
-	underConstructionLedgerKey = []byte("underConstructionLedgerKey")
-	ledgerKeyPrefix            = []byte("l")
+	underConstructionLedgerKey             = []byte("underConstructionLedgerKey")
+	underConstructionFromSnapshotLedgerKey = []byte("underConstructionFromSnapshotLedgerKey")
+	ledgerKeyPrefix                        = []byte("l")
+	rebuildDBsPhaseKeyPrefix               = []byte("rebuildDBsPhase/")
+	underDeletionKeyPrefix                 = []byte("underDeletion/")
 )
 
 // Provider implements interface ledger.PeerLedgerProvider
@@ -91,7 +93,10 @@ func (provider *Provider) Initialize(initializer *ledger.Initializer) error {
 	}
 	provider.stats = newStats(initializer.MetricsProvider)
 	provider.recoverUnderConstructionLedger()
-	return nil
+	if err := provider.completeInterruptedRemovals(); err != nil {
+		return err
+	}
+	return provider.completeInterruptedDrops()
 }
 
 // Create implements the corresponding method from interface ledger.PeerLedgerProvider
@@ -176,6 +181,12 @@ func (provider *Provider) openInternal(ledgerID string) (ledger.PeerLedger, erro
 	if err != nil {
 		return nil, err
 	}
+	// the dbs are in sync with the block store after opening the ledger and hence, the rebuild of the dbs
+	// (if was in progress) is complete
+	if err := provider.completeRebuildDBs(ledgerID); err != nil {
+		l.Close()
+		return nil, err
+	}
 	return l, nil
 }
 
@@ -202,7 +213,8 @@ func (provider *Provider) Close() {
 // recoverUnderConstructionLedger checks whether the under construction flag is set - this would be the case
 // if a crash had happened during creation of ledger and the ledger creation could have been left in intermediate
 // state. Recovery checks if the ledger was created and the genesis block was committed successfully then it completes
-// the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag
+// the last step of adding the ledger id to the list of created ledgers. Else, it clears the under construction flag.
+// A ledger that was being created from a snapshot is never completed; its partially imported data is dropped instead
 func (provider *Provider) recoverUnderConstructionLedger() {
 	logger.Debugf("Recovering under construction ledger")
 	ledgerID, err := provider.idStore.getUnderConstructionFlag()
@@ -212,6 +224,12 @@ func (provider *Provider) recoverUnderConstructionLedger() {
 		return
 	}
 	logger.Infof("ledger [%s] found as under construction", ledgerID)
+	fromSnapshot, err := provider.idStore.isUnderConstructionFromSnapshot()
+	panicOnErr(err, "Error while checking whether the ledger [%s] was being created from a snapshot", ledgerID)
+	if fromSnapshot {
+		provider.abortCreationFromSnapshot(ledgerID, errors.New("creation of the ledger from a snapshot did not complete"))
+		return
+	}
 	ledger, err := provider.openInternal(ledgerID)
 	panicOnErr(err, "Error while opening under construction ledger [%s]", ledgerID)
 	bcInfo, err := ledger.GetBlockchainInfo()
@@ -272,8 +290,26 @@ func (s *idStore) setUnderConstructionFlag(ledgerID string) error {
 	return s.db.Put(underConstructionLedgerKey, []byte(ledgerID), true)
 }
 
+func (s *idStore) setUnderConstructionFromSnapshotFlag(ledgerID string) error {
+	batch := &leveldb.Batch{}
+	batch.Put(underConstructionLedgerKey, []byte(ledgerID))
+	batch.Put(underConstructionFromSnapshotLedgerKey, []byte(ledgerID))
+	return s.db.WriteBatch(batch, true)
+}
+
 func (s *idStore) unsetUnderConstructionFlag() error {
-	return s.db.Delete(underConstructionLedgerKey, true)
+	batch := &leveldb.Batch{}
+	batch.Delete(underConstructionLedgerKey)
+	batch.Delete(underConstructionFromSnapshotLedgerKey)
+	return s.db.WriteBatch(batch, true)
+}
+
+func (s *idStore) isUnderConstructionFromSnapshot() (bool, error) {
+	val, err := s.db.Get(underConstructionFromSnapshotLedgerKey)
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
 }
 
 func (s *idStore) getUnderConstructionFlag() (string, error) {
@@ -284,6 +320,60 @@ func (s *idStore) getUnderConstructionFlag() (string, error) {
 	return string(val), nil
 }
 
+func (s *idStore) setRebuildDBsPhase(ledgerID string, phase byte) error {
+	return s.db.Put(s.encodeRebuildDBsPhaseKey(ledgerID), []byte{phase}, true)
+}
+
+func (s *idStore) unsetRebuildDBsPhase(ledgerID string) error {
+	return s.db.Delete(s.encodeRebuildDBsPhaseKey(ledgerID), true)
+}
+
+// getRebuildDBsPhase returns the phase of the rebuild of the dbs for the given ledger. A zero phase
+// is returned if the rebuild of the dbs is not in progress
+func (s *idStore) getRebuildDBsPhase(ledgerID string) (byte, error) {
+	val, err := s.db.Get(s.encodeRebuildDBsPhaseKey(ledgerID))
+	if err != nil || val == nil {
+		return 0, err
+	}
+	return val[0], nil
+}
+
+func (s *idStore) getLedgerIDsWithRebuildDBsPhase(phase byte) ([]string, error) {
+	var ids []string
+	itr := s.db.GetIterator(rebuildDBsPhaseKeyPrefix, append(append([]byte{}, rebuildDBsPhaseKeyPrefix...), 0xff))
+	defer itr.Release()
+	for itr.Next() {
+		if itr.Value()[0] == phase {
+			ids = append(ids, string(itr.Key()[len(rebuildDBsPhaseKeyPrefix):]))
+		}
+	}
+	return ids, errors.Wrap(itr.Error(), "error while iterating over the ledger ids with the rebuild of dbs in progress")
+}
+
+// markLedgerUnderDeletion removes the ledger from the list of created ledgers and records that the data
+// of the ledger is being deleted, in a single batch
+func (s *idStore) markLedgerUnderDeletion(ledgerID string) error {
+	batch := &leveldb.Batch{}
+	batch.Delete(s.encodeLedgerKey(ledgerID))
+	batch.Delete(s.encodeRebuildDBsPhaseKey(ledgerID))
+	batch.Put(s.encodeUnderDeletionKey(ledgerID), []byte{})
+	return s.db.WriteBatch(batch, true)
+}
+
+func (s *idStore) unmarkLedgerUnderDeletion(ledgerID string) error {
+	return s.db.Delete(s.encodeUnderDeletionKey(ledgerID), true)
+}
+
+func (s *idStore) getLedgerIDsUnderDeletion() ([]string, error) {
+	var ids []string
+	itr := s.db.GetIterator(underDeletionKeyPrefix, append(append([]byte{}, underDeletionKeyPrefix...), 0xff))
+	defer itr.Release()
+	for itr.Next() {
+		ids = append(ids, string(itr.Key()[len(underDeletionKeyPrefix):]))
+	}
+	return ids, errors.Wrap(itr.Error(), "error while iterating over the ledger ids under deletion")
+}
+
 func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
 	key := s.encodeLedgerKey(ledgerID)
 	var val []byte
@@ -300,6 +390,7 @@ func (s *idStore) createLedgerID(ledgerID string, gb *common.Block) error {
 	batch := &leveldb.Batch{}
 	batch.Put(key, val)
 	batch.Delete(underConstructionLedgerKey)
+	batch.Delete(underConstructionFromSnapshotLedgerKey)
 	return s.db.WriteBatch(batch, true)
 }
 
@@ -315,18 +406,13 @@ func (s *idStore) ledgerIDExists(ledgerID string) (bool, error) {
 
 func (s *idStore) getAllLedgerIds() ([]string, error) {
 	var ids []string
-	itr := s.db.GetIterator(nil, nil)
+	itr := s.db.GetIterator(ledgerKeyPrefix, []byte{ledgerKeyPrefix[0] + 1})
 	defer itr.Release()
-	itr.First()
-	for itr.Valid() {
-		if bytes.Equal(itr.Key(), underConstructionLedgerKey) {
-			continue
-		}
+	for itr.Next() {
 		id := string(s.decodeLedgerID(itr.Key()))
 		ids = append(ids, id)
-		itr.Next()
 	}
-	return ids, nil
+	return ids, errors.Wrap(itr.Error(), "error while iterating over ledger ids")
 }
 
 func (s *idStore) close() {
@@ -337,6 +423,14 @@ func (s *idStore) encodeLedgerKey(ledgerID string) []byte {
 	return append(ledgerKeyPrefix, []byte(ledgerID)...)
 }
 
+func (s *idStore) encodeRebuildDBsPhaseKey(ledgerID string) []byte {
+	return append(append([]byte{}, rebuildDBsPhaseKeyPrefix...), []byte(ledgerID)...)
+}
+
+func (s *idStore) encodeUnderDeletionKey(ledgerID string) []byte {
+	return append(append([]byte{}, underDeletionKeyPrefix...), []byte(ledgerID)...)
+}
+
 func (s *idStore) decodeLedgerID(key []byte) string {
 	return string(key[len(ledgerKeyPrefix):])
 }
@@ -7,17 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	lgr "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -119,6 +123,61 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 	assert.Equal(t, peer.TxValidationCode_VALID, validCode)
 }
 
+func TestKVLedgerPrune(t *testing.T) {
+	// a small block file size so that the blocks span across multiple block files
+	viper.Set("ledger.blockchain.maxBlockfileSize", 3000)
+	defer viper.Set("ledger.blockchain.maxBlockfileSize", 0)
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	var blocks []*common.Block
+	for i := 0; i < 10; i++ {
+		simulator, _ := ledger.NewTxSimulator(util.GenerateUUID())
+		simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimBytes})
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+		blocks = append(blocks, block)
+	}
+
+	err = ledger.Prune(nil)
+	assert.EqualError(t, err, "unsupported prune policy [<nil>]")
+	assert.NoError(t, ledger.Prune(&commonledger.BlockRetentionPolicy{RetainFromBlockNum: 8}))
+	bcInfo, err := ledger.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(11), bcInfo.Height)
+	assert.True(t, bcInfo.FirstBlockNum > 1 && bcInfo.FirstBlockNum <= 8, "unexpected first block num [%d]", bcInfo.FirstBlockNum)
+
+	_, err = ledger.GetBlockByNumber(1)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	_, err = ledger.GetBlocksIterator(1)
+	assert.Error(t, err)
+	b, err := ledger.GetBlockByNumber(10)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[9], b), "proto messages are not equal")
+
+	// a transaction in a pruned block is known only by its validation code
+	txEnv, err := putils.GetEnvelopeFromBlock(blocks[0].Data.Data[0])
+	assert.NoError(t, err)
+	payload, err := putils.GetPayload(txEnv)
+	assert.NoError(t, err)
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	assert.NoError(t, err)
+	processedTx, err := ledger.GetTransactionByID(chdr.TxId)
+	assert.NoError(t, err)
+	assert.Nil(t, processedTx.TransactionEnvelope)
+	assert.Equal(t, int32(peer.TxValidationCode_VALID), processedTx.ValidationCode)
+}
+
 func TestKVLedgerBlockStorageWithPvtdata(t *testing.T) {
 	t.Skip()
 	env := newTestEnv(t)
@@ -335,7 +394,7 @@ func testSyncStateAndHistoryDBWithBlockstore(t *testing.T) {
 	_, err = ledger.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata4, true)
 	assert.NoError(t, err)
 	assert.NoError(t, ledger.(*kvLedger).blockStore.CommitWithPvtData(blockAndPvtdata4))
-	assert.NoError(t, ledger.(*kvLedger).historyDB.Commit(blockAndPvtdata4.Block))
+	assert.NoError(t, ledger.(*kvLedger).historyDB.Commit(blockAndPvtdata4.Block, nil))
 
 	checkBCSummaryForTest(t, ledger,
 		&bcSummary{
@@ -551,6 +610,43 @@ func TestLedgerWithCouchDbEnabledWithBinaryAndJSONData(t *testing.T) {
 	}
 }
 
+func TestPrivateDataHistory(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t,
+		"ns", map[string]uint64{"coll": 0},
+	)
+	defer provider.Close()
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	assert.NoError(t, err)
+	defer ledger.Close()
+
+	blockAndPvtdata1 := prepareNextBlockForTest(t, ledger, bg, "SimulateForBlk1",
+		map[string]string{"key1": "value1.1"}, map[string]string{"key1": "pvtValue1.1"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata1))
+	// the pvt data of the second block is missing in the peer
+	blockAndPvtdata2, _ := prepareNextBlockWithMissingPvtDataForTest(t, ledger, bg, "SimulateForBlk2",
+		map[string]string{"key1": "value1.2"}, map[string]string{"key1": "pvtValue1.2"})
+	assert.NoError(t, ledger.CommitWithPvtData(blockAndPvtdata2))
+
+	qhistory, err := ledger.NewHistoryQueryExecutor()
+	assert.NoError(t, err)
+	itr, err := qhistory.GetPrivateDataHistoryForKey("ns", "coll", "key1")
+	assert.NoError(t, err)
+	defer itr.Close()
+	var results []*queryresult.KeyModification
+	for kmod, err := itr.Next(); kmod != nil; kmod, err = itr.Next() {
+		assert.NoError(t, err)
+		results = append(results, kmod.(*queryresult.KeyModification))
+	}
+	assert.Len(t, results, 2)
+	assert.Equal(t, []byte("pvtValue1.1"), results[0].Value)
+	assert.Equal(t, ledgerutil.ComputeStringHash("pvtValue1.1"), results[0].ValueHash)
+	assert.Nil(t, results[1].Value)
+	assert.Equal(t, ledgerutil.ComputeStringHash("pvtValue1.2"), results[1].ValueHash)
+}
+
 func prepareNextBlockWithMissingPvtDataForTest(t *testing.T, l lgr.PeerLedger, bg *testutil.BlockGenerator,
 	txid string, pubKVs map[string]string, pvtKVs map[string]string) (*lgr.BlockAndPvtData, *lgr.TxPvtData) {
 
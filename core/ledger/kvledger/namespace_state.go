@@ -0,0 +1,195 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/pubstatepurgemgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/storageutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/pkg/errors"
+)
+
+// systemNamespaces are the namespaces whose state is maintained by the peer itself, e.g., the chaincode
+// definitions, and hence cannot be imported
+var systemNamespaces = map[string]bool{
+	"lscc":       true,
+	"_lifecycle": true,
+	"cscc":       true,
+	"qscc":       true,
+	"escc":       true,
+	"vscc":       true,
+}
+
+// maxKeysInStateImportBatch limits the number of keys that are written to the statedb in a single batch
+// while importing the state of a namespace
+var maxKeysInStateImportBatch = 10000
+
+// ExportNamespaceState implements the function in the interface ledger.NamespaceStateManager
+func (l *kvLedger) ExportNamespaceState(namespace string, process func(*ledger.StateRecord) error) error {
+	if err := checkPublicNamespace(namespace); err != nil {
+		return err
+	}
+	l.blockAPIsRWLock.Lock()
+	defer l.blockAPIsRWLock.Unlock()
+
+	itr, err := l.versionedDB.GetStateRangeScanIteratorWithMetadata(namespace, "", "", nil)
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	numKeys := 0
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*statedb.VersionedKV)
+		metadata, err := storageutil.DeserializeMetadata(kv.Metadata)
+		if err != nil {
+			return errors.Wrapf(err, "error while deserializing the metadata of key [%s]", kv.Key)
+		}
+		record := &ledger.StateRecord{
+			Key:      kv.Key,
+			Value:    kv.Value,
+			BlockNum: kv.Version.BlockNum,
+			TxNum:    kv.Version.TxNum,
+			Metadata: metadata,
+		}
+		if err := process(record); err != nil {
+			return err
+		}
+		numKeys++
+	}
+	logger.Infof("Channel [%s]: Exported [%d] keys of namespace [%s]", l.ledgerID, numKeys, namespace)
+	return nil
+}
+
+// ImportNamespaceState implements the function in the interface ledger.NamespaceStateManager. The imported keys
+// retain the versions of the records so that all the peers that import the same records end up with the same state.
+// The keys that carry a block-to-live are added to the expiry schedule of the ledger. As the imported keys are
+// not written by a transaction, no entries are added to the history db for them
+func (l *kvLedger) ImportNamespaceState(namespace string, next func() (*ledger.StateRecord, error)) (uint64, error) {
+	if err := checkPublicNamespace(namespace); err != nil {
+		return 0, err
+	}
+	if systemNamespaces[namespace] {
+		return 0, errors.Errorf("importing the state of the system namespace [%s] is not allowed", namespace)
+	}
+	l.blockAPIsRWLock.Lock()
+	defer l.blockAPIsRWLock.Unlock()
+
+	savepoint, err := l.versionedDB.GetLatestSavePoint()
+	if err != nil {
+		return 0, err
+	}
+	if savepoint == nil {
+		return 0, errors.Errorf("state db for ledger [%s] is empty", l.ledgerID)
+	}
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	numImported, numExpired, numKeysInBatch := uint64(0), 0, 0
+	applyBatch := func() error {
+		if numKeysInBatch == 0 {
+			return nil
+		}
+		if err := l.versionedDB.ApplyPrivacyAwareUpdates(batch, savepoint); err != nil {
+			return err
+		}
+		if err := pubstatepurgemgmt.ScheduleExpiry(l.ledgerID, l.bookkeepingProvider, batch.PubUpdates); err != nil {
+			return err
+		}
+		batch = privacyenabledstate.NewUpdateBatch()
+		numKeysInBatch = 0
+		return nil
+	}
+
+	for {
+		record, err := next()
+		if err != nil {
+			return 0, err
+		}
+		if record == nil {
+			break
+		}
+		if record.Key == "" {
+			return 0, errors.New("key must not be empty")
+		}
+		if len(record.Value) == 0 {
+			return 0, errors.Errorf("value of key [%s] must not be empty", record.Key)
+		}
+		metadata, err := serializeStateRecordMetadata(record.Metadata)
+		if err != nil {
+			return 0, errors.WithMessage(err, fmt.Sprintf("error while serializing the metadata of key [%s]", record.Key))
+		}
+		vv := &statedb.VersionedValue{
+			Value:    record.Value,
+			Metadata: metadata,
+			Version:  version.NewHeight(record.BlockNum, record.TxNum),
+		}
+		if pubstatepurgemgmt.HasExpired(vv, savepoint.BlockNum) {
+			numExpired++
+			continue
+		}
+		batch.PubUpdates.PutValAndMetadata(namespace, record.Key, vv.Value, vv.Metadata, vv.Version)
+		numImported++
+		if numKeysInBatch++; numKeysInBatch == maxKeysInStateImportBatch {
+			if err := applyBatch(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := applyBatch(); err != nil {
+		return 0, err
+	}
+	if numExpired > 0 {
+		logger.Warningf("Channel [%s]: Skipped [%d] keys of namespace [%s] whose block-to-live has passed at block [%d]",
+			l.ledgerID, numExpired, namespace, savepoint.BlockNum)
+	}
+	logger.Infof("Channel [%s]: Imported [%d] keys of namespace [%s]", l.ledgerID, numImported, namespace)
+	return numImported, nil
+}
+
+// checkPublicNamespace returns an error if the namespace is empty or is the namespace of the private data or
+// of the hashes of the private data of a collection
+func checkPublicNamespace(namespace string) error {
+	if namespace == "" {
+		return errors.New("namespace must not be empty")
+	}
+	if strings.Contains(namespace, "$$") {
+		return errors.Errorf("namespace [%s] must not contain [$$]", namespace)
+	}
+	return nil
+}
+
+// serializeStateRecordMetadata serializes the metadata entries in the order of their names so that
+// all the peers that import the same records store the same bytes
+func serializeStateRecordMetadata(metadata map[string][]byte) ([]byte, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	var names []string
+	for name := range metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var entries []*kvrwset.KVMetadataEntry
+	for _, name := range names {
+		entries = append(entries, &kvrwset.KVMetadataEntry{Name: name, Value: metadata[name]})
+	}
+	return storageutil.SerializeMetadata(entries)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/capabilities"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/storageutil"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportNamespaceState(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	commitTx := func(ledger lgr.PeerLedger, bg *testutil.BlockGenerator, simulate func(lgr.TxSimulator)) {
+		simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		simulate(simulator)
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		require.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}))
+	}
+	noop := func(lgr.TxSimulator) {}
+	btlMetadata := map[string][]byte{storageutil.BlockToLiveMetadataKey: storageutil.EncodeBlockToLive(2)}
+
+	bg1, gb1 := testutil.NewBlockGenerator(t, "ledger1", false)
+	ledger1, err := provider.Create(gb1)
+	require.NoError(t, err)
+	defer ledger1.Close()
+	commitTx(ledger1, bg1, func(s lgr.TxSimulator) {
+		require.NoError(t, s.SetState("ns1", "key1", []byte("value1")))
+		require.NoError(t, s.SetState("ns1", "key2", []byte("value2")))
+		require.NoError(t, s.SetStateMetadata("ns1", "key2", map[string][]byte{"entry1": []byte("metadata1")}))
+		require.NoError(t, s.SetState("ns2", "key1", []byte("value1")))
+	})
+	commitTx(ledger1, bg1, func(s lgr.TxSimulator) {
+		require.NoError(t, s.SetState("ns1", "key3", []byte("value3")))
+		require.NoError(t, s.SetStateMetadata("ns1", "key3", btlMetadata))
+	})
+
+	var records []*lgr.StateRecord
+	err = ledger1.(lgr.NamespaceStateManager).ExportNamespaceState("ns1", func(record *lgr.StateRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*lgr.StateRecord{
+		{Key: "key1", Value: []byte("value1"), BlockNum: 1, TxNum: 0},
+		{Key: "key2", Value: []byte("value2"), BlockNum: 1, TxNum: 0, Metadata: map[string][]byte{"entry1": []byte("metadata1")}},
+		{Key: "key3", Value: []byte("value3"), BlockNum: 2, TxNum: 0, Metadata: btlMetadata},
+	}, records)
+
+	// an error returned by the processing function aborts the export
+	err = ledger1.(lgr.NamespaceStateManager).ExportNamespaceState("ns1", func(record *lgr.StateRecord) error {
+		return errors.New("process error")
+	})
+	assert.EqualError(t, err, "process error")
+
+	// the public state keys are purged only if the channel has the state expiry capability
+	genesisBlock, err := configtxtest.MakeGenesisBlock("ledger2")
+	require.NoError(t, err)
+	gb2 := testConfigBlockWithAppCapabilities(t, genesisBlock, 0, capabilities.ApplicationStateExpiry)
+	gb2.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = ledgerutil.NewTxValidationFlagsSetValue(1, peer.TxValidationCode_VALID)
+	bg2 := testutil.NewBlockGeneratorFromGenesis(t, gb2, false)
+	ledger2, err := provider.Create(gb2)
+	require.NoError(t, err)
+	defer ledger2.Close()
+	commitTx(ledger2, bg2, noop)
+	commitTx(ledger2, bg2, noop)
+
+	// key4 was committed at block 0 with a block-to-live of 1, i.e. it expired with the commit of block 2, and is skipped
+	importedRecords := append(records, &lgr.StateRecord{Key: "key4", Value: []byte("value4"), Metadata: map[string][]byte{
+		storageutil.BlockToLiveMetadataKey: storageutil.EncodeBlockToLive(1),
+	}})
+	numImported, err := ledger2.(lgr.NamespaceStateManager).ImportNamespaceState("ns1", recordsIterator(importedRecords))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), numImported)
+
+	var reexportedRecords []*lgr.StateRecord
+	err = ledger2.(lgr.NamespaceStateManager).ExportNamespaceState("ns1", func(record *lgr.StateRecord) error {
+		reexportedRecords = append(reexportedRecords, record)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, records, reexportedRecords)
+
+	// key3 expires with the commit of block 5 as per its block-to-live
+	commitTx(ledger2, bg2, noop)
+	commitTx(ledger2, bg2, noop)
+	verifyNamespaceState(t, ledger2, "ns1", "key3", []byte("value3"))
+	commitTx(ledger2, bg2, noop)
+	verifyNamespaceState(t, ledger2, "ns1", "key3", nil)
+	verifyNamespaceState(t, ledger2, "ns1", "key1", []byte("value1"))
+	verifyNamespaceState(t, ledger2, "ns2", "key1", nil)
+
+	stateManager := ledger2.(lgr.NamespaceStateManager)
+	_, err = stateManager.ImportNamespaceState("", recordsIterator(records))
+	assert.EqualError(t, err, "namespace must not be empty")
+	assert.EqualError(t, stateManager.ExportNamespaceState("", nil), "namespace must not be empty")
+	_, err = stateManager.ImportNamespaceState("ns1$$hcoll1", recordsIterator(records))
+	assert.EqualError(t, err, "namespace [ns1$$hcoll1] must not contain [$$]")
+	assert.EqualError(t, stateManager.ExportNamespaceState("ns1$$pcoll1", nil), "namespace [ns1$$pcoll1] must not contain [$$]")
+	for _, namespace := range []string{"lscc", "_lifecycle"} {
+		_, err = stateManager.ImportNamespaceState(namespace, recordsIterator(records))
+		assert.EqualError(t, err, "importing the state of the system namespace ["+namespace+"] is not allowed")
+	}
+	_, err = stateManager.ImportNamespaceState("ns1", recordsIterator([]*lgr.StateRecord{{Value: []byte("value")}}))
+	assert.EqualError(t, err, "key must not be empty")
+	_, err = stateManager.ImportNamespaceState("ns1", recordsIterator([]*lgr.StateRecord{{Key: "key"}}))
+	assert.EqualError(t, err, "value of key [key] must not be empty")
+	_, err = stateManager.ImportNamespaceState("ns1", func() (*lgr.StateRecord, error) {
+		return nil, errors.New("read error")
+	})
+	assert.EqualError(t, err, "read error")
+}
+
+func TestImportNamespaceStateInBatches(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	defaultMaxKeys := maxKeysInStateImportBatch
+	defer func() { maxKeysInStateImportBatch = defaultMaxKeys }()
+	maxKeysInStateImportBatch = 2
+
+	_, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer ledger.Close()
+
+	records := []*lgr.StateRecord{
+		{Key: "key1", Value: []byte("value1")},
+		{Key: "key2", Value: []byte("value2")},
+		{Key: "key3", Value: []byte("value3")},
+	}
+	numImported, err := ledger.(lgr.NamespaceStateManager).ImportNamespaceState("ns1", recordsIterator(records))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), numImported)
+	for _, record := range records {
+		verifyNamespaceState(t, ledger, "ns1", record.Key, record.Value)
+	}
+}
+
+func recordsIterator(records []*lgr.StateRecord) func() (*lgr.StateRecord, error) {
+	i := 0
+	return func() (*lgr.StateRecord, error) {
+		if i == len(records) {
+			return nil, nil
+		}
+		i++
+		return records[i-1], nil
+	}
+}
+
+func verifyNamespaceState(t *testing.T, ledger lgr.PeerLedger, namespace, key string, expectedValue []byte) {
+	qe, err := ledger.NewQueryExecutor()
+	require.NoError(t, err)
+	defer qe.Done()
+	val, err := qe.GetState(namespace, key)
+	require.NoError(t, err)
+	assert.Equal(t, expectedValue, val)
+}
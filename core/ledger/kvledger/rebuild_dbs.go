@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// the phases of rebuilding the dbs of a ledger. The phase is recorded in the id store so that
+// an interrupted rebuild is resumed from the phase it was in
+const (
+	// rebuildDBsPhaseDrop indicates that the dbs are being dropped. A part of the data may still be present in the dbs
+	rebuildDBsPhaseDrop = byte(1)
+	// rebuildDBsPhaseRecommit indicates that the dbs have been dropped and the blocks are being recommitted
+	rebuildDBsPhaseRecommit = byte(2)
+)
+
+// RebuildDBs implements the function in the interface `ledger.DBsRebuilder`. The state database, the history
+// database, and the bookkeeping of the ledger are dropped and regenerated by recommitting all the blocks in the
+// block store, via the same recovery that brings the dbs in sync with the block store when a ledger is opened.
+// If the rebuild gets interrupted, the dbs are dropped again only if the drop did not complete; otherwise, the
+// recommit of the blocks resumes from the savepoints of the dbs - either when this function is invoked again or
+// when the ledger is opened
+func (provider *Provider) RebuildDBs(ledgerID string) (uint64, error) {
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrNonExistingLedgerID
+	}
+	phase, err := provider.idStore.getRebuildDBsPhase(ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	switch phase {
+	case rebuildDBsPhaseRecommit:
+		logger.Infof("The dbs of ledger [%s] have already been dropped. Resuming the recommit of the blocks", ledgerID)
+	default:
+		if _, err := provider.checkDBsRebuildable(ledgerID); err != nil {
+			return 0, err
+		}
+		if err := provider.idStore.setRebuildDBsPhase(ledgerID, rebuildDBsPhaseDrop); err != nil {
+			return 0, err
+		}
+		if err := provider.dropDBs(ledgerID); err != nil {
+			return 0, err
+		}
+	}
+
+	// opening the ledger recommits the blocks to the dropped dbs and clears the rebuild phase
+	lgr, err := provider.openInternal(ledgerID)
+	if err != nil {
+		return 0, err
+	}
+	defer lgr.Close()
+	bcInfo, err := lgr.GetBlockchainInfo()
+	if err != nil {
+		return 0, err
+	}
+	logger.Infof("Rebuilt the dbs of ledger [%s] up to the height [%d]", ledgerID, bcInfo.Height)
+	return bcInfo.Height, nil
+}
+
+// checkDBsRebuildable checks that the block store contains all the blocks starting from the genesis block,
+// which is not the case for a ledger that is bootstrapped from a snapshot or a ledger whose blocks are pruned.
+// The blockchain info of the block store is returned
+func (provider *Provider) checkDBsRebuildable(ledgerID string) (*common.BlockchainInfo, error) {
+	blockStore, err := provider.ledgerStoreProvider.Open(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer blockStore.Shutdown()
+	bcInfo, err := blockStore.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	if bcInfo.FirstBlockNum != 0 {
+		return nil, errors.Errorf("the dbs of ledger [%s] cannot be rebuilt, as the blocks below block [%d] are not present in the "+
+			"block store (the ledger was bootstrapped from a snapshot or the blocks have been pruned)", ledgerID, bcInfo.FirstBlockNum)
+	}
+	return bcInfo, nil
+}
+
+// dropDBs drops the data of the ledger from the state database, the history database, and the bookkeeping,
+// and then moves the rebuild of the dbs to the recommit phase
+func (provider *Provider) dropDBs(ledgerID string) error {
+	logger.Infof("Dropping the state database, the history database, and the bookkeeping of ledger [%s]", ledgerID)
+	if err := provider.vdbProvider.Drop(ledgerID); err != nil {
+		return errors.WithMessage(err, "error while dropping the state database")
+	}
+	if err := provider.historydbProvider.Drop(ledgerID); err != nil {
+		return errors.WithMessage(err, "error while dropping the history database")
+	}
+	if err := provider.bookkeepingProvider.Drop(ledgerID); err != nil {
+		return errors.WithMessage(err, "error while dropping the bookkeeping")
+	}
+	return provider.idStore.setRebuildDBsPhase(ledgerID, rebuildDBsPhaseRecommit)
+}
+
+func (provider *Provider) completeRebuildDBs(ledgerID string) error {
+	phase, err := provider.idStore.getRebuildDBsPhase(ledgerID)
+	if err != nil || phase == 0 {
+		return err
+	}
+	return provider.idStore.unsetRebuildDBsPhase(ledgerID)
+}
+
+// completeInterruptedDrops drops the dbs of the ledgers for which a rebuild of the dbs was interrupted
+// while the dbs were being dropped. This is required before opening these ledgers, as the partially
+// dropped dbs are not consistent with their savepoints
+func (provider *Provider) completeInterruptedDrops() error {
+	ledgerIDs, err := provider.idStore.getLedgerIDsWithRebuildDBsPhase(rebuildDBsPhaseDrop)
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		logger.Infof("Found an interrupted drop of the dbs of ledger [%s]", ledgerID)
+		if err := provider.dropDBs(ledgerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
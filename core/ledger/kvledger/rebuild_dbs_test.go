@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildDBs(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		require.NoError(t, simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i))))
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		require.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}))
+	}
+	ledger.Close()
+
+	// add a key to the state db that is not present in any block, which is expected to be removed by the rebuild
+	addStaleKey := func(p *Provider) {
+		vdb, err := p.vdbProvider.GetDBHandle("testLedger")
+		require.NoError(t, err)
+		batch := privacyenabledstate.NewUpdateBatch()
+		batch.PubUpdates.Put("ns1", "staleKey", []byte("staleValue"), version.NewHeight(3, 1))
+		require.NoError(t, vdb.ApplyPrivacyAwareUpdates(batch, version.NewHeight(3, 1)))
+	}
+	verifyLedger := func(p lgr.PeerLedgerProvider) {
+		ledger, err := p.Open("testLedger")
+		require.NoError(t, err)
+		defer ledger.Close()
+		qe, err := ledger.NewQueryExecutor()
+		require.NoError(t, err)
+		defer qe.Done()
+		val, err := qe.GetState("ns1", "key1")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value2"), val)
+		val, err = qe.GetState("ns1", "staleKey")
+		require.NoError(t, err)
+		assert.Nil(t, val)
+
+		hqe, err := ledger.NewHistoryQueryExecutor()
+		require.NoError(t, err)
+		itr, err := hqe.GetHistoryForKey("ns1", "key1")
+		require.NoError(t, err)
+		defer itr.Close()
+		numEntries := 0
+		for res, err := itr.Next(); res != nil; res, err = itr.Next() {
+			require.NoError(t, err)
+			numEntries++
+		}
+		assert.Equal(t, 3, numEntries)
+	}
+
+	addStaleKey(provider.(*Provider))
+	rebuilder := provider.(lgr.DBsRebuilder)
+	height, err := rebuilder.RebuildDBs("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), height)
+	verifyLedger(provider)
+	phase, err := provider.(*Provider).idStore.getRebuildDBsPhase("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), phase)
+	_, err = rebuilder.RebuildDBs("non-existing-ledger")
+	assert.Equal(t, ErrNonExistingLedgerID, err)
+
+	// simulate a crash while the dbs are being dropped; the drop is completed when the provider is initialized
+	addStaleKey(provider.(*Provider))
+	require.NoError(t, provider.(*Provider).idStore.setRebuildDBsPhase("testLedger", rebuildDBsPhaseDrop))
+	provider.Close()
+	provider = testutilNewProvider(t)
+	phase, err = provider.(*Provider).idStore.getRebuildDBsPhase("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, rebuildDBsPhaseRecommit, phase)
+	savepoint, err := getStateDBSavepoint(provider.(*Provider), "testLedger")
+	require.NoError(t, err)
+	assert.Nil(t, savepoint)
+
+	// the recommit of the blocks resumes, and the rebuild completes, when the ledger is opened
+	verifyLedger(provider)
+	phase, err = provider.(*Provider).idStore.getRebuildDBsPhase("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), phase)
+	provider.Close()
+}
+
+func TestRebuildDBsResumesRecommit(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	require.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{[]byte("tx")})}))
+	ledger.Close()
+
+	// the dbs are already dropped in an earlier interrupted rebuild; the rebuild is expected to not drop the dbs again
+	require.NoError(t, provider.(*Provider).idStore.setRebuildDBsPhase("testLedger", rebuildDBsPhaseRecommit))
+	vdb, err := provider.(*Provider).vdbProvider.GetDBHandle("testLedger")
+	require.NoError(t, err)
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	require.NoError(t, vdb.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 0)))
+
+	height, err := provider.(lgr.DBsRebuilder).RebuildDBs("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), height)
+	val, err := vdb.GetState("ns1", "key1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val.Value)
+}
+
+func getStateDBSavepoint(provider *Provider, ledgerID string) (*version.Height, error) {
+	vdb, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	return vdb.GetLatestSavePoint()
+}
@@ -0,0 +1,78 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/pkg/errors"
+)
+
+// RemoveLedger implements the function in the interface `ledger.LedgerRemover`. The ledger is removed from the list
+// of the created ledgers and recorded as under deletion in a single step, and then the data of the ledger is deleted
+// from all the stores. If the removal gets interrupted, the deletion of the data is completed when the provider is
+// initialized next time
+func (provider *Provider) RemoveLedger(ledgerID string) error {
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNonExistingLedgerID
+	}
+	logger.Infof("Removing ledger [%s]", ledgerID)
+	if err := provider.idStore.markLedgerUnderDeletion(ledgerID); err != nil {
+		return err
+	}
+	if err := provider.deleteLedgerData(ledgerID); err != nil {
+		return err
+	}
+	logger.Infof("Removed ledger [%s]", ledgerID)
+	return nil
+}
+
+// deleteLedgerData deletes the data of the ledger from all the stores and then clears the under deletion mark
+func (provider *Provider) deleteLedgerData(ledgerID string) error {
+	if err := provider.dropStores(ledgerID); err != nil {
+		return err
+	}
+	return provider.idStore.unmarkLedgerUnderDeletion(ledgerID)
+}
+
+// dropStores deletes the data of the ledger from the block store, the pvt data store, the state database,
+// the history database, the bookkeeping, and the config history
+func (provider *Provider) dropStores(ledgerID string) error {
+	if err := provider.ledgerStoreProvider.Remove(ledgerID); err != nil {
+		return errors.WithMessage(err, "error while removing the block store")
+	}
+	if err := provider.vdbProvider.Drop(ledgerID); err != nil {
+		return errors.WithMessage(err, "error while dropping the state database")
+	}
+	if err := provider.historydbProvider.Drop(ledgerID); err != nil {
+		return errors.WithMessage(err, "error while dropping the history database")
+	}
+	if err := provider.bookkeepingProvider.Drop(ledgerID); err != nil {
+		return errors.WithMessage(err, "error while dropping the bookkeeping")
+	}
+	if err := provider.configHistoryMgr.Drop(ledgerID); err != nil {
+		return errors.WithMessage(err, "error while dropping the config history")
+	}
+	return nil
+}
+
+// completeInterruptedRemovals deletes the data of the ledgers whose removal was interrupted
+func (provider *Provider) completeInterruptedRemovals() error {
+	ledgerIDs, err := provider.idStore.getLedgerIDsUnderDeletion()
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		logger.Infof("Found an interrupted removal of ledger [%s]", ledgerID)
+		if err := provider.deleteLedgerData(ledgerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
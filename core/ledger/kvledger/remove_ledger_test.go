@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+
+	createLedger := func(ledgerID string) {
+		bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+		ledger, err := provider.Create(gb)
+		require.NoError(t, err)
+		defer ledger.Close()
+		simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		require.NoError(t, simulator.SetState("ns1", "key1", []byte("value1")))
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		require.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}))
+	}
+	verifyLedger := func(ledgerID string, expectedHeight uint64, expectedValue []byte) {
+		ledger, err := provider.Open(ledgerID)
+		require.NoError(t, err)
+		defer ledger.Close()
+		bcInfo, err := ledger.GetBlockchainInfo()
+		require.NoError(t, err)
+		assert.Equal(t, expectedHeight, bcInfo.Height)
+		qe, err := ledger.NewQueryExecutor()
+		require.NoError(t, err)
+		defer qe.Done()
+		val, err := qe.GetState("ns1", "key1")
+		require.NoError(t, err)
+		assert.Equal(t, expectedValue, val)
+	}
+
+	createLedger("ledger1")
+	createLedger("ledger2")
+	remover := provider.(lgr.LedgerRemover)
+	require.NoError(t, remover.RemoveLedger("ledger1"))
+	ledgerIDs, err := provider.List()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ledger2"}, ledgerIDs)
+	_, err = provider.Open("ledger1")
+	assert.Equal(t, ErrNonExistingLedgerID, err)
+	assert.Equal(t, ErrNonExistingLedgerID, remover.RemoveLedger("ledger1"))
+	verifyLedger("ledger2", 2, []byte("value1"))
+
+	// a ledger with the id of a removed ledger starts afresh
+	bg, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	ledger.Close()
+	verifyLedger("ledger1", 1, nil)
+
+	// simulate a crash after the ledger is marked as under deletion; the removal is completed when the provider is initialized
+	createdLedger, err := provider.Open("ledger1")
+	require.NoError(t, err)
+	require.NoError(t, createdLedger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{[]byte("tx")})}))
+	createdLedger.Close()
+	require.NoError(t, provider.(*Provider).idStore.markLedgerUnderDeletion("ledger1"))
+	provider.Close()
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	ids, err := provider.(*Provider).idStore.getLedgerIDsUnderDeletion()
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	blockStore, err := provider.(*Provider).ledgerStoreProvider.Open("ledger1")
+	require.NoError(t, err)
+	bcInfo, err := blockStore.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), bcInfo.Height)
+	blockStore.Shutdown()
+}
@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/pkg/errors"
+)
+
+// RollbackLedger implements the function in the interface `ledger.LedgerRollbacker`. The blocks above the given block
+// number are removed from the block store, and the state database, the history database, and the bookkeeping of the
+// ledger are dropped so that these are rebuilt from the retained blocks when the ledger is opened next time. Before the
+// block store is rolled back, the rebuild of the dbs is recorded in the drop phase, so that the dbs are dropped even if
+// the rollback gets interrupted. The pvt data store is not rolled back; the pvt data retained in the pvt data store is
+// used when the removed blocks are committed again (e.g., when the blocks are pulled from the other peers via gossip)
+func (provider *Provider) RollbackLedger(ledgerID string, blockNum uint64) error {
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNonExistingLedgerID
+	}
+	bcInfo, err := provider.checkDBsRebuildable(ledgerID)
+	if err != nil {
+		return err
+	}
+	if blockNum+1 >= bcInfo.Height {
+		return errors.Errorf("cannot roll back ledger [%s] to block [%d] as the height of the ledger is [%d]", ledgerID, blockNum, bcInfo.Height)
+	}
+
+	logger.Infof("Rolling back ledger [%s] to block [%d]", ledgerID, blockNum)
+	if err := provider.idStore.setRebuildDBsPhase(ledgerID, rebuildDBsPhaseDrop); err != nil {
+		return err
+	}
+	if err := provider.ledgerStoreProvider.RollbackBlockStore(ledgerID, blockNum); err != nil {
+		return err
+	}
+	if err := provider.dropDBs(ledgerID); err != nil {
+		return err
+	}
+	logger.Infof("Rolled back ledger [%s] to block [%d]. The dbs of the ledger are rebuilt when the ledger is opened", ledgerID, blockNum)
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	rollbacker := provider.(lgr.LedgerRollbacker)
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	var blocks []*common.Block
+	for i := 0; i < 4; i++ {
+		simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		require.NoError(t, simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i))))
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		block := bg.NextBlock([][]byte{pubSimBytes})
+		require.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+		blocks = append(blocks, block)
+	}
+	ledger.Close()
+
+	assert.Equal(t, ErrNonExistingLedgerID, rollbacker.RollbackLedger("non-existing-ledger", 1))
+	assert.EqualError(t, rollbacker.RollbackLedger("testLedger", 4),
+		"cannot roll back ledger [testLedger] to block [4] as the height of the ledger is [5]")
+
+	require.NoError(t, rollbacker.RollbackLedger("testLedger", 2))
+	phase, err := provider.(*Provider).idStore.getRebuildDBsPhase("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, rebuildDBsPhaseRecommit, phase)
+
+	verifyState := func(ledger lgr.PeerLedger, expectedHeight uint64, expectedValue string, expectedHistoryEntries int) {
+		bcInfo, err := ledger.GetBlockchainInfo()
+		require.NoError(t, err)
+		assert.Equal(t, expectedHeight, bcInfo.Height)
+		qe, err := ledger.NewQueryExecutor()
+		require.NoError(t, err)
+		defer qe.Done()
+		val, err := qe.GetState("ns1", "key1")
+		require.NoError(t, err)
+		assert.Equal(t, []byte(expectedValue), val)
+
+		hqe, err := ledger.NewHistoryQueryExecutor()
+		require.NoError(t, err)
+		itr, err := hqe.GetHistoryForKey("ns1", "key1")
+		require.NoError(t, err)
+		defer itr.Close()
+		numEntries := 0
+		for res, err := itr.Next(); res != nil; res, err = itr.Next() {
+			require.NoError(t, err)
+			numEntries++
+		}
+		assert.Equal(t, expectedHistoryEntries, numEntries)
+	}
+
+	// the dbs are rebuilt from the retained blocks when the ledger is opened
+	ledger, err = provider.Open("testLedger")
+	require.NoError(t, err)
+	defer ledger.Close()
+	verifyState(ledger, 3, "value1", 2)
+	phase, err = provider.(*Provider).idStore.getRebuildDBsPhase("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), phase)
+
+	// the removed blocks can be committed again, as these would be pulled from the other peers
+	for _, block := range blocks[2:] {
+		require.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+	}
+	verifyState(ledger, 5, "value3", 4)
+}
@@ -0,0 +1,318 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/pubstatepurgemgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/pvtstatepurgemgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotFileFormat = byte(1)
+	// SnapshotSignableMetadataFileName is the name of the file in a snapshot that contains the metadata
+	// of the snapshot, including the hashes of all the other files in the snapshot
+	SnapshotSignableMetadataFileName = "_snapshot_signable_metadata.json"
+	// snapshotBootstrappingBlocksFileName is the name of the file in a snapshot that carries the last block and
+	// the last config block of the ledger. These blocks are needed by a peer that bootstraps a channel from the snapshot
+	snapshotBootstrappingBlocksFileName = "bootstrapping_blocks.data"
+)
+
+// SnapshotSignableMetadata is the metadata of a ledger snapshot. The hashes of the snapshot files are included
+// so that the metadata file can be signed by the peer admin and the content of the snapshot can be verified against it
+type SnapshotSignableMetadata struct {
+	ChannelName            string            `json:"channel_name"`
+	LastBlockNumber        uint64            `json:"last_block_number"`
+	LastBlockHashInHex     string            `json:"last_block_hash"`
+	PreviousBlockHashInHex string            `json:"previous_block_hash"`
+	FilesAndHashes         map[string]string `json:"snapshot_files_raw_hashes"`
+}
+
+// GenerateSnapshot exports the snapshot of the ledger in the given dir. The dir is created by this function and
+// is expected to not exist. The snapshot is taken at the current height of the ledger; the commit of the next
+// block is blocked until the snapshot generation completes
+func (l *kvLedger) GenerateSnapshot(dir string) error {
+	if _, err := os.Stat(dir); err == nil {
+		return errors.Errorf("snapshot dir [%s] already exists", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "error while creating snapshot dir [%s]", dir)
+	}
+	if err := l.generateSnapshot(dir); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	return nil
+}
+
+func (l *kvLedger) generateSnapshot(dir string) error {
+	l.blockAPIsRWLock.Lock()
+	defer l.blockAPIsRWLock.Unlock()
+
+	bcInfo, err := l.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if bcInfo.Height == 0 {
+		return errors.Errorf("ledger [%s] is empty", l.ledgerID)
+	}
+	lastBlockNum := bcInfo.Height - 1
+	filesAndHashes := map[string][]byte{}
+	addHashes := func(m map[string][]byte) {
+		for f, h := range m {
+			filesAndHashes[f] = h
+		}
+	}
+
+	txIDsHashes, err := l.blockStore.ExportTxIds(dir)
+	if err != nil {
+		return err
+	}
+	addHashes(txIDsHashes)
+	stateHashes, err := l.versionedDB.ExportPubStateAndPvtStateHashes(dir)
+	if err != nil {
+		return err
+	}
+	addHashes(stateHashes)
+	configHistoryHashes, err := l.configHistoryMgr.ExportConfigHistory(dir, l.ledgerID)
+	if err != nil {
+		return err
+	}
+	addHashes(configHistoryHashes)
+	bootstrappingBlocksHash, err := l.exportBootstrappingBlocks(dir, lastBlockNum)
+	if err != nil {
+		return err
+	}
+	filesAndHashes[snapshotBootstrappingBlocksFileName] = bootstrappingBlocksHash
+
+	metadata := &SnapshotSignableMetadata{
+		ChannelName:            l.ledgerID,
+		LastBlockNumber:        lastBlockNum,
+		LastBlockHashInHex:     hex.EncodeToString(bcInfo.CurrentBlockHash),
+		PreviousBlockHashInHex: hex.EncodeToString(bcInfo.PreviousBlockHash),
+		FilesAndHashes:         map[string]string{},
+	}
+	for f, h := range filesAndHashes {
+		metadata.FilesAndHashes[f] = hex.EncodeToString(h)
+	}
+	metadataBytes, err := json.MarshalIndent(metadata, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "error while marshalling snapshot metadata")
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, SnapshotSignableMetadataFileName), metadataBytes, 0644); err != nil {
+		return errors.Wrap(err, "error while writing snapshot metadata file")
+	}
+	logger.Infof("Generated snapshot for ledger [%s] at block [%d] in dir [%s]", l.ledgerID, lastBlockNum, dir)
+	return nil
+}
+
+// exportBootstrappingBlocks writes the last block and the last config block (if different from the last block)
+func (l *kvLedger) exportBootstrappingBlocks(dir string, lastBlockNum uint64) ([]byte, error) {
+	lastBlock, err := l.blockStore.RetrieveBlockByNumber(lastBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	blocks := []*common.Block{lastBlock}
+	lastConfigBlockNum, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	if lastConfigBlockNum != lastBlockNum {
+		lastConfigBlock, err := l.blockStore.RetrieveBlockByNumber(lastConfigBlockNum)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, lastConfigBlock)
+	}
+
+	dataFile, err := snapshot.CreateFile(filepath.Join(dir, snapshotBootstrappingBlocksFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer dataFile.Close()
+	if err := dataFile.EncodeUVarint(uint64(len(blocks))); err != nil {
+		return nil, err
+	}
+	for _, b := range blocks {
+		if err := dataFile.EncodeProtoMessage(b); err != nil {
+			return nil, err
+		}
+	}
+	return dataFile.Done()
+}
+
+func loadBootstrappingBlocks(snapshotDir string) ([]*common.Block, error) {
+	dataFile, err := snapshot.OpenFile(filepath.Join(snapshotDir, snapshotBootstrappingBlocksFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer dataFile.Close()
+	numBlocks, err := dataFile.DecodeUVarInt()
+	if err != nil {
+		return nil, err
+	}
+	var blocks []*common.Block
+	for i := uint64(0); i < numBlocks; i++ {
+		b := &common.Block{}
+		if err := dataFile.DecodeProtoMessage(b); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
+
+// LoadSnapshotMetadata reads the metadata of the snapshot in the given dir and verifies that the hashes
+// of the snapshot files match the ones recorded in the metadata
+func LoadSnapshotMetadata(snapshotDir string) (*SnapshotSignableMetadata, error) {
+	metadataBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, SnapshotSignableMetadataFileName))
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading snapshot metadata file")
+	}
+	metadata := &SnapshotSignableMetadata{}
+	if err := json.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling snapshot metadata")
+	}
+	for fileName, expectedHash := range metadata.FilesAndHashes {
+		hash, err := computeFileHash(filepath.Join(snapshotDir, fileName))
+		if err != nil {
+			return nil, err
+		}
+		if hex.EncodeToString(hash) != expectedHash {
+			return nil, errors.Errorf("hash of the snapshot file [%s] does not match the hash recorded in the snapshot metadata", fileName)
+		}
+	}
+	return metadata, nil
+}
+
+func computeFileHash(filePath string) ([]byte, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while opening the snapshot file [%s]", filePath)
+	}
+	defer f.Close()
+	hasher := snapshot.NewHashFunc()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshot file [%s]", filePath)
+	}
+	return hasher.Sum(nil), nil
+}
+
+// CreateFromSnapshot implements the corresponding method from interface ledger.PeerLedgerProvider.
+// This function follows the same pattern as the function `Create` for setting the under construction flag.
+// As the data imported from a snapshot cannot be rolled back, on a failure, the partially imported data
+// is dropped from all the stores before clearing the flag. If a crash happens during the import, the same
+// is done on the next start
+func (provider *Provider) CreateFromSnapshot(snapshotDir string) (ledger.PeerLedger, error) {
+	metadata, err := LoadSnapshotMetadata(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	ledgerID := metadata.ChannelName
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrLedgerIDExists
+	}
+	if err = provider.idStore.setUnderConstructionFromSnapshotFlag(ledgerID); err != nil {
+		return nil, err
+	}
+	lastConfigBlock, err := provider.importFromSnapshot(ledgerID, snapshotDir, metadata)
+	if err != nil {
+		provider.abortCreationFromSnapshot(ledgerID, err)
+		return nil, err
+	}
+	lgr, err := provider.openInternal(ledgerID)
+	if err != nil {
+		provider.abortCreationFromSnapshot(ledgerID, err)
+		return nil, err
+	}
+	// the btl policy looks up the collection configurations in the imported state and hence,
+	// the expiry schedule of the private data hashes is built once the ledger is opened
+	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(
+		&collectionInfoRetriever{ledgerID, lgr, provider.initializer.DeployedChaincodeInfoProvider},
+	)
+	if err := pvtstatepurgemgmt.ImportExpirySchedule(ledgerID, btlPolicy, provider.bookkeepingProvider, snapshotDir); err != nil {
+		lgr.Close()
+		provider.abortCreationFromSnapshot(ledgerID, err)
+		return nil, err
+	}
+	panicOnErr(provider.idStore.createLedgerID(ledgerID, lastConfigBlock), "Error while marking ledger as created")
+	logger.Infof("Created ledger [%s] from snapshot at block [%d]", ledgerID, metadata.LastBlockNumber)
+	return lgr, nil
+}
+
+// abortCreationFromSnapshot drops the data imported into the stores for the ledger and clears the under construction flag
+func (provider *Provider) abortCreationFromSnapshot(ledgerID string, err error) {
+	logger.Errorf("Error while creating ledger [%s] from snapshot. Dropping the imported data and unsetting under construction flag. Error: %+v",
+		ledgerID, err)
+	panicOnErr(provider.dropStores(ledgerID), "Error while dropping the imported data for ledger id [%s]", ledgerID)
+	panicOnErr(provider.idStore.unsetUnderConstructionFlag(), "Error while unsetting under construction flag")
+}
+
+// importFromSnapshot loads the data from the snapshot into the block store, state db, config history,
+// and history db and returns the last config block carried in the snapshot
+func (provider *Provider) importFromSnapshot(ledgerID, snapshotDir string, metadata *SnapshotSignableMetadata) (*common.Block, error) {
+	lastBlockHash, err := hex.DecodeString(metadata.LastBlockHashInHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while decoding last block hash from snapshot metadata")
+	}
+	previousBlockHash, err := hex.DecodeString(metadata.PreviousBlockHashInHex)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while decoding previous block hash from snapshot metadata")
+	}
+	bootstrappingBlocks, err := loadBootstrappingBlocks(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	lastConfigBlock := bootstrappingBlocks[len(bootstrappingBlocks)-1]
+
+	if err := provider.ledgerStoreProvider.ImportFromSnapshot(ledgerID, snapshotDir,
+		&blkstorage.SnapshotInfo{
+			LastBlockNum:        metadata.LastBlockNumber,
+			LastBlockHash:       lastBlockHash,
+			PreviousBlockHash:   previousBlockHash,
+			BootstrappingBlocks: bootstrappingBlocks,
+		},
+	); err != nil {
+		return nil, err
+	}
+	savepoint := version.NewHeight(metadata.LastBlockNumber, 0)
+	if err := provider.vdbProvider.ImportFromSnapshot(ledgerID, savepoint, snapshotDir); err != nil {
+		return nil, err
+	}
+	if err := pubstatepurgemgmt.ImportExpirySchedule(ledgerID, provider.bookkeepingProvider, snapshotDir); err != nil {
+		return nil, err
+	}
+	if err := provider.configHistoryMgr.ImportConfigHistory(snapshotDir, ledgerID); err != nil {
+		return nil, err
+	}
+	historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := historyDB.InitSavepointForSnapshot(savepoint); err != nil {
+		return nil, err
+	}
+	return lastConfigBlock, nil
+}
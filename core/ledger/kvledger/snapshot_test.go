@@ -0,0 +1,261 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSnapshotAndCreateFromSnapshot(t *testing.T) {
+	snapshotRootDir, err := ioutil.TempDir("", "kvledger-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotRootDir)
+	snapshotDir := filepath.Join(snapshotRootDir, "testLedger")
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	sourceLedger, err := provider.Create(gb)
+	require.NoError(t, err)
+
+	simulator, err := sourceLedger.NewTxSimulator(util.GenerateUUID())
+	require.NoError(t, err)
+	require.NoError(t, simulator.SetState("ns1", "key1", []byte("value1")))
+	require.NoError(t, simulator.SetState("ns1", "key2", []byte("value2")))
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimBytes, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+	block1 := bg.NextBlock([][]byte{pubSimBytes})
+	require.NoError(t, sourceLedger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block1}))
+
+	require.NoError(t, sourceLedger.(lgr.SnapshotGenerator).GenerateSnapshot(snapshotDir))
+	err = sourceLedger.(lgr.SnapshotGenerator).GenerateSnapshot(snapshotDir)
+	assert.EqualError(t, err, "snapshot dir ["+snapshotDir+"] already exists")
+	sourceBCInfo, err := sourceLedger.GetBlockchainInfo()
+	require.NoError(t, err)
+	sourceLedger.Close()
+	provider.Close()
+
+	metadata, err := LoadSnapshotMetadata(snapshotDir)
+	require.NoError(t, err)
+	assert.Equal(t, "testLedger", metadata.ChannelName)
+	assert.Equal(t, uint64(1), metadata.LastBlockNumber)
+	assert.Len(t, metadata.FilesAndHashes, 5)
+
+	// bootstrap a ledger on a different peer from the snapshot
+	bootstrappedEnv := newTestEnv(t)
+	defer bootstrappedEnv.cleanup()
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	bootstrappedLedger, err := provider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	_, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.Equal(t, ErrLedgerIDExists, err)
+	ledgerIDs, err := provider.List()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"testLedger"}, ledgerIDs)
+
+	bcInfo, err := bootstrappedLedger.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, sourceBCInfo.Height, bcInfo.Height)
+	assert.Equal(t, sourceBCInfo.CurrentBlockHash, bcInfo.CurrentBlockHash)
+	assert.Equal(t, sourceBCInfo.PreviousBlockHash, bcInfo.PreviousBlockHash)
+	assert.Equal(t, sourceBCInfo.Height, bcInfo.FirstBlockNum)
+	qe, err := bootstrappedLedger.NewQueryExecutor()
+	require.NoError(t, err)
+	val, err := qe.GetState("ns1", "key1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	qe.Done()
+
+	// a transaction committed before the snapshot is known only by its validation code
+	txID := extractTxIDFromBlock(t, block1)
+	processedTx, err := bootstrappedLedger.GetTransactionByID(txID)
+	require.NoError(t, err)
+	assert.Nil(t, processedTx.TransactionEnvelope)
+	assert.Equal(t, int32(peer.TxValidationCode_VALID), processedTx.ValidationCode)
+	lastBlock, err := bootstrappedLedger.GetBlockByNumber(1)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(block1, lastBlock))
+
+	// the bootstrapped ledger continues with the next block
+	simulator, err = bootstrappedLedger.NewTxSimulator(util.GenerateUUID())
+	require.NoError(t, err)
+	require.NoError(t, simulator.SetState("ns1", "key1", []byte("value1-updated")))
+	simulator.Done()
+	simRes, err = simulator.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubSimBytes, err = simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+	block2 := bg.NextBlock([][]byte{pubSimBytes})
+	require.NoError(t, bootstrappedLedger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block2}))
+	bcInfo, err = bootstrappedLedger.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), bcInfo.Height)
+	bootstrappedLedger.Close()
+
+	// reopen the bootstrapped ledger
+	bootstrappedLedger, err = provider.Open("testLedger")
+	require.NoError(t, err)
+	defer bootstrappedLedger.Close()
+	qe, err = bootstrappedLedger.NewQueryExecutor()
+	require.NoError(t, err)
+	defer qe.Done()
+	val, err = qe.GetState("ns1", "key1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1-updated"), val)
+}
+
+func TestCreateFromSnapshotWithPvtdataExpiry(t *testing.T) {
+	snapshotRootDir, err := ioutil.TempDir("", "kvledger-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotRootDir)
+	snapshotDir := filepath.Join(snapshotRootDir, "testLedger")
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 1})
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	sourceLedger, err := provider.Create(gb)
+	require.NoError(t, err)
+	// the hash of the private key committed in block 1 expires with the commit of block 3
+	block1 := prepareNextBlockForTest(t, sourceLedger, bg, "txid-1",
+		map[string]string{"pubKey1": "pubValue1"}, map[string]string{"pvtKey1": "pvtValue1"})
+	require.NoError(t, sourceLedger.CommitWithPvtData(block1))
+	require.NoError(t, sourceLedger.(lgr.SnapshotGenerator).GenerateSnapshot(snapshotDir))
+	sourceLedger.Close()
+	provider.Close()
+
+	// the expiry schedule cannot be built as the collection is not known to the bootstrapping peer
+	bootstrappedEnv := newTestEnv(t)
+	defer bootstrappedEnv.cleanup()
+	provider = testutilNewProviderWithCollectionConfig(t, "another-ns", map[string]uint64{"coll": 1})
+	_, err = provider.CreateFromSnapshot(snapshotDir)
+	assert.Error(t, err)
+	ledgerIDs, err := provider.List()
+	require.NoError(t, err)
+	assert.Empty(t, ledgerIDs)
+	underConstructionLedgerID, err := provider.(*Provider).idStore.getUnderConstructionFlag()
+	require.NoError(t, err)
+	assert.Equal(t, "", underConstructionLedgerID)
+	provider.Close()
+
+	// the data imported by the failed attempt has been dropped and hence, the creation can be retried
+	provider = testutilNewProviderWithCollectionConfig(t, "ns", map[string]uint64{"coll": 1})
+	defer provider.Close()
+	bootstrappedLedger, err := provider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	defer bootstrappedLedger.Close()
+
+	checkPvtdataHash := func(expectedToExist bool) {
+		qe, err := bootstrappedLedger.NewQueryExecutor()
+		require.NoError(t, err)
+		defer qe.Done()
+		hash, err := qe.GetPrivateDataHash("ns", "coll", "pvtKey1")
+		require.NoError(t, err)
+		if expectedToExist {
+			assert.Equal(t, util.ComputeSHA256([]byte("pvtValue1")), hash)
+		} else {
+			assert.Nil(t, hash)
+		}
+	}
+	checkPvtdataHash(true)
+	block2 := prepareNextBlockForTest(t, bootstrappedLedger, bg, "txid-2", map[string]string{"pubKey2": "pubValue2"}, nil)
+	require.NoError(t, bootstrappedLedger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block2.Block}))
+	checkPvtdataHash(true)
+	block3 := prepareNextBlockForTest(t, bootstrappedLedger, bg, "txid-3", map[string]string{"pubKey3": "pubValue3"}, nil)
+	require.NoError(t, bootstrappedLedger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block3.Block}))
+	checkPvtdataHash(false)
+}
+
+func TestRecoveryOfInterruptedCreationFromSnapshot(t *testing.T) {
+	snapshotRootDir, err := ioutil.TempDir("", "kvledger-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotRootDir)
+	snapshotDir := filepath.Join(snapshotRootDir, "testLedger")
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	sourceLedger, err := provider.Create(gb)
+	require.NoError(t, err)
+	require.NoError(t, sourceLedger.(lgr.SnapshotGenerator).GenerateSnapshot(snapshotDir))
+	sourceLedger.Close()
+	provider.Close()
+
+	// simulate a crash after the data has been imported but before the ledger is marked as created
+	bootstrappedEnv := newTestEnv(t)
+	defer bootstrappedEnv.cleanup()
+	provider = testutilNewProvider(t)
+	metadata, err := LoadSnapshotMetadata(snapshotDir)
+	require.NoError(t, err)
+	require.NoError(t, provider.(*Provider).idStore.setUnderConstructionFromSnapshotFlag("testLedger"))
+	_, err = provider.(*Provider).importFromSnapshot("testLedger", snapshotDir, metadata)
+	require.NoError(t, err)
+	provider.Close()
+
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	underConstructionLedgerID, err := provider.(*Provider).idStore.getUnderConstructionFlag()
+	require.NoError(t, err)
+	assert.Equal(t, "", underConstructionLedgerID)
+	ledgerIDs, err := provider.List()
+	require.NoError(t, err)
+	assert.Empty(t, ledgerIDs)
+
+	bootstrappedLedger, err := provider.CreateFromSnapshot(snapshotDir)
+	require.NoError(t, err)
+	bootstrappedLedger.Close()
+}
+
+func TestLoadSnapshotMetadataDetectsTampering(t *testing.T) {
+	snapshotRootDir, err := ioutil.TempDir("", "kvledger-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotRootDir)
+	snapshotDir := filepath.Join(snapshotRootDir, "testLedger")
+
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	l, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer l.Close()
+	require.NoError(t, l.(lgr.SnapshotGenerator).GenerateSnapshot(snapshotDir))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, snapshotBootstrappingBlocksFileName), []byte("tampered"), 0644))
+	_, err = LoadSnapshotMetadata(snapshotDir)
+	assert.EqualError(t, err, "hash of the snapshot file ["+snapshotBootstrappingBlocksFileName+"] does not match the hash recorded in the snapshot metadata")
+}
+
+func extractTxIDFromBlock(t *testing.T, block *common.Block) string {
+	txEnv, err := putils.GetEnvelopeFromBlock(block.Data.Data[0])
+	require.NoError(t, err)
+	payload, err := putils.GetPayload(txEnv)
+	require.NoError(t, err)
+	chdr, err := putils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	return chdr.TxId
+}
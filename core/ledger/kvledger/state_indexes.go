@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+// AddStateIndex implements the function in the interface ledger.StateIndexManager
+func (l *kvLedger) AddStateIndex(chaincodeName, collection string, indexDefinition []byte) (*ledger.StateIndex, error) {
+	if chaincodeName == "" {
+		return nil, errors.New("chaincode name must not be empty")
+	}
+	if len(indexDefinition) == 0 {
+		return nil, errors.New("index definition must not be empty")
+	}
+	indexInfo, err := l.versionedDB.CreateIndex(chaincodeName, collection, indexDefinition)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Channel [%s]: Added index [%s] in design document [%s] for chaincode [%s], collection [%s]",
+		l.ledgerID, indexInfo.Name, indexInfo.DesignDoc, chaincodeName, collection)
+	return toStateIndex(indexInfo), nil
+}
+
+// ListStateIndexes implements the function in the interface ledger.StateIndexManager
+func (l *kvLedger) ListStateIndexes(chaincodeName, collection string) ([]*ledger.StateIndex, error) {
+	if chaincodeName == "" {
+		return nil, errors.New("chaincode name must not be empty")
+	}
+	indexInfos, err := l.versionedDB.ListIndexes(chaincodeName, collection)
+	if err != nil {
+		return nil, err
+	}
+	var stateIndexes []*ledger.StateIndex
+	for _, indexInfo := range indexInfos {
+		stateIndexes = append(stateIndexes, toStateIndex(indexInfo))
+	}
+	return stateIndexes, nil
+}
+
+// DropStateIndex implements the function in the interface ledger.StateIndexManager
+func (l *kvLedger) DropStateIndex(chaincodeName, collection, designDoc, indexName string) error {
+	if chaincodeName == "" || designDoc == "" || indexName == "" {
+		return errors.New("chaincode name, design document, and index name must not be empty")
+	}
+	if err := l.versionedDB.DropIndex(chaincodeName, collection, designDoc, indexName); err != nil {
+		return err
+	}
+	logger.Infof("Channel [%s]: Dropped index [%s] in design document [%s] for chaincode [%s], collection [%s]",
+		l.ledgerID, indexName, designDoc, chaincodeName, collection)
+	return nil
+}
+
+func toStateIndex(indexInfo *statedb.IndexInfo) *ledger.StateIndex {
+	return &ledger.StateIndex{
+		DesignDoc:     indexInfo.DesignDoc,
+		Name:          indexInfo.Name,
+		Definition:    indexInfo.Definition,
+		Building:      indexInfo.Building,
+		BuildProgress: indexInfo.BuildProgress,
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type indexManageableDB struct {
+	privacyenabledstate.DB
+	indexes map[string][]*statedb.IndexInfo
+}
+
+func (db *indexManageableDB) CreateIndex(namespace, collection string, indexDefinition []byte) (*statedb.IndexInfo, error) {
+	indexInfo := &statedb.IndexInfo{DesignDoc: "ddoc1", Name: "index1", Definition: string(indexDefinition), Building: true, BuildProgress: 20}
+	db.indexes[namespace+"/"+collection] = append(db.indexes[namespace+"/"+collection], indexInfo)
+	return indexInfo, nil
+}
+
+func (db *indexManageableDB) ListIndexes(namespace, collection string) ([]*statedb.IndexInfo, error) {
+	return db.indexes[namespace+"/"+collection], nil
+}
+
+func (db *indexManageableDB) DropIndex(namespace, collection, designDoc, indexName string) error {
+	if _, ok := db.indexes[namespace+"/"+collection]; !ok {
+		return errors.Errorf("index [%s] not found", indexName)
+	}
+	delete(db.indexes, namespace+"/"+collection)
+	return nil
+}
+
+func TestStateIndexManager(t *testing.T) {
+	l := &kvLedger{
+		ledgerID:    "ledger1",
+		versionedDB: &indexManageableDB{indexes: map[string][]*statedb.IndexInfo{}},
+	}
+	var indexManager lgr.StateIndexManager = l
+
+	stateIndex, err := indexManager.AddStateIndex("cc1", "coll1", []byte(`{"index":{"fields":["owner"]}}`))
+	require.NoError(t, err)
+	expectedStateIndex := &lgr.StateIndex{
+		DesignDoc:     "ddoc1",
+		Name:          "index1",
+		Definition:    `{"index":{"fields":["owner"]}}`,
+		Building:      true,
+		BuildProgress: 20,
+	}
+	assert.Equal(t, expectedStateIndex, stateIndex)
+
+	stateIndexes, err := indexManager.ListStateIndexes("cc1", "coll1")
+	require.NoError(t, err)
+	assert.Equal(t, []*lgr.StateIndex{expectedStateIndex}, stateIndexes)
+	stateIndexes, err = indexManager.ListStateIndexes("cc1", "")
+	require.NoError(t, err)
+	assert.Nil(t, stateIndexes)
+
+	require.NoError(t, indexManager.DropStateIndex("cc1", "coll1", "ddoc1", "index1"))
+	assert.EqualError(t, indexManager.DropStateIndex("cc1", "coll1", "ddoc1", "index1"), "index [index1] not found")
+
+	_, err = indexManager.AddStateIndex("", "", []byte(`{"index":{"fields":["owner"]}}`))
+	assert.EqualError(t, err, "chaincode name must not be empty")
+	_, err = indexManager.AddStateIndex("cc1", "", nil)
+	assert.EqualError(t, err, "index definition must not be empty")
+	_, err = indexManager.ListStateIndexes("", "")
+	assert.EqualError(t, err, "chaincode name must not be empty")
+	assert.EqualError(t, indexManager.DropStateIndex("cc1", "", "", "index1"),
+		"chaincode name, design document, and index name must not be empty")
+}
+
+func TestStateIndexManagerUnsupportedStateDB(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	_, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer ledger.Close()
+
+	indexManager, ok := ledger.(lgr.StateIndexManager)
+	require.True(t, ok)
+	_, err = indexManager.AddStateIndex("cc1", "", []byte(`{"index":{"fields":["owner"]}}`))
+	assert.EqualError(t, err, "the state database does not support managing indexes on demand")
+	_, err = indexManager.ListStateIndexes("cc1", "")
+	assert.EqualError(t, err, "the state database does not support managing indexes on demand")
+}
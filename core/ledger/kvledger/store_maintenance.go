@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/pkg/errors"
+)
+
+// StoreDiskUsage implements the function in the interface `ledger.StoreMaintainer`. The stores that are not
+// kept in leveldb (e.g., the state database when CouchDB is used) are not reported
+func (provider *Provider) StoreDiskUsage(ledgerID string) (map[string]uint64, error) {
+	if err := provider.checkLedgerExists(ledgerID); err != nil {
+		return nil, err
+	}
+	usage := map[string]uint64{}
+	for name, maintainer := range provider.storeMaintainers() {
+		bytes, err := maintainer.DiskUsage(ledgerID)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error while computing the disk usage of the %s store", name))
+		}
+		usage[name] = bytes
+	}
+	return usage, nil
+}
+
+// CompactStore implements the function in the interface `ledger.StoreMaintainer`. The compaction is performed while
+// the ledger remains in use and hence, it competes with the commits and the queries for the disk bandwidth
+func (provider *Provider) CompactStore(ledgerID string, store string) error {
+	if err := provider.checkLedgerExists(ledgerID); err != nil {
+		return err
+	}
+	maintainers := provider.storeMaintainers()
+	maintainer, ok := maintainers[store]
+	if !ok {
+		return errors.Errorf("store [%s] does not support compaction, supported stores are %s", store, storeNames(maintainers))
+	}
+	logger.Infof("Compacting the %s store of ledger [%s]", store, ledgerID)
+	startTime := time.Now()
+	if err := maintainer.Compact(ledgerID); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error while compacting the %s store", store))
+	}
+	logger.Infof("Compacted the %s store of ledger [%s] in %s", store, ledgerID, time.Since(startTime))
+	return nil
+}
+
+func (provider *Provider) checkLedgerExists(ledgerID string) error {
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNonExistingLedgerID
+	}
+	return nil
+}
+
+func (provider *Provider) storeMaintainers() map[string]leveldbhelper.StoreMaintainer {
+	maintainers := provider.ledgerStoreProvider.StoreMaintainers()
+	if vdbProvider, ok := provider.vdbProvider.(*privacyenabledstate.CommonStorageDBProvider); ok {
+		if maintainer, ok := vdbProvider.VersionedDBProvider.(leveldbhelper.StoreMaintainer); ok {
+			maintainers[ledger.StateStore] = maintainer
+		}
+	}
+	if maintainer, ok := provider.historydbProvider.(leveldbhelper.StoreMaintainer); ok {
+		maintainers[ledger.HistoryStore] = maintainer
+	}
+	return maintainers
+}
+
+func storeNames(maintainers map[string]leveldbhelper.StoreMaintainer) []string {
+	var names []string
+	for name := range maintainers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreMaintenance(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer ledger.Close()
+	for i := 0; i < 5; i++ {
+		simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		require.NoError(t, simulator.SetState("ns1", "key1", []byte("value1")))
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		require.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}))
+	}
+
+	maintainer := provider.(lgr.StoreMaintainer)
+	// the stores are compacted while the ledger is opened, which also flushes the recent writes to the files
+	for _, store := range []string{lgr.StateStore, lgr.HistoryStore, lgr.BlockIndexStore, lgr.PvtDataStore} {
+		require.NoError(t, maintainer.CompactStore("ledger1", store))
+	}
+	usage, err := maintainer.StoreDiskUsage("ledger1")
+	require.NoError(t, err)
+	assert.Len(t, usage, 4)
+	assert.True(t, usage[lgr.StateStore] > 0)
+	assert.True(t, usage[lgr.HistoryStore] > 0)
+	assert.True(t, usage[lgr.BlockIndexStore] > 0)
+	assert.Contains(t, usage, lgr.PvtDataStore)
+
+	qe, err := ledger.NewQueryExecutor()
+	require.NoError(t, err)
+	val, err := qe.GetState("ns1", "key1")
+	qe.Done()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+
+	err = maintainer.CompactStore("ledger1", "blocks")
+	assert.EqualError(t, err, "store [blocks] does not support compaction, supported stores are [history index pvtdata state]")
+	assert.Equal(t, ErrNonExistingLedgerID, maintainer.CompactStore("ledger2", lgr.StateStore))
+	_, err = maintainer.StoreDiskUsage("ledger2")
+	assert.Equal(t, ErrNonExistingLedgerID, err)
+}
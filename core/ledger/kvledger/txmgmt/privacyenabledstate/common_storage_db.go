@@ -18,8 +18,11 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
-	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecache"
+	// the built-in state databases register themselves with the statedb package
+	_ "github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statesql"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/pkg/errors"
@@ -38,21 +41,42 @@ type CommonStorageDBProvider struct {
 	statedb.VersionedDBProvider
 	HealthCheckRegistry ledger.HealthCheckRegistry
 	bookkeepingProvider bookkeeping.Provider
+	// StateDatabase is the name of the state database backend that provides the VersionedDBProvider
+	StateDatabase string
+	cacheProvider *statecache.Provider
 }
 
-// NewCommonStorageDBProvider constructs an instance of DBProvider
+// NewCommonStorageDBProvider constructs an instance of DBProvider. The state database backend is looked
+// up, by the name configured in `ledger.state.stateDatabase`, among the backends registered with the
+// statedb package. If `ledger.state.stateDatabasePlugin` is configured, the backend is first loaded
+// from the plugin and registered under the configured name. A name that is not registered falls back
+// to goleveldb, as in the earlier releases. The DB instances are fronted by a state cache, if the cache
+// is enabled for any chaincode in `ledger.state.cache`
 func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metricsProvider metrics.Provider, healthCheckRegistry ledger.HealthCheckRegistry) (DBProvider, error) {
-	var vdbProvider statedb.VersionedDBProvider
-	var err error
-	if ledgerconfig.IsCouchDBEnabled() {
-		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(metricsProvider); err != nil {
+	stateDatabase := ledgerconfig.GetStateDatabase()
+	if pluginPath := ledgerconfig.GetStateDatabasePlugin(); pluginPath != "" {
+		if err := statedb.LoadVersionedDBProviderPlugin(stateDatabase, pluginPath); err != nil {
 			return nil, err
 		}
-	} else {
-		vdbProvider = stateleveldb.NewVersionedDBProvider()
+	}
+	stateDatabase = resolveStateDatabase(stateDatabase)
+	logger.Infof("Using state database [%s]", stateDatabase)
+	vdbProvider, err := statedb.NewVersionedDBProvider(stateDatabase, metricsProvider)
+	if err != nil {
+		return nil, err
 	}
 
-	dbProvider := &CommonStorageDBProvider{vdbProvider, healthCheckRegistry, bookkeeperProvider}
+	dbProvider := &CommonStorageDBProvider{
+		VersionedDBProvider: vdbProvider,
+		HealthCheckRegistry: healthCheckRegistry,
+		bookkeepingProvider: bookkeeperProvider,
+		StateDatabase:       stateDatabase,
+		cacheProvider: statecache.NewProvider(
+			ledgerconfig.GetStateCacheSize(),
+			ledgerconfig.GetStateCacheChaincodeSizes(),
+			metricsProvider,
+		),
+	}
 
 	err = dbProvider.RegisterHealthChecker()
 	if err != nil {
@@ -62,9 +86,26 @@ func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metrics
 	return dbProvider, nil
 }
 
+// resolveStateDatabase returns the name of the registered state database backend to use for the configured name.
+// Before the backends were made pluggable, any value other than "CouchDB" (e.g., "LevelDB") selected goleveldb.
+// For backward compatibility, a name that is not registered falls back to goleveldb
+func resolveStateDatabase(stateDatabase string) string {
+	registered := statedb.RegisteredVersionedDBProviders()
+	for _, name := range registered {
+		if name == stateDatabase {
+			return stateDatabase
+		}
+	}
+	logger.Warningf("State database [%s] is not registered, using [%s] instead. The registered state databases are %v",
+		stateDatabase, stateleveldb.StateDatabaseName, registered)
+	return stateleveldb.StateDatabaseName
+}
+
+// RegisterHealthChecker registers the VersionedDBProvider with the HealthCheckRegistry, under the lower-cased name
+// of the state database, if the VersionedDBProvider is a health checker (e.g., CouchDB)
 func (p *CommonStorageDBProvider) RegisterHealthChecker() error {
 	if healthChecker, ok := p.VersionedDBProvider.(healthz.HealthChecker); ok {
-		return p.HealthCheckRegistry.RegisterChecker("couchdb", healthChecker)
+		return p.HealthCheckRegistry.RegisterChecker(strings.ToLower(p.StateDatabase), healthChecker)
 	}
 	return nil
 }
@@ -77,7 +118,19 @@ func (p *CommonStorageDBProvider) GetDBHandle(id string) (DB, error) {
 	}
 	bookkeeper := p.bookkeepingProvider.GetDBHandle(id, bookkeeping.MetadataPresenceIndicator)
 	metadataHint := newMetadataHint(bookkeeper)
-	return NewCommonStorageDB(vdb, id, metadataHint)
+	var cache *statecache.Cache
+	if p.cacheProvider != nil && p.cacheProvider.Enabled() {
+		cache = p.cacheProvider.NewCache(id, cacheWriteThroughSupported(p.StateDatabase))
+	}
+	return NewCommonStorageDB(vdb, id, metadataHint, cache)
+}
+
+// cacheWriteThroughSupported returns true if the given state database returns the values exactly
+// as they were committed, so that the committed values can be written through the state cache.
+// For instance, CouchDB re-serializes the JSON values and hence, the committed values of the
+// keys are not written through but their cached entries are invalidated
+func cacheWriteThroughSupported(stateDatabase string) bool {
+	return stateDatabase == stateleveldb.StateDatabaseName || stateDatabase == statesql.StateDatabaseName
 }
 
 // Close implements function from interface DBProvider
@@ -90,12 +143,15 @@ func (p *CommonStorageDBProvider) Close() {
 type CommonStorageDB struct {
 	statedb.VersionedDB
 	metadataHint *metadataHint
+	cache        *statecache.Cache
 }
 
 // NewCommonStorageDB wraps a VersionedDB instance. The public data is managed directly by the wrapped versionedDB.
-// For managing the hashed data and private data, this implementation creates separate namespaces in the wrapped db
-func NewCommonStorageDB(vdb statedb.VersionedDB, ledgerid string, metadataHint *metadataHint) (DB, error) {
-	return &CommonStorageDB{vdb, metadataHint}, nil
+// For managing the hashed data and private data, this implementation creates separate namespaces in the wrapped db.
+// If the cache is not nil, the point lookups of the keys are served from the cache, which is kept in sync with the
+// updates applied to the wrapped db
+func NewCommonStorageDB(vdb statedb.VersionedDB, ledgerid string, metadataHint *metadataHint, cache *statecache.Cache) (DB, error) {
+	return &CommonStorageDB{vdb, metadataHint, cache}, nil
 }
 
 // IsBulkOptimizable implements corresponding function in interface DB
@@ -104,6 +160,47 @@ func (s *CommonStorageDB) IsBulkOptimizable() bool {
 	return ok
 }
 
+// GetIndexQueryable implements corresponding function in interface DB
+func (s *CommonStorageDB) GetIndexQueryable() (statedb.IndexQueryable, bool) {
+	indexQueryable, ok := s.VersionedDB.(statedb.IndexQueryable)
+	return indexQueryable, ok
+}
+
+// CreateIndex implements corresponding function in interface DB
+func (s *CommonStorageDB) CreateIndex(namespace, collection string, indexDefinition []byte) (*statedb.IndexInfo, error) {
+	indexManageable, err := s.indexManageable()
+	if err != nil {
+		return nil, err
+	}
+	return indexManageable.CreateIndex(deriveIndexedNs(namespace, collection), indexDefinition)
+}
+
+// ListIndexes implements corresponding function in interface DB
+func (s *CommonStorageDB) ListIndexes(namespace, collection string) ([]*statedb.IndexInfo, error) {
+	indexManageable, err := s.indexManageable()
+	if err != nil {
+		return nil, err
+	}
+	return indexManageable.ListIndexes(deriveIndexedNs(namespace, collection))
+}
+
+// DropIndex implements corresponding function in interface DB
+func (s *CommonStorageDB) DropIndex(namespace, collection, designDoc, indexName string) error {
+	indexManageable, err := s.indexManageable()
+	if err != nil {
+		return err
+	}
+	return indexManageable.DropIndex(deriveIndexedNs(namespace, collection), designDoc, indexName)
+}
+
+func (s *CommonStorageDB) indexManageable() (statedb.IndexManageable, error) {
+	indexManageable, ok := s.VersionedDB.(statedb.IndexManageable)
+	if !ok {
+		return nil, errors.New("the state database does not support managing indexes on demand")
+	}
+	return indexManageable, nil
+}
+
 // LoadCommittedVersionsOfPubAndHashedKeys implements corresponding function in interface DB
 func (s *CommonStorageDB) LoadCommittedVersionsOfPubAndHashedKeys(pubKeys []*statedb.CompositeKey,
 	hashedKeys []*HashedCompositeKey) error {
@@ -153,6 +250,69 @@ func (s *CommonStorageDB) GetChaincodeEventListener() cceventmgmt.ChaincodeLifec
 	return nil
 }
 
+// GetState overrides the function in statedb.VersionedDB to serve the value from the state cache, if possible
+func (s *CommonStorageDB) GetState(namespace, key string) (*statedb.VersionedValue, error) {
+	if s.cache == nil {
+		return s.VersionedDB.GetState(namespace, key)
+	}
+	if vv, ok := s.cache.Get(namespace, key); ok {
+		return vv, nil
+	}
+	generation := s.cache.Generation()
+	vv, err := s.VersionedDB.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.Put(generation, namespace, key, vv)
+	return vv, nil
+}
+
+// GetVersion overrides the function in statedb.VersionedDB to serve the version from the state cache, if possible
+func (s *CommonStorageDB) GetVersion(namespace, key string) (*version.Height, error) {
+	if s.cache != nil {
+		if vv, ok := s.cache.Get(namespace, key); ok {
+			if vv == nil {
+				return nil, nil
+			}
+			return vv.Version, nil
+		}
+	}
+	return s.VersionedDB.GetVersion(namespace, key)
+}
+
+// GetStateMultipleKeys overrides the function in statedb.VersionedDB to serve the values from the state cache,
+// if possible. Only the keys that are not cached are retrieved from the wrapped db
+func (s *CommonStorageDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	if s.cache == nil {
+		return s.VersionedDB.GetStateMultipleKeys(namespace, keys)
+	}
+	vals := make([]*statedb.VersionedValue, len(keys))
+	var missedKeys []string
+	var missedIndexes []int
+	for i, key := range keys {
+		vv, ok := s.cache.Get(namespace, key)
+		if !ok {
+			missedKeys = append(missedKeys, key)
+			missedIndexes = append(missedIndexes, i)
+			continue
+		}
+		vals[i] = vv
+	}
+	if len(missedKeys) == 0 {
+		return vals, nil
+	}
+	generation := s.cache.Generation()
+	missedVals, err := s.VersionedDB.GetStateMultipleKeys(namespace, missedKeys)
+	if err != nil {
+		return nil, err
+	}
+	for i, vv := range missedVals {
+		vals[missedIndexes[i]] = vv
+		s.cache.Put(generation, namespace, missedKeys[i], vv)
+	}
+	return vals, nil
+}
+
 // GetPrivateData implements corresponding function in interface DB
 func (s *CommonStorageDB) GetPrivateData(namespace, collection, key string) (*statedb.VersionedValue, error) {
 	return s.GetState(derivePvtDataNs(namespace, collection), key)
@@ -200,11 +360,21 @@ func (s *CommonStorageDB) GetPrivateDataRangeScanIterator(namespace, collection,
 	return s.GetStateRangeScanIterator(derivePvtDataNs(namespace, collection), startKey, endKey)
 }
 
+// GetPrivateDataRangeScanIteratorWithMetadata implements corresponding function in interface DB
+func (s *CommonStorageDB) GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	return s.GetStateRangeScanIteratorWithMetadata(derivePvtDataNs(namespace, collection), startKey, endKey, metadata)
+}
+
 // ExecuteQueryOnPrivateData implements corresponding function in interface DB
 func (s CommonStorageDB) ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error) {
 	return s.ExecuteQuery(derivePvtDataNs(namespace, collection), query)
 }
 
+// ExecuteQueryOnPrivateDataWithMetadata implements corresponding function in interface DB
+func (s *CommonStorageDB) ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	return s.ExecuteQueryWithMetadata(derivePvtDataNs(namespace, collection), query, metadata)
+}
+
 // ApplyUpdates overrides the function in statedb.VersionedDB and throws appropriate error message
 // Otherwise, somewhere in the code, usage of this function could lead to updating only public data.
 func (s *CommonStorageDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
@@ -218,7 +388,15 @@ func (s *CommonStorageDB) ApplyPrivacyAwareUpdates(updates *UpdateBatch, height
 	addPvtUpdates(combinedUpdates, updates.PvtUpdates)
 	addHashedUpdates(combinedUpdates, updates.HashUpdates, !s.BytesKeySupported())
 	s.metadataHint.setMetadataUsedFlag(updates)
-	return s.VersionedDB.ApplyUpdates(combinedUpdates.UpdateBatch, height)
+	if s.cache == nil {
+		return s.VersionedDB.ApplyUpdates(combinedUpdates.UpdateBatch, height)
+	}
+	s.cache.ApplyUpdates(combinedUpdates.UpdateBatch, false)
+	if err := s.VersionedDB.ApplyUpdates(combinedUpdates.UpdateBatch, height); err != nil {
+		return err
+	}
+	s.cache.ApplyUpdates(combinedUpdates.UpdateBatch, true)
+	return nil
 }
 
 // GetStateMetadata implements corresponding function in interface DB. This implementation provides
@@ -316,6 +494,15 @@ func derivePvtDataNs(namespace, collection string) string {
 	return namespace + nsJoiner + pvtDataPrefix + collection
 }
 
+// deriveIndexedNs returns the namespace that holds the public data of the namespace or,
+// if the collection is not empty, the private data of the collection
+func deriveIndexedNs(namespace, collection string) string {
+	if collection == "" {
+		return namespace
+	}
+	return derivePvtDataNs(namespace, collection)
+}
+
 func deriveHashedDataNs(namespace, collection string) string {
 	return namespace + nsJoiner + hashDataPrefix + collection
 }
@@ -7,13 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package privacyenabledstate_test
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
 )
 
 func TestHealthCheckRegister(t *testing.T) {
@@ -23,6 +28,7 @@ func TestHealthCheckRegister(t *testing.T) {
 	dbProvider := &privacyenabledstate.CommonStorageDBProvider{
 		VersionedDBProvider: &stateleveldb.VersionedDBProvider{},
 		HealthCheckRegistry: fakeHealthCheckRegistry,
+		StateDatabase:       stateleveldb.StateDatabaseName,
 	}
 
 	err := dbProvider.RegisterHealthChecker()
@@ -30,6 +36,7 @@ func TestHealthCheckRegister(t *testing.T) {
 	gt.Expect(fakeHealthCheckRegistry.RegisterCheckerCallCount()).To(Equal(0))
 
 	dbProvider.VersionedDBProvider = &statecouchdb.VersionedDBProvider{}
+	dbProvider.StateDatabase = statecouchdb.StateDatabaseName
 	err = dbProvider.RegisterHealthChecker()
 	gt.Expect(err).NotTo(HaveOccurred())
 	gt.Expect(fakeHealthCheckRegistry.RegisterCheckerCallCount()).To(Equal(1))
@@ -38,3 +45,24 @@ func TestHealthCheckRegister(t *testing.T) {
 	gt.Expect(arg1).To(Equal("couchdb"))
 	gt.Expect(arg2).NotTo(Equal(nil))
 }
+
+func TestStateDatabaseFallback(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	testDir, err := ioutil.TempDir("", "statedbfallback")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(testDir)
+	viper.Set("peer.fileSystemPath", testDir)
+	defer viper.Set("ledger.state.stateDatabase", "")
+	bookkeepingEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeepingEnv.Cleanup()
+
+	// the names used by the earlier releases, and any other name that is not registered, select goleveldb
+	for _, stateDatabase := range []string{"", "goleveldb", "LevelDB", "leveldb", "unknown"} {
+		viper.Set("ledger.state.stateDatabase", stateDatabase)
+		dbProvider, err := privacyenabledstate.NewCommonStorageDBProvider(bookkeepingEnv.TestProvider, &disabled.Provider{}, &mock.HealthCheckRegistry{})
+		gt.Expect(err).NotTo(HaveOccurred())
+		gt.Expect(dbProvider.(*privacyenabledstate.CommonStorageDBProvider).StateDatabase).To(Equal(stateleveldb.StateDatabaseName))
+		gt.Expect(dbProvider.(*privacyenabledstate.CommonStorageDBProvider).VersionedDBProvider).To(BeAssignableToTypeOf(&stateleveldb.VersionedDBProvider{}))
+		dbProvider.Close()
+	}
+}
@@ -18,6 +18,11 @@ import (
 type DBProvider interface {
 	// GetDBHandle returns a handle to a PvtVersionedDB
 	GetDBHandle(id string) (DB, error)
+	// ImportFromSnapshot loads the public state and the hashes of the private state, exported in a snapshot,
+	// into the db for the given ledger and sets the savepoint of the db to the given height
+	ImportFromSnapshot(id string, savepoint *version.Height, snapshotDir string) error
+	// Drop drops all the data in the PvtVersionedDB for the given id, including the savepoint
+	Drop(id string) error
 	// Close closes all the PvtVersionedDB instances and releases any resources held by VersionedDBProvider
 	Close()
 }
@@ -35,10 +40,23 @@ type DB interface {
 	GetKeyHashVersion(namespace, collection string, keyHash []byte) (*version.Height, error)
 	GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([]*statedb.VersionedValue, error)
 	GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (statedb.ResultsIterator, error)
+	GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error)
 	GetStateMetadata(namespace, key string) ([]byte, error)
 	GetPrivateDataMetadataByHash(namespace, collection string, keyHash []byte) ([]byte, error)
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (statedb.ResultsIterator, error)
+	ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error)
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
+	// ExportPubStateAndPvtStateHashes exports the public state and the hashes of the private state
+	// into the snapshot files in the given dir and returns the hashes of the files created
+	ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error)
+	// GetIndexQueryable returns the underlying state db if it supports the queries on secondary indexes
+	GetIndexQueryable() (statedb.IndexQueryable, bool)
+	// CreateIndex, ListIndexes, and DropIndex manage the indexes of the public data of a namespace or, if the
+	// collection is not empty, of the private data of the collection. An error is returned if the underlying
+	// state db does not support managing the indexes on demand
+	CreateIndex(namespace, collection string, indexDefinition []byte) (*statedb.IndexInfo, error)
+	ListIndexes(namespace, collection string) ([]*statedb.IndexInfo, error)
+	DropIndex(namespace, collection, designDoc, indexName string) error
 }
 
 // PvtdataCompositeKey encloses Namespace, CollectionName and Key components
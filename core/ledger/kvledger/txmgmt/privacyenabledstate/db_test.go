@@ -12,12 +12,17 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/mock"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecache"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -231,6 +236,22 @@ func testGetStateRangeScanIterator(t *testing.T, env TestEnv) {
 
 	pvtItr4, _ := db.GetPrivateDataRangeScanIterator("ns2", "coll1", "", "")
 	testItr(t, pvtItr4, []string{"key5", "key6"})
+
+	pvtItr5, err := db.GetPrivateDataRangeScanIteratorWithMetadata("ns1", "coll1", "key1", "", map[string]interface{}{"limit": int32(2)})
+	assert.NoError(t, err)
+	for _, expectedKey := range []string{"key1", "key2"} {
+		queryResult, err := pvtItr5.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, expectedKey, queryResult.(*statedb.VersionedKV).Key)
+	}
+	last, err := pvtItr5.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, last)
+	assert.Equal(t, "key3", pvtItr5.GetBookmarkAndClose())
+
+	pvtItr6, err := db.GetPrivateDataRangeScanIteratorWithMetadata("ns1", "coll1", "key3", "", map[string]interface{}{"limit": int32(2)})
+	assert.NoError(t, err)
+	testItr(t, pvtItr6, []string{"key3", "key4"})
 }
 
 func TestQueryOnCouchDB(t *testing.T) {
@@ -586,3 +607,188 @@ func deletePvtUpdates(t *testing.T, updates *UpdateBatch, ns, coll, key string,
 	updates.PvtUpdates.Delete(ns, coll, key, ver)
 	updates.HashUpdates.Delete(ns, coll, util.ComputeStringHash(key), ver)
 }
+
+func TestStateCache(t *testing.T) {
+	bookkeepingTestEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeepingTestEnv.Cleanup()
+	bookkeeper := bookkeepingTestEnv.TestProvider.GetDBHandle("ledger1", bookkeeping.MetadataPresenceIndicator)
+	cacheProvider := statecache.NewProvider(10, map[string]int{"uncachedcc": 0}, &disabled.Provider{})
+
+	for _, writeThrough := range []bool{true, false} {
+		t.Run(fmt.Sprintf("writeThrough=%t", writeThrough), func(t *testing.T) {
+			mockVersionedDB := &mock.VersionedDB{}
+			mockVersionedDB.GetStateReturns(&statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, nil)
+			mockVersionedDB.GetStateMultipleKeysStub = func(ns string, keys []string) ([]*statedb.VersionedValue, error) {
+				vals := make([]*statedb.VersionedValue, len(keys))
+				for i, key := range keys {
+					vals[i] = &statedb.VersionedValue{Value: []byte("value-" + key), Version: version.NewHeight(1, 1)}
+				}
+				return vals, nil
+			}
+			db, err := NewCommonStorageDB(mockVersionedDB, "ledger1", newMetadataHint(bookkeeper), cacheProvider.NewCache("ledger1", writeThrough))
+			assert.NoError(t, err)
+
+			// the first lookup goes to the db, the subsequent lookups are served from the cache
+			for i := 0; i < 3; i++ {
+				vv, err := db.GetState("ns1", "key1")
+				assert.NoError(t, err)
+				assert.Equal(t, []byte("value1"), vv.Value)
+				ver, err := db.GetVersion("ns1", "key1")
+				assert.NoError(t, err)
+				assert.Equal(t, version.NewHeight(1, 1), ver)
+			}
+			assert.Equal(t, 1, mockVersionedDB.GetStateCallCount())
+			assert.Equal(t, 0, mockVersionedDB.GetVersionCallCount())
+
+			// only the keys that are not cached are retrieved from the db
+			vals, err := db.GetStateMultipleKeys("ns1", []string{"key1", "key2", "key3"})
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("value1"), vals[0].Value)
+			assert.Equal(t, []byte("value-key2"), vals[1].Value)
+			assert.Equal(t, []byte("value-key3"), vals[2].Value)
+			_, keys := mockVersionedDB.GetStateMultipleKeysArgsForCall(0)
+			assert.Equal(t, []string{"key2", "key3"}, keys)
+			_, err = db.GetStateMultipleKeys("ns1", []string{"key1", "key2", "key3"})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, mockVersionedDB.GetStateMultipleKeysCallCount())
+
+			// the private data namespaces of a chaincode share the cache of the chaincode
+			_, err = db.GetPrivateData("ns1", "coll1", "key1")
+			assert.NoError(t, err)
+			_, err = db.GetPrivateData("ns1", "coll1", "key1")
+			assert.NoError(t, err)
+			assert.Equal(t, 2, mockVersionedDB.GetStateCallCount())
+
+			// the lookups of a chaincode for which the cache is disabled always go to the db
+			_, err = db.GetState("uncachedcc", "key1")
+			assert.NoError(t, err)
+			_, err = db.GetState("uncachedcc", "key1")
+			assert.NoError(t, err)
+			assert.Equal(t, 4, mockVersionedDB.GetStateCallCount())
+
+			updates := NewUpdateBatch()
+			updates.PubUpdates.Put("ns1", "key1", []byte("value1-updated"), version.NewHeight(2, 1))
+			updates.PubUpdates.Delete("ns1", "key2", version.NewHeight(2, 1))
+			assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 1)))
+
+			mockVersionedDB.GetStateReturns(&statedb.VersionedValue{Value: []byte("value1-updated"), Version: version.NewHeight(2, 1)}, nil)
+			vv, err := db.GetState("ns1", "key1")
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("value1-updated"), vv.Value)
+			assert.Equal(t, version.NewHeight(2, 1), vv.Version)
+			if writeThrough {
+				assert.Equal(t, 4, mockVersionedDB.GetStateCallCount())
+			} else {
+				assert.Equal(t, 5, mockVersionedDB.GetStateCallCount())
+			}
+
+			// the deletes are cached irrespective of the write-through
+			vv, err = db.GetState("ns1", "key2")
+			assert.NoError(t, err)
+			assert.Nil(t, vv)
+			ver, err := db.GetVersion("ns1", "key2")
+			assert.NoError(t, err)
+			assert.Nil(t, ver)
+			// the unchanged keys remain cached
+			vv, err = db.GetState("ns1", "key3")
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("value-key3"), vv.Value)
+			assert.Equal(t, 0, mockVersionedDB.GetVersionCallCount())
+		})
+	}
+
+	t.Run("failed-commit-invalidates", func(t *testing.T) {
+		mockVersionedDB := &mock.VersionedDB{}
+		mockVersionedDB.GetStateReturns(&statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, nil)
+		mockVersionedDB.ApplyUpdatesReturns(errors.New("apply-error"))
+		db, err := NewCommonStorageDB(mockVersionedDB, "ledger1", newMetadataHint(bookkeeper), cacheProvider.NewCache("ledger1", true))
+		assert.NoError(t, err)
+		_, err = db.GetState("ns1", "key1")
+		assert.NoError(t, err)
+
+		updates := NewUpdateBatch()
+		updates.PubUpdates.Put("ns1", "key1", []byte("value1-updated"), version.NewHeight(2, 1))
+		assert.EqualError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 1)), "apply-error")
+
+		vv, err := db.GetState("ns1", "key1")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value1"), vv.Value)
+		assert.Equal(t, 2, mockVersionedDB.GetStateCallCount())
+	})
+}
+
+func TestStateCacheWithLevelDB(t *testing.T) {
+	viper.Set("ledger.state.cache.size", 100)
+	defer viper.Set("ledger.state.cache.size", 0)
+	testEnv := &LevelDBCommonStorageTestEnv{}
+	testEnv.Init(t)
+	defer testEnv.Cleanup()
+	db := testEnv.GetDBHandle("test-state-cache")
+	assert.NotNil(t, db.(*CommonStorageDB).cache)
+
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updates.PvtUpdates.Put("ns1", "coll1", "key1", []byte("pvtvalue1"), version.NewHeight(1, 1))
+	updates.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("pvtvalue1"), version.NewHeight(1, 1))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(1, 1)))
+
+	vv, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, vv)
+	pvtVV, err := db.GetPrivateData("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("pvtvalue1"), pvtVV.Value)
+	ver, err := db.GetKeyHashVersion("ns1", "coll1", util.ComputeStringHash("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 1), ver)
+
+	updates = NewUpdateBatch()
+	updates.PubUpdates.Delete("ns1", "key1", version.NewHeight(2, 1))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 1)))
+	vv, err = db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	vv, err = db.(*CommonStorageDB).VersionedDB.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+}
+
+type indexManageableVersionedDB struct {
+	mock.VersionedDB
+	namespaces []string
+}
+
+func (vdb *indexManageableVersionedDB) CreateIndex(namespace string, indexDefinition []byte) (*statedb.IndexInfo, error) {
+	vdb.namespaces = append(vdb.namespaces, namespace)
+	return &statedb.IndexInfo{DesignDoc: "ddoc1", Name: "index1"}, nil
+}
+
+func (vdb *indexManageableVersionedDB) ListIndexes(namespace string) ([]*statedb.IndexInfo, error) {
+	vdb.namespaces = append(vdb.namespaces, namespace)
+	return nil, nil
+}
+
+func (vdb *indexManageableVersionedDB) DropIndex(namespace, designDoc, indexName string) error {
+	vdb.namespaces = append(vdb.namespaces, namespace)
+	return nil
+}
+
+func TestIndexManagement(t *testing.T) {
+	vdb := &indexManageableVersionedDB{}
+	db, err := NewCommonStorageDB(vdb, "ledger1", nil, nil)
+	assert.NoError(t, err)
+
+	_, err = db.CreateIndex("ns1", "", []byte("index-definition"))
+	assert.NoError(t, err)
+	_, err = db.CreateIndex("ns1", "coll1", []byte("index-definition"))
+	assert.NoError(t, err)
+	_, err = db.ListIndexes("ns1", "coll1")
+	assert.NoError(t, err)
+	assert.NoError(t, db.DropIndex("ns1", "coll1", "ddoc1", "index1"))
+	assert.Equal(t, []string{"ns1", "ns1$$pcoll1", "ns1$$pcoll1", "ns1$$pcoll1"}, vdb.namespaces)
+
+	db, err = NewCommonStorageDB(&mock.VersionedDB{}, "ledger1", nil, nil)
+	assert.NoError(t, err)
+	_, err = db.ListIndexes("ns1", "")
+	assert.EqualError(t, err, "the state database does not support managing indexes on demand")
+}
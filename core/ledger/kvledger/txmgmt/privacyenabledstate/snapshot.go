@@ -0,0 +1,269 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotFileFormat = byte(1)
+	// PubStateDataFileName is the name of the snapshot file that contains the public state
+	PubStateDataFileName = "public_state.data"
+	// PvtStateHashesFileName is the name of the snapshot file that contains the hashes of the private state
+	PvtStateHashesFileName = "private_state_hashes.data"
+	// maxKeysInImportBatch limits the number of keys that are loaded in the statedb in a single batch
+	// while bootstrapping a ledger from a snapshot
+	maxKeysInImportBatch = 10000
+)
+
+// ExportPubStateAndPvtStateHashes implements corresponding function in interface DB.
+// The public state and the hashes of the private state are exported in two separate files.
+// The private state itself is not exported as it is not expected to be shared with the
+// peers that bootstrap from the snapshot. The caller is expected to ensure that no block is
+// committed while the export is in progress
+func (s *CommonStorageDB) ExportPubStateAndPvtStateHashes(dir string) (map[string][]byte, error) {
+	fullScannable, ok := s.VersionedDB.(statedb.FullScannable)
+	if !ok {
+		return nil, errors.New("exporting state is not supported for the state database in use")
+	}
+	itr, err := fullScannable.GetFullScanIterator(isPvtdataNs)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	pubStateWriter, err := snapshot.CreateFile(filepath.Join(dir, PubStateDataFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer pubStateWriter.Close()
+	pvtStateHashesWriter, err := snapshot.CreateFile(filepath.Join(dir, PvtStateHashesFileName), snapshotFileFormat)
+	if err != nil {
+		return nil, err
+	}
+	defer pvtStateHashesWriter.Close()
+
+	numPubKeys, numHashedKeys := uint64(0), uint64(0)
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			break
+		}
+		kv := res.(*statedb.VersionedKV)
+		namespace, coll, isHashed := splitHashedDataNs(kv.Namespace)
+		if !isHashed {
+			if err := writeSnapshotRecord(pubStateWriter, namespace, "", []byte(kv.Key), &kv.VersionedValue); err != nil {
+				return nil, err
+			}
+			numPubKeys++
+			continue
+		}
+		keyHash := []byte(kv.Key)
+		if !s.BytesKeySupported() {
+			if keyHash, err = base64.StdEncoding.DecodeString(kv.Key); err != nil {
+				return nil, errors.Wrapf(err, "error while decoding the key hash for namespace [%s]", kv.Namespace)
+			}
+		}
+		if err := writeSnapshotRecord(pvtStateHashesWriter, namespace, coll, keyHash, &kv.VersionedValue); err != nil {
+			return nil, err
+		}
+		numHashedKeys++
+	}
+
+	pubStateHash, err := finishSnapshotFile(pubStateWriter, numPubKeys)
+	if err != nil {
+		return nil, err
+	}
+	pvtStateHashesHash, err := finishSnapshotFile(pvtStateHashesWriter, numHashedKeys)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Exported [%d] public keys and [%d] private key hashes to the snapshot", numPubKeys, numHashedKeys)
+	return map[string][]byte{
+		PubStateDataFileName:   pubStateHash,
+		PvtStateHashesFileName: pvtStateHashesHash,
+	}, nil
+}
+
+// ImportFromSnapshot implements corresponding function in interface DBProvider.
+// The state db for the ledger is expected to be empty and, on a successful import, the savepoint
+// of the state db is set to the supplied height
+func (p *CommonStorageDBProvider) ImportFromSnapshot(id string, savepoint *version.Height, snapshotDir string) error {
+	db, err := p.GetDBHandle(id)
+	if err != nil {
+		return err
+	}
+	existingSavepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if existingSavepoint != nil {
+		return errors.Errorf("state db for ledger [%s] is not empty", id)
+	}
+
+	batch := NewUpdateBatch()
+	numKeysInBatch := 0
+	applyBatchIfFull := func() error {
+		numKeysInBatch++
+		if numKeysInBatch < maxKeysInImportBatch {
+			return nil
+		}
+		if err := db.ApplyPrivacyAwareUpdates(batch, nil); err != nil {
+			return err
+		}
+		batch = NewUpdateBatch()
+		numKeysInBatch = 0
+		return nil
+	}
+
+	err = readSnapshotFile(filepath.Join(snapshotDir, PubStateDataFileName),
+		func(namespace, _ string, key []byte, vv *statedb.VersionedValue) error {
+			batch.PubUpdates.PutValAndMetadata(namespace, string(key), vv.Value, vv.Metadata, vv.Version)
+			return applyBatchIfFull()
+		},
+	)
+	if err != nil {
+		return err
+	}
+	err = readSnapshotFile(filepath.Join(snapshotDir, PvtStateHashesFileName),
+		func(namespace, coll string, keyHash []byte, vv *statedb.VersionedValue) error {
+			batch.HashUpdates.PutValHashAndMetadata(namespace, coll, keyHash, vv.Value, vv.Metadata, vv.Version)
+			return applyBatchIfFull()
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return db.ApplyPrivacyAwareUpdates(batch, savepoint)
+}
+
+// ReadPubStateFromSnapshot invokes the function 'process' for each of the public state records
+// carried in the snapshot files present in the directory 'snapshotDir'
+func ReadPubStateFromSnapshot(snapshotDir string, process func(namespace, key string, vv *statedb.VersionedValue) error) error {
+	return readSnapshotFile(filepath.Join(snapshotDir, PubStateDataFileName),
+		func(namespace, _ string, key []byte, vv *statedb.VersionedValue) error {
+			return process(namespace, string(key), vv)
+		},
+	)
+}
+
+// ReadPvtStateHashesFromSnapshot invokes the function 'process' for each of the hashed private state records
+// carried in the snapshot files present in the directory 'snapshotDir'
+func ReadPvtStateHashesFromSnapshot(snapshotDir string, process func(namespace, coll string, keyHash []byte, vv *statedb.VersionedValue) error) error {
+	return readSnapshotFile(filepath.Join(snapshotDir, PvtStateHashesFileName), process)
+}
+
+func writeSnapshotRecord(w *snapshot.FileWriter, namespace, coll string, key []byte, vv *statedb.VersionedValue) error {
+	if err := w.EncodeString(namespace); err != nil {
+		return err
+	}
+	if err := w.EncodeString(coll); err != nil {
+		return err
+	}
+	if err := w.EncodeBytes(key); err != nil {
+		return err
+	}
+	if err := w.EncodeBytes(vv.Value); err != nil {
+		return err
+	}
+	if err := w.EncodeBytes(vv.Metadata); err != nil {
+		return err
+	}
+	return w.EncodeBytes(vv.Version.ToBytes())
+}
+
+// finishSnapshotFile writes the terminating record, that carries the total number of records, and closes the file
+func finishSnapshotFile(w *snapshot.FileWriter, numRecords uint64) ([]byte, error) {
+	if err := w.EncodeString(""); err != nil {
+		return nil, err
+	}
+	if err := w.EncodeUVarint(numRecords); err != nil {
+		return nil, err
+	}
+	return w.Done()
+}
+
+func readSnapshotFile(filePath string, process func(namespace, coll string, key []byte, vv *statedb.VersionedValue) error) error {
+	r, err := snapshot.OpenFile(filePath, snapshotFileFormat)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	numRecords := uint64(0)
+	for {
+		namespace, err := r.DecodeString()
+		if err != nil {
+			return err
+		}
+		if namespace == "" {
+			expectedNumRecords, err := r.DecodeUVarInt()
+			if err != nil {
+				return err
+			}
+			if expectedNumRecords != numRecords {
+				return errors.Errorf("unexpected number of records in the snapshot file [%s]. Expected = [%d], found = [%d]",
+					filePath, expectedNumRecords, numRecords)
+			}
+			return nil
+		}
+		coll, err := r.DecodeString()
+		if err != nil {
+			return err
+		}
+		key, err := r.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		value, err := r.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		metadata, err := r.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		versionBytes, err := r.DecodeBytes()
+		if err != nil {
+			return err
+		}
+		ver, _, err := version.NewHeightFromBytes(versionBytes)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error while decoding the version of a record in the snapshot file [%s]", filePath))
+		}
+		if len(metadata) == 0 {
+			metadata = nil
+		}
+		if err := process(namespace, coll, key, &statedb.VersionedValue{Value: value, Metadata: metadata, Version: ver}); err != nil {
+			return err
+		}
+		numRecords++
+	}
+}
+
+func isPvtdataNs(namespace string) bool {
+	return strings.Contains(namespace, nsJoiner+pvtDataPrefix)
+}
+
+func splitHashedDataNs(namespace string) (string, string, bool) {
+	split := strings.SplitN(namespace, nsJoiner+hashDataPrefix, 2)
+	if len(split) != 2 {
+		return namespace, "", false
+	}
+	return split[0], split[1], true
+}
@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAndImportState(t *testing.T) {
+	testEnv := &LevelDBCommonStorageTestEnv{}
+	testEnv.Init(t)
+	defer testEnv.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "privacyenabledstate-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	db := testEnv.GetDBHandle("source-ledger")
+	updates := NewUpdateBatch()
+	updates.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	updates.PubUpdates.PutValAndMetadata("ns1", "key2", []byte("value2"), []byte("metadata2"), version.NewHeight(1, 2))
+	updates.PubUpdates.Put("ns2", "key3", []byte("value3"), version.NewHeight(2, 1))
+	putPvtUpdates(t, updates, "ns1", "coll1", "pvtKey1", []byte("pvtValue1"), version.NewHeight(2, 2))
+	putPvtUpdates(t, updates, "ns2", "coll2", "pvtKey2", []byte("pvtValue2"), version.NewHeight(2, 3))
+	require.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(2, 3)))
+
+	fileHashes, err := db.ExportPubStateAndPvtStateHashes(snapshotDir)
+	require.NoError(t, err)
+	assert.Len(t, fileHashes, 2)
+	assert.Contains(t, fileHashes, PubStateDataFileName)
+	assert.Contains(t, fileHashes, PvtStateHashesFileName)
+
+	savepoint := version.NewHeight(2, 0)
+	require.NoError(t, testEnv.provider.ImportFromSnapshot("bootstrapped-ledger", savepoint, snapshotDir))
+	bootstrappedDB := testEnv.GetDBHandle("bootstrapped-ledger")
+	sp, err := bootstrappedDB.GetLatestSavePoint()
+	require.NoError(t, err)
+	assert.Equal(t, savepoint, sp)
+
+	for _, key := range []struct{ ns, key string }{{"ns1", "key1"}, {"ns1", "key2"}, {"ns2", "key3"}} {
+		expected, err := db.GetState(key.ns, key.key)
+		require.NoError(t, err)
+		actual, err := bootstrappedDB.GetState(key.ns, key.key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+
+	for _, key := range []struct{ ns, coll, key string }{{"ns1", "coll1", "pvtKey1"}, {"ns2", "coll2", "pvtKey2"}} {
+		keyHash := util.ComputeStringHash(key.key)
+		expected, err := db.GetValueHash(key.ns, key.coll, keyHash)
+		require.NoError(t, err)
+		actual, err := bootstrappedDB.GetValueHash(key.ns, key.coll, keyHash)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		// private data is not carried in the snapshot
+		pvtValue, err := bootstrappedDB.GetPrivateData(key.ns, key.coll, key.key)
+		require.NoError(t, err)
+		assert.Nil(t, pvtValue)
+	}
+
+	var hashedKeys []string
+	err = ReadPvtStateHashesFromSnapshot(snapshotDir,
+		func(namespace, coll string, keyHash []byte, vv *statedb.VersionedValue) error {
+			hashedKeys = append(hashedKeys, namespace+"/"+coll)
+			return nil
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ns1/coll1", "ns2/coll2"}, hashedKeys)
+
+	err = testEnv.provider.ImportFromSnapshot("bootstrapped-ledger", savepoint, snapshotDir)
+	assert.EqualError(t, err, "state db for ledger [bootstrapped-ledger] is not empty")
+
+	_, err = db.ExportPubStateAndPvtStateHashes(snapshotDir)
+	assert.Contains(t, err.Error(), "error while creating the snapshot file")
+}
+
+func TestImportStateWithCorruptedVersion(t *testing.T) {
+	testEnv := &LevelDBCommonStorageTestEnv{}
+	testEnv.Init(t)
+	defer testEnv.Cleanup()
+	snapshotDir, err := ioutil.TempDir("", "privacyenabledstate-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	w, err := snapshot.CreateFile(filepath.Join(snapshotDir, PubStateDataFileName), snapshotFileFormat)
+	require.NoError(t, err)
+	defer w.Close()
+	require.NoError(t, w.EncodeString("ns1"))
+	require.NoError(t, w.EncodeString(""))
+	require.NoError(t, w.EncodeBytes([]byte("key1")))
+	require.NoError(t, w.EncodeBytes([]byte("value1")))
+	require.NoError(t, w.EncodeBytes(nil))
+	require.NoError(t, w.EncodeBytes([]byte{0xff}))
+	_, err = finishSnapshotFile(w, 1)
+	require.NoError(t, err)
+
+	err = testEnv.provider.ImportFromSnapshot("bootstrapped-ledger", version.NewHeight(1, 0), snapshotDir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while decoding the version of a record in the snapshot file")
+	assert.Contains(t, err.Error(), "error decoding the block number of the height")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statesql"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/integration/runner"
@@ -74,6 +75,46 @@ func (env *LevelDBCommonStorageTestEnv) Cleanup() {
 	removeDBPath(env.t)
 }
 
+///////////// SQL Environment //////////////
+
+// SQLCommonStorageTestEnv implements TestEnv interface for the embedded sql state database
+type SQLCommonStorageTestEnv struct {
+	t                 testing.TB
+	provider          DBProvider
+	bookkeeperTestEnv *bookkeeping.TestEnv
+}
+
+// Init implements corresponding function from interface TestEnv
+func (env *SQLCommonStorageTestEnv) Init(t testing.TB) {
+	viper.Set("ledger.state.stateDatabase", statesql.StateDatabaseName)
+	removeSQLDBPath(t)
+	env.bookkeeperTestEnv = bookkeeping.NewTestEnv(t)
+	dbProvider, err := NewCommonStorageDBProvider(env.bookkeeperTestEnv.TestProvider, &disabled.Provider{}, &mock.HealthCheckRegistry{})
+	assert.NoError(t, err)
+	env.t = t
+	env.provider = dbProvider
+}
+
+// GetDBHandle implements corresponding function from interface TestEnv
+func (env *SQLCommonStorageTestEnv) GetDBHandle(id string) DB {
+	db, err := env.provider.GetDBHandle(id)
+	assert.NoError(env.t, err)
+	return db
+}
+
+// GetName implements corresponding function from interface TestEnv
+func (env *SQLCommonStorageTestEnv) GetName() string {
+	return "sqlCommonStorageTestEnv"
+}
+
+// Cleanup implements corresponding function from interface TestEnv
+func (env *SQLCommonStorageTestEnv) Cleanup() {
+	env.provider.Close()
+	env.bookkeeperTestEnv.Cleanup()
+	removeSQLDBPath(env.t)
+	viper.Set("ledger.state.stateDatabase", "")
+}
+
 ///////////// CouchDB Environment //////////////
 
 // CouchDBCommonStorageTestEnv implements TestEnv interface for couchdb based storage
@@ -149,3 +190,9 @@ func removeDBPath(t testing.TB) {
 		t.FailNow()
 	}
 }
+
+func removeSQLDBPath(t testing.TB) {
+	if err := os.RemoveAll(ledgerconfig.GetStateSQLDBPath()); err != nil {
+		t.Fatalf("Err: %s", err)
+	}
+}
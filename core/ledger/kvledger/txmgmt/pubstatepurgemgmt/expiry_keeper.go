@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pubstatepurgemgmt
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+)
+
+var logger = flogging.MustGetLogger("pubstatepurgemgmt")
+
+const (
+	expiryPrefix = '1'
+)
+
+// expiryInfoKey is used as a key of an entry in the bookkeeper (backed by a leveldb instance)
+type expiryInfoKey struct {
+	committingBlk uint64
+	expiryBlk     uint64
+}
+
+// expiryInfo encapsulates an 'expiryInfoKey' and corresponding public state keys.
+// In another words, this struct encapsulates the keys that are committed by the block
+// number 'expiryInfoKey.committingBlk' and should be expired (and hence purged) with
+// the commit of block number 'expiryInfoKey.expiryBlk'
+type expiryInfo struct {
+	expiryInfoKey *expiryInfoKey
+	pubStateKeys  *PubStateKeys
+}
+
+// expiryKeeper is used to keep track of the expired items in the public state
+type expiryKeeper interface {
+	// updateBookkeeping keeps track of the list of keys and their corresponding expiry block number
+	updateBookkeeping(toTrack []*expiryInfo, toClear []*expiryInfoKey) error
+	// retrieve returns the keys info that are supposed to be expired by the given block number
+	retrieve(expiringAtBlkNum uint64) ([]*expiryInfo, error)
+}
+
+func newExpiryKeeper(ledgerid string, provider bookkeeping.Provider) expiryKeeper {
+	return &expKeeper{provider.GetDBHandle(ledgerid, bookkeeping.PubStateExpiry)}
+}
+
+type expKeeper struct {
+	db *leveldbhelper.DBHandle
+}
+
+// updateBookkeeping updates the information stored in the bookkeeper.
+// 'toTrack' parameter causes new entries in the bookkeeper and 'toClear' parameter contains the entries that
+// are to be removed from the bookkeeper. This function is invoked with the commit of every block, in the same
+// manner as the bookkeeping of the expiry of the private data (see package pvtstatepurgemgmt)
+func (ek *expKeeper) updateBookkeeping(toTrack []*expiryInfo, toClear []*expiryInfoKey) error {
+	updateBatch := leveldbhelper.NewUpdateBatch()
+	for _, expinfo := range toTrack {
+		k, v, err := encodeKV(expinfo)
+		if err != nil {
+			return err
+		}
+		updateBatch.Put(k, v)
+	}
+	for _, expinfokey := range toClear {
+		updateBatch.Delete(encodeExpiryInfoKey(expinfokey))
+	}
+	return ek.db.WriteBatch(updateBatch, true)
+}
+
+func (ek *expKeeper) retrieve(expiringAtBlkNum uint64) ([]*expiryInfo, error) {
+	startKey := encodeExpiryInfoKey(&expiryInfoKey{expiryBlk: expiringAtBlkNum, committingBlk: 0})
+	endKey := encodeExpiryInfoKey(&expiryInfoKey{expiryBlk: expiringAtBlkNum + 1, committingBlk: 0})
+	itr := ek.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+
+	var listExpinfo []*expiryInfo
+	for itr.Next() {
+		expinfo, err := decodeExpiryInfo(itr.Key(), itr.Value())
+		if err != nil {
+			return nil, err
+		}
+		listExpinfo = append(listExpinfo, expinfo)
+	}
+	return listExpinfo, nil
+}
+
+func encodeKV(expinfo *expiryInfo) (key []byte, value []byte, err error) {
+	key = encodeExpiryInfoKey(expinfo.expiryInfoKey)
+	value, err = proto.Marshal(expinfo.pubStateKeys)
+	return
+}
+
+func encodeExpiryInfoKey(expinfoKey *expiryInfoKey) []byte {
+	key := append([]byte{expiryPrefix}, util.EncodeOrderPreservingVarUint64(expinfoKey.expiryBlk)...)
+	return append(key, util.EncodeOrderPreservingVarUint64(expinfoKey.committingBlk)...)
+}
+
+func decodeExpiryInfo(key []byte, value []byte) (*expiryInfo, error) {
+	expiryBlk, n := util.DecodeOrderPreservingVarUint64(key[1:])
+	committingBlk, _ := util.DecodeOrderPreservingVarUint64(key[n+1:])
+	pubStateKeys := &PubStateKeys{}
+	if err := proto.Unmarshal(value, pubStateKeys); err != nil {
+		return nil, err
+	}
+	return &expiryInfo{
+			expiryInfoKey: &expiryInfoKey{committingBlk: committingBlk, expiryBlk: expiryBlk},
+			pubStateKeys:  pubStateKeys},
+		nil
+}
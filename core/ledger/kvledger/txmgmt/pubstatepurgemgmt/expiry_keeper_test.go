@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pubstatepurgemgmt
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiryKVEncoding(t *testing.T) {
+	pubStateKeys := newPubStateKeys()
+	pubStateKeys.add("ns1", "key-1")
+	expiryInfo := &expiryInfo{&expiryInfoKey{expiryBlk: 10, committingBlk: 2}, pubStateKeys}
+	k, v, err := encodeKV(expiryInfo)
+	assert.NoError(t, err)
+	expiryInfo1, err := decodeExpiryInfo(k, v)
+	assert.NoError(t, err)
+	assert.Equal(t, expiryInfo.expiryInfoKey, expiryInfo1.expiryInfoKey)
+	assert.True(t, proto.Equal(expiryInfo.pubStateKeys, expiryInfo1.pubStateKeys), "proto messages are not equal")
+}
+
+func TestExpiryKeeper(t *testing.T) {
+	testenv := bookkeeping.NewTestEnv(t)
+	defer testenv.Cleanup()
+	expiryKeeper := newExpiryKeeper("testledger", testenv.TestProvider)
+
+	expinfo1 := &expiryInfo{&expiryInfoKey{committingBlk: 3, expiryBlk: 13}, buildPubStateKeysForTest("ns1", "key1")}
+	expinfo2 := &expiryInfo{&expiryInfoKey{committingBlk: 3, expiryBlk: 15}, buildPubStateKeysForTest("ns1", "key2")}
+	expinfo3 := &expiryInfo{&expiryInfoKey{committingBlk: 4, expiryBlk: 13}, buildPubStateKeysForTest("ns2", "key3")}
+
+	assert.NoError(t, expiryKeeper.updateBookkeeping([]*expiryInfo{expinfo1, expinfo2, expinfo3}, nil))
+
+	listExpinfo, err := expiryKeeper.retrieve(13)
+	assert.NoError(t, err)
+	assert.Len(t, listExpinfo, 2)
+	assert.Equal(t, expinfo1.expiryInfoKey, listExpinfo[0].expiryInfoKey)
+	assert.True(t, proto.Equal(expinfo1.pubStateKeys, listExpinfo[0].pubStateKeys))
+	assert.Equal(t, expinfo3.expiryInfoKey, listExpinfo[1].expiryInfoKey)
+	assert.True(t, proto.Equal(expinfo3.pubStateKeys, listExpinfo[1].pubStateKeys))
+
+	// Clear entries for the expiring block 13 and the entry for the expiring block 15 should be intact
+	assert.NoError(t, expiryKeeper.updateBookkeeping(nil, []*expiryInfoKey{expinfo1.expiryInfoKey, expinfo3.expiryInfoKey}))
+	listExpinfo, err = expiryKeeper.retrieve(13)
+	assert.NoError(t, err)
+	assert.Len(t, listExpinfo, 0)
+	listExpinfo, err = expiryKeeper.retrieve(15)
+	assert.NoError(t, err)
+	assert.Len(t, listExpinfo, 1)
+	assert.True(t, proto.Equal(expinfo2.pubStateKeys, listExpinfo[0].pubStateKeys))
+}
+
+func buildPubStateKeysForTest(ns string, keys ...string) *PubStateKeys {
+	pubStateKeys := newPubStateKeys()
+	for _, key := range keys {
+		pubStateKeys.add(ns, key)
+	}
+	return pubStateKeys
+}
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pubstatepurgemgmt
+
+import (
+	"math"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/storageutil"
+)
+
+type expiryScheduleBuilder struct {
+	scheduleEntries map[expiryInfoKey]*PubStateKeys
+}
+
+func newExpiryScheduleBuilder() *expiryScheduleBuilder {
+	return &expiryScheduleBuilder{make(map[expiryInfoKey]*PubStateKeys)}
+}
+
+func (builder *expiryScheduleBuilder) add(ns, key string, versionedValue *statedb.VersionedValue) {
+	if isDelete(versionedValue) {
+		return
+	}
+	btl, err := storageutil.GetBlockToLive(versionedValue.Metadata)
+	if err != nil {
+		// the chaincode handler rejects malformed values, hence this can only be
+		// caused by a malicious endorser and all the peers treat it in the same manner
+		logger.Warningf("Ignoring the block-to-live of the key [ns=%s, key=%s]: %s", ns, key, err)
+		return
+	}
+	if btl == 0 {
+		return
+	}
+	committingBlk := versionedValue.Version.BlockNum
+	expiryBlk := computeExpiringBlock(committingBlk, btl)
+	if neverExpires(expiryBlk) {
+		return
+	}
+	expinfoKey := expiryInfoKey{committingBlk: committingBlk, expiryBlk: expiryBlk}
+	pubStateKeys, ok := builder.scheduleEntries[expinfoKey]
+	if !ok {
+		pubStateKeys = newPubStateKeys()
+		builder.scheduleEntries[expinfoKey] = pubStateKeys
+	}
+	pubStateKeys.add(ns, key)
+}
+
+// computeExpiringBlock follows the same arithmetic as the BlockToLive of the private data collections,
+// i.e., a key committed in block 'committingBlk' is purged with the commit of block 'committingBlk + btl + 1'
+func computeExpiringBlock(committingBlk, btl uint64) uint64 {
+	expiryBlk := committingBlk + btl + uint64(1)
+	if expiryBlk <= committingBlk { // committingBlk + btl overflows uint64-max
+		expiryBlk = math.MaxUint64
+	}
+	return expiryBlk
+}
+
+func isDelete(versionedValue *statedb.VersionedValue) bool {
+	return versionedValue.Value == nil
+}
+
+func neverExpires(expiryBlk uint64) bool {
+	return expiryBlk == math.MaxUint64
+}
+
+func (builder *expiryScheduleBuilder) getExpiryInfo() []*expiryInfo {
+	var listExpinfo []*expiryInfo
+	for expinfoKey, pubStateKeys := range builder.scheduleEntries {
+		expinfoKeyCopy := expinfoKey
+		listExpinfo = append(listExpinfo, &expiryInfo{expiryInfoKey: &expinfoKeyCopy, pubStateKeys: pubStateKeys})
+	}
+	return listExpinfo
+}
+
+func buildExpirySchedule(pubUpdates *privacyenabledstate.PubUpdateBatch) []*expiryInfo {
+	logger.Debugf("Building the expiry schedules based on the update batch")
+	expiryScheduleBuilder := newExpiryScheduleBuilder()
+	for _, ns := range pubUpdates.GetUpdatedNamespaces() {
+		for key, vv := range pubUpdates.GetUpdates(ns) {
+			expiryScheduleBuilder.add(ns, key, vv)
+		}
+	}
+	return expiryScheduleBuilder.getExpiryInfo()
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pubstatepurgemgmt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/storageutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildExpirySchedule(t *testing.T) {
+	updates := privacyenabledstate.NewPubUpdateBatch()
+	updates.PutValAndMetadata("ns1", "key1", []byte("value1"), btlMetadataForTest(t, 1), version.NewHeight(1, 1))
+	updates.PutValAndMetadata("ns1", "key2", []byte("value2"), btlMetadataForTest(t, 2), version.NewHeight(1, 2))
+	updates.PutValAndMetadata("ns2", "key3", []byte("value3"), btlMetadataForTest(t, 2), version.NewHeight(1, 3))
+	updates.Put("ns2", "key4", []byte("value4"), version.NewHeight(1, 4))
+	updates.PutValAndMetadata("ns2", "key5", []byte("value5"), btlMetadataForTest(t, 0), version.NewHeight(1, 5))
+	updates.PutValAndMetadata("ns2", "key6", []byte("value6"), btlMetadataForTest(t, math.MaxUint64), version.NewHeight(1, 6))
+	updates.PutValAndMetadata("ns2", "key7", []byte("value7"), metadataForTest(t, []byte{0xff}), version.NewHeight(1, 7))
+	updates.Delete("ns2", "key8", version.NewHeight(1, 8))
+
+	listExpinfo := buildExpirySchedule(updates)
+	assert.Len(t, listExpinfo, 2)
+	expected := map[expiryInfoKey]*PubStateKeys{
+		{committingBlk: 1, expiryBlk: 3}: buildPubStateKeysForTest("ns1", "key1"),
+		{committingBlk: 1, expiryBlk: 4}: {Map: map[string]*Keys{
+			"ns1": {List: []string{"key2"}},
+			"ns2": {List: []string{"key3"}},
+		}},
+	}
+	for _, expinfo := range listExpinfo {
+		assert.True(t, proto.Equal(expected[*expinfo.expiryInfoKey], expinfo.pubStateKeys))
+	}
+}
+
+func btlMetadataForTest(t *testing.T, btl uint64) []byte {
+	return metadataForTest(t, storageutil.EncodeBlockToLive(btl))
+}
+
+func metadataForTest(t *testing.T, btlValue []byte) []byte {
+	metadata, err := storageutil.SerializeMetadata([]*kvrwset.KVMetadataEntry{
+		{Name: "ENDORSEMENT_POLICY", Value: []byte("policy")},
+		{Name: storageutil.BlockToLiveMetadataKey, Value: btlValue},
+	})
+	assert.NoError(t, err)
+	return metadata
+}
@@ -0,0 +1,122 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: pubstate_keys.proto
+
+package pubstatepurgemgmt // import "github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/pubstatepurgemgmt"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type PubStateKeys struct {
+	Map                  map[string]*Keys `protobuf:"bytes,1,rep,name=map,proto3" json:"map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PubStateKeys) Reset()         { *m = PubStateKeys{} }
+func (m *PubStateKeys) String() string { return proto.CompactTextString(m) }
+func (*PubStateKeys) ProtoMessage()    {}
+func (*PubStateKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pubstate_keys_231dcfa7ee010382, []int{0}
+}
+func (m *PubStateKeys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PubStateKeys.Unmarshal(m, b)
+}
+func (m *PubStateKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PubStateKeys.Marshal(b, m, deterministic)
+}
+func (dst *PubStateKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubStateKeys.Merge(dst, src)
+}
+func (m *PubStateKeys) XXX_Size() int {
+	return xxx_messageInfo_PubStateKeys.Size(m)
+}
+func (m *PubStateKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubStateKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubStateKeys proto.InternalMessageInfo
+
+func (m *PubStateKeys) GetMap() map[string]*Keys {
+	if m != nil {
+		return m.Map
+	}
+	return nil
+}
+
+type Keys struct {
+	List                 []string `protobuf:"bytes,1,rep,name=list,proto3" json:"list,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Keys) Reset()         { *m = Keys{} }
+func (m *Keys) String() string { return proto.CompactTextString(m) }
+func (*Keys) ProtoMessage()    {}
+func (*Keys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_pubstate_keys_231dcfa7ee010382, []int{1}
+}
+func (m *Keys) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Keys.Unmarshal(m, b)
+}
+func (m *Keys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Keys.Marshal(b, m, deterministic)
+}
+func (dst *Keys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Keys.Merge(dst, src)
+}
+func (m *Keys) XXX_Size() int {
+	return xxx_messageInfo_Keys.Size(m)
+}
+func (m *Keys) XXX_DiscardUnknown() {
+	xxx_messageInfo_Keys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Keys proto.InternalMessageInfo
+
+func (m *Keys) GetList() []string {
+	if m != nil {
+		return m.List
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*PubStateKeys)(nil), "pubstatepurgemgmt.PubStateKeys")
+	proto.RegisterMapType((map[string]*Keys)(nil), "pubstatepurgemgmt.PubStateKeys.MapEntry")
+	proto.RegisterType((*Keys)(nil), "pubstatepurgemgmt.Keys")
+}
+
+func init() { proto.RegisterFile("pubstate_keys.proto", fileDescriptor_pubstate_keys_231dcfa7ee010382) }
+
+var fileDescriptor_pubstate_keys_231dcfa7ee010382 = []byte{
+	// 230 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2e, 0x28, 0x4d, 0x2a,
+	0x2e, 0x49, 0x2c, 0x49, 0x8d, 0xcf, 0x4e, 0xad, 0x2c, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
+	0x12, 0x84, 0x09, 0x16, 0x94, 0x16, 0xa5, 0xa7, 0xe6, 0xa6, 0xe7, 0x96, 0x28, 0xcd, 0x66, 0xe4,
+	0xe2, 0x09, 0x28, 0x4d, 0x0a, 0x06, 0x89, 0x7a, 0xa7, 0x56, 0x16, 0x0b, 0x59, 0x71, 0x31, 0xe7,
+	0x26, 0x16, 0x48, 0x30, 0x2a, 0x30, 0x6b, 0x70, 0x1b, 0x69, 0xe8, 0x61, 0xe8, 0xd0, 0x43, 0x56,
+	0xad, 0xe7, 0x9b, 0x58, 0xe0, 0x9a, 0x57, 0x52, 0x54, 0x19, 0x04, 0xd2, 0x24, 0xe5, 0xcf, 0xc5,
+	0x01, 0x13, 0x10, 0x12, 0xe0, 0x62, 0xce, 0x4e, 0xad, 0x94, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c,
+	0x02, 0x31, 0x85, 0x74, 0xb9, 0x58, 0xcb, 0x12, 0x73, 0x4a, 0x53, 0x25, 0x98, 0x14, 0x18, 0x35,
+	0xb8, 0x8d, 0xc4, 0xb1, 0x98, 0x0d, 0x32, 0x33, 0x08, 0xa2, 0xca, 0x8a, 0xc9, 0x82, 0x51, 0x49,
+	0x8a, 0x8b, 0x05, 0xec, 0x28, 0x21, 0x2e, 0x96, 0x9c, 0xcc, 0xe2, 0x12, 0xb0, 0xab, 0x38, 0x83,
+	0xc0, 0x6c, 0x27, 0xdf, 0x28, 0xef, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24, 0xbd, 0xe4, 0xfc, 0x5c,
+	0xfd, 0x8c, 0xca, 0x82, 0xd4, 0xa2, 0x9c, 0xd4, 0x94, 0xf4, 0xd4, 0x22, 0xfd, 0xb4, 0xc4, 0xa4,
+	0xa2, 0xcc, 0x64, 0xfd, 0xe4, 0xfc, 0xa2, 0x54, 0x7d, 0xa8, 0x50, 0x76, 0x19, 0x94, 0x51, 0x52,
+	0x01, 0xb2, 0x48, 0x1f, 0xc3, 0xea, 0x24, 0x36, 0x70, 0x10, 0x19, 0x03, 0x06, 0x00, 0x14, 0xf4,
+	0xa7, 0xc6, 0x39, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,20 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pubstatepurgemgmt
+
+func (pubStateKeys *PubStateKeys) add(ns string, key string) {
+	keys, ok := pubStateKeys.Map[ns]
+	if !ok {
+		keys = &Keys{}
+		pubStateKeys.Map[ns] = keys
+	}
+	keys.List = append(keys.List, key)
+}
+
+func newPubStateKeys() *PubStateKeys {
+	return &PubStateKeys{Map: make(map[string]*Keys)}
+}
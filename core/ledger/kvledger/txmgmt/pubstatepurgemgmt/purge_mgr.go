@@ -0,0 +1,199 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pubstatepurgemgmt
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/storageutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+// PurgeMgr manages purging of the public state keys that are written with a block-to-live
+type PurgeMgr interface {
+	// PrepareForExpiringKeys gives a chance to the PurgeMgr to do background work in advance if any
+	PrepareForExpiringKeys(expiringAtBlk uint64)
+	// WaitForPrepareToFinish holds the caller till the background goroutine lauched by 'PrepareForExpiringKeys' is finished
+	WaitForPrepareToFinish()
+	// DeleteExpiredAndUpdateBookkeeping updates the bookkeeping and modifies the update batch by adding the deletes for the expired keys.
+	// The keys deleted are returned
+	DeleteExpiredAndUpdateBookkeeping(pubUpdates *privacyenabledstate.PubUpdateBatch) ([]*txmgr.PurgedKey, error)
+	// BlockCommitDone is a callback to the PurgeMgr when the block is committed to the ledger
+	BlockCommitDone() error
+}
+
+type compositeKey struct {
+	ns, key string
+}
+
+type workingset struct {
+	toPurge             map[compositeKey]uint64
+	toClearFromSchedule []*expiryInfoKey
+	expiringBlk         uint64
+	err                 error
+}
+
+type purgeMgr struct {
+	db        privacyenabledstate.DB
+	expKeeper expiryKeeper
+
+	lock    *sync.Mutex
+	waitGrp *sync.WaitGroup
+
+	workingset *workingset
+}
+
+// InstantiatePurgeMgr instantiates a PurgeMgr.
+func InstantiatePurgeMgr(ledgerid string, db privacyenabledstate.DB, bookkeepingProvider bookkeeping.Provider) (PurgeMgr, error) {
+	return &purgeMgr{
+		db:        db,
+		expKeeper: newExpiryKeeper(ledgerid, bookkeepingProvider),
+		lock:      &sync.Mutex{},
+		waitGrp:   &sync.WaitGroup{},
+	}, nil
+}
+
+// ImportExpirySchedule builds the expiry schedule for the public state keys carried in the snapshot files
+// present in the directory 'snapshotDir'. This is expected to be invoked while bootstrapping a ledger from
+// a snapshot so that the keys with a block-to-live are purged at the same block as on the other peers
+func ImportExpirySchedule(ledgerid string, bookkeepingProvider bookkeeping.Provider, snapshotDir string) error {
+	builder := newExpiryScheduleBuilder()
+	err := privacyenabledstate.ReadPubStateFromSnapshot(snapshotDir,
+		func(namespace, key string, vv *statedb.VersionedValue) error {
+			builder.add(namespace, key, vv)
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return newExpiryKeeper(ledgerid, bookkeepingProvider).updateBookkeeping(builder.getExpiryInfo(), nil)
+}
+
+// ScheduleExpiry adds the keys in the given updates that carry a block-to-live to the expiry schedule of the ledger.
+// It is used when the keys are written to the state database other than by the commit of a block
+func ScheduleExpiry(ledgerid string, bookkeepingProvider bookkeeping.Provider, pubUpdates *privacyenabledstate.PubUpdateBatch) error {
+	return newExpiryKeeper(ledgerid, bookkeepingProvider).updateBookkeeping(buildExpirySchedule(pubUpdates), nil)
+}
+
+// HasExpired returns true if the key with the given value would have been purged with the commit of the given block,
+// or an earlier block, as per the block-to-live recorded in the metadata of the key
+func HasExpired(versionedValue *statedb.VersionedValue, lastCommittedBlk uint64) bool {
+	if isDelete(versionedValue) {
+		return false
+	}
+	btl, err := storageutil.GetBlockToLive(versionedValue.Metadata)
+	if err != nil || btl == 0 {
+		return false
+	}
+	return computeExpiringBlock(versionedValue.Version.BlockNum, btl) <= lastCommittedBlk
+}
+
+// PrepareForExpiringKeys implements function in the interface 'PurgeMgr'
+func (p *purgeMgr) PrepareForExpiringKeys(expiringAtBlk uint64) {
+	p.waitGrp.Add(1)
+	go func() {
+		p.lock.Lock()
+		p.waitGrp.Done()
+		defer p.lock.Unlock()
+		p.workingset = p.prepareWorkingsetFor(expiringAtBlk)
+	}()
+	p.waitGrp.Wait()
+}
+
+// WaitForPrepareToFinish implements function in the interface 'PurgeMgr'
+func (p *purgeMgr) WaitForPrepareToFinish() {
+	p.lock.Lock()
+	p.lock.Unlock()
+}
+
+// DeleteExpiredAndUpdateBookkeeping implements function in the interface 'PurgeMgr'
+func (p *purgeMgr) DeleteExpiredAndUpdateBookkeeping(pubUpdates *privacyenabledstate.PubUpdateBatch) ([]*txmgr.PurgedKey, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.workingset.err != nil {
+		return nil, p.workingset.err
+	}
+
+	listExpiryInfo := buildExpirySchedule(pubUpdates)
+
+	// For each key selected for purging, check if the key is not getting updated in the current block,
+	// add its deletion in the update batch
+	expiringTxVersion := version.NewHeight(p.workingset.expiringBlk, math.MaxUint64)
+	var purgedKeys []*txmgr.PurgedKey
+	for k := range p.workingset.toPurge {
+		if pubUpdates.Exists(k.ns, k.key) {
+			logger.Debugf("Key [ns=%s, key=%s] is updated in the committing block, hence not purging", k.ns, k.key)
+			continue
+		}
+		logger.Debugf("Adding the expired key [ns=%s, key=%s] to the delete list in the update batch", k.ns, k.key)
+		pubUpdates.Delete(k.ns, k.key, expiringTxVersion)
+		purgedKeys = append(purgedKeys, &txmgr.PurgedKey{Namespace: k.ns, Key: k.key})
+	}
+	if err := p.expKeeper.updateBookkeeping(listExpiryInfo, nil); err != nil {
+		return nil, err
+	}
+	sort.Slice(purgedKeys, func(i, j int) bool {
+		if purgedKeys[i].Namespace != purgedKeys[j].Namespace {
+			return purgedKeys[i].Namespace < purgedKeys[j].Namespace
+		}
+		return purgedKeys[i].Key < purgedKeys[j].Key
+	})
+	return purgedKeys, nil
+}
+
+// BlockCommitDone implements function in the interface 'PurgeMgr'
+func (p *purgeMgr) BlockCommitDone() error {
+	defer func() { p.workingset = nil }()
+	return p.expKeeper.updateBookkeeping(nil, p.workingset.toClearFromSchedule)
+}
+
+// prepareWorkingsetFor returns a working set for a given expiring block 'expiringAtBlk'.
+// This working set contains the public state keys that will expire with the commit of block 'expiringAtBlk'.
+func (p *purgeMgr) prepareWorkingsetFor(expiringAtBlk uint64) *workingset {
+	logger.Debugf("Preparing potential purge list working-set for expiringAtBlk [%d]", expiringAtBlk)
+	workingset := &workingset{expiringBlk: expiringAtBlk}
+	expiryInfo, err := p.expKeeper.retrieve(expiringAtBlk)
+	if err != nil {
+		workingset.err = err
+		return workingset
+	}
+	toPurge := make(map[compositeKey]uint64)
+	var expiryInfoKeysToClear []*expiryInfoKey
+	for _, expinfo := range expiryInfo {
+		expiryInfoKeysToClear = append(expiryInfoKeysToClear, expinfo.expiryInfoKey)
+		for ns, keys := range expinfo.pubStateKeys.Map {
+			for _, key := range keys.List {
+				toPurge[compositeKey{ns, key}] = expinfo.expiryInfoKey.committingBlk
+			}
+		}
+	}
+	logger.Debugf("Total [%d] expiring entries found. Evaluating whether some of these keys have been overwritten in later blocks...", len(toPurge))
+
+	// A key that has been written (or its metadata has been changed) in a later block carries a newer
+	// version and, if it still has a block-to-live, a later entry in the expiry schedule
+	for k, committingBlk := range toPurge {
+		currentVersion, err := p.db.GetVersion(k.ns, k.key)
+		if err != nil {
+			workingset.err = err
+			return workingset
+		}
+		if currentVersion == nil || currentVersion.BlockNum != committingBlk {
+			logger.Debugf("Removing key [ns=%s, key=%s] from the purge list as it has been deleted or overwritten", k.ns, k.key)
+			delete(toPurge, k)
+		}
+	}
+	workingset.toPurge = toPurge
+	workingset.toClearFromSchedule = expiryInfoKeysToClear
+	return workingset
+}
@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pubstatepurgemgmt
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	flogging.ActivateSpec("pubstatepurgemgmt,privacyenabledstate=debug")
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger/pubstatepurgemgmt")
+	os.Exit(m.Run())
+}
+
+func TestPurgeMgr(t *testing.T) {
+	dbEnvs := []privacyenabledstate.TestEnv{
+		&privacyenabledstate.LevelDBCommonStorageTestEnv{},
+		&privacyenabledstate.CouchDBCommonStorageTestEnv{},
+	}
+	for _, dbEnv := range dbEnvs {
+		t.Run(dbEnv.GetName(), func(t *testing.T) { testPurgeMgr(t, dbEnv) })
+	}
+}
+
+func testPurgeMgr(t *testing.T, dbEnv privacyenabledstate.TestEnv) {
+	testHelper := &testHelper{}
+	testHelper.init(t, "testledger-purge-mgr", dbEnv)
+	defer testHelper.cleanup()
+
+	block1Updates := privacyenabledstate.NewUpdateBatch()
+	block1Updates.PubUpdates.PutValAndMetadata("ns1", "key1", []byte("value1-1"), btlMetadataForTest(t, 1), version.NewHeight(1, 1))
+	block1Updates.PubUpdates.PutValAndMetadata("ns1", "key2", []byte("value2-1"), btlMetadataForTest(t, 2), version.NewHeight(1, 2))
+	block1Updates.PubUpdates.PutValAndMetadata("ns2", "key3", []byte("value3-1"), btlMetadataForTest(t, 2), version.NewHeight(1, 3))
+	block1Updates.PubUpdates.PutValAndMetadata("ns2", "key4", []byte("value4-1"), btlMetadataForTest(t, 3), version.NewHeight(1, 4))
+	block1Updates.PubUpdates.Put("ns2", "key5", []byte("value5-1"), version.NewHeight(1, 5))
+	assert.Empty(t, testHelper.commitUpdatesForTesting(1, block1Updates))
+	testHelper.checkKeyExists("ns1", "key1", []byte("value1-1"))
+
+	// key2 is overwritten in block 2 without a block-to-live
+	block2Updates := privacyenabledstate.NewUpdateBatch()
+	block2Updates.PubUpdates.Put("ns1", "key2", []byte("value2-2"), version.NewHeight(2, 1))
+	testHelper.commitUpdatesForTesting(2, block2Updates)
+	testHelper.checkKeyExists("ns1", "key1", []byte("value1-1"))
+
+	// key1 expires with block 3
+	block3Updates := privacyenabledstate.NewUpdateBatch()
+	purgedKeys := testHelper.commitUpdatesForTesting(3, block3Updates)
+	assert.Equal(t, []*txmgr.PurgedKey{{Namespace: "ns1", Key: "key1"}}, purgedKeys)
+	testHelper.checkKeyDoesNotExist("ns1", "key1")
+	testHelper.checkKeyExists("ns2", "key3", []byte("value3-1"))
+
+	// key3 expires with block 4 - whereas, key2 has been overwritten in block 2 and key4 is updated in block 4 itself
+	block4Updates := privacyenabledstate.NewUpdateBatch()
+	block4Updates.PubUpdates.Put("ns2", "key4", []byte("value4-4"), version.NewHeight(4, 1))
+	purgedKeys = testHelper.commitUpdatesForTesting(4, block4Updates)
+	assert.Equal(t, []*txmgr.PurgedKey{{Namespace: "ns2", Key: "key3"}}, purgedKeys)
+	testHelper.checkKeyExists("ns1", "key2", []byte("value2-2"))
+	testHelper.checkKeyDoesNotExist("ns2", "key3")
+	testHelper.checkKeyExists("ns2", "key4", []byte("value4-4"))
+
+	// key4 would have expired with block 5 had it not been overwritten in block 4
+	block5Updates := privacyenabledstate.NewUpdateBatch()
+	assert.Empty(t, testHelper.commitUpdatesForTesting(5, block5Updates))
+	testHelper.checkKeyExists("ns2", "key4", []byte("value4-4"))
+	testHelper.checkKeyExists("ns2", "key5", []byte("value5-1"))
+}
+
+func TestImportExpirySchedule(t *testing.T) {
+	dbEnv := &privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	dbEnv.Init(t)
+	defer dbEnv.Cleanup()
+	db := dbEnv.GetDBHandle("source-ledger")
+
+	updates := privacyenabledstate.NewUpdateBatch()
+	updates.PubUpdates.PutValAndMetadata("ns1", "key1", []byte("value1"), btlMetadataForTest(t, 5), version.NewHeight(2, 1))
+	updates.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(2, 2))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(3, 1)))
+
+	snapshotDir, err := ioutil.TempDir("", "pubstatepurgemgmt")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	_, err = db.(*privacyenabledstate.CommonStorageDB).ExportPubStateAndPvtStateHashes(snapshotDir)
+	assert.NoError(t, err)
+
+	bookkeepingEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeepingEnv.Cleanup()
+	assert.NoError(t, ImportExpirySchedule("target-ledger", bookkeepingEnv.TestProvider, snapshotDir))
+	listExpinfo, err := newExpiryKeeper("target-ledger", bookkeepingEnv.TestProvider).retrieve(8)
+	assert.NoError(t, err)
+	assert.Len(t, listExpinfo, 1)
+	assert.Equal(t, &expiryInfoKey{committingBlk: 2, expiryBlk: 8}, listExpinfo[0].expiryInfoKey)
+	assert.True(t, proto.Equal(buildPubStateKeysForTest("ns1", "key1"), listExpinfo[0].pubStateKeys))
+}
+
+type testHelper struct {
+	t              *testing.T
+	bookkeepingEnv *bookkeeping.TestEnv
+	dbEnv          privacyenabledstate.TestEnv
+
+	db       privacyenabledstate.DB
+	purgeMgr PurgeMgr
+}
+
+func TestScheduleExpiry(t *testing.T) {
+	updates := privacyenabledstate.NewPubUpdateBatch()
+	updates.PutValAndMetadata("ns1", "key1", []byte("value1"), btlMetadataForTest(t, 5), version.NewHeight(2, 1))
+	updates.Put("ns1", "key2", []byte("value2"), version.NewHeight(2, 2))
+
+	bookkeepingEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeepingEnv.Cleanup()
+	assert.NoError(t, ScheduleExpiry("ledger1", bookkeepingEnv.TestProvider, updates))
+	listExpinfo, err := newExpiryKeeper("ledger1", bookkeepingEnv.TestProvider).retrieve(8)
+	assert.NoError(t, err)
+	assert.Len(t, listExpinfo, 1)
+	assert.Equal(t, &expiryInfoKey{committingBlk: 2, expiryBlk: 8}, listExpinfo[0].expiryInfoKey)
+	assert.True(t, proto.Equal(buildPubStateKeysForTest("ns1", "key1"), listExpinfo[0].pubStateKeys))
+}
+
+func TestHasExpired(t *testing.T) {
+	withBTL := &statedb.VersionedValue{Value: []byte("value1"), Metadata: btlMetadataForTest(t, 5), Version: version.NewHeight(2, 1)}
+	assert.False(t, HasExpired(withBTL, 7))
+	assert.True(t, HasExpired(withBTL, 8))
+	assert.True(t, HasExpired(withBTL, 100))
+
+	withoutBTL := &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(2, 1)}
+	assert.False(t, HasExpired(withoutBTL, 100))
+	invalidBTL := &statedb.VersionedValue{Value: []byte("value1"), Metadata: []byte("junk"), Version: version.NewHeight(2, 1)}
+	assert.False(t, HasExpired(invalidBTL, 100))
+}
+
+func (h *testHelper) init(t *testing.T, ledgerid string, dbEnv privacyenabledstate.TestEnv) {
+	h.t = t
+	h.bookkeepingEnv = bookkeeping.NewTestEnv(t)
+	dbEnv.Init(t)
+	h.dbEnv = dbEnv
+	h.db = h.dbEnv.GetDBHandle(ledgerid)
+	var err error
+	if h.purgeMgr, err = InstantiatePurgeMgr(ledgerid, h.db, h.bookkeepingEnv.TestProvider); err != nil {
+		t.Fatalf("err:%s", err)
+	}
+}
+
+func (h *testHelper) cleanup() {
+	h.bookkeepingEnv.Cleanup()
+	h.dbEnv.Cleanup()
+}
+
+func (h *testHelper) commitUpdatesForTesting(blkNum uint64, updates *privacyenabledstate.UpdateBatch) []*txmgr.PurgedKey {
+	h.purgeMgr.PrepareForExpiringKeys(blkNum)
+	purgedKeys, err := h.purgeMgr.DeleteExpiredAndUpdateBookkeeping(updates.PubUpdates)
+	assert.NoError(h.t, err)
+	assert.NoError(h.t, h.db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(blkNum, 1)))
+	h.db.ClearCachedVersions()
+	assert.NoError(h.t, h.purgeMgr.BlockCommitDone())
+	return purgedKeys
+}
+
+func (h *testHelper) checkKeyExists(ns, key string, value []byte) {
+	vv, err := h.db.GetState(ns, key)
+	assert.NoError(h.t, err)
+	assert.NotNil(h.t, vv)
+	assert.Equal(h.t, value, vv.Value)
+}
+
+func (h *testHelper) checkKeyDoesNotExist(ns, key string) {
+	vv, err := h.db.GetState(ns, key)
+	assert.NoError(h.t, err)
+	assert.Nil(h.t, vv)
+}
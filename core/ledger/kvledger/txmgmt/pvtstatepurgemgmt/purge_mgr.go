@@ -71,6 +71,22 @@ func InstantiatePurgeMgr(ledgerid string, db privacyenabledstate.DB, btlPolicy p
 	}, nil
 }
 
+// ImportExpirySchedule builds the expiry schedule for the hashed private state keys carried in the snapshot files
+// present in the directory 'snapshotDir'. This is expected to be invoked while bootstrapping a ledger from
+// a snapshot. As the snapshot does not carry the raw private keys, only the key hashes are scheduled for purge
+func ImportExpirySchedule(ledgerid string, btlPolicy pvtdatapolicy.BTLPolicy, bookkeepingProvider bookkeeping.Provider, snapshotDir string) error {
+	builder := newExpiryScheduleBuilder(btlPolicy)
+	err := privacyenabledstate.ReadPvtStateHashesFromSnapshot(snapshotDir,
+		func(namespace, coll string, keyHash []byte, vv *statedb.VersionedValue) error {
+			return builder.add(namespace, coll, "", keyHash, vv)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return newExpiryKeeper(ledgerid, bookkeepingProvider).updateBookkeeping(builder.getExpiryInfo(), nil)
+}
+
 // PrepareForExpiringKeys implements function in the interface 'PurgeMgr'
 func (p *purgeMgr) PrepareForExpiringKeys(expiringAtBlk uint64) {
 	p.waitGrp.Add(1)
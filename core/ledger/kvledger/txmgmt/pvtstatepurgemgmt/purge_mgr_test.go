@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package pvtstatepurgemgmt
 
 import (
+	"errors"
+	"io/ioutil"
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
@@ -274,6 +277,52 @@ func TestOnlyHashDeleteBeforeExpiryBlock(t *testing.T) {
 	testHelper.checkPvtdataDoesNotExist("ns", "coll", "pvtkey")
 }
 
+func TestImportExpirySchedule(t *testing.T) {
+	dbEnv := &privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	dbEnv.Init(t)
+	defer dbEnv.Cleanup()
+	db := dbEnv.GetDBHandle("source-ledger")
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns1", "coll1"}: 1,
+			{"ns1", "coll2"}: 0,
+		},
+	)
+
+	updates := privacyenabledstate.NewUpdateBatch()
+	putPvtAndHashUpdates(t, updates, "ns1", "coll1", "pvtkey1", []byte("pvtvalue1"), version.NewHeight(2, 1))
+	putPvtAndHashUpdates(t, updates, "ns1", "coll2", "pvtkey2", []byte("pvtvalue2"), version.NewHeight(2, 2))
+	assert.NoError(t, db.ApplyPrivacyAwareUpdates(updates, version.NewHeight(3, 1)))
+
+	snapshotDir, err := ioutil.TempDir("", "pvtstatepurgemgmt")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+	_, err = db.(*privacyenabledstate.CommonStorageDB).ExportPubStateAndPvtStateHashes(snapshotDir)
+	assert.NoError(t, err)
+
+	bookkeepingEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeepingEnv.Cleanup()
+	assert.NoError(t, ImportExpirySchedule("target-ledger", btlPolicy, bookkeepingEnv.TestProvider, snapshotDir))
+	listExpinfo, err := newExpiryKeeper("target-ledger", bookkeepingEnv.TestProvider).retrieve(4)
+	assert.NoError(t, err)
+	assert.Len(t, listExpinfo, 1)
+	assert.Equal(t, &expiryInfoKey{committingBlk: 2, expiryBlk: 4}, listExpinfo[0].expiryInfoKey)
+	expectedPvtdataKeys := newPvtdataKeys()
+	expectedPvtdataKeys.add("ns1", "coll1", "", util.ComputeStringHash("pvtkey1"))
+	assert.True(t, proto.Equal(expectedPvtdataKeys, listExpinfo[0].pvtdataKeys))
+
+	err = ImportExpirySchedule("another-ledger", &failingBTLPolicy{}, bookkeepingEnv.TestProvider, snapshotDir)
+	assert.EqualError(t, err, "btl policy error")
+}
+
+type failingBTLPolicy struct {
+	pvtdatapolicy.BTLPolicy
+}
+
+func (p *failingBTLPolicy) GetExpiringBlock(namespace, collection string, committingBlock uint64) (uint64, error) {
+	return 0, errors.New("btl policy error")
+}
+
 type testHelper struct {
 	t              *testing.T
 	bookkeepingEnv *bookkeeping.TestEnv
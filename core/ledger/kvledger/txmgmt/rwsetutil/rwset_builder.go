@@ -40,6 +40,10 @@ type nsPubRwBuilder struct {
 	metadataWriteMap  map[string]*kvrwset.KVMetadataWrite
 	rangeQueriesMap   map[rangeQueryKey]*kvrwset.RangeQueryInfo //for phantom read validation
 	rangeQueriesKeys  []rangeQueryKey
+	indexQueriesMap   map[indexQueryKey]*kvrwset.IndexQueryInfo //for phantom read validation
+	indexQueriesKeys  []indexQueryKey
+	richQueriesMap    map[string]*kvrwset.RichQueryInfo //for phantom read validation
+	richQueriesKeys   []string
 	collHashRwBuilder map[string]*collHashRwBuilder
 }
 
@@ -68,6 +72,13 @@ type rangeQueryKey struct {
 	itrExhausted bool
 }
 
+type indexQueryKey struct {
+	indexName    string
+	startKey     string
+	endKey       string
+	itrExhausted bool
+}
+
 // NewRWSetBuilder constructs a new instance of RWSetBuilder
 func NewRWSetBuilder() *RWSetBuilder {
 	return &RWSetBuilder{make(map[string]*nsPubRwBuilder), make(map[string]*nsPvtRwBuilder)}
@@ -103,6 +114,27 @@ func (b *RWSetBuilder) AddToRangeQuerySet(ns string, rqi *kvrwset.RangeQueryInfo
 	}
 }
 
+// AddToIndexQuerySet adds an index query info for performing phantom read validation
+func (b *RWSetBuilder) AddToIndexQuerySet(ns string, iqi *kvrwset.IndexQueryInfo) {
+	nsPubRwBuilder := b.getOrCreateNsPubRwBuilder(ns)
+	key := indexQueryKey{iqi.IndexName, string(iqi.StartKey), string(iqi.EndKey), iqi.ItrExhausted}
+	_, ok := nsPubRwBuilder.indexQueriesMap[key]
+	if !ok {
+		nsPubRwBuilder.indexQueriesMap[key] = iqi
+		nsPubRwBuilder.indexQueriesKeys = append(nsPubRwBuilder.indexQueriesKeys, key)
+	}
+}
+
+// AddToRichQuerySet adds a rich query info for performing phantom read validation
+func (b *RWSetBuilder) AddToRichQuerySet(ns string, rqi *kvrwset.RichQueryInfo) {
+	nsPubRwBuilder := b.getOrCreateNsPubRwBuilder(ns)
+	_, ok := nsPubRwBuilder.richQueriesMap[rqi.Query]
+	if !ok {
+		nsPubRwBuilder.richQueriesMap[rqi.Query] = rqi
+		nsPubRwBuilder.richQueriesKeys = append(nsPubRwBuilder.richQueriesKeys, rqi.Query)
+	}
+}
+
 // AddToHashedReadSet adds a key and corresponding version to the hashed read-set
 func (b *RWSetBuilder) AddToHashedReadSet(ns string, coll string, key string, version *version.Height) {
 	kvReadHash := newPvtKVReadHash(key, version)
@@ -198,6 +230,8 @@ func (b *nsPubRwBuilder) build() *NsRwSet {
 	var writeSet []*kvrwset.KVWrite
 	var metadataWriteSet []*kvrwset.KVMetadataWrite
 	var rangeQueriesInfo []*kvrwset.RangeQueryInfo
+	var indexQueriesInfo []*kvrwset.IndexQueryInfo
+	var richQueriesInfo []*kvrwset.RichQueryInfo
 	var collHashedRwSet []*CollHashedRwSet
 	//add read set
 	util.GetValuesBySortedKeys(&(b.readMap), &readSet)
@@ -208,6 +242,14 @@ func (b *nsPubRwBuilder) build() *NsRwSet {
 	for _, key := range b.rangeQueriesKeys {
 		rangeQueriesInfo = append(rangeQueriesInfo, b.rangeQueriesMap[key])
 	}
+	//add index query info
+	for _, key := range b.indexQueriesKeys {
+		indexQueriesInfo = append(indexQueriesInfo, b.indexQueriesMap[key])
+	}
+	//add rich query info
+	for _, key := range b.richQueriesKeys {
+		richQueriesInfo = append(richQueriesInfo, b.richQueriesMap[key])
+	}
 	// add hashed rws for private collections
 	sortedCollBuilders := []*collHashRwBuilder{}
 	util.GetValuesBySortedKeys(&(b.collHashRwBuilder), &sortedCollBuilders)
@@ -221,6 +263,8 @@ func (b *nsPubRwBuilder) build() *NsRwSet {
 			Writes:           writeSet,
 			MetadataWrites:   metadataWriteSet,
 			RangeQueriesInfo: rangeQueriesInfo,
+			IndexQueriesInfo: indexQueriesInfo,
+			RichQueriesInfo:  richQueriesInfo,
 		},
 		CollHashedRwSets: collHashedRwSet,
 	}
@@ -316,6 +360,10 @@ func newNsPubRwBuilder(namespace string) *nsPubRwBuilder {
 		make(map[string]*kvrwset.KVMetadataWrite),
 		make(map[rangeQueryKey]*kvrwset.RangeQueryInfo),
 		nil,
+		make(map[indexQueryKey]*kvrwset.IndexQueryInfo),
+		nil,
+		make(map[string]*kvrwset.RichQueryInfo),
+		nil,
 		make(map[string]*collHashRwBuilder),
 	}
 }
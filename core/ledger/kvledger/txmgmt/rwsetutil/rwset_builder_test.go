@@ -80,6 +80,51 @@ func TestTxSimulationResultWithOnlyPubData(t *testing.T) {
 	assert.Nil(t, txSimulationResults.PubSimulationResults.NsRwset[0].CollectionHashedRwset)
 }
 
+func TestTxSimulationResultWithIndexQueries(t *testing.T) {
+	rwSetBuilder := NewRWSetBuilder()
+
+	iqi1 := &kvrwset.IndexQueryInfo{IndexName: "ownerIndex", StartKey: []byte("s\"tom\"\x00"), EndKey: []byte("s\"tom\"\x00key2"), ItrExhausted: false}
+	iqi1.SetRawReads([]*kvrwset.KVRead{NewKVRead("key1", version.NewHeight(1, 1)), NewKVRead("key2", version.NewHeight(1, 2))})
+	rwSetBuilder.AddToIndexQuerySet("ns1", iqi1)
+
+	iqi2 := &kvrwset.IndexQueryInfo{IndexName: "ownerIndex", StartKey: []byte("s\"tom\"\x00"), EndKey: []byte("s\"tom\"\x00key2"), ItrExhausted: false}
+	iqi2.SetRawReads([]*kvrwset.KVRead{NewKVRead("key1", version.NewHeight(1, 1)), NewKVRead("key2", version.NewHeight(1, 2))})
+	rwSetBuilder.AddToIndexQuerySet("ns1", iqi2)
+
+	iqi3 := &kvrwset.IndexQueryInfo{IndexName: "ownerIndex", StartKey: []byte("s\"tom\"\x00"), EndKey: []byte("s\"tom\"\xff"), ItrExhausted: true}
+	iqi3.SetRawReads([]*kvrwset.KVRead{NewKVRead("key1", version.NewHeight(1, 1)), NewKVRead("key2", version.NewHeight(1, 2))})
+	rwSetBuilder.AddToIndexQuerySet("ns1", iqi3)
+
+	txSimulationResults, err := rwSetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+
+	ns1KVRWSet := &kvrwset.KVRWSet{IndexQueriesInfo: []*kvrwset.IndexQueryInfo{iqi1, iqi3}}
+	expectedTxRWSet := &rwset.TxReadWriteSet{NsRwset: []*rwset.NsReadWriteSet{
+		{Namespace: "ns1", Rwset: serializeTestProtoMsg(t, ns1KVRWSet)},
+	}}
+	assert.Equal(t, expectedTxRWSet, txSimulationResults.PubSimulationResults)
+}
+
+func TestTxSimulationResultWithRichQueries(t *testing.T) {
+	rwSetBuilder := NewRWSetBuilder()
+
+	rqi1 := &kvrwset.RichQueryInfo{Query: `{"selector":{"owner":"tom"}}`}
+	rwSetBuilder.AddToRichQuerySet("ns1", rqi1)
+	rqi2 := &kvrwset.RichQueryInfo{Query: `{"selector":{"owner":"tom"}}`}
+	rwSetBuilder.AddToRichQuerySet("ns1", rqi2)
+	rqi3 := &kvrwset.RichQueryInfo{Query: `{"selector":{"owner":"jerry"}}`}
+	rwSetBuilder.AddToRichQuerySet("ns1", rqi3)
+
+	txSimulationResults, err := rwSetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+
+	ns1KVRWSet := &kvrwset.KVRWSet{RichQueriesInfo: []*kvrwset.RichQueryInfo{rqi1, rqi3}}
+	expectedTxRWSet := &rwset.TxReadWriteSet{NsRwset: []*rwset.NsReadWriteSet{
+		{Namespace: "ns1", Rwset: serializeTestProtoMsg(t, ns1KVRWSet)},
+	}}
+	assert.Equal(t, expectedTxRWSet, txSimulationResults.PubSimulationResults)
+}
+
 func TestTxSimulationResultWithPvtData(t *testing.T) {
 	rwSetBuilder := NewRWSetBuilder()
 	// public rws ns1 + ns2
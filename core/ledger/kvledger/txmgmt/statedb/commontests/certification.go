@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package commontests
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// VersionedDBProviderConstructor constructs a fresh VersionedDBProvider of the state database
+// under test along with a function that closes the provider and removes its data
+type VersionedDBProviderConstructor func(t *testing.T) (dbProvider statedb.VersionedDBProvider, cleanup func())
+
+// TestVersionedDBProvider runs the tests of this package that are applicable to any state database
+// backend against the backend constructed by newDBProvider. A state database that is plugged into the
+// peer is expected to pass these tests. The tests that are specific to a backend (for instance, the
+// tests for the rich queries, which are in the native syntax of the backend) should be run by the
+// backend in addition to these tests.
+func TestVersionedDBProvider(t *testing.T, newDBProvider VersionedDBProviderConstructor) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, dbProvider statedb.VersionedDBProvider)
+	}{
+		{"BasicRW", TestBasicRW},
+		{"MultiDBBasicRW", TestMultiDBBasicRW},
+		{"Drop", TestDrop},
+		{"Deletes", TestDeletes},
+		{"Iterator", TestIterator},
+		{"GetStateMultipleKeys", TestGetStateMultipleKeys},
+		{"GetVersion", TestGetVersion},
+		{"ValueAndMetadataWrites", TestValueAndMetadataWrites},
+		{"PaginatedRangeQuery", TestPaginatedRangeQuery},
+		{"ApplyUpdatesWithNilHeight", TestApplyUpdatesWithNilHeight},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dbProvider, cleanup := newDBProvider(t)
+			defer cleanup()
+			tc.test(t, dbProvider)
+		})
+	}
+}
@@ -139,6 +139,37 @@ func TestMultiDBBasicRW(t *testing.T, dbProvider statedb.VersionedDBProvider) {
 	assert.Equal(t, savePoint2, sp)
 }
 
+// TestDrop tests dropping a db, which is expected to not affect the other dbs
+func TestDrop(t *testing.T, dbProvider statedb.VersionedDBProvider) {
+	for _, dbName := range []string{"testdrop1", "testdrop2"} {
+		db, err := dbProvider.GetDBHandle(dbName)
+		assert.NoError(t, err)
+		batch := statedb.NewUpdateBatch()
+		batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+		batch.Put("ns2", "key1", []byte("value1"), version.NewHeight(1, 2))
+		assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+	}
+
+	assert.NoError(t, dbProvider.Drop("testdrop1"))
+	db1, err := dbProvider.GetDBHandle("testdrop1")
+	assert.NoError(t, err)
+	vv, err := db1.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	sp, err := db1.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Nil(t, sp)
+
+	db2, err := dbProvider.GetDBHandle("testdrop2")
+	assert.NoError(t, err)
+	vv, err = db2.GetState("ns2", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), vv.Value)
+	sp, err = db2.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 2), sp)
+}
+
 // TestDeletes tests deletes
 func TestDeletes(t *testing.T, dbProvider statedb.VersionedDBProvider) {
 	db, err := dbProvider.GetDBHandle("testdeletes")
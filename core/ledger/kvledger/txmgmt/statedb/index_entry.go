@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// indexKeySep separates the encoded values of the fields in the key of an index entry
+var indexKeySep = []byte{0x00}
+
+// IndexEntryKey returns the key of the entry of a secondary index on the fields at the given paths for a state.
+// The key is made of the encoded values of the fields followed by the key of the state, where a path holds the
+// names of the nested fields and the fields missing in the value are encoded as missing. Nil is returned if the
+// value of the state is not a JSON object. The key depends only on the fields and on the state, so that the
+// entries of an index are the same on any peer and in any state database that maintains the index
+func IndexEntryKey(paths [][]string, key string, value []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil || object == nil || decoder.More() {
+		return nil
+	}
+	var entryKey []byte
+	for _, path := range paths {
+		v, present := lookupField(object, path)
+		entryKey = append(entryKey, encodeIndexValue(v, present)...)
+		entryKey = append(entryKey, indexKeySep...)
+	}
+	return append(entryKey, key...)
+}
+
+// KeyFromIndexEntryKey extracts the key of the state from the key of an entry of an index on the given number of fields
+func KeyFromIndexEntryKey(numFields int, entryKey []byte) string {
+	remaining := entryKey
+	for i := 0; i < numFields; i++ {
+		remaining = remaining[bytes.Index(remaining, indexKeySep)+1:]
+	}
+	return string(remaining)
+}
+
+// IndexFieldPaths splits the names of the indexed fields, in which a dot separates the names of the nested fields
+func IndexFieldPaths(fields []string) [][]string {
+	paths := make([][]string, len(fields))
+	for i, field := range fields {
+		paths[i] = strings.Split(field, ".")
+	}
+	return paths
+}
+
+// IndexValuesPrefix returns the prefix of the keys of the index entries whose leading fields have the
+// given values. The values are expected to be of the types that result from decoding JSON
+func IndexValuesPrefix(values []interface{}) []byte {
+	prefix := []byte{}
+	for _, v := range values {
+		prefix = append(prefix, encodeIndexValue(v, true)...)
+		prefix = append(prefix, indexKeySep...)
+	}
+	return prefix
+}
+
+// lookupField returns the value of the nested field at the given path
+func lookupField(object map[string]interface{}, path []string) (interface{}, bool) {
+	var current interface{} = object
+	for _, name := range path {
+		o, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = o[name]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// encodeIndexValue encodes the value of an indexed field such that the encoding never contains
+// a nil byte and two values are equal if and only if their encodings are equal
+func encodeIndexValue(v interface{}, present bool) []byte {
+	if !present {
+		return []byte{'m'}
+	}
+	switch x := v.(type) {
+	case nil:
+		return []byte{'z'}
+	case bool:
+		if x {
+			return []byte("b1")
+		}
+		return []byte("b0")
+	case float64:
+		if x == 0 {
+			// negative zero is equal to zero
+			x = 0
+		}
+		return append([]byte{'n'}, strconv.FormatFloat(x, 'g', -1, 64)...)
+	case string:
+		// json escapes the control characters, including the nil byte
+		encoded, _ := json.Marshal(x)
+		return append([]byte{'s'}, encoded...)
+	default:
+		// json encodes the keys of the objects in the sorted order
+		encoded, _ := json.Marshal(x)
+		return append([]byte{'o'}, encoded...)
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexEntryKey(t *testing.T) {
+	fields := IndexFieldPaths([]string{"color", "size", "owner.name"})
+	entryKey := IndexEntryKey(fields, "marble1", []byte(`{"color":"blue","size":4,"owner":{"name":"tom"}}`))
+	assert.Equal(t, []byte("s\"blue\"\x00n4\x00s\"tom\"\x00marble1"), entryKey)
+	assert.Equal(t, "marble1", KeyFromIndexEntryKey(len(fields), entryKey))
+	assert.Equal(t, []byte("s\"blue\"\x00n4\x00m\x00marble1"),
+		IndexEntryKey(fields, "marble1", []byte(`{"color":"blue","size":4.0,"owner":"tom"}`)))
+	assert.Equal(t, []byte("z\x00b1\x00o{\"a\":1,\"b\":2}\x00marble1"),
+		IndexEntryKey(fields, "marble1", []byte(`{"color":null,"size":true,"owner":{"name":{"b":2,"a":1}}}`)))
+	assert.Nil(t, IndexEntryKey(fields, "marble1", []byte(`not a json`)))
+	assert.Nil(t, IndexEntryKey(fields, "marble1", []byte(`["blue"]`)))
+
+	// the prefix of the entries whose leading fields have the given values
+	assert.Equal(t, []byte("s\"blue\"\x00n4\x00"), IndexValuesPrefix([]interface{}{"blue", float64(4)}))
+	assert.Equal(t, []byte{}, IndexValuesPrefix(nil))
+}
@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"os"
+	"plugin"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
+)
+
+// pluginFactory is the name of the symbol that a state database plugin is expected to export.
+// The symbol is expected to be a function of the type `func(metrics.Provider) (statedb.VersionedDBProvider, error)`
+const pluginFactory = "NewVersionedDBProvider"
+
+// VersionedDBProviderFactory constructs the VersionedDBProvider of a state database backend
+type VersionedDBProviderFactory func(metricsProvider metrics.Provider) (VersionedDBProvider, error)
+
+type providerRegistry struct {
+	lock      sync.RWMutex
+	factories map[string]VersionedDBProviderFactory
+}
+
+var registry = &providerRegistry{factories: map[string]VersionedDBProviderFactory{}}
+
+// RegisterVersionedDBProvider registers the factory of a state database backend under the given name.
+// The backend is selected by setting `ledger.state.stateDatabase` to this name in the peer configuration.
+// The built-in backends register themselves under the names "goleveldb" and "CouchDB".
+// An error is returned if the name is empty or a backend is already registered under the name
+func RegisterVersionedDBProvider(name string, factory VersionedDBProviderFactory) error {
+	if name == "" {
+		return errors.New("name of the state database must not be empty")
+	}
+	if factory == nil {
+		return errors.Errorf("nil factory supplied for the state database [%s]", name)
+	}
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	if _, ok := registry.factories[name]; ok {
+		return errors.Errorf("state database [%s] is already registered", name)
+	}
+	registry.factories[name] = factory
+	return nil
+}
+
+// MustRegisterVersionedDBProvider is the same as RegisterVersionedDBProvider but panics on error.
+// This is meant to be called from the init function of the package that implements a backend
+func MustRegisterVersionedDBProvider(name string, factory VersionedDBProviderFactory) {
+	if err := RegisterVersionedDBProvider(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// LoadVersionedDBProviderPlugin loads the state database backend from the Go plugin at the given path
+// and registers it under the given name. The plugin is expected to export a function named
+// `NewVersionedDBProvider` of the type `func(metrics.Provider) (statedb.VersionedDBProvider, error)`
+func LoadVersionedDBProviderPlugin(name, pluginPath string) error {
+	if _, err := os.Stat(pluginPath); err != nil {
+		return errors.Wrapf(err, "could not find state database plugin at path %s", pluginPath)
+	}
+	p, err := plugin.Open(pluginPath)
+	if err != nil {
+		return errors.Wrapf(err, "error opening state database plugin at path %s", pluginPath)
+	}
+	symbol, err := p.Lookup(pluginFactory)
+	if err != nil {
+		return errors.Wrapf(err, "state database plugin at path %s must export %s", pluginPath, pluginFactory)
+	}
+	constructor, ok := symbol.(func(metrics.Provider) (VersionedDBProvider, error))
+	if !ok {
+		return errors.Errorf("%s exported by the state database plugin at path %s is not of the type func(metrics.Provider) (statedb.VersionedDBProvider, error)",
+			pluginFactory, pluginPath)
+	}
+	return RegisterVersionedDBProvider(name, constructor)
+}
+
+// NewVersionedDBProvider constructs the VersionedDBProvider of the state database backend registered under the given name
+func NewVersionedDBProvider(name string, metricsProvider metrics.Provider) (VersionedDBProvider, error) {
+	registry.lock.RLock()
+	factory, ok := registry.factories[name]
+	registry.lock.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("state database [%s] is not registered, registered state databases are %v",
+			name, RegisteredVersionedDBProviders())
+	}
+	return factory(metricsProvider)
+}
+
+// RegisteredVersionedDBProviders returns the sorted names of the registered state database backends
+func RegisteredVersionedDBProviders() []string {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+	names := []string{}
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/stretchr/testify/assert"
+)
+
+type testVersionedDBProvider struct {
+	VersionedDBProvider
+	metricsProvider metrics.Provider
+}
+
+func TestRegisterVersionedDBProvider(t *testing.T) {
+	factory := func(metricsProvider metrics.Provider) (VersionedDBProvider, error) {
+		return &testVersionedDBProvider{metricsProvider: metricsProvider}, nil
+	}
+
+	err := RegisterVersionedDBProvider("", factory)
+	assert.EqualError(t, err, "name of the state database must not be empty")
+	err = RegisterVersionedDBProvider("testdb", nil)
+	assert.EqualError(t, err, "nil factory supplied for the state database [testdb]")
+
+	assert.NoError(t, RegisterVersionedDBProvider("testdb", factory))
+	defer delete(registry.factories, "testdb")
+	err = RegisterVersionedDBProvider("testdb", factory)
+	assert.EqualError(t, err, "state database [testdb] is already registered")
+	assert.Panics(t, func() { MustRegisterVersionedDBProvider("testdb", factory) })
+	assert.Contains(t, RegisteredVersionedDBProviders(), "testdb")
+
+	metricsProvider := &disabled.Provider{}
+	dbProvider, err := NewVersionedDBProvider("testdb", metricsProvider)
+	assert.NoError(t, err)
+	assert.Equal(t, &testVersionedDBProvider{metricsProvider: metricsProvider}, dbProvider)
+
+	_, err = NewVersionedDBProvider("unknowndb", metricsProvider)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "state database [unknowndb] is not registered, registered state databases are [")
+}
+
+func TestLoadVersionedDBProviderPluginErrors(t *testing.T) {
+	err := LoadVersionedDBProviderPlugin("plugindb", "/non/existent/plugin.so")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not find state database plugin at path /non/existent/plugin.so")
+	assert.NotContains(t, RegisteredVersionedDBProviders(), "plugindb")
+}
@@ -0,0 +1,249 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// nsJoiner separates the chaincode name from the collection in the namespaces that
+// hold the private data and the hashes of the private data of a chaincode
+const nsJoiner = "$$"
+
+// Provider creates the state caches of the ledgers. The caches of all the ledgers
+// share the same size configuration and metrics
+type Provider struct {
+	defaultSize    int
+	chaincodeSizes map[string]int
+	stats          *stats
+}
+
+// NewProvider constructs a Provider. defaultSize is the maximum number of entries that
+// are cached for a chaincode that has no size configured in chaincodeSizes. A size of
+// zero disables the caching for the chaincode. The names of the chaincodes in
+// chaincodeSizes are matched case insensitively
+func NewProvider(defaultSize int, chaincodeSizes map[string]int, metricsProvider metrics.Provider) *Provider {
+	sizes := map[string]int{}
+	for chaincodeName, size := range chaincodeSizes {
+		sizes[strings.ToLower(chaincodeName)] = size
+	}
+	return &Provider{
+		defaultSize:    defaultSize,
+		chaincodeSizes: sizes,
+		stats:          newStats(metricsProvider),
+	}
+}
+
+// Enabled returns true if the caching is enabled for at least one chaincode
+func (p *Provider) Enabled() bool {
+	if p.defaultSize > 0 {
+		return true
+	}
+	for _, size := range p.chaincodeSizes {
+		if size > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// NewCache constructs the state cache of a ledger. If writeThrough is true, the values
+// committed by ApplyUpdates are added to the cache. Otherwise, the cached entries of the
+// committed keys are only invalidated. The write-through should be used only if the
+// state database returns the values exactly as they were committed (e.g., CouchDB
+// normalizes JSON values and hence, they are not written through)
+func (p *Provider) NewCache(ledgerID string, writeThrough bool) *Cache {
+	return &Cache{
+		ledgerID:     ledgerID,
+		provider:     p,
+		writeThrough: writeThrough,
+		lrus:         map[string]*lru{},
+	}
+}
+
+func (p *Provider) sizeFor(chaincodeName string) int {
+	if size, ok := p.chaincodeSizes[strings.ToLower(chaincodeName)]; ok {
+		return size
+	}
+	return p.defaultSize
+}
+
+// Cache is a bounded cache of the committed state of a ledger, keyed by namespace and key.
+// The entries of a chaincode, including the entries of the private data and hashed
+// private data namespaces of the chaincode, are evicted in least recently used order
+// once the number of entries exceeds the size configured for the chaincode.
+// An entry may hold a nil value, that records that the key does not exist in the state database.
+//
+// A reader that misses the cache is expected to take the Generation before reading from the
+// state database and to supply it to Put. This guards the cache against the value read by a
+// reader that races with ApplyUpdates being added to the cache after the new value is committed
+type Cache struct {
+	ledgerID     string
+	provider     *Provider
+	writeThrough bool
+
+	lock       sync.Mutex
+	generation uint64
+	lrus       map[string]*lru
+}
+
+// Generation returns the current generation of the cache. The generation changes
+// every time the entries of the cache are changed by ApplyUpdates
+func (c *Cache) Generation() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.generation
+}
+
+// Get returns the cached value for the given namespace and key. The boolean return value
+// is false if the key is not cached. The hits and misses are recorded only for the
+// namespaces of the chaincodes for which the caching is enabled
+func (c *Cache) Get(namespace, key string) (*statedb.VersionedValue, bool) {
+	chaincodeName := chaincodeNameOf(namespace)
+	c.lock.Lock()
+	lru := c.lruFor(chaincodeName)
+	if lru == nil {
+		c.lock.Unlock()
+		return nil, false
+	}
+	vv, ok := lru.get(namespace, key)
+	c.lock.Unlock()
+
+	if ok {
+		c.provider.stats.cacheHits.With("channel", c.ledgerID, "chaincode", chaincodeName).Add(1)
+	} else {
+		c.provider.stats.cacheMisses.With("channel", c.ledgerID, "chaincode", chaincodeName).Add(1)
+	}
+	return vv, ok
+}
+
+// Put adds the value read from the state database for the given namespace and key to the cache.
+// The value is ignored if the cache has been changed by ApplyUpdates since the given generation
+func (c *Cache) Put(generation uint64, namespace, key string, vv *statedb.VersionedValue) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if lru := c.lruFor(chaincodeNameOf(namespace)); lru != nil {
+		lru.put(namespace, key, vv)
+	}
+}
+
+// ApplyUpdates brings the cache in sync with the given batch that is being committed to
+// the state database. It is expected to be invoked once before the batch is applied to the
+// state database, with committed set to false, and once after the batch is successfully
+// applied, with committed set to true. The first invocation invalidates the cached entries
+// of the keys in the batch. The second invocation adds the committed values to the cache
+// if the cache is write-through and invalidates the entries of the keys again otherwise
+func (c *Cache) ApplyUpdates(batch *statedb.UpdateBatch, committed bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generation++
+	for _, namespace := range batch.GetUpdatedNamespaces() {
+		lru := c.lruFor(chaincodeNameOf(namespace))
+		if lru == nil {
+			continue
+		}
+		for key, vv := range batch.GetUpdates(namespace) {
+			switch {
+			case committed && vv.Value == nil:
+				lru.put(namespace, key, nil)
+			case committed && c.writeThrough:
+				lru.put(namespace, key, &statedb.VersionedValue{Value: vv.Value, Metadata: vv.Metadata, Version: vv.Version})
+			default:
+				lru.remove(namespace, key)
+			}
+		}
+	}
+}
+
+// lruFor returns the lru of the given chaincode, creating it if needed.
+// nil is returned if the caching is disabled for the chaincode
+func (c *Cache) lruFor(chaincodeName string) *lru {
+	if l, ok := c.lrus[chaincodeName]; ok {
+		return l
+	}
+	var l *lru
+	if size := c.provider.sizeFor(chaincodeName); size > 0 {
+		l = newLRU(size)
+	}
+	c.lrus[chaincodeName] = l
+	return l
+}
+
+func chaincodeNameOf(namespace string) string {
+	if i := strings.Index(namespace, nsJoiner); i >= 0 {
+		return namespace[:i]
+	}
+	return namespace
+}
+
+type compositeKey struct {
+	namespace, key string
+}
+
+type lruEntry struct {
+	key compositeKey
+	vv  *statedb.VersionedValue
+}
+
+// lru is a least recently used cache of a fixed size. lru is not safe for concurrent use
+type lru struct {
+	size    int
+	entries *list.List
+	index   map[compositeKey]*list.Element
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:    size,
+		entries: list.New(),
+		index:   map[compositeKey]*list.Element{},
+	}
+}
+
+func (l *lru) get(namespace, key string) (*statedb.VersionedValue, bool) {
+	elem, ok := l.index[compositeKey{namespace, key}]
+	if !ok {
+		return nil, false
+	}
+	l.entries.MoveToFront(elem)
+	return elem.Value.(*lruEntry).vv, true
+}
+
+func (l *lru) put(namespace, key string, vv *statedb.VersionedValue) {
+	ck := compositeKey{namespace, key}
+	if elem, ok := l.index[ck]; ok {
+		elem.Value.(*lruEntry).vv = vv
+		l.entries.MoveToFront(elem)
+		return
+	}
+	l.index[ck] = l.entries.PushFront(&lruEntry{ck, vv})
+	if l.entries.Len() > l.size {
+		oldest := l.entries.Back()
+		l.entries.Remove(oldest)
+		delete(l.index, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (l *lru) remove(namespace, key string) {
+	ck := compositeKey{namespace, key}
+	if elem, ok := l.index[ck]; ok {
+		l.entries.Remove(elem)
+		delete(l.index, ck)
+	}
+}
+
+func (l *lru) len() int {
+	return l.entries.Len()
+}
@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecache
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderEnabled(t *testing.T) {
+	assert.False(t, NewProvider(0, nil, &disabled.Provider{}).Enabled())
+	assert.False(t, NewProvider(0, map[string]int{"cc1": 0}, &disabled.Provider{}).Enabled())
+	assert.True(t, NewProvider(10, nil, &disabled.Provider{}).Enabled())
+	assert.True(t, NewProvider(0, map[string]int{"cc1": 10}, &disabled.Provider{}).Enabled())
+}
+
+func TestChaincodeSizes(t *testing.T) {
+	p := NewProvider(2, map[string]int{"LargeCC": 4, "uncachedcc": 0}, &disabled.Provider{})
+	assert.Equal(t, 2, p.sizeFor("cc1"))
+	assert.Equal(t, 4, p.sizeFor("largecc"))
+	assert.Equal(t, 4, p.sizeFor("LargeCC"))
+	assert.Equal(t, 0, p.sizeFor("uncachedcc"))
+
+	cache := p.NewCache("ledger1", true)
+	for _, ns := range []string{"cc1", "largecc", "largecc$$pcoll1", "uncachedcc"} {
+		for _, key := range []string{"key1", "key2", "key3", "key4", "key5"} {
+			cache.Put(cache.Generation(), ns, key, nil)
+		}
+	}
+	assert.Equal(t, 2, cache.lrus["cc1"].len())
+	// the private data namespaces share the lru of the chaincode
+	assert.Equal(t, 4, cache.lrus["largecc"].len())
+	assert.Nil(t, cache.lrus["uncachedcc"])
+	_, ok := cache.Get("uncachedcc", "key5")
+	assert.False(t, ok)
+}
+
+func TestLRUEviction(t *testing.T) {
+	cache := NewProvider(2, nil, &disabled.Provider{}).NewCache("ledger1", true)
+	vv1 := &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}
+	vv2 := &statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)}
+	vv3 := &statedb.VersionedValue{Value: []byte("value3"), Version: version.NewHeight(1, 3)}
+
+	cache.Put(cache.Generation(), "ns", "key1", vv1)
+	cache.Put(cache.Generation(), "ns", "key2", vv2)
+	// lookup makes key1 the most recently used
+	vv, ok := cache.Get("ns", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, vv1, vv)
+
+	cache.Put(cache.Generation(), "ns", "key3", vv3)
+	_, ok = cache.Get("ns", "key2")
+	assert.False(t, ok)
+	vv, ok = cache.Get("ns", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, vv1, vv)
+	vv, ok = cache.Get("ns", "key3")
+	assert.True(t, ok)
+	assert.Equal(t, vv3, vv)
+}
+
+func TestPutIgnoredAfterApplyUpdates(t *testing.T) {
+	cache := NewProvider(10, nil, &disabled.Provider{}).NewCache("ledger1", true)
+	generation := cache.Generation()
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", []byte("value1-new"), version.NewHeight(2, 1))
+	cache.ApplyUpdates(batch, false)
+	cache.ApplyUpdates(batch, true)
+
+	// a value read before the commit must not replace the committed value
+	cache.Put(generation, "ns", "key1", &statedb.VersionedValue{Value: []byte("value1-old"), Version: version.NewHeight(1, 1)})
+	cache.Put(generation, "ns", "key2", &statedb.VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 1)})
+	vv, ok := cache.Get("ns", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value1-new"), vv.Value)
+	_, ok = cache.Get("ns", "key2")
+	assert.False(t, ok)
+}
+
+func TestApplyUpdates(t *testing.T) {
+	for _, writeThrough := range []bool{true, false} {
+		cache := NewProvider(10, nil, &disabled.Provider{}).NewCache("ledger1", writeThrough)
+		for _, key := range []string{"key1", "key2", "key3"} {
+			cache.Put(cache.Generation(), "ns", key, &statedb.VersionedValue{Value: []byte("value"), Version: version.NewHeight(1, 1)})
+		}
+
+		batch := statedb.NewUpdateBatch()
+		batch.Put("ns", "key1", []byte("value1-new"), version.NewHeight(2, 1))
+		batch.Delete("ns", "key2", version.NewHeight(2, 1))
+		batch.Put("ns", "key4", []byte("value4"), version.NewHeight(2, 1))
+
+		cache.ApplyUpdates(batch, false)
+		for _, key := range []string{"key1", "key2", "key4"} {
+			_, ok := cache.Get("ns", key)
+			assert.False(t, ok)
+		}
+
+		cache.ApplyUpdates(batch, true)
+		vv, ok := cache.Get("ns", "key1")
+		assert.Equal(t, writeThrough, ok)
+		if writeThrough {
+			assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1-new"), Version: version.NewHeight(2, 1)}, vv)
+		}
+		vv, ok = cache.Get("ns", "key2")
+		assert.True(t, ok)
+		assert.Nil(t, vv)
+		_, ok = cache.Get("ns", "key3")
+		assert.True(t, ok)
+		_, ok = cache.Get("ns", "key4")
+		assert.Equal(t, writeThrough, ok)
+	}
+}
+
+func TestCacheMetrics(t *testing.T) {
+	fakeHits := &metricsfakes.Counter{}
+	fakeHits.WithReturns(fakeHits)
+	fakeMisses := &metricsfakes.Counter{}
+	fakeMisses.WithReturns(fakeMisses)
+	fakeProvider := &metricsfakes.Provider{}
+	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
+		switch opts.Name {
+		case cacheHitsOpts.Name:
+			return fakeHits
+		case cacheMissesOpts.Name:
+			return fakeMisses
+		}
+		return nil
+	}
+
+	cache := NewProvider(10, map[string]int{"uncachedcc": 0}, fakeProvider).NewCache("ledger1", true)
+	cache.Get("cc1$$pcoll1", "key1")
+	cache.Put(cache.Generation(), "cc1$$pcoll1", "key1", nil)
+	cache.Get("cc1$$pcoll1", "key1")
+	cache.Get("uncachedcc", "key1")
+
+	assert.Equal(t, 1, fakeMisses.AddCallCount())
+	assert.Equal(t, []string{"channel", "ledger1", "chaincode", "cc1"}, fakeMisses.WithArgsForCall(0))
+	assert.Equal(t, float64(1), fakeMisses.AddArgsForCall(0))
+	assert.Equal(t, 1, fakeHits.AddCallCount())
+	assert.Equal(t, []string{"channel", "ledger1", "chaincode", "cc1"}, fakeHits.WithArgsForCall(0))
+}
@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecache
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	cacheHitsOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "statedb_cache_hits",
+		Help:         "Number of lookups of a key that were served by the state cache.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	cacheMissesOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "statedb_cache_misses",
+		Help:         "Number of lookups of a key that were not served by the state cache.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+)
+
+type stats struct {
+	cacheHits   metrics.Counter
+	cacheMisses metrics.Counter
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	return &stats{
+		cacheHits:   metricsProvider.NewCounter(cacheHitsOpts),
+		cacheMisses: metricsProvider.NewCounter(cacheMissesOpts),
+	}
+}
@@ -6,10 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 package statecouchdb
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	"github.com/pkg/errors"
 )
 
 // nsMetadataRetriever implements `batch` interface and wraps the function `retrieveNsMetadata`
@@ -21,11 +23,19 @@ type nsMetadataRetriever struct {
 	executionResult []*couchdb.DocMetadata
 }
 
-// subNsMetadataRetriever implements `batch` interface and wraps the function `couchdb.BatchRetrieveDocumentMetadata`
+// subNsMetadataRetriever implements `batch` interface and wraps the function `couchdb.BatchRetrieveDocuments`
 // for allowing parallel execution of this function for different sets of keys within a namespace.
 // Different sets of keys is exeptected to be created based on configuration `ledgerconfig.GetMaxBatchUpdateSize()`
 type subNsMetadataRetriever nsMetadataRetriever
 
+// subNsDocsRetriever implements `batch` interface and wraps the function `couchdb.BatchRetrieveDocuments`
+// for allowing parallel bulk reads of the documents for different sets of keys within a namespace
+type subNsDocsRetriever struct {
+	db              *couchdb.CouchDatabase
+	keys            []string
+	executionResult []*couchdb.CouchDoc
+}
+
 // retrievedMetadata retrievs the metadata for a collection of `namespace-keys` combination
 func (vdb *VersionedDB) retrieveMetadata(nsKeysMap map[string][]string) (map[string][]*couchdb.DocMetadata, error) {
 	// consturct one batch per namespace
@@ -52,17 +62,9 @@ func (vdb *VersionedDB) retrieveMetadata(nsKeysMap map[string][]string) (map[str
 // retrieveNsMetadata retrieves metadata for a given namespace
 func retrieveNsMetadata(db *couchdb.CouchDatabase, keys []string) ([]*couchdb.DocMetadata, error) {
 	// consturct one batch per group of keys based on maxBacthSize
-	maxBacthSize := ledgerconfig.GetMaxBatchUpdateSize()
 	batches := []batch{}
-	remainingKeys := keys
-	for {
-		numKeys := minimum(maxBacthSize, len(remainingKeys))
-		if numKeys == 0 {
-			break
-		}
-		batch := &subNsMetadataRetriever{db: db, keys: remainingKeys[:numKeys]}
-		batches = append(batches, batch)
-		remainingKeys = remainingKeys[numKeys:]
+	for _, batchKeys := range splitKeys(keys) {
+		batches = append(batches, &subNsMetadataRetriever{db: db, keys: batchKeys})
 	}
 	if err := executeBatches(batches); err != nil {
 		return nil, err
@@ -75,6 +77,40 @@ func retrieveNsMetadata(db *couchdb.CouchDatabase, keys []string) ([]*couchdb.Do
 	return executionResults, nil
 }
 
+// retrieveNsDocs retrieves the documents for the given keys in a namespace. The documents that do not exist are not returned
+func retrieveNsDocs(db *couchdb.CouchDatabase, keys []string) ([]*couchdb.CouchDoc, error) {
+	// consturct one batch per group of keys based on maxBacthSize
+	batches := []batch{}
+	for _, batchKeys := range splitKeys(keys) {
+		batches = append(batches, &subNsDocsRetriever{db: db, keys: batchKeys})
+	}
+	if err := executeBatches(batches); err != nil {
+		return nil, err
+	}
+	// accumulate results from each batch
+	var executionResults []*couchdb.CouchDoc
+	for _, b := range batches {
+		executionResults = append(executionResults, b.(*subNsDocsRetriever).executionResult...)
+	}
+	return executionResults, nil
+}
+
+// splitKeys splits the keys in groups of at most `ledgerconfig.GetMaxBatchUpdateSize()` keys
+func splitKeys(keys []string) [][]string {
+	maxBacthSize := ledgerconfig.GetMaxBatchUpdateSize()
+	var groups [][]string
+	remainingKeys := keys
+	for {
+		numKeys := minimum(maxBacthSize, len(remainingKeys))
+		if numKeys == 0 {
+			break
+		}
+		groups = append(groups, remainingKeys[:numKeys])
+		remainingKeys = remainingKeys[numKeys:]
+	}
+	return groups
+}
+
 func (r *nsMetadataRetriever) execute() error {
 	var err error
 	if r.executionResult, err = retrieveNsMetadata(r.db, r.keys); err != nil {
@@ -88,10 +124,19 @@ func (r *nsMetadataRetriever) String() string {
 }
 
 func (b *subNsMetadataRetriever) execute() error {
-	var err error
-	if b.executionResult, err = b.db.BatchRetrieveDocumentMetadata(b.keys); err != nil {
+	// the attachments are not needed for the metadata and hence, their data is not retrieved
+	couchDocs, err := b.db.BatchRetrieveDocuments(b.keys, false)
+	if err != nil {
 		return err
 	}
+	b.executionResult = make([]*couchdb.DocMetadata, len(couchDocs))
+	for i, couchDoc := range couchDocs {
+		docMetadata := &couchdb.DocMetadata{}
+		if err := json.Unmarshal(couchDoc.JSONValue, docMetadata); err != nil {
+			return errors.Wrap(err, "error unmarshalling json data")
+		}
+		b.executionResult[i] = docMetadata
+	}
 	return nil
 }
 
@@ -99,6 +144,18 @@ func (b *subNsMetadataRetriever) String() string {
 	return fmt.Sprintf("subNsMetadataRetriever:ns=%s, num keys=%d", b.ns, len(b.keys))
 }
 
+func (b *subNsDocsRetriever) execute() error {
+	var err error
+	if b.executionResult, err = b.db.BatchRetrieveDocuments(b.keys, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *subNsDocsRetriever) String() string {
+	return fmt.Sprintf("subNsDocsRetriever:db=%s, num keys=%d", b.db.DBName, len(b.keys))
+}
+
 func minimum(a, b int) int {
 	if a < b {
 		return a
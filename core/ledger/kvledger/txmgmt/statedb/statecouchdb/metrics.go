@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// The phases of the processing of a block by the state database, in their order
+const (
+	// phaseLoadCommittedVersions is the bulk read of the versions of the keys in the read-sets, for the validation
+	phaseLoadCommittedVersions = "load_committed_versions"
+	// phasePrepareUpdates is the bulk read of the missing revisions and the preparation of the documents to write
+	phasePrepareUpdates = "prepare_updates"
+	// phaseApplyUpdates is the bulk update of the documents
+	phaseApplyUpdates = "apply_updates"
+	// phaseRecordSavepoint is the flush of the updated databases and the write of the savepoint
+	phaseRecordSavepoint = "record_savepoint"
+)
+
+var (
+	commitPhaseTimeOpts = metrics.HistogramOpts{
+		Namespace:    "couchdb",
+		Subsystem:    "",
+		Name:         "commit_phase_time",
+		Help:         "Time taken in seconds by a phase of the commit of a block to the CouchDB state database.",
+		LabelNames:   []string{"channel", "phase"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{phase}",
+		Buckets:      []float64{0.005, 0.01, 0.015, 0.05, 0.1, 1, 10},
+	}
+)
+
+type stats struct {
+	commitPhaseTime metrics.Histogram
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	return &stats{
+		commitPhaseTime: metricsProvider.NewHistogram(commitPhaseTimeOpts),
+	}
+}
+
+func (s *stats) observeCommitPhaseTime(startTime time.Time, chainName, phase string) {
+	s.commitPhaseTime.With(
+		"channel", chainName,
+		"phase", phase,
+	).Observe(time.Since(startTime).Seconds())
+}
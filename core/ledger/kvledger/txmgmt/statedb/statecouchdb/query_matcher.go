@@ -0,0 +1,439 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MatchesQuery returns whether the state with the given key and value satisfies the selector of the
+// given CouchDB query, disregarding the limit, the sort order, and the bookmark of the query. The selector
+// is evaluated in memory, as CouchDB evaluates a selector against a document, and hence the result does
+// not depend on the CouchDB instance nor on its indexes. A value that is not a JSON object is evaluated as
+// a document without any field other than the _id. The strings are compared by their code points, whereas
+// CouchDB collates them as per the Unicode Collation Algorithm for the comparison operators. An error is
+// returned for a malformed query or an operator that is not supported
+func MatchesQuery(query, key string, value []byte) (bool, error) {
+	var queryObj map[string]interface{}
+	if err := unmarshalJSONWithNumbers([]byte(query), &queryObj); err != nil {
+		return false, errors.Wrap(err, "error unmarshalling the query")
+	}
+	selector, ok := queryObj["selector"].(map[string]interface{})
+	if !ok {
+		return false, errors.New("the query does not contain a selector object")
+	}
+	doc := map[string]interface{}{}
+	if isJSON, _ := tryCastingToJSON(value); isJSON {
+		if err := unmarshalJSONWithNumbers(value, &doc); err != nil {
+			return false, err
+		}
+	}
+	doc[idField] = key
+	return matchSelector(selector, doc)
+}
+
+func unmarshalJSONWithNumbers(b []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// matchSelector evaluates a selector, i.e., an object whose members are the combination operators
+// or the fields of the document along with their conditions, all of which must be satisfied
+func matchSelector(selector map[string]interface{}, doc interface{}) (bool, error) {
+	for _, name := range sortedNames(selector) {
+		var matches bool
+		var err error
+		if strings.HasPrefix(name, "$") {
+			matches, err = matchCombination(name, selector[name], doc, matchSelector)
+		} else {
+			matches, err = matchField(name, selector[name], doc)
+		}
+		if err != nil || !matches {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchField evaluates the condition on a field of the document, which is identified by a path of
+// field names separated by dots. A field that is not present satisfies only {"$exists": false}
+func matchField(path string, condition interface{}, doc interface{}) (bool, error) {
+	value, found := getField(doc, splitFieldPath(path))
+	if !found {
+		cond, ok := condition.(map[string]interface{})
+		if !ok || len(cond) != 1 {
+			return false, nil
+		}
+		exists, ok := cond["$exists"].(bool)
+		return ok && !exists, nil
+	}
+	return matchCondition(condition, value)
+}
+
+// matchCondition evaluates a condition against the value of a field. A condition that is not an object
+// of operators is an implicit $eq, except for an object of fields, which is a selector on the sub-fields
+func matchCondition(condition interface{}, value interface{}) (bool, error) {
+	cond, ok := condition.(map[string]interface{})
+	if !ok || len(cond) == 0 {
+		return matchOperator("$eq", condition, value)
+	}
+	numOperators := 0
+	for name := range cond {
+		if strings.HasPrefix(name, "$") {
+			numOperators++
+		}
+	}
+	if numOperators == 0 {
+		return matchSelector(cond, value)
+	}
+	if numOperators != len(cond) {
+		return false, errors.New("a condition must not mix the operators with the fields")
+	}
+	for _, name := range sortedNames(cond) {
+		matches, err := matchOperator(name, cond[name], value)
+		if err != nil || !matches {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// matchCombination evaluates a combination operator whose arguments are evaluated by the given function,
+// i.e., as selectors on the document or as conditions on the value of a field
+func matchCombination(operator string, arg interface{}, value interface{},
+	match func(map[string]interface{}, interface{}) (bool, error)) (bool, error) {
+	if operator == "$not" {
+		subSelector, ok := arg.(map[string]interface{})
+		if !ok {
+			return false, errors.New("the argument of $not must be an object")
+		}
+		matches, err := match(subSelector, value)
+		return !matches, err
+	}
+	args, ok := arg.([]interface{})
+	if !ok {
+		return false, errors.Errorf("the argument of %s must be an array", operator)
+	}
+	numMatches := 0
+	for _, a := range args {
+		subSelector, ok := a.(map[string]interface{})
+		if !ok {
+			return false, errors.Errorf("the arguments of %s must be objects", operator)
+		}
+		matches, err := match(subSelector, value)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			numMatches++
+		}
+	}
+	switch operator {
+	case "$and":
+		return numMatches == len(args), nil
+	case "$or":
+		return numMatches > 0, nil
+	case "$nor":
+		return numMatches == 0, nil
+	}
+	return false, errors.Errorf("operator %s is not supported", operator)
+}
+
+// matchOperator evaluates a condition operator against the value of a field
+func matchOperator(operator string, arg interface{}, value interface{}) (bool, error) {
+	switch operator {
+	case "$and", "$or", "$nor", "$not":
+		return matchCombination(operator, arg, value, func(cond map[string]interface{}, v interface{}) (bool, error) {
+			return matchCondition(cond, v)
+		})
+	case "$eq", "$ne", "$lt", "$lte", "$gt", "$gte":
+		c, err := compareJSON(value, arg)
+		if err != nil {
+			return false, err
+		}
+		switch operator {
+		case "$eq":
+			return c == 0, nil
+		case "$ne":
+			return c != 0, nil
+		case "$lt":
+			return c < 0, nil
+		case "$lte":
+			return c <= 0, nil
+		case "$gt":
+			return c > 0, nil
+		}
+		return c >= 0, nil
+	case "$exists":
+		exists, ok := arg.(bool)
+		if !ok {
+			return false, errors.New("the argument of $exists must be a boolean")
+		}
+		return exists, nil
+	case "$type":
+		typeName, ok := arg.(string)
+		if !ok {
+			return false, errors.New("the argument of $type must be a string")
+		}
+		return jsonTypeName(value) == typeName, nil
+	case "$in", "$nin":
+		args, ok := arg.([]interface{})
+		if !ok {
+			return false, errors.Errorf("the argument of %s must be an array", operator)
+		}
+		found, err := containsAny(value, args)
+		if err != nil {
+			return false, err
+		}
+		return found == (operator == "$in"), nil
+	case "$all":
+		args, ok := arg.([]interface{})
+		if !ok {
+			return false, errors.New("the argument of $all must be an array")
+		}
+		values, ok := value.([]interface{})
+		if !ok {
+			return false, nil
+		}
+		for _, a := range args {
+			found, err := containsAny(values, []interface{}{a})
+			if err != nil || !found {
+				return false, err
+			}
+		}
+		return true, nil
+	case "$size":
+		size, ok := toInt64(arg)
+		if !ok {
+			return false, errors.New("the argument of $size must be an integer")
+		}
+		values, ok := value.([]interface{})
+		return ok && int64(len(values)) == size, nil
+	case "$mod":
+		args, ok := arg.([]interface{})
+		if !ok || len(args) != 2 {
+			return false, errors.New("the argument of $mod must be an array of a divisor and a remainder")
+		}
+		divisor, ok1 := toInt64(args[0])
+		remainder, ok2 := toInt64(args[1])
+		if !ok1 || !ok2 || divisor == 0 {
+			return false, errors.New("the divisor and the remainder of $mod must be integers and the divisor must not be zero")
+		}
+		n, ok := toInt64(value)
+		return ok && n%divisor == remainder, nil
+	case "$regex":
+		pattern, ok := arg.(string)
+		if !ok {
+			return false, errors.New("the argument of $regex must be a string")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, errors.Wrap(err, "error compiling the regular expression of $regex")
+		}
+		s, ok := value.(string)
+		return ok && re.MatchString(s), nil
+	case "$elemMatch", "$allMatch":
+		cond, ok := arg.(map[string]interface{})
+		if !ok {
+			return false, errors.Errorf("the argument of %s must be an object", operator)
+		}
+		values, ok := value.([]interface{})
+		if !ok || len(values) == 0 {
+			return false, nil
+		}
+		for _, v := range values {
+			matches, err := matchCondition(cond, v)
+			if err != nil {
+				return false, err
+			}
+			if matches == (operator == "$elemMatch") {
+				return matches, nil
+			}
+		}
+		return operator == "$allMatch", nil
+	}
+	return false, errors.Errorf("operator %s is not supported", operator)
+}
+
+// containsAny returns whether the value, or any of its elements if the value is an array, is equal to any of the args
+func containsAny(value interface{}, args []interface{}) (bool, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	for _, v := range values {
+		for _, a := range args {
+			c, err := compareJSON(v, a)
+			if err != nil {
+				return false, err
+			}
+			if c == 0 {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// getField returns the value at the given path of field names. A name that is an index
+// selects the corresponding element of an array
+func getField(doc interface{}, path []string) (interface{}, bool) {
+	value := doc
+	for _, name := range path {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[name]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(name)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// splitFieldPath splits a path on the dots that are not escaped by a backslash
+func splitFieldPath(path string) []string {
+	var names []string
+	var name strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			name.WriteByte('.')
+			i++
+		case path[i] == '.':
+			names = append(names, name.String())
+			name.Reset()
+		default:
+			name.WriteByte(path[i])
+		}
+	}
+	return append(names, name.String())
+}
+
+// compareJSON compares two JSON values in the order in which CouchDB collates the JSON types,
+// i.e., null, false, true, numbers, strings, arrays, and objects. Two objects are only compared for equality
+func compareJSON(a, b interface{}) (int, error) {
+	ra, rb := jsonTypeRank(a), jsonTypeRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1, nil
+		}
+		return 1, nil
+	}
+	switch av := a.(type) {
+	case json.Number:
+		x, ok1 := new(big.Float).SetString(av.String())
+		y, ok2 := new(big.Float).SetString(b.(json.Number).String())
+		if !ok1 || !ok2 {
+			return 0, errors.Errorf("invalid number in [%s, %s]", av, b)
+		}
+		return x.Cmp(y), nil
+	case string:
+		return strings.Compare(av, b.(string)), nil
+	case []interface{}:
+		bv := b.([]interface{})
+		for i := 0; i < len(av) && i < len(bv); i++ {
+			c, err := compareJSON(av[i], bv[i])
+			if err != nil || c != 0 {
+				return c, err
+			}
+		}
+		return len(av) - len(bv), nil
+	case map[string]interface{}:
+		bv := b.(map[string]interface{})
+		if len(av) == len(bv) {
+			equal := true
+			for name, v := range av {
+				w, ok := bv[name]
+				if !ok {
+					equal = false
+					break
+				}
+				if c, err := compareJSON(v, w); err != nil || c != 0 {
+					equal = false
+					break
+				}
+			}
+			if equal {
+				return 0, nil
+			}
+		}
+		return 0, errors.New("the objects can be compared only for equality")
+	}
+	return 0, nil
+}
+
+// jsonTypeRank returns the rank of the type of a JSON value in the collation order of CouchDB.
+// The values false and true have distinct ranks
+func jsonTypeRank(v interface{}) int {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 2
+		}
+		return 1
+	case json.Number:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	}
+	return 6
+}
+
+func jsonTypeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	return i, err == nil
+}
+
+func sortedNames(m map[string]interface{}) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesQuery(t *testing.T) {
+	value := []byte(`{"owner":"tom","color":"blue","size":10,"tags":["a","b"],"address":{"city":"paris","zip.code":"75001"},"sold":false,"parts":[{"id":1},{"id":2}]}`)
+	tests := []struct {
+		selector string
+		matches  bool
+	}{
+		{`{}`, true},
+		{`{"owner":"tom"}`, true},
+		{`{"owner":"jerry"}`, false},
+		{`{"owner":"tom","color":"red"}`, false},
+		{`{"_id":"marble1"}`, true},
+		{`{"size":{"$gt":9,"$lte":10}}`, true},
+		{`{"size":{"$lt":10}}`, false},
+		{`{"size":10.0}`, true},
+		{`{"size":{"$gt":"a"}}`, false},
+		{`{"size":{"$lt":"a"}}`, true},
+		{`{"owner":{"$ne":"jerry"}}`, true},
+		{`{"owner":{"$gte":"tol"}}`, true},
+		{`{"sold":{"$lt":true}}`, true},
+		{`{"address.city":"paris"}`, true},
+		{`{"address":{"city":"paris"}}`, true},
+		{`{"address.zip\\.code":"75001"}`, true},
+		{`{"parts.1.id":2}`, true},
+		{`{"missing":{"$exists":false}}`, true},
+		{`{"missing":{"$exists":true}}`, false},
+		{`{"missing":{"$ne":"tom"}}`, false},
+		{`{"owner":{"$exists":false}}`, false},
+		{`{"size":{"$type":"number"}}`, true},
+		{`{"owner":{"$in":["jerry","tom"]}}`, true},
+		{`{"owner":{"$nin":["jerry","tom"]}}`, false},
+		{`{"tags":{"$in":["b","c"]}}`, true},
+		{`{"tags":{"$all":["a","b"]}}`, true},
+		{`{"tags":{"$all":["a","c"]}}`, false},
+		{`{"tags":{"$size":2}}`, true},
+		{`{"tags":["a","b"]}`, true},
+		{`{"size":{"$mod":[3,1]}}`, true},
+		{`{"owner":{"$regex":"^t"}}`, true},
+		{`{"parts":{"$elemMatch":{"id":2}}}`, true},
+		{`{"parts":{"$allMatch":{"id":{"$gt":1}}}}`, false},
+		{`{"$or":[{"owner":"jerry"},{"color":"blue"}]}`, true},
+		{`{"$and":[{"owner":"tom"},{"color":"red"}]}`, false},
+		{`{"$nor":[{"owner":"jerry"},{"color":"red"}]}`, true},
+		{`{"$not":{"owner":"tom"}}`, false},
+		{`{"size":{"$or":[{"$lt":5},{"$gt":8}]}}`, true},
+		{`{"size":{"$not":{"$gt":8}}}`, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.selector, func(t *testing.T) {
+			matches, err := MatchesQuery(`{"selector":`+tc.selector+`,"limit":1,"sort":["owner"]}`, "marble1", value)
+			require.NoError(t, err)
+			assert.Equal(t, tc.matches, matches)
+		})
+	}
+
+	// a value that is not a JSON object has no field other than the _id
+	matches, err := MatchesQuery(`{"selector":{"_id":{"$gt":""}}}`, "text", []byte("not json"))
+	require.NoError(t, err)
+	assert.True(t, matches)
+	matches, err = MatchesQuery(`{"selector":{"owner":"tom"}}`, "text", []byte("not json"))
+	require.NoError(t, err)
+	assert.False(t, matches)
+
+	for _, query := range []string{
+		`not json`,
+		`{"fields":["owner"]}`,
+		`{"selector":{"owner":{"$unknown":"tom"}}}`,
+		`{"selector":{"owner":{"$eq":"tom","city":"paris"}}}`,
+		`{"selector":{"address":{"$gt":{"city":"lyon"}}}}`,
+		`{"selector":{"owner":{"$regex":"("}}}`,
+		`{"selector":{"size":{"$mod":[0,1]}}}`,
+		`{"selector":{"$or":{"owner":"tom"}}}`,
+	} {
+		_, err := MatchesQuery(query, "marble1", value)
+		assert.Error(t, err, query)
+	}
+}
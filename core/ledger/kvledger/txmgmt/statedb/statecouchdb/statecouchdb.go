@@ -10,7 +10,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics"
@@ -27,24 +29,40 @@ var logger = flogging.MustGetLogger("statecouchdb")
 // LsccCacheSize denotes the number of entries allowed in the lsccStateCache
 const lsccCacheSize = 50
 
+// StateDatabaseName is the name under which this state database is registered
+// and that selects it in `ledger.state.stateDatabase`
+const StateDatabaseName = "CouchDB"
+
+func init() {
+	statedb.MustRegisterVersionedDBProvider(StateDatabaseName,
+		func(metricsProvider metrics.Provider) (statedb.VersionedDBProvider, error) {
+			provider, err := NewVersionedDBProvider(metricsProvider)
+			if err != nil {
+				return nil, err
+			}
+			return provider, nil
+		},
+	)
+}
+
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	couchInstance *couchdb.CouchInstance
 	databases     map[string]*VersionedDB
 	mux           sync.Mutex
 	openCounts    uint64
+	stats         *stats
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
 func NewVersionedDBProvider(metricsProvider metrics.Provider) (*VersionedDBProvider, error) {
 	logger.Debugf("constructing CouchDB VersionedDBProvider")
 	couchDBDef := couchdb.GetCouchDBDefinition()
-	couchInstance, err := couchdb.CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
-		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout, couchDBDef.CreateGlobalChangesDB, metricsProvider)
+	couchInstance, err := couchdb.NewCouchInstance(couchDBDef, metricsProvider)
 	if err != nil {
 		return nil, err
 	}
-	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0}, nil
+	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0, newStats(metricsProvider)}, nil
 }
 
 // GetDBHandle gets the handle to a named database
@@ -54,7 +72,7 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	vdb := provider.databases[dbName]
 	if vdb == nil {
 		var err error
-		vdb, err = newVersionedDB(provider.couchInstance, dbName)
+		vdb, err = newVersionedDB(provider.couchInstance, dbName, provider.stats)
 		if err != nil {
 			return nil, err
 		}
@@ -63,6 +81,25 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	return vdb, nil
 }
 
+// Drop drops the metadata database and the namespace databases of the named database (i.e., the chain/channel)
+func (provider *VersionedDBProvider) Drop(dbName string) error {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+	couchDBNames, err := provider.couchInstance.RetrieveApplicationDBNames()
+	if err != nil {
+		return err
+	}
+	for _, couchDBName := range couchdb.ChainDBNames(dbName, couchDBNames) {
+		db := &couchdb.CouchDatabase{CouchInstance: provider.couchInstance, DBName: couchDBName}
+		if _, err := db.DropDatabase(); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error dropping database [%s]", couchDBName))
+		}
+		logger.Infof("Dropped database [%s] of the chain [%s]", couchDBName, dbName)
+	}
+	delete(provider.databases, dbName)
+	return nil
+}
+
 // Close closes the underlying db instance
 func (provider *VersionedDBProvider) Close() {
 	// No close needed on Couch
@@ -83,6 +120,7 @@ type VersionedDB struct {
 	verCacheLock       sync.RWMutex
 	mux                sync.RWMutex
 	lsccStateCache     *lsccStateCache
+	stats              *stats
 }
 
 type lsccStateCache struct {
@@ -135,7 +173,7 @@ func (l *lsccStateCache) evictARandomEntry() {
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*VersionedDB, error) {
+func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string, stats *stats) (*VersionedDB, error) {
 	// CreateCouchDatabase creates a CouchDB database object, as well as the underlying database if it does not exist
 	chainName := dbName
 	dbName = couchdb.ConstructMetadataDBName(dbName)
@@ -154,6 +192,7 @@ func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*Versi
 		lsccStateCache: &lsccStateCache{
 			cache: make(map[string]*statedb.VersionedValue),
 		},
+		stats: stats,
 	}, nil
 }
 
@@ -198,6 +237,79 @@ func (vdb *VersionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileE
 	return nil
 }
 
+// CreateIndex implements method in statedb.IndexManageable interface. The index is warmed after its
+// creation so that CouchDB starts building the index in the background, instead of on the first query
+func (vdb *VersionedDB) CreateIndex(namespace string, indexDefinition []byte) (*statedb.IndexInfo, error) {
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := db.CreateIndex(string(indexDefinition))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error creating index for namespace [%s]", namespace))
+	}
+	designDoc := strings.TrimPrefix(resp.ID, "_design/")
+	if err := db.WarmIndex(designDoc, resp.Name); err != nil {
+		logger.Warningf("Error while warming index [%s] in design document [%s] of namespace [%s]: %s", resp.Name, designDoc, namespace, err)
+	}
+	indexes, err := listIndexes(db)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		if index.DesignDoc == designDoc && index.Name == resp.Name {
+			return index, nil
+		}
+	}
+	return &statedb.IndexInfo{DesignDoc: designDoc, Name: resp.Name}, nil
+}
+
+// ListIndexes implements method in statedb.IndexManageable interface
+func (vdb *VersionedDB) ListIndexes(namespace string) ([]*statedb.IndexInfo, error) {
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return listIndexes(db)
+}
+
+// DropIndex implements method in statedb.IndexManageable interface
+func (vdb *VersionedDB) DropIndex(namespace, designDoc, indexName string) error {
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return err
+	}
+	if err := db.DeleteIndex(designDoc, indexName); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error dropping index [%s] in design document [%s] of namespace [%s]",
+			indexName, designDoc, namespace))
+	}
+	return nil
+}
+
+// listIndexes returns the indexes of the database along with the progress of the builds that are ongoing
+func listIndexes(db *couchdb.CouchDatabase) ([]*statedb.IndexInfo, error) {
+	indexes, err := db.ListIndex()
+	if err != nil {
+		return nil, err
+	}
+	buildProgress, err := db.IndexBuildProgress()
+	if err != nil {
+		return nil, err
+	}
+	var indexInfos []*statedb.IndexInfo
+	for _, index := range indexes {
+		progress, building := buildProgress[index.DesignDocument]
+		indexInfos = append(indexInfos, &statedb.IndexInfo{
+			DesignDoc:     index.DesignDocument,
+			Name:          index.Name,
+			Definition:    index.Definition,
+			Building:      building,
+			BuildProgress: progress,
+		})
+	}
+	return indexInfos, nil
+}
+
 // GetDBType returns the hosted stateDB
 func (vdb *VersionedDB) GetDBType() string {
 	return "couchdb"
@@ -208,6 +320,7 @@ func (vdb *VersionedDB) GetDBType() string {
 // committedVersions cache will be used for state validation of readsets
 // revisionNumbers cache will be used during commit phase for couchdb bulk updates
 func (vdb *VersionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) error {
+	defer vdb.stats.observeCommitPhaseTime(time.Now(), vdb.chainName, phaseLoadCommittedVersions)
 	nsKeysMap := map[string][]string{}
 	committedDataCache := newVersionCache()
 	for _, compositeKey := range keys {
@@ -309,15 +422,40 @@ func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.Version
 	return kv.VersionedValue, nil
 }
 
-// GetStateMultipleKeys implements method in VersionedDB interface
+// GetStateMultipleKeys implements method in VersionedDB interface. The documents are retrieved by bulk reads
+// made in parallel, each one for at most `maxBatchUpdateSize` keys
 func (vdb *VersionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
 	vals := make([]*statedb.VersionedValue, len(keys))
-	for i, key := range keys {
-		val, err := vdb.GetState(namespace, key)
+	if namespace == "lscc" || len(keys) <= 1 {
+		// the states of lscc are served from the cache by GetState
+		for i, key := range keys {
+			val, err := vdb.GetState(namespace, key)
+			if err != nil {
+				return nil, err
+			}
+			vals[i] = val
+		}
+		return vals, nil
+	}
+
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return nil, err
+	}
+	couchDocs, err := retrieveNsDocs(db, keys)
+	if err != nil {
+		return nil, err
+	}
+	retrievedVals := make(map[string]*statedb.VersionedValue, len(couchDocs))
+	for _, couchDoc := range couchDocs {
+		kv, err := couchDocToKeyValue(couchDoc)
 		if err != nil {
 			return nil, err
 		}
-		vals[i] = val
+		retrievedVals[kv.key] = kv.VersionedValue
+	}
+	for i, key := range keys {
+		vals[i] = retrievedVals[key]
 	}
 	return vals, nil
 }
@@ -520,21 +658,27 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	// and keep it in memory
 	var updateBatches []batch
 	var err error
+	startTime := time.Now()
 	if updateBatches, err = vdb.buildCommitters(updates); err != nil {
 		return err
 	}
+	vdb.stats.observeCommitPhaseTime(startTime, vdb.chainName, phasePrepareUpdates)
 	// stage 2 - ApplyUpdates push the changes to the DB
+	startTime = time.Now()
 	if err = executeBatches(updateBatches); err != nil {
 		return err
 	}
+	vdb.stats.observeCommitPhaseTime(startTime, vdb.chainName, phaseApplyUpdates)
 
 	// Stgae 3 - PostUpdateProcessing - flush and record savepoint.
 	namespaces := updates.GetUpdatedNamespaces()
 	// Record a savepoint at a given height
+	startTime = time.Now()
 	if err = vdb.ensureFullCommitAndRecordSavepoint(height, namespaces); err != nil {
 		logger.Errorf("Error during recordSavepoint: %s", err.Error())
 		return err
 	}
+	vdb.stats.observeCommitPhaseTime(startTime, vdb.chainName, phaseRecordSavepoint)
 
 	lsccUpdates := updates.GetUpdates("lscc")
 	for key, value := range lsccUpdates {
@@ -16,6 +16,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
@@ -86,6 +87,12 @@ func TestMultiDBBasicRW(t *testing.T) {
 
 }
 
+func TestDrop(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestDrop(t, env.DBProvider)
+}
+
 func TestDeletes(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -127,6 +134,56 @@ func TestSmallBatchSize(t *testing.T) {
 	commontests.TestSmallBatchSize(t, env.DBProvider)
 }
 
+func TestGetStateMultipleKeysInSmallBatches(t *testing.T) {
+	viper.Set("ledger.state.couchDBConfig.maxBatchUpdateSize", 2)
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	defer viper.Set("ledger.state.couchDBConfig.maxBatchUpdateSize", 1000)
+	db, err := env.DBProvider.GetDBHandle("testgetstatemultiplekeysinsmallbatches")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name":"marble1"}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte("binary value"), version.NewHeight(1, 2))
+	batch.PutValAndMetadata("ns1", "key3", []byte(`{"asset_name":"marble3"}`), []byte("metadata"), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte(`{"asset_name":"marble4"}`), version.NewHeight(1, 4))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 4)))
+	batch = statedb.NewUpdateBatch()
+	batch.Delete("ns1", "key4", version.NewHeight(2, 1))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 1)))
+
+	// the keys are retrieved by bulk reads of at most two keys, in the order given
+	vals, err := db.GetStateMultipleKeys("ns1", []string{"key3", "key5", "key2", "key4", "key1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*statedb.VersionedValue{
+		{Value: []byte(`{"asset_name":"marble3"}`), Metadata: []byte("metadata"), Version: version.NewHeight(1, 3)},
+		nil,
+		{Value: []byte("binary value"), Version: version.NewHeight(1, 2)},
+		nil,
+		{Value: []byte(`{"asset_name":"marble1"}`), Version: version.NewHeight(1, 1)},
+	}, vals)
+}
+
+func TestCommitPhaseMetrics(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testcommitphasemetrics")
+	assert.NoError(t, err)
+	fakeHistogram := &metricsfakes.Histogram{}
+	fakeHistogram.WithReturns(fakeHistogram)
+	db.(*VersionedDB).stats = &stats{commitPhaseTime: fakeHistogram}
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	assert.NoError(t, db.(*VersionedDB).LoadCommittedVersions([]*statedb.CompositeKey{{Namespace: "ns1", Key: "key1"}}))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 1)))
+
+	assert.Equal(t, 4, fakeHistogram.ObserveCallCount())
+	for i, phase := range []string{phaseLoadCommittedVersions, phasePrepareUpdates, phaseApplyUpdates, phaseRecordSavepoint} {
+		assert.Equal(t, []string{"channel", "testcommitphasemetrics", "phase", phase}, fakeHistogram.WithArgsForCall(i))
+	}
+}
+
 func TestBatchRetry(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -351,6 +408,59 @@ func TestHandleChaincodeDeploy(t *testing.T) {
 
 }
 
+func TestIndexManagement(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexmanagement")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"asset_name": "marble1","color": "blue","size": 1,"owner": "tom"}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"asset_name": "marble2","color": "blue","size": 2,"owner": "jerry"}`), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	indexManageable, ok := db.(statedb.IndexManageable)
+	if !ok {
+		t.Fatalf("Couchdb state impl is expected to implement interface `statedb.IndexManageable`")
+	}
+
+	queryString := `{"selector":{"owner":"tom"}, "sort": [{"size": "desc"}]}`
+	_, err = db.ExecuteQuery("ns1", queryString)
+	assert.Error(t, err, "Error should have been thrown for a missing index")
+
+	indexes, err := indexManageable.ListIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Len(t, indexes, 0)
+
+	index, err := indexManageable.CreateIndex("ns1",
+		[]byte(`{"index":{"fields":[{"size":"desc"}]},"ddoc":"indexSizeSortDoc","name":"indexSizeSortName","type":"json"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "indexSizeSortDoc", index.DesignDoc)
+	assert.Equal(t, "indexSizeSortName", index.Name)
+	_, err = db.ExecuteQuery("ns1", queryString)
+	assert.NoError(t, err)
+
+	indexes, err = indexManageable.ListIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Len(t, indexes, 1)
+	assert.Equal(t, "indexSizeSortDoc", indexes[0].DesignDoc)
+	assert.Equal(t, "indexSizeSortName", indexes[0].Name)
+	assert.Contains(t, indexes[0].Definition, "size")
+	// an index is created in the namespace only
+	indexes, err = indexManageable.ListIndexes("ns2")
+	assert.NoError(t, err)
+	assert.Len(t, indexes, 0)
+
+	_, err = indexManageable.CreateIndex("ns1", []byte(`{"index":{"fields": This is a bad json}`))
+	assert.EqualError(t, err, "error creating index for namespace [ns1]: JSON format is not valid")
+
+	assert.NoError(t, indexManageable.DropIndex("ns1", "indexSizeSortDoc", "indexSizeSortName"))
+	indexes, err = indexManageable.ListIndexes("ns1")
+	assert.NoError(t, err)
+	assert.Len(t, indexes, 0)
+	assert.Error(t, indexManageable.DropIndex("ns1", "indexSizeSortDoc", "indexSizeSortName"))
+}
+
 func TestTryCastingToJSON(t *testing.T) {
 	sampleJSON := []byte(`{"a":"A", "b":"B"}`)
 	isJSON, jsonVal := tryCastingToJSON(sampleJSON)
@@ -22,6 +22,8 @@ import (
 type VersionedDBProvider interface {
 	// GetDBHandle returns a handle to a VersionedDB
 	GetDBHandle(id string) (VersionedDB, error)
+	// Drop drops all the data in the VersionedDB for the given id, including the savepoint
+	Drop(id string) error
 	// Close closes all the VersionedDB instances and releases any resources held by VersionedDBProvider
 	Close()
 }
@@ -88,6 +90,74 @@ type IndexCapable interface {
 	ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error
 }
 
+//FullScannable interface provides additional functions for
+//databases capable of iterating over all the keys of all the namespaces.
+//This is used for exporting the state in a snapshot
+type FullScannable interface {
+	// GetFullScanIterator returns an iterator over all the keys (excluding the namespaces for which
+	// the function `skipNamespace` returns true) ordered by namespace and then by key.
+	// The returned ResultsIterator contains results of type *VersionedKV
+	GetFullScanIterator(skipNamespace func(string) bool) (ResultsIterator, error)
+}
+
+//IndexQueryable interface provides additional functions for databases
+//that maintain secondary indexes on the fields of the JSON values and
+//that can serve the entries of an index in the order of their keys.
+//The keys of the entries are derived by the function IndexEntryKey and
+//are recorded in the read-write set for the validation of phantom reads.
+//The functions return an *IndexNotFoundError if the index is not defined
+type IndexQueryable interface {
+	// GetIndexKeyRange returns the range [startKey, endKey) of the keys of the entries of an index
+	// whose leading fields have the given values. An empty endKey refers to the end of the index
+	GetIndexKeyRange(namespace, indexName string, values []interface{}) (startKey, endKey []byte, err error)
+	// GetIndexRangeScanIterator returns an iterator over the entries of an index whose keys are in the
+	// range [startKey, endKey). An empty endKey refers to the end of the index.
+	// The returned ResultsIterator contains results of type *IndexedKV
+	GetIndexRangeScanIterator(namespace, indexName string, startKey, endKey []byte) (ResultsIterator, error)
+	// GetIndexFields returns the fields of the JSON values on which an index is defined
+	GetIndexFields(namespace, indexName string) ([]string, error)
+}
+
+//IndexManageable interface provides additional functions for databases
+//whose indexes can be created, listed and dropped on demand, in addition
+//to the indexes that are created on the deployment of a chaincode
+type IndexManageable interface {
+	// CreateIndex creates the index with the given definition in the namespace. The format of the
+	// definition is the same as that of the index files packaged with a chaincode
+	CreateIndex(namespace string, indexDefinition []byte) (*IndexInfo, error)
+	// ListIndexes returns the indexes of the namespace along with their build status
+	ListIndexes(namespace string) ([]*IndexInfo, error)
+	// DropIndex drops the index with the given name in the given design document from the namespace
+	DropIndex(namespace, designDoc, indexName string) error
+}
+
+// IndexInfo describes an index of a namespace
+type IndexInfo struct {
+	DesignDoc  string
+	Name       string
+	Definition string
+	// Building is true while the database builds the index, in which case
+	// BuildProgress is the percentage of the build that is complete
+	Building      bool
+	BuildProgress int
+}
+
+// IndexNotFoundError is returned for an index that is not defined in a namespace
+type IndexNotFoundError struct {
+	Namespace string
+	IndexName string
+}
+
+func (e *IndexNotFoundError) Error() string {
+	return fmt.Sprintf("index [%s] not found in namespace [%s]", e.IndexName, e.Namespace)
+}
+
+// IndexedKV is a state along with the key of its entry in a secondary index
+type IndexedKV struct {
+	VersionedKV
+	IndexKey []byte
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -0,0 +1,323 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateindex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("stateindex")
+
+const (
+	separator        = byte(0x00)
+	lastKeyIndicator = byte(0x01)
+)
+
+// Definition is the definition of a secondary index on one or more (possibly nested) fields of the JSON values
+// in a namespace. Each field is identified by the path of the names of the nested fields. An index entry is
+// maintained for every state whose value is a JSON object, the fields missing in the value are indexed as missing
+type Definition struct {
+	Name   string     `json:"name"`
+	Fields [][]string `json:"fields"`
+}
+
+// Equal returns whether two definitions define the same index
+func (def *Definition) Equal(other *Definition) bool {
+	if def.Name != other.Name || len(def.Fields) != len(other.Fields) {
+		return false
+	}
+	for i, path := range def.Fields {
+		if len(path) != len(other.Fields[i]) {
+			return false
+		}
+		for j, name := range path {
+			if name != other.Fields[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// EntryKey returns the key of the entry of the index for a state, relative to the prefix of the index.
+// Nil is returned if the value of the state is not a JSON object
+func (def *Definition) EntryKey(key string, value []byte) []byte {
+	return statedb.IndexEntryKey(def.Fields, key, value)
+}
+
+// KeyFromEntryKey extracts the key of the state from the key of an entry of the index
+func (def *Definition) KeyFromEntryKey(entryKey []byte) string {
+	return statedb.KeyFromIndexEntryKey(len(def.Fields), entryKey)
+}
+
+// Store maintains the secondary indexes of a state database that keeps its states in a leveldb. The entries
+// of the indexes and their definitions are kept in the same db as the states, with keys that begin with the
+// given prefixes, and are updated in the same batches as the states
+//
+//	<entryKeyPrefix> <namespace> 0x00 <index name> 0x00 <value> 0x00 ... <key>  -> empty, one entry per indexed state
+//	<definitionKeyPrefix> <namespace> 0x00 <index name>                         -> index definition
+type Store struct {
+	db                  *leveldbhelper.DBHandle
+	entryKeyPrefix      []byte
+	definitionKeyPrefix []byte
+	// commitLock serializes the updates of the states and of the indexes
+	commitLock      sync.Mutex
+	definitionsLock sync.RWMutex
+	definitions     map[string][]*Definition
+}
+
+// NewStore constructs a Store for the indexes kept in the given db
+func NewStore(db *leveldbhelper.DBHandle, entryKeyPrefix, definitionKeyPrefix []byte) *Store {
+	return &Store{
+		db:                  db,
+		entryKeyPrefix:      entryKeyPrefix,
+		definitionKeyPrefix: definitionKeyPrefix,
+		definitions:         map[string][]*Definition{},
+	}
+}
+
+// Lock acquires the lock that serializes the updates of the states and of the indexes. The lock is expected
+// to be held while a batch of updates is prepared and written, so that the index entries that are removed are
+// the ones derived from the committed states
+func (s *Store) Lock() {
+	s.commitLock.Lock()
+}
+
+// Unlock releases the lock acquired by Lock
+func (s *Store) Unlock() {
+	s.commitLock.Unlock()
+}
+
+// IndexPrefix returns the prefix of the keys of the entries of an index in the db
+func (s *Store) IndexPrefix(namespace, indexName string) []byte {
+	prefix := append(append([]byte{}, s.entryKeyPrefix...), namespace...)
+	prefix = append(prefix, separator)
+	prefix = append(prefix, indexName...)
+	return append(prefix, separator)
+}
+
+func (s *Store) definitionKey(namespace, indexName string) []byte {
+	key := append(append([]byte{}, s.definitionKeyPrefix...), namespace...)
+	key = append(key, separator)
+	return append(key, indexName...)
+}
+
+// Indexes returns the definitions of the indexes of a namespace, which are cached after the first load
+func (s *Store) Indexes(namespace string) ([]*Definition, error) {
+	s.definitionsLock.RLock()
+	defs, ok := s.definitions[namespace]
+	s.definitionsLock.RUnlock()
+	if ok {
+		return defs, nil
+	}
+	defs, err := s.loadDefinitions(namespace)
+	if err != nil {
+		return nil, err
+	}
+	s.definitionsLock.Lock()
+	s.definitions[namespace] = defs
+	s.definitionsLock.Unlock()
+	return defs, nil
+}
+
+// Index returns the definition of an index. An *statedb.IndexNotFoundError is returned if the index is not defined
+func (s *Store) Index(namespace, indexName string) (*Definition, error) {
+	defs, err := s.Indexes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, def := range defs {
+		if def.Name == indexName {
+			return def, nil
+		}
+	}
+	return nil, &statedb.IndexNotFoundError{Namespace: namespace, IndexName: indexName}
+}
+
+// loadDefinitions reads the definitions of the indexes of the given namespace from the db
+func (s *Store) loadDefinitions(namespace string) ([]*Definition, error) {
+	start := s.definitionKey(namespace, "")
+	itr := s.db.GetIterator(start, RangeEnd(start))
+	defer itr.Release()
+	defs := []*Definition{}
+	for itr.Next() {
+		def := &Definition{}
+		if err := json.Unmarshal(itr.Value(), def); err != nil {
+			return nil, errors.Wrapf(err, "error decoding the definition of the index [%s] in the namespace [%s]",
+				itr.Key()[len(start):], namespace)
+		}
+		defs = append(defs, def)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "error loading the index definitions of the namespace [%s]", namespace)
+	}
+	return defs, nil
+}
+
+// AddIndexUpdates adds to the db batch the changes in the index entries caused by the updates in the given batch.
+// The entries to remove are derived from the states committed in the given db
+func (s *Store) AddIndexUpdates(dbBatch *leveldbhelper.UpdateBatch, db statedb.VersionedDB, batch *statedb.UpdateBatch) error {
+	for _, ns := range batch.GetUpdatedNamespaces() {
+		defs, err := s.Indexes(ns)
+		if err != nil {
+			return err
+		}
+		if len(defs) == 0 {
+			continue
+		}
+		for key, vv := range batch.GetUpdates(ns) {
+			committed, err := db.GetState(ns, key)
+			if err != nil {
+				return err
+			}
+			for _, def := range defs {
+				var oldEntry, newEntry []byte
+				if committed != nil {
+					oldEntry = def.EntryKey(key, committed.Value)
+				}
+				if vv.Value != nil {
+					newEntry = def.EntryKey(key, vv.Value)
+				}
+				if bytes.Equal(oldEntry, newEntry) {
+					continue
+				}
+				if oldEntry != nil {
+					dbBatch.Delete(append(s.IndexPrefix(ns, def.Name), oldEntry...))
+				}
+				if newEntry != nil {
+					dbBatch.Put(append(s.IndexPrefix(ns, def.Name), newEntry...), []byte{})
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ProcessIndexesForChaincodeDeploy records the definitions of the indexes of a namespace, which are parsed from
+// the files of the chaincode package by the given function. An index that already exists with the same definition
+// is left as is, otherwise the index is (re)built from the states of the namespace in the given db in the same
+// batch that records its definition
+func (s *Store) ProcessIndexesForChaincodeDeploy(db statedb.VersionedDB, namespace string, fileEntries []*ccprovider.TarFileEntry,
+	parseDefinition func(content []byte) (*Definition, error)) error {
+	s.Lock()
+	defer s.Unlock()
+
+	existing, err := s.loadDefinitions(namespace)
+	if err != nil {
+		return err
+	}
+	existingByName := map[string]*Definition{}
+	for _, def := range existing {
+		existingByName[def.Name] = def
+	}
+
+	var defs []*Definition
+	filenames := map[string]string{}
+	for _, fileEntry := range fileEntries {
+		filename := fileEntry.FileHeader.Name
+		def, err := parseDefinition(fileEntry.FileContent)
+		if err == nil && bytes.IndexByte([]byte(def.Name), separator) != -1 {
+			err = errors.Errorf("index name [%q] must not contain a nil byte", def.Name)
+		}
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error creating index from file [%s] for namespace [%s]", filename, namespace))
+		}
+		if other, ok := filenames[def.Name]; ok {
+			return errors.Errorf("index [%s] is defined in both the files [%s] and [%s] for namespace [%s]",
+				def.Name, other, filename, namespace)
+		}
+		filenames[def.Name] = filename
+		defs = append(defs, def)
+	}
+
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	var toBuild []*Definition
+	for _, def := range defs {
+		if old, ok := existingByName[def.Name]; ok {
+			if old.Equal(def) {
+				logger.Debugf("Index [%s] for namespace [%s] already exists", def.Name, namespace)
+				continue
+			}
+			logger.Infof("Rebuilding index [%s] for namespace [%s] as its definition has changed", def.Name, namespace)
+			if err := s.addIndexRemoval(dbBatch, namespace, old); err != nil {
+				return err
+			}
+		}
+		encodedDef, err := json.Marshal(def)
+		if err != nil {
+			return errors.Wrapf(err, "error encoding the definition of the index [%s]", def.Name)
+		}
+		dbBatch.Put(s.definitionKey(namespace, def.Name), encodedDef)
+		toBuild = append(toBuild, def)
+	}
+	if err := s.addIndexBuild(dbBatch, db, namespace, toBuild); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error creating the indexes for namespace [%s]", namespace))
+	}
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+
+	s.definitionsLock.Lock()
+	delete(s.definitions, namespace)
+	s.definitionsLock.Unlock()
+	return nil
+}
+
+// addIndexRemoval adds to the batch the deletion of all the entries of an index
+func (s *Store) addIndexRemoval(dbBatch *leveldbhelper.UpdateBatch, namespace string, def *Definition) error {
+	prefix := s.IndexPrefix(namespace, def.Name)
+	itr := s.db.GetIterator(prefix, RangeEnd(prefix))
+	defer itr.Release()
+	for itr.Next() {
+		dbBatch.Delete(append([]byte{}, itr.Key()...))
+	}
+	return errors.Wrapf(itr.Error(), "internal leveldb error while removing the index [%s]", def.Name)
+}
+
+// addIndexBuild adds to the batch the entries of the given indexes for the states of the namespace,
+// which are scanned once for all the indexes
+func (s *Store) addIndexBuild(dbBatch *leveldbhelper.UpdateBatch, db statedb.VersionedDB, namespace string, defs []*Definition) error {
+	if len(defs) == 0 {
+		return nil
+	}
+	itr, err := db.GetStateRangeScanIterator(namespace, "", "")
+	if err != nil {
+		return err
+	}
+	defer itr.Close()
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			return nil
+		}
+		versionedKV := result.(*statedb.VersionedKV)
+		for _, def := range defs {
+			if entry := def.EntryKey(versionedKV.Key, versionedKV.Value); entry != nil {
+				dbBatch.Put(append(s.IndexPrefix(namespace, def.Name), entry...), []byte{})
+			}
+		}
+	}
+}
+
+// RangeEnd returns the exclusive end of the range of the keys that begin with the given prefix,
+// which is expected to end with the nil byte that separates the components of the keys
+func RangeEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	end[len(end)-1] = lastKeyIndicator
+	return end
+}
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateindex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefinitionEntryKey(t *testing.T) {
+	def := &Definition{Name: "indexOwner", Fields: [][]string{{"docType"}, {"owner", "name"}}}
+	entryKey := def.EntryKey("marble1", []byte(`{"docType":"marble","owner":{"name":"tom"}}`))
+	assert.Equal(t, []byte("s\"marble\"\x00s\"tom\"\x00marble1"), entryKey)
+	assert.Equal(t, "marble1", def.KeyFromEntryKey(entryKey))
+
+	// the missing fields and the null values are indexed, with distinct encodings
+	assert.Equal(t, []byte("m\x00m\x00key"), def.EntryKey("key", []byte(`{}`)))
+	assert.Equal(t, []byte("z\x00m\x00key"), def.EntryKey("key", []byte(`{"docType":null,"owner":"tom"}`)))
+
+	// the values that are not JSON objects are not indexed
+	assert.Nil(t, def.EntryKey("key", []byte(`not a json`)))
+	assert.Nil(t, def.EntryKey("key", []byte(`["marble"]`)))
+	assert.Nil(t, def.EntryKey("key", []byte(`{"docType":"marble"} {}`)))
+}
+
+func TestDefinitionEqual(t *testing.T) {
+	def := &Definition{Name: "indexOwner", Fields: [][]string{{"docType"}, {"owner", "name"}}}
+	assert.True(t, def.Equal(&Definition{Name: "indexOwner", Fields: [][]string{{"docType"}, {"owner", "name"}}}))
+	assert.False(t, def.Equal(&Definition{Name: "indexDocType", Fields: [][]string{{"docType"}, {"owner", "name"}}}))
+	assert.False(t, def.Equal(&Definition{Name: "indexOwner", Fields: [][]string{{"docType"}, {"owner.name"}}}))
+	assert.False(t, def.Equal(&Definition{Name: "indexOwner", Fields: [][]string{{"docType"}}}))
+}
+
+func TestRangeEnd(t *testing.T) {
+	assert.Equal(t, []byte("ns1\x01"), RangeEnd([]byte("ns1\x00")))
+}
@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateindex"
+	"github.com/pkg/errors"
+)
+
+// indexDefinition is the definition of a secondary index as found in a file `META-INF/statedb/leveldb/indexes/<file>.json`
+// of a chaincode package. For instance, `{"name":"indexOwner","fields":["docType","owner.name"]}` where a dot
+// separates the names of the nested fields
+type indexDefinition struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+func parseIndexDefinition(content []byte) (*stateindex.Definition, error) {
+	def := &indexDefinition{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(def); err != nil {
+		return nil, errors.Wrap(err, "invalid index definition")
+	}
+	if def.Name == "" {
+		return nil, errors.New("invalid index definition: the name of the index is missing")
+	}
+	if len(def.Fields) == 0 {
+		return nil, errors.Errorf("invalid index definition: no fields are specified for the index [%s]", def.Name)
+	}
+	for _, field := range def.Fields {
+		for _, name := range strings.Split(field, ".") {
+			if name == "" {
+				return nil, errors.Errorf("invalid index definition: invalid field [%s] in the index [%s]", field, def.Name)
+			}
+		}
+	}
+	return &stateindex.Definition{Name: def.Name, Fields: statedb.IndexFieldPaths(def.Fields)}, nil
+}
+
+// The keys of the index entries and of the index definitions begin with a nil byte, like the savepoint key,
+// so that they never collide with the keys of the states
+var (
+	indexEntryKeyPrefix      = []byte{0x00, 0x01}
+	indexDefinitionKeyPrefix = []byte{0x00, 0x02}
+)
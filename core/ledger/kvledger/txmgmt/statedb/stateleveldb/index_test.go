@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"archive/tar"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateindex"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIndexDefinition(t *testing.T) {
+	def, err := parseIndexDefinition([]byte(`{"name":"indexOwner","fields":["docType","owner.name"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, &stateindex.Definition{Name: "indexOwner", Fields: [][]string{{"docType"}, {"owner", "name"}}}, def)
+
+	for content, expectedErr := range map[string]string{
+		`{"fields":["owner"]}`:                              "invalid index definition: the name of the index is missing",
+		`{"name":"indexOwner"}`:                             "invalid index definition: no fields are specified for the index [indexOwner]",
+		`{"name":"indexOwner","fields":["owner."]}`:         "invalid index definition: invalid field [owner.] in the index [indexOwner]",
+		`{"name":"indexOwner","fields":["owner"],"ddoc":1}`: `invalid index definition: json: unknown field "ddoc"`,
+	} {
+		_, err := parseIndexDefinition([]byte(content))
+		assert.EqualError(t, err, expectedErr, content)
+	}
+}
+
+func TestIndexes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexes")
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+	populateMarbles(t, db)
+
+	// the indexes are built from the existing states
+	createIndexes(t, db, "ns1",
+		`{"name":"ownerIndex","fields":["owner"]}`,
+		`{"name":"colorSizeIndex","fields":["color","size"]}`,
+	)
+	assert.Equal(t, []string{"marble2", "marble4", "marble1", "marble3", "marble5"}, indexedKeys(t, db, "ns1", "ownerIndex"))
+	assert.Equal(t, []string{"marble1", "marble3"}, indexedKeys(t, db, "ns1", "ownerIndex", "tom"))
+	assert.Equal(t, []string{"marble1", "marble4"}, indexedKeys(t, db, "ns1", "colorSizeIndex", "blue"))
+	assert.Equal(t, []string{"marble4"}, indexedKeys(t, db, "ns1", "colorSizeIndex", "blue", 4))
+	assert.Empty(t, indexedKeys(t, db, "ns1", "ownerIndex", "nobody"))
+
+	// the indexes are maintained as the states are updated
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "marble1", []byte(`{"owner":"jerry","color":"blue","size":1}`), version.NewHeight(2, 1))
+	batch.Put("ns1", "marble6", []byte(`{"owner":"tom","color":"white","size":6}`), version.NewHeight(2, 2))
+	batch.Delete("ns1", "marble3", version.NewHeight(2, 3))
+	batch.Put("ns1", "marble4", []byte(`not a json`), version.NewHeight(2, 4))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 4)))
+	assert.Equal(t, []string{"marble6"}, indexedKeys(t, db, "ns1", "ownerIndex", "tom"))
+	assert.Equal(t, []string{"marble1", "marble2"}, indexedKeys(t, db, "ns1", "ownerIndex", "jerry"))
+	assert.Equal(t, []string{"marble1"}, indexedKeys(t, db, "ns1", "colorSizeIndex", "blue"))
+
+	// the index entries are not visible in the range queries and in the full scan
+	itr, err := db.GetStateRangeScanIterator("ns1", "", "")
+	require.NoError(t, err)
+	assert.Len(t, collectKeys(t, itr), 6)
+	itr, err = vdb.GetFullScanIterator(func(string) bool { return false })
+	require.NoError(t, err)
+	assert.Len(t, collectKeys(t, itr), 7)
+
+	// redeploying the same definitions leaves the indexes as they are
+	createIndexes(t, db, "ns1", `{"name":"ownerIndex","fields":["owner"]}`)
+	defs, err := vdb.indexes.Indexes("ns1")
+	require.NoError(t, err)
+	assert.Len(t, defs, 2)
+
+	// a changed definition rebuilds the index
+	createIndexes(t, db, "ns1", `{"name":"ownerIndex","fields":["color"]}`)
+	assert.Equal(t, []string{"marble2"}, indexedKeys(t, db, "ns1", "ownerIndex", "red"))
+	assert.Empty(t, indexedKeys(t, db, "ns1", "ownerIndex", "jerry"))
+
+	// invalid definitions are rejected
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{indexFileEntry(`{"name":"bad"}`)})
+	assert.EqualError(t, err, "error creating index from file [META-INF/statedb/leveldb/indexes/index0.json] for namespace [ns1]: "+
+		"invalid index definition: no fields are specified for the index [bad]")
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{indexFileEntry(`{"name":"b\u0000d","fields":["a"]}`)})
+	assert.EqualError(t, err, "error creating index from file [META-INF/statedb/leveldb/indexes/index0.json] for namespace [ns1]: "+
+		`index name ["b\x00d"] must not contain a nil byte`)
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{
+		indexFileEntry(`{"name":"dup","fields":["a"]}`),
+		indexFileEntry(`{"name":"dup","fields":["b"]}`),
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "index [dup] is defined in both the files")
+
+	// the index definitions survive the restart of the provider
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider()
+	db, err = env.DBProvider.GetDBHandle("testindexes")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"marble2"}, indexedKeys(t, db, "ns1", "ownerIndex", "red"))
+}
+
+func TestIndexQueryable(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexqueryable")
+	require.NoError(t, err)
+	populateMarbles(t, db)
+	createIndexes(t, db, "ns1", `{"name":"colorSizeIndex","fields":["color","size"]}`)
+	indexQueryable := db.(statedb.IndexQueryable)
+
+	_, _, err = indexQueryable.GetIndexKeyRange("ns1", "missingIndex", nil)
+	assert.EqualError(t, err, "index [missingIndex] not found in namespace [ns1]")
+	assert.IsType(t, &statedb.IndexNotFoundError{}, err)
+	_, err = indexQueryable.GetIndexRangeScanIterator("ns2", "colorSizeIndex", nil, nil)
+	assert.IsType(t, &statedb.IndexNotFoundError{}, err)
+	_, _, err = indexQueryable.GetIndexKeyRange("ns1", "colorSizeIndex", []interface{}{"blue", 1, true})
+	assert.EqualError(t, err, "3 values are given for the index [colorSizeIndex] of the namespace [ns1] that has 2 fields")
+
+	// no values select the whole index
+	startKey, endKey, err := indexQueryable.GetIndexKeyRange("ns1", "colorSizeIndex", nil)
+	require.NoError(t, err)
+	assert.Empty(t, startKey)
+	assert.Empty(t, endKey)
+
+	// the values of the query are normalized, an int matches the JSON number of the state
+	startKey, endKey, err = indexQueryable.GetIndexKeyRange("ns1", "colorSizeIndex", []interface{}{"blue", int64(4)})
+	require.NoError(t, err)
+	itr, err := indexQueryable.GetIndexRangeScanIterator("ns1", "colorSizeIndex", startKey, endKey)
+	require.NoError(t, err)
+	result, err := itr.Next()
+	require.NoError(t, err)
+	indexedKV := result.(*statedb.IndexedKV)
+	assert.Equal(t, "marble4", indexedKV.Key)
+	assert.Equal(t, version.NewHeight(1, 4), indexedKV.Version)
+	assert.Equal(t, []byte("s\"blue\"\x00n4\x00marble4"), indexedKV.IndexKey)
+	result, err = itr.Next()
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	itr.Close()
+
+	fields, err := indexQueryable.GetIndexFields("ns1", "colorSizeIndex")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"color", "size"}, fields)
+	_, err = indexQueryable.GetIndexFields("ns1", "missingIndex")
+	assert.IsType(t, &statedb.IndexNotFoundError{}, err)
+}
+
+func populateMarbles(t *testing.T, db statedb.VersionedDB) {
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "marble1", []byte(`{"owner":"tom","color":"blue","size":1}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "marble2", []byte(`{"owner":"jerry","color":"red","size":2}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "marble3", []byte(`{"owner":"tom","color":"green","size":3}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "marble4", []byte(`{"owner":"jerry","color":"blue","size":4}`), version.NewHeight(1, 4))
+	batch.Put("ns1", "marble5", []byte(`{"owner":null,"color":"grey","size":-1}`), version.NewHeight(1, 5))
+	batch.Put("ns1", "text", []byte(`not a json`), version.NewHeight(1, 6))
+	batch.Put("ns2", "marble1", []byte(`{"owner":"tom"}`), version.NewHeight(1, 7))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 7)))
+}
+
+func indexFileEntry(definition string) *ccprovider.TarFileEntry {
+	return &ccprovider.TarFileEntry{
+		FileHeader:  &tar.Header{Name: "META-INF/statedb/leveldb/indexes/index0.json"},
+		FileContent: []byte(definition),
+	}
+}
+
+func createIndexes(t *testing.T, db statedb.VersionedDB, namespace string, definitions ...string) {
+	var entries []*ccprovider.TarFileEntry
+	for i, definition := range definitions {
+		entry := indexFileEntry(definition)
+		entry.FileHeader.Name = fmt.Sprintf("META-INF/statedb/leveldb/indexes/index%d.json", i)
+		entries = append(entries, entry)
+	}
+	require.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy(namespace, entries))
+}
+
+// indexedKeys returns the keys of the states whose entries in the given index match the given values,
+// in the order of the index
+func indexedKeys(t *testing.T, db statedb.VersionedDB, namespace, indexName string, values ...interface{}) []string {
+	indexQueryable := db.(statedb.IndexQueryable)
+	startKey, endKey, err := indexQueryable.GetIndexKeyRange(namespace, indexName, values)
+	require.NoError(t, err)
+	itr, err := indexQueryable.GetIndexRangeScanIterator(namespace, indexName, startKey, endKey)
+	require.NoError(t, err)
+	return collectKeys(t, itr)
+}
+
+func collectKeys(t *testing.T, itr statedb.ResultsIterator) []string {
+	defer itr.Close()
+	var keys []string
+	for {
+		result, err := itr.Next()
+		require.NoError(t, err)
+		if result == nil {
+			return keys
+		}
+		switch kv := result.(type) {
+		case *statedb.IndexedKV:
+			keys = append(keys, kv.Key)
+		case *statedb.VersionedKV:
+			keys = append(keys, kv.Key)
+		}
+	}
+}
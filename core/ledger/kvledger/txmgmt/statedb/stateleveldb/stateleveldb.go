@@ -7,10 +7,16 @@ package stateleveldb
 
 import (
 	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateindex"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/pkg/errors"
@@ -23,9 +29,27 @@ var compositeKeySep = []byte{0x00}
 var lastKeyIndicator = byte(0x01)
 var savePointKey = []byte{0x00}
 
+// StateDatabaseName is the name under which this state database is registered
+// and that selects it in `ledger.state.stateDatabase`
+const StateDatabaseName = "goleveldb"
+
+// dbType is the name of the directory `META-INF/statedb/<dbType>` in the chaincode package that
+// contains the index definitions for this state database
+const dbType = "leveldb"
+
+func init() {
+	statedb.MustRegisterVersionedDBProvider(StateDatabaseName,
+		func(metrics.Provider) (statedb.VersionedDBProvider, error) {
+			return NewVersionedDBProvider(), nil
+		},
+	)
+}
+
 // VersionedDBProvider implements interface VersionedDBProvider
 type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
+	lock       sync.Mutex
+	databases  map[string]*versionedDB
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
@@ -33,12 +57,38 @@ func NewVersionedDBProvider() *VersionedDBProvider {
 	dbPath := ledgerconfig.GetStateLevelDBPath()
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
-	return &VersionedDBProvider{dbProvider}
+	return &VersionedDBProvider{dbProvider: dbProvider, databases: map[string]*versionedDB{}}
 }
 
-// GetDBHandle gets the handle to a named database
+// GetDBHandle gets the handle to a named database. The same handle is returned for a name
+// so that the index definitions cached by the handle are shared by all its users
 func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
-	return newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName), nil
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	vdb, ok := provider.databases[dbName]
+	if !ok {
+		vdb = newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName)
+		provider.databases[dbName] = vdb
+	}
+	return vdb, nil
+}
+
+// Drop deletes all the keys of the named database from the underlying shared db
+func (provider *VersionedDBProvider) Drop(dbName string) error {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	delete(provider.databases, dbName)
+	return provider.dbProvider.GetDBHandle(dbName).DeleteAll()
+}
+
+// DiskUsage implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (provider *VersionedDBProvider) DiskUsage(dbName string) (uint64, error) {
+	return provider.dbProvider.GetDBHandle(dbName).DiskUsage()
+}
+
+// Compact implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (provider *VersionedDBProvider) Compact(dbName string) error {
+	return provider.dbProvider.GetDBHandle(dbName).Compact()
 }
 
 // Close closes the underlying db
@@ -46,15 +96,16 @@ func (provider *VersionedDBProvider) Close() {
 	provider.dbProvider.Close()
 }
 
-// VersionedDB implements VersionedDB interface
+// VersionedDB implements VersionedDB, IndexCapable, IndexQueryable, and FullScannable interfaces
 type versionedDB struct {
-	db     *leveldbhelper.DBHandle
-	dbName string
+	db      *leveldbhelper.DBHandle
+	dbName  string
+	indexes *stateindex.Store
 }
 
 // newVersionedDB constructs an instance of VersionedDB
 func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+	return &versionedDB{db: db, dbName: dbName, indexes: stateindex.NewStore(db, indexEntryKeyPrefix, indexDefinitionKeyPrefix)}
 }
 
 // Open implements method in VersionedDB interface
@@ -165,8 +216,15 @@ func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metada
 }
 
 // ApplyUpdates implements method in VersionedDB interface
+// The index entries of the updated states are maintained in the same batch as the states
 func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexes.Lock()
+	defer vdb.indexes.Unlock()
+
 	dbBatch := leveldbhelper.NewUpdateBatch()
+	if err := vdb.indexes.AddIndexUpdates(dbBatch, vdb, batch); err != nil {
+		return err
+	}
 	namespaces := batch.GetUpdatedNamespaces()
 	for _, ns := range namespaces {
 		updates := batch.GetUpdates(ns)
@@ -208,10 +266,87 @@ func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	if versionBytes == nil {
 		return nil, nil
 	}
-	version, _ := version.NewHeightFromBytes(versionBytes)
+	version, _, err := version.NewHeightFromBytes(versionBytes)
+	if err != nil {
+		return nil, err
+	}
 	return version, nil
 }
 
+// GetDBType implements method in IndexCapable interface
+func (vdb *versionedDB) GetDBType() string {
+	return dbType
+}
+
+// ProcessIndexesForChaincodeDeploy implements method in IndexCapable interface. Each file is expected to
+// contain the JSON definition of an index. An index that already exists with the same definition is left
+// as is, otherwise the index is (re)built from the states present in the namespace in the same batch that
+// records its definition
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	return vdb.indexes.ProcessIndexesForChaincodeDeploy(vdb, namespace, fileEntries, parseIndexDefinition)
+}
+
+// GetIndexKeyRange implements method in IndexQueryable interface
+func (vdb *versionedDB) GetIndexKeyRange(namespace, indexName string, values []interface{}) ([]byte, []byte, error) {
+	def, err := vdb.indexes.Index(namespace, indexName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(values) > len(def.Fields) {
+		return nil, nil, errors.Errorf("%d values are given for the index [%s] of the namespace [%s] that has %d fields",
+			len(values), indexName, namespace, len(def.Fields))
+	}
+	// the values are normalized to the types that result from decoding the JSON values of the states
+	encodedValues, err := json.Marshal(values)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid values for the index [%s] of the namespace [%s]", indexName, namespace)
+	}
+	var normalizedValues []interface{}
+	if err := json.Unmarshal(encodedValues, &normalizedValues); err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid values for the index [%s] of the namespace [%s]", indexName, namespace)
+	}
+	startKey := statedb.IndexValuesPrefix(normalizedValues)
+	if len(startKey) == 0 {
+		return startKey, nil, nil
+	}
+	return startKey, stateindex.RangeEnd(startKey), nil
+}
+
+// GetIndexRangeScanIterator implements method in IndexQueryable interface
+func (vdb *versionedDB) GetIndexRangeScanIterator(namespace, indexName string, startKey, endKey []byte) (statedb.ResultsIterator, error) {
+	def, err := vdb.indexes.Index(namespace, indexName)
+	if err != nil {
+		return nil, err
+	}
+	prefix := vdb.indexes.IndexPrefix(namespace, indexName)
+	dbStartKey := append(append([]byte{}, prefix...), startKey...)
+	dbEndKey := stateindex.RangeEnd(prefix)
+	if len(endKey) != 0 {
+		dbEndKey = append(append([]byte{}, prefix...), endKey...)
+	}
+	dbItr := vdb.db.GetIterator(dbStartKey, dbEndKey)
+	return &indexScanner{vdb: vdb, namespace: namespace, def: def, prefixLength: len(prefix), dbItr: dbItr}, nil
+}
+
+// GetIndexFields implements method in IndexQueryable interface
+func (vdb *versionedDB) GetIndexFields(namespace, indexName string) ([]string, error) {
+	def, err := vdb.indexes.Index(namespace, indexName)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, len(def.Fields))
+	for i, path := range def.Fields {
+		fields[i] = strings.Join(path, ".")
+	}
+	return fields, nil
+}
+
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.ResultsIterator, error) {
+	dbItr := vdb.db.GetIterator(nil, nil)
+	return &fullDBScanner{dbItr: dbItr, skipNamespace: skipNamespace}, nil
+}
+
 func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
@@ -275,3 +410,75 @@ func (scanner *kvScanner) GetBookmarkAndClose() string {
 	scanner.Close()
 	return retval
 }
+
+type fullDBScanner struct {
+	dbItr         iterator.Iterator
+	skipNamespace func(string) bool
+}
+
+func (s *fullDBScanner) Next() (statedb.QueryResult, error) {
+	for s.dbItr.Next() {
+		dbKey := s.dbItr.Key()
+		if bytes.HasPrefix(dbKey, savePointKey) {
+			// the savepoint and the entries of the indexes
+			continue
+		}
+		ns, key := splitCompositeKey(dbKey)
+		if s.skipNamespace != nil && s.skipNamespace(ns) {
+			continue
+		}
+		dbVal := s.dbItr.Value()
+		dbValCopy := make([]byte, len(dbVal))
+		copy(dbValCopy, dbVal)
+		vv, err := decodeValue(dbValCopy)
+		if err != nil {
+			return nil, err
+		}
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+	return nil, errors.Wrap(s.dbItr.Error(), "internal leveldb error while scanning the state db")
+}
+
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
+
+// indexScanner iterates over the entries of an index and returns the corresponding states
+type indexScanner struct {
+	vdb          *versionedDB
+	namespace    string
+	def          *stateindex.Definition
+	prefixLength int
+	dbItr        iterator.Iterator
+}
+
+func (s *indexScanner) Next() (statedb.QueryResult, error) {
+	for s.dbItr.Next() {
+		indexKey := append([]byte{}, s.dbItr.Key()[s.prefixLength:]...)
+		key := s.def.KeyFromEntryKey(indexKey)
+		vv, err := s.vdb.GetState(s.namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		if vv == nil {
+			logger.Warningf("Skipping the entry of the index [%s] of the namespace [%s] for the missing key [%s]",
+				s.def.Name, s.namespace, key)
+			continue
+		}
+		return &statedb.IndexedKV{
+			VersionedKV: statedb.VersionedKV{
+				CompositeKey:   statedb.CompositeKey{Namespace: s.namespace, Key: key},
+				VersionedValue: *vv,
+			},
+			IndexKey: indexKey,
+		}, nil
+	}
+	return nil, errors.Wrap(s.dbItr.Error(), "internal leveldb error while scanning the index")
+}
+
+func (s *indexScanner) Close() {
+	s.dbItr.Release()
+}
@@ -20,6 +20,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -44,6 +45,12 @@ func TestMultiDBBasicRW(t *testing.T) {
 	commontests.TestMultiDBBasicRW(t, env.DBProvider)
 }
 
+func TestDrop(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	commontests.TestDrop(t, env.DBProvider)
+}
+
 func TestDeletes(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -56,6 +63,22 @@ func TestIterator(t *testing.T) {
 	commontests.TestIterator(t, env.DBProvider)
 }
 
+func TestVersionedDBProviderCertification(t *testing.T) {
+	commontests.TestVersionedDBProvider(t, func(t *testing.T) (statedb.VersionedDBProvider, func()) {
+		env := NewTestVDBEnv(t)
+		return env.DBProvider, env.Cleanup
+	})
+}
+
+func TestRegisteredVersionedDBProvider(t *testing.T) {
+	assert.Contains(t, statedb.RegisteredVersionedDBProviders(), StateDatabaseName)
+	dbProvider, err := statedb.NewVersionedDBProvider(StateDatabaseName, &disabled.Provider{})
+	assert.NoError(t, err)
+	assert.IsType(t, &VersionedDBProvider{}, dbProvider)
+	dbProvider.Close()
+	removeDBPath(t, "TestRegisteredVersionedDBProvider")
+}
+
 func TestCompositeKey(t *testing.T) {
 	testCompositeKey(t, "ledger1", "ns", "key")
 	testCompositeKey(t, "ledger2", "ns", "")
@@ -136,3 +159,38 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("test-full-scan")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 2))
+	batch.Put("ns3", "key3", []byte("value3"), version.NewHeight(1, 3))
+	batch.Put("ns3", "key4", []byte("value4"), version.NewHeight(1, 4))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 4)))
+
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator(
+		func(ns string) bool { return ns == "ns2" },
+	)
+	assert.NoError(t, err)
+	defer itr.Close()
+	var results []*statedb.VersionedKV
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		results = append(results, res.(*statedb.VersionedKV))
+	}
+	assert.Len(t, results, 3)
+	assert.Equal(t, statedb.CompositeKey{Namespace: "ns1", Key: "key1"}, results[0].CompositeKey)
+	assert.Equal(t, statedb.CompositeKey{Namespace: "ns3", Key: "key3"}, results[1].CompositeKey)
+	assert.Equal(t, statedb.CompositeKey{Namespace: "ns3", Key: "key4"}, results[2].CompositeKey)
+	assert.Equal(t, []byte("value4"), results[2].Value)
+	assert.Equal(t, version.NewHeight(1, 4), results[2].Version)
+}
@@ -35,6 +35,12 @@ func TestEncodeDecodeJSONOldFormat(t *testing.T) {
 	assert.Equal(t, &statedb.VersionedValue{Version: version2, Value: bytesJSON2}, decodedValue)
 }
 
+// TestDecodeCorruptedOldFormat tests that decoding a value in the old format with a corrupted version fails
+func TestDecodeCorruptedOldFormat(t *testing.T) {
+	_, err := decodeValue([]byte{0x09, 0x01})
+	assert.EqualError(t, err, "error decoding the block number of the height: invalid size [9] of the number in the bytes [0901]")
+}
+
 func TestEncodeDecodeOldAndNewFormat(t *testing.T) {
 	testdata := []*statedb.VersionedValue{
 		{
@@ -0,0 +1,32 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateindex"
+)
+
+// selectIndex returns the index that has the most leading fields constrained to a value by the
+// given conditions, along with the values of these fields. Nil is returned if no index can be used
+func selectIndex(defs []*stateindex.Definition, conditions map[string]interface{}) (*stateindex.Definition, []interface{}) {
+	var selected *stateindex.Definition
+	var selectedValues []interface{}
+	for _, def := range defs {
+		var values []interface{}
+		for _, path := range def.Fields {
+			v, ok := conditions[pathString(path)]
+			if !ok {
+				break
+			}
+			values = append(values, v)
+		}
+		if len(values) > len(selectedValues) {
+			selected, selectedValues = def, values
+		}
+	}
+	return selected, selectedValues
+}
@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// query is the parsed form of a `SELECT` statement. A state matches the query if it satisfies all the conditions
+type query struct {
+	conditions []*condition
+	limit      int32
+}
+
+// condition is the comparison of a field of the JSON value, or of the key if the path is nil, with a literal
+type condition struct {
+	path  []string
+	op    string
+	value interface{}
+}
+
+func newCondition(path []string, op string, value interface{}) *condition {
+	if len(path) == 1 && path[0] == keyColumn {
+		path = nil
+	}
+	return &condition{path: path, op: op, value: value}
+}
+
+// document is a state as seen by a query. The fields are nil if the value is not a JSON object
+type document struct {
+	key    string
+	fields map[string]interface{}
+}
+
+func newDocument(key string, value []byte) *document {
+	doc := &document{key: key}
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err == nil && !decoder.More() {
+		doc.fields = fields
+	}
+	return doc
+}
+
+func (q *query) matches(doc *document) bool {
+	for _, cond := range q.conditions {
+		if !cond.matches(doc) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches returns whether the document satisfies the condition. A field that is missing or null never satisfies
+// a condition, and a field of a type other than the type of the literal satisfies only the operator `!=`
+func (c *condition) matches(doc *document) bool {
+	var v interface{} = doc.key
+	if c.path != nil {
+		var ok bool
+		if v, ok = lookupField(doc.fields, c.path); !ok || v == nil {
+			return false
+		}
+	}
+	result, comparable := compareValues(v, c.value)
+	if !comparable {
+		return c.op == "!="
+	}
+	switch c.op {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	default:
+		return result >= 0
+	}
+}
+
+// lookupField returns the value of the nested field at the given path
+func lookupField(fields map[string]interface{}, path []string) (interface{}, bool) {
+	var current interface{} = fields
+	for _, name := range path {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// compareValues compares two values of the same type among the numbers, the strings, and the booleans
+func compareValues(a, b interface{}) (int, bool) {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	case bool:
+		y, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x == y:
+			return 0, true
+		case !x:
+			return -1, true
+		}
+		return 1, true
+	}
+	return 0, false
+}
+
+// equalityConditions returns the literal values that the fields are required to be equal to.
+// Only these conditions are used to look up the indexes
+func equalityConditions(q *query) map[string]interface{} {
+	conditions := map[string]interface{}{}
+	for _, cond := range q.conditions {
+		if cond.op == "=" && cond.path != nil {
+			conditions[pathString(cond.path)] = cond.value
+		}
+	}
+	return conditions
+}
+
+// pathString returns a representation of a path that is unambiguous even if the names of the fields contain dots
+func pathString(path []string) string {
+	encoded, _ := json.Marshal(path)
+	return string(encoded)
+}
@@ -0,0 +1,429 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateindex"
+	"github.com/pkg/errors"
+)
+
+// tableName is the name of the table that holds the state of a namespace in the queries and in the index
+// definitions. Each namespace is exposed to its chaincode as a single table with this name
+const tableName = "state"
+
+// keyColumn is the pseudo column that refers to the key of a state in the queries
+const keyColumn = "_key"
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenize splits a statement into tokens. Identifiers may be quoted with double quotes, string literals
+// are enclosed in single quotes (a single quote is escaped by doubling it), and the supported symbols are
+// ( ) , . * - = != <> < <= > >= ;
+func tokenize(statement string) ([]token, error) {
+	var tokens []token
+	runes := []rune(statement)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, string(runes[start:i]), start})
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' ||
+				runes[i] == 'e' || runes[i] == 'E' ||
+				((runes[i] == '+' || runes[i] == '-') && (runes[i-1] == 'e' || runes[i-1] == 'E'))) {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[start:i]), start})
+
+		case r == '\'' || r == '"':
+			start := i
+			text, next, err := scanQuoted(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			kind := tokenString
+			if r == '"' {
+				kind = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{kind, text, start})
+			i = next
+
+		default:
+			start := i
+			symbol := string(r)
+			if i+1 < len(runes) {
+				if twoRunes := string(runes[i : i+2]); twoRunes == "!=" || twoRunes == "<>" || twoRunes == "<=" || twoRunes == ">=" {
+					symbol = twoRunes
+				}
+			}
+			if !strings.Contains("(),.*-=<>;", symbol) && symbol != "!=" && symbol != "<>" && symbol != "<=" && symbol != ">=" {
+				return nil, errors.Errorf("unexpected character [%c] at position %d", r, start)
+			}
+			tokens = append(tokens, token{tokenSymbol, symbol, start})
+			i += len([]rune(symbol))
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(runes)}), nil
+}
+
+// scanQuoted scans the text enclosed in the quote character present at the position `start`,
+// where a doubled quote character stands for the quote character itself
+func scanQuoted(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var text []rune
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] != quote {
+			text = append(text, runes[i])
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == quote {
+			text = append(text, quote)
+			i++
+			continue
+		}
+		return string(text), i + 1, nil
+	}
+	return "", 0, errors.Errorf("unterminated quoted text starting at position %d", start)
+}
+
+// parser parses the subset of SQL that is supported by this state database
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func newParser(statement string) (*parser, error) {
+	tokens, err := tokenize(statement)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{tokens: tokens}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// isKeyword returns true if the next token is the given keyword. The keywords are case insensitive
+func (p *parser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+func (p *parser) acceptKeyword(keyword string) bool {
+	if p.isKeyword(keyword) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if !p.acceptKeyword(keyword) {
+		return p.unexpected(keyword)
+	}
+	return nil
+}
+
+func (p *parser) isSymbol(symbol string) bool {
+	t := p.peek()
+	return t.kind == tokenSymbol && t.text == symbol
+}
+
+func (p *parser) acceptSymbol(symbol string) bool {
+	if p.isSymbol(symbol) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectSymbol(symbol string) error {
+	if !p.acceptSymbol(symbol) {
+		return p.unexpected(symbol)
+	}
+	return nil
+}
+
+// expectEnd consumes an optional trailing semicolon and verifies that the statement ends
+func (p *parser) expectEnd() error {
+	p.acceptSymbol(";")
+	if p.peek().kind != tokenEOF {
+		return p.unexpected("end of statement")
+	}
+	return nil
+}
+
+func (p *parser) unexpected(expected string) error {
+	return unexpectedToken(p.peek(), expected)
+}
+
+func unexpectedToken(t token, expected string) error {
+	if t.kind == tokenEOF {
+		return errors.Errorf("expected %s but reached the end of statement", expected)
+	}
+	return errors.Errorf("expected %s but found [%s] at position %d", expected, t.text, t.pos)
+}
+
+var reservedWords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "AND": true, "LIMIT": true,
+	"TRUE": true, "FALSE": true, "CREATE": true, "INDEX": true, "ON": true,
+}
+
+// parseIdentifier parses an unquoted identifier that is not a reserved word, or a quoted identifier
+func (p *parser) parseIdentifier() (string, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenQuotedIdent:
+		p.next()
+		return t.text, nil
+	case t.kind == tokenIdent && !reservedWords[strings.ToUpper(t.text)]:
+		p.next()
+		return t.text, nil
+	default:
+		return "", p.unexpected("an identifier")
+	}
+}
+
+// parsePath parses a field of the JSON value, where the nested fields are separated by dots
+func (p *parser) parsePath() ([]string, error) {
+	var path []string
+	for {
+		ident, err := p.parseIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, ident)
+		if !p.acceptSymbol(".") {
+			return path, nil
+		}
+	}
+}
+
+// parseTable parses the name of the table, which is required to be `state`
+func (p *parser) parseTable() error {
+	t := p.peek()
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(name, tableName) {
+		return errors.Errorf("unknown table [%s] at position %d, the state of the chaincode is in the table [%s]", name, t.pos, tableName)
+	}
+	return nil
+}
+
+// parseQuery parses a query of the form
+//
+//	SELECT * FROM state [WHERE <field> <operator> <literal> [AND ...]] [LIMIT <n>]
+//
+// where the operator is one of = != <> < <= > >= and the literal is a string, a number, TRUE, or FALSE.
+// The other constructs of SQL, such as OR, NOT, IN, LIKE, or ORDER BY, are not supported
+func parseQuery(statement string) (*query, error) {
+	p, err := newParser(statement)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid query")
+	}
+	q, err := p.parseSelect()
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid query")
+	}
+	return q, nil
+}
+
+func (p *parser) parseSelect() (*query, error) {
+	q := &query{}
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+	if err := p.expectSymbol("*"); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	if err := p.parseTable(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("WHERE") {
+		for {
+			cond, err := p.parseCondition()
+			if err != nil {
+				return nil, err
+			}
+			q.conditions = append(q.conditions, cond)
+			if !p.acceptKeyword("AND") {
+				break
+			}
+		}
+	}
+	if p.acceptKeyword("LIMIT") {
+		t := p.next()
+		limit, err := strconv.ParseInt(t.text, 10, 32)
+		if t.kind != tokenNumber || err != nil || limit <= 0 {
+			return nil, errors.Errorf("expected a positive integer but found [%s] at position %d", t.text, t.pos)
+		}
+		q.limit = int32(limit)
+	}
+	if err := p.expectEnd(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// parseCondition parses the comparison of a field, or of the key, with a literal
+func (p *parser) parseCondition() (*condition, error) {
+	path, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	t := p.next()
+	op := t.text
+	switch {
+	case t.kind != tokenSymbol:
+		return nil, unexpectedToken(t, "a comparison operator")
+	case op == "<>":
+		op = "!="
+	case op != "=" && op != "!=" && op != "<" && op != "<=" && op != ">" && op != ">=":
+		return nil, unexpectedToken(t, "a comparison operator")
+	}
+	value, err := p.parseLiteral()
+	if err != nil {
+		return nil, err
+	}
+	return newCondition(path, op, value), nil
+}
+
+// parseLiteral parses a string, a number, TRUE, or FALSE. The numbers are represented as float64
+// so as to compare them with the numbers in the JSON values
+func (p *parser) parseLiteral() (interface{}, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString:
+		return t.text, nil
+	case t.kind == tokenNumber:
+		return parseNumber(t.text, t.pos)
+	case t.kind == tokenSymbol && t.text == "-":
+		n := p.next()
+		if n.kind != tokenNumber {
+			return nil, unexpectedToken(n, "a number")
+		}
+		value, err := parseNumber(n.text, n.pos)
+		if err != nil {
+			return nil, err
+		}
+		return -value, nil
+	case t.kind == tokenIdent && strings.EqualFold(t.text, "TRUE"):
+		return true, nil
+	case t.kind == tokenIdent && strings.EqualFold(t.text, "FALSE"):
+		return false, nil
+	}
+	return nil, unexpectedToken(t, "a literal")
+}
+
+func parseNumber(text string, pos int) (float64, error) {
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid number [%s] at position %d", text, pos)
+	}
+	return value, nil
+}
+
+// parseIndexDefinition parses an index definition of the form
+//
+//	CREATE INDEX <name> ON state (<field>, ...)
+func parseIndexDefinition(statement string) (*stateindex.Definition, error) {
+	p, err := newParser(statement)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid index definition")
+	}
+	def, err := p.parseCreateIndex()
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid index definition")
+	}
+	return def, nil
+}
+
+func (p *parser) parseCreateIndex() (*stateindex.Definition, error) {
+	if err := p.expectKeyword("CREATE"); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("INDEX"); err != nil {
+		return nil, err
+	}
+	name, err := p.parseIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("ON"); err != nil {
+		return nil, err
+	}
+	if err := p.parseTable(); err != nil {
+		return nil, err
+	}
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	def := &stateindex.Definition{Name: name}
+	for {
+		t := p.peek()
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 1 && path[0] == keyColumn {
+			return nil, errors.Errorf("the key column [%s] at position %d cannot be indexed", keyColumn, t.pos)
+		}
+		def.Fields = append(def.Fields, path)
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	if err := p.expectEnd(); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
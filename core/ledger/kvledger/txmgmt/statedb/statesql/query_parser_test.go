@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateindex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query         string
+		expectedError string
+	}{
+		{``, "invalid query: expected SELECT but reached the end of statement"},
+		{`SELECT owner FROM state`, "invalid query: expected * but found [owner] at position 7"},
+		{`SELECT * FROM state WHERE`, "invalid query: expected an identifier but reached the end of statement"},
+		{`SELECT * FROM state WHERE owner = 'tom`, "invalid query: unterminated quoted text starting at position 34"},
+		{`SELECT * FROM state WHERE owner`, "invalid query: expected a comparison operator but reached the end of statement"},
+		{`SELECT * FROM state WHERE 'tom' = owner`, "invalid query: expected an identifier but found [tom] at position 26"},
+		{`SELECT * FROM state WHERE owner = color`, "invalid query: expected a literal but found [color] at position 34"},
+		{`SELECT * FROM state WHERE owner = NULL`, "invalid query: expected a literal but found [NULL] at position 34"},
+		{`SELECT * FROM state WHERE owner = 'tom' OR owner = 'jerry'`, "invalid query: expected end of statement but found [OR] at position 40"},
+		{`SELECT * FROM state WHERE (owner = 'tom')`, "invalid query: expected an identifier but found [(] at position 26"},
+		{`SELECT * FROM state WHERE owner IN ('tom')`, "invalid query: expected a comparison operator but found [IN] at position 32"},
+		{`SELECT * FROM state ORDER BY owner`, "invalid query: expected end of statement but found [ORDER] at position 20"},
+		{`SELECT * FROM state LIMIT 0`, "invalid query: expected a positive integer but found [0] at position 26"},
+		{`SELECT * FROM state WHERE select = 1`, "invalid query: expected an identifier but found [select] at position 26"},
+		{`SELECT * FROM state; SELECT`, "invalid query: expected end of statement but found [SELECT] at position 21"},
+		{`SELECT * FROM state WHERE size = -`, "invalid query: expected a number but reached the end of statement"},
+		{`SELECT * FROM state WHERE owner # 1`, "invalid query: unexpected character [#] at position 32"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := parseQuery(tc.query)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestParseIndexDefinition(t *testing.T) {
+	def, err := parseIndexDefinition(`CREATE INDEX ownerIndex ON state (owner, "details"."weight.kg");`)
+	require.NoError(t, err)
+	assert.Equal(t, &stateindex.Definition{Name: "ownerIndex", Fields: [][]string{{"owner"}, {"details", "weight.kg"}}}, def)
+
+	_, err = parseIndexDefinition(`CREATE INDEX ownerIndex ON state ()`)
+	assert.EqualError(t, err, "invalid index definition: expected an identifier but found [)] at position 34")
+	_, err = parseIndexDefinition(`CREATE INDEX ownerIndex ON marbles (owner)`)
+	assert.EqualError(t, err, "invalid index definition: unknown table [marbles] at position 27, the state of the chaincode is in the table [state]")
+}
+
+func TestMatches(t *testing.T) {
+	doc := newDocument("key1", []byte(`{"a":1,"b":"text","c":null,"d":{"e":[1,2]},"f":true}`))
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{`a = 1`, true},
+		{`a = 1.0`, true},
+		{`a = '1'`, false},
+		{`a != '1'`, true},
+		{`a <> 1`, false},
+		{`c = 1`, false},
+		{`c != 1`, false},
+		{`missing != 1`, false},
+		{`d.e = 1`, false},
+		{`d.e != 1`, true},
+		{`a >= 1 AND b = 'text'`, true},
+		{`a = 1 AND b = 'other'`, false},
+		{`f = TRUE AND f > FALSE`, true},
+		{`_key = 'key1'`, true},
+		{`_key < 'key0'`, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.condition, func(t *testing.T) {
+			q, err := parseQuery("SELECT * FROM state WHERE " + tc.condition)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, q.matches(doc))
+		})
+	}
+
+	// the fields of a value that is not a JSON object are missing
+	q, err := parseQuery("SELECT * FROM state WHERE _key = 'key2'")
+	require.NoError(t, err)
+	assert.True(t, q.matches(newDocument("key2", []byte(`[1,2]`))))
+	q, err = parseQuery("SELECT * FROM state WHERE a != 2")
+	require.NoError(t, err)
+	assert.False(t, q.matches(newDocument("key2", []byte(`{"a":1} trailing`))))
+}
+
+func TestSelectIndex(t *testing.T) {
+	defs := []*stateindex.Definition{
+		{Name: "a", Fields: [][]string{{"a"}}},
+		{Name: "ab", Fields: [][]string{{"a"}, {"b"}}},
+		{Name: "bc", Fields: [][]string{{"b"}, {"c"}}},
+	}
+	tests := []struct {
+		condition      string
+		expectedIndex  string
+		expectedValues []interface{}
+	}{
+		{`a = 1`, "a", []interface{}{float64(1)}},
+		{`b = 'x' AND a = 1`, "ab", []interface{}{float64(1), "x"}},
+		{`b = 'x' AND c > 1`, "bc", []interface{}{"x"}},
+		{`a > 1`, "", nil},
+		{`_key = 'x'`, "", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.condition, func(t *testing.T) {
+			q, err := parseQuery("SELECT * FROM state WHERE " + tc.condition)
+			require.NoError(t, err)
+			index, values := selectIndex(defs, equalityConditions(q))
+			if tc.expectedIndex == "" {
+				assert.Nil(t, index)
+				return
+			}
+			require.NotNil(t, index)
+			assert.Equal(t, tc.expectedIndex, index.Name)
+			assert.Equal(t, tc.expectedValues, values)
+		})
+	}
+}
@@ -0,0 +1,520 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"bytes"
+	"encoding/base64"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateindex"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("statesql")
+
+// StateDatabaseName is the name under which this state database is registered
+// and that selects it in `ledger.state.stateDatabase`
+const StateDatabaseName = "SQL"
+
+// dbType is the name of the directory `META-INF/statedb/<dbType>` in the chaincode package that
+// contains the index definitions for this state database
+const dbType = "sql"
+
+// The keys in the underlying db are prefixed by the kind of the entry
+//
+//	d<namespace>0x00<key>                                -> encoded versioned value
+//	x<namespace>0x00<index name>                         -> index definition
+//	i<namespace>0x00<index name>0x00<value>0x00...<key>  -> empty, one entry per indexed state
+//	s                                                    -> savepoint
+const (
+	dataKeyPrefix            = byte('d')
+	indexDefinitionKeyPrefix = byte('x')
+	indexEntryKeyPrefix      = byte('i')
+	separator                = byte(0x00)
+	lastKeyIndicator         = byte(0x01)
+)
+
+var savePointKey = []byte{'s'}
+
+const (
+	optionBookmark = "bookmark"
+	optionLimit    = "limit"
+)
+
+func init() {
+	statedb.MustRegisterVersionedDBProvider(StateDatabaseName,
+		func(metrics.Provider) (statedb.VersionedDBProvider, error) {
+			return NewVersionedDBProvider(), nil
+		},
+	)
+}
+
+// VersionedDBProvider implements interface VersionedDBProvider
+type VersionedDBProvider struct {
+	dbProvider *leveldbhelper.Provider
+	lock       sync.Mutex
+	databases  map[string]*versionedDB
+}
+
+// NewVersionedDBProvider instantiates VersionedDBProvider
+func NewVersionedDBProvider() *VersionedDBProvider {
+	dbPath := ledgerconfig.GetStateSQLDBPath()
+	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	return &VersionedDBProvider{dbProvider: dbProvider, databases: map[string]*versionedDB{}}
+}
+
+// GetDBHandle gets the handle to a named database. The same handle is returned for a name
+// so that the index definitions cached by the handle are shared by all its users
+func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	vdb, ok := provider.databases[dbName]
+	if !ok {
+		vdb = newVersionedDB(provider.dbProvider.GetDBHandle(dbName), dbName)
+		provider.databases[dbName] = vdb
+	}
+	return vdb, nil
+}
+
+// Drop deletes all the keys of the named database from the underlying shared db
+func (provider *VersionedDBProvider) Drop(dbName string) error {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	delete(provider.databases, dbName)
+	return provider.dbProvider.GetDBHandle(dbName).DeleteAll()
+}
+
+// DiskUsage implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (provider *VersionedDBProvider) DiskUsage(dbName string) (uint64, error) {
+	return provider.dbProvider.GetDBHandle(dbName).DiskUsage()
+}
+
+// Compact implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (provider *VersionedDBProvider) Compact(dbName string) error {
+	return provider.dbProvider.GetDBHandle(dbName).Compact()
+}
+
+// Close closes the underlying db
+func (provider *VersionedDBProvider) Close() {
+	provider.dbProvider.Close()
+}
+
+// versionedDB implements VersionedDB, IndexCapable, and FullScannable interfaces
+type versionedDB struct {
+	db      *leveldbhelper.DBHandle
+	dbName  string
+	indexes *stateindex.Store
+}
+
+func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
+	indexes := stateindex.NewStore(db, []byte{indexEntryKeyPrefix}, []byte{indexDefinitionKeyPrefix})
+	return &versionedDB{db: db, dbName: dbName, indexes: indexes}
+}
+
+// Open implements method in VersionedDB interface
+func (vdb *versionedDB) Open() error {
+	// do nothing because shared db is used
+	return nil
+}
+
+// Close implements method in VersionedDB interface
+func (vdb *versionedDB) Close() {
+	// do nothing because shared db is used
+}
+
+// ValidateKeyValue implements method in VersionedDB interface
+func (vdb *versionedDB) ValidateKeyValue(key string, value []byte) error {
+	return nil
+}
+
+// BytesKeySupported implements method in VersionedDB interface
+func (vdb *versionedDB) BytesKeySupported() bool {
+	return true
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
+	dbVal, err := vdb.db.Get(constructDataKey(namespace, key))
+	if err != nil {
+		return nil, err
+	}
+	if dbVal == nil {
+		return nil, nil
+	}
+	return decodeValue(dbVal)
+}
+
+// GetVersion implements method in VersionedDB interface
+func (vdb *versionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	versionedValue, err := vdb.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	if versionedValue == nil {
+		return nil, nil
+	}
+	return versionedValue.Version, nil
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	vals := make([]*statedb.VersionedValue, len(keys))
+	for i, key := range keys {
+		val, err := vdb.GetState(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+// GetStateRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	return vdb.GetStateRangeScanIteratorWithMetadata(namespace, startKey, endKey, nil)
+}
+
+// GetStateRangeScanIteratorWithMetadata implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	requestedLimit := int32(0)
+	if metadata != nil {
+		if err := statedb.ValidateRangeMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+	}
+	dataStartKey := constructDataKey(namespace, startKey)
+	dataEndKey := constructDataKey(namespace, endKey)
+	if endKey == "" {
+		dataEndKey = stateindex.RangeEnd(dataEndKey)
+	}
+	source := &sourceIterator{vdb: vdb, namespace: namespace, dbItr: vdb.db.GetIterator(dataStartKey, dataEndKey)}
+	return &queryScanner{source: source, limit: requestedLimit, rangeScan: true}, nil
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
+}
+
+// ExecuteQueryWithMetadata implements method in VersionedDB interface. The query is a `SELECT` statement
+// over the table `state` that holds the states of the namespace. See the function `parseQuery` for
+// the supported syntax. The results are returned in the order of the keys, or in the order of the index
+// used for the query. The results are paginated if the metadata carries the page size in the option
+// "limit", the bookmark returned for a page is passed in the option "bookmark" to retrieve the next page
+func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, statement string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	if err := validateQueryMetadata(metadata); err != nil {
+		return nil, err
+	}
+	q, err := parseQuery(statement)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := int32(0)
+	if limitOption, ok := metadata[optionLimit]; ok {
+		pageSize = limitOption.(int32)
+	}
+	bookmark := ""
+	if bookmarkOption, ok := metadata[optionBookmark]; ok {
+		bookmark = bookmarkOption.(string)
+	}
+	if q.limit > 0 && (pageSize > 0 || bookmark != "") {
+		return nil, errors.New("the LIMIT clause cannot be used in a query with pagination")
+	}
+
+	indexes, err := vdb.indexes.Indexes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	index, values := selectIndex(indexes, equalityConditions(q))
+	var start []byte
+	if index == nil {
+		start = constructDataKey(namespace, "")
+	} else {
+		logger.Debugf("Using index [%s] for the query [%s] on namespace [%s]", index.Name, statement, namespace)
+		start = append(vdb.indexes.IndexPrefix(namespace, index.Name), statedb.IndexValuesPrefix(values)...)
+	}
+	end := stateindex.RangeEnd(start)
+	if bookmark != "" {
+		if start, err = decodePositionBookmark(bookmark, start, end); err != nil {
+			return nil, err
+		}
+	}
+	source := &sourceIterator{vdb: vdb, namespace: namespace, index: index, dbItr: vdb.db.GetIterator(start, end)}
+	limit := q.limit
+	if pageSize > 0 {
+		limit = pageSize
+	}
+	return &queryScanner{source: source, query: q, limit: limit, paginated: q.limit == 0}, nil
+}
+
+func validateQueryMetadata(metadata map[string]interface{}) error {
+	for key, keyVal := range metadata {
+		switch key {
+		case optionBookmark:
+			if _, ok := keyVal.(string); !ok {
+				return errors.New("invalid entry, \"bookmark\" must be a string")
+			}
+		case optionLimit:
+			if _, ok := keyVal.(int32); !ok {
+				return errors.New("invalid entry, \"limit\" must be an int32")
+			}
+		default:
+			return errors.Errorf("invalid entry, option %s not recognized", key)
+		}
+	}
+	return nil
+}
+
+// ApplyUpdates implements method in VersionedDB interface. The entries of the indexes are updated in the
+// same batch as the states so that the indexes are always consistent with the states
+func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	vdb.indexes.Lock()
+	defer vdb.indexes.Unlock()
+
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	if err := vdb.indexes.AddIndexUpdates(dbBatch, vdb, batch); err != nil {
+		return err
+	}
+	for _, ns := range batch.GetUpdatedNamespaces() {
+		for k, vv := range batch.GetUpdates(ns) {
+			dataKey := constructDataKey(ns, k)
+			logger.Debugf("Channel [%s]: Applying key(string)=[%s] key(bytes)=[%#v]", vdb.dbName, string(dataKey), dataKey)
+			if vv.Value == nil {
+				dbBatch.Delete(dataKey)
+				continue
+			}
+			encodedVal, err := encodeValue(vv)
+			if err != nil {
+				return err
+			}
+			dbBatch.Put(dataKey, encodedVal)
+		}
+	}
+	// Record a savepoint at a given height
+	// If a given height is nil, it denotes that we are committing pvt data of old blocks.
+	// In this case, we should not store a savepoint for recovery. The lastUpdatedOldBlockList
+	// in the pvtstore acts as a savepoint for pvt data.
+	if height != nil {
+		dbBatch.Put(savePointKey, height.ToBytes())
+	}
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// GetLatestSavePoint implements method in VersionedDB interface
+func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
+	versionBytes, err := vdb.db.Get(savePointKey)
+	if err != nil {
+		return nil, err
+	}
+	if versionBytes == nil {
+		return nil, nil
+	}
+	version, _, err := version.NewHeightFromBytes(versionBytes)
+	if err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// ProcessIndexesForChaincodeDeploy implements method in IndexCapable interface. Each file is expected to
+// contain the definition of an index of the form `CREATE INDEX <name> ON state (<field>, ...)`.
+// An index that already exists with the same definition is left as is, otherwise the index is (re)built
+// from the states present in the namespace in the same batch that records its definition
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	return vdb.indexes.ProcessIndexesForChaincodeDeploy(vdb, namespace, fileEntries, func(content []byte) (*stateindex.Definition, error) {
+		return parseIndexDefinition(string(content))
+	})
+}
+
+// GetDBType implements method in IndexCapable interface
+func (vdb *versionedDB) GetDBType() string {
+	return dbType
+}
+
+// MatchesQuery returns whether the state with the given key and value satisfies the conditions of the
+// given SQL statement, disregarding its order and its limit. The conditions are evaluated in memory,
+// hence the result does not depend on the indexes of the database
+func MatchesQuery(statement, key string, value []byte) (bool, error) {
+	q, err := parseQuery(statement)
+	if err != nil {
+		return false, err
+	}
+	return q.matches(newDocument(key, value)), nil
+}
+
+// GetFullScanIterator implements method in FullScannable interface
+func (vdb *versionedDB) GetFullScanIterator(skipNamespace func(string) bool) (statedb.ResultsIterator, error) {
+	dbItr := vdb.db.GetIterator([]byte{dataKeyPrefix}, []byte{dataKeyPrefix + 1})
+	return &fullDBScanner{dbItr: dbItr, skipNamespace: skipNamespace}, nil
+}
+
+func constructDataKey(ns string, key string) []byte {
+	dataKey := append([]byte{dataKeyPrefix}, ns...)
+	dataKey = append(dataKey, separator)
+	return append(dataKey, key...)
+}
+
+func splitDataKey(dataKey []byte) (string, string) {
+	split := bytes.SplitN(dataKey[1:], []byte{separator}, 2)
+	return string(split[0]), string(split[1])
+}
+
+// decodePositionBookmark decodes the bookmark of a query whose results are returned in the order of
+// the scan. The bookmark is the position in the scan of the first result of the page
+func decodePositionBookmark(bookmark string, start, end []byte) ([]byte, error) {
+	position, err := base64.RawURLEncoding.DecodeString(bookmark)
+	if err != nil || bytes.Compare(position, start) < 0 || bytes.Compare(position, end) >= 0 {
+		return nil, errors.Errorf("invalid bookmark [%s] for the query", bookmark)
+	}
+	return position, nil
+}
+
+// sourceIterator iterates over the states in the order of a range of the keys of the states or of
+// the entries of an index
+type sourceIterator struct {
+	vdb       *versionedDB
+	namespace string
+	index     *stateindex.Definition
+	dbItr     *leveldbhelper.Iterator
+}
+
+// next returns the next state and its position in the scan. A nil state is returned at the end of the scan
+func (itr *sourceIterator) next() (*statedb.VersionedKV, []byte, error) {
+	for itr.dbItr.Next() {
+		position := append([]byte{}, itr.dbItr.Key()...)
+		if itr.index == nil {
+			_, key := splitDataKey(position)
+			vv, err := decodeValue(append([]byte{}, itr.dbItr.Value()...))
+			if err != nil {
+				return nil, nil, err
+			}
+			return newVersionedKV(itr.namespace, key, vv), position, nil
+		}
+		key := itr.index.KeyFromEntryKey(position[len(itr.vdb.indexes.IndexPrefix(itr.namespace, itr.index.Name)):])
+		vv, err := itr.vdb.GetState(itr.namespace, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		if vv == nil {
+			logger.Warningf("Index [%s] of namespace [%s] has an entry for the missing key [%s]", itr.index.Name, itr.namespace, key)
+			continue
+		}
+		return newVersionedKV(itr.namespace, key, vv), position, nil
+	}
+	return nil, nil, errors.Wrap(itr.dbItr.Error(), "internal leveldb error while scanning the state db")
+}
+
+func (itr *sourceIterator) close() {
+	itr.dbItr.Release()
+}
+
+func newVersionedKV(namespace, key string, vv *statedb.VersionedValue) *statedb.VersionedKV {
+	return &statedb.VersionedKV{
+		CompositeKey:   statedb.CompositeKey{Namespace: namespace, Key: key},
+		VersionedValue: *vv,
+	}
+}
+
+// queryScanner returns the results of a range query or of a query in the order of the scan. The results
+// of a query are filtered as per its conditions
+type queryScanner struct {
+	source *sourceIterator
+	// query is nil for a range query
+	query *query
+	limit int32
+	// paginated is false if the limit is set by the LIMIT clause of the query, in which case no bookmark is returned
+	paginated bool
+	// rangeScan is true for a range query, for which the bookmark is the next key, as in the other state databases
+	rangeScan            bool
+	totalRecordsReturned int32
+}
+
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	if scanner.limit > 0 && scanner.totalRecordsReturned >= scanner.limit {
+		return nil, nil
+	}
+	kv, _, err := scanner.nextMatch()
+	if err != nil || kv == nil {
+		return nil, err
+	}
+	scanner.totalRecordsReturned++
+	return kv, nil
+}
+
+func (scanner *queryScanner) nextMatch() (*statedb.VersionedKV, []byte, error) {
+	for {
+		kv, position, err := scanner.source.next()
+		if err != nil || kv == nil {
+			return nil, nil, err
+		}
+		if scanner.query == nil || scanner.query.matches(newDocument(kv.Key, kv.Value)) {
+			return kv, position, nil
+		}
+	}
+}
+
+func (scanner *queryScanner) Close() {
+	scanner.source.close()
+}
+
+func (scanner *queryScanner) GetBookmarkAndClose() string {
+	defer scanner.Close()
+	if !scanner.rangeScan && !scanner.paginated {
+		return ""
+	}
+	kv, position, err := scanner.nextMatch()
+	if err != nil {
+		logger.Errorf("Error while computing the bookmark: %s", err)
+		return ""
+	}
+	if kv == nil {
+		return ""
+	}
+	if scanner.rangeScan {
+		return kv.Key
+	}
+	return base64.RawURLEncoding.EncodeToString(position)
+}
+
+type fullDBScanner struct {
+	dbItr         *leveldbhelper.Iterator
+	skipNamespace func(string) bool
+}
+
+func (s *fullDBScanner) Next() (statedb.QueryResult, error) {
+	for s.dbItr.Next() {
+		ns, key := splitDataKey(s.dbItr.Key())
+		if s.skipNamespace != nil && s.skipNamespace(ns) {
+			continue
+		}
+		vv, err := decodeValue(append([]byte{}, s.dbItr.Value()...))
+		if err != nil {
+			return nil, err
+		}
+		return newVersionedKV(ns, key, vv), nil
+	}
+	return nil, errors.Wrap(s.dbItr.Error(), "internal leveldb error while scanning the state db")
+}
+
+func (s *fullDBScanner) Close() {
+	s.dbItr.Release()
+}
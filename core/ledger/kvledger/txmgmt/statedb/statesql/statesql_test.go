@@ -0,0 +1,358 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	"archive/tar"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/commontests"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateindex"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/ledgertests/kvledger/txmgmt/statedb/statesql")
+	os.Exit(m.Run())
+}
+
+func TestVersionedDBProviderCertification(t *testing.T) {
+	commontests.TestVersionedDBProvider(t, func(t *testing.T) (statedb.VersionedDBProvider, func()) {
+		env := NewTestVDBEnv(t)
+		return env.DBProvider, env.Cleanup
+	})
+}
+
+func TestRegisteredVersionedDBProvider(t *testing.T) {
+	assert.Contains(t, statedb.RegisteredVersionedDBProviders(), StateDatabaseName)
+	dbProvider, err := statedb.NewVersionedDBProvider(StateDatabaseName, &disabled.Provider{})
+	assert.NoError(t, err)
+	assert.IsType(t, &VersionedDBProvider{}, dbProvider)
+	dbProvider.Close()
+	removeDBPath(t, "TestRegisteredVersionedDBProvider")
+}
+
+func TestUtilityFunctions(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testutilityfunctions")
+	assert.NoError(t, err)
+
+	assert.True(t, db.BytesKeySupported())
+	assert.NoError(t, db.ValidateKeyValue("testKey", []byte("testValue")))
+	assert.Equal(t, "sql", db.(statedb.IndexCapable).GetDBType())
+
+	sameDB, err := env.DBProvider.GetDBHandle("testutilityfunctions")
+	assert.NoError(t, err)
+	assert.True(t, db == sameDB)
+
+	// a corrupted savepoint is reported
+	require.NoError(t, db.(*versionedDB).db.Put(savePointKey, []byte{0x09}, true))
+	_, err = db.GetLatestSavePoint()
+	assert.EqualError(t, err, "error decoding the block number of the height: invalid size [9] of the number in the bytes [09]")
+}
+
+func TestFullScanIterator(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("test-full-scan")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns2", "key2", []byte("value2"), version.NewHeight(1, 2))
+	batch.Put("ns3", "key3", []byte(`{"a":1}`), version.NewHeight(1, 3))
+	batch.Put("ns3", "key4", []byte("value4"), version.NewHeight(1, 4))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 4)))
+	createIndexes(t, db, "ns3", "CREATE INDEX a ON state (a)")
+
+	itr, err := db.(statedb.FullScannable).GetFullScanIterator(
+		func(ns string) bool { return ns == "ns2" },
+	)
+	assert.NoError(t, err)
+	defer itr.Close()
+	var results []*statedb.VersionedKV
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		results = append(results, res.(*statedb.VersionedKV))
+	}
+	// the index entries and the savepoint are not included in the scan
+	assert.Len(t, results, 3)
+	assert.Equal(t, statedb.CompositeKey{Namespace: "ns1", Key: "key1"}, results[0].CompositeKey)
+	assert.Equal(t, statedb.CompositeKey{Namespace: "ns3", Key: "key3"}, results[1].CompositeKey)
+	assert.Equal(t, statedb.CompositeKey{Namespace: "ns3", Key: "key4"}, results[2].CompositeKey)
+	assert.Equal(t, []byte("value4"), results[2].Value)
+	assert.Equal(t, version.NewHeight(1, 4), results[2].Version)
+}
+
+func TestExecuteQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testquery")
+	require.NoError(t, err)
+	populateMarbles(t, db)
+
+	tests := []struct {
+		query        string
+		expectedKeys []string
+	}{
+		{`SELECT * FROM state`, []string{"marble1", "marble2", "marble3", "marble4", "marble5", "text"}},
+		{`SELECT * FROM state WHERE owner = 'tom'`, []string{"marble1", "marble3"}},
+		{`select * from STATE where Owner = 'tom'`, nil},
+		{`SELECT * FROM state WHERE owner = 'tom' AND size > 1`, []string{"marble3"}},
+		{`SELECT * FROM state WHERE owner <> 'tom'`, []string{"marble2", "marble4"}},
+		{`SELECT * FROM state WHERE size >= 2 AND size <= 3`, []string{"marble2", "marble3"}},
+		{`SELECT * FROM state WHERE owner != 'tom' AND color = 'blue';`, []string{"marble4"}},
+		{`SELECT * FROM state WHERE details.weight >= 20.5`, []string{"marble2"}},
+		{`SELECT * FROM state WHERE "details"."weight" < 20`, []string{"marble1"}},
+		{`SELECT * FROM state WHERE sold = TRUE`, []string{"marble3"}},
+		{`SELECT * FROM state WHERE size = -1`, []string{"marble5"}},
+		{`SELECT * FROM state WHERE _key >= 'marble4'`, []string{"marble4", "marble5", "text"}},
+		{`SELECT * FROM state WHERE size = 'one'`, nil},
+		{`SELECT * FROM state LIMIT 2`, []string{"marble1", "marble2"}},
+		{`SELECT * FROM state WHERE size > 0 LIMIT 3`, []string{"marble1", "marble2", "marble3"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			itr, err := db.ExecuteQuery("ns1", tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedKeys, collectKeys(t, itr))
+		})
+	}
+
+	_, err = db.ExecuteQuery("ns1", `SELECT * FROM marbles`)
+	assert.EqualError(t, err, "invalid query: unknown table [marbles] at position 14, the state of the chaincode is in the table [state]")
+	_, err = db.ExecuteQuery("ns1", `SELECT * FROM state WHERE owner =`)
+	assert.EqualError(t, err, "invalid query: expected a literal but reached the end of statement")
+	_, err = db.ExecuteQuery("ns1", `SELECT * FROM state ORDER BY size`)
+	assert.EqualError(t, err, "invalid query: expected end of statement but found [ORDER] at position 20")
+	_, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"tom"}}`)
+	assert.Error(t, err)
+
+	// the queries are confined to the namespace
+	itr, err := db.ExecuteQuery("ns2", `SELECT * FROM state`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"marble1"}, collectKeys(t, itr))
+}
+
+func TestMatchesQuery(t *testing.T) {
+	value := []byte(`{"owner":"tom","color":"blue","size":1}`)
+	tests := []struct {
+		query   string
+		matches bool
+	}{
+		{`SELECT * FROM state`, true},
+		{`SELECT * FROM state WHERE owner = 'tom' AND size < 2`, true},
+		{`SELECT * FROM state WHERE owner = 'jerry'`, false},
+		{`SELECT * FROM state WHERE color != 'blue'`, false},
+		{`SELECT * FROM state WHERE _key = 'marble1'`, true},
+		{`SELECT * FROM state WHERE color = 'blue' LIMIT 1`, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			matches, err := MatchesQuery(tc.query, "marble1", value)
+			require.NoError(t, err)
+			assert.Equal(t, tc.matches, matches)
+		})
+	}
+
+	matches, err := MatchesQuery(`SELECT * FROM state WHERE owner = 'tom'`, "text", []byte("not json"))
+	require.NoError(t, err)
+	assert.False(t, matches)
+	_, err = MatchesQuery(`SELECT * FROM marbles`, "marble1", value)
+	assert.Error(t, err)
+}
+
+func TestIndexes(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexes")
+	require.NoError(t, err)
+	vdb := db.(*versionedDB)
+	populateMarbles(t, db)
+
+	createIndexes(t, db, "ns1",
+		"CREATE INDEX ownerIndex ON state (owner)",
+		"CREATE INDEX ownerColorIndex ON state (owner, color)",
+	)
+	// the entries are ordered by the encoded owner, the non JSON values are not indexed
+	assert.Equal(t, []string{"marble2", "marble4", "marble1", "marble3", "marble5"}, indexedKeys(t, vdb, "ns1", "ownerIndex"))
+	assert.Equal(t, []string{"marble1", "marble3"}, queryKeys(t, db, "ns1", `SELECT * FROM state WHERE owner = 'tom'`))
+	assert.Equal(t, []string{"marble1"}, queryKeys(t, db, "ns1", `SELECT * FROM state WHERE color = 'blue' AND owner = 'tom'`))
+
+	// the indexes are maintained as the states are updated
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "marble1", []byte(`{"owner":"jerry","color":"blue","size":1}`), version.NewHeight(2, 1))
+	batch.Put("ns1", "marble6", []byte(`{"owner":"tom","color":"white","size":6}`), version.NewHeight(2, 2))
+	batch.Delete("ns1", "marble3", version.NewHeight(2, 3))
+	batch.Put("ns1", "marble4", []byte(`not a json`), version.NewHeight(2, 4))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 4)))
+	assert.Equal(t, []string{"marble1", "marble2", "marble6", "marble5"}, indexedKeys(t, vdb, "ns1", "ownerIndex"))
+	assert.Equal(t, []string{"marble6"}, queryKeys(t, db, "ns1", `SELECT * FROM state WHERE owner = 'tom'`))
+	assert.Equal(t, []string{"marble1", "marble2"}, queryKeys(t, db, "ns1", `SELECT * FROM state WHERE owner = 'jerry'`))
+
+	// redeploying the same definitions leaves the indexes as they are
+	createIndexes(t, db, "ns1", "CREATE INDEX ownerIndex ON state (owner)")
+	defs, err := vdb.indexes.Indexes("ns1")
+	require.NoError(t, err)
+	assert.Len(t, defs, 2)
+
+	// a changed definition rebuilds the index
+	createIndexes(t, db, "ns1", "CREATE INDEX ownerIndex ON state (color)")
+	assert.Equal(t, []string{"marble1", "marble5", "marble2", "marble6"}, indexedKeys(t, vdb, "ns1", "ownerIndex"))
+	assert.Equal(t, []string{"marble2"}, queryKeys(t, db, "ns1", `SELECT * FROM state WHERE color = 'red'`))
+
+	// invalid definitions are rejected
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{indexFileEntry("CREATE INDEX bad ON state (_key)")})
+	assert.EqualError(t, err, "error creating index from file [META-INF/statedb/sql/indexes/index0.sql] for namespace [ns1]: "+
+		"invalid index definition: the key column [_key] at position 27 cannot be indexed")
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{
+		indexFileEntry("CREATE INDEX dup ON state (a)"),
+		indexFileEntry("CREATE INDEX dup ON state (b)"),
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "index [dup] is defined in both the files")
+
+	// the index definitions survive the restart of the provider
+	env.DBProvider.Close()
+	env.DBProvider = NewVersionedDBProvider()
+	db, err = env.DBProvider.GetDBHandle("testindexes")
+	require.NoError(t, err)
+	defs, err = db.(*versionedDB).indexes.Indexes("ns1")
+	require.NoError(t, err)
+	assert.Len(t, defs, 2)
+
+	// dropping the db removes the indexes
+	require.NoError(t, env.DBProvider.Drop("testindexes"))
+	db, err = env.DBProvider.GetDBHandle("testindexes")
+	require.NoError(t, err)
+	defs, err = db.(*versionedDB).indexes.Indexes("ns1")
+	require.NoError(t, err)
+	assert.Len(t, defs, 0)
+}
+
+func TestPaginatedQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testpaginatedquery")
+	require.NoError(t, err)
+	populateMarbles(t, db)
+	createIndexes(t, db, "ns1", "CREATE INDEX ownerIndex ON state (owner)")
+
+	keys, bookmark := queryPage(t, db, `SELECT * FROM state WHERE size > 0`, 3, "")
+	assert.Equal(t, []string{"marble1", "marble2", "marble3"}, keys)
+	assert.NotEmpty(t, bookmark)
+	keys, bookmark = queryPage(t, db, `SELECT * FROM state WHERE size > 0`, 3, bookmark)
+	assert.Equal(t, []string{"marble4"}, keys)
+	assert.Empty(t, bookmark)
+
+	keys, bookmark = queryPage(t, db, `SELECT * FROM state WHERE owner = 'tom'`, 1, "")
+	assert.Equal(t, []string{"marble1"}, keys)
+	keys, bookmark = queryPage(t, db, `SELECT * FROM state WHERE owner = 'tom'`, 1, bookmark)
+	assert.Equal(t, []string{"marble3"}, keys)
+	assert.Empty(t, bookmark)
+
+	// a bookmark of a different query is rejected
+	_, bookmark = queryPage(t, db, `SELECT * FROM state WHERE owner = 'tom'`, 1, "")
+	_, err = db.ExecuteQueryWithMetadata("ns1", `SELECT * FROM state WHERE owner = 'jerry'`,
+		map[string]interface{}{"limit": int32(1), "bookmark": bookmark})
+	assert.EqualError(t, err, "invalid bookmark ["+bookmark+"] for the query")
+
+	_, err = db.ExecuteQueryWithMetadata("ns1", `SELECT * FROM state LIMIT 1`, map[string]interface{}{"limit": int32(1)})
+	assert.EqualError(t, err, "the LIMIT clause cannot be used in a query with pagination")
+	_, err = db.ExecuteQueryWithMetadata("ns1", `SELECT * FROM state`, map[string]interface{}{"limit": 1})
+	assert.EqualError(t, err, "invalid entry, \"limit\" must be an int32")
+	_, err = db.ExecuteQueryWithMetadata("ns1", `SELECT * FROM state`, map[string]interface{}{"skip": int32(1)})
+	assert.EqualError(t, err, "invalid entry, option skip not recognized")
+}
+
+func populateMarbles(t *testing.T, db statedb.VersionedDB) {
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "marble1", []byte(`{"owner":"tom","color":"blue","size":1,"details":{"weight":10}}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "marble2", []byte(`{"owner":"jerry","color":"red","size":2,"details":{"weight":20.5}}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "marble3", []byte(`{"owner":"tom","color":"green","size":3,"sold":true,"details":{"weight":null}}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "marble4", []byte(`{"owner":"jerry","color":"blue","size":4,"details":{"weight":"heavy"}}`), version.NewHeight(1, 4))
+	batch.Put("ns1", "marble5", []byte(`{"owner":null,"color":"grey","size":-1}`), version.NewHeight(1, 5))
+	batch.Put("ns1", "text", []byte(`not a json`), version.NewHeight(1, 6))
+	batch.Put("ns2", "marble1", []byte(`{"owner":"tom"}`), version.NewHeight(1, 7))
+	require.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 7)))
+}
+
+func indexFileEntry(definition string) *ccprovider.TarFileEntry {
+	return &ccprovider.TarFileEntry{
+		FileHeader:  &tar.Header{Name: "META-INF/statedb/sql/indexes/index0.sql"},
+		FileContent: []byte(definition),
+	}
+}
+
+func createIndexes(t *testing.T, db statedb.VersionedDB, namespace string, definitions ...string) {
+	var entries []*ccprovider.TarFileEntry
+	for _, definition := range definitions {
+		entries = append(entries, indexFileEntry(definition))
+	}
+	require.NoError(t, db.(statedb.IndexCapable).ProcessIndexesForChaincodeDeploy(namespace, entries))
+}
+
+// indexedKeys returns the keys of the states that have an entry in the given index, in the order of the index
+func indexedKeys(t *testing.T, vdb *versionedDB, namespace, indexName string) []string {
+	def, err := vdb.indexes.Index(namespace, indexName)
+	require.NoError(t, err)
+	prefix := vdb.indexes.IndexPrefix(namespace, indexName)
+	itr := vdb.db.GetIterator(prefix, stateindex.RangeEnd(prefix))
+	defer itr.Release()
+	var keys []string
+	for itr.Next() {
+		keys = append(keys, def.KeyFromEntryKey(itr.Key()[len(prefix):]))
+	}
+	require.NoError(t, itr.Error())
+	return keys
+}
+
+func queryKeys(t *testing.T, db statedb.VersionedDB, namespace, query string) []string {
+	itr, err := db.ExecuteQuery(namespace, query)
+	require.NoError(t, err)
+	return collectKeys(t, itr)
+}
+
+func queryPage(t *testing.T, db statedb.VersionedDB, query string, pageSize int32, bookmark string) ([]string, string) {
+	itr, err := db.ExecuteQueryWithMetadata("ns1", query, map[string]interface{}{"limit": pageSize, "bookmark": bookmark})
+	require.NoError(t, err)
+	var keys []string
+	for {
+		res, err := itr.Next()
+		require.NoError(t, err)
+		if res == nil {
+			break
+		}
+		keys = append(keys, res.(*statedb.VersionedKV).Key)
+	}
+	return keys, itr.GetBookmarkAndClose()
+}
+
+func collectKeys(t *testing.T, itr statedb.ResultsIterator) []string {
+	defer itr.Close()
+	var keys []string
+	for {
+		res, err := itr.Next()
+		require.NoError(t, err)
+		if res == nil {
+			return keys
+		}
+		keys = append(keys, res.(*statedb.VersionedKV).Key)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statesql
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb/msgs"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+// encodeValue encodes the versioned value in the same format as the goleveldb state database
+func encodeValue(v *statedb.VersionedValue) ([]byte, error) {
+	vvMsg := &msgs.VersionedValueProto{
+		VersionBytes: v.Version.ToBytes(),
+		Value:        v.Value,
+		Metadata:     v.Metadata,
+	}
+	return proto.Marshal(vvMsg)
+}
+
+// decodeValue decodes the value encoded by the function `encodeValue`
+func decodeValue(encodedValue []byte) (*statedb.VersionedValue, error) {
+	msg := &msgs.VersionedValueProto{}
+	if err := proto.Unmarshal(encodedValue, msg); err != nil {
+		return nil, err
+	}
+	ver, _, err := version.NewHeightFromBytes(msg.VersionBytes)
+	if err != nil {
+		return nil, err
+	}
+	val := msg.Value
+	// protobuf always makes an empty byte array as nil
+	if val == nil {
+		val = []byte{}
+	}
+	return &statedb.VersionedValue{Version: ver, Value: val, Metadata: msg.Metadata}, nil
+}
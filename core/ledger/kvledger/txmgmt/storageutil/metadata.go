@@ -9,8 +9,13 @@ package storageutil
 import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 )
 
+// BlockToLiveMetadataKey is the name of the metadata entry that carries the block-to-live of a public state key
+var BlockToLiveMetadataKey = peer.MetaDataKeys_BLOCK_TO_LIVE.String()
+
 // SerializeMetadata serializes metadata entries for stroing in statedb
 func SerializeMetadata(metadataEntries []*kvrwset.KVMetadataEntry) ([]byte, error) {
 	metadata := &kvrwset.KVMetadataWrite{Entries: metadataEntries}
@@ -32,3 +37,31 @@ func DeserializeMetadata(metadataBytes []byte) (map[string][]byte, error) {
 	}
 	return m, nil
 }
+
+// EncodeBlockToLive encodes the block-to-live value of a public state key for storing as a metadata entry
+func EncodeBlockToLive(btl uint64) []byte {
+	return proto.EncodeVarint(btl)
+}
+
+// DecodeBlockToLive decodes the block-to-live value from the bytes obtained from function 'EncodeBlockToLive'
+func DecodeBlockToLive(value []byte) (uint64, error) {
+	btl, n := proto.DecodeVarint(value)
+	if n == 0 || n != len(value) {
+		return 0, errors.Errorf("invalid block-to-live value [%x]", value)
+	}
+	return btl, nil
+}
+
+// GetBlockToLive returns the block-to-live recorded in the serialized metadata of a public state key.
+// A return value of zero means that the key never expires
+func GetBlockToLive(metadataBytes []byte) (uint64, error) {
+	metadata, err := DeserializeMetadata(metadataBytes)
+	if err != nil {
+		return 0, err
+	}
+	value, ok := metadata[BlockToLiveMetadataKey]
+	if !ok {
+		return 0, nil
+	}
+	return DecodeBlockToLive(value)
+}
@@ -29,3 +29,27 @@ func TestSerializeDeSerialize(t *testing.T) {
 	assert.Equal(t, []byte("metadata_value_2"), metadataMap["metadata_2"])
 	assert.Equal(t, []byte("metadata_value_3"), metadataMap["metadata_3"])
 }
+
+func TestBlockToLive(t *testing.T) {
+	btl, err := DecodeBlockToLive(EncodeBlockToLive(300))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(300), btl)
+
+	_, err = DecodeBlockToLive(nil)
+	assert.EqualError(t, err, "invalid block-to-live value []")
+	_, err = DecodeBlockToLive([]byte{0xff})
+	assert.EqualError(t, err, "invalid block-to-live value [ff]")
+
+	btl, err = GetBlockToLive(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), btl)
+
+	serializedMetadata, err := SerializeMetadata([]*kvrwset.KVMetadataEntry{
+		{Name: "metadata_1", Value: []byte("metadata_value_1")},
+		{Name: BlockToLiveMetadataKey, Value: EncodeBlockToLive(5)},
+	})
+	assert.NoError(t, err)
+	btl, err = GetBlockToLive(serializedMetadata)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), btl)
+}
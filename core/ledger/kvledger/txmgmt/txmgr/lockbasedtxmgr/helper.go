@@ -27,6 +27,7 @@ type queryHelper struct {
 	collNameValidator *collNameValidator
 	rwsetBuilder      *rwsetutil.RWSetBuilder
 	itrs              []*resultsItr
+	indexItrs         []*indexResultsItr
 	err               error
 	doneInvoked       bool
 }
@@ -106,6 +107,7 @@ func (h *queryHelper) executeQuery(namespace, query string) (commonledger.Result
 	if err != nil {
 		return nil, err
 	}
+	h.addRichQueryInfo(namespace, query)
 	return &queryResultsItr{DBItr: dbItr, RWSetBuilder: h.rwsetBuilder}, nil
 }
 
@@ -117,9 +119,36 @@ func (h *queryHelper) executeQueryWithMetadata(namespace, query string, metadata
 	if err != nil {
 		return nil, err
 	}
+	h.addRichQueryInfo(namespace, query)
 	return &queryResultsItr{DBItr: dbItr, RWSetBuilder: h.rwsetBuilder}, nil
 }
 
+// addRichQueryInfo records the query, if the phantom read protection is enabled, so that the committing peers
+// can detect the states that the preceding transactions of the block add to the results of the query. The results
+// themselves are recorded in the read-set as they are returned. The paginated queries are recorded the same way
+func (h *queryHelper) addRichQueryInfo(namespace, query string) {
+	if h.rwsetBuilder != nil && ledgerconfig.IsRichQueryPhantomProtectionEnabled() {
+		h.rwsetBuilder.AddToRichQuerySet(namespace, &kvrwset.RichQueryInfo{Query: query})
+	}
+}
+
+func (h *queryHelper) getStateByIndex(namespace, indexName string, values []interface{}) (commonledger.ResultsIterator, error) {
+	if err := h.checkDone(); err != nil {
+		return nil, err
+	}
+	db, ok := h.txmgr.db.GetIndexQueryable()
+	if !ok {
+		return nil, errors.New("queries on secondary indexes are not supported by the state database")
+	}
+	itr, err := newIndexResultsItr(namespace, indexName, values, db, h.rwsetBuilder,
+		ledgerconfig.IsQueryReadsHashingEnabled(), ledgerconfig.GetMaxDegreeQueryReadsHashing())
+	if err != nil {
+		return nil, err
+	}
+	h.indexItrs = append(h.indexItrs, itr)
+	return itr, nil
+}
+
 func (h *queryHelper) getPrivateData(ns, coll, key string) ([]byte, error) {
 	if err := h.validateCollName(ns, coll); err != nil {
 		return nil, err
@@ -224,6 +253,34 @@ func (h *queryHelper) executeQueryOnPrivateData(namespace, collection, query str
 	return &pvtdataResultsItr{namespace, collection, dbItr}, nil
 }
 
+func (h *queryHelper) getPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	if err := h.validateCollName(namespace, collection); err != nil {
+		return nil, err
+	}
+	if err := h.checkDone(); err != nil {
+		return nil, err
+	}
+	dbItr, err := h.txmgr.db.GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &pvtdataResultsItr{namespace, collection, dbItr}, nil
+}
+
+func (h *queryHelper) executeQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	if err := h.validateCollName(namespace, collection); err != nil {
+		return nil, err
+	}
+	if err := h.checkDone(); err != nil {
+		return nil, err
+	}
+	dbItr, err := h.txmgr.db.ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &pvtdataResultsItr{namespace, collection, dbItr}, nil
+}
+
 func (h *queryHelper) getStateMetadata(ns string, key string) (map[string][]byte, error) {
 	if err := h.checkDone(); err != nil {
 		return nil, err
@@ -290,6 +347,9 @@ func (h *queryHelper) done() {
 		for _, itr := range h.itrs {
 			itr.Close()
 		}
+		for _, itr := range h.indexItrs {
+			itr.Close()
+		}
 	}()
 }
 
@@ -310,6 +370,22 @@ func (h *queryHelper) addRangeQueryInfo() {
 			h.rwsetBuilder.AddToRangeQuerySet(itr.ns, itr.rangeQueryInfo)
 		}
 	}
+	for _, itr := range h.indexItrs {
+		if h.rwsetBuilder != nil {
+			results, hash, err := itr.resultsHelper.Done()
+			if err != nil {
+				h.err = err
+				return
+			}
+			if results != nil {
+				itr.indexQueryInfo.SetRawReads(results)
+			}
+			if hash != nil {
+				itr.indexQueryInfo.SetMerkelSummary(hash)
+			}
+			h.rwsetBuilder.AddToIndexQuerySet(itr.ns, itr.indexQueryInfo)
+		}
+	}
 }
 
 func (h *queryHelper) checkDone() error {
@@ -422,6 +498,86 @@ func (itr *resultsItr) Close() {
 	itr.dbItr.Close()
 }
 
+// indexResultsItr implements interface ledger.ResultsIterator
+// this wraps the db iterator over the entries of a secondary index and
+// intercepts the calls to build indexQueryInfo in the ReadWriteSet that
+// is used for performing phantom read validation during commit
+type indexResultsItr struct {
+	ns             string
+	endKey         []byte
+	dbItr          statedb.ResultsIterator
+	rwSetBuilder   *rwsetutil.RWSetBuilder
+	indexQueryInfo *kvrwset.IndexQueryInfo
+	resultsHelper  *rwsetutil.RangeQueryResultsHelper
+}
+
+func newIndexResultsItr(ns, indexName string, values []interface{}, db statedb.IndexQueryable,
+	rwsetBuilder *rwsetutil.RWSetBuilder, enableHashing bool, maxDegree uint32) (*indexResultsItr, error) {
+	startKey, endKey, err := db.GetIndexKeyRange(ns, indexName, values)
+	if err != nil {
+		return nil, err
+	}
+	dbItr, err := db.GetIndexRangeScanIterator(ns, indexName, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	itr := &indexResultsItr{ns: ns, dbItr: dbItr}
+	// it's a simulation request so, enable capture of index query info
+	if rwsetBuilder != nil {
+		fields, err := db.GetIndexFields(ns, indexName)
+		if err != nil {
+			dbItr.Close()
+			return nil, err
+		}
+		itr.rwSetBuilder = rwsetBuilder
+		itr.endKey = endKey
+		// until a result is returned, the range is limited to the start key, which is not the key of any entry
+		itr.indexQueryInfo = &kvrwset.IndexQueryInfo{IndexName: indexName, StartKey: startKey, EndKey: startKey, Fields: fields}
+		resultsHelper, err := rwsetutil.NewRangeQueryResultsHelper(enableHashing, maxDegree)
+		if err != nil {
+			dbItr.Close()
+			return nil, err
+		}
+		itr.resultsHelper = resultsHelper
+	}
+	return itr, nil
+}
+
+// Next implements method in interface ledger.ResultsIterator
+// Before returning the next result, update the EndKey and ItrExhausted in indexQueryInfo,
+// as the resultsItr does for a range query
+func (itr *indexResultsItr) Next() (commonledger.QueryResult, error) {
+	queryResult, err := itr.dbItr.Next()
+	if err != nil {
+		return nil, err
+	}
+	itr.updateIndexQueryInfo(queryResult)
+	if queryResult == nil {
+		return nil, nil
+	}
+	indexedKV := queryResult.(*statedb.IndexedKV)
+	return &queryresult.KV{Namespace: indexedKV.Namespace, Key: indexedKV.Key, Value: indexedKV.Value}, nil
+}
+
+func (itr *indexResultsItr) updateIndexQueryInfo(queryResult statedb.QueryResult) {
+	if itr.rwSetBuilder == nil {
+		return
+	}
+	if queryResult == nil {
+		itr.indexQueryInfo.ItrExhausted = true
+		itr.indexQueryInfo.EndKey = itr.endKey
+		return
+	}
+	indexedKV := queryResult.(*statedb.IndexedKV)
+	itr.resultsHelper.AddResult(rwsetutil.NewKVRead(indexedKV.Key, indexedKV.Version))
+	itr.indexQueryInfo.EndKey = indexedKV.IndexKey
+}
+
+// Close implements method in interface ledger.ResultsIterator
+func (itr *indexResultsItr) Close() {
+	itr.dbItr.Close()
+}
+
 type queryResultsItr struct {
 	DBItr        statedb.ResultsIterator
 	RWSetBuilder *rwsetutil.RWSetBuilder
@@ -499,3 +655,12 @@ func (itr *pvtdataResultsItr) Next() (commonledger.QueryResult, error) {
 func (itr *pvtdataResultsItr) Close() {
 	itr.dbItr.Close()
 }
+
+// GetBookmarkAndClose implements method in interface ledger.QueryResultsIterator
+func (itr *pvtdataResultsItr) GetBookmarkAndClose() string {
+	returnBookmark := ""
+	if queryResultIterator, ok := itr.dbItr.(statedb.QueryResultsIterator); ok {
+		returnBookmark = queryResultIterator.GetBookmarkAndClose()
+	}
+	return returnBookmark
+}
@@ -65,6 +65,11 @@ func (q *lockBasedQueryExecutor) ExecuteQueryWithMetadata(namespace, query strin
 	return q.helper.executeQueryWithMetadata(namespace, query, metadata)
 }
 
+// GetStateByIndex implements method in interface `ledger.QueryExecutor`
+func (q *lockBasedQueryExecutor) GetStateByIndex(namespace, indexName string, values []interface{}) (commonledger.ResultsIterator, error) {
+	return q.helper.getStateByIndex(namespace, indexName, values)
+}
+
 // GetPrivateData implements method in interface `ledger.QueryExecutor`
 func (q *lockBasedQueryExecutor) GetPrivateData(namespace, collection, key string) ([]byte, error) {
 	return q.helper.getPrivateData(namespace, collection, key)
@@ -95,11 +100,21 @@ func (q *lockBasedQueryExecutor) GetPrivateDataRangeScanIterator(namespace, coll
 	return q.helper.getPrivateDataRangeScanIterator(namespace, collection, startKey, endKey)
 }
 
+// GetPrivateDataRangeScanIteratorWithMetadata implements method in interface `ledger.QueryExecutor`
+func (q *lockBasedQueryExecutor) GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	return q.helper.getPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey, metadata)
+}
+
 // ExecuteQueryOnPrivateData implements method in interface `ledger.QueryExecutor`
 func (q *lockBasedQueryExecutor) ExecuteQueryOnPrivateData(namespace, collection, query string) (commonledger.ResultsIterator, error) {
 	return q.helper.executeQueryOnPrivateData(namespace, collection, query)
 }
 
+// ExecuteQueryOnPrivateDataWithMetadata implements method in interface `ledger.QueryExecutor`
+func (q *lockBasedQueryExecutor) ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	return q.helper.executeQueryOnPrivateDataWithMetadata(namespace, collection, query, metadata)
+}
+
 // Done implements method in interface `ledger.QueryExecutor`
 func (q *lockBasedQueryExecutor) Done() {
 	logger.Debugf("Done with transaction simulation / query execution [%s]", q.txid)
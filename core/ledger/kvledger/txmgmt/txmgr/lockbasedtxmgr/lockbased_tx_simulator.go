@@ -131,6 +131,28 @@ func (s *lockBasedTxSimulator) ExecuteQueryOnPrivateData(namespace, collection,
 	return s.lockBasedQueryExecutor.ExecuteQueryOnPrivateData(namespace, collection, query)
 }
 
+// GetPrivateDataRangeScanIteratorWithMetadata implements method in interface `ledger.QueryExecutor`
+func (s *lockBasedTxSimulator) GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	if err := s.checkBeforePvtdataQueries(); err != nil {
+		return nil, err
+	}
+	if err := s.checkBeforePaginatedQueries(); err != nil {
+		return nil, err
+	}
+	return s.lockBasedQueryExecutor.GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey, metadata)
+}
+
+// ExecuteQueryOnPrivateDataWithMetadata implements method in interface `ledger.QueryExecutor`
+func (s *lockBasedTxSimulator) ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	if err := s.checkBeforePvtdataQueries(); err != nil {
+		return nil, err
+	}
+	if err := s.checkBeforePaginatedQueries(); err != nil {
+		return nil, err
+	}
+	return s.lockBasedQueryExecutor.ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query, metadata)
+}
+
 // GetStateRangeScanIteratorWithMetadata implements method in interface `ledger.QueryExecutor`
 func (s *lockBasedTxSimulator) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 	if err := s.checkBeforePaginatedQueries(); err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/pubstatepurgemgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/pvtstatepurgemgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/queryutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
@@ -32,21 +33,25 @@ var logger = flogging.MustGetLogger("lockbasedtxmgr")
 // LockBasedTxMgr a simple implementation of interface `txmgmt.TxMgr`.
 // This implementation uses a read-write lock to prevent conflicts between transaction simulation and committing
 type LockBasedTxMgr struct {
-	ledgerid        string
-	db              privacyenabledstate.DB
-	pvtdataPurgeMgr *pvtdataPurgeMgr
-	validator       validator.Validator
-	stateListeners  []ledger.StateListener
-	ccInfoProvider  ledger.DeployedChaincodeInfoProvider
-	commitRWLock    sync.RWMutex
-	oldBlockCommit  sync.Mutex
-	current         *current
+	ledgerid              string
+	db                    privacyenabledstate.DB
+	pvtdataPurgeMgr       *pvtdataPurgeMgr
+	pubstatePurgeMgr      *pubstatePurgeMgr
+	validator             validator.Validator
+	stateListeners        []ledger.StateListener
+	ccInfoProvider        ledger.DeployedChaincodeInfoProvider
+	capabilitiesRetriever txmgr.CapabilitiesRetriever
+	commitRWLock          sync.RWMutex
+	oldBlockCommit        sync.Mutex
+	current               *current
+	purgedKeys            []*txmgr.PurgedKey
 }
 
 type current struct {
-	block     *common.Block
-	batch     *privacyenabledstate.UpdateBatch
-	listeners []ledger.StateListener
+	block       *common.Block
+	batch       *privacyenabledstate.UpdateBatch
+	listeners   []ledger.StateListener
+	stateExpiry bool
 }
 
 func (c *current) blockNum() uint64 {
@@ -59,20 +64,27 @@ func (c *current) maxTxNumber() uint64 {
 
 // NewLockBasedTxMgr constructs a new instance of NewLockBasedTxMgr
 func NewLockBasedTxMgr(ledgerid string, db privacyenabledstate.DB, stateListeners []ledger.StateListener,
-	btlPolicy pvtdatapolicy.BTLPolicy, bookkeepingProvider bookkeeping.Provider, ccInfoProvider ledger.DeployedChaincodeInfoProvider) (*LockBasedTxMgr, error) {
+	btlPolicy pvtdatapolicy.BTLPolicy, bookkeepingProvider bookkeeping.Provider, ccInfoProvider ledger.DeployedChaincodeInfoProvider,
+	capabilitiesRetriever txmgr.CapabilitiesRetriever) (*LockBasedTxMgr, error) {
 	db.Open()
 	txmgr := &LockBasedTxMgr{
-		ledgerid:       ledgerid,
-		db:             db,
-		stateListeners: stateListeners,
-		ccInfoProvider: ccInfoProvider,
+		ledgerid:              ledgerid,
+		db:                    db,
+		stateListeners:        stateListeners,
+		ccInfoProvider:        ccInfoProvider,
+		capabilitiesRetriever: capabilitiesRetriever,
 	}
 	pvtstatePurgeMgr, err := pvtstatepurgemgmt.InstantiatePurgeMgr(ledgerid, db, btlPolicy, bookkeepingProvider)
 	if err != nil {
 		return nil, err
 	}
 	txmgr.pvtdataPurgeMgr = &pvtdataPurgeMgr{pvtstatePurgeMgr, false}
-	txmgr.validator = valimpl.NewStatebasedValidator(txmgr, db)
+	pubPurgeMgr, err := pubstatepurgemgmt.InstantiatePurgeMgr(ledgerid, db, bookkeepingProvider)
+	if err != nil {
+		return nil, err
+	}
+	txmgr.pubstatePurgeMgr = &pubstatePurgeMgr{pubPurgeMgr, false}
+	txmgr.validator = valimpl.NewStatebasedValidator(txmgr, db, capabilitiesRetriever)
 	return txmgr, nil
 }
 
@@ -127,7 +139,12 @@ func (txmgr *LockBasedTxMgr) ValidateAndPrepare(blockAndPvtdata *ledger.BlockAnd
 		txmgr.reset()
 		return nil, err
 	}
-	txmgr.current = &current{block: block, batch: batch}
+	stateExpiry, err := txmgr.isStateExpiryEnabled(block)
+	if err != nil {
+		txmgr.reset()
+		return nil, err
+	}
+	txmgr.current = &current{block: block, batch: batch, stateExpiry: stateExpiry}
 	if err := txmgr.invokeNamespaceListeners(); err != nil {
 		txmgr.reset()
 		return nil, err
@@ -435,6 +452,7 @@ func (txmgr *LockBasedTxMgr) Shutdown() {
 	// wait for background go routine to finish else the timing issue causes a nil pointer inside goleveldb code
 	// see FAB-11974
 	txmgr.pvtdataPurgeMgr.WaitForPrepareToFinish()
+	txmgr.pubstatePurgeMgr.WaitForPrepareToFinish()
 	txmgr.db.Close()
 }
 
@@ -458,8 +476,13 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 		txmgr.pvtdataPurgeMgr.PrepareForExpiringKeys(txmgr.current.blockNum())
 		txmgr.pvtdataPurgeMgr.usedOnce = true
 	}
+	if !txmgr.pubstatePurgeMgr.usedOnce {
+		txmgr.pubstatePurgeMgr.PrepareForExpiringKeys(txmgr.current.blockNum())
+		txmgr.pubstatePurgeMgr.usedOnce = true
+	}
 	defer func() {
 		txmgr.pvtdataPurgeMgr.PrepareForExpiringKeys(txmgr.current.blockNum() + 1)
+		txmgr.pubstatePurgeMgr.PrepareForExpiringKeys(txmgr.current.blockNum() + 1)
 		logger.Debugf("launched the background routine for preparing keys to purge with the next block")
 		txmgr.reset()
 	}()
@@ -474,6 +497,17 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 		return err
 	}
 
+	// the public state keys are scheduled for expiry and purged only once the capability is enabled on the channel,
+	// so that all the peers purge the same keys with the same block
+	txmgr.purgedKeys = nil
+	if txmgr.current.stateExpiry {
+		purgedKeys, err := txmgr.pubstatePurgeMgr.DeleteExpiredAndUpdateBookkeeping(txmgr.current.batch.PubUpdates)
+		if err != nil {
+			return err
+		}
+		txmgr.purgedKeys = purgedKeys
+	}
+
 	commitHeight := version.NewHeight(txmgr.current.blockNum(), txmgr.current.maxTxNumber())
 	txmgr.commitRWLock.Lock()
 	logger.Debugf("Write lock acquired for committing updates to state database")
@@ -492,12 +526,34 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 	if err := txmgr.pvtdataPurgeMgr.BlockCommitDone(); err != nil {
 		return err
 	}
+	if txmgr.current.stateExpiry {
+		if err := txmgr.pubstatePurgeMgr.BlockCommitDone(); err != nil {
+			return err
+		}
+	}
 	// In the case of error state listeners will not recieve this call - instead a peer panic is caused by the ledger upon receiveing
 	// an error from this function
 	txmgr.updateStateListeners()
 	return nil
 }
 
+// PurgedKeys implements method in interface `txmgmt.TxMgr`
+func (txmgr *LockBasedTxMgr) PurgedKeys() []*txmgr.PurgedKey {
+	return txmgr.purgedKeys
+}
+
+// isStateExpiryEnabled returns true if the block-to-live of the public state keys is enabled for the block
+func (txmgr *LockBasedTxMgr) isStateExpiryEnabled(block *common.Block) (bool, error) {
+	if txmgr.capabilitiesRetriever == nil {
+		return false, nil
+	}
+	capabilities, err := txmgr.capabilitiesRetriever.ApplicationCapabilities(block)
+	if err != nil {
+		return false, err
+	}
+	return capabilities.StateExpiry(), nil
+}
+
 // Rollback implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Rollback() {
 	txmgr.reset()
@@ -571,3 +627,10 @@ type pvtdataPurgeMgr struct {
 	pvtstatepurgemgmt.PurgeMgr
 	usedOnce bool
 }
+
+// pubstatePurgeMgr wraps the purge manager of the public state keys that are written with a block-to-live
+// and an additional flag 'usedOnce', in the same manner as the 'pvtdataPurgeMgr'
+type pubstatePurgeMgr struct {
+	pubstatepurgemgmt.PurgeMgr
+	usedOnce bool
+}
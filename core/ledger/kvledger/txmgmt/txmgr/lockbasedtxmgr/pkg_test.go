@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
@@ -89,11 +90,29 @@ func (env *lockBasedEnv) init(t *testing.T, testLedgerID string, btlPolicy pvtda
 	env.txmgr, err = NewLockBasedTxMgr(
 		testLedgerID, env.testDB, nil,
 		btlPolicy, env.testBookkeepingEnv.TestProvider,
-		&mock.DeployedChaincodeInfoProvider{})
+		&mock.DeployedChaincodeInfoProvider{}, &testCapabilitiesRetriever{})
 	assert.NoError(t, err)
 
 }
 
+// testCapabilitiesRetriever enables, for all the blocks, the application capabilities that are relevant to the state
+type testCapabilitiesRetriever struct{}
+
+func (r *testCapabilitiesRetriever) ApplicationCapabilities(block *common.Block) (txmgr.ApplicationCapabilities, error) {
+	return capabilities.NewApplicationProvider(map[string]*common.Capability{
+		capabilities.ApplicationIndexPhantomProtection:     {},
+		capabilities.ApplicationRichQueryPhantomProtection: {},
+		capabilities.ApplicationStateExpiry:                {},
+	}), nil
+}
+
+// noCapabilitiesRetriever enables no application capability
+type noCapabilitiesRetriever struct{}
+
+func (r *noCapabilitiesRetriever) ApplicationCapabilities(block *common.Block) (txmgr.ApplicationCapabilities, error) {
+	return capabilities.NewApplicationProvider(nil), nil
+}
+
 func (env *lockBasedEnv) getTxMgr() txmgr.TxMgr {
 	return env.txmgr
 }
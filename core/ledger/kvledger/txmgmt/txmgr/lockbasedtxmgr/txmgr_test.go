@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package lockbasedtxmgr
 
 import (
+	"archive/tar"
 	"bytes"
 	"encoding/gob"
 	"encoding/json"
@@ -14,16 +15,23 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/storageutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/mock"
 	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -734,6 +742,192 @@ func testExecuteQuery(t *testing.T, env testEnv) {
 	assert.Equal(t, 3, counter)
 }
 
+func TestGetStateByIndex(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		// Queries on secondary indexes are only supported by the LevelDB testEnv
+		if testEnv.getName() == levelDBtestEnvName {
+			t.Logf("Running test for TestEnv = %s", testEnv.getName())
+			testLedgerID := "testgetstatebyindex"
+			testEnv.init(t, testLedgerID, nil)
+			testGetStateByIndex(t, testEnv)
+			testEnv.cleanup()
+		}
+	}
+}
+
+func testGetStateByIndex(t *testing.T, env testEnv) {
+	txMgr := env.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	s1.SetState("ns1", "key1", []byte(`{"asset_name":"marble1","color":"red","owner":"jerry"}`))
+	s1.SetState("ns1", "key2", []byte(`{"asset_name":"marble2","color":"blue","owner":"bob"}`))
+	s1.SetState("ns1", "key3", []byte(`{"asset_name":"marble3","color":"blue","owner":"jerry"}`))
+	s1.SetState("ns1", "key4", []byte(`{"asset_name":"marble4","color":"green","owner":"bob"}`))
+	s1.Done()
+	txRWSet1, _ := s1.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet1.PubSimulationResults)
+
+	indexCapable := env.getVDB().(*privacyenabledstate.CommonStorageDB).VersionedDB.(statedb.IndexCapable)
+	assert.NoError(t, indexCapable.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{{
+		FileHeader:  &tar.Header{Name: "META-INF/statedb/leveldb/indexes/ownerIndex.json"},
+		FileContent: []byte(`{"name":"ownerIndex","fields":["owner"]}`),
+	}}))
+
+	queryOwner := func(sim ledger.QueryExecutor, owner string) []string {
+		itr, err := sim.GetStateByIndex("ns1", "ownerIndex", []interface{}{owner})
+		assert.NoError(t, err)
+		defer itr.Close()
+		var keys []string
+		for {
+			kv, err := itr.Next()
+			assert.NoError(t, err)
+			if kv == nil {
+				return keys
+			}
+			keys = append(keys, kv.(*queryresult.KV).Key)
+		}
+	}
+
+	// simulate tx2 and tx3 that query the assets of bob and of jerry
+	s2, _ := txMgr.NewTxSimulator("test_tx2")
+	assert.Equal(t, []string{"key2", "key4"}, queryOwner(s2, "bob"))
+	s2.Done()
+	s3, _ := txMgr.NewTxSimulator("test_tx3")
+	assert.Equal(t, []string{"key1", "key3"}, queryOwner(s3, "jerry"))
+	s3.Done()
+
+	s4, _ := txMgr.NewTxSimulator("test_tx4")
+	_, err := s4.GetStateByIndex("ns1", "missingIndex", nil)
+	assert.EqualError(t, err, "index [missingIndex] not found in namespace [ns1]")
+	s4.Done()
+
+	// the query info is recorded in the read set
+	txRWSet2, _ := s2.GetTxSimulationResults()
+	txRWSet3, _ := s3.GetTxSimulationResults()
+	pubSimulationBytes2, err := txRWSet2.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	rwSet2 := &rwsetutil.TxRwSet{}
+	assert.NoError(t, rwSet2.FromProtoBytes(pubSimulationBytes2))
+	indexQueriesInfo := rwSet2.NsRwSets[0].KvRwSet.IndexQueriesInfo
+	assert.Len(t, indexQueriesInfo, 1)
+	assert.Equal(t, "ownerIndex", indexQueriesInfo[0].IndexName)
+	assert.True(t, indexQueriesInfo[0].ItrExhausted)
+	assert.Len(t, indexQueriesInfo[0].GetRawReads().GetKvReads(), 2)
+
+	// simulate tx5 before committing tx2 and tx3, which gives an asset to bob
+	s5, _ := txMgr.NewTxSimulator("test_tx5")
+	s5.SetState("ns1", "key5", []byte(`{"asset_name":"marble5","color":"red","owner":"bob"}`))
+	s5.Done()
+	txRWSet5, _ := s5.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet5.PubSimulationResults)
+
+	// tx2 should be invalid as a phantom asset of bob appeared, tx3 should still be valid
+	txMgrHelper.checkRWsetInvalid(txRWSet2.PubSimulationResults)
+	txMgrHelper.validateAndCommitRWSet(txRWSet3.PubSimulationResults)
+}
+
+// TestRichQueryPhantomProtection is tested on the embedded sql state database, the only one other than CouchDB that executes rich queries
+func TestRichQueryPhantomProtection(t *testing.T) {
+	viper.Set("ledger.state.richQueryPhantomProtection", true)
+	defer viper.Set("ledger.state.richQueryPhantomProtection", false)
+	env := &lockBasedEnv{name: "sql_LockBasedTxMgr", testDBEnv: &privacyenabledstate.SQLCommonStorageTestEnv{}}
+	env.init(t, "testrichqueryphantomprotection", nil)
+	defer env.cleanup()
+	txMgr := env.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	s1.SetState("ns1", "key1", []byte(`{"asset_name":"marble1","owner":"jerry"}`))
+	s1.SetState("ns1", "key2", []byte(`{"asset_name":"marble2","owner":"bob"}`))
+	s1.SetState("ns1", "key3", []byte(`{"asset_name":"marble3","owner":"jerry"}`))
+	s1.Done()
+	txRWSet1, _ := s1.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet1.PubSimulationResults)
+
+	queryOwner := func(sim ledger.QueryExecutor, owner string) []string {
+		itr, err := sim.ExecuteQuery("ns1", fmt.Sprintf("SELECT * FROM state WHERE owner = '%s'", owner))
+		assert.NoError(t, err)
+		defer itr.Close()
+		var keys []string
+		for {
+			kv, err := itr.Next()
+			assert.NoError(t, err)
+			if kv == nil {
+				return keys
+			}
+			keys = append(keys, kv.(*queryresult.KV).Key)
+		}
+	}
+
+	// simulate tx2 and tx3 that query the assets of bob and of jerry
+	s2, _ := txMgr.NewTxSimulator("test_tx2")
+	assert.Equal(t, []string{"key2"}, queryOwner(s2, "bob"))
+	s2.Done()
+	s3, _ := txMgr.NewTxSimulator("test_tx3")
+	assert.Equal(t, []string{"key1", "key3"}, queryOwner(s3, "jerry"))
+	s3.Done()
+
+	// the query info is recorded in the read set
+	txRWSet2, _ := s2.GetTxSimulationResults()
+	txRWSet3, _ := s3.GetTxSimulationResults()
+	pubSimulationBytes3, err := txRWSet3.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	rwSet3 := &rwsetutil.TxRwSet{}
+	assert.NoError(t, rwSet3.FromProtoBytes(pubSimulationBytes3))
+	richQueriesInfo := rwSet3.NsRwSets[0].KvRwSet.RichQueriesInfo
+	assert.Len(t, richQueriesInfo, 1)
+	assert.Equal(t, "SELECT * FROM state WHERE owner = 'jerry'", richQueriesInfo[0].Query)
+	assert.Len(t, rwSet3.NsRwSets[0].KvRwSet.Reads, 2)
+
+	// simulate tx4, which gives an asset to bob, and commit it in the same block as, and before, tx2 and tx3
+	s4, _ := txMgr.NewTxSimulator("test_tx4")
+	s4.SetState("ns1", "key4", []byte(`{"asset_name":"marble4","owner":"bob"}`))
+	s4.Done()
+	txRWSet4, _ := s4.GetTxSimulationResults()
+	var txs [][]byte
+	for _, txRWSet := range []*ledger.TxSimulationResults{txRWSet4, txRWSet2, txRWSet3} {
+		rwSetBytes, err := proto.Marshal(txRWSet.PubSimulationResults)
+		assert.NoError(t, err)
+		txs = append(txs, rwSetBytes)
+	}
+	block := txMgrHelper.bg.NextBlock(txs)
+	_, err = txMgr.ValidateAndPrepare(&ledger.BlockAndPvtData{Block: block}, true)
+	assert.NoError(t, err)
+	assert.NoError(t, txMgr.Commit())
+
+	// tx2 should be invalid as a phantom asset of bob appeared, tx3 should still be valid
+	txsFltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.False(t, txsFltr.IsInvalid(0))
+	assert.True(t, txsFltr.IsInvalid(1))
+	assert.False(t, txsFltr.IsInvalid(2))
+
+	// the paginated queries are recorded as well
+	s5, _ := txMgr.NewTxSimulator("test_tx5")
+	itr, err := s5.ExecuteQueryWithMetadata("ns1", "SELECT * FROM state WHERE owner = 'bob'", map[string]interface{}{"limit": int32(1)})
+	assert.NoError(t, err)
+	itr.Close()
+	s5.Done()
+	txRWSet5, _ := s5.GetTxSimulationResults()
+	pubSimulationBytes5, err := txRWSet5.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	rwSet5 := &rwsetutil.TxRwSet{}
+	assert.NoError(t, rwSet5.FromProtoBytes(pubSimulationBytes5))
+	assert.Len(t, rwSet5.NsRwSets[0].KvRwSet.RichQueriesInfo, 1)
+
+	// the queries are not recorded when the protection is disabled
+	viper.Set("ledger.state.richQueryPhantomProtection", false)
+	s6, _ := txMgr.NewTxSimulator("test_tx6")
+	assert.Equal(t, []string{"key2", "key4"}, queryOwner(s6, "bob"))
+	s6.Done()
+	txRWSet6, _ := s6.GetTxSimulationResults()
+	pubSimulationBytes6, err := txRWSet6.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	rwSet6 := &rwsetutil.TxRwSet{}
+	assert.NoError(t, rwSet6.FromProtoBytes(pubSimulationBytes6))
+	assert.Empty(t, rwSet6.NsRwSets[0].KvRwSet.RichQueriesInfo)
+}
+
 // TestExecutePaginatedQuery is only tested on the CouchDB testEnv
 func TestExecutePaginatedQuery(t *testing.T) {
 
@@ -897,6 +1091,20 @@ func TestTxSimulatorUnsupportedTx(t *testing.T) {
 	_, ok = err.(*txmgr.ErrUnsupportedTransaction)
 	assert.True(t, ok)
 
+	simulator, _ = txMgr.NewTxSimulator("txid5")
+	err = simulator.SetState("ns", "key", []byte("value"))
+	assert.NoError(t, err)
+	_, err = simulator.GetPrivateDataRangeScanIteratorWithMetadata("ns1", "coll1", "startKey", "endKey", queryOptions)
+	_, ok = err.(*txmgr.ErrUnsupportedTransaction)
+	assert.True(t, ok)
+
+	simulator, _ = txMgr.NewTxSimulator("txid6")
+	_, err = simulator.GetPrivateDataRangeScanIteratorWithMetadata("ns1", "coll1", "startKey", "endKey", queryOptions)
+	assert.NoError(t, err)
+	err = simulator.SetState("ns", "key", []byte("value"))
+	_, ok = err.(*txmgr.ErrUnsupportedTransaction)
+	assert.True(t, ok)
+
 }
 
 // TestTxSimulatorQueryUnsupportedTx is only tested on the CouchDB testEnv
@@ -1432,6 +1640,85 @@ func TestTxSimulatorMissingPvtdataExpiry(t *testing.T) {
 	assert.True(t, testPvtValueEqual(t, txMgr, "ns", "coll", "pvtkey1", nil))
 }
 
+func TestTxWithPubStateExpiry(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		t.Run(testEnv.getName(), func(t *testing.T) {
+			testEnv.init(t, "testtxwithpubstateexpiry", nil)
+			defer testEnv.cleanup()
+			testTxWithPubStateExpiry(t, testEnv)
+		})
+	}
+}
+
+func testTxWithPubStateExpiry(t *testing.T, env testEnv) {
+	txMgr := env.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+	btlMetadata := map[string][]byte{storageutil.BlockToLiveMetadataKey: storageutil.EncodeBlockToLive(2)}
+
+	// key1 expires with the third block after the block that commits it, whereas key2 never expires
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	s1.SetState("ns", "key1", []byte("value1"))
+	s1.SetStateMetadata("ns", "key1", btlMetadata)
+	s1.SetState("ns", "key2", []byte("value2"))
+	s1.Done()
+	txRWSet1, _ := s1.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet1.PubSimulationResults)
+
+	for i := 0; i < 3; i++ {
+		qe, _ := txMgr.NewQueryExecutor("test_query")
+		checkTestQueryResults(t, qe, "ns", "key1", []byte("value1"), btlMetadata)
+		qe.Done()
+		assert.Empty(t, txMgr.PurgedKeys())
+
+		s, _ := txMgr.NewTxSimulator(fmt.Sprintf("test_tx_empty_%d", i))
+		s.Done()
+		txRWSet, _ := s.GetTxSimulationResults()
+		txMgrHelper.validateAndCommitRWSet(txRWSet.PubSimulationResults)
+	}
+	assert.Equal(t, []*txmgr.PurgedKey{{Namespace: "ns", Key: "key1"}}, txMgr.PurgedKeys())
+
+	qe, _ := txMgr.NewQueryExecutor("test_query")
+	defer qe.Done()
+	checkTestQueryResults(t, qe, "ns", "key1", nil, nil)
+	checkTestQueryResults(t, qe, "ns", "key2", []byte("value2"), nil)
+}
+
+func TestTxWithPubStateExpiryNotEnabled(t *testing.T) {
+	testDBEnv := &privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	bookkeepingEnv := bookkeeping.NewTestEnv(t)
+	defer bookkeepingEnv.Cleanup()
+
+	// the capabilities of the channel do not enable the block-to-live of the public state keys
+	txMgr, err := NewLockBasedTxMgr("testtxwithpubstateexpirynotenabled", testDBEnv.GetDBHandle("testtxwithpubstateexpirynotenabled"),
+		nil, nil, bookkeepingEnv.TestProvider, &mock.DeployedChaincodeInfoProvider{}, &noCapabilitiesRetriever{})
+	assert.NoError(t, err)
+	defer txMgr.Shutdown()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+	btlMetadata := map[string][]byte{storageutil.BlockToLiveMetadataKey: storageutil.EncodeBlockToLive(1)}
+
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	s1.SetState("ns", "key1", []byte("value1"))
+	s1.SetStateMetadata("ns", "key1", btlMetadata)
+	s1.Done()
+	txRWSet1, _ := s1.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet1.PubSimulationResults)
+
+	for i := 0; i < 3; i++ {
+		s, _ := txMgr.NewTxSimulator(fmt.Sprintf("test_tx_empty_%d", i))
+		s.Done()
+		txRWSet, _ := s.GetTxSimulationResults()
+		txMgrHelper.validateAndCommitRWSet(txRWSet.PubSimulationResults)
+		assert.Empty(t, txMgr.PurgedKeys())
+	}
+
+	// the key is never purged
+	qe, _ := txMgr.NewQueryExecutor("test_query")
+	defer qe.Done()
+	checkTestQueryResults(t, qe, "ns", "key1", []byte("value1"), btlMetadata)
+}
+
 func TestTxWithPubMetadata(t *testing.T) {
 	for _, testEnv := range testEnvs {
 		t.Logf("Running test for TestEnv = %s", testEnv.getName())
@@ -33,10 +33,36 @@ type TxMgr interface {
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
 	CommitLostBlock(blockAndPvtdata *ledger.BlockAndPvtData) error
 	Commit() error
+	// PurgedKeys returns the public state keys that were purged by the last call to Commit, as their block-to-live elapsed
+	PurgedKeys() []*PurgedKey
 	Rollback()
 	Shutdown()
 }
 
+// CapabilitiesRetriever retrieves the application capabilities of the channel that are in effect for a block.
+// The capabilities decide how the transactions of the block are validated and committed and hence, they must
+// be derived from the config of the channel as of the block, so that all the peers, including the ones that
+// replay the block during the recovery of their state db, agree on them
+type CapabilitiesRetriever interface {
+	ApplicationCapabilities(block *common.Block) (ApplicationCapabilities, error)
+}
+
+// ApplicationCapabilities lists the application capabilities of a channel that are relevant to the state
+type ApplicationCapabilities interface {
+	// IndexPhantomProtection returns true if the phantom reads of the queries on the secondary indexes are validated
+	IndexPhantomProtection() bool
+	// RichQueryPhantomProtection returns true if the phantom reads of the rich queries are validated
+	RichQueryPhantomProtection() bool
+	// StateExpiry returns true if the public state keys with a block-to-live are purged
+	StateExpiry() bool
+}
+
+// PurgedKey identifies a public state key that is purged with the commit of a block, as its block-to-live elapsed
+type PurgedKey struct {
+	Namespace string
+	Key       string
+}
+
 // TxStatInfo encapsulates information about a transaction
 type TxStatInfo struct {
 	ValidationCode peer.TxValidationCode
@@ -10,6 +10,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/protos/peer"
 )
@@ -27,6 +28,9 @@ type Validator interface {
 type Block struct {
 	Num uint64
 	Txs []*Transaction
+	// Capabilities are the application capabilities of the channel in effect for the block.
+	// If nil, none of the capabilities is enabled
+	Capabilities txmgr.ApplicationCapabilities
 }
 
 // Transaction is used to hold the information from its proto format to a structure
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// indexScanIterator implements the interface statedb.ResultsIterator.
+// It iterates, in the order of the keys of the index entries, over the entries of a secondary index in a range
+// as they would be if the updates of the preceding valid transactions in the block were applied to the db.
+// Internally, it merges (1) the entries in the range of the index maintained by the db, skipping the entries of
+// the states that are updated in the batch, and (2) the entries derived from the updated states in the batch.
+// As the indexes are defined in the chaincode package and are maintained along with the states on every peer,
+// the results are the same on all the peers, while only the entries in the range are read.
+//
+// This is used to perform the validation of phantom reads for the queries on the secondary indexes
+type indexScanIterator struct {
+	ns             string
+	updates        *statedb.UpdateBatch
+	dbItr          statedb.ResultsIterator
+	dbItem         *statedb.IndexedKV
+	updatesEntries []*statedb.IndexedKV
+}
+
+func newIndexScanIterator(db statedb.IndexQueryable, updates *statedb.UpdateBatch,
+	ns, indexName string, fields []string, startKey, endKey []byte) (*indexScanIterator, error) {
+	paths := statedb.IndexFieldPaths(fields)
+	var updatesEntries []*statedb.IndexedKV
+	for key, vv := range updates.GetUpdates(ns) {
+		if vv.Value == nil {
+			continue
+		}
+		indexKey := statedb.IndexEntryKey(paths, key, vv.Value)
+		if indexKey == nil || bytes.Compare(indexKey, startKey) < 0 ||
+			(len(endKey) != 0 && bytes.Compare(indexKey, endKey) >= 0) {
+			continue
+		}
+		updatesEntries = append(updatesEntries, &statedb.IndexedKV{
+			VersionedKV: statedb.VersionedKV{
+				CompositeKey:   statedb.CompositeKey{Namespace: ns, Key: key},
+				VersionedValue: *vv,
+			},
+			IndexKey: indexKey,
+		})
+	}
+	sort.Slice(updatesEntries, func(i, j int) bool {
+		return bytes.Compare(updatesEntries[i].IndexKey, updatesEntries[j].IndexKey) < 0
+	})
+
+	dbItr, err := db.GetIndexRangeScanIterator(ns, indexName, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	itr := &indexScanIterator{ns: ns, updates: updates, dbItr: dbItr, updatesEntries: updatesEntries}
+	if err := itr.moveDBItr(); err != nil {
+		dbItr.Close()
+		return nil, err
+	}
+	return itr, nil
+}
+
+// moveDBItr moves the db iterator to the next entry of a state that is not updated in the batch
+func (itr *indexScanIterator) moveDBItr() error {
+	for {
+		result, err := itr.dbItr.Next()
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			itr.dbItem = nil
+			return nil
+		}
+		itr.dbItem = result.(*statedb.IndexedKV)
+		if !itr.updates.Exists(itr.ns, itr.dbItem.Key) {
+			return nil
+		}
+	}
+}
+
+// Next returns the entry that has the next smaller index key
+func (itr *indexScanIterator) Next() (statedb.QueryResult, error) {
+	if len(itr.updatesEntries) != 0 &&
+		(itr.dbItem == nil || bytes.Compare(itr.updatesEntries[0].IndexKey, itr.dbItem.IndexKey) < 0) {
+		selectedItem := itr.updatesEntries[0]
+		itr.updatesEntries = itr.updatesEntries[1:]
+		return &selectedItem.VersionedKV, nil
+	}
+	if itr.dbItem == nil {
+		return nil, nil
+	}
+	selectedItem := itr.dbItem
+	if err := itr.moveDBItr(); err != nil {
+		return nil, err
+	}
+	return &selectedItem.VersionedKV, nil
+}
+
+func (itr *indexScanIterator) Close() {
+	itr.dbItr.Close()
+}
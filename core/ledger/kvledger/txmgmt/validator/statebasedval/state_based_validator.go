@@ -6,10 +6,15 @@ SPDX-License-Identifier: Apache-2.0
 package statebasedval
 
 import (
+	"strings"
+
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statesql"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/internal"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
@@ -101,7 +106,7 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 	for _, tx := range block.Txs {
 		var validationCode peer.TxValidationCode
 		var err error
-		if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
+		if validationCode, err = v.validateEndorserTX(tx.RWSet, block.Capabilities, doMVCCValidation, updates); err != nil {
 			return nil, err
 		}
 
@@ -121,6 +126,7 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 // validateEndorserTX validates endorser transaction
 func (v *Validator) validateEndorserTX(
 	txRWSet *rwsetutil.TxRwSet,
+	capabilities txmgr.ApplicationCapabilities,
 	doMVCCValidation bool,
 	updates *internal.PubAndHashUpdates) (peer.TxValidationCode, error) {
 
@@ -128,12 +134,13 @@ func (v *Validator) validateEndorserTX(
 	var err error
 	//mvccvalidation, may invalidate transaction
 	if doMVCCValidation {
-		validationCode, err = v.validateTx(txRWSet, updates)
+		validationCode, err = v.validateTx(txRWSet, capabilities, updates)
 	}
 	return validationCode, err
 }
 
-func (v *Validator) validateTx(txRWSet *rwsetutil.TxRwSet, updates *internal.PubAndHashUpdates) (peer.TxValidationCode, error) {
+func (v *Validator) validateTx(txRWSet *rwsetutil.TxRwSet, capabilities txmgr.ApplicationCapabilities,
+	updates *internal.PubAndHashUpdates) (peer.TxValidationCode, error) {
 	// Uncomment the following only for local debugging. Don't want to print data in the logs in production
 	//logger.Debugf("validateTx - validating txRWSet: %s", spew.Sdump(txRWSet))
 	for _, nsRWSet := range txRWSet.NsRwSets {
@@ -152,6 +159,26 @@ func (v *Validator) validateTx(txRWSet *rwsetutil.TxRwSet, updates *internal.Pub
 			}
 			return peer.TxValidationCode_PHANTOM_READ_CONFLICT, nil
 		}
+		// Validate queries on secondary indexes for phantom items, if enabled on the channel. Otherwise, the
+		// queries recorded in the read set are ignored, as they are by the peers that do not support the capability
+		if capabilities != nil && capabilities.IndexPhantomProtection() {
+			if valid, err := v.validateIndexQueries(ns, nsRWSet.KvRwSet.IndexQueriesInfo, updates.PubUpdates); !valid || err != nil {
+				if err != nil {
+					return peer.TxValidationCode(-1), err
+				}
+				return peer.TxValidationCode_PHANTOM_READ_CONFLICT, nil
+			}
+		}
+		// Validate rich queries for phantom items, if enabled on the channel. Otherwise, the rich queries
+		// recorded in the read set are ignored, as they are by the peers that do not support the capability
+		if capabilities != nil && capabilities.RichQueryPhantomProtection() {
+			if valid, err := v.validateRichQueries(ns, nsRWSet.KvRwSet.RichQueriesInfo, updates.PubUpdates); !valid || err != nil {
+				if err != nil {
+					return peer.TxValidationCode(-1), err
+				}
+				return peer.TxValidationCode_PHANTOM_READ_CONFLICT, nil
+			}
+		}
 		// Validate hashes for private reads
 		if valid, err := v.validateNsHashedReadSets(ns, nsRWSet.CollHashedRwSets, updates.HashUpdates); !valid || err != nil {
 			if err != nil {
@@ -239,6 +266,131 @@ func (v *Validator) validateRangeQuery(ns string, rangeQueryInfo *kvrwset.RangeQ
 	return validator.validate()
 }
 
+////////////////////////////////////////////////////////////////////////////////
+/////                 Validation of queries on secondary indexes
+////////////////////////////////////////////////////////////////////////////////
+func (v *Validator) validateIndexQueries(ns string, indexQueriesInfo []*kvrwset.IndexQueryInfo, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+	for _, iqi := range indexQueriesInfo {
+		if valid, err := v.validateIndexQuery(ns, iqi, updates); !valid || err != nil {
+			return valid, err
+		}
+	}
+	return true, nil
+}
+
+// validateIndexQuery performs a phantom read check for a query on a secondary index, the same way as validateRangeQuery
+// does for a range query, by iterating over the entries of the index in the recorded range as they are in the
+// statedb + updates. The entries are read from the index maintained by the statedb, which is defined in the chaincode
+// package and is updated along with the states on every peer. The validation fails if the index does not exist on this
+// peer or if it is not defined on the fields recorded in the query info, as the results could then differ across peers
+func (v *Validator) validateIndexQuery(ns string, indexQueryInfo *kvrwset.IndexQueryInfo, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+	logger.Debugf("validateIndexQuery: ns=%s, indexQueryInfo=%s", ns, indexQueryInfo)
+
+	indexQueryable, ok := v.db.GetIndexQueryable()
+	if !ok {
+		logger.Debugf("Validation failed: the statedb does not support the queries on the index [%s]", indexQueryInfo.IndexName)
+		return false, nil
+	}
+	fields, err := indexQueryable.GetIndexFields(ns, indexQueryInfo.IndexName)
+	if _, ok := err.(*statedb.IndexNotFoundError); ok {
+		logger.Debugf("Validation failed: the index [%s] does not exist in the namespace [%s]", indexQueryInfo.IndexName, ns)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !equalFields(fields, indexQueryInfo.Fields) {
+		logger.Debugf("Validation failed: the index [%s] is defined on the fields %s instead of the recorded fields %s",
+			indexQueryInfo.IndexName, fields, indexQueryInfo.Fields)
+		return false, nil
+	}
+	// If during simulation, the caller had not exhausted the iterator so
+	// indexQueryInfo.EndKey is the key of the last entry seen by the caller
+	// and hence the range should include this key
+	endKey := indexQueryInfo.EndKey
+	if !indexQueryInfo.ItrExhausted {
+		endKey = append(append([]byte{}, endKey...), 0x00)
+	}
+	scanItr, err := newIndexScanIterator(indexQueryable, updates.UpdateBatch,
+		ns, indexQueryInfo.IndexName, fields, indexQueryInfo.StartKey, endKey)
+	if err != nil {
+		return false, err
+	}
+	defer scanItr.Close()
+
+	// the results are validated as the results of a range query
+	rangeQueryInfo := &kvrwset.RangeQueryInfo{}
+	var validator rangeQueryValidator
+	if indexQueryInfo.GetReadsMerkleHashes() != nil {
+		rangeQueryInfo.SetMerkelSummary(indexQueryInfo.GetReadsMerkleHashes())
+		validator = &rangeQueryHashValidator{}
+	} else {
+		rangeQueryInfo.SetRawReads(indexQueryInfo.GetRawReads().GetKvReads())
+		validator = &rangeQueryResultsValidator{}
+	}
+	if err := validator.init(rangeQueryInfo, scanItr); err != nil {
+		return false, err
+	}
+	return validator.validate()
+}
+
+func equalFields(fields, recordedFields []string) bool {
+	if len(fields) != len(recordedFields) {
+		return false
+	}
+	for i, field := range fields {
+		if field != recordedFields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+////////////////////////////////////////////////////////////////////////////////
+/////                 Validation of rich queries
+////////////////////////////////////////////////////////////////////////////////
+func (v *Validator) validateRichQueries(ns string, richQueriesInfo []*kvrwset.RichQueryInfo, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+	for _, rqi := range richQueriesInfo {
+		if valid, err := v.validateRichQuery(ns, rqi, updates); !valid || err != nil {
+			return valid, err
+		}
+	}
+	return true, nil
+}
+
+// validateRichQuery performs a phantom read check for a rich query. The results of the query are in the read-set
+// of the transaction and are validated as any other read. In addition, the updates of the preceding valid
+// transactions in the current block must not add a state to the results of the query. The query is evaluated
+// against each updated state in memory, irrespective of the type and of the indexes of the local state database,
+// so that all the peers of the channel reach the same result. A query that cannot be evaluated is invalid
+func (v *Validator) validateRichQuery(ns string, richQueryInfo *kvrwset.RichQueryInfo, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+	logger.Debugf("validateRichQuery: ns=%s, richQueryInfo=%s", ns, richQueryInfo)
+	for key, vv := range updates.GetUpdates(ns) {
+		if vv.Value == nil {
+			continue
+		}
+		matches, err := matchesRichQuery(richQueryInfo.Query, key, vv.Value)
+		if err != nil {
+			logger.Debugf("Validation failed: the query cannot be evaluated: %s", err)
+			return false, nil
+		}
+		if matches {
+			logger.Debugf("Validation failed: updated key [%s] matches the query", key)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchesRichQuery evaluates a query in the language in which it is written, i.e., a CouchDB query
+// if it is a JSON object and a SQL statement otherwise
+func matchesRichQuery(query, key string, value []byte) (bool, error) {
+	if strings.HasPrefix(strings.TrimSpace(query), "{") {
+		return statecouchdb.MatchesQuery(query, key, value)
+	}
+	return statesql.MatchesQuery(query, key, value)
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 /////                 Validation of hashed read-set
 ////////////////////////////////////////////////////////////////////////////////
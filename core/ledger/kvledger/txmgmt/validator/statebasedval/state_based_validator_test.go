@@ -17,17 +17,22 @@ limitations under the License.
 package statebasedval
 
 import (
+	"archive/tar"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/internal"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
@@ -344,7 +349,161 @@ func TestPhantomHashBasedValidation(t *testing.T) {
 	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder2), []int{0})
 }
 
+func TestIndexQueryPhantomValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	//populate db with initial data
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte(`{"owner":"tom"}`), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte(`{"owner":"jerry"}`), version.NewHeight(1, 1))
+	batch.PubUpdates.Put("ns1", "key3", []byte(`{"owner":"tom"}`), version.NewHeight(1, 2))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 2))
+	startKey := statedb.IndexValuesPrefix([]interface{}{"tom"})
+	endKey := append(append([]byte{}, startKey[:len(startKey)-1]...), 0x01)
+	key1IndexKey := statedb.IndexEntryKey([][]string{{"owner"}}, "key1", []byte(`{"owner":"tom"}`))
+	indexCapable := db.(*privacyenabledstate.CommonStorageDB).VersionedDB.(statedb.IndexCapable)
+	createIndex := func(definition string) {
+		assert.NoError(t, indexCapable.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{{
+			FileHeader:  &tar.Header{Name: "META-INF/statedb/leveldb/indexes/ownerIndex.json"},
+			FileContent: []byte(definition),
+		}}))
+	}
+
+	validator := NewValidator(db)
+	caps := capabilities.NewApplicationProvider(map[string]*common.Capability{
+		capabilities.ApplicationIndexPhantomProtection: {},
+	})
+	checkValidation := func(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
+		checkValidationWithCapabilities(t, val, caps, transRWSets, expectedInvalidTxIndexes)
+	}
+	queryTom := func(fields ...string) *rwsetutil.RWSetBuilder {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		iqi := &kvrwset.IndexQueryInfo{IndexName: "ownerIndex", StartKey: startKey, EndKey: endKey, ItrExhausted: true, Fields: fields}
+		iqi.SetRawReads([]*kvrwset.KVRead{
+			rwsetutil.NewKVRead("key1", version.NewHeight(1, 0)),
+			rwsetutil.NewKVRead("key3", version.NewHeight(1, 2))})
+		rwsetBuilder.AddToIndexQuerySet("ns1", iqi)
+		return rwsetBuilder
+	}
+	write := func(key string, value []byte) *rwsetutil.RWSetBuilder {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToWriteSet("ns1", key, value)
+		return rwsetBuilder
+	}
+
+	//the query should be invalid if the index does not exist on this peer
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, queryTom("owner")), []int{0})
+
+	//the query should be invalid if the index is not defined on the recorded fields
+	createIndex(`{"name":"ownerIndex","fields":["size"]}`)
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, queryTom("owner")), []int{0})
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, queryTom()), []int{0})
+
+	//the query should be valid as long as no entry is inserted, updated or deleted in the range
+	createIndex(`{"name":"ownerIndex","fields":["owner"]}`)
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, queryTom("owner")), []int{})
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key2", []byte(`{"owner":"spike"}`)), queryTom("owner")), []int{})
+
+	//the query should be invalid if a preceding transaction in the block inserts, updates or deletes an entry in the range
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key4", []byte(`{"owner":"tom"}`)), queryTom("owner")), []int{1})
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key2", []byte(`{"owner":"tom"}`)), queryTom("owner")), []int{1})
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key1", []byte(`{"owner":"jerry"}`)), queryTom("owner")), []int{1})
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key3", nil), queryTom("owner")), []int{1})
+
+	//the query should be invalid if an entry is inserted in the range by a committed transaction
+	batch = privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key5", []byte(`{"owner":"tom"}`), version.NewHeight(2, 0))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(2, 0))
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, queryTom("owner")), []int{0})
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key5", []byte(`{"owner":"jerry"}`)), queryTom("owner")), []int{})
+
+	//an iterator that was not exhausted covers the range up to the last entry seen
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	iqi := &kvrwset.IndexQueryInfo{IndexName: "ownerIndex", StartKey: startKey, EndKey: key1IndexKey, ItrExhausted: false, Fields: []string{"owner"}}
+	iqi.SetRawReads([]*kvrwset.KVRead{rwsetutil.NewKVRead("key1", version.NewHeight(1, 0))})
+	rwsetBuilder.AddToIndexQuerySet("ns1", iqi)
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key4", []byte(`{"owner":"tom"}`)), rwsetBuilder), []int{})
+
+	//the index queries are not validated if the capability is not enabled
+	checkValidationWithCapabilities(t, validator, capabilities.NewApplicationProvider(nil),
+		getTestPubSimulationRWSet(t, write("key4", []byte(`{"owner":"tom"}`)), queryTom("owner")), []int{})
+	checkValidationWithCapabilities(t, validator, nil, getTestPubSimulationRWSet(t, queryTom()), []int{})
+}
+
+func TestRichQueryPhantomValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	//populate db with initial data
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte(`{"owner":"tom"}`), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte(`{"owner":"jerry"}`), version.NewHeight(1, 1))
+	batch.PubUpdates.Put("ns1", "key3", []byte(`{"owner":"tom"}`), version.NewHeight(1, 2))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 2))
+
+	validator := NewValidator(db)
+	query := func(query string) *rwsetutil.RWSetBuilder {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+		rwsetBuilder.AddToReadSet("ns1", "key3", version.NewHeight(1, 2))
+		rwsetBuilder.AddToRichQuerySet("ns1", &kvrwset.RichQueryInfo{Query: query})
+		return rwsetBuilder
+	}
+	write := func(key string, value []byte) *rwsetutil.RWSetBuilder {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToWriteSet("ns1", key, value)
+		return rwsetBuilder
+	}
+	caps := capabilities.NewApplicationProvider(map[string]*common.Capability{
+		capabilities.ApplicationRichQueryPhantomProtection: {},
+	})
+	checkValidation := func(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
+		checkValidationWithCapabilities(t, val, caps, transRWSets, expectedInvalidTxIndexes)
+	}
+
+	//the queries are evaluated in the language in which they are written, irrespective of the local state database
+	for _, queryTom := range []string{`{"selector":{"owner":"tom"}}`, `SELECT * FROM state WHERE owner = 'tom'`} {
+		t.Run(queryTom, func(t *testing.T) {
+			//the query should be valid as long as no preceding transaction adds a result
+			checkValidation(t, validator, getTestPubSimulationRWSet(t, query(queryTom)), []int{})
+			checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key2", []byte(`{"owner":"spike"}`)), query(queryTom)), []int{})
+			checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key2", nil), query(queryTom)), []int{})
+			checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key4", []byte("not json")), query(queryTom)), []int{})
+
+			//the query should be invalid if a preceding transaction in the block adds a result
+			checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key4", []byte(`{"owner":"tom"}`)), query(queryTom)), []int{1})
+			checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key2", []byte(`{"owner":"tom"}`)), query(queryTom)), []int{1})
+
+			//the updates and the deletes of the results are detected as for any read
+			checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key1", []byte(`{"owner":"jerry"}`)), query(queryTom)), []int{1})
+			checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key3", nil), query(queryTom)), []int{1})
+		})
+	}
+
+	//the query should be invalid if it cannot be evaluated against a preceding update
+	for _, badQuery := range []string{`SELECT FROM`, `{"selector":{"owner":{"$unknown":"tom"}}}`} {
+		checkValidation(t, validator, getTestPubSimulationRWSet(t, query(badQuery)), []int{})
+		checkValidation(t, validator, getTestPubSimulationRWSet(t, write("key4", []byte(`{"owner":"tom"}`)), query(badQuery)), []int{1})
+	}
+
+	//the queries are not validated if the capability is not enabled on the channel
+	addTom := write("key4", []byte(`{"owner":"tom"}`))
+	checkValidationWithCapabilities(t, validator, capabilities.NewApplicationProvider(nil),
+		getTestPubSimulationRWSet(t, addTom, query(`{"selector":{"owner":"tom"}}`)), []int{})
+	checkValidationWithCapabilities(t, validator, nil, getTestPubSimulationRWSet(t, addTom, query(`{"selector":{"owner":"tom"}}`)), []int{})
+}
+
 func checkValidation(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
+	checkValidationWithCapabilities(t, val, nil, transRWSets, expectedInvalidTxIndexes)
+}
+
+func checkValidationWithCapabilities(t *testing.T, val *Validator, capabilities txmgr.ApplicationCapabilities,
+	transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
 	var trans []*internal.Transaction
 	for i, tranRWSet := range transRWSets {
 		tx := &internal.Transaction{
@@ -355,7 +514,7 @@ func checkValidation(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRw
 		}
 		trans = append(trans, tx)
 	}
-	block := &internal.Block{Num: 1, Txs: trans}
+	block := &internal.Block{Num: 1, Txs: trans, Capabilities: capabilities}
 	_, err := val.ValidateAndPrepareBatch(block, true)
 	assert.NoError(t, err)
 	t.Logf("block.Txs[0].ValidationCode = %d", block.Txs[0].ValidationCode)
@@ -25,15 +25,17 @@ var logger = flogging.MustGetLogger("valimpl")
 // and for actual validation of the public rwset, it encloses an internal validator (that implements interface
 // internal.InternalValidator) such as statebased validator
 type DefaultImpl struct {
-	txmgr             txmgr.TxMgr
-	db                privacyenabledstate.DB
-	internalValidator internal.Validator
+	txmgr                 txmgr.TxMgr
+	db                    privacyenabledstate.DB
+	capabilitiesRetriever txmgr.CapabilitiesRetriever
+	internalValidator     internal.Validator
 }
 
 // NewStatebasedValidator constructs a validator that internally manages statebased validator and in addition
-// handles the tasks that are agnostic to a particular validation scheme such as parsing the block and handling the pvt data
-func NewStatebasedValidator(txmgr txmgr.TxMgr, db privacyenabledstate.DB) validator.Validator {
-	return &DefaultImpl{txmgr, db, statebasedval.NewValidator(db)}
+// handles the tasks that are agnostic to a particular validation scheme such as parsing the block and handling the pvt data.
+// The capabilities of the channel in effect for a block are obtained from the capabilitiesRetriever, if not nil
+func NewStatebasedValidator(txmgr txmgr.TxMgr, db privacyenabledstate.DB, capabilitiesRetriever txmgr.CapabilitiesRetriever) validator.Validator {
+	return &DefaultImpl{txmgr, db, capabilitiesRetriever, statebasedval.NewValidator(db)}
 }
 
 // ValidateAndPrepareBatch implements the function in interface validator.Validator
@@ -51,6 +53,11 @@ func (impl *DefaultImpl) ValidateAndPrepareBatch(blockAndPvtdata *ledger.BlockAn
 	if internalBlock, txsStatInfo, err = preprocessProtoBlock(impl.txmgr, impl.db.ValidateKeyValue, block, doMVCCValidation); err != nil {
 		return nil, nil, err
 	}
+	if impl.capabilitiesRetriever != nil {
+		if internalBlock.Capabilities, err = impl.capabilitiesRetriever.ApplicationCapabilities(block); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	if pubAndHashUpdates, err = impl.internalValidator.ValidateAndPrepareBatch(internalBlock, doMVCCValidation); err != nil {
 		return nil, nil, err
@@ -16,7 +16,11 @@ limitations under the License.
 
 package version
 
-import "github.com/hyperledger/fabric/common/ledger/util"
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/pkg/errors"
+)
 
 // Height represents the height of a transaction in blockchain
 type Height struct {
@@ -29,11 +33,32 @@ func NewHeight(blockNum, txNum uint64) *Height {
 	return &Height{blockNum, txNum}
 }
 
-// NewHeightFromBytes constructs a new instance of Height from serialized bytes
-func NewHeightFromBytes(b []byte) (*Height, int) {
-	blockNum, n1 := util.DecodeOrderPreservingVarUint64(b)
-	txNum, n2 := util.DecodeOrderPreservingVarUint64(b[n1:])
-	return NewHeight(blockNum, txNum), n1 + n2
+// NewHeightFromBytes constructs a new instance of Height from serialized bytes.
+// It also returns the number of bytes consumed from the given bytes
+func NewHeightFromBytes(b []byte) (*Height, int, error) {
+	blockNum, n1, err := decodeOrderPreservingVarUint64(b)
+	if err != nil {
+		return nil, -1, errors.WithMessage(err, "error decoding the block number of the height")
+	}
+	txNum, n2, err := decodeOrderPreservingVarUint64(b[n1:])
+	if err != nil {
+		return nil, -1, errors.WithMessage(err, "error decoding the transaction number of the height")
+	}
+	return NewHeight(blockNum, txNum), n1 + n2, nil
+}
+
+// decodeOrderPreservingVarUint64 verifies that the bytes begin with a number encoded by the function
+// 'util.EncodeOrderPreservingVarUint64' before decoding it, as the decoding panics on malformed bytes
+func decodeOrderPreservingVarUint64(b []byte) (uint64, int, error) {
+	size, n := proto.DecodeVarint(b)
+	switch {
+	case n != 1:
+		return 0, 0, errors.Errorf("invalid size prefix in the bytes [%x]", b)
+	case size > 8 || int(size) > len(b)-1:
+		return 0, 0, errors.Errorf("invalid size [%d] of the number in the bytes [%x]", size, b)
+	}
+	number, consumed := util.DecodeOrderPreservingVarUint64(b)
+	return number, consumed, nil
 }
 
 // ToBytes serializes the Height
@@ -25,7 +25,8 @@ import (
 func TestVersionSerialization(t *testing.T) {
 	h1 := NewHeight(10, 100)
 	b := h1.ToBytes()
-	h2, n := NewHeightFromBytes(b)
+	h2, n, err := NewHeightFromBytes(b)
+	assert.NoError(t, err)
 	assert.Equal(t, h1, h2)
 	assert.Len(t, b, n)
 }
@@ -46,8 +47,18 @@ func TestVersionExtraBytes(t *testing.T) {
 	h1 := NewHeight(10, 100)
 	b := h1.ToBytes()
 	b1 := append(b, extraBytes...)
-	h2, n := NewHeightFromBytes(b1)
+	h2, n, err := NewHeightFromBytes(b1)
+	assert.NoError(t, err)
 	assert.Equal(t, h1, h2)
 	assert.Len(t, b, n)
 	assert.Equal(t, extraBytes, b1[n:])
 }
+
+func TestVersionBadBytes(t *testing.T) {
+	_, _, err := NewHeightFromBytes(nil)
+	assert.EqualError(t, err, "error decoding the block number of the height: invalid size prefix in the bytes []")
+	_, _, err = NewHeightFromBytes([]byte{0x09, 0x01})
+	assert.EqualError(t, err, "error decoding the block number of the height: invalid size [9] of the number in the bytes [0901]")
+	_, _, err = NewHeightFromBytes(NewHeight(10, 100).ToBytes()[:3])
+	assert.EqualError(t, err, "error decoding the transaction number of the height: invalid size [1] of the number in the bytes [01]")
+}
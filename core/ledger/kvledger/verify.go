@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+)
+
+// VerifyLedger implements the function in the interface `ledger.LedgerVerifier`. In addition to the verification
+// of the block store, the savepoints of the state database and the history database are checked against the height
+// of the block store. A database that is behind the block store is reported as a warning, as the database is
+// brought up to date by the recovery when the ledger is opened; a database that is ahead of the block store is
+// reported as corrupted
+func (provider *Provider) VerifyLedger(ledgerID string) (*ledger.VerificationReport, error) {
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNonExistingLedgerID
+	}
+	blockStoreResult, err := provider.ledgerStoreProvider.VerifyBlockStore(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	report := &ledger.VerificationReport{
+		LedgerID:         ledgerID,
+		BlockStoreHeight: blockStoreResult.Height,
+	}
+	for _, c := range blockStoreResult.Corruptions {
+		report.Corruptions = append(report.Corruptions, c.String())
+	}
+
+	vdb, err := provider.vdbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	stateDBSavepoint, err := vdb.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	report.StateDBHeight = heightFromSavepoint(stateDBSavepoint)
+	checkDBHeight(report, "state database", report.StateDBHeight)
+
+	if !ledgerconfig.IsHistoryDBEnabled() {
+		return report, nil
+	}
+	historyDB, err := provider.historydbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	historyDBSavepoint, err := historyDB.GetLastSavepoint()
+	if err != nil {
+		return nil, err
+	}
+	report.HistoryDBHeight = heightFromSavepoint(historyDBSavepoint)
+	checkDBHeight(report, "history database", report.HistoryDBHeight)
+	return report, nil
+}
+
+// checkDBHeight adds a corruption to the report if the height of the database is ahead of the height of
+// the block store and a warning if it is behind
+func checkDBHeight(report *ledger.VerificationReport, dbName string, dbHeight uint64) {
+	switch {
+	case dbHeight > report.BlockStoreHeight:
+		report.Corruptions = append(report.Corruptions, fmt.Sprintf(
+			"%s: the height of the %s [%d] is ahead of the height of the block store [%d]",
+			dbName, dbName, dbHeight, report.BlockStoreHeight))
+	case dbHeight < report.BlockStoreHeight:
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"%s: the height of the %s [%d] is behind the height of the block store [%d], the %s is brought up to date when the peer is started",
+			dbName, dbName, dbHeight, report.BlockStoreHeight, dbName))
+	}
+}
+
+func heightFromSavepoint(savepoint *version.Height) uint64 {
+	if savepoint == nil {
+		return 0
+	}
+	return savepoint.BlockNum + 1
+}
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		simulator, err := ledger.NewTxSimulator(util.GenerateUUID())
+		require.NoError(t, err)
+		require.NoError(t, simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i))))
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		require.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}))
+	}
+	ledger.Close()
+
+	verifier := provider.(lgr.LedgerVerifier)
+	report, err := verifier.VerifyLedger("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, &lgr.VerificationReport{
+		LedgerID:         "testLedger",
+		BlockStoreHeight: 4,
+		StateDBHeight:    4,
+		HistoryDBHeight:  4,
+	}, report)
+	_, err = verifier.VerifyLedger("non-existing-ledger")
+	assert.Equal(t, ErrNonExistingLedgerID, err)
+
+	// lose the tail of the last block, as if the block file got truncated
+	blockfilePath := filepath.Join(ledgerconfig.GetBlockStorePath(), "chains", "testLedger", "blockfile_000000")
+	fileInfo, err := os.Stat(blockfilePath)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(blockfilePath, fileInfo.Size()-10))
+	report, err = verifier.VerifyLedger("testLedger")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), report.BlockStoreHeight)
+	require.Len(t, report.Corruptions, 5)
+	assert.Contains(t, report.Corruptions[0], blockfilePath)
+	assert.Contains(t, report.Corruptions[0], "block cannot be read: unexpected end of blockfile")
+	assert.Equal(t,
+		"block files: block [3] is recorded as the last block in the checkpoint info while the height of the block files is [3]",
+		report.Corruptions[1])
+	assert.Equal(t,
+		"block index: block [3] is recorded as the last indexed block while the height of the block files is [3]",
+		report.Corruptions[2])
+	assert.Equal(t,
+		"state database: the height of the state database [4] is ahead of the height of the block store [3]",
+		report.Corruptions[3])
+	assert.Equal(t,
+		"history database: the height of the history database [4] is ahead of the height of the block store [3]",
+		report.Corruptions[4])
+}
+
+func TestVerifyLedgerReportsDBsBehindBlockStore(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	// add a block to the block store only, as if the peer crashed before committing the block to the dbs
+	blockStore := ledger.(*kvLedger).blockStore
+	require.NoError(t, blockStore.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{})}))
+	ledger.Close()
+
+	report, err := provider.(lgr.LedgerVerifier).VerifyLedger("testLedger")
+	require.NoError(t, err)
+	assert.Empty(t, report.Corruptions)
+	assert.Equal(t, []string{
+		"state database: the height of the state database [1] is behind the height of the block store [2], " +
+			"the state database is brought up to date when the peer is started",
+		"history database: the height of the history database [1] is behind the height of the block store [2], " +
+			"the history database is brought up to date when the peer is started",
+	}, report.Warnings)
+}
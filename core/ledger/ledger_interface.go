@@ -35,6 +35,9 @@ type PeerLedgerProvider interface {
 	// This function guarantees that the creation of ledger and committing the genesis block would an atomic action
 	// The chain id retrieved from the genesis block is treated as a ledger id
 	Create(genesisBlock *common.Block) (PeerLedger, error)
+	// CreateFromSnapshot creates a new ledger from the snapshot in the given dir.
+	// The ledger id is the channel name recorded in the metadata of the snapshot
+	CreateFromSnapshot(snapshotDir string) (PeerLedger, error)
 	// Open opens an already created ledger
 	Open(ledgerID string) (PeerLedger, error)
 	// Exists tells whether the ledger with given id exists
@@ -45,6 +48,126 @@ type PeerLedgerProvider interface {
 	Close()
 }
 
+// SnapshotGenerator is implemented by a PeerLedger that supports exporting a snapshot
+// of the ledger from which the ledger for the channel can be bootstrapped on another peer
+type SnapshotGenerator interface {
+	// GenerateSnapshot exports the snapshot of the ledger at the current height in the given dir
+	GenerateSnapshot(dir string) error
+}
+
+// StateIndexManager is implemented by a PeerLedger whose state database supports managing the indexes
+// of a chaincode on demand (e.g., CouchDB), without deploying a new version of the chaincode.
+// The indexes are managed on the public data of the chaincode or, if the collection is not empty,
+// on the private data of the collection
+type StateIndexManager interface {
+	// AddStateIndex creates the index with the given definition. The format of the definition is the same
+	// as that of the index files packaged with a chaincode (e.g., META-INF/statedb/couchdb/indexes/*.json)
+	AddStateIndex(chaincodeName, collection string, indexDefinition []byte) (*StateIndex, error)
+	// ListStateIndexes returns the indexes along with their build status
+	ListStateIndexes(chaincodeName, collection string) ([]*StateIndex, error)
+	// DropStateIndex drops the index with the given name in the given design document
+	DropStateIndex(chaincodeName, collection, designDoc, indexName string) error
+}
+
+// NamespaceStateManager is implemented by a PeerLedger that supports exporting the public state of a namespace,
+// e.g., for debugging, and importing the public state of a namespace exported from a ledger, possibly on another network
+type NamespaceStateManager interface {
+	// ExportNamespaceState invokes the given function for each key in the public state of the namespace,
+	// in the order of the keys. No block is committed while the export is in progress
+	ExportNamespaceState(namespace string, process func(*StateRecord) error) error
+	// ImportNamespaceState writes the records returned by the given function, until it returns a nil record,
+	// to the public state of the namespace and returns the number of the imported records. The records are written
+	// directly to the state database, and not via a block, and hence, the import is expected to be performed on
+	// all the peers of the channel. The existing keys are overwritten and the keys with a block-to-live that has
+	// already passed at the current height of the ledger are skipped. The imported keys are not recorded in the history
+	// of the keys. The state of the system namespaces cannot be imported. The ledger is expected to not be in use
+	ImportNamespaceState(namespace string, next func() (*StateRecord, error)) (uint64, error)
+}
+
+// StateRecord is a key in the public state of a namespace along with its value, version, and metadata
+type StateRecord struct {
+	Key      string
+	Value    []byte
+	BlockNum uint64
+	TxNum    uint64
+	Metadata map[string][]byte
+}
+
+// StateIndex describes an index of the state database
+type StateIndex struct {
+	DesignDoc  string
+	Name       string
+	Definition string
+	// Building is true while the state database builds the index, in which
+	// case BuildProgress is the percentage of the build that is complete
+	Building      bool
+	BuildProgress int
+}
+
+// LedgerVerifier is implemented by a PeerLedgerProvider that supports verifying
+// the integrity of a ledger while the ledger is not opened
+type LedgerVerifier interface {
+	// VerifyLedger verifies the ledger with the given id and reports the detected corruptions
+	VerifyLedger(ledgerID string) (*VerificationReport, error)
+}
+
+// VerificationReport contains the results of verifying the integrity of a ledger
+type VerificationReport struct {
+	LedgerID string
+	// BlockStoreHeight is the height of the block store as per the blocks present in the block store
+	BlockStoreHeight uint64
+	// StateDBHeight and HistoryDBHeight are the heights up to which the blocks have been committed to
+	// the state database and the history database. A database that is behind the block store is brought
+	// up to date when the peer is started
+	StateDBHeight   uint64
+	HistoryDBHeight uint64
+	// Corruptions lists the detected corruptions. The ledger is found intact, if empty
+	Corruptions []string
+	// Warnings lists the detected inconsistencies that do not indicate a corruption, such as a database
+	// that is behind the block store
+	Warnings []string
+}
+
+// DBsRebuilder is implemented by a PeerLedgerProvider that supports regenerating the databases
+// of a ledger (i.e., the data derived from the blocks) from the block store while the ledger is not opened
+type DBsRebuilder interface {
+	// RebuildDBs drops the databases of the ledger with the given id and regenerates them by recommitting
+	// the blocks present in the block store. The height of the rebuilt ledger is returned
+	RebuildDBs(ledgerID string) (uint64, error)
+}
+
+// LedgerRollbacker is implemented by a PeerLedgerProvider that supports rolling back a ledger
+// to an earlier block while the ledger is not opened
+type LedgerRollbacker interface {
+	// RollbackLedger removes the blocks above the given block number from the ledger with the given id.
+	// The databases of the ledger are rebuilt from the retained blocks when the ledger is opened next time
+	RollbackLedger(ledgerID string, blockNum uint64) error
+}
+
+// LedgerRemover is implemented by a PeerLedgerProvider that supports removing a ledger while the ledger is not opened
+type LedgerRemover interface {
+	// RemoveLedger removes the ledger with the given id from the list of the ledgers and deletes all the data of the ledger
+	RemoveLedger(ledgerID string) error
+}
+
+// StoreMaintainer is implemented by a PeerLedgerProvider that supports reporting the disk usage of, and compacting,
+// the leveldb based stores of a ledger while the ledger remains in use
+type StoreMaintainer interface {
+	// StoreDiskUsage returns the approximate number of bytes used on the file system by the data of the ledger
+	// with the given id, keyed by the name of the store (e.g., StateStore)
+	StoreDiskUsage(ledgerID string) (map[string]uint64, error)
+	// CompactStore compacts the data of the ledger with the given id in the store with the given name
+	CompactStore(ledgerID string, store string) error
+}
+
+// The names of the leveldb based stores of a ledger, as reported by a StoreMaintainer
+const (
+	StateStore      = "state"
+	HistoryStore    = "history"
+	BlockIndexStore = "index"
+	PvtDataStore    = "pvtdata"
+)
+
 // PeerLedger differs from the OrdererLedger in that PeerLedger locally maintain a bitmask
 // that tells apart valid transactions from invalid ones
 type PeerLedger interface {
@@ -145,6 +268,12 @@ type QueryExecutor interface {
 	// For a chaincode, the namespace corresponds to the chaincodeId
 	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
 	ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (QueryResultsIterator, error)
+	// GetStateByIndex returns an iterator over the states of a namespace whose leading fields, as per the given
+	// secondary index, have the given values. The results are returned in the order of the index and are protected
+	// from phantom reads during the validation of the transaction.
+	// Only used for state databases that support secondary indexes
+	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
+	GetStateByIndex(namespace, indexName string, values []interface{}) (commonledger.ResultsIterator, error)
 	// GetPrivateData gets the value of a private data item identified by a tuple <namespace, collection, key>
 	GetPrivateData(namespace, collection, key string) ([]byte, error)
 	// GetPrivateDataHash gets the hash of the value of a private data item identified by a tuple <namespace, collection, key>
@@ -164,11 +293,21 @@ type QueryExecutor interface {
 	// can be supplied as empty strings. However, a full scan shuold be used judiciously for performance reasons.
 	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
 	GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (commonledger.ResultsIterator, error)
+	// GetPrivateDataRangeScanIteratorWithMetadata returns an iterator that contains all the key-values between given key ranges
+	// in a collection. metadata is a map of additional query parameters (e.g., the limit on the number of results).
+	// The returned QueryResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
+	GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey string, metadata map[string]interface{}) (QueryResultsIterator, error)
 	// ExecuteQuery executes the given query and returns an iterator that contains results of type specific to the underlying data store.
 	// Only used for state databases that support query
 	// For a chaincode, the namespace corresponds to the chaincodeId
 	// The returned ResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
 	ExecuteQueryOnPrivateData(namespace, collection, query string) (commonledger.ResultsIterator, error)
+	// ExecuteQueryOnPrivateDataWithMetadata executes the given query on the private data of a collection and returns
+	// an iterator that contains results of type specific to the underlying data store.
+	// metadata is a map of additional query parameters (e.g., the limit on the number of results and the bookmark).
+	// Only used for state databases that support query
+	// The returned QueryResultsIterator contains results of type *KV which is defined in protos/ledger/queryresult.
+	ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (QueryResultsIterator, error)
 	// Done releases resources occupied by the QueryExecutor
 	Done()
 }
@@ -178,6 +317,34 @@ type HistoryQueryExecutor interface {
 	// GetHistoryForKey retrieves the history of values for a key.
 	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
+	// GetPrivateDataHistoryForKey retrieves the history of a private data key in a collection.
+	// The returned ResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	// Each result carries the hash of the value. The value is set only if the private data is available in the peer
+	// (i.e., the peer is a member of the collection and the private data has not been purged)
+	GetPrivateDataHistoryForKey(namespace, collection, key string) (commonledger.ResultsIterator, error)
+	// GetHistoryForKeyWithOptions retrieves the history of values for a key, bounded, ordered, and paginated as
+	// per the supplied options. A nil options retrieves the full history oldest-first, same as `GetHistoryForKey`.
+	// The returned QueryResultsIterator contains results of type *KeyModification which is defined in protos/ledger/queryresult.
+	// When the results are limited, the bookmark returned by the iterator can be set in the options of the next
+	// query to retrieve the next page of results
+	GetHistoryForKeyWithOptions(namespace, key string, options *HistoryQueryOptions) (QueryResultsIterator, error)
+}
+
+// HistoryQueryOptions bounds, orders, and limits the results of a history query
+type HistoryQueryOptions struct {
+	// StartBlockNum and StartTxNum give the height (inclusive) from which the history is retrieved
+	StartBlockNum uint64
+	StartTxNum    uint64
+	// EndBlockNum and EndTxNum give the height (exclusive) up to which the history is retrieved.
+	// The history is not bounded at the end if both are zero
+	EndBlockNum uint64
+	EndTxNum    uint64
+	// Descending causes the history to be retrieved newest-first
+	Descending bool
+	// Limit is the maximum number of results returned by the iterator. A zero value does not limit the results
+	Limit int32
+	// Bookmark is the bookmark returned by a previous query with the same options, from which the results are resumed
+	Bookmark string
 }
 
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
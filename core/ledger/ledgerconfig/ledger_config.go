@@ -10,22 +10,43 @@ import (
 	"path/filepath"
 
 	"github.com/hyperledger/fabric/core/config"
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
 //IsCouchDBEnabled exposes the useCouchDB variable
 func IsCouchDBEnabled() bool {
-	stateDatabase := viper.GetString("ledger.state.stateDatabase")
+	stateDatabase := viper.GetString(confStateDatabase)
 	if stateDatabase == "CouchDB" {
 		return true
 	}
 	return false
 }
 
+// GetStateDatabase returns the name of the state database backend configured in
+// `ledger.state.stateDatabase`. The default is "goleveldb"
+func GetStateDatabase() string {
+	stateDatabase := viper.GetString(confStateDatabase)
+	if stateDatabase == "" {
+		return defaultStateDatabase
+	}
+	return stateDatabase
+}
+
+// GetStateDatabasePlugin returns the path of the Go plugin that implements the state database backend,
+// if the backend is not compiled into the peer
+func GetStateDatabasePlugin() string {
+	return config.GetPath(confStateDatabasePlugin)
+}
+
+const confStateDatabase = "ledger.state.stateDatabase"
+const confStateDatabasePlugin = "ledger.state.stateDatabasePlugin"
+const defaultStateDatabase = "goleveldb"
 const confPeerFileSystemPath = "peer.fileSystemPath"
 const confLedgersData = "ledgersData"
 const confLedgerProvider = "ledgerProvider"
 const confStateleveldb = "stateLeveldb"
+const confStateSQLdb = "stateSQLdb"
 const confHistoryLeveldb = "historyLeveldb"
 const confBookkeeper = "bookkeeper"
 const confConfigHistory = "configHistory"
@@ -34,12 +55,19 @@ const confPvtdataStore = "pvtdataStore"
 const confTotalQueryLimit = "ledger.state.totalQueryLimit"
 const confInternalQueryLimit = "ledger.state.couchDBConfig.internalQueryLimit"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
+const confRichQueryPhantomProtection = "ledger.state.richQueryPhantomProtection"
+const confStateCacheSize = "ledger.state.cache.size"
+const confStateCacheChaincodes = "ledger.state.cache.chaincodes"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confBlockfilesArchiveDir = "ledger.blockchain.archive.dir"
 
 var confCollElgProcMaxDbBatchSize = &conf{"ledger.pvtdataStore.collElgProcMaxDbBatchSize", 5000}
 var confCollElgProcDbBatchesInterval = &conf{"ledger.pvtdataStore.collElgProcDbBatchesInterval", 1000}
+var confMaxBlockfileSize = &conf{"ledger.blockchain.maxBlockfileSize", 64 * 1024 * 1024}
+var confArchiveLocalBlockfiles = &conf{"ledger.blockchain.archive.localBlockfiles", 2}
+var confArchiveFetchedBlockfilesCacheSize = &conf{"ledger.blockchain.archive.fetchedBlockfilesCacheSize", 2}
 
 // GetRootPath returns the filesystem path.
 // All ledger related contents are expected to be stored under this path
@@ -58,6 +86,11 @@ func GetStateLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confStateleveldb)
 }
 
+// GetStateSQLDBPath returns the filesystem path that is used to maintain the state db of the embedded SQL state database
+func GetStateSQLDBPath() string {
+	return filepath.Join(GetRootPath(), confStateSQLdb)
+}
+
 // GetHistoryLevelDBPath returns the filesystem path that is used to maintain the history level db
 func GetHistoryLevelDBPath() string {
 	return filepath.Join(GetRootPath(), confHistoryLeveldb)
@@ -85,7 +118,36 @@ func GetConfigHistoryPath() string {
 
 // GetMaxBlockfileSize returns maximum size of the block file
 func GetMaxBlockfileSize() int {
-	return 64 * 1024 * 1024
+	maxBlockfileSize := viper.GetInt(confMaxBlockfileSize.Name)
+	if maxBlockfileSize <= 0 {
+		maxBlockfileSize = confMaxBlockfileSize.DefaultVal
+	}
+	return maxBlockfileSize
+}
+
+// GetBlockfilesArchivePath returns the filesystem path of the directory to which the block files are archived.
+// An empty path is returned if the block files are not to be archived
+func GetBlockfilesArchivePath() string {
+	return config.GetPath(confBlockfilesArchiveDir)
+}
+
+// GetArchiveLocalBlockfiles returns the number of the most recent block files that are not archived
+func GetArchiveLocalBlockfiles() int {
+	localBlockfiles := viper.GetInt(confArchiveLocalBlockfiles.Name)
+	if localBlockfiles <= 0 {
+		localBlockfiles = confArchiveLocalBlockfiles.DefaultVal
+	}
+	return localBlockfiles
+}
+
+// GetArchiveFetchedBlockfilesCacheSize returns the maximum number of the block files
+// fetched back from the archive that are cached locally
+func GetArchiveFetchedBlockfilesCacheSize() int {
+	cacheSize := viper.GetInt(confArchiveFetchedBlockfilesCacheSize.Name)
+	if cacheSize <= 0 {
+		cacheSize = confArchiveFetchedBlockfilesCacheSize.DefaultVal
+	}
+	return cacheSize
 }
 
 // GetTotalQueryLimit exposes the totalLimit variable
@@ -159,6 +221,29 @@ func IsQueryReadsHashingEnabled() bool {
 	return true
 }
 
+// IsRichQueryPhantomProtectionEnabled returns whether the rich queries performed by the transactions
+// are recorded in their read-write sets, so that they are evaluated against the updates of the block during validation
+func IsRichQueryPhantomProtectionEnabled() bool {
+	return viper.GetBool(confRichQueryPhantomProtection)
+}
+
+// GetStateCacheSize returns the maximum number of entries of the state cache of a ledger that are
+// kept for a chaincode that has no size configured in `ledger.state.cache.chaincodes`.
+// A size of zero (the default) disables the state cache for such chaincodes
+func GetStateCacheSize() int {
+	return viper.GetInt(confStateCacheSize)
+}
+
+// GetStateCacheChaincodeSizes returns the maximum number of entries of the state cache of a ledger
+// that are kept for the individual chaincodes, keyed by the (lower-cased) chaincode name
+func GetStateCacheChaincodeSizes() map[string]int {
+	sizes := map[string]int{}
+	for chaincodeName, size := range viper.GetStringMap(confStateCacheChaincodes) {
+		sizes[chaincodeName] = cast.ToInt(size)
+	}
+	return sizes
+}
+
 // GetMaxDegreeQueryReadsHashing return the maximum degree of the merkle tree for hashes of
 // of range query results for phantom item validation
 // For more details - see description in kvledger/txmgmt/rwset/query_results_helper.go
@@ -46,11 +46,26 @@ func TestIsCouchDBEnabled(t *testing.T) {
 	assert.True(t, updatedValue) //test config returns true
 }
 
+func TestGetStateDatabase(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.stateDatabase", "")
+	assert.Equal(t, "goleveldb", GetStateDatabase())
+	viper.Set("ledger.state.stateDatabase", "CouchDB")
+	assert.Equal(t, "CouchDB", GetStateDatabase())
+	viper.Set("ledger.state.stateDatabase", "sql")
+	assert.Equal(t, "sql", GetStateDatabase())
+	assert.Equal(t, "", GetStateDatabasePlugin())
+	viper.Set("ledger.state.stateDatabasePlugin", "/opt/plugins/statedb.so")
+	assert.Equal(t, "/opt/plugins/statedb.so", GetStateDatabasePlugin())
+}
+
 func TestLedgerConfigPathDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	assert.Equal(t, "/var/hyperledger/production/ledgersData", GetRootPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/ledgerProvider", GetLedgerProviderPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateLeveldb", GetStateLevelDBPath())
+	assert.Equal(t, "/var/hyperledger/production/ledgersData/stateSQLdb", GetStateSQLDBPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/historyLeveldb", GetHistoryLevelDBPath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/chains", GetBlockStorePath())
 	assert.Equal(t, "/var/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
@@ -64,6 +79,7 @@ func TestLedgerConfigPath(t *testing.T) {
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData", GetRootPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/ledgerProvider", GetLedgerProviderPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateLeveldb", GetStateLevelDBPath())
+	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/stateSQLdb", GetStateSQLDBPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/historyLeveldb", GetHistoryLevelDBPath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/chains", GetBlockStorePath())
 	assert.Equal(t, "/tmp/hyperledger/production/ledgersData/pvtdataStore", GetPvtdataStorePath())
@@ -196,6 +212,26 @@ func TestIsHistoryDBEnabledFalse(t *testing.T) {
 	assert.False(t, updatedValue) //test config returns false
 }
 
+func TestIsRichQueryPhantomProtectionEnabled(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsRichQueryPhantomProtectionEnabled()) //test default config is false
+	viper.Set("ledger.state.richQueryPhantomProtection", true)
+	assert.True(t, IsRichQueryPhantomProtectionEnabled())
+}
+
+func TestStateCacheSizes(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, 0, GetStateCacheSize()) //test default config is 0
+	assert.Empty(t, GetStateCacheChaincodeSizes())
+
+	viper.Set("ledger.state.cache.size", 1000)
+	viper.Set("ledger.state.cache.chaincodes", map[string]interface{}{"mycc": 5000, "othercc": "0"})
+	assert.Equal(t, 1000, GetStateCacheSize())
+	assert.Equal(t, map[string]int{"mycc": 5000, "othercc": 0}, GetStateCacheChaincodeSizes())
+}
+
 func TestIsAutoWarmIndexesEnabledDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := IsAutoWarmIndexesEnabled()
@@ -246,6 +282,23 @@ func TestGetWarmIndexesAfterNBlocks(t *testing.T) {
 
 func TestGetMaxBlockfileSize(t *testing.T) {
 	assert.Equal(t, 67108864, GetMaxBlockfileSize())
+	defer viper.Set("ledger.blockchain.maxBlockfileSize", 0)
+	viper.Set("ledger.blockchain.maxBlockfileSize", 1024)
+	assert.Equal(t, 1024, GetMaxBlockfileSize())
+}
+
+func TestGetBlockfilesArchiveConfig(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, "", GetBlockfilesArchivePath())
+	assert.Equal(t, 2, GetArchiveLocalBlockfiles())
+	assert.Equal(t, 2, GetArchiveFetchedBlockfilesCacheSize())
+	viper.Set("ledger.blockchain.archive.dir", "/tmp/archive")
+	viper.Set("ledger.blockchain.archive.localBlockfiles", 5)
+	viper.Set("ledger.blockchain.archive.fetchedBlockfilesCacheSize", 3)
+	assert.Equal(t, "/tmp/archive", GetBlockfilesArchivePath())
+	assert.Equal(t, 5, GetArchiveLocalBlockfiles())
+	assert.Equal(t, 3, GetArchiveFetchedBlockfilesCacheSize())
 }
 
 func setUpCoreYAMLConfig() {
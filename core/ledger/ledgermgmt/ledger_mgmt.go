@@ -106,6 +106,237 @@ func CreateLedger(genesisBlock *common.Block) (ledger.PeerLedger, error) {
 	return l, nil
 }
 
+// CreateLedgerFromSnapshot creates a new ledger from the snapshot in the given dir.
+// The channel name recorded in the metadata of the snapshot is treated as the ledger id
+func CreateLedgerFromSnapshot(snapshotDir string) (ledger.PeerLedger, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+	logger.Infof("Creating ledger from snapshot [%s]", snapshotDir)
+	l, err := ledgerProvider.CreateFromSnapshot(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	bcInfo, err := l.GetBlockchainInfo()
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	lastBlock, err := l.GetBlockByNumber(bcInfo.Height - 1)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	id, err := utils.GetChainIDFromBlock(lastBlock)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	l = wrapLedger(id, l)
+	openedLedgers[id] = l
+	logger.Infof("Created ledger [%s] from snapshot [%s]", id, snapshotDir)
+	return l, nil
+}
+
+// GenerateSnapshot generates a snapshot of the ledger with the given id in the given dir.
+// If the ledger is not already opened, it is opened for the snapshot generation and closed thereafter
+func GenerateSnapshot(id string, snapshotDir string) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return ErrLedgerMgmtNotInitialized
+	}
+	l, ok := openedLedgers[id]
+	if !ok {
+		var err error
+		if l, err = ledgerProvider.Open(id); err != nil {
+			return err
+		}
+		defer l.Close()
+	} else {
+		l = l.(*closableLedger).PeerLedger
+	}
+	snapshotGenerator, ok := l.(ledger.SnapshotGenerator)
+	if !ok {
+		return errors.Errorf("ledger [%s] does not support generating snapshots", id)
+	}
+	return snapshotGenerator.GenerateSnapshot(snapshotDir)
+}
+
+// ExportNamespaceState invokes the given function for each key in the public state of the namespace of the ledger
+// with the given id. If the ledger is not already opened, it is opened for the export and closed thereafter
+func ExportNamespaceState(id string, namespace string, process func(*ledger.StateRecord) error) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return ErrLedgerMgmtNotInitialized
+	}
+	l, ok := openedLedgers[id]
+	if !ok {
+		var err error
+		if l, err = ledgerProvider.Open(id); err != nil {
+			return err
+		}
+		defer l.Close()
+	} else {
+		l = l.(*closableLedger).PeerLedger
+	}
+	stateManager, ok := l.(ledger.NamespaceStateManager)
+	if !ok {
+		return errors.Errorf("ledger [%s] does not support exporting the state of a namespace", id)
+	}
+	return stateManager.ExportNamespaceState(namespace, process)
+}
+
+// ImportNamespaceState writes the records returned by the given function to the public state of the namespace of
+// the ledger with the given id and returns the number of the imported records. The ledger is expected to not be opened
+func ImportNamespaceState(id string, namespace string, next func() (*ledger.StateRecord, error)) (uint64, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return 0, ErrLedgerMgmtNotInitialized
+	}
+	if _, ok := openedLedgers[id]; ok {
+		return 0, ErrLedgerAlreadyOpened
+	}
+	l, err := ledgerProvider.Open(id)
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	stateManager, ok := l.(ledger.NamespaceStateManager)
+	if !ok {
+		return 0, errors.Errorf("ledger [%s] does not support importing the state of a namespace", id)
+	}
+	return stateManager.ImportNamespaceState(namespace, next)
+}
+
+// GetStateIndexManager returns the manager of the indexes of the state database of the ledger with the given id.
+// The ledger is expected to be opened
+func GetStateIndexManager(id string) (ledger.StateIndexManager, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+	l, ok := openedLedgers[id]
+	if !ok {
+		return nil, errors.Errorf("ledger [%s] is not opened", id)
+	}
+	indexManager, ok := l.(*closableLedger).PeerLedger.(ledger.StateIndexManager)
+	if !ok {
+		return nil, errors.Errorf("ledger [%s] does not support managing state indexes", id)
+	}
+	return indexManager, nil
+}
+
+// GetStoreDiskUsage returns the approximate number of bytes used on the file system by the data of the ledger with
+// the given id, keyed by the name of the store
+func GetStoreDiskUsage(id string) (map[string]uint64, error) {
+	maintainer, err := getStoreMaintainer()
+	if err != nil {
+		return nil, err
+	}
+	return maintainer.StoreDiskUsage(id)
+}
+
+// CompactStore compacts the data of the ledger with the given id in the store with the given name. The ledger
+// may be opened and in use while the compaction is in progress
+func CompactStore(id string, store string) error {
+	maintainer, err := getStoreMaintainer()
+	if err != nil {
+		return err
+	}
+	return maintainer.CompactStore(id, store)
+}
+
+// getStoreMaintainer returns the ledger provider as a StoreMaintainer. The lock is not held
+// for the duration of a compaction, so that the ledgers can be opened and created meanwhile
+func getStoreMaintainer() (ledger.StoreMaintainer, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+	maintainer, ok := ledgerProvider.(ledger.StoreMaintainer)
+	if !ok {
+		return nil, errors.Errorf("ledger provider [%T] does not support maintaining the stores", ledgerProvider)
+	}
+	return maintainer, nil
+}
+
+// VerifyLedger verifies the integrity of the ledger with the given id. The ledger is expected to be not opened
+func VerifyLedger(id string) (*ledger.VerificationReport, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return nil, ErrLedgerMgmtNotInitialized
+	}
+	if _, ok := openedLedgers[id]; ok {
+		return nil, ErrLedgerAlreadyOpened
+	}
+	verifier, ok := ledgerProvider.(ledger.LedgerVerifier)
+	if !ok {
+		return nil, errors.Errorf("ledger provider [%T] does not support verifying ledgers", ledgerProvider)
+	}
+	return verifier.VerifyLedger(id)
+}
+
+// RebuildDBs regenerates the databases of the ledger with the given id from the block store and returns
+// the height of the rebuilt ledger. The ledger is expected to not be opened
+func RebuildDBs(id string) (uint64, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return 0, ErrLedgerMgmtNotInitialized
+	}
+	if _, ok := openedLedgers[id]; ok {
+		return 0, ErrLedgerAlreadyOpened
+	}
+	rebuilder, ok := ledgerProvider.(ledger.DBsRebuilder)
+	if !ok {
+		return 0, errors.Errorf("ledger provider [%T] does not support rebuilding the dbs of ledgers", ledgerProvider)
+	}
+	return rebuilder.RebuildDBs(id)
+}
+
+// RollbackLedger removes the blocks above the given block number from the ledger with the given id. The databases of
+// the ledger are rebuilt from the retained blocks when the ledger is opened next time. The ledger is expected to not be opened
+func RollbackLedger(id string, blockNum uint64) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return ErrLedgerMgmtNotInitialized
+	}
+	if _, ok := openedLedgers[id]; ok {
+		return ErrLedgerAlreadyOpened
+	}
+	rollbacker, ok := ledgerProvider.(ledger.LedgerRollbacker)
+	if !ok {
+		return errors.Errorf("ledger provider [%T] does not support rolling back ledgers", ledgerProvider)
+	}
+	return rollbacker.RollbackLedger(id, blockNum)
+}
+
+// RemoveLedger removes the ledger with the given id and deletes all the data of the ledger. The ledger is expected to not be opened
+func RemoveLedger(id string) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return ErrLedgerMgmtNotInitialized
+	}
+	if _, ok := openedLedgers[id]; ok {
+		return ErrLedgerAlreadyOpened
+	}
+	remover, ok := ledgerProvider.(ledger.LedgerRemover)
+	if !ok {
+		return errors.Errorf("ledger provider [%T] does not support removing ledgers", ledgerProvider)
+	}
+	return remover.RemoveLedger(id)
+}
+
 // OpenLedger returns a ledger for the given id
 func OpenLedger(id string) (ledger.PeerLedger, error) {
 	logger.Infof("Opening ledger with id = %s", id)
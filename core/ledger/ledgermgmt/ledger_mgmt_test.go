@@ -18,15 +18,19 @@ package ledgermgmt
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -130,6 +134,186 @@ func TestChaincodeInfoProvider(t *testing.T) {
 	assert.Equal(t, constructTestCCInfo("cc1", "cc1", "cc1"), ccInfo)
 }
 
+func TestGenerateSnapshotAndCreateLedgerFromSnapshot(t *testing.T) {
+	snapshotRootDir, err := ioutil.TempDir("", "ledgermgmt-snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(snapshotRootDir)
+
+	InitializeTestEnv()
+	gb, _ := test.MakeGenesisBlock("ledger1")
+	l, err := CreateLedger(gb)
+	assert.NoError(t, err)
+	// snapshot of an opened ledger
+	assert.NoError(t, GenerateSnapshot("ledger1", filepath.Join(snapshotRootDir, "snapshot1")))
+	l.Close()
+	// snapshot of a ledger that is not opened
+	assert.NoError(t, GenerateSnapshot("ledger1", filepath.Join(snapshotRootDir, "snapshot2")))
+	CleanupTestEnv()
+
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	l, err = CreateLedgerFromSnapshot(filepath.Join(snapshotRootDir, "snapshot2"))
+	assert.NoError(t, err)
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), bcInfo.Height)
+	ids, err := GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ledger1"}, ids)
+	_, err = OpenLedger("ledger1")
+	assert.Equal(t, ErrLedgerAlreadyOpened, err)
+}
+
+func TestExportImportNamespaceState(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	for _, ledgerID := range []string{"ledger1", "ledger2"} {
+		gb, _ := test.MakeGenesisBlock(ledgerID)
+		l, err := CreateLedger(gb)
+		assert.NoError(t, err)
+		l.Close()
+	}
+	records := []*ledger.StateRecord{{Key: "key1", Value: []byte("value1")}}
+	i := 0
+	next := func() (*ledger.StateRecord, error) {
+		if i == len(records) {
+			return nil, nil
+		}
+		i++
+		return records[i-1], nil
+	}
+	numImported, err := ImportNamespaceState("ledger1", "ns1", next)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), numImported)
+
+	// the export opens the ledger if it is not already opened
+	var exportedRecords []*ledger.StateRecord
+	process := func(record *ledger.StateRecord) error {
+		exportedRecords = append(exportedRecords, record)
+		return nil
+	}
+	assert.NoError(t, ExportNamespaceState("ledger1", "ns1", process))
+	assert.Equal(t, records, exportedRecords)
+	l, err := OpenLedger("ledger1")
+	assert.NoError(t, err)
+	defer l.Close()
+	exportedRecords = nil
+	assert.NoError(t, ExportNamespaceState("ledger1", "ns1", process))
+	assert.Equal(t, records, exportedRecords)
+
+	_, err = ImportNamespaceState("ledger1", "ns1", next)
+	assert.Equal(t, ErrLedgerAlreadyOpened, err)
+	assert.Equal(t, kvledger.ErrNonExistingLedgerID, ExportNamespaceState("non-existing-ledger", "ns1", process))
+	_, err = ImportNamespaceState("non-existing-ledger", "ns1", next)
+	assert.Equal(t, kvledger.ErrNonExistingLedgerID, err)
+}
+
+func TestGetStateIndexManager(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	gb, _ := test.MakeGenesisBlock("ledger1")
+	l, err := CreateLedger(gb)
+	assert.NoError(t, err)
+	indexManager, err := GetStateIndexManager("ledger1")
+	assert.NoError(t, err)
+	assert.NotNil(t, indexManager)
+	l.Close()
+
+	_, err = GetStateIndexManager("ledger1")
+	assert.EqualError(t, err, "ledger [ledger1] is not opened")
+}
+
+func TestStoreMaintenance(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	gb, _ := test.MakeGenesisBlock("ledger1")
+	l, err := CreateLedger(gb)
+	assert.NoError(t, err)
+	defer l.Close()
+	assert.NoError(t, CompactStore("ledger1", ledger.BlockIndexStore))
+	usage, err := GetStoreDiskUsage("ledger1")
+	assert.NoError(t, err)
+	assert.True(t, usage[ledger.BlockIndexStore] > 0)
+	assert.EqualError(t, CompactStore("ledger1", "blocks"), "store [blocks] does not support compaction, supported stores are [history index pvtdata state]")
+	_, err = GetStoreDiskUsage("non-existing-ledger")
+	assert.Equal(t, kvledger.ErrNonExistingLedgerID, err)
+}
+
+func TestVerifyLedger(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	gb, _ := test.MakeGenesisBlock("ledger1")
+	l, err := CreateLedger(gb)
+	assert.NoError(t, err)
+	_, err = VerifyLedger("ledger1")
+	assert.Equal(t, ErrLedgerAlreadyOpened, err)
+	l.Close()
+
+	report, err := VerifyLedger("ledger1")
+	assert.NoError(t, err)
+	assert.Equal(t, &ledger.VerificationReport{
+		LedgerID:         "ledger1",
+		BlockStoreHeight: 1,
+		StateDBHeight:    1,
+	}, report)
+	_, err = VerifyLedger("non-existing-ledger")
+	assert.Equal(t, kvledger.ErrNonExistingLedgerID, err)
+}
+
+func TestRebuildDBs(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	gb, _ := test.MakeGenesisBlock("ledger1")
+	l, err := CreateLedger(gb)
+	assert.NoError(t, err)
+	_, err = RebuildDBs("ledger1")
+	assert.Equal(t, ErrLedgerAlreadyOpened, err)
+	l.Close()
+
+	height, err := RebuildDBs("ledger1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), height)
+	_, err = RebuildDBs("non-existing-ledger")
+	assert.Equal(t, kvledger.ErrNonExistingLedgerID, err)
+}
+
+func TestRollbackLedger(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	bg, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	l, err := CreateLedger(gb)
+	assert.NoError(t, err)
+	assert.NoError(t, l.CommitWithPvtData(&ledger.BlockAndPvtData{Block: bg.NextBlock([][]byte{[]byte("tx")})}))
+	assert.Equal(t, ErrLedgerAlreadyOpened, RollbackLedger("ledger1", 0))
+	l.Close()
+
+	assert.NoError(t, RollbackLedger("ledger1", 0))
+	l, err = OpenLedger("ledger1")
+	assert.NoError(t, err)
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), bcInfo.Height)
+	assert.Equal(t, kvledger.ErrNonExistingLedgerID, RollbackLedger("non-existing-ledger", 0))
+}
+
+func TestRemoveLedger(t *testing.T) {
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+	gb, _ := test.MakeGenesisBlock("ledger1")
+	l, err := CreateLedger(gb)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrLedgerAlreadyOpened, RemoveLedger("ledger1"))
+	l.Close()
+
+	assert.NoError(t, RemoveLedger("ledger1"))
+	ids, err := GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.Empty(t, ids)
+	_, err = OpenLedger("ledger1")
+	assert.Equal(t, kvledger.ErrNonExistingLedgerID, err)
+	assert.Equal(t, kvledger.ErrNonExistingLedgerID, RemoveLedger("ledger1"))
+}
+
 func constructTestLedgerID(i int) string {
 	return fmt.Sprintf("ledger_%06d", i)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
@@ -48,9 +49,18 @@ func NewProvider() *Provider {
 		blkstorage.IndexableAttrTxValidationCode,
 	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
-		indexConfig)
+	blockStoreConf := fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize())
+	if archivePath := ledgerconfig.GetBlockfilesArchivePath(); archivePath != "" {
+		blockStoreConf = fsblkstorage.NewConfWithArchive(
+			ledgerconfig.GetBlockStorePath(),
+			ledgerconfig.GetMaxBlockfileSize(),
+			&fsblkstorage.ArchiveConf{
+				Archive:                    fsblkstorage.NewDirArchive(archivePath),
+				LocalBlockfiles:            ledgerconfig.GetArchiveLocalBlockfiles(),
+				FetchedBlockfilesCacheSize: ledgerconfig.GetArchiveFetchedBlockfilesCacheSize(),
+			})
+	}
+	blockStoreProvider := fsblkstorage.NewProvider(blockStoreConf, indexConfig)
 
 	pvtStoreProvider := pvtdatastorage.NewProvider()
 	return &Provider{blockStoreProvider, pvtStoreProvider}
@@ -75,6 +85,57 @@ func (p *Provider) Open(ledgerid string) (*Store, error) {
 	return store, nil
 }
 
+// ImportFromSnapshot initializes the block store for the ledger from the txids exported in a snapshot.
+// The pvt data store gets initialized at the height of the snapshot when the store is opened for the first time
+func (p *Provider) ImportFromSnapshot(ledgerid string, snapshotDir string, snapshotInfo *blkstorage.SnapshotInfo) error {
+	return p.blkStoreProvider.ImportFromSnapshot(ledgerid, snapshotDir, snapshotInfo)
+}
+
+// VerifyBlockStore verifies the integrity of the block store for the ledger, while the ledger is not opened
+func (p *Provider) VerifyBlockStore(ledgerid string) (*blkstorage.VerificationResult, error) {
+	verifier, ok := p.blkStoreProvider.(blkstorage.BlockStoreVerifier)
+	if !ok {
+		return nil, errors.Errorf("block store provider [%T] does not support verification", p.blkStoreProvider)
+	}
+	return verifier.Verify(ledgerid)
+}
+
+// RollbackBlockStore removes the blocks above the given block number from the block store for the ledger, while the
+// ledger is not opened. The pvt data store is not rolled back; the pvt data of the removed blocks is retained in the
+// pvt data store and is not written again when the blocks are committed again
+func (p *Provider) RollbackBlockStore(ledgerid string, blockNum uint64) error {
+	rollbacker, ok := p.blkStoreProvider.(blkstorage.BlockStoreRollbacker)
+	if !ok {
+		return errors.Errorf("block store provider [%T] does not support rollback", p.blkStoreProvider)
+	}
+	return rollbacker.Rollback(ledgerid, blockNum)
+}
+
+// Remove removes the block store and deletes the pvt data of the ledger, while the ledger is not opened
+func (p *Provider) Remove(ledgerid string) error {
+	remover, ok := p.blkStoreProvider.(blkstorage.BlockStoreRemover)
+	if !ok {
+		return errors.Errorf("block store provider [%T] does not support removal", p.blkStoreProvider)
+	}
+	if err := p.pvtdataStoreProvider.Drop(ledgerid); err != nil {
+		return errors.WithMessage(err, "error while dropping the pvt data store")
+	}
+	return remover.Remove(ledgerid)
+}
+
+// StoreMaintainers returns the block index and the pvt data store, keyed by their names, if the respective
+// providers support reporting the disk usage and compaction
+func (p *Provider) StoreMaintainers() map[string]leveldbhelper.StoreMaintainer {
+	maintainers := map[string]leveldbhelper.StoreMaintainer{}
+	if maintainer, ok := p.blkStoreProvider.(leveldbhelper.StoreMaintainer); ok {
+		maintainers[ledger.BlockIndexStore] = maintainer
+	}
+	if maintainer, ok := p.pvtdataStoreProvider.(leveldbhelper.StoreMaintainer); ok {
+		maintainers[ledger.PvtDataStore] = maintainer
+	}
+	return maintainers
+}
+
 // Close closes the provider
 func (p *Provider) Close() {
 	p.blkStoreProvider.Close()
@@ -205,6 +266,22 @@ func (s *Store) GetPvtDataByNum(blockNum uint64, filter ledger.PvtNsCollFilter)
 	return s.getPvtDataByNumWithoutLock(blockNum, filter)
 }
 
+// GetRetainedPvtData returns the pvt data that is retained in the pvt data store for a block that is not present in
+// the block store. This is the case for a block that is committed again after the block store has been rolled back,
+// as the pvt data store is not rolled back. A nil value is returned if the block has not been committed to the pvt data store
+func (s *Store) GetRetainedPvtData(blockNum uint64) ([]*ledger.TxPvtData, error) {
+	s.rwlock.RLock()
+	defer s.rwlock.RUnlock()
+	pvtBlkStoreHt, err := s.pvtdataStore.LastCommittedBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	if pvtBlkStoreHt < blockNum+1 {
+		return nil, nil
+	}
+	return s.getPvtDataByNumWithoutLock(blockNum, nil)
+}
+
 // getPvtDataByNumWithoutLock returns only the pvt data  corresponding to the given block number.
 // This function does not acquire a readlock and it is expected that in most of the circumstances, the caller
 // possesses a read lock on `s.rwlock`
@@ -308,7 +385,9 @@ func (s *Store) syncPvtdataStoreWithBlockStore() error {
 		return err
 	}
 
-	if bcInfo.Height == pvtdataStoreHt {
+	// the block store is below the pvt data store if the block store has been rolled back,
+	// in which case the block of the pending batch is committed again later
+	if bcInfo.Height <= pvtdataStoreHt {
 		return s.pvtdataStore.Rollback()
 	}
 
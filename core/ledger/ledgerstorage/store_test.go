@@ -254,6 +254,52 @@ func TestCrashBeforePvtdataStoreCommit(t *testing.T) {
 	assert.True(t, proto.Equal(dataAtCrash.Block, blkAndPvtdata.Block))
 }
 
+func TestRollbackBlockStore(t *testing.T) {
+	testEnv := newTestEnv(t)
+	defer testEnv.cleanup()
+	provider := NewProvider()
+	defer provider.Close()
+	store, err := provider.Open("testLedger")
+	assert.NoError(t, err)
+	store.Init(btlPolicyForSampleData())
+
+	sampleData := sampleDataWithPvtdataForAllTxs(t)
+	for _, sampleDatum := range sampleData[0:5] {
+		assert.NoError(t, store.CommitWithPvtData(sampleDatum))
+	}
+	// mimic a crash after the pvt data store is prepared for the next block
+	var pvtdataAtCrash []*ledger.TxPvtData
+	for _, p := range sampleData[5].PvtData {
+		pvtdataAtCrash = append(pvtdataAtCrash, p)
+	}
+	assert.NoError(t, store.pvtdataStore.Prepare(5, pvtdataAtCrash, nil))
+	store.Shutdown()
+
+	assert.NoError(t, provider.RollbackBlockStore("testLedger", 2))
+	store, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	store.Init(btlPolicyForSampleData())
+	bcInfo, err := store.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), bcInfo.Height)
+
+	// the pvt data of the removed blocks is retained in the pvt data store
+	retainedPvtData, err := store.GetRetainedPvtData(3)
+	assert.NoError(t, err)
+	assert.Len(t, retainedPvtData, 2)
+	retainedPvtData, err = store.GetRetainedPvtData(5)
+	assert.NoError(t, err)
+	assert.Nil(t, retainedPvtData)
+
+	for _, sampleDatum := range sampleData[3:6] {
+		assert.NoError(t, store.CommitWithPvtData(sampleDatum))
+	}
+	pvtdata, err := store.GetPvtDataByNum(5, nil)
+	assert.NoError(t, err)
+	assert.Len(t, pvtdata, 2)
+}
+
 func TestAddAfterPvtdataStoreError(t *testing.T) {
 	testEnv := newTestEnv(t)
 	defer testEnv.cleanup()
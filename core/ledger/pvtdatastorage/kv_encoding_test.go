@@ -16,10 +16,22 @@ import (
 
 func TestDataKeyEncoding(t *testing.T) {
 	dataKey1 := &dataKey{nsCollBlk: nsCollBlk{ns: "ns1", coll: "coll1", blkNum: 2}, txNum: 5}
-	datakey2 := decodeDatakey(encodeDataKey(dataKey1))
+	datakey2, err := decodeDatakey(encodeDataKey(dataKey1))
+	assert.NoError(t, err)
 	assert.Equal(t, dataKey1, datakey2)
 }
 
+func TestDecodeCorruptedKeys(t *testing.T) {
+	_, err := decodeDatakey([]byte{0x02, 0x09, 0x01})
+	assert.Contains(t, err.Error(), "error decoding the data key")
+	_, err = decodeExpiryKey([]byte{0x03, 0x09, 0x01})
+	assert.Contains(t, err.Error(), "error decoding the expiry key")
+	_, err = v11Format([]byte{0x02, 0x09, 0x01})
+	assert.Error(t, err)
+	_, _, err = v11DecodePK([]byte{0x02, 0x09, 0x01})
+	assert.Error(t, err)
+}
+
 func TestDatakeyRange(t *testing.T) {
 	blockNum := uint64(20)
 	startKey, endKey := datakeyRange(blockNum)
@@ -15,6 +15,8 @@ import (
 // private write sets for a ledger
 type Provider interface {
 	OpenStore(id string) (Store, error)
+	// Drop deletes all the pvt data and the bookkeeping of the pvt data store for the given id
+	Drop(id string) error
 	Close()
 }
 
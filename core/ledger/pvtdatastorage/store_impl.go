@@ -130,6 +130,21 @@ func (p *provider) OpenStore(ledgerid string) (Store, error) {
 	return s, nil
 }
 
+// Drop implements the function in the interface 'Provider'
+func (p *provider) Drop(ledgerid string) error {
+	return p.dbProvider.GetDBHandle(ledgerid).DeleteAll()
+}
+
+// DiskUsage implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (p *provider) DiskUsage(ledgerid string) (uint64, error) {
+	return p.dbProvider.GetDBHandle(ledgerid).DiskUsage()
+}
+
+// Compact implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (p *provider) Compact(ledgerid string) error {
+	return p.dbProvider.GetDBHandle(ledgerid).Compact()
+}
+
 // Close closes the store
 func (p *provider) Close() {
 	p.dbProvider.Close()
@@ -625,11 +640,18 @@ func (s *store) GetPvtDataByBlockNum(blockNum uint64, filter ledger.PvtNsCollFil
 
 	for itr.Next() {
 		dataKeyBytes := itr.Key()
-		if v11Format(dataKeyBytes) {
+		v11Fmt, err := v11Format(dataKeyBytes)
+		if err != nil {
+			return nil, err
+		}
+		if v11Fmt {
 			return v11RetrievePvtdata(itr, filter)
 		}
 		dataValueBytes := itr.Value()
-		dataKey := decodeDatakey(dataKeyBytes)
+		dataKey, err := decodeDatakey(dataKeyBytes)
+		if err != nil {
+			return nil, err
+		}
 		expired, err := isExpired(dataKey.nsCollBlk, s.btlPolicy, s.lastCommittedBlock)
 		if err != nil {
 			return nil, err
@@ -820,7 +842,10 @@ func (s *store) retrieveExpiryEntries(minBlkNum, maxBlkNum uint64) ([]*expiryEnt
 	for itr.Next() {
 		expiryKeyBytes := itr.Key()
 		expiryValueBytes := itr.Value()
-		expiryKey := decodeExpiryKey(expiryKeyBytes)
+		expiryKey, err := decodeExpiryKey(expiryKeyBytes)
+		if err != nil {
+			return nil, err
+		}
 		expiryValue, err := decodeExpiryValue(expiryValueBytes)
 		if err != nil {
 			return nil, err
@@ -12,17 +12,24 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/pkg/errors"
 )
 
-func v11Format(datakeyBytes []byte) bool {
-	_, n := version.NewHeightFromBytes(datakeyBytes[1:])
+func v11Format(datakeyBytes []byte) (bool, error) {
+	_, n, err := version.NewHeightFromBytes(datakeyBytes[1:])
+	if err != nil {
+		return false, errors.WithMessage(err, "error decoding the data key")
+	}
 	remainingBytes := datakeyBytes[n+1:]
-	return len(remainingBytes) == 0
+	return len(remainingBytes) == 0, nil
 }
 
-func v11DecodePK(key blkTranNumKey) (blockNum uint64, tranNum uint64) {
-	height, _ := version.NewHeightFromBytes(key[1:])
-	return height.BlockNum, height.TxNum
+func v11DecodePK(key blkTranNumKey) (blockNum uint64, tranNum uint64, err error) {
+	height, _, err := version.NewHeightFromBytes(key[1:])
+	if err != nil {
+		return 0, 0, errors.WithMessage(err, "error decoding the data key")
+	}
+	return height.BlockNum, height.TxNum, nil
 }
 
 func v11DecodePvtRwSet(encodedBytes []byte) (*rwset.TxPvtReadWriteSet, error) {
@@ -48,9 +55,11 @@ func v11RetrievePvtdata(itr *leveldbhelper.Iterator, filter ledger.PvtNsCollFilt
 }
 
 func v11DecodeKV(k, v []byte, filter ledger.PvtNsCollFilter) (*ledger.TxPvtData, error) {
-	bNum, tNum := v11DecodePK(k)
+	bNum, tNum, err := v11DecodePK(k)
+	if err != nil {
+		return nil, err
+	}
 	var pvtWSet *rwset.TxPvtReadWriteSet
-	var err error
 	if pvtWSet, err = v11DecodePvtRwSet(v); err != nil {
 		return nil, err
 	}
@@ -77,10 +77,15 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.state.totalQueryLimit", 10000)
 	viper.Set("ledger.state.couchDBConfig.internalQueryLimit", 1000)
 	viper.Set("ledger.state.stateDatabase", "goleveldb")
+	viper.Set("ledger.state.stateDatabasePlugin", "")
+	viper.Set("ledger.state.richQueryPhantomProtection", false)
+	viper.Set("ledger.state.cache.size", 0)
+	viper.Set("ledger.state.cache.chaincodes", map[string]interface{}{})
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
+	viper.Set("ledger.blockchain.archive.dir", "")
 }
 
 // ParseTestParams parses tests params
@@ -31,8 +31,27 @@ type CouchDBDef struct {
 	MaxRetriesOnStartup   int
 	RequestTimeout        time.Duration
 	CreateGlobalChangesDB bool
+	ConnectionPool        ConnectionPoolDef
 }
 
+// ConnectionPoolDef contains the parameters of the pool of HTTP connections to a CouchDB instance
+// and the number of requests that can be made in parallel to a database
+type ConnectionPoolDef struct {
+	// MaxIdleConnsPerHost is the number of idle connections that are kept open for reuse
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the number of connections, zero means no limit
+	MaxConnsPerHost int
+	// IdleConnTimeout is the duration after which an idle connection is closed
+	IdleConnTimeout time.Duration
+	// MaxParallelRequestsPerDB limits the number of requests in flight to a database, zero means no limit
+	MaxParallelRequestsPerDB int
+}
+
+const (
+	defaultMaxIdleConnsPerHost = 100
+	defaultIdleConnTimeout     = 90 * time.Second
+)
+
 //GetCouchDBDefinition exposes the useCouchDB variable
 func GetCouchDBDefinition() *CouchDBDef {
 
@@ -43,6 +62,30 @@ func GetCouchDBDefinition() *CouchDBDef {
 	maxRetriesOnStartup := viper.GetInt("ledger.state.couchDBConfig.maxRetriesOnStartup")
 	requestTimeout := viper.GetDuration("ledger.state.couchDBConfig.requestTimeout")
 	createGlobalChangesDB := viper.GetBool("ledger.state.couchDBConfig.createGlobalChangesDB")
+	connectionPool := ConnectionPoolDef{
+		MaxIdleConnsPerHost:      viper.GetInt("ledger.state.couchDBConfig.maxIdleConnsPerHost"),
+		MaxConnsPerHost:          viper.GetInt("ledger.state.couchDBConfig.maxConnsPerHost"),
+		IdleConnTimeout:          viper.GetDuration("ledger.state.couchDBConfig.idleConnTimeout"),
+		MaxParallelRequestsPerDB: viper.GetInt("ledger.state.couchDBConfig.maxParallelRequestsPerDB"),
+	}
+
+	return &CouchDBDef{couchDBAddress, username, password, maxRetries, maxRetriesOnStartup, requestTimeout, createGlobalChangesDB, connectionPool}
+}
 
-	return &CouchDBDef{couchDBAddress, username, password, maxRetries, maxRetriesOnStartup, requestTimeout, createGlobalChangesDB}
+// withDefaults returns the parameters of the connection pool where the ones that are not set are
+// replaced by their default values
+func (def ConnectionPoolDef) withDefaults() ConnectionPoolDef {
+	if def.MaxIdleConnsPerHost <= 0 {
+		def.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+	if def.MaxConnsPerHost < 0 {
+		def.MaxConnsPerHost = 0
+	}
+	if def.IdleConnTimeout <= 0 {
+		def.IdleConnTimeout = defaultIdleConnTimeout
+	}
+	if def.MaxParallelRequestsPerDB < 0 {
+		def.MaxParallelRequestsPerDB = 0
+	}
+	return def
 }
@@ -24,4 +24,28 @@ func TestGetCouchDBDefinition(t *testing.T) {
 	assert.Equal(t, 3, couchDBDef.MaxRetries)
 	assert.Equal(t, 20, couchDBDef.MaxRetriesOnStartup)
 	assert.Equal(t, time.Second*35, couchDBDef.RequestTimeout)
+	assert.Equal(t, 100, couchDBDef.ConnectionPool.MaxIdleConnsPerHost)
+	assert.Equal(t, 0, couchDBDef.ConnectionPool.MaxConnsPerHost)
+	assert.Equal(t, 90*time.Second, couchDBDef.ConnectionPool.IdleConnTimeout)
+	assert.Equal(t, 0, couchDBDef.ConnectionPool.MaxParallelRequestsPerDB)
+}
+
+func TestConnectionPoolDefaults(t *testing.T) {
+	pool := ConnectionPoolDef{}.withDefaults()
+	assert.Equal(t, ConnectionPoolDef{
+		MaxIdleConnsPerHost: defaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     defaultIdleConnTimeout,
+	}, pool)
+
+	pool = ConnectionPoolDef{
+		MaxIdleConnsPerHost:      10,
+		MaxConnsPerHost:          20,
+		IdleConnTimeout:          time.Minute,
+		MaxParallelRequestsPerDB: 4,
+	}
+	assert.Equal(t, pool, pool.withDefaults())
+
+	pool = ConnectionPoolDef{MaxConnsPerHost: -1, MaxParallelRequestsPerDB: -1}.withDefaults()
+	assert.Equal(t, 0, pool.MaxConnsPerHost)
+	assert.Equal(t, 0, pool.MaxParallelRequestsPerDB)
 }
@@ -24,6 +24,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -125,6 +126,7 @@ type CouchConnectionDef struct {
 	MaxRetriesOnStartup   int
 	RequestTimeout        time.Duration
 	CreateGlobalChangesDB bool
+	ConnectionPool        ConnectionPoolDef
 }
 
 //CouchInstance represents a CouchDB instance
@@ -132,6 +134,10 @@ type CouchInstance struct {
 	conf   CouchConnectionDef //connection configuration
 	client *http.Client       // a client to connect to this instance
 	stats  *stats
+
+	// dbRequestSlots limits the number of requests in flight per database
+	dbRequestSlotsLock sync.Mutex
+	dbRequestSlots     map[string]chan struct{}
 }
 
 //CouchDatabase represents a database within a CouchDB instance
@@ -188,6 +194,20 @@ type BatchRetrieveDocMetadataResponse struct {
 	} `json:"rows"`
 }
 
+//BulkGetResponse is used for processing REST _bulk_get responses from CouchDB
+type BulkGetResponse struct {
+	Results []struct {
+		ID   string `json:"id"`
+		Docs []struct {
+			OK    json.RawMessage `json:"ok"`
+			Error *struct {
+				Error  string `json:"error"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"docs"`
+	} `json:"results"`
+}
+
 //BatchUpdateResponse defines a structure for batch update response
 type BatchUpdateResponse struct {
 	ID     string `json:"id"`
@@ -254,7 +274,7 @@ func CreateConnectionDefinition(couchDBAddress, username, password string, maxRe
 
 	//return an object containing the connection information
 	return &CouchConnectionDef{finalURL.String(), username, password, maxRetries,
-		maxRetriesOnStartup, requestTimeout, createGlobalChangesDB}, nil
+		maxRetriesOnStartup, requestTimeout, createGlobalChangesDB, ConnectionPoolDef{}}, nil
 
 }
 
@@ -451,6 +471,36 @@ func (couchInstance *CouchInstance) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// RetrieveApplicationDBNames returns the names of all the databases in the CouchDB instance,
+// excluding the system databases (i.e., the databases with names that begin with an underscore)
+func (couchInstance *CouchInstance) RetrieveApplicationDBNames() ([]string, error) {
+	connectURL, err := url.Parse(couchInstance.conf.URL)
+	if err != nil {
+		logger.Errorf("URL parse error: %s", err)
+		return nil, errors.Wrapf(err, "error parsing CouchDB URL: %s", couchInstance.conf.URL)
+	}
+	connectURL.Path = "/_all_dbs"
+
+	resp, _, err := couchInstance.handleRequest(context.Background(), http.MethodGet, "", "RetrieveApplicationDBNames", connectURL, nil,
+		couchInstance.conf.Username, couchInstance.conf.Password, couchInstance.conf.MaxRetries, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+
+	var dbNames []string
+	if err := json.NewDecoder(resp.Body).Decode(&dbNames); err != nil {
+		return nil, errors.Wrap(err, "error decoding response body")
+	}
+	var applicationDBNames []string
+	for _, dbName := range dbNames {
+		if !strings.HasPrefix(dbName, "_") {
+			applicationDBNames = append(applicationDBNames, dbName)
+		}
+	}
+	return applicationDBNames, nil
+}
+
 //DropDatabase provides method to drop an existing database
 func (dbclient *CouchDatabase) DropDatabase() (*DBOperationResponse, error) {
 	dbName := dbclient.DBName
@@ -1342,6 +1392,77 @@ func (dbclient *CouchDatabase) WarmIndexAllIndexes() error {
 
 }
 
+//ActiveTask contains the definition of a task running in CouchDB, as reported by _active_tasks
+type ActiveTask struct {
+	Type           string `json:"type"`
+	Database       string `json:"database"`
+	DesignDocument string `json:"design_document"`
+	Progress       int    `json:"progress"`
+}
+
+// RetrieveActiveTasks returns the tasks that are running in the CouchDB instance, such as the builds of the indexes
+func (couchInstance *CouchInstance) RetrieveActiveTasks() ([]*ActiveTask, error) {
+	connectURL, err := url.Parse(couchInstance.conf.URL)
+	if err != nil {
+		logger.Errorf("URL parse error: %s", err)
+		return nil, errors.Wrapf(err, "error parsing CouchDB URL: %s", couchInstance.conf.URL)
+	}
+	connectURL.Path = "/_active_tasks"
+
+	resp, _, err := couchInstance.handleRequest(context.Background(), http.MethodGet, "", "RetrieveActiveTasks", connectURL, nil,
+		"", "", couchInstance.conf.MaxRetries, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+
+	var tasks []*ActiveTask
+	if err := json.NewDecoder(resp.Body).Decode(&tasks); err != nil {
+		return nil, errors.Wrap(err, "error decoding response body")
+	}
+	return tasks, nil
+}
+
+// IndexBuildProgress returns the progress, in percent, of the builds of the indexes of the database that are
+// in progress, keyed by the design document of the indexes. The design documents whose indexes are not being
+// built are not included. CouchDB builds the indexes of the shards of a database independently, in which
+// case the progress is averaged over the shards
+func (dbclient *CouchDatabase) IndexBuildProgress() (map[string]int, error) {
+	tasks, err := dbclient.CouchInstance.RetrieveActiveTasks()
+	if err != nil {
+		return nil, err
+	}
+	progressSum := map[string]int{}
+	shardCount := map[string]int{}
+	for _, task := range tasks {
+		if task.Type != "indexer" || activeTaskDBName(task.Database) != dbclient.DBName {
+			continue
+		}
+		designDoc := strings.TrimPrefix(task.DesignDocument, "_design/")
+		progressSum[designDoc] += task.Progress
+		shardCount[designDoc]++
+	}
+	progress := map[string]int{}
+	for designDoc, sum := range progressSum {
+		progress[designDoc] = sum / shardCount[designDoc]
+	}
+	return progress, nil
+}
+
+// activeTaskDBName returns the name of the database of an active task. CouchDB 2.x reports the database
+// of a shard in the form `shards/<range>/<database>.<suffix>`
+func activeTaskDBName(database string) string {
+	parts := strings.SplitN(database, "/", 3)
+	if len(parts) != 3 || parts[0] != "shards" {
+		return database
+	}
+	name := parts[2]
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
 //GetDatabaseSecurity method provides function to retrieve the security config for a database
 func (dbclient *CouchDatabase) GetDatabaseSecurity() (*DatabaseSecurity, error) {
 	dbName := dbclient.DBName
@@ -1504,6 +1625,114 @@ func (dbclient *CouchDatabase) BatchRetrieveDocumentMetadata(keys []string) ([]*
 
 }
 
+//BatchRetrieveDocuments - batch method to retrieve the documents for a set of keys in a single
+//_bulk_get request. The documents that do not exist or are deleted are not returned. The attachments
+//of the documents are included if includeAttachments is set, the JSON value of a document keeps
+//the fields _id and _rev
+func (dbclient *CouchDatabase) BatchRetrieveDocuments(keys []string, includeAttachments bool) ([]*CouchDoc, error) {
+
+	logger.Debugf("[%s] Entering BatchRetrieveDocuments()  keys=%s", dbclient.DBName, keys)
+
+	bulkGetURL, err := url.Parse(dbclient.CouchInstance.conf.URL)
+	if err != nil {
+		logger.Errorf("URL parse error: %s", err)
+		return nil, errors.Wrapf(err, "error parsing CouchDB URL: %s", dbclient.CouchInstance.conf.URL)
+	}
+
+	queryParms := bulkGetURL.Query()
+	if includeAttachments {
+		queryParms.Add("attachments", "true")
+	}
+
+	type docRef struct {
+		ID string `json:"id"`
+	}
+	docRefs := make([]docRef, len(keys))
+	for i, key := range keys {
+		docRefs[i] = docRef{ID: key}
+	}
+	jsonKeys, err := json.Marshal(map[string]interface{}{"docs": docRefs})
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling json data")
+	}
+
+	//get the number of retries
+	maxRetries := dbclient.CouchInstance.conf.MaxRetries
+
+	resp, _, err := dbclient.handleRequest(http.MethodPost, "BatchRetrieveDocuments", bulkGetURL, jsonKeys, "", "", maxRetries, true, &queryParms, "_bulk_get")
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+
+	//handle as JSON document
+	jsonResponseRaw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading response body")
+	}
+
+	var jsonResponse = &BulkGetResponse{}
+	if err := json.Unmarshal(jsonResponseRaw, jsonResponse); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling json data")
+	}
+
+	couchDocs := []*CouchDoc{}
+	for _, result := range jsonResponse.Results {
+		for _, doc := range result.Docs {
+			if doc.Error != nil {
+				if doc.Error.Error == "not_found" {
+					continue
+				}
+				return nil, errors.Errorf("error retrieving document ID: %s. Error: %s, Reason: %s", result.ID, doc.Error.Error, doc.Error.Reason)
+			}
+			couchDoc, err := bulkGetDocToCouchDoc(doc.OK)
+			if err != nil {
+				return nil, err
+			}
+			if couchDoc != nil {
+				couchDocs = append(couchDocs, couchDoc)
+			}
+		}
+	}
+
+	logger.Debugf("[%s] Exiting BatchRetrieveDocuments()", dbclient.DBName)
+
+	return couchDocs, nil
+}
+
+// bulkGetDocToCouchDoc converts a document returned by _bulk_get, where the attachments are inlined
+// in the field _attachments, to a CouchDoc. Nil is returned for a deleted document
+func bulkGetDocToCouchDoc(rawDoc json.RawMessage) (*CouchDoc, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(rawDoc, &fields); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling json data")
+	}
+	if deleted, ok := fields["_deleted"]; ok && string(deleted) == "true" {
+		return nil, nil
+	}
+	rawAttachments, ok := fields["_attachments"]
+	if !ok {
+		return &CouchDoc{JSONValue: rawDoc}, nil
+	}
+	attachmentsMap := make(map[string]*AttachmentInfo)
+	if err := json.Unmarshal(rawAttachments, &attachmentsMap); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling json data")
+	}
+	couchDoc := &CouchDoc{}
+	for name, attachment := range attachmentsMap {
+		attachment.Name = name
+		attachment.Length = uint64(len(attachment.AttachmentBytes))
+		couchDoc.Attachments = append(couchDoc.Attachments, attachment)
+	}
+	delete(fields, "_attachments")
+	jsonValue, err := json.Marshal(fields)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling json data")
+	}
+	couchDoc.JSONValue = jsonValue
+	return couchDoc, nil
+}
+
 //BatchUpdateDocuments - batch method to batch update documents
 func (dbclient *CouchDatabase) BatchUpdateDocuments(documents []*CouchDoc) ([]*BatchUpdateResponse, error) {
 	dbName := dbclient.DBName
@@ -1627,8 +1856,10 @@ func (dbclient *CouchDatabase) handleRequestWithRevisionRetry(id, method, dbName
 		}
 
 		//handle the request for saving/deleting the couchdb data
+		release := dbclient.CouchInstance.acquireDBRequestSlot(dbName)
 		resp, couchDBReturn, errResp = dbclient.CouchInstance.handleRequest(context.Background(), method, dbName, functionName, connectURL,
 			data, rev, multipartBoundary, maxRetries, keepConnectionOpen, queryParms, id)
+		release()
 
 		//If there was a 409 conflict error during the save/delete, log it and retry it.
 		//Otherwise, break out of the retry loop
@@ -1647,12 +1878,35 @@ func (dbclient *CouchDatabase) handleRequestWithRevisionRetry(id, method, dbName
 func (dbclient *CouchDatabase) handleRequest(method, functionName string, connectURL *url.URL, data []byte, rev, multipartBoundary string,
 	maxRetries int, keepConnectionOpen bool, queryParms *url.Values, pathElements ...string) (*http.Response, *DBReturn, error) {
 
+	release := dbclient.CouchInstance.acquireDBRequestSlot(dbclient.DBName)
+	defer release()
 	return dbclient.CouchInstance.handleRequest(context.Background(),
 		method, dbclient.DBName, functionName, connectURL, data, rev, multipartBoundary,
 		maxRetries, keepConnectionOpen, queryParms, pathElements...,
 	)
 }
 
+// acquireDBRequestSlot waits until the number of requests in flight to the database is below the limit
+// configured in `MaxParallelRequestsPerDB` and returns the function that releases the slot taken
+func (couchInstance *CouchInstance) acquireDBRequestSlot(dbName string) func() {
+	maxParallelRequests := couchInstance.conf.ConnectionPool.MaxParallelRequestsPerDB
+	if maxParallelRequests <= 0 {
+		return func() {}
+	}
+	couchInstance.dbRequestSlotsLock.Lock()
+	if couchInstance.dbRequestSlots == nil {
+		couchInstance.dbRequestSlots = make(map[string]chan struct{})
+	}
+	slots, ok := couchInstance.dbRequestSlots[dbName]
+	if !ok {
+		slots = make(chan struct{}, maxParallelRequests)
+		couchInstance.dbRequestSlots[dbName] = slots
+	}
+	couchInstance.dbRequestSlotsLock.Unlock()
+	slots <- struct{}{}
+	return func() { <-slots }
+}
+
 //handleRequest method is a generic http request handler.
 // If it returns an error, it ensures that the response body is closed, else it is the
 // callee's responsibility to close response correctly.
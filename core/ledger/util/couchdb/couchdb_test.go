@@ -151,7 +151,7 @@ func TestHealthCheck(t *testing.T) {
 	badConnectDef := CouchConnectionDef{URL: badURL, Username: "", Password: "",
 		MaxRetries: 1, MaxRetriesOnStartup: 1, RequestTimeout: time.Second * 30}
 
-	badCouchDBInstance := CouchInstance{badConnectDef, client, newStats(&disabled.Provider{})}
+	badCouchDBInstance := CouchInstance{conf: badConnectDef, client: client, stats: newStats(&disabled.Provider{})}
 	err := badCouchDBInstance.HealthCheck(context.Background())
 	assert.Error(t, err, "Health check should result in an error if unable to connect to couch db")
 	assert.Contains(t, err.Error(), "failed to connect to couch db")
@@ -161,7 +161,7 @@ func TestHealthCheck(t *testing.T) {
 	goodConnectDef := CouchConnectionDef{URL: goodURL, Username: "", Password: "",
 		MaxRetries: 1, MaxRetriesOnStartup: 1, RequestTimeout: time.Second * 30}
 
-	goodCouchDBInstance := CouchInstance{goodConnectDef, client, newStats(&disabled.Provider{})}
+	goodCouchDBInstance := CouchInstance{conf: goodConnectDef, client: client, stats: newStats(&disabled.Provider{})}
 	err = goodCouchDBInstance.HealthCheck(context.Background())
 	assert.NoError(t, err)
 }
@@ -175,7 +175,7 @@ func TestBadCouchDBInstance(t *testing.T) {
 	client := &http.Client{}
 
 	//Create a bad couchdb instance
-	badCouchDBInstance := CouchInstance{badConnectDef, client, newStats(&disabled.Provider{})}
+	badCouchDBInstance := CouchInstance{conf: badConnectDef, client: client, stats: newStats(&disabled.Provider{})}
 
 	//Create a bad CouchDatabase
 	badDB := CouchDatabase{&badCouchDBInstance, "baddb", 1}
@@ -232,6 +232,10 @@ func TestBadCouchDBInstance(t *testing.T) {
 	_, err = badDB.BatchRetrieveDocumentMetadata(nil)
 	assert.Error(t, err, "Error should have been thrown with BatchRetrieveDocumentMetadata and invalid connection")
 
+	//Test BatchRetrieveDocuments with bad connection
+	_, err = badDB.BatchRetrieveDocuments(nil, true)
+	assert.Error(t, err, "Error should have been thrown with BatchRetrieveDocuments and invalid connection")
+
 	//Test BatchUpdateDocuments with bad connection
 	_, err = badDB.BatchUpdateDocuments(nil)
 	assert.Error(t, err, "Error should have been thrown with BatchUpdateDocuments and invalid connection")
@@ -795,6 +799,62 @@ func TestDBSaveAttachment(t *testing.T) {
 
 }
 
+func TestBatchRetrieveDocuments(t *testing.T) {
+
+	database := "testbatchretrievedocuments"
+	err := cleanup(database)
+	assert.NoError(t, err, "Error when trying to cleanup  Error: %s", err)
+	defer cleanup(database)
+
+	//create a new instance with a limit on the parallel requests and a database object
+	def := *couchDBDef
+	def.ConnectionPool.MaxParallelRequestsPerDB = 2
+	couchInstance, err := NewCouchInstance(&def, &disabled.Provider{})
+	assert.NoError(t, err, "Error when trying to create couch instance")
+	db := CouchDatabase{CouchInstance: couchInstance, DBName: database}
+
+	//create a new database
+	errdb := db.CreateDatabaseIfNotExist()
+	assert.NoError(t, errdb, "Error when trying to create database")
+
+	byteText := []byte(`This is a test document.  This is only a test`)
+	attachment := &AttachmentInfo{AttachmentBytes: byteText, ContentType: "application/octet-stream", Length: uint64(len(byteText)), Name: "valueBytes"}
+	_, err = db.SaveDoc("1", "", &CouchDoc{JSONValue: []byte(`{"_id":"1","asset_name":"marble1"}`)})
+	assert.NoError(t, err, "Error when trying to save a document")
+	_, err = db.SaveDoc("2", "", &CouchDoc{JSONValue: []byte(`{"_id":"2"}`), Attachments: []*AttachmentInfo{attachment}})
+	assert.NoError(t, err, "Error when trying to save a document")
+	_, err = db.SaveDoc("3", "", &CouchDoc{JSONValue: []byte(`{"_id":"3","asset_name":"marble3"}`)})
+	assert.NoError(t, err, "Error when trying to save a document")
+	err = db.DeleteDoc("3", "")
+	assert.NoError(t, err, "Error when trying to delete a document")
+
+	//the missing and the deleted documents are not returned
+	couchDocs, err := db.BatchRetrieveDocuments([]string{"1", "2", "3", "4"}, true)
+	assert.NoError(t, err, "Error when trying to retrieve the documents")
+	assert.Len(t, couchDocs, 2)
+	docMetadata := &DocMetadata{}
+	assert.NoError(t, json.Unmarshal(couchDocs[0].JSONValue, docMetadata))
+	assert.Equal(t, "1", docMetadata.ID)
+	assert.NotEmpty(t, docMetadata.Rev)
+	assert.Nil(t, couchDocs[0].Attachments)
+	assert.NoError(t, json.Unmarshal(couchDocs[1].JSONValue, docMetadata))
+	assert.Equal(t, "2", docMetadata.ID)
+	assert.Nil(t, docMetadata.AttachmentsInfo)
+	assert.Len(t, couchDocs[1].Attachments, 1)
+	assert.Equal(t, "valueBytes", couchDocs[1].Attachments[0].Name)
+	assert.Equal(t, byteText, couchDocs[1].Attachments[0].AttachmentBytes)
+
+	//the data of the attachments is not retrieved if not requested
+	couchDocs, err = db.BatchRetrieveDocuments([]string{"2"}, false)
+	assert.NoError(t, err, "Error when trying to retrieve the documents")
+	assert.Len(t, couchDocs, 1)
+	assert.Nil(t, couchDocs[0].Attachments[0].AttachmentBytes)
+
+	couchDocs, err = db.BatchRetrieveDocuments(nil, true)
+	assert.NoError(t, err, "Error when trying to retrieve the documents")
+	assert.Empty(t, couchDocs)
+}
+
 func TestDBDeleteDocument(t *testing.T) {
 
 	database := "testdbdeletedocument"
@@ -1666,3 +1726,9 @@ func TestURLWithSpecialCharacters(t *testing.T) {
 	assert.Equal(t, database, dbInfo.DbName)
 
 }
+
+func TestActiveTaskDBName(t *testing.T) {
+	assert.Equal(t, "mychannel_marbles", activeTaskDBName("shards/00000000-1fffffff/mychannel_marbles.1565283401"))
+	assert.Equal(t, "mychannel_marbles$$pcollection$marbles", activeTaskDBName("shards/00000000-1fffffff/mychannel_marbles$$pcollection$marbles.1565283401"))
+	assert.Equal(t, "mychannel_marbles", activeTaskDBName("mychannel_marbles"))
+}
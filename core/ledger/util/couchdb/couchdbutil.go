@@ -33,22 +33,40 @@ var chainNameAllowedLength = 50
 var namespaceNameAllowedLength = 50
 var collectionNameAllowedLength = 50
 
-//CreateCouchInstance creates a CouchDB instance
+//CreateCouchInstance creates a CouchDB instance with the default connection pool
 func CreateCouchInstance(couchDBConnectURL, id, pw string, maxRetries,
 	maxRetriesOnStartup int, connectionTimeout time.Duration, createGlobalChangesDB bool, metricsProvider metrics.Provider) (*CouchInstance, error) {
 
-	couchConf, err := CreateConnectionDefinition(couchDBConnectURL,
-		id, pw, maxRetries, maxRetriesOnStartup, connectionTimeout, createGlobalChangesDB)
+	return NewCouchInstance(&CouchDBDef{
+		URL:                   couchDBConnectURL,
+		Username:              id,
+		Password:              pw,
+		MaxRetries:            maxRetries,
+		MaxRetriesOnStartup:   maxRetriesOnStartup,
+		RequestTimeout:        connectionTimeout,
+		CreateGlobalChangesDB: createGlobalChangesDB,
+	}, metricsProvider)
+}
+
+//NewCouchInstance creates a CouchDB instance from the given definition, which includes the
+//parameters of the connection pool
+func NewCouchInstance(couchDBDef *CouchDBDef, metricsProvider metrics.Provider) (*CouchInstance, error) {
+
+	couchConf, err := CreateConnectionDefinition(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout, couchDBDef.CreateGlobalChangesDB)
 	if err != nil {
 		logger.Errorf("Error calling CouchDB CreateConnectionDefinition(): %s", err)
 		return nil, err
 	}
+	couchConf.ConnectionPool = couchDBDef.ConnectionPool.withDefaults()
 
 	// Create the http client once
 	// Clients and Transports are safe for concurrent use by multiple goroutines
 	// and for efficiency should only be created once and re-used.
 	client := &http.Client{Timeout: couchConf.RequestTimeout}
 
+	// The connections are kept open for reuse by the requests that are made in parallel
+	// during the commit of a block, see `MaxParallelRequestsPerDB`
 	transport := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
@@ -56,6 +74,10 @@ func CreateCouchInstance(couchDBConnectURL, id, pw string, maxRetries,
 			KeepAlive: 30 * time.Second,
 			DualStack: true,
 		}).DialContext,
+		MaxIdleConns:        couchConf.ConnectionPool.MaxIdleConnsPerHost,
+		MaxIdleConnsPerHost: couchConf.ConnectionPool.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     couchConf.ConnectionPool.MaxConnsPerHost,
+		IdleConnTimeout:     couchConf.ConnectionPool.IdleConnTimeout,
 	}
 	transport.DisableCompression = false
 	client.Transport = transport
@@ -279,3 +301,81 @@ func escapeUpperCase(dbName string) string {
 	dbName = re.ReplaceAllString(dbName, "$$"+"$1")
 	return strings.ToLower(dbName)
 }
+
+var truncatedDBNameSuffix = regexp.MustCompile(`\([0-9a-f]{64}\)$`)
+
+// ChainDBNames returns, among the given CouchDB database names, the names of the metadata database and of the
+// namespace databases of the given chain/channel.
+//
+// A namespace database whose name has been truncated by ConstructNamespaceDBName carries the first 50 chars of
+// the chain name and hence, may equally belong to another chain with the same first 50 chars. Such a database is
+// matched by recomputing the hash appended to its name. When the namespace or the collection name has been
+// truncated as well, the hash cannot be recomputed and the database is matched only if no other chain that shares
+// the first 50 chars of the chain name has a metadata database among the given names
+func ChainDBNames(chainName string, dbNames []string) []string {
+	mapName := func(name string) string {
+		return strings.Replace(name, ".", "$", -1)
+	}
+	truncatedChainName := chainName
+	if len(truncatedChainName) > chainNameAllowedLength {
+		truncatedChainName = truncatedChainName[:chainNameAllowedLength]
+	}
+	metadataDBName := mapName(ConstructMetadataDBName(chainName))
+	truncatedPrefix := mapName(truncatedChainName) + "_"
+
+	sharedTruncatedChainName := false
+	for _, dbName := range dbNames {
+		if dbName == metadataDBName || !strings.HasSuffix(dbName, "_") {
+			continue
+		}
+		otherChainName := truncatedDBNameSuffix.ReplaceAllString(strings.TrimSuffix(dbName, "_"), "")
+		if len(otherChainName) > chainNameAllowedLength {
+			otherChainName = otherChainName[:chainNameAllowedLength]
+		}
+		if otherChainName+"_" == truncatedPrefix {
+			sharedTruncatedChainName = true
+		}
+	}
+
+	var chainDBNames []string
+	for _, dbName := range dbNames {
+		switch {
+		case dbName == metadataDBName:
+			chainDBNames = append(chainDBNames, dbName)
+		case !truncatedDBNameSuffix.MatchString(dbName):
+			if strings.HasPrefix(dbName, mapName(chainName)+"_") {
+				chainDBNames = append(chainDBNames, dbName)
+			}
+		case strings.HasPrefix(dbName, truncatedPrefix):
+			hash := dbName[len(dbName)-65 : len(dbName)-1]
+			escapedNamespace := dbName[len(truncatedPrefix) : len(dbName)-66]
+			namespace, complete := unescapeUpperCase(escapedNamespace)
+			if hex.EncodeToString(util.ComputeSHA256([]byte(chainName+"_"+namespace))) == hash ||
+				(!complete && !sharedTruncatedChainName) {
+				chainDBNames = append(chainDBNames, dbName)
+			}
+		}
+	}
+	return chainDBNames
+}
+
+// unescapeUpperCase reverts escapeUpperCase on an escaped namespace of the form 'namespace' or
+// 'namespace$$collection'. It also returns false if the namespace or the collection name may have
+// been truncated to the allowed length
+func unescapeUpperCase(escapedNamespace string) (string, bool) {
+	re := regexp.MustCompile(`\$([a-z])`)
+	names := strings.Split(escapedNamespace, "$$")
+	if len(names) > 2 {
+		return "", false
+	}
+	complete := len(names[0]) < namespaceNameAllowedLength
+	if len(names) == 2 {
+		// a namespace truncated right after an escaping '$' leaves three '$' ahead of the collection name
+		complete = complete && len(names[1]) < collectionNameAllowedLength &&
+			!(len(names[0]) == namespaceNameAllowedLength-1 && strings.HasPrefix(names[1], "$"))
+	}
+	for i, name := range names {
+		names[i] = re.ReplaceAllStringFunc(name, func(s string) string { return strings.ToUpper(s[1:]) })
+	}
+	return strings.Join(names, "$$"), complete
+}
@@ -7,6 +7,7 @@ package couchdb
 
 import (
 	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/metrics/disabled"
@@ -188,3 +189,56 @@ func TestConstructedNamespaceDBName(t *testing.T) {
 	assert.Equal(t, expectedDBNameLength, len(constructedDBName))
 	assert.Equal(t, expectedDBName, constructedDBName)
 }
+
+func TestChainDBNames(t *testing.T) {
+	// Allowed pattern for chainName: [a-z][a-z0-9.-]
+	chainName := "tob2g.y-z0f.qwp-rq5g4-ogid5g6oucyryg9sc16mz0t4vuake5q557esz7sn493nf0ghch0xih6dwuirokyoi4jvs67gh6r5v6mhz3-292un2-9egdcs88cstg3f7xa9m1i8v4gj0t3jedsm-woh3kgiqehwej6h93hdy5tr4v.1qmmqjzz0ox62k.507sh3fkw3-mfqh.ukfvxlm5szfbwtpfkd1r4j.cy8oft5obvwqpzjxb27xuw6"
+	// otherChainName shares the first 50 chars (i.e., chainNameAllowedLength) with chainName
+	otherChainName := chainName[:chainNameAllowedLength] + "-other"
+	shortChainName := "mychannel"
+
+	longNs := "wMCnSXiV9YoIqNQyNvFVTdM8XnUtvrOFFIWsKelmP5NEszmNLl8YhtOKbFu3P_NgwgsYF8PsfwjYCD8f1XRpANQLoErDHwLlweryqXeJ6vzT2x0pS_GwSx0m6tBI0zOmHQOq_2De8A87x6zUOPwufC2T6dkidFxiuq8Sey2-5vUo_iNKCij3WTeCnKx78PUIg_U1gp4_0KTvYVtRBRvH0kz5usizBxPaiFu3TPhB9XLviScvdUVSbSYJ0Z"
+	longColl := "pvWjtfSTXVK8WJus5s6zWoMIciXd7qHRZIusF9SkOS6m8XuHCiJDE9cCRuVerq22Na8qBL2ywDGFpVMIuzfyEXLjeJb0mMuH4cwewT6r1INOTOSYwrikwOLlT_fl0V1L7IQEwUBB8WCvRqSdj6j5-E5aGul_pv_0UeCdwWiyA_GrZmP7ocLzfj2vP8btigrajqdH-irLO2ydEjQUAvf8fiuxru9la402KmKRy457GgI98UHoUdqV3f3FCdR"
+
+	mapName := func(name string) string {
+		return strings.Replace(name, ".", "$", -1)
+	}
+	dbNamesOf := func(chainName string, namespaces ...string) []string {
+		dbNames := []string{mapName(ConstructMetadataDBName(chainName))}
+		for _, ns := range namespaces {
+			dbNames = append(dbNames, mapName(ConstructNamespaceDBName(chainName, ns)))
+		}
+		return dbNames
+	}
+
+	// the namespace databases of chainName are all truncated, with a verifiable hash for the short namespaces
+	verifiableDBNames := dbNamesOf(chainName, "lscc", "myCC", "myCC$$pMyColl", "myCC$$hMyColl")
+	unverifiableDBNames := dbNamesOf(chainName, longNs, "myCC$$"+longColl)[1:]
+	// the namespace databases of otherChainName are truncated only for the long namespaces
+	otherDBNames := dbNamesOf(otherChainName, "lscc", "myCC$$pMyColl", longNs, longNs+"$$"+longColl)
+	shortChainDBNames := dbNamesOf(shortChainName, "lscc", "myCC$$pMyColl", longNs, longNs+"$$"+longColl)
+	for _, dbNames := range [][]string{verifiableDBNames[1:], unverifiableDBNames, otherDBNames[3:]} {
+		for _, dbName := range dbNames {
+			assert.True(t, truncatedDBNameSuffix.MatchString(dbName))
+		}
+	}
+
+	var allDBNames []string
+	for _, dbNames := range [][]string{verifiableDBNames, unverifiableDBNames, otherDBNames, shortChainDBNames} {
+		allDBNames = append(allDBNames, dbNames...)
+	}
+
+	// the databases of chainName for which the hash cannot be recomputed are not matched
+	// as they may belong to otherChainName
+	assert.ElementsMatch(t, verifiableDBNames, ChainDBNames(chainName, allDBNames))
+	assert.ElementsMatch(t, otherDBNames[:3], ChainDBNames(otherChainName, allDBNames))
+	assert.ElementsMatch(t, shortChainDBNames, ChainDBNames(shortChainName, allDBNames))
+
+	// once otherChainName is dropped, all the databases of chainName are matched
+	var remainingDBNames []string
+	for _, dbNames := range [][]string{verifiableDBNames, unverifiableDBNames, shortChainDBNames} {
+		remainingDBNames = append(remainingDBNames, dbNames...)
+	}
+	assert.ElementsMatch(t, append(verifiableDBNames, unverifiableDBNames...), ChainDBNames(chainName, remainingDBNames))
+	assert.Empty(t, ChainDBNames("otherchannel", allDBNames))
+}
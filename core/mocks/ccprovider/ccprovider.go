@@ -62,6 +62,10 @@ func (m *MockTxSim) ExecuteQueryWithMetadata(namespace, query string, metadata m
 	return nil, nil
 }
 
+func (m *MockTxSim) GetStateByIndex(namespace, indexName string, values []interface{}) (commonledger.ResultsIterator, error) {
+	return nil, nil
+}
+
 func (m *MockTxSim) Done() {
 }
 
@@ -93,6 +97,10 @@ func (m *MockTxSim) ExecuteQueryOnPrivateData(namespace, collection, query strin
 	return nil, nil
 }
 
+func (m *MockTxSim) ExecuteQueryOnPrivateDataWithMetadata(namespace, collection, query string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	return nil, nil
+}
+
 func (m *MockTxSim) GetPrivateData(namespace, collection, key string) ([]byte, error) {
 	return nil, nil
 }
@@ -109,6 +117,10 @@ func (m *MockTxSim) GetPrivateDataRangeScanIterator(namespace, collection, start
 	return nil, nil
 }
 
+func (m *MockTxSim) GetPrivateDataRangeScanIteratorWithMetadata(namespace, collection, startKey, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
+	return nil, nil
+}
+
 func (m *MockTxSim) SetPrivateData(namespace, collection, key string, value []byte) error {
 	return nil
 }
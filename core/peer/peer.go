@@ -20,6 +20,7 @@ import (
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/semaphore"
@@ -107,6 +108,48 @@ func (sp *storeProvider) OpenStore(ledgerID string) (transientstore.Store, error
 	return store, err
 }
 
+// Drop deletes all the private write sets in the transient store of the channel
+func (sp *storeProvider) Drop(channel string) error {
+	sp.Lock()
+	defer sp.Unlock()
+	delete(sp.stores, channel)
+	if sp.StoreProvider == nil {
+		sp.StoreProvider = transientstore.NewStoreProvider()
+	}
+	return sp.StoreProvider.Drop(channel)
+}
+
+// DiskUsage returns the approximate number of bytes used on the file system by the transient store of the channel
+func (sp *storeProvider) DiskUsage(channel string) (uint64, error) {
+	maintainer, err := sp.storeMaintainer()
+	if err != nil {
+		return 0, err
+	}
+	return maintainer.DiskUsage(channel)
+}
+
+// Compact compacts the transient store of the channel
+func (sp *storeProvider) Compact(channel string) error {
+	maintainer, err := sp.storeMaintainer()
+	if err != nil {
+		return err
+	}
+	return maintainer.Compact(channel)
+}
+
+func (sp *storeProvider) storeMaintainer() (leveldbhelper.StoreMaintainer, error) {
+	sp.RLock()
+	defer sp.RUnlock()
+	if sp.StoreProvider == nil {
+		return nil, errors.New("transient store is not opened")
+	}
+	maintainer, ok := sp.StoreProvider.(leveldbhelper.StoreMaintainer)
+	if !ok {
+		return nil, errors.Errorf("transient store provider [%T] does not support maintaining the store", sp.StoreProvider)
+	}
+	return maintainer, nil
+}
+
 func (cs *chainSupport) Apply(configtx *common.ConfigEnvelope) error {
 	err := cs.ConfigtxValidator().Validate(configtx)
 	if err != nil {
@@ -445,6 +488,33 @@ func CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sc
 	return createChain(cid, l, cb, ccp, sccp, pluginMapper, deployedCCInfoProvider, lr)
 }
 
+// UnjoinChannel removes the channel with the given ID from the peer. The peer leaves the gossip network of
+// the channel and stops the delivery of blocks for the channel, then the committer and the ledger of the
+// channel are closed, and the ledger and the private data in the transient store of the channel are removed
+func UnjoinChannel(cid string) error {
+	chains.Lock()
+	c, ok := chains.list[cid]
+	delete(chains.list, cid)
+	chains.Unlock()
+	if !ok {
+		return errors.Errorf("peer is not joined to channel [%s]", cid)
+	}
+
+	peerLogger.Infof("Unjoining channel [%s]", cid)
+	service.GetGossipService().LeaveChannel(cid)
+	c.committer.Close()
+	c.cs.ledger.Close()
+
+	if err := TransientStoreFactory.Drop(cid); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error while dropping the transient store for channel [%s]", cid))
+	}
+	if err := ledgermgmt.RemoveLedger(cid); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error while removing the ledger for channel [%s]", cid))
+	}
+	peerLogger.Infof("Unjoined channel [%s]", cid)
+	return nil
+}
+
 // GetLedger returns the ledger of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetLedger(cid string) ledger.PeerLedger {
@@ -8,8 +8,10 @@ package peer
 
 import (
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"net"
+	"os"
 	"testing"
 
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
@@ -22,15 +24,18 @@ import (
 	deliverclient "github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	ledgermocks "github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/core/mocks/ccprovider"
+	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/service"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
 	"github.com/hyperledger/fabric/peer/gossip/mocks"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -194,6 +199,17 @@ func TestCreateChainFromBlock(t *testing.T) {
 		t.Fatalf("incorrect number of channels")
 	}
 
+	// Unjoin the channel
+	err = UnjoinChannel(testChainID)
+	assert.NoError(t, err)
+	assert.Nil(t, GetLedger(testChainID))
+	assert.Empty(t, GetChannelsInfo())
+	ledgerIDs, err := ledgermgmt.GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.NotContains(t, ledgerIDs, testChainID)
+	err = UnjoinChannel(testChainID)
+	assert.EqualError(t, err, fmt.Sprintf("peer is not joined to channel [%s]", testChainID))
+
 	// cleanup the chain referenes to enable execution with -count n
 	chains.Lock()
 	chains.list = map[string]*chain{}
@@ -217,3 +233,24 @@ func TestDeliverSupportManager(t *testing.T) {
 	chainSupport = manager.GetChain("testchain")
 	assert.NotNil(t, chainSupport, "chain support should not be nil")
 }
+
+func TestTransientStoreMaintenance(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "transientstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	viper.Set("peer.fileSystemPath", tempDir)
+	defer viper.Reset()
+
+	sp := &storeProvider{stores: make(map[string]transientstore.Store)}
+	_, err = sp.DiskUsage("testchannelid")
+	assert.EqualError(t, err, "transient store is not opened")
+	assert.EqualError(t, sp.Compact("testchannelid"), "transient store is not opened")
+
+	_, err = sp.OpenStore("testchannelid")
+	require.NoError(t, err)
+	defer sp.StoreProvider.Close()
+	require.NoError(t, sp.Compact("testchannelid"))
+	usage, err := sp.DiskUsage("testchannelid")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), usage)
+}
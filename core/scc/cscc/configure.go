@@ -81,6 +81,7 @@ var cnflogger = flogging.MustGetLogger("cscc")
 // These are function names from Invoke first parameter
 const (
 	JoinChain                string = "JoinChain"
+	UnjoinChain              string = "UnjoinChain"
 	GetConfigBlock           string = "GetConfigBlock"
 	GetChannels              string = "GetChannels"
 	GetConfigTree            string = "GetConfigTree"
@@ -95,6 +96,7 @@ func (e *PeerConfiger) Init(stub shim.ChaincodeStubInterface) pb.Response {
 
 // Invoke is called for the following:
 // # to process joining a chain (called by app as a transaction proposal)
+// # to process unjoining a chain (called by app as a transaction proposal)
 // # to get the current configuration block (called by app)
 // # to update the configuration block (called by committer)
 // Peer calls this function with 2 arguments:
@@ -170,6 +172,18 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 		}
 
 		return joinChain(cid, block, e.ccp, e.sccp, e.deployedCCInfoProvider, e.lifecycle)
+	case UnjoinChain:
+		cid := string(args[1])
+		if cid == "" {
+			return shim.Error("Cannot unjoin the channel, no channel ID provided")
+		}
+
+		// check local MSP Admins policy
+		if err = e.policyChecker.CheckPolicyNoChannel(mgmt.Admins, sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", fname, cid, err))
+		}
+
+		return unjoinChain(cid)
 	case GetConfigBlock:
 		// 2. check policy
 		if err = e.aclProvider.CheckACL(resources.Cscc_GetConfigBlock, string(args[1]), sp); err != nil {
@@ -250,6 +264,15 @@ func joinChain(chainID string, block *common.Block, ccp ccprovider.ChaincodeProv
 	return shim.Success(nil)
 }
 
+// unjoinChain removes the specified chain, along with its ledger, from the peer
+func unjoinChain(chainID string) pb.Response {
+	if err := peer.UnjoinChannel(chainID); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
 // Return the current configuration block for the specified chainID. If the
 // peer doesn't belong to the chain, return error
 func getConfigBlock(chainID []byte) pb.Response {
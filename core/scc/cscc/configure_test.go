@@ -355,6 +355,40 @@ func TestConfigerInvokeJoinChainCorrectParams(t *testing.T) {
 	if len(cqr.GetChannels()) != 1 {
 		t.FailNow()
 	}
+
+	// Unjoin the channel
+	args = [][]byte{[]byte(UnjoinChain), []byte(chainID)}
+	sProp.Signature = nil
+	res = stub.MockInvokeWithSignedProposal("4", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "access denied for [UnjoinChain][mytestchainid]")
+	sProp.Signature = sProp.ProposalBytes
+
+	res = stub.MockInvokeWithSignedProposal("4", [][]byte{[]byte(UnjoinChain), nil}, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Cannot unjoin the channel, no channel ID provided", res.Message)
+
+	res = stub.MockInvokeWithSignedProposal("4", args, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Nil(t, peer.GetLedger(chainID))
+	ledgerIDs, err := ledgermgmt.GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.NotContains(t, ledgerIDs, chainID)
+
+	res = stub.MockInvokeWithSignedProposal("5", [][]byte{[]byte(GetChannels)}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status)
+	cqr = &pb.ChannelQueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, cqr))
+	assert.Empty(t, cqr.GetChannels())
+
+	res = stub.MockInvokeWithSignedProposal("6", args, sProp)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "peer is not joined to channel [mytestchainid]", res.Message)
+
+	// the channel can be joined again
+	res = stub.MockInvokeWithSignedProposal("7", [][]byte{[]byte(JoinChain), blockBytes}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.NotNil(t, peer.GetLedger(chainID))
 }
 
 func TestGetConfigTree(t *testing.T) {
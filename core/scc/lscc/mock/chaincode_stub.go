@@ -47,6 +47,55 @@ type ChaincodeStub struct {
 	delStateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DelStateByRangeStub        func(startKey string, endKey string) error
+	delStateByRangeMutex       sync.RWMutex
+	delStateByRangeArgsForCall []struct {
+		startKey string
+		endKey   string
+	}
+	delStateByRangeReturns struct {
+		result1 error
+	}
+	delStateByRangeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DelStateByPartialCompositeKeyStub        func(objectType string, keys []string) error
+	delStateByPartialCompositeKeyMutex       sync.RWMutex
+	delStateByPartialCompositeKeyArgsForCall []struct {
+		objectType string
+		keys       []string
+	}
+	delStateByPartialCompositeKeyReturns struct {
+		result1 error
+	}
+	delStateByPartialCompositeKeyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetStateExpiryStub        func(key string, blocksToLive uint64) error
+	setStateExpiryMutex       sync.RWMutex
+	setStateExpiryArgsForCall []struct {
+		key          string
+		blocksToLive uint64
+	}
+	setStateExpiryReturns struct {
+		result1 error
+	}
+	setStateExpiryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetStateExpiryStub        func(key string) (uint64, error)
+	getStateExpiryMutex       sync.RWMutex
+	getStateExpiryArgsForCall []struct {
+		key string
+	}
+	getStateExpiryReturns struct {
+		result1 uint64
+		result2 error
+	}
+	getStateExpiryReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
 	GetArgsStub        func() [][]byte
 	getArgsMutex       sync.RWMutex
 	getArgsArgsForCall []struct {
@@ -138,6 +187,24 @@ type ChaincodeStub struct {
 		result1 shim.HistoryQueryIteratorInterface
 		result2 error
 	}
+	GetHistoryForKeyWithPaginationStub        func(string, *shim.HistoryQueryOptions, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getHistoryForKeyWithPaginationMutex       sync.RWMutex
+	getHistoryForKeyWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 *shim.HistoryQueryOptions
+		arg3 int32
+		arg4 string
+	}
+	getHistoryForKeyWithPaginationReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getHistoryForKeyWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataStub        func(string, string) ([]byte, error)
 	getPrivateDataMutex       sync.RWMutex
 	getPrivateDataArgsForCall []struct {
@@ -167,6 +234,25 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetPrivateDataByPartialCompositeKeyWithPaginationStub        func(string, string, []string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getPrivateDataByPartialCompositeKeyWithPaginationMutex       sync.RWMutex
+	getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+		arg4 int32
+		arg5 string
+	}
+	getPrivateDataByPartialCompositeKeyWithPaginationReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataByRangeStub        func(string, string, string) (shim.StateQueryIteratorInterface, error)
 	getPrivateDataByRangeMutex       sync.RWMutex
 	getPrivateDataByRangeArgsForCall []struct {
@@ -182,6 +268,25 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetPrivateDataByRangeWithPaginationStub        func(string, string, string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getPrivateDataByRangeWithPaginationMutex       sync.RWMutex
+	getPrivateDataByRangeWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int32
+		arg5 string
+	}
+	getPrivateDataByRangeWithPaginationReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getPrivateDataByRangeWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataHashStub        func(string, string) ([]byte, error)
 	getPrivateDataHashMutex       sync.RWMutex
 	getPrivateDataHashArgsForCall []struct {
@@ -196,6 +301,20 @@ type ChaincodeStub struct {
 		result1 []byte
 		result2 error
 	}
+	GetPrivateDataHistoryForKeyStub        func(string, string) (shim.HistoryQueryIteratorInterface, error)
+	getPrivateDataHistoryForKeyMutex       sync.RWMutex
+	getPrivateDataHistoryForKeyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getPrivateDataHistoryForKeyReturns struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
+	getPrivateDataHistoryForKeyReturnsOnCall map[int]struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}
 	GetPrivateDataQueryResultStub        func(string, string) (shim.StateQueryIteratorInterface, error)
 	getPrivateDataQueryResultMutex       sync.RWMutex
 	getPrivateDataQueryResultArgsForCall []struct {
@@ -210,6 +329,24 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetPrivateDataQueryResultWithPaginationStub        func(string, string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getPrivateDataQueryResultWithPaginationMutex       sync.RWMutex
+	getPrivateDataQueryResultWithPaginationArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int32
+		arg4 string
+	}
+	getPrivateDataQueryResultWithPaginationReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getPrivateDataQueryResultWithPaginationReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetPrivateDataValidationParameterStub        func(string, string) ([]byte, error)
 	getPrivateDataValidationParameterMutex       sync.RWMutex
 	getPrivateDataValidationParameterArgsForCall []struct {
@@ -279,6 +416,20 @@ type ChaincodeStub struct {
 		result1 []byte
 		result2 error
 	}
+	GetStateByIndexStub        func(string, ...interface{}) (shim.StateQueryIteratorInterface, error)
+	getStateByIndexMutex       sync.RWMutex
+	getStateByIndexArgsForCall []struct {
+		arg1 string
+		arg2 []interface{}
+	}
+	getStateByIndexReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
+	getStateByIndexReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
 	GetStateByPartialCompositeKeyStub        func(string, []string) (shim.StateQueryIteratorInterface, error)
 	getStateByPartialCompositeKeyMutex       sync.RWMutex
 	getStateByPartialCompositeKeyArgsForCall []struct {
@@ -684,6 +835,209 @@ func (fake *ChaincodeStub) DelStateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *ChaincodeStub) DelStateByRange(startKey string, endKey string) error {
+	fake.delStateByRangeMutex.Lock()
+	ret, specificReturn := fake.delStateByRangeReturnsOnCall[len(fake.delStateByRangeArgsForCall)]
+	fake.delStateByRangeArgsForCall = append(fake.delStateByRangeArgsForCall, struct {
+		startKey string
+		endKey   string
+	}{startKey, endKey})
+	fake.recordInvocation("DelStateByRange", []interface{}{startKey, endKey})
+	fake.delStateByRangeMutex.Unlock()
+	if fake.DelStateByRangeStub != nil {
+		return fake.DelStateByRangeStub(startKey, endKey)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.delStateByRangeReturns.result1
+}
+
+func (fake *ChaincodeStub) DelStateByRangeCallCount() int {
+	fake.delStateByRangeMutex.RLock()
+	defer fake.delStateByRangeMutex.RUnlock()
+	return len(fake.delStateByRangeArgsForCall)
+}
+
+func (fake *ChaincodeStub) DelStateByRangeArgsForCall(i int) (string, string) {
+	fake.delStateByRangeMutex.RLock()
+	defer fake.delStateByRangeMutex.RUnlock()
+	return fake.delStateByRangeArgsForCall[i].startKey, fake.delStateByRangeArgsForCall[i].endKey
+}
+
+func (fake *ChaincodeStub) DelStateByRangeReturns(result1 error) {
+	fake.DelStateByRangeStub = nil
+	fake.delStateByRangeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) DelStateByRangeReturnsOnCall(i int, result1 error) {
+	fake.DelStateByRangeStub = nil
+	if fake.delStateByRangeReturnsOnCall == nil {
+		fake.delStateByRangeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.delStateByRangeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKey(objectType string, keys []string) error {
+	var keysCopy []string
+	if keys != nil {
+		keysCopy = make([]string, len(keys))
+		copy(keysCopy, keys)
+	}
+	fake.delStateByPartialCompositeKeyMutex.Lock()
+	ret, specificReturn := fake.delStateByPartialCompositeKeyReturnsOnCall[len(fake.delStateByPartialCompositeKeyArgsForCall)]
+	fake.delStateByPartialCompositeKeyArgsForCall = append(fake.delStateByPartialCompositeKeyArgsForCall, struct {
+		objectType string
+		keys       []string
+	}{objectType, keysCopy})
+	fake.recordInvocation("DelStateByPartialCompositeKey", []interface{}{objectType, keysCopy})
+	fake.delStateByPartialCompositeKeyMutex.Unlock()
+	if fake.DelStateByPartialCompositeKeyStub != nil {
+		return fake.DelStateByPartialCompositeKeyStub(objectType, keys)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.delStateByPartialCompositeKeyReturns.result1
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKeyCallCount() int {
+	fake.delStateByPartialCompositeKeyMutex.RLock()
+	defer fake.delStateByPartialCompositeKeyMutex.RUnlock()
+	return len(fake.delStateByPartialCompositeKeyArgsForCall)
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKeyArgsForCall(i int) (string, []string) {
+	fake.delStateByPartialCompositeKeyMutex.RLock()
+	defer fake.delStateByPartialCompositeKeyMutex.RUnlock()
+	return fake.delStateByPartialCompositeKeyArgsForCall[i].objectType, fake.delStateByPartialCompositeKeyArgsForCall[i].keys
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKeyReturns(result1 error) {
+	fake.DelStateByPartialCompositeKeyStub = nil
+	fake.delStateByPartialCompositeKeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) DelStateByPartialCompositeKeyReturnsOnCall(i int, result1 error) {
+	fake.DelStateByPartialCompositeKeyStub = nil
+	if fake.delStateByPartialCompositeKeyReturnsOnCall == nil {
+		fake.delStateByPartialCompositeKeyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.delStateByPartialCompositeKeyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) SetStateExpiry(key string, blocksToLive uint64) error {
+	fake.setStateExpiryMutex.Lock()
+	ret, specificReturn := fake.setStateExpiryReturnsOnCall[len(fake.setStateExpiryArgsForCall)]
+	fake.setStateExpiryArgsForCall = append(fake.setStateExpiryArgsForCall, struct {
+		key          string
+		blocksToLive uint64
+	}{key, blocksToLive})
+	fake.recordInvocation("SetStateExpiry", []interface{}{key, blocksToLive})
+	fake.setStateExpiryMutex.Unlock()
+	if fake.SetStateExpiryStub != nil {
+		return fake.SetStateExpiryStub(key, blocksToLive)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setStateExpiryReturns.result1
+}
+
+func (fake *ChaincodeStub) SetStateExpiryCallCount() int {
+	fake.setStateExpiryMutex.RLock()
+	defer fake.setStateExpiryMutex.RUnlock()
+	return len(fake.setStateExpiryArgsForCall)
+}
+
+func (fake *ChaincodeStub) SetStateExpiryArgsForCall(i int) (string, uint64) {
+	fake.setStateExpiryMutex.RLock()
+	defer fake.setStateExpiryMutex.RUnlock()
+	return fake.setStateExpiryArgsForCall[i].key, fake.setStateExpiryArgsForCall[i].blocksToLive
+}
+
+func (fake *ChaincodeStub) SetStateExpiryReturns(result1 error) {
+	fake.SetStateExpiryStub = nil
+	fake.setStateExpiryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) SetStateExpiryReturnsOnCall(i int, result1 error) {
+	fake.SetStateExpiryStub = nil
+	if fake.setStateExpiryReturnsOnCall == nil {
+		fake.setStateExpiryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setStateExpiryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) GetStateExpiry(key string) (uint64, error) {
+	fake.getStateExpiryMutex.Lock()
+	ret, specificReturn := fake.getStateExpiryReturnsOnCall[len(fake.getStateExpiryArgsForCall)]
+	fake.getStateExpiryArgsForCall = append(fake.getStateExpiryArgsForCall, struct {
+		key string
+	}{key})
+	fake.recordInvocation("GetStateExpiry", []interface{}{key})
+	fake.getStateExpiryMutex.Unlock()
+	if fake.GetStateExpiryStub != nil {
+		return fake.GetStateExpiryStub(key)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getStateExpiryReturns.result1, fake.getStateExpiryReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateExpiryCallCount() int {
+	fake.getStateExpiryMutex.RLock()
+	defer fake.getStateExpiryMutex.RUnlock()
+	return len(fake.getStateExpiryArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateExpiryArgsForCall(i int) string {
+	fake.getStateExpiryMutex.RLock()
+	defer fake.getStateExpiryMutex.RUnlock()
+	return fake.getStateExpiryArgsForCall[i].key
+}
+
+func (fake *ChaincodeStub) GetStateExpiryReturns(result1 uint64, result2 error) {
+	fake.GetStateExpiryStub = nil
+	fake.getStateExpiryReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateExpiryReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.GetStateExpiryStub = nil
+	if fake.getStateExpiryReturnsOnCall == nil {
+		fake.getStateExpiryReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.getStateExpiryReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetArgs() [][]byte {
 	fake.getArgsMutex.Lock()
 	ret, specificReturn := fake.getArgsReturnsOnCall[len(fake.getArgsArgsForCall)]
@@ -1123,6 +1477,75 @@ func (fake *ChaincodeStub) GetHistoryForKeyReturnsOnCall(i int, result1 shim.His
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetHistoryForKeyWithPagination(arg1 string, arg2 *shim.HistoryQueryOptions, arg3 int32, arg4 string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getHistoryForKeyWithPaginationReturnsOnCall[len(fake.getHistoryForKeyWithPaginationArgsForCall)]
+	fake.getHistoryForKeyWithPaginationArgsForCall = append(fake.getHistoryForKeyWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 *shim.HistoryQueryOptions
+		arg3 int32
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetHistoryForKeyWithPagination", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	if fake.GetHistoryForKeyWithPaginationStub != nil {
+		return fake.GetHistoryForKeyWithPaginationStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getHistoryForKeyWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationCallCount() int {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	return len(fake.getHistoryForKeyWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationCalls(stub func(string, *shim.HistoryQueryOptions, int32, string) (shim.HistoryQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationArgsForCall(i int) (string, *shim.HistoryQueryOptions, int32, string) {
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
+	argsForCall := fake.getHistoryForKeyWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturns(result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	fake.getHistoryForKeyWithPaginationReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetHistoryForKeyWithPaginationReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getHistoryForKeyWithPaginationMutex.Lock()
+	defer fake.getHistoryForKeyWithPaginationMutex.Unlock()
+	fake.GetHistoryForKeyWithPaginationStub = nil
+	if fake.getHistoryForKeyWithPaginationReturnsOnCall == nil {
+		fake.getHistoryForKeyWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getHistoryForKeyWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateData(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataReturnsOnCall[len(fake.getPrivateDataArgsForCall)]
@@ -1257,6 +1680,81 @@ func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyReturnsOnCall(i in
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPagination(arg1 string, arg2 string, arg3 []string, arg4 int32, arg5 string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall[len(fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall)]
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall = append(fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+		arg4 int32
+		arg5 string
+	}{arg1, arg2, arg3Copy, arg4, arg5})
+	fake.recordInvocation("GetPrivateDataByPartialCompositeKeyWithPagination", []interface{}{arg1, arg2, arg3Copy, arg4, arg5})
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Unlock()
+	if fake.GetPrivateDataByPartialCompositeKeyWithPaginationStub != nil {
+		return fake.GetPrivateDataByPartialCompositeKeyWithPaginationStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getPrivateDataByPartialCompositeKeyWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPaginationCallCount() int {
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RUnlock()
+	return len(fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPaginationCalls(stub func(string, string, []string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Lock()
+	defer fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Unlock()
+	fake.GetPrivateDataByPartialCompositeKeyWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPaginationArgsForCall(i int) (string, string, []string, int32, string) {
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RUnlock()
+	argsForCall := fake.getPrivateDataByPartialCompositeKeyWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPaginationReturns(result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Lock()
+	defer fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Unlock()
+	fake.GetPrivateDataByPartialCompositeKeyWithPaginationStub = nil
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Lock()
+	defer fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.Unlock()
+	fake.GetPrivateDataByPartialCompositeKeyWithPaginationStub = nil
+	if fake.getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall == nil {
+		fake.getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateDataByRange(arg1 string, arg2 string, arg3 string) (shim.StateQueryIteratorInterface, error) {
 	fake.getPrivateDataByRangeMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataByRangeReturnsOnCall[len(fake.getPrivateDataByRangeArgsForCall)]
@@ -1322,6 +1820,76 @@ func (fake *ChaincodeStub) GetPrivateDataByRangeReturnsOnCall(i int, result1 shi
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPagination(arg1 string, arg2 string, arg3 string, arg4 int32, arg5 string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getPrivateDataByRangeWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataByRangeWithPaginationReturnsOnCall[len(fake.getPrivateDataByRangeWithPaginationArgsForCall)]
+	fake.getPrivateDataByRangeWithPaginationArgsForCall = append(fake.getPrivateDataByRangeWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 int32
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("GetPrivateDataByRangeWithPagination", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getPrivateDataByRangeWithPaginationMutex.Unlock()
+	if fake.GetPrivateDataByRangeWithPaginationStub != nil {
+		return fake.GetPrivateDataByRangeWithPaginationStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getPrivateDataByRangeWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPaginationCallCount() int {
+	fake.getPrivateDataByRangeWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByRangeWithPaginationMutex.RUnlock()
+	return len(fake.getPrivateDataByRangeWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPaginationCalls(stub func(string, string, string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getPrivateDataByRangeWithPaginationMutex.Lock()
+	defer fake.getPrivateDataByRangeWithPaginationMutex.Unlock()
+	fake.GetPrivateDataByRangeWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPaginationArgsForCall(i int) (string, string, string, int32, string) {
+	fake.getPrivateDataByRangeWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByRangeWithPaginationMutex.RUnlock()
+	argsForCall := fake.getPrivateDataByRangeWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPaginationReturns(result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getPrivateDataByRangeWithPaginationMutex.Lock()
+	defer fake.getPrivateDataByRangeWithPaginationMutex.Unlock()
+	fake.GetPrivateDataByRangeWithPaginationStub = nil
+	fake.getPrivateDataByRangeWithPaginationReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataByRangeWithPaginationReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getPrivateDataByRangeWithPaginationMutex.Lock()
+	defer fake.getPrivateDataByRangeWithPaginationMutex.Unlock()
+	fake.GetPrivateDataByRangeWithPaginationStub = nil
+	if fake.getPrivateDataByRangeWithPaginationReturnsOnCall == nil {
+		fake.getPrivateDataByRangeWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getPrivateDataByRangeWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateDataHash(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataHashMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataHashReturnsOnCall[len(fake.getPrivateDataHashArgsForCall)]
@@ -1386,6 +1954,70 @@ func (fake *ChaincodeStub) GetPrivateDataHashReturnsOnCall(i int, result1 []byte
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKey(arg1 string, arg2 string) (shim.HistoryQueryIteratorInterface, error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataHistoryForKeyReturnsOnCall[len(fake.getPrivateDataHistoryForKeyArgsForCall)]
+	fake.getPrivateDataHistoryForKeyArgsForCall = append(fake.getPrivateDataHistoryForKeyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetPrivateDataHistoryForKey", []interface{}{arg1, arg2})
+	fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	if fake.GetPrivateDataHistoryForKeyStub != nil {
+		return fake.GetPrivateDataHistoryForKeyStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataHistoryForKeyReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyCallCount() int {
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
+	return len(fake.getPrivateDataHistoryForKeyArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyCalls(stub func(string, string) (shim.HistoryQueryIteratorInterface, error)) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	defer fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	fake.GetPrivateDataHistoryForKeyStub = stub
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyArgsForCall(i int) (string, string) {
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
+	argsForCall := fake.getPrivateDataHistoryForKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyReturns(result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	defer fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	fake.GetPrivateDataHistoryForKeyStub = nil
+	fake.getPrivateDataHistoryForKeyReturns = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHistoryForKeyReturnsOnCall(i int, result1 shim.HistoryQueryIteratorInterface, result2 error) {
+	fake.getPrivateDataHistoryForKeyMutex.Lock()
+	defer fake.getPrivateDataHistoryForKeyMutex.Unlock()
+	fake.GetPrivateDataHistoryForKeyStub = nil
+	if fake.getPrivateDataHistoryForKeyReturnsOnCall == nil {
+		fake.getPrivateDataHistoryForKeyReturnsOnCall = make(map[int]struct {
+			result1 shim.HistoryQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getPrivateDataHistoryForKeyReturnsOnCall[i] = struct {
+		result1 shim.HistoryQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetPrivateDataQueryResult(arg1 string, arg2 string) (shim.StateQueryIteratorInterface, error) {
 	fake.getPrivateDataQueryResultMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataQueryResultReturnsOnCall[len(fake.getPrivateDataQueryResultArgsForCall)]
@@ -1450,6 +2082,75 @@ func (fake *ChaincodeStub) GetPrivateDataQueryResultReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPagination(arg1 string, arg2 string, arg3 int32, arg4 string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataQueryResultWithPaginationReturnsOnCall[len(fake.getPrivateDataQueryResultWithPaginationArgsForCall)]
+	fake.getPrivateDataQueryResultWithPaginationArgsForCall = append(fake.getPrivateDataQueryResultWithPaginationArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int32
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetPrivateDataQueryResultWithPagination", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getPrivateDataQueryResultWithPaginationMutex.Unlock()
+	if fake.GetPrivateDataQueryResultWithPaginationStub != nil {
+		return fake.GetPrivateDataQueryResultWithPaginationStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getPrivateDataQueryResultWithPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationCallCount() int {
+	fake.getPrivateDataQueryResultWithPaginationMutex.RLock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.RUnlock()
+	return len(fake.getPrivateDataQueryResultWithPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationCalls(stub func(string, string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.Lock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.Unlock()
+	fake.GetPrivateDataQueryResultWithPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationArgsForCall(i int) (string, string, int32, string) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.RLock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.RUnlock()
+	argsForCall := fake.getPrivateDataQueryResultWithPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationReturns(result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.Lock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.Unlock()
+	fake.GetPrivateDataQueryResultWithPaginationStub = nil
+	fake.getPrivateDataQueryResultWithPaginationReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataQueryResultWithPaginationReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getPrivateDataQueryResultWithPaginationMutex.Lock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.Unlock()
+	fake.GetPrivateDataQueryResultWithPaginationStub = nil
+	if fake.getPrivateDataQueryResultWithPaginationReturnsOnCall == nil {
+		fake.getPrivateDataQueryResultWithPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getPrivateDataQueryResultWithPaginationReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetPrivateDataValidationParameter(arg1 string, arg2 string) ([]byte, error) {
 	fake.getPrivateDataValidationParameterMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataValidationParameterReturnsOnCall[len(fake.getPrivateDataValidationParameterArgsForCall)]
@@ -1763,6 +2464,70 @@ func (fake *ChaincodeStub) GetStateReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateByIndex(arg1 string, arg2 ...interface{}) (shim.StateQueryIteratorInterface, error) {
+	fake.getStateByIndexMutex.Lock()
+	ret, specificReturn := fake.getStateByIndexReturnsOnCall[len(fake.getStateByIndexArgsForCall)]
+	fake.getStateByIndexArgsForCall = append(fake.getStateByIndexArgsForCall, struct {
+		arg1 string
+		arg2 []interface{}
+	}{arg1, arg2})
+	fake.recordInvocation("GetStateByIndex", []interface{}{arg1, arg2})
+	fake.getStateByIndexMutex.Unlock()
+	if fake.GetStateByIndexStub != nil {
+		return fake.GetStateByIndexStub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateByIndexReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateByIndexCallCount() int {
+	fake.getStateByIndexMutex.RLock()
+	defer fake.getStateByIndexMutex.RUnlock()
+	return len(fake.getStateByIndexArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateByIndexCalls(stub func(string, ...interface{}) (shim.StateQueryIteratorInterface, error)) {
+	fake.getStateByIndexMutex.Lock()
+	defer fake.getStateByIndexMutex.Unlock()
+	fake.GetStateByIndexStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateByIndexArgsForCall(i int) (string, []interface{}) {
+	fake.getStateByIndexMutex.RLock()
+	defer fake.getStateByIndexMutex.RUnlock()
+	argsForCall := fake.getStateByIndexArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetStateByIndexReturns(result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByIndexMutex.Lock()
+	defer fake.getStateByIndexMutex.Unlock()
+	fake.GetStateByIndexStub = nil
+	fake.getStateByIndexReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateByIndexReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByIndexMutex.Lock()
+	defer fake.getStateByIndexMutex.Unlock()
+	fake.GetStateByIndexStub = nil
+	if fake.getStateByIndexReturnsOnCall == nil {
+		fake.getStateByIndexReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getStateByIndexReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStateByPartialCompositeKey(arg1 string, arg2 []string) (shim.StateQueryIteratorInterface, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -2790,6 +3555,14 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.delPrivateDataMutex.RUnlock()
 	fake.delStateMutex.RLock()
 	defer fake.delStateMutex.RUnlock()
+	fake.delStateByRangeMutex.RLock()
+	defer fake.delStateByRangeMutex.RUnlock()
+	fake.delStateByPartialCompositeKeyMutex.RLock()
+	defer fake.delStateByPartialCompositeKeyMutex.RUnlock()
+	fake.setStateExpiryMutex.RLock()
+	defer fake.setStateExpiryMutex.RUnlock()
+	fake.getStateExpiryMutex.RLock()
+	defer fake.getStateExpiryMutex.RUnlock()
 	fake.getArgsMutex.RLock()
 	defer fake.getArgsMutex.RUnlock()
 	fake.getArgsSliceMutex.RLock()
@@ -2806,16 +3579,26 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getFunctionAndParametersMutex.RUnlock()
 	fake.getHistoryForKeyMutex.RLock()
 	defer fake.getHistoryForKeyMutex.RUnlock()
+	fake.getHistoryForKeyWithPaginationMutex.RLock()
+	defer fake.getHistoryForKeyWithPaginationMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
 	fake.getPrivateDataByPartialCompositeKeyMutex.RLock()
 	defer fake.getPrivateDataByPartialCompositeKeyMutex.RUnlock()
+	fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByPartialCompositeKeyWithPaginationMutex.RUnlock()
 	fake.getPrivateDataByRangeMutex.RLock()
 	defer fake.getPrivateDataByRangeMutex.RUnlock()
+	fake.getPrivateDataByRangeWithPaginationMutex.RLock()
+	defer fake.getPrivateDataByRangeWithPaginationMutex.RUnlock()
 	fake.getPrivateDataHashMutex.RLock()
 	defer fake.getPrivateDataHashMutex.RUnlock()
+	fake.getPrivateDataHistoryForKeyMutex.RLock()
+	defer fake.getPrivateDataHistoryForKeyMutex.RUnlock()
 	fake.getPrivateDataQueryResultMutex.RLock()
 	defer fake.getPrivateDataQueryResultMutex.RUnlock()
+	fake.getPrivateDataQueryResultWithPaginationMutex.RLock()
+	defer fake.getPrivateDataQueryResultWithPaginationMutex.RUnlock()
 	fake.getPrivateDataValidationParameterMutex.RLock()
 	defer fake.getPrivateDataValidationParameterMutex.RUnlock()
 	fake.getQueryResultMutex.RLock()
@@ -2826,6 +3609,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getSignedProposalMutex.RUnlock()
 	fake.getStateMutex.RLock()
 	defer fake.getStateMutex.RUnlock()
+	fake.getStateByIndexMutex.RLock()
+	defer fake.getStateByIndexMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyWithPaginationMutex.RLock()
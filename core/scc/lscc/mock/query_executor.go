@@ -42,6 +42,22 @@ type QueryExecutor struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	ExecuteQueryOnPrivateDataWithMetadataStub        func(string, string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)
+	executeQueryOnPrivateDataWithMetadataMutex       sync.RWMutex
+	executeQueryOnPrivateDataWithMetadataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 map[string]interface{}
+	}
+	executeQueryOnPrivateDataWithMetadataReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	executeQueryOnPrivateDataWithMetadataReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
 	ExecuteQueryWithMetadataStub        func(string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)
 	executeQueryWithMetadataMutex       sync.RWMutex
 	executeQueryWithMetadataArgsForCall []struct {
@@ -148,6 +164,23 @@ type QueryExecutor struct {
 		result1 ledger.ResultsIterator
 		result2 error
 	}
+	GetPrivateDataRangeScanIteratorWithMetadataStub        func(string, string, string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)
+	getPrivateDataRangeScanIteratorWithMetadataMutex       sync.RWMutex
+	getPrivateDataRangeScanIteratorWithMetadataArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 map[string]interface{}
+	}
+	getPrivateDataRangeScanIteratorWithMetadataReturns struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
+	getPrivateDataRangeScanIteratorWithMetadataReturnsOnCall map[int]struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}
 	GetStateStub        func(string, string) ([]byte, error)
 	getStateMutex       sync.RWMutex
 	getStateArgsForCall []struct {
@@ -162,6 +195,21 @@ type QueryExecutor struct {
 		result1 []byte
 		result2 error
 	}
+	GetStateByIndexStub        func(string, string, []interface{}) (ledger.ResultsIterator, error)
+	getStateByIndexMutex       sync.RWMutex
+	getStateByIndexArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []interface{}
+	}
+	getStateByIndexReturns struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
+	getStateByIndexReturnsOnCall map[int]struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}
 	GetStateMetadataStub        func(string, string) (map[string][]byte, error)
 	getStateMetadataMutex       sync.RWMutex
 	getStateMetadataArgsForCall []struct {
@@ -377,6 +425,72 @@ func (fake *QueryExecutor) ExecuteQueryOnPrivateDataReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *QueryExecutor) ExecuteQueryOnPrivateDataWithMetadata(arg1 string, arg2 string, arg3 string, arg4 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Lock()
+	ret, specificReturn := fake.executeQueryOnPrivateDataWithMetadataReturnsOnCall[len(fake.executeQueryOnPrivateDataWithMetadataArgsForCall)]
+	fake.executeQueryOnPrivateDataWithMetadataArgsForCall = append(fake.executeQueryOnPrivateDataWithMetadataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 map[string]interface{}
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ExecuteQueryOnPrivateDataWithMetadata", []interface{}{arg1, arg2, arg3, arg4})
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Unlock()
+	if fake.ExecuteQueryOnPrivateDataWithMetadataStub != nil {
+		return fake.ExecuteQueryOnPrivateDataWithMetadataStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.executeQueryOnPrivateDataWithMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *QueryExecutor) ExecuteQueryOnPrivateDataWithMetadataCallCount() int {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.RLock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.RUnlock()
+	return len(fake.executeQueryOnPrivateDataWithMetadataArgsForCall)
+}
+
+func (fake *QueryExecutor) ExecuteQueryOnPrivateDataWithMetadataCalls(stub func(string, string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Lock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.Unlock()
+	fake.ExecuteQueryOnPrivateDataWithMetadataStub = stub
+}
+
+func (fake *QueryExecutor) ExecuteQueryOnPrivateDataWithMetadataArgsForCall(i int) (string, string, string, map[string]interface{}) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.RLock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.RUnlock()
+	argsForCall := fake.executeQueryOnPrivateDataWithMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *QueryExecutor) ExecuteQueryOnPrivateDataWithMetadataReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Lock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.Unlock()
+	fake.ExecuteQueryOnPrivateDataWithMetadataStub = nil
+	fake.executeQueryOnPrivateDataWithMetadataReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QueryExecutor) ExecuteQueryOnPrivateDataWithMetadataReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.executeQueryOnPrivateDataWithMetadataMutex.Lock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.Unlock()
+	fake.ExecuteQueryOnPrivateDataWithMetadataStub = nil
+	if fake.executeQueryOnPrivateDataWithMetadataReturnsOnCall == nil {
+		fake.executeQueryOnPrivateDataWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.executeQueryOnPrivateDataWithMetadataReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *QueryExecutor) ExecuteQueryWithMetadata(arg1 string, arg2 string, arg3 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
 	fake.executeQueryWithMetadataMutex.Lock()
 	ret, specificReturn := fake.executeQueryWithMetadataReturnsOnCall[len(fake.executeQueryWithMetadataArgsForCall)]
@@ -843,6 +957,73 @@ func (fake *QueryExecutor) GetPrivateDataRangeScanIteratorReturnsOnCall(i int, r
 	}{result1, result2}
 }
 
+func (fake *QueryExecutor) GetPrivateDataRangeScanIteratorWithMetadata(arg1 string, arg2 string, arg3 string, arg4 string, arg5 map[string]interface{}) (ledgera.QueryResultsIterator, error) {
+	fake.getPrivateDataRangeScanIteratorWithMetadataMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataRangeScanIteratorWithMetadataReturnsOnCall[len(fake.getPrivateDataRangeScanIteratorWithMetadataArgsForCall)]
+	fake.getPrivateDataRangeScanIteratorWithMetadataArgsForCall = append(fake.getPrivateDataRangeScanIteratorWithMetadataArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 map[string]interface{}
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("GetPrivateDataRangeScanIteratorWithMetadata", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getPrivateDataRangeScanIteratorWithMetadataMutex.Unlock()
+	if fake.GetPrivateDataRangeScanIteratorWithMetadataStub != nil {
+		return fake.GetPrivateDataRangeScanIteratorWithMetadataStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataRangeScanIteratorWithMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *QueryExecutor) GetPrivateDataRangeScanIteratorWithMetadataCallCount() int {
+	fake.getPrivateDataRangeScanIteratorWithMetadataMutex.RLock()
+	defer fake.getPrivateDataRangeScanIteratorWithMetadataMutex.RUnlock()
+	return len(fake.getPrivateDataRangeScanIteratorWithMetadataArgsForCall)
+}
+
+func (fake *QueryExecutor) GetPrivateDataRangeScanIteratorWithMetadataCalls(stub func(string, string, string, string, map[string]interface{}) (ledgera.QueryResultsIterator, error)) {
+	fake.getPrivateDataRangeScanIteratorWithMetadataMutex.Lock()
+	defer fake.getPrivateDataRangeScanIteratorWithMetadataMutex.Unlock()
+	fake.GetPrivateDataRangeScanIteratorWithMetadataStub = stub
+}
+
+func (fake *QueryExecutor) GetPrivateDataRangeScanIteratorWithMetadataArgsForCall(i int) (string, string, string, string, map[string]interface{}) {
+	fake.getPrivateDataRangeScanIteratorWithMetadataMutex.RLock()
+	defer fake.getPrivateDataRangeScanIteratorWithMetadataMutex.RUnlock()
+	argsForCall := fake.getPrivateDataRangeScanIteratorWithMetadataArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *QueryExecutor) GetPrivateDataRangeScanIteratorWithMetadataReturns(result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getPrivateDataRangeScanIteratorWithMetadataMutex.Lock()
+	defer fake.getPrivateDataRangeScanIteratorWithMetadataMutex.Unlock()
+	fake.GetPrivateDataRangeScanIteratorWithMetadataStub = nil
+	fake.getPrivateDataRangeScanIteratorWithMetadataReturns = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QueryExecutor) GetPrivateDataRangeScanIteratorWithMetadataReturnsOnCall(i int, result1 ledgera.QueryResultsIterator, result2 error) {
+	fake.getPrivateDataRangeScanIteratorWithMetadataMutex.Lock()
+	defer fake.getPrivateDataRangeScanIteratorWithMetadataMutex.Unlock()
+	fake.GetPrivateDataRangeScanIteratorWithMetadataStub = nil
+	if fake.getPrivateDataRangeScanIteratorWithMetadataReturnsOnCall == nil {
+		fake.getPrivateDataRangeScanIteratorWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 ledgera.QueryResultsIterator
+			result2 error
+		})
+	}
+	fake.getPrivateDataRangeScanIteratorWithMetadataReturnsOnCall[i] = struct {
+		result1 ledgera.QueryResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *QueryExecutor) GetState(arg1 string, arg2 string) ([]byte, error) {
 	fake.getStateMutex.Lock()
 	ret, specificReturn := fake.getStateReturnsOnCall[len(fake.getStateArgsForCall)]
@@ -907,6 +1088,76 @@ func (fake *QueryExecutor) GetStateReturnsOnCall(i int, result1 []byte, result2
 	}{result1, result2}
 }
 
+func (fake *QueryExecutor) GetStateByIndex(arg1 string, arg2 string, arg3 []interface{}) (ledger.ResultsIterator, error) {
+	var arg3Copy []interface{}
+	if arg3 != nil {
+		arg3Copy = make([]interface{}, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getStateByIndexMutex.Lock()
+	ret, specificReturn := fake.getStateByIndexReturnsOnCall[len(fake.getStateByIndexArgsForCall)]
+	fake.getStateByIndexArgsForCall = append(fake.getStateByIndexArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []interface{}
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("GetStateByIndex", []interface{}{arg1, arg2, arg3Copy})
+	fake.getStateByIndexMutex.Unlock()
+	if fake.GetStateByIndexStub != nil {
+		return fake.GetStateByIndexStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateByIndexReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *QueryExecutor) GetStateByIndexCallCount() int {
+	fake.getStateByIndexMutex.RLock()
+	defer fake.getStateByIndexMutex.RUnlock()
+	return len(fake.getStateByIndexArgsForCall)
+}
+
+func (fake *QueryExecutor) GetStateByIndexCalls(stub func(string, string, []interface{}) (ledger.ResultsIterator, error)) {
+	fake.getStateByIndexMutex.Lock()
+	defer fake.getStateByIndexMutex.Unlock()
+	fake.GetStateByIndexStub = stub
+}
+
+func (fake *QueryExecutor) GetStateByIndexArgsForCall(i int) (string, string, []interface{}) {
+	fake.getStateByIndexMutex.RLock()
+	defer fake.getStateByIndexMutex.RUnlock()
+	argsForCall := fake.getStateByIndexArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *QueryExecutor) GetStateByIndexReturns(result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateByIndexMutex.Lock()
+	defer fake.getStateByIndexMutex.Unlock()
+	fake.GetStateByIndexStub = nil
+	fake.getStateByIndexReturns = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *QueryExecutor) GetStateByIndexReturnsOnCall(i int, result1 ledger.ResultsIterator, result2 error) {
+	fake.getStateByIndexMutex.Lock()
+	defer fake.getStateByIndexMutex.Unlock()
+	fake.GetStateByIndexStub = nil
+	if fake.getStateByIndexReturnsOnCall == nil {
+		fake.getStateByIndexReturnsOnCall = make(map[int]struct {
+			result1 ledger.ResultsIterator
+			result2 error
+		})
+	}
+	fake.getStateByIndexReturnsOnCall[i] = struct {
+		result1 ledger.ResultsIterator
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *QueryExecutor) GetStateMetadata(arg1 string, arg2 string) (map[string][]byte, error) {
 	fake.getStateMetadataMutex.Lock()
 	ret, specificReturn := fake.getStateMetadataReturnsOnCall[len(fake.getStateMetadataArgsForCall)]
@@ -1180,6 +1431,8 @@ func (fake *QueryExecutor) Invocations() map[string][][]interface{} {
 	defer fake.executeQueryMutex.RUnlock()
 	fake.executeQueryOnPrivateDataMutex.RLock()
 	defer fake.executeQueryOnPrivateDataMutex.RUnlock()
+	fake.executeQueryOnPrivateDataWithMetadataMutex.RLock()
+	defer fake.executeQueryOnPrivateDataWithMetadataMutex.RUnlock()
 	fake.executeQueryWithMetadataMutex.RLock()
 	defer fake.executeQueryWithMetadataMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
@@ -1194,8 +1447,12 @@ func (fake *QueryExecutor) Invocations() map[string][][]interface{} {
 	defer fake.getPrivateDataMultipleKeysMutex.RUnlock()
 	fake.getPrivateDataRangeScanIteratorMutex.RLock()
 	defer fake.getPrivateDataRangeScanIteratorMutex.RUnlock()
+	fake.getPrivateDataRangeScanIteratorWithMetadataMutex.RLock()
+	defer fake.getPrivateDataRangeScanIteratorWithMetadataMutex.RUnlock()
 	fake.getStateMutex.RLock()
 	defer fake.getStateMutex.RUnlock()
+	fake.getStateByIndexMutex.RLock()
+	defer fake.getStateByIndexMutex.RUnlock()
 	fake.getStateMetadataMutex.RLock()
 	defer fake.getStateMetadataMutex.RUnlock()
 	fake.getStateMultipleKeysMutex.RLock()
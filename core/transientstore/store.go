@@ -34,6 +34,8 @@ var ErrStoreEmpty = errors.New("Transient store is empty")
 // StoreProvider provides an instance of a TransientStore
 type StoreProvider interface {
 	OpenStore(ledgerID string) (Store, error)
+	// Drop deletes all the private write sets in the transient store for the given ledgerID
+	Drop(ledgerID string) error
 	Close()
 }
 
@@ -131,6 +133,21 @@ func (provider *storeProvider) OpenStore(ledgerID string) (Store, error) {
 	return &store{db: dbHandle, ledgerID: ledgerID}, nil
 }
 
+// Drop deletes all the private write sets in the transient store for the given ledgerID
+func (provider *storeProvider) Drop(ledgerID string) error {
+	return provider.dbProvider.GetDBHandle(ledgerID).DeleteAll()
+}
+
+// DiskUsage implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (provider *storeProvider) DiskUsage(ledgerID string) (uint64, error) {
+	return provider.dbProvider.GetDBHandle(ledgerID).DiskUsage()
+}
+
+// Compact implements the function in the interface `leveldbhelper.StoreMaintainer`
+func (provider *storeProvider) Compact(ledgerID string) error {
+	return provider.dbProvider.GetDBHandle(ledgerID).Compact()
+}
+
 // Close closes the TransientStoreProvider
 func (provider *storeProvider) Close() {
 	provider.dbProvider.Close()
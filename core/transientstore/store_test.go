@@ -619,3 +619,32 @@ func sampleCollectionConfigPackage(colName string) *common.CollectionConfig {
 
 	return createCollectionConfig(colName, policyEnvelope, requiredPeerCount, maximumPeerCount)
 }
+
+func TestTransientStoreDrop(t *testing.T) {
+	env := NewTestStoreEnv(t)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	otherStore, err := env.TestStoreProvider.OpenStore("OtherStore")
+	assert.NoError(err)
+	samplePvtRWSetWithConfig := samplePvtDataWithConfigInfo(t)
+	assert.NoError(env.TestStore.PersistWithConfig("txid-1", 10, samplePvtRWSetWithConfig))
+	assert.NoError(otherStore.PersistWithConfig("txid-1", 10, samplePvtRWSetWithConfig))
+
+	assert.NoError(env.TestStoreProvider.Drop("TestStore"))
+	countResults := func(s Store) int {
+		iter, err := s.GetTxPvtRWSetByTxid("txid-1", nil)
+		assert.NoError(err)
+		defer iter.Close()
+		count := 0
+		for {
+			result, err := iter.NextWithConfig()
+			assert.NoError(err)
+			if result == nil {
+				return count
+			}
+			count++
+		}
+	}
+	assert.Equal(0, countResults(env.TestStore))
+	assert.Equal(1, countResults(otherStore))
+}
@@ -50,6 +50,9 @@ type GossipService interface {
 	InitializeChannel(chainID string, endpoints []string, support Support)
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *gproto.Payload) error
+	// LeaveChannel makes the peer leave the gossip network of the channel, stops the delivery of blocks
+	// for the channel and releases the resources allocated by InitializeChannel
+	LeaveChannel(chainID string)
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -358,6 +361,43 @@ func (g *gossipServiceImpl) AddPayload(chainID string, payload *gproto.Payload)
 	return g.chains[chainID].AddPayload(payload)
 }
 
+// LeaveChannel makes the peer leave the gossip network of the channel, stops the delivery of blocks
+// for the channel and releases the resources allocated by InitializeChannel
+func (g *gossipServiceImpl) LeaveChannel(chainID string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	logger.Info("Leaving channel", chainID)
+	g.LeaveChan(gossipCommon.ChainID(chainID))
+
+	// The leader election is stopped first so that the delivery of blocks is not started again
+	isLeader := viper.GetBool("peer.gossip.orgLeader")
+	if le, exists := g.leaderElection[chainID]; exists {
+		logger.Infof("Stopping leader election for %s", chainID)
+		le.Stop()
+		isLeader = le.IsLeader()
+		delete(g.leaderElection, chainID)
+	}
+	if ds := g.deliveryService[chainID]; ds != nil {
+		if isLeader {
+			if err := ds.StopDeliverForChannel(chainID); err != nil {
+				logger.Warningf("Delivery service is not able to stop blocks delivery for chain, due to %+v", errors.WithStack(err))
+			}
+		}
+		ds.Stop()
+	}
+	delete(g.deliveryService, chainID)
+
+	if stateProvider, exists := g.chains[chainID]; exists {
+		stateProvider.Stop()
+		delete(g.chains, chainID)
+	}
+	if handler, exists := g.privateHandlers[chainID]; exists {
+		handler.close()
+		delete(g.privateHandlers, chainID)
+	}
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	g.lock.Lock()
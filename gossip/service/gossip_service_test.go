@@ -303,6 +303,50 @@ func TestWithStaticDeliverClientBothStaticAndLeaderElection(t *testing.T) {
 	stopPeers(gossips)
 }
 
+func TestLeaveChannel(t *testing.T) {
+	util.SetVal("peer.gossip.useLeaderElection", false)
+	util.SetVal("peer.gossip.orgLeader", true)
+
+	n := 2
+	gossips := startPeers(t, n, 20600, 0, 1)
+
+	peerIndexes := []int{0, 1}
+	addPeersToChannel(t, n, 20600, "chanA", gossips, peerIndexes)
+	addPeersToChannel(t, n, 20600, "chanB", gossips, peerIndexes)
+
+	waitForFullMembership(t, gossips, n, time.Second*30, time.Second*2)
+
+	deliverServiceFactory := &mockDeliverServiceFactory{
+		service: &mockDeliverService{
+			running: make(map[string]bool),
+		},
+	}
+
+	g := gossips[0].(*gossipServiceImpl)
+	g.deliveryFactory = deliverServiceFactory
+	for _, channelName := range []string{"chanA", "chanB"} {
+		g.InitializeChannel(channelName, []string{"localhost:5005"}, Support{
+			Committer: &mockLedgerInfo{1},
+			Store:     &mockTransientStore{},
+		})
+	}
+	assert.True(t, deliverServiceFactory.service.running["chanA"])
+	assert.True(t, deliverServiceFactory.service.running["chanB"])
+
+	g.LeaveChannel("chanA")
+	assert.False(t, deliverServiceFactory.service.running["chanA"], "Block deliverer not stopped for the channel left")
+	assert.True(t, deliverServiceFactory.service.running["chanB"], "Block deliverer stopped for another channel")
+	assert.NotContains(t, g.deliveryService, "chanA")
+	assert.NotContains(t, g.chains, "chanA")
+	assert.NotContains(t, g.privateHandlers, "chanA")
+	assert.Contains(t, g.chains, "chanB")
+
+	// the peer no longer takes part in the gossip of the channel left
+	assert.Empty(t, g.PeersOfChannel(gossipCommon.ChainID("chanA")))
+
+	stopPeers(gossips)
+}
+
 type mockDeliverServiceFactory struct {
 	service *mockDeliverService
 }
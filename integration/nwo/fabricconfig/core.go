@@ -244,20 +244,30 @@ type Ledger struct {
 }
 
 type StateConfig struct {
-	StateDatabase string         `yaml:"stateDatabase,omitempty"`
-	CouchDBConfig *CouchDBConfig `yaml:"couchDBConfig,omitempty"`
+	StateDatabase string            `yaml:"stateDatabase,omitempty"`
+	Cache         *StateCacheConfig `yaml:"cache,omitempty"`
+	CouchDBConfig *CouchDBConfig    `yaml:"couchDBConfig,omitempty"`
+}
+
+type StateCacheConfig struct {
+	Size       int            `yaml:"size,omitempty"`
+	Chaincodes map[string]int `yaml:"chaincodes,omitempty"`
 }
 
 type CouchDBConfig struct {
-	CouchDBAddress          string        `yaml:"couchDBAddress,omitempty"`
-	Username                string        `yaml:"username,omitempty"`
-	Password                string        `yaml:"password,omitempty"`
-	MaxRetries              int           `yaml:"maxRetries,omitempty"`
-	MaxRetriesOnStartup     int           `yaml:"maxRetriesOnStartup,omitempty"`
-	RequestTimeout          time.Duration `yaml:"requestTimeout,omitempty"`
-	QueryLimit              int           `yaml:"queryLimit,omitempty"`
-	MaxBatchUpdateSize      int           `yaml:"maxBatchUpdateSize,omitempty"`
-	WarmIndexesAfterNBlocks int           `yaml:"warmIndexesAfteNBlocks,omitempty"`
+	CouchDBAddress           string        `yaml:"couchDBAddress,omitempty"`
+	Username                 string        `yaml:"username,omitempty"`
+	Password                 string        `yaml:"password,omitempty"`
+	MaxRetries               int           `yaml:"maxRetries,omitempty"`
+	MaxRetriesOnStartup      int           `yaml:"maxRetriesOnStartup,omitempty"`
+	RequestTimeout           time.Duration `yaml:"requestTimeout,omitempty"`
+	QueryLimit               int           `yaml:"queryLimit,omitempty"`
+	MaxBatchUpdateSize       int           `yaml:"maxBatchUpdateSize,omitempty"`
+	MaxIdleConnsPerHost      int           `yaml:"maxIdleConnsPerHost,omitempty"`
+	MaxConnsPerHost          int           `yaml:"maxConnsPerHost,omitempty"`
+	IdleConnTimeout          time.Duration `yaml:"idleConnTimeout,omitempty"`
+	MaxParallelRequestsPerDB int           `yaml:"maxParallelRequestsPerDB,omitempty"`
+	WarmIndexesAfterNBlocks  int           `yaml:"warmIndexesAfteNBlocks,omitempty"`
 }
 
 type HistoryConfig struct {
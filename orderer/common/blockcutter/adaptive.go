@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockcutter
+
+import (
+	"math"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+)
+
+// AdaptiveConfig bounds the batch timeout and the preferred maximum batch size which an
+// adaptive receiver picks according to the rate at which messages are ordered on its channel.
+// At no load the minimums are picked, and from HighLoadRate on the BatchTimeout and the
+// PreferredMaxBytes of the channel are picked, so that blocks are cut with low latency when
+// the channel is idle and with high throughput when it is busy.
+type AdaptiveConfig struct {
+	// MinBatchTimeout is the batch timeout picked when no messages are ordered.
+	MinBatchTimeout time.Duration
+	// MinPreferredMaxBytes is the preferred maximum batch size picked when no messages are ordered.
+	MinPreferredMaxBytes uint32
+	// HighLoadRate is the rate, in messages per second, from which the batch parameters
+	// of the channel are picked.
+	HighLoadRate uint32
+	// RateWindow is the time constant of the moving average of the rate of messages.
+	RateWindow time.Duration
+}
+
+// adaptiveBatching picks the batch parameters of the pending batch of an adaptive receiver.
+type adaptiveBatching struct {
+	config AdaptiveConfig
+	rate   rateMeter
+
+	batchTimeout      time.Duration
+	preferredMaxBytes uint32
+}
+
+func newAdaptiveBatching(config AdaptiveConfig) *adaptiveBatching {
+	return &adaptiveBatching{
+		config: config,
+		rate:   rateMeter{window: config.RateWindow},
+	}
+}
+
+// adapt picks the batch timeout and the preferred maximum size of a new batch, by scaling
+// them between the configured minimums and the batch parameters of the channel according
+// to the rate of messages.
+func (ab *adaptiveBatching) adapt(ordererConfig channelconfig.Orderer, now time.Time) {
+	load := 1.0
+	if ab.config.HighLoadRate > 0 {
+		load = math.Min(ab.rate.rateAt(now)/float64(ab.config.HighLoadRate), 1)
+	}
+
+	maxBatchTimeout := ordererConfig.BatchTimeout()
+	minBatchTimeout := ab.config.MinBatchTimeout
+	if minBatchTimeout > maxBatchTimeout {
+		minBatchTimeout = maxBatchTimeout
+	}
+	ab.batchTimeout = minBatchTimeout + time.Duration(load*float64(maxBatchTimeout-minBatchTimeout))
+
+	maxPreferredMaxBytes := ordererConfig.BatchSize().PreferredMaxBytes
+	minPreferredMaxBytes := ab.config.MinPreferredMaxBytes
+	if minPreferredMaxBytes > maxPreferredMaxBytes {
+		minPreferredMaxBytes = maxPreferredMaxBytes
+	}
+	ab.preferredMaxBytes = minPreferredMaxBytes + uint32(load*float64(maxPreferredMaxBytes-minPreferredMaxBytes))
+}
+
+// rateMeter keeps an exponentially weighted moving average of the rate of events.
+type rateMeter struct {
+	window     time.Duration
+	rate       float64
+	lastUpdate time.Time
+}
+
+// mark records an event which occurred at the given time.
+func (rm *rateMeter) mark(now time.Time) {
+	rm.rate = rm.rateAt(now) + 1/rm.window.Seconds()
+	rm.lastUpdate = now
+}
+
+// rateAt returns the rate of events per second at the given time.
+func (rm *rateMeter) rateAt(now time.Time) float64 {
+	if rm.lastUpdate.IsZero() {
+		return 0
+	}
+	elapsed := now.Sub(rm.lastUpdate).Seconds()
+	return rm.rate * math.Exp(-elapsed/rm.window.Seconds())
+}
@@ -31,10 +31,25 @@ type Receiver interface {
 	Cut() []*cb.Envelope
 }
 
+// batchTimeoutPicker is implemented by receivers which pick the batch timeout of their pending batch.
+type batchTimeoutPicker interface {
+	BatchTimeout() time.Duration
+}
+
+// BatchTimeout returns the time after which the pending batch of the given receiver is to be cut:
+// the BatchTimeout of the channel, unless the receiver adapts it to the load of the channel.
+func BatchTimeout(receiver Receiver, ordererConfig channelconfig.Orderer) time.Duration {
+	if picker, ok := receiver.(batchTimeoutPicker); ok {
+		return picker.BatchTimeout()
+	}
+	return ordererConfig.BatchTimeout()
+}
+
 type receiver struct {
 	sharedConfigFetcher   OrdererConfigFetcher
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
+	adaptive              *adaptiveBatching
 
 	PendingBatchStartTime time.Time
 	ChannelID             string
@@ -50,6 +65,18 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 	}
 }
 
+// NewAdaptiveReceiver creates a Receiver which picks the batch timeout and the preferred
+// maximum size of each batch according to the rate at which messages are ordered, within
+// the bounds of the given config and of the batch parameters of the channel.
+func NewAdaptiveReceiver(channelID string, sharedConfigFetcher OrdererConfigFetcher, metrics *Metrics, config AdaptiveConfig) Receiver {
+	return &receiver{
+		sharedConfigFetcher: sharedConfigFetcher,
+		adaptive:            newAdaptiveBatching(config),
+		Metrics:             metrics,
+		ChannelID:           channelID,
+	}
+}
+
 // Ordered should be invoked sequentially as messages are ordered
 //
 // messageBatches length: 0, pending: false
@@ -67,21 +94,28 @@ func NewReceiverImpl(channelID string, sharedConfigFetcher OrdererConfigFetcher,
 //
 // Note that messageBatches can not be greater than 2.
 func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, pending bool) {
-	if len(r.pendingBatch) == 0 {
-		// We are beginning a new batch, mark the time
-		r.PendingBatchStartTime = time.Now()
-	}
-
 	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
 	if !ok {
 		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
 	}
 
+	if r.adaptive != nil {
+		r.adaptive.rate.mark(time.Now())
+	}
+	if len(r.pendingBatch) == 0 {
+		// We are beginning a new batch, mark the time
+		r.beginBatch(ordererConfig)
+	}
+
 	batchSize := ordererConfig.BatchSize()
+	preferredMaxBytes := batchSize.PreferredMaxBytes
+	if r.adaptive != nil {
+		preferredMaxBytes = r.adaptive.preferredMaxBytes
+	}
 
 	messageSizeBytes := messageSizeBytes(msg)
-	if messageSizeBytes > batchSize.PreferredMaxBytes {
-		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, batchSize.PreferredMaxBytes)
+	if messageSizeBytes > preferredMaxBytes {
+		logger.Debugf("The current message, with %v bytes, is larger than the preferred batch size of %v bytes and will be isolated.", messageSizeBytes, preferredMaxBytes)
 
 		// cut pending batch, if it has any messages
 		if len(r.pendingBatch) > 0 {
@@ -98,13 +132,13 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 		return
 	}
 
-	messageWillOverflowBatchSizeBytes := r.pendingBatchSizeBytes+messageSizeBytes > batchSize.PreferredMaxBytes
+	messageWillOverflowBatchSizeBytes := r.pendingBatchSizeBytes+messageSizeBytes > preferredMaxBytes
 
 	if messageWillOverflowBatchSizeBytes {
 		logger.Debugf("The current message, with %v bytes, will overflow the pending batch of %v bytes.", messageSizeBytes, r.pendingBatchSizeBytes)
 		logger.Debugf("Pending batch would overflow if current message is added, cutting batch now.")
 		messageBatch := r.Cut()
-		r.PendingBatchStartTime = time.Now()
+		r.beginBatch(ordererConfig)
 		messageBatches = append(messageBatches, messageBatch)
 	}
 
@@ -123,6 +157,36 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 	return
 }
 
+// beginBatch marks the time a new batch begins at and, if the receiver is adaptive,
+// picks the batch parameters of the new batch.
+func (r *receiver) beginBatch(ordererConfig channelconfig.Orderer) {
+	now := time.Now()
+	r.PendingBatchStartTime = now
+	if r.adaptive == nil {
+		return
+	}
+
+	r.adaptive.adapt(ordererConfig, now)
+	r.Metrics.BatchTimeout.With("channel", r.ChannelID).Set(r.adaptive.batchTimeout.Seconds())
+	r.Metrics.PreferredMaxBytes.With("channel", r.ChannelID).Set(float64(r.adaptive.preferredMaxBytes))
+	r.Metrics.BroadcastRate.With("channel", r.ChannelID).Set(r.adaptive.rate.rateAt(now))
+	logger.Debugf("Picked a batch timeout of %s and a preferred batch size of %d bytes", r.adaptive.batchTimeout, r.adaptive.preferredMaxBytes)
+}
+
+// BatchTimeout returns the batch timeout of the pending batch, which is the BatchTimeout
+// of the channel unless the receiver is adaptive.
+func (r *receiver) BatchTimeout() time.Duration {
+	if r.adaptive != nil && r.adaptive.batchTimeout > 0 {
+		return r.adaptive.batchTimeout
+	}
+
+	ordererConfig, ok := r.sharedConfigFetcher.OrdererConfig()
+	if !ok {
+		logger.Panicf("Could not retrieve orderer config to query batch parameters, block cutting is not possible")
+	}
+	return ordererConfig.BatchTimeout()
+}
+
 // Cut returns the current batch and starts a new one
 func (r *receiver) Cut() []*cb.Envelope {
 	r.Metrics.BlockFillDuration.With("channel", r.ChannelID).Observe(time.Since(r.PendingBatchStartTime).Seconds())
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -183,4 +185,125 @@ var _ = Describe("Blockcutter", func() {
 			})
 		})
 	})
+
+	Describe("BatchTimeout", func() {
+		BeforeEach(func() {
+			fakeConfig.BatchTimeoutReturns(2 * time.Second)
+		})
+
+		It("returns the batch timeout of the channel", func() {
+			Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(Equal(2 * time.Second))
+		})
+	})
+
+	Describe("Adaptive", func() {
+		var (
+			adaptiveConfig        blockcutter.AdaptiveConfig
+			message               *cb.Envelope
+			fakeBatchTimeout      *mock.MetricsGauge
+			fakePreferredMaxBytes *mock.MetricsGauge
+			fakeBroadcastRate     *mock.MetricsGauge
+		)
+
+		BeforeEach(func() {
+			fakeConfig.BatchTimeoutReturns(2 * time.Second)
+			fakeConfig.BatchSizeReturns(&ab.BatchSize{
+				MaxMessageCount:   100,
+				PreferredMaxBytes: 1000,
+			})
+
+			fakeBatchTimeout = &mock.MetricsGauge{}
+			fakeBatchTimeout.WithReturns(fakeBatchTimeout)
+			fakePreferredMaxBytes = &mock.MetricsGauge{}
+			fakePreferredMaxBytes.WithReturns(fakePreferredMaxBytes)
+			fakeBroadcastRate = &mock.MetricsGauge{}
+			fakeBroadcastRate.WithReturns(fakeBroadcastRate)
+			metrics.BatchTimeout = fakeBatchTimeout
+			metrics.PreferredMaxBytes = fakePreferredMaxBytes
+			metrics.BroadcastRate = fakeBroadcastRate
+
+			adaptiveConfig = blockcutter.AdaptiveConfig{
+				MinBatchTimeout:      100 * time.Millisecond,
+				MinPreferredMaxBytes: 50,
+				HighLoadRate:         10,
+				RateWindow:           time.Second,
+			}
+
+			message = &cb.Envelope{Payload: []byte("Twenty Bytes of Data"), Signature: []byte("Twenty Bytes of Data")}
+		})
+
+		JustBeforeEach(func() {
+			bc = blockcutter.NewAdaptiveReceiver("mychannel", fakeConfigFetcher, metrics, adaptiveConfig)
+		})
+
+		It("returns the batch timeout of the channel before any message is ordered", func() {
+			Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(Equal(2 * time.Second))
+		})
+
+		Context("when the load is low", func() {
+			BeforeEach(func() {
+				adaptiveConfig.HighLoadRate = 1000
+			})
+
+			It("picks the minimal batch parameters", func() {
+				batches, pending := bc.Ordered(message)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+				Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(BeNumerically("~", 100*time.Millisecond, 20*time.Millisecond))
+
+				By("cutting the batch once it exceeds the minimal preferred max bytes")
+				batches, pending = bc.Ordered(message)
+				Expect(len(batches)).To(Equal(1))
+				Expect(len(batches[0])).To(Equal(1))
+				Expect(pending).To(BeTrue())
+
+				Expect(fakeBatchTimeout.SetCallCount()).To(Equal(2))
+				Expect(fakeBatchTimeout.SetArgsForCall(0)).To(BeNumerically("~", 0.1, 0.02))
+				Expect(fakeBatchTimeout.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+				Expect(fakePreferredMaxBytes.SetCallCount()).To(Equal(2))
+				Expect(fakePreferredMaxBytes.SetArgsForCall(0)).To(BeNumerically("~", 50, 10))
+				Expect(fakePreferredMaxBytes.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+				Expect(fakeBroadcastRate.SetCallCount()).To(Equal(2))
+				Expect(fakeBroadcastRate.SetArgsForCall(0)).To(BeNumerically("~", 1, 0.1))
+				Expect(fakeBroadcastRate.WithArgsForCall(0)).To(Equal([]string{"channel", "mychannel"}))
+			})
+		})
+
+		Context("when the load is high", func() {
+			It("picks the batch parameters of the channel", func() {
+				for i := 0; i < 20; i++ {
+					bc.Ordered(message)
+				}
+				bc.Cut()
+
+				batches, pending := bc.Ordered(message)
+				Expect(batches).To(BeEmpty())
+				Expect(pending).To(BeTrue())
+				Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(Equal(2 * time.Second))
+
+				By("filling the batch up to the preferred max bytes of the channel")
+				for i := 0; i < 24; i++ {
+					batches, _ = bc.Ordered(message)
+					Expect(batches).To(BeEmpty())
+				}
+				batches, pending = bc.Ordered(message)
+				Expect(len(batches)).To(Equal(1))
+				Expect(len(batches[0])).To(Equal(25))
+				Expect(pending).To(BeTrue())
+			})
+		})
+
+		Context("when the minimal batch parameters exceed the batch parameters of the channel", func() {
+			BeforeEach(func() {
+				adaptiveConfig.MinBatchTimeout = time.Minute
+				adaptiveConfig.MinPreferredMaxBytes = 10000
+			})
+
+			It("picks the batch parameters of the channel", func() {
+				bc.Ordered(message)
+				Expect(blockcutter.BatchTimeout(bc, fakeConfig)).To(Equal(2 * time.Second))
+				Expect(fakePreferredMaxBytes.SetArgsForCall(0)).To(Equal(float64(1000)))
+			})
+		})
+	})
 })
@@ -16,14 +16,41 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	batchTimeout = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "batch_timeout",
+		Help:         "The batch timeout picked by adaptive block cutting for the pending block in seconds.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	preferredMaxBytes = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "preferred_max_bytes",
+		Help:         "The preferred maximum size picked by adaptive block cutting for the pending block in bytes.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	broadcastRate = metrics.GaugeOpts{
+		Namespace:    "blockcutter",
+		Name:         "broadcast_rate",
+		Help:         "The moving average of the rate of transactions ordered, observed by adaptive block cutting, in transactions per second.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 type Metrics struct {
 	BlockFillDuration metrics.Histogram
+	BatchTimeout      metrics.Gauge
+	PreferredMaxBytes metrics.Gauge
+	BroadcastRate     metrics.Gauge
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
 		BlockFillDuration: p.NewHistogram(blockFillDuration),
+		BatchTimeout:      p.NewGauge(batchTimeout),
+		PreferredMaxBytes: p.NewGauge(preferredMaxBytes),
+		BroadcastRate:     p.NewGauge(broadcastRate),
 	}
 }
@@ -23,14 +23,19 @@ var _ = Describe("Metrics", func() {
 		BeforeEach(func() {
 			fakeProvider = &mock.MetricsProvider{}
 			fakeProvider.NewHistogramReturns(&mock.MetricsHistogram{})
+			fakeProvider.NewGaugeReturns(&mock.MetricsGauge{})
 		})
 
 		It("uses the provider to initialize its field", func() {
 			metrics := blockcutter.NewMetrics(fakeProvider)
 			Expect(metrics).NotTo(BeNil())
 			Expect(metrics.BlockFillDuration).To(Equal(&mock.MetricsHistogram{}))
+			Expect(metrics.BatchTimeout).To(Equal(&mock.MetricsGauge{}))
+			Expect(metrics.PreferredMaxBytes).To(Equal(&mock.MetricsGauge{}))
+			Expect(metrics.BroadcastRate).To(Equal(&mock.MetricsGauge{}))
 
 			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
+			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(3))
 		})
 	})
 })
@@ -0,0 +1,224 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// URLBaseV1Channels is the URL of the channels resource of the channel participation API.
+const URLBaseV1Channels = "/participation/v1/channels"
+
+// DefaultMaxRequestBodySize is the maximal size of a config block which can be joined with,
+// if none is configured.
+const DefaultMaxRequestBodySize = 1024 * 1024
+
+// ChannelManagement joins the orderer to channels, lists them and removes the orderer from them.
+// It is implemented by the multichannel.Registrar.
+type ChannelManagement interface {
+	// ChannelList lists the channels the orderer is a member of.
+	ChannelList() ChannelList
+	// ChannelInfo describes the given channel, or returns ErrChannelNotExist.
+	ChannelInfo(channelID string) (ChannelInfo, error)
+	// JoinChannel joins the orderer to the channel of the given config block.
+	JoinChannel(channelID string, configBlock *common.Block) (ChannelInfo, error)
+	// RemoveChannel removes the orderer from the given channel, and removes its ledger.
+	RemoveChannel(channelID string) error
+}
+
+// ErrorResponse is the response to a failed request
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// HTTPHandler serves the channel participation API:
+//
+//	GET    /participation/v1/channels        lists the channels of the orderer
+//	GET    /participation/v1/channels/{name} describes a channel
+//	POST   /participation/v1/channels        joins the channel of the config block in the request body
+//	DELETE /participation/v1/channels/{name} removes the orderer from a channel
+type HTTPHandler struct {
+	Logger             *flogging.FabricLogger
+	Registrar          ChannelManagement
+	MaxRequestBodySize int64
+	router             *mux.Router
+}
+
+// NewHTTPHandler constructs an HTTPHandler, which reads config blocks of at most
+// maxRequestBodySize bytes, or DefaultMaxRequestBodySize if it is zero.
+func NewHTTPHandler(registrar ChannelManagement, maxRequestBodySize int64) *HTTPHandler {
+	if maxRequestBodySize == 0 {
+		maxRequestBodySize = DefaultMaxRequestBodySize
+	}
+
+	h := &HTTPHandler{
+		Logger:             flogging.MustGetLogger("orderer.commmon.channelparticipation"),
+		Registrar:          registrar,
+		MaxRequestBodySize: maxRequestBodySize,
+		router:             mux.NewRouter(),
+	}
+
+	h.router.HandleFunc(URLBaseV1Channels, h.serveListAll).Methods(http.MethodGet)
+	h.router.HandleFunc(URLBaseV1Channels, h.serveJoin).Methods(http.MethodPost)
+	h.router.HandleFunc(URLBaseV1Channels+"/{channelID}", h.serveListOne).Methods(http.MethodGet)
+	h.router.HandleFunc(URLBaseV1Channels+"/{channelID}", h.serveRemove).Methods(http.MethodDelete)
+	h.router.MethodNotAllowedHandler = http.HandlerFunc(h.serveNotAllowed)
+
+	return h
+}
+
+func (h *HTTPHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	h.router.ServeHTTP(resp, req)
+}
+
+func (h *HTTPHandler) serveListAll(resp http.ResponseWriter, req *http.Request) {
+	h.sendResponse(resp, http.StatusOK, h.Registrar.ChannelList())
+}
+
+func (h *HTTPHandler) serveListOne(resp http.ResponseWriter, req *http.Request) {
+	info, err := h.Registrar.ChannelInfo(mux.Vars(req)["channelID"])
+	if err != nil {
+		h.sendResponse(resp, statusCode(err), err)
+		return
+	}
+	h.sendResponse(resp, http.StatusOK, info)
+}
+
+func (h *HTTPHandler) serveJoin(resp http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(resp, req.Body, h.MaxRequestBodySize))
+	if err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, errors.Wrap(err, "cannot read request body"))
+		return
+	}
+
+	configBlock := &common.Block{}
+	if err := proto.Unmarshal(body, configBlock); err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, errors.Wrap(err, "cannot unmarshal config block"))
+		return
+	}
+	channelID, err := ValidateJoinBlock(configBlock)
+	if err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	info, err := h.Registrar.JoinChannel(channelID, configBlock)
+	if err != nil {
+		h.sendResponse(resp, statusCode(err), errors.WithMessage(err, "cannot join channel "+channelID))
+		return
+	}
+	h.Logger.Infow("joined channel", "channel", channelID, "block", configBlock.Header.Number, "status", info.Status)
+
+	resp.Header().Set("Location", info.URL)
+	h.sendResponse(resp, http.StatusCreated, info)
+}
+
+func (h *HTTPHandler) serveRemove(resp http.ResponseWriter, req *http.Request) {
+	channelID := mux.Vars(req)["channelID"]
+	if err := h.Registrar.RemoveChannel(channelID); err != nil {
+		h.sendResponse(resp, statusCode(err), errors.WithMessage(err, "cannot remove channel "+channelID))
+		return
+	}
+	h.Logger.Infow("removed channel", "channel", channelID)
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPHandler) serveNotAllowed(resp http.ResponseWriter, req *http.Request) {
+	allow := "GET, POST"
+	if req.URL.Path != URLBaseV1Channels {
+		allow = "GET, DELETE"
+	}
+	resp.Header().Set("Allow", allow)
+	h.sendResponse(resp, http.StatusMethodNotAllowed, errors.Errorf("invalid request method: %s", req.Method))
+}
+
+func (h *HTTPHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
+
+func statusCode(err error) int {
+	switch errors.Cause(err) {
+	case ErrChannelNotExist:
+		return http.StatusNotFound
+	case ErrSystemChannelExists, ErrChannelAlreadyExists, ErrChannelOnboarding:
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// ChannelURL returns the URL of the ChannelInfo of the given channel.
+func ChannelURL(channelID string) string {
+	return path.Join(URLBaseV1Channels, channelID)
+}
+
+// ValidateJoinBlock checks that the given block is a config block which an orderer can
+// join an application channel with, and returns the name of the channel.
+func ValidateJoinBlock(configBlock *common.Block) (string, error) {
+	if configBlock.Header == nil || configBlock.Data == nil {
+		return "", errors.New("block is empty")
+	}
+
+	envelope, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot extract envelope from block")
+	}
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot unmarshal payload of block")
+	}
+	if payload.Header == nil {
+		return "", errors.New("block does not carry a payload header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return "", errors.WithMessage(err, "cannot unmarshal channel header of block")
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_CONFIG {
+		return "", errors.New("block is not a config block")
+	}
+	if chdr.ChannelId == "" {
+		return "", errors.New("config block does not name a channel")
+	}
+
+	configEnvelope := &common.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+		return "", errors.Wrap(err, "cannot unmarshal config envelope of config block")
+	}
+	if configEnvelope.Config == nil || configEnvelope.Config.ChannelGroup == nil {
+		return "", errors.New("config block does not carry a config")
+	}
+	if _, exists := configEnvelope.Config.ChannelGroup.Groups[channelconfig.ConsortiumsGroupKey]; exists {
+		return "", errors.Errorf("config block of channel %s is a system channel block, the orderer must be bootstrapped with it instead", chdr.ChannelId)
+	}
+	if _, exists := configEnvelope.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]; !exists {
+		return "", errors.Errorf("config block of channel %s does not carry an orderer config", chdr.ChannelId)
+	}
+
+	return chdr.ChannelId, nil
+}
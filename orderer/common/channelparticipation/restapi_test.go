@@ -0,0 +1,254 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeManagement struct {
+	channels map[string]channelparticipation.ChannelInfo
+	joinErr  error
+	joined   *cb.Block
+}
+
+func (fm *fakeManagement) ChannelList() channelparticipation.ChannelList {
+	list := channelparticipation.ChannelList{}
+	for name, info := range fm.channels {
+		list.Channels = append(list.Channels, channelparticipation.ChannelInfoShort{Name: name, URL: info.URL})
+	}
+	return list
+}
+
+func (fm *fakeManagement) ChannelInfo(channelID string) (channelparticipation.ChannelInfo, error) {
+	info, exists := fm.channels[channelID]
+	if !exists {
+		return channelparticipation.ChannelInfo{}, channelparticipation.ErrChannelNotExist
+	}
+	return info, nil
+}
+
+func (fm *fakeManagement) JoinChannel(channelID string, configBlock *cb.Block) (channelparticipation.ChannelInfo, error) {
+	if fm.joinErr != nil {
+		return channelparticipation.ChannelInfo{}, fm.joinErr
+	}
+	fm.joined = configBlock
+	info := channelparticipation.ChannelInfo{
+		Name:   channelID,
+		URL:    channelparticipation.ChannelURL(channelID),
+		Status: channelparticipation.StatusActive,
+		Height: configBlock.Header.Number + 1,
+	}
+	fm.channels[channelID] = info
+	return info, nil
+}
+
+func (fm *fakeManagement) RemoveChannel(channelID string) error {
+	info, exists := fm.channels[channelID]
+	if !exists {
+		return channelparticipation.ErrChannelNotExist
+	}
+	if info.Status == channelparticipation.StatusOnboarding {
+		return channelparticipation.ErrChannelOnboarding
+	}
+	delete(fm.channels, channelID)
+	return nil
+}
+
+func newFakeManagement() *fakeManagement {
+	return &fakeManagement{
+		channels: map[string]channelparticipation.ChannelInfo{
+			"app-channel": {
+				Name:   "app-channel",
+				URL:    "/participation/v1/channels/app-channel",
+				Status: channelparticipation.StatusActive,
+				Height: 5,
+			},
+			"onboarding-channel": {
+				Name:   "onboarding-channel",
+				URL:    "/participation/v1/channels/onboarding-channel",
+				Status: channelparticipation.StatusOnboarding,
+			},
+		},
+	}
+}
+
+func configBlock(headerType cb.HeaderType, channelID string, groups ...string) *cb.Block {
+	channelGroup := cb.NewConfigGroup()
+	for _, group := range groups {
+		channelGroup.Groups[group] = cb.NewConfigGroup()
+	}
+	payload := &cb.Payload{
+		Header: utils.MakePayloadHeader(
+			utils.MakeChannelHeader(headerType, 0, channelID, 0),
+			utils.MakeSignatureHeader(nil, nil),
+		),
+		Data: utils.MarshalOrPanic(&cb.ConfigEnvelope{Config: &cb.Config{ChannelGroup: channelGroup}}),
+	}
+	block := cb.NewBlock(0, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(&cb.Envelope{Payload: utils.MarshalOrPanic(payload)})}
+	return block
+}
+
+func serve(h http.Handler, method, target string, body []byte) *httptest.ResponseRecorder {
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(method, target, bytes.NewReader(body)))
+	return resp
+}
+
+func decodeError(t *testing.T, resp *httptest.ResponseRecorder) string {
+	errResp := &channelparticipation.ErrorResponse{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(errResp))
+	return errResp.Error
+}
+
+func TestHTTPHandlerList(t *testing.T) {
+	h := channelparticipation.NewHTTPHandler(newFakeManagement(), 0)
+
+	resp := serve(h, http.MethodGet, "/participation/v1/channels", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	list := channelparticipation.ChannelList{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	assert.Len(t, list.Channels, 2)
+	assert.Nil(t, list.SystemChannel)
+
+	resp = serve(h, http.MethodGet, "/participation/v1/channels/app-channel", nil)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	info := channelparticipation.ChannelInfo{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+	assert.Equal(t, channelparticipation.ChannelInfo{
+		Name:   "app-channel",
+		URL:    "/participation/v1/channels/app-channel",
+		Status: "active",
+		Height: 5,
+	}, info)
+
+	resp = serve(h, http.MethodGet, "/participation/v1/channels/missing-channel", nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "channel does not exist", decodeError(t, resp))
+}
+
+func TestHTTPHandlerJoin(t *testing.T) {
+	fm := newFakeManagement()
+	h := channelparticipation.NewHTTPHandler(fm, 0)
+
+	block := configBlock(cb.HeaderType_CONFIG, "new-channel", channelconfig.OrdererGroupKey, channelconfig.ApplicationGroupKey)
+	resp := serve(h, http.MethodPost, "/participation/v1/channels", utils.MarshalOrPanic(block))
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Equal(t, "/participation/v1/channels/new-channel", resp.Header().Get("Location"))
+	info := channelparticipation.ChannelInfo{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+	assert.Equal(t, "new-channel", info.Name)
+	assert.Equal(t, uint64(1), info.Height)
+	assert.True(t, proto.Equal(block, fm.joined))
+
+	fm.joinErr = channelparticipation.ErrChannelAlreadyExists
+	resp = serve(h, http.MethodPost, "/participation/v1/channels", utils.MarshalOrPanic(block))
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Equal(t, "cannot join channel new-channel: channel already exists", decodeError(t, resp))
+
+	fm.joinErr = errors.New("bundle is invalid")
+	resp = serve(h, http.MethodPost, "/participation/v1/channels", utils.MarshalOrPanic(block))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, "cannot join channel new-channel: bundle is invalid", decodeError(t, resp))
+
+	resp = serve(h, http.MethodPost, "/participation/v1/channels", []byte("not a block"))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, decodeError(t, resp), "cannot unmarshal config block")
+
+	h = channelparticipation.NewHTTPHandler(fm, 10)
+	resp = serve(h, http.MethodPost, "/participation/v1/channels", utils.MarshalOrPanic(block))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, decodeError(t, resp), "cannot read request body")
+}
+
+func TestHTTPHandlerRemove(t *testing.T) {
+	fm := newFakeManagement()
+	h := channelparticipation.NewHTTPHandler(fm, 0)
+
+	resp := serve(h, http.MethodDelete, "/participation/v1/channels/app-channel", nil)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Empty(t, resp.Body.Bytes())
+	assert.NotContains(t, fm.channels, "app-channel")
+
+	resp = serve(h, http.MethodDelete, "/participation/v1/channels/app-channel", nil)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "cannot remove channel app-channel: channel does not exist", decodeError(t, resp))
+
+	resp = serve(h, http.MethodDelete, "/participation/v1/channels/onboarding-channel", nil)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Equal(t, "cannot remove channel onboarding-channel: channel is being onboarded", decodeError(t, resp))
+}
+
+func TestHTTPHandlerMethodNotAllowed(t *testing.T) {
+	h := channelparticipation.NewHTTPHandler(newFakeManagement(), 0)
+
+	resp := serve(h, http.MethodPut, "/participation/v1/channels", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Equal(t, "GET, POST", resp.Header().Get("Allow"))
+	assert.Equal(t, "invalid request method: PUT", decodeError(t, resp))
+
+	resp = serve(h, http.MethodPost, "/participation/v1/channels/app-channel", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Equal(t, "GET, DELETE", resp.Header().Get("Allow"))
+}
+
+func TestValidateJoinBlock(t *testing.T) {
+	channelID, err := channelparticipation.ValidateJoinBlock(configBlock(cb.HeaderType_CONFIG, "app-channel", channelconfig.OrdererGroupKey))
+	assert.NoError(t, err)
+	assert.Equal(t, "app-channel", channelID)
+
+	for _, testCase := range []struct {
+		name  string
+		block *cb.Block
+		err   string
+	}{
+		{
+			name:  "empty block",
+			block: &cb.Block{},
+			err:   "block is empty",
+		},
+		{
+			name:  "not a config block",
+			block: configBlock(cb.HeaderType_ENDORSER_TRANSACTION, "app-channel", channelconfig.OrdererGroupKey),
+			err:   "block is not a config block",
+		},
+		{
+			name:  "no channel",
+			block: configBlock(cb.HeaderType_CONFIG, "", channelconfig.OrdererGroupKey),
+			err:   "config block does not name a channel",
+		},
+		{
+			name:  "system channel",
+			block: configBlock(cb.HeaderType_CONFIG, "system-channel", channelconfig.OrdererGroupKey, channelconfig.ConsortiumsGroupKey),
+			err:   "config block of channel system-channel is a system channel block, the orderer must be bootstrapped with it instead",
+		},
+		{
+			name:  "no orderer group",
+			block: configBlock(cb.HeaderType_CONFIG, "app-channel", channelconfig.ApplicationGroupKey),
+			err:   "config block of channel app-channel does not carry an orderer config",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := channelparticipation.ValidateJoinBlock(testCase.block)
+			assert.EqualError(t, err, testCase.err)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelparticipation
+
+import (
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrSystemChannelExists is returned when the orderer is asked to join or leave a channel
+	// while it has a system channel, through which it manages its channels instead.
+	ErrSystemChannelExists = errors.New("system channel exists")
+
+	// ErrChannelAlreadyExists is returned when the orderer is asked to join a channel it is a member of.
+	ErrChannelAlreadyExists = errors.New("channel already exists")
+
+	// ErrChannelNotExist is returned when the orderer is asked about a channel it is not a member of.
+	ErrChannelNotExist = errors.New("channel does not exist")
+
+	// ErrChannelOnboarding is returned when the orderer is asked to leave a channel
+	// whose blocks are still being replicated.
+	ErrChannelOnboarding = errors.New("channel is being onboarded")
+)
+
+// The statuses of a channel the orderer is a member of.
+const (
+	// StatusActive is the status of a channel the orderer participates in.
+	StatusActive = "active"
+	// StatusOnboarding is the status of a channel whose blocks are being replicated
+	// from the other orderers, up to the config block it was joined with.
+	StatusOnboarding = "onboarding"
+	// StatusFailed is the status of a channel whose blocks could not be replicated.
+	StatusFailed = "failed"
+	// StatusInactive is the status of a channel whose consenters the orderer is not among.
+	StatusInactive = "inactive"
+)
+
+// ChannelList carries the channels the orderer is a member of.
+type ChannelList struct {
+	Channels []ChannelInfoShort `json:"channels"`
+	// SystemChannel is set if the orderer has a system channel, in which case it
+	// manages its channels through it.
+	SystemChannel *ChannelInfoShort `json:"systemChannel"`
+}
+
+// ChannelInfoShort names a channel and carries the URL of its ChannelInfo.
+type ChannelInfoShort struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ChannelInfo describes a channel the orderer is a member of.
+type ChannelInfo struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	// Error describes why the blocks of the channel could not be replicated, if its status is StatusFailed.
+	Error  string `json:"error,omitempty"`
+	Height uint64 `json:"height"`
+}
@@ -28,14 +28,16 @@ var logger = flogging.MustGetLogger("localconfig")
 // modify the default mapping, see the "Unmarshal"
 // section of https://github.com/spf13/viper for more info.
 type TopLevel struct {
-	General    General
-	FileLedger FileLedger
-	RAMLedger  RAMLedger
-	Kafka      Kafka
-	Debug      Debug
-	Consensus  interface{}
-	Operations Operations
-	Metrics    Metrics
+	General              General
+	FileLedger           FileLedger
+	RAMLedger            RAMLedger
+	Kafka                Kafka
+	Debug                Debug
+	Consensus            interface{}
+	BlockCutter          BlockCutter
+	Operations           Operations
+	Metrics              Metrics
+	ChannelParticipation ChannelParticipation
 }
 
 // General contains config which should be common among all orderer types.
@@ -191,6 +193,28 @@ type Operations struct {
 	TLS           TLS
 }
 
+// BlockCutter configures how the orderer cuts blocks.
+type BlockCutter struct {
+	Adaptive AdaptiveBlockCutting
+}
+
+// AdaptiveBlockCutting configures the adaptation of the batch timeout and the preferred
+// maximum size of blocks to the rate at which transactions are ordered on each channel.
+type AdaptiveBlockCutting struct {
+	Enabled              bool
+	MinBatchTimeout      time.Duration
+	MinPreferredMaxBytes uint32
+	HighLoadRate         uint32
+	RateWindow           time.Duration
+}
+
+// ChannelParticipation configures the channel participation API of the orderer,
+// which is served by the operations endpoint.
+type ChannelParticipation struct {
+	Enabled            bool
+	MaxRequestBodySize uint32
+}
+
 // Operations confiures the metrics provider for the orderer.
 type Metrics struct {
 	Provider string
@@ -278,6 +302,19 @@ var Defaults = TopLevel{
 	Metrics: Metrics{
 		Provider: "disabled",
 	},
+	BlockCutter: BlockCutter{
+		Adaptive: AdaptiveBlockCutting{
+			Enabled:              false,
+			MinBatchTimeout:      50 * time.Millisecond,
+			MinPreferredMaxBytes: 64 * 1024,
+			HighLoadRate:         1000,
+			RateWindow:           5 * time.Second,
+		},
+	},
+	ChannelParticipation: ChannelParticipation{
+		Enabled:            false,
+		MaxRequestBodySize: 1024 * 1024,
+	},
 }
 
 // Load parses the orderer YAML file and environment, producing
@@ -422,6 +459,23 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("Kafka.Version unset, setting to %v", Defaults.Kafka.Version)
 			c.Kafka.Version = Defaults.Kafka.Version
 
+		case c.BlockCutter.Adaptive.Enabled && c.BlockCutter.Adaptive.MinBatchTimeout == 0:
+			logger.Infof("BlockCutter.Adaptive.MinBatchTimeout unset, setting to %v", Defaults.BlockCutter.Adaptive.MinBatchTimeout)
+			c.BlockCutter.Adaptive.MinBatchTimeout = Defaults.BlockCutter.Adaptive.MinBatchTimeout
+		case c.BlockCutter.Adaptive.Enabled && c.BlockCutter.Adaptive.MinPreferredMaxBytes == 0:
+			logger.Infof("BlockCutter.Adaptive.MinPreferredMaxBytes unset, setting to %v", Defaults.BlockCutter.Adaptive.MinPreferredMaxBytes)
+			c.BlockCutter.Adaptive.MinPreferredMaxBytes = Defaults.BlockCutter.Adaptive.MinPreferredMaxBytes
+		case c.BlockCutter.Adaptive.Enabled && c.BlockCutter.Adaptive.HighLoadRate == 0:
+			logger.Infof("BlockCutter.Adaptive.HighLoadRate unset, setting to %v", Defaults.BlockCutter.Adaptive.HighLoadRate)
+			c.BlockCutter.Adaptive.HighLoadRate = Defaults.BlockCutter.Adaptive.HighLoadRate
+		case c.BlockCutter.Adaptive.Enabled && c.BlockCutter.Adaptive.RateWindow == 0:
+			logger.Infof("BlockCutter.Adaptive.RateWindow unset, setting to %v", Defaults.BlockCutter.Adaptive.RateWindow)
+			c.BlockCutter.Adaptive.RateWindow = Defaults.BlockCutter.Adaptive.RateWindow
+
+		case c.ChannelParticipation.Enabled && c.ChannelParticipation.MaxRequestBodySize == 0:
+			logger.Infof("ChannelParticipation.MaxRequestBodySize unset, setting to %v", Defaults.ChannelParticipation.MaxRequestBodySize)
+			c.ChannelParticipation.MaxRequestBodySize = Defaults.ChannelParticipation.MaxRequestBodySize
+
 		default:
 			return
 		}
@@ -141,6 +141,24 @@ func TestClusterDefaults(t *testing.T) {
 	assert.Equal(t, cfg.General.Cluster.ReplicationMaxRetries, Defaults.General.Cluster.ReplicationMaxRetries)
 }
 
+func TestAdaptiveBlockCuttingDefaults(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	cfg, err := Load()
+	assert.NoError(t, err)
+	assert.Equal(t, Defaults.BlockCutter.Adaptive, cfg.BlockCutter.Adaptive)
+
+	cfg.BlockCutter.Adaptive = AdaptiveBlockCutting{Enabled: true, HighLoadRate: 200}
+	cfg.completeInitialization("/dummy/path")
+	assert.Equal(t, AdaptiveBlockCutting{
+		Enabled:              true,
+		MinBatchTimeout:      Defaults.BlockCutter.Adaptive.MinBatchTimeout,
+		MinPreferredMaxBytes: Defaults.BlockCutter.Adaptive.MinPreferredMaxBytes,
+		HighLoadRate:         200,
+		RateWindow:           Defaults.BlockCutter.Adaptive.RateWindow,
+	}, cfg.BlockCutter.Adaptive)
+}
+
 func TestSystemChannel(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
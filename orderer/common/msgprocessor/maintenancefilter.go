@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"github.com/hyperledger/fabric/common/channelconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ErrMaintenanceMode is returned by the maintenance filter for messages which are
+// rejected because the channel is undergoing a consensus-type migration.
+var ErrMaintenanceMode = errors.New("maintenance mode")
+
+// InMaintenance returns whether a channel with the given orderer config is in maintenance mode,
+// that is, the orderer permits a Kafka to Raft migration and the channel is in the midst of one.
+// A channel enters maintenance mode with the first migration step, and leaves it once a config
+// update sets its migration state back to MIG_STATE_NONE.
+func InMaintenance(oc channelconfig.Orderer) bool {
+	return oc.Capabilities().Kafka2RaftMigration() && oc.ConsensusMigrationState() != ab.ConsensusType_MIG_STATE_NONE
+}
+
+// SystemChannelStatus reports on the state of the ordering system channel.
+type SystemChannelStatus interface {
+	// SystemChannelInMaintenance returns whether the ordering system channel is in maintenance mode.
+	SystemChannelInMaintenance() bool
+}
+
+// MaintenanceFilter implements the Rule interface.
+// While a channel is in maintenance mode it rejects normal transactions, as well as
+// channel creation requests on the system channel, so that the only messages ordered
+// are the config updates which drive the consensus-type migration.
+type MaintenanceFilter struct {
+	support       LimitedSupport
+	systemChannel SystemChannelStatus
+}
+
+// NewMaintenanceFilter creates a new maintenance filter. A standard channel is also considered
+// to be in maintenance mode while the system channel is, and systemChannel is used to determine
+// that; it may be nil for the system channel itself.
+func NewMaintenanceFilter(support LimitedSupport, systemChannel SystemChannelStatus) *MaintenanceFilter {
+	return &MaintenanceFilter{
+		support:       support,
+		systemChannel: systemChannel,
+	}
+}
+
+// Apply rejects messages which may not be ordered while the channel is in maintenance mode.
+func (mf *MaintenanceFilter) Apply(env *cb.Envelope) error {
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return errors.Errorf("bad channel header: %s", err)
+	}
+
+	switch chdr.Type {
+	case int32(cb.HeaderType_CONFIG_UPDATE), int32(cb.HeaderType_CONFIG):
+		return nil
+	case int32(cb.HeaderType_ORDERER_TRANSACTION):
+		if mf.inMaintenance() {
+			return errors.WithMessage(ErrMaintenanceMode, "channel creation is rejected")
+		}
+		return nil
+	default:
+		if mf.inMaintenance() {
+			return errors.WithMessage(ErrMaintenanceMode, "normal transactions are rejected")
+		}
+		return nil
+	}
+}
+
+func (mf *MaintenanceFilter) inMaintenance() bool {
+	ordererConfig, ok := mf.support.OrdererConfig()
+	if !ok {
+		logger.Panicf("Channel does not have orderer config")
+	}
+
+	if InMaintenance(ordererConfig) {
+		return true
+	}
+
+	return mf.systemChannel != nil && mf.systemChannel.SystemChannelInMaintenance()
+}
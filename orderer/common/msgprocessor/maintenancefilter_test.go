@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"testing"
+
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSystemChannelStatus bool
+
+func (m mockSystemChannelStatus) SystemChannelInMaintenance() bool {
+	return bool(m)
+}
+
+func makeEnvelopeOfType(headerType cb.HeaderType) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(headerType),
+					ChannelId: testChannelID,
+				}),
+			},
+		}),
+	}
+}
+
+func newMaintenanceSupport(migrationPermitted bool, state ab.ConsensusType_MigrationState) *mockSupport {
+	return &mockSupport{
+		msc: &mockconfig.Orderer{
+			ConsensusTypeVal:               "kafka",
+			ConsensusTypeMigrationStateVal: state,
+			CapabilitiesVal:                &mockconfig.OrdererCapabilities{Kafka2RaftMigVal: migrationPermitted},
+		},
+	}
+}
+
+func TestInMaintenance(t *testing.T) {
+	assert.False(t, InMaintenance(newMaintenanceSupport(true, ab.ConsensusType_MIG_STATE_NONE).msc))
+	assert.True(t, InMaintenance(newMaintenanceSupport(true, ab.ConsensusType_MIG_STATE_START).msc))
+	assert.True(t, InMaintenance(newMaintenanceSupport(true, ab.ConsensusType_MIG_STATE_CONTEXT).msc))
+	assert.False(t, InMaintenance(newMaintenanceSupport(false, ab.ConsensusType_MIG_STATE_START).msc))
+}
+
+func TestMaintenanceFilter(t *testing.T) {
+	normalTx := makeEnvelopeOfType(cb.HeaderType_ENDORSER_TRANSACTION)
+	configUpdateTx := makeEnvelopeOfType(cb.HeaderType_CONFIG_UPDATE)
+	configTx := makeEnvelopeOfType(cb.HeaderType_CONFIG)
+	channelCreationTx := makeEnvelopeOfType(cb.HeaderType_ORDERER_TRANSACTION)
+
+	t.Run("NotInMaintenance", func(t *testing.T) {
+		mf := NewMaintenanceFilter(newMaintenanceSupport(true, ab.ConsensusType_MIG_STATE_NONE), mockSystemChannelStatus(false))
+		assert.NoError(t, mf.Apply(normalTx))
+		assert.NoError(t, mf.Apply(configUpdateTx))
+		assert.NoError(t, mf.Apply(configTx))
+		assert.NoError(t, mf.Apply(channelCreationTx))
+	})
+
+	t.Run("InMaintenance", func(t *testing.T) {
+		mf := NewMaintenanceFilter(newMaintenanceSupport(true, ab.ConsensusType_MIG_STATE_START), nil)
+		err := mf.Apply(normalTx)
+		assert.Equal(t, ErrMaintenanceMode, errors.Cause(err))
+		assert.EqualError(t, err, "normal transactions are rejected: maintenance mode")
+		err = mf.Apply(channelCreationTx)
+		assert.Equal(t, ErrMaintenanceMode, errors.Cause(err))
+		assert.EqualError(t, err, "channel creation is rejected: maintenance mode")
+		assert.NoError(t, mf.Apply(configUpdateTx))
+		assert.NoError(t, mf.Apply(configTx))
+	})
+
+	t.Run("SystemChannelInMaintenance", func(t *testing.T) {
+		mf := NewMaintenanceFilter(newMaintenanceSupport(true, ab.ConsensusType_MIG_STATE_NONE), mockSystemChannelStatus(true))
+		assert.Equal(t, ErrMaintenanceMode, errors.Cause(mf.Apply(normalTx)))
+		assert.NoError(t, mf.Apply(configUpdateTx))
+	})
+
+	t.Run("BadHeader", func(t *testing.T) {
+		mf := NewMaintenanceFilter(newMaintenanceSupport(true, ab.ConsensusType_MIG_STATE_NONE), nil)
+		assert.Error(t, mf.Apply(&cb.Envelope{Payload: []byte("bad")}))
+	})
+}
@@ -45,7 +45,7 @@ func NewStandardChannel(support StandardChannelSupport, filters *RuleSet) *Stand
 }
 
 // CreateStandardChannelFilters creates the set of filters for a normal (non-system) chain
-func CreateStandardChannelFilters(filterSupport channelconfig.Resources) *RuleSet {
+func CreateStandardChannelFilters(filterSupport channelconfig.Resources, systemChannel SystemChannelStatus) *RuleSet {
 	ordererConfig, ok := filterSupport.OrdererConfig()
 	if !ok {
 		logger.Panicf("Missing orderer config")
@@ -55,6 +55,7 @@ func CreateStandardChannelFilters(filterSupport channelconfig.Resources) *RuleSe
 		NewExpirationRejectRule(filterSupport),
 		NewSizeFilter(ordererConfig),
 		NewSigFilter(policies.ChannelWriters, filterSupport),
+		NewMaintenanceFilter(filterSupport, systemChannel),
 	})
 }
 
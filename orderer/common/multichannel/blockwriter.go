@@ -38,6 +38,9 @@ type BlockWriter struct {
 	lastConfigSeq      uint64
 	lastBlock          *cb.Block
 	committingBlock    sync.Mutex
+	// handedOff is set once a config block switched the consensus type of the channel,
+	// after which the consenter which cut that block may not write any further blocks.
+	handedOff bool
 }
 
 func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSupport) *BlockWriter {
@@ -88,7 +91,14 @@ func (bw *BlockWriter) CreateNextBlock(messages []*cb.Envelope) *cb.Block {
 // WriteConfigBlock should be invoked for blocks which contain a config transaction.
 // This call will block until the new config has taken effect, then will return
 // while the block is written asynchronously to disk.
+// If the config switches the consensus type of the channel, the channel is handed
+// off to a consenter of the new type once the block is written.
 func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []byte) {
+	if bw.handedOff {
+		logger.Warningf("[channel: %s] Discarding config block %d, the channel has been handed off to another consenter", bw.support.ChainID(), block.Header.Number)
+		return
+	}
+
 	ctx, err := utils.ExtractEnvelope(block, 0)
 	if err != nil {
 		logger.Panicf("Told to write a config block, but could not get configtx: %s", err)
@@ -121,6 +131,9 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 			logger.Panicf("Told to write a config block with new channel, but did not have config envelope encoded: %s", err)
 		}
 
+		oldConsensusType := consensusType(bw.support.ConfigProto())
+		newConsensusType := consensusType(configEnvelope.Config)
+
 		err = bw.support.Validate(configEnvelope)
 		if err != nil {
 			logger.Panicf("Told to write a config block with new config, but could not apply it: %s", err)
@@ -132,6 +145,15 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 		}
 
 		bw.support.Update(bundle)
+
+		if oldConsensusType != "" && oldConsensusType != newConsensusType {
+			logger.Infof("[channel: %s] Config block %d switches consensus type from %s to %s",
+				chdr.ChannelId, block.Header.Number, oldConsensusType, newConsensusType)
+			bw.WriteBlock(block, encodedMetadataValue)
+			bw.handedOff = true
+			go bw.registrar.handOffChain(chdr.ChannelId)
+			return
+		}
 	default:
 		logger.Panicf("Told to write a config block with unknown header type: %v", chdr.Type)
 	}
@@ -146,6 +168,11 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 // then release the lock.  This allows the calling thread to begin assembling the next block
 // before the commit phase is complete.
 func (bw *BlockWriter) WriteBlock(block *cb.Block, encodedMetadataValue []byte) {
+	if bw.handedOff {
+		logger.Warningf("[channel: %s] Discarding block %d, the channel has been handed off to another consenter", bw.support.ChainID(), block.Header.Number)
+		return
+	}
+
 	bw.committingBlock.Lock()
 	bw.lastBlock = block
 
@@ -15,7 +15,6 @@ import (
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
-	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -27,6 +26,7 @@ type ChainSupport struct {
 	consensus.Chain
 	cutter blockcutter.Receiver
 	crypto.LocalSigner
+	registrar *Registrar
 }
 
 func newChainSupport(
@@ -39,7 +39,7 @@ func newChainSupport(
 	// Read in the last block and metadata for the channel
 	lastBlock := blockledger.GetBlock(ledgerResources, ledgerResources.Height()-1)
 
-	metadata, err := utils.GetMetadataFromBlock(lastBlock, cb.BlockMetadataIndex_ORDERER)
+	metadata, err := consenterMetadata(ledgerResources, lastBlock)
 	// Assuming a block created with cb.NewBlock(), this should not
 	// error even if the orderer metadata is an empty byte slice
 	if err != nil {
@@ -50,6 +50,7 @@ func newChainSupport(
 	cs := &ChainSupport{
 		ledgerResources: ledgerResources,
 		LocalSigner:     signer,
+		registrar:       registrar,
 		cutter: blockcutter.NewReceiverImpl(
 			ledgerResources.ConfigtxValidator().ChainID(),
 			ledgerResources,
@@ -57,14 +58,25 @@ func newChainSupport(
 		),
 	}
 
+	// Blocks are cut by every Kafka based orderer, which must all cut the same blocks,
+	// so the batch parameters cannot be adapted to the load observed by each of them.
+	consenterType := ledgerResources.SharedConfig().ConsensusType()
+	if registrar.adaptiveCutting != nil && consenterType != "kafka" {
+		cs.cutter = blockcutter.NewAdaptiveReceiver(
+			ledgerResources.ConfigtxValidator().ChainID(),
+			ledgerResources,
+			blockcutterMetrics,
+			*registrar.adaptiveCutting,
+		)
+	}
+
 	// Set up the msgprocessor
-	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs))
+	cs.Processor = msgprocessor.NewStandardChannel(cs, msgprocessor.CreateStandardChannelFilters(cs, registrar))
 
 	// Set up the block writer
 	cs.BlockWriter = newBlockWriter(lastBlock, registrar, cs)
 
 	// Set up the consenter
-	consenterType := ledgerResources.SharedConfig().ConsensusType()
 	consenter, ok := consenters[consenterType]
 	if !ok {
 		logger.Panicf("Error retrieving consenter of type: %s", consenterType)
@@ -128,6 +140,11 @@ func (cs *ChainSupport) ProposeConfigUpdate(configtx *cb.Envelope) (*cb.ConfigEn
 		return nil, errors.Wrap(err, "config update is not compatible")
 	}
 
+	newOrdererConfig, _ := bundle.OrdererConfig()
+	if err = cs.registrar.validateConsensusTypeChange(cs.ChainID(), cs.SharedConfig(), newOrdererConfig); err != nil {
+		return nil, errors.WithMessage(err, "config update is not compatible")
+	}
+
 	return env, cs.ValidateNew(bundle)
 }
 
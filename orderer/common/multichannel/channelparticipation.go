@@ -0,0 +1,307 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichannel
+
+import (
+	"sort"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/hyperledger/fabric/orderer/consensus/inactive"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ChannelPuller replicates the blocks of a channel from the other orderers of the channel.
+type ChannelPuller interface {
+	// PullChannel pulls the blocks of the given channel into its ledger, up to and including
+	// the given config block, and verifies that the last block pulled is the config block.
+	PullChannel(channelID string, configBlock *cb.Block) error
+}
+
+// chainDataRemover is implemented by chains which keep data of their own besides the ledger,
+// such as the write-ahead log and snapshots of a Raft chain.
+type chainDataRemover interface {
+	// RemoveData removes the data of the chain, once it is halted.
+	RemoveData() error
+}
+
+// joiningChannel tracks a channel whose blocks are being pulled from the other orderers
+// before it is started, or failed to be pulled.
+type joiningChannel struct {
+	status string
+	err    error
+	ledger blockledger.Reader
+}
+
+// SetChannelPuller sets the ChannelPuller which pulls the blocks of channels which
+// are joined with a config block other than their genesis block.
+func (r *Registrar) SetChannelPuller(channelPuller ChannelPuller) {
+	r.channelPuller = channelPuller
+}
+
+// ChannelList lists the channels the orderer is a member of, and its system channel if it has one.
+func (r *Registrar) ChannelList() channelparticipation.ChannelList {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	list := channelparticipation.ChannelList{}
+	for channelID := range r.chains {
+		info := channelparticipation.ChannelInfoShort{Name: channelID, URL: channelparticipation.ChannelURL(channelID)}
+		if channelID == r.systemChannelID {
+			list.SystemChannel = &info
+			continue
+		}
+		list.Channels = append(list.Channels, info)
+	}
+	for channelID := range r.joining {
+		if _, started := r.chains[channelID]; started {
+			continue
+		}
+		list.Channels = append(list.Channels, channelparticipation.ChannelInfoShort{Name: channelID, URL: channelparticipation.ChannelURL(channelID)})
+	}
+
+	sort.Slice(list.Channels, func(i, j int) bool {
+		return list.Channels[i].Name < list.Channels[j].Name
+	})
+	return list
+}
+
+// ChannelInfo describes the given channel, or returns channelparticipation.ErrChannelNotExist
+// if the orderer is not a member of it.
+func (r *Registrar) ChannelInfo(channelID string) (channelparticipation.ChannelInfo, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	info := channelparticipation.ChannelInfo{
+		Name: channelID,
+		URL:  channelparticipation.ChannelURL(channelID),
+	}
+
+	if cs, exists := r.chains[channelID]; exists {
+		info.Status = channelparticipation.StatusActive
+		if _, isInactive := cs.Chain.(*inactive.Chain); isInactive {
+			info.Status = channelparticipation.StatusInactive
+		}
+		info.Height = cs.Height()
+		return info, nil
+	}
+
+	if jc, exists := r.joining[channelID]; exists {
+		info.Status = jc.status
+		if jc.err != nil {
+			info.Error = jc.err.Error()
+		}
+		if jc.ledger != nil {
+			info.Height = jc.ledger.Height()
+		}
+		return info, nil
+	}
+
+	return channelparticipation.ChannelInfo{}, channelparticipation.ErrChannelNotExist
+}
+
+// JoinChannel joins the orderer to the channel of the given config block, which must not be
+// a system channel block. If the config block is the genesis block of the channel the channel
+// is started right away, otherwise its blocks are first pulled from the other orderers of the
+// channel, during which it is onboarding. Joining channels is only possible if the orderer
+// has no system channel.
+func (r *Registrar) JoinChannel(channelID string, configBlock *cb.Block) (channelparticipation.ChannelInfo, error) {
+	if err := r.validateJoinBlock(channelID, configBlock); err != nil {
+		return channelparticipation.ChannelInfo{}, err
+	}
+	if configBlock.Header.Number > 0 && r.channelPuller == nil {
+		return channelparticipation.ChannelInfo{}, errors.Errorf("cannot join channel with config block %d, as blocks cannot be pulled from other orderers", configBlock.Header.Number)
+	}
+
+	ledger, err := r.reserveChannel(channelID)
+	if err != nil {
+		return channelparticipation.ChannelInfo{}, err
+	}
+
+	if configBlock.Header.Number == 0 {
+		if err := ledger.Append(configBlock); err != nil {
+			r.failJoin(channelID, errors.WithMessage(err, "failed appending genesis block"))
+			return channelparticipation.ChannelInfo{}, errors.WithMessage(err, "failed appending genesis block")
+		}
+		r.startJoinedChannel(channelID, ledger)
+		logger.Infof("Joined channel %s with its genesis block", channelID)
+		return r.ChannelInfo(channelID)
+	}
+
+	go func() {
+		logger.Infof("Pulling the blocks of channel %s up to config block %d", channelID, configBlock.Header.Number)
+		if err := r.channelPuller.PullChannel(channelID, configBlock); err != nil {
+			logger.Errorf("Failed pulling the blocks of channel %s: %s", channelID, err)
+			r.failJoin(channelID, err)
+			return
+		}
+		r.startJoinedChannel(channelID, ledger)
+		logger.Infof("Joined channel %s with config block %d", channelID, configBlock.Header.Number)
+	}()
+
+	return r.ChannelInfo(channelID)
+}
+
+// validateJoinBlock checks that the config block can be joined, before anything is written to a ledger.
+func (r *Registrar) validateJoinBlock(channelID string, configBlock *cb.Block) error {
+	if r.SystemChannelID() != "" {
+		return channelparticipation.ErrSystemChannelExists
+	}
+
+	envelope, err := utils.ExtractEnvelope(configBlock, 0)
+	if err != nil {
+		return errors.WithMessage(err, "failed extracting envelope from config block")
+	}
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return errors.WithMessage(err, "failed unmarshaling payload of config block")
+	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return errors.WithMessage(err, "failed unmarshaling config envelope of config block")
+	}
+
+	bundle, err := channelconfig.NewBundle(channelID, configEnvelope.Config)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating channel config bundle")
+	}
+	if _, isSystemChannel := bundle.ConsortiumsConfig(); isSystemChannel {
+		return errors.New("cannot join a system channel, the orderer must be bootstrapped with it instead")
+	}
+	if err := checkResources(bundle); err != nil {
+		return err
+	}
+	oc, _ := bundle.OrdererConfig()
+	if _, exists := r.consenters[oc.ConsensusType()]; !exists {
+		return errors.Errorf("consensus type %s is not available on this orderer", oc.ConsensusType())
+	}
+
+	return nil
+}
+
+// reserveChannel marks the channel as onboarding, so that it is neither joined nor removed
+// concurrently, and creates its ledger.
+func (r *Registrar) reserveChannel(channelID string) (blockledger.ReadWriter, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.systemChannelID != "" {
+		return nil, channelparticipation.ErrSystemChannelExists
+	}
+	if _, exists := r.chains[channelID]; exists {
+		return nil, channelparticipation.ErrChannelAlreadyExists
+	}
+	if _, exists := r.joining[channelID]; exists {
+		return nil, channelparticipation.ErrChannelAlreadyExists
+	}
+
+	ledger, err := r.ledgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed creating ledger")
+	}
+	if ledger.Height() > 0 {
+		return nil, channelparticipation.ErrChannelAlreadyExists
+	}
+
+	r.joining[channelID] = &joiningChannel{
+		status: channelparticipation.StatusOnboarding,
+		ledger: ledger,
+	}
+	return ledger, nil
+}
+
+// startJoinedChannel starts the chain of a channel whose ledger reached the config block it was joined with.
+func (r *Registrar) startJoinedChannel(channelID string, ledger blockledger.Reader) {
+	r.newChain(configTx(ledger))
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.joining, channelID)
+}
+
+// failJoin marks the channel as failed and removes its ledger, so that the orderer does
+// not start the channel from a partial ledger when it restarts.
+func (r *Registrar) failJoin(channelID string, err error) {
+	if removeErr := r.removeLedger(channelID); removeErr != nil {
+		logger.Errorf("Failed removing the ledger of channel %s: %s", channelID, removeErr)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.joining[channelID] = &joiningChannel{
+		status: channelparticipation.StatusFailed,
+		err:    err,
+	}
+}
+
+// RemoveChannel halts the chain of the given channel, and removes its ledger along with the data
+// of its chain. Removing channels is only possible if the orderer has no system channel.
+func (r *Registrar) RemoveChannel(channelID string) error {
+	if _, ok := r.ledgerFactory.(blockledger.Remover); !ok {
+		return errors.New("the ledger does not support removing channels")
+	}
+
+	r.lock.Lock()
+	if r.systemChannelID != "" {
+		r.lock.Unlock()
+		return channelparticipation.ErrSystemChannelExists
+	}
+	if jc, exists := r.joining[channelID]; exists {
+		defer r.lock.Unlock()
+		if jc.status == channelparticipation.StatusOnboarding {
+			return channelparticipation.ErrChannelOnboarding
+		}
+		// The ledger of a channel which failed to join is already removed
+		delete(r.joining, channelID)
+		return nil
+	}
+	cs, exists := r.chains[channelID]
+	if !exists {
+		r.lock.Unlock()
+		return channelparticipation.ErrChannelNotExist
+	}
+
+	// Copy the map to allow concurrent reads from broadcast/deliver while the chain is removed
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		if key != channelID {
+			newChains[key] = value
+		}
+	}
+	r.chains = newChains
+	r.lock.Unlock()
+
+	logger.Infof("Halting the chain of channel %s to remove it", channelID)
+	cs.Halt()
+
+	if dataRemover, ok := cs.Chain.(chainDataRemover); ok {
+		if err := dataRemover.RemoveData(); err != nil {
+			return errors.WithMessage(err, "failed removing chain data")
+		}
+	}
+	if err := r.removeLedger(channelID); err != nil {
+		return err
+	}
+
+	logger.Infof("Removed channel %s", channelID)
+	return nil
+}
+
+func (r *Registrar) removeLedger(channelID string) error {
+	remover, ok := r.ledgerFactory.(blockledger.Remover)
+	if !ok {
+		return errors.New("the ledger does not support removing channels")
+	}
+	if err := remover.Remove(channelID); err != nil {
+		return errors.WithMessage(err, "failed removing ledger")
+	}
+	return nil
+}
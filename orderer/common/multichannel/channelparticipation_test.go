@@ -0,0 +1,211 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichannel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	ramledger "github.com/hyperledger/fabric/common/ledger/blockledger/ram"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChannelPuller struct {
+	ledgerFactory blockledger.Factory
+	blocks        []*cb.Block
+	release       chan struct{}
+	err           error
+}
+
+func (mcp *mockChannelPuller) PullChannel(channelID string, configBlock *cb.Block) error {
+	<-mcp.release
+	if mcp.err != nil {
+		return mcp.err
+	}
+	ledger, err := mcp.ledgerFactory.GetOrCreate(channelID)
+	if err != nil {
+		return err
+	}
+	for _, block := range mcp.blocks {
+		if err := ledger.Append(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appChannelBlocks returns the genesis block of an application channel, and a config block following it
+func appChannelBlocks(channelID string) (*cb.Block, *cb.Block) {
+	appConf := *conf
+	appConf.Consortiums = nil
+	appConf.Application = &genesisconfig.Application{}
+	genesis := encoder.New(&appConf).GenesisBlockForChannel(channelID)
+
+	rl := ramledger.New(10)
+	ledger, _ := rl.GetOrCreate(channelID)
+	ledger.Append(genesis)
+	configBlock := blockledger.CreateNextBlock(ledger, []*cb.Envelope{utils.ExtractEnvelopeOrPanic(genesis, 0)})
+	configBlock.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: 1}),
+	})
+	return genesis, configBlock
+}
+
+func waitForStatus(t *testing.T, registrar *Registrar, channelID, status string) {
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		if info, _ := registrar.ChannelInfo(channelID); info.Status == status {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("channel %s did not reach status %s", channelID, status)
+}
+
+func newRegistrarWithoutSystemChannel() (*Registrar, blockledger.Factory) {
+	lf := ramledger.New(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	registrar.Initialize(consenters)
+	return registrar, lf
+}
+
+func TestJoinChannelGenesisBlock(t *testing.T) {
+	registrar, lf := newRegistrarWithoutSystemChannel()
+	assert.Empty(t, registrar.SystemChannelID())
+
+	genesis, _ := appChannelBlocks("app-channel")
+	info, err := registrar.JoinChannel("app-channel", genesis)
+	assert.NoError(t, err)
+	assert.Equal(t, channelparticipation.ChannelInfo{
+		Name:   "app-channel",
+		URL:    "/participation/v1/channels/app-channel",
+		Status: channelparticipation.StatusActive,
+		Height: 1,
+	}, info)
+	assert.NotNil(t, registrar.GetChain("app-channel"))
+	assert.Equal(t, channelparticipation.ChannelList{
+		Channels: []channelparticipation.ChannelInfoShort{{Name: "app-channel", URL: "/participation/v1/channels/app-channel"}},
+	}, registrar.ChannelList())
+
+	_, err = registrar.JoinChannel("app-channel", genesis)
+	assert.Equal(t, channelparticipation.ErrChannelAlreadyExists, err)
+
+	chain := registrar.GetChain("app-channel")
+	assert.NoError(t, registrar.RemoveChannel("app-channel"))
+	<-chain.Chain.(*mockChain).done
+	assert.Nil(t, registrar.GetChain("app-channel"))
+	assert.Empty(t, lf.ChainIDs())
+	_, err = registrar.ChannelInfo("app-channel")
+	assert.Equal(t, channelparticipation.ErrChannelNotExist, err)
+	assert.Equal(t, channelparticipation.ErrChannelNotExist, registrar.RemoveChannel("app-channel"))
+}
+
+func TestJoinChannelConfigBlock(t *testing.T) {
+	registrar, lf := newRegistrarWithoutSystemChannel()
+	genesis, configBlock := appChannelBlocks("app-channel")
+
+	_, err := registrar.JoinChannel("app-channel", configBlock)
+	assert.EqualError(t, err, "cannot join channel with config block 1, as blocks cannot be pulled from other orderers")
+
+	puller := &mockChannelPuller{
+		ledgerFactory: lf,
+		blocks:        []*cb.Block{genesis, configBlock},
+		release:       make(chan struct{}),
+	}
+	registrar.SetChannelPuller(puller)
+
+	info, err := registrar.JoinChannel("app-channel", configBlock)
+	assert.NoError(t, err)
+	assert.Equal(t, channelparticipation.StatusOnboarding, info.Status)
+	assert.Equal(t, channelparticipation.ErrChannelOnboarding, registrar.RemoveChannel("app-channel"))
+	_, err = registrar.JoinChannel("app-channel", configBlock)
+	assert.Equal(t, channelparticipation.ErrChannelAlreadyExists, err)
+
+	close(puller.release)
+	waitForStatus(t, registrar, "app-channel", channelparticipation.StatusActive)
+	info, _ = registrar.ChannelInfo("app-channel")
+	assert.Equal(t, uint64(2), info.Height)
+	assert.Len(t, registrar.ChannelList().Channels, 1)
+}
+
+func TestJoinChannelPullFailure(t *testing.T) {
+	registrar, lf := newRegistrarWithoutSystemChannel()
+	_, configBlock := appChannelBlocks("app-channel")
+
+	puller := &mockChannelPuller{
+		release: make(chan struct{}),
+		err:     errors.New("cluster is unreachable"),
+	}
+	close(puller.release)
+	registrar.SetChannelPuller(puller)
+
+	_, err := registrar.JoinChannel("app-channel", configBlock)
+	assert.NoError(t, err)
+	waitForStatus(t, registrar, "app-channel", channelparticipation.StatusFailed)
+	info, _ := registrar.ChannelInfo("app-channel")
+	assert.Equal(t, "cluster is unreachable", info.Error)
+	assert.Empty(t, lf.ChainIDs(), "Expected the ledger of the failed channel to be removed")
+
+	assert.NoError(t, registrar.RemoveChannel("app-channel"))
+	_, err = registrar.ChannelInfo("app-channel")
+	assert.Equal(t, channelparticipation.ErrChannelNotExist, err)
+}
+
+func TestJoinChannelRejection(t *testing.T) {
+	registrar, lf := newRegistrarWithoutSystemChannel()
+
+	_, err := registrar.JoinChannel("system-channel", encoder.New(conf).GenesisBlockForChannel("system-channel"))
+	assert.EqualError(t, err, "cannot join a system channel, the orderer must be bootstrapped with it instead")
+
+	appConf := *conf
+	appConf.Consortiums = nil
+	ordererConf := *conf.Orderer
+	ordererConf.OrdererType = "kafka"
+	appConf.Orderer = &ordererConf
+	_, err = registrar.JoinChannel("app-channel", encoder.New(&appConf).GenesisBlockForChannel("app-channel"))
+	assert.EqualError(t, err, "consensus type kafka is not available on this orderer")
+	assert.Empty(t, lf.ChainIDs())
+	assert.Empty(t, registrar.ChannelList().Channels)
+}
+
+func TestChannelParticipationWithSystemChannel(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	registrar.Initialize(consenters)
+
+	genesis, _ := appChannelBlocks("app-channel")
+	_, err := registrar.JoinChannel("app-channel", genesis)
+	assert.Equal(t, channelparticipation.ErrSystemChannelExists, err)
+	assert.Equal(t, channelparticipation.ErrSystemChannelExists, registrar.RemoveChannel(genesisconfig.TestChainID))
+
+	assert.Equal(t, channelparticipation.ChannelList{
+		SystemChannel: &channelparticipation.ChannelInfoShort{
+			Name: genesisconfig.TestChainID,
+			URL:  "/participation/v1/channels/" + genesisconfig.TestChainID,
+		},
+	}, registrar.ChannelList())
+	info, err := registrar.ChannelInfo(genesisconfig.TestChainID)
+	assert.NoError(t, err)
+	assert.Equal(t, channelparticipation.StatusActive, info.Status)
+}
+
+func TestBroadcastChannelSupportWithoutSystemChannel(t *testing.T) {
+	registrar, _ := newRegistrarWithoutSystemChannel()
+	_, _, _, err := registrar.BroadcastChannelSupport(makeConfigTx("new-channel", 1))
+	assert.EqualError(t, err, "channel creation request not allowed because the orderer system channel is not defined")
+}
@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichannel
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// consensusType returns the consensus type set in the given config, or the empty
+// string if the config carries no orderer consensus type.
+func consensusType(config *cb.Config) string {
+	if config == nil || config.ChannelGroup == nil {
+		return ""
+	}
+	ordererGroup, ok := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !ok {
+		return ""
+	}
+	value, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !ok {
+		return ""
+	}
+	ct := &ab.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, ct); err != nil {
+		return ""
+	}
+	return ct.Type
+}
+
+// consensusTypeOfBlock returns the consensus type set by the given config block.
+func consensusTypeOfBlock(block *cb.Block) (string, error) {
+	configEnv, err := cluster.ConfigFromBlock(block)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed extracting config from block")
+	}
+	return consensusType(configEnv.Config), nil
+}
+
+// previousConfigBlock returns the config block which was in effect when the block
+// with the given number was cut.
+func previousConfigBlock(reader blockledger.Reader, number uint64) (*cb.Block, error) {
+	if number == 0 {
+		return nil, errors.New("the genesis block has no previous config block")
+	}
+	prevBlock := blockledger.GetBlock(reader, number-1)
+	if prevBlock == nil {
+		return nil, errors.Errorf("could not retrieve block %d", number-1)
+	}
+	index := uint64(0)
+	if prevBlock.Header.Number != 0 {
+		var err error
+		index, err = utils.GetLastConfigIndexFromBlock(prevBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+	configBlock := blockledger.GetBlock(reader, index)
+	if configBlock == nil {
+		return nil, errors.Errorf("could not retrieve config block %d", index)
+	}
+	return configBlock, nil
+}
+
+// writerConsensusType returns the consensus type of the consenter which cut the given block.
+// A config block is cut by the consenter in effect before it, even if it switches the consensus type.
+func writerConsensusType(reader blockledger.Reader, block *cb.Block) (string, error) {
+	if block.Header.Number == 0 {
+		return consensusTypeOfBlock(block)
+	}
+	configBlock, err := previousConfigBlock(reader, block.Header.Number)
+	if err != nil {
+		return "", err
+	}
+	return consensusTypeOfBlock(configBlock)
+}
+
+// consenterMetadata returns the orderer metadata the consenter of a channel is started with.
+// This is the metadata of the last block, unless that block is the config block which switched
+// the consensus type. Such a block was cut by the previous consenter, so the new consenter
+// is given the metadata of the last block it cut itself, or empty metadata if it never cut one.
+func consenterMetadata(reader blockledger.Reader, lastBlock *cb.Block) (*cb.Metadata, error) {
+	metadata, err := utils.GetMetadataFromBlock(lastBlock, cb.BlockMetadataIndex_ORDERER)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastBlock.Header.Number == 0 {
+		return metadata, nil
+	}
+
+	lastConfigIndex, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	if lastConfigIndex != lastBlock.Header.Number {
+		return metadata, nil
+	}
+
+	currentType, err := consensusTypeOfBlock(lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	writerType, err := writerConsensusType(reader, lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	if writerType == currentType {
+		return metadata, nil
+	}
+
+	// The last block switched the consensus type. If the previous config block switched it the
+	// other way round, e.g. a migration that has since been aborted, that config block was the
+	// last one cut by the current consenter.
+	prevConfigBlock, err := previousConfigBlock(reader, lastBlock.Header.Number)
+	if err != nil {
+		return nil, err
+	}
+	if prevConfigBlock.Header.Number != 0 {
+		prevWriterType, err := writerConsensusType(reader, prevConfigBlock)
+		if err != nil {
+			return nil, err
+		}
+		if prevWriterType == currentType {
+			logger.Infof("Consensus type switched back to %s at block %d, resuming from the metadata of block %d",
+				currentType, lastBlock.Header.Number, prevConfigBlock.Header.Number)
+			return utils.GetMetadataFromBlock(prevConfigBlock, cb.BlockMetadataIndex_ORDERER)
+		}
+	}
+
+	logger.Infof("Consensus type switched from %s to %s at block %d, starting with empty orderer metadata",
+		writerType, currentType, lastBlock.Header.Number)
+	return &cb.Metadata{}, nil
+}
+
+// SystemChannelInMaintenance returns whether the ordering system channel is in maintenance mode,
+// i.e. whether a consensus-type migration of the ordering service is in progress.
+func (r *Registrar) SystemChannelInMaintenance() bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if r.systemChannel == nil {
+		return false
+	}
+	return msgprocessor.InMaintenance(r.systemChannel.SharedConfig())
+}
+
+// validateConsensusTypeChange checks that the given channel may switch from its current consensus
+// type to the one of the proposed config, as part of a Kafka to Raft migration.
+func (r *Registrar) validateConsensusTypeChange(chainID string, current, proposed channelconfig.Orderer) error {
+	if current.ConsensusType() == proposed.ConsensusType() {
+		return nil
+	}
+
+	if _, ok := r.consenters[proposed.ConsensusType()]; !ok {
+		return errors.Errorf("consensus type %s is not available on this orderer, it must be restarted with a config which permits migration",
+			proposed.ConsensusType())
+	}
+
+	if chainID != r.systemChannelID {
+		if !r.SystemChannelInMaintenance() {
+			return errors.Errorf("channel %s may switch consensus type only while the system channel is in maintenance mode", chainID)
+		}
+		return nil
+	}
+
+	// The system channel commits the migration once all standard channels have switched
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for id, cs := range r.chains {
+		if id == chainID {
+			continue
+		}
+		if cs.SharedConfig().ConsensusType() != proposed.ConsensusType() {
+			return errors.Errorf("channel %s has not switched to consensus type %s yet", id, proposed.ConsensusType())
+		}
+	}
+
+	return nil
+}
+
+// handOffChain replaces the consenter of a channel whose consensus type was switched by its last
+// config block with one of the new type, which continues the chain at the same height.
+func (r *Registrar) handOffChain(chainID string) {
+	cs := r.GetChain(chainID)
+	if cs == nil {
+		logger.Panicf("Told to hand off channel %s, but it does not exist", chainID)
+	}
+
+	// Wait until the block which switched the consensus type is committed,
+	// so that the new consenter starts from a ledger which includes it.
+	cs.BlockWriter.committingBlock.Lock()
+	cs.BlockWriter.committingBlock.Unlock()
+
+	logger.Infof("[channel: %s] Halting the chain of type %T to hand it off to the %s consenter at height %d",
+		chainID, cs.Chain, cs.SharedConfig().ConsensusType(), cs.Height())
+	cs.Halt()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	newCS := newChainSupport(r, cs.ledgerResources, r.consenters, r.signer, r.blockcutterMetrics)
+	if chainID == r.systemChannelID {
+		r.templator = msgprocessor.NewDefaultTemplator(newCS)
+		newCS.Processor = msgprocessor.NewSystemChannel(newCS, r.templator, msgprocessor.CreateSystemChannelFilters(r, newCS))
+		r.systemChannel = newCS
+	}
+
+	newChains := make(map[string]*ChainSupport)
+	for key, value := range r.chains {
+		newChains[key] = value
+	}
+	newChains[chainID] = newCS
+	newCS.start()
+
+	r.chains = newChains
+	logger.Infof("[channel: %s] Handed off the chain to a chain of type %T", chainID, newCS.Chain)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multichannel
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	mockchannelconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeConsensusTypeConfigTx(chainID string, consensusType string) *cb.Envelope {
+	group := cb.NewConfigGroup()
+	group.Groups[channelconfig.OrdererGroupKey] = cb.NewConfigGroup()
+	group.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey] = &cb.ConfigValue{
+		Value: utils.MarshalOrPanic(&ab.ConsensusType{Type: consensusType}),
+	}
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, chainID, mockCrypto(), &cb.ConfigEnvelope{
+		Config: &cb.Config{ChannelGroup: group},
+	}, msgVersion, epoch)
+	if err != nil {
+		panic(err)
+	}
+	return env
+}
+
+// appendBlock appends a block with the given envelope, orderer metadata and last config index to the ledger.
+func appendBlock(rl blockledger.ReadWriter, env *cb.Envelope, ordererMetadata string, lastConfig uint64) *cb.Block {
+	block := blockledger.CreateNextBlock(rl, []*cb.Envelope{env})
+	block.Metadata.Metadata[cb.BlockMetadataIndex_ORDERER] = utils.MarshalOrPanic(&cb.Metadata{Value: []byte(ordererMetadata)})
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&cb.Metadata{
+		Value: utils.MarshalOrPanic(&cb.LastConfig{Index: lastConfig}),
+	})
+	if err := rl.Append(block); err != nil {
+		panic(err)
+	}
+	return block
+}
+
+func TestConsenterMetadata(t *testing.T) {
+	rl := NewRAMLedger(10)
+	soloType := conf.Orderer.OrdererType
+
+	// A normal block carries the metadata of the consenter which cut it
+	block := appendBlock(rl, makeNormalTx(genesisconfig.TestChainID, 1), "solo-1", 0)
+	metadata, err := consenterMetadata(rl, block)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("solo-1"), metadata.Value)
+
+	// A config block which keeps the consensus type carries the metadata of the consenter which cut it
+	block = appendBlock(rl, makeConsensusTypeConfigTx(genesisconfig.TestChainID, soloType), "solo-2", 2)
+	metadata, err = consenterMetadata(rl, block)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("solo-2"), metadata.Value)
+
+	// A config block which switches the consensus type was cut by the previous consenter,
+	// so the new one starts from empty metadata
+	block = appendBlock(rl, makeConsensusTypeConfigTx(genesisconfig.TestChainID, "kafka"), "solo-3", 3)
+	metadata, err = consenterMetadata(rl, block)
+	assert.NoError(t, err)
+	assert.Empty(t, metadata.Value)
+
+	// Switching back resumes from the last block cut by the original consenter
+	block = appendBlock(rl, makeConsensusTypeConfigTx(genesisconfig.TestChainID, soloType), "kafka-4", 4)
+	metadata, err = consenterMetadata(rl, block)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("solo-3"), metadata.Value)
+
+	// Missing blocks are reported
+	_, err = consenterMetadata(NewRAMLedger(10), block)
+	assert.Error(t, err)
+}
+
+func TestValidateConsensusTypeChange(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	consenters := map[string]consensus.Consenter{
+		conf.Orderer.OrdererType: &mockConsenter{},
+		"etcdraft":               &mockConsenter{},
+	}
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	registrar.Initialize(consenters)
+
+	ordererConfig := func(consensusType string, state ab.ConsensusType_MigrationState) channelconfig.Orderer {
+		return &mockchannelconfig.Orderer{
+			ConsensusTypeVal:               consensusType,
+			ConsensusTypeMigrationStateVal: state,
+			CapabilitiesVal:                &mockchannelconfig.OrdererCapabilities{Kafka2RaftMigVal: true},
+		}
+	}
+	solo := ordererConfig(conf.Orderer.OrdererType, ab.ConsensusType_MIG_STATE_NONE)
+
+	t.Run("SameType", func(t *testing.T) {
+		assert.NoError(t, registrar.validateConsensusTypeChange("mychannel", solo, solo))
+	})
+
+	t.Run("UnknownConsenter", func(t *testing.T) {
+		err := registrar.validateConsensusTypeChange("mychannel", solo, ordererConfig("kafka", ab.ConsensusType_MIG_STATE_NONE))
+		assert.EqualError(t, err, "consensus type kafka is not available on this orderer, it must be restarted with a config which permits migration")
+	})
+
+	t.Run("StandardChannelOutsideMaintenance", func(t *testing.T) {
+		err := registrar.validateConsensusTypeChange("mychannel", solo, ordererConfig("etcdraft", ab.ConsensusType_MIG_STATE_CONTEXT))
+		assert.EqualError(t, err, "channel mychannel may switch consensus type only while the system channel is in maintenance mode")
+	})
+
+	t.Run("SystemChannel", func(t *testing.T) {
+		err := registrar.validateConsensusTypeChange(genesisconfig.TestChainID, solo, ordererConfig("etcdraft", ab.ConsensusType_MIG_STATE_COMMIT))
+		assert.NoError(t, err)
+	})
+}
+
+func TestHandOffChain(t *testing.T) {
+	lf, rl := NewRAMLedgerAndFactory(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	registrar.Initialize(consenters)
+
+	chain := registrar.GetChain(genesisconfig.TestChainID)
+	assert.NotNil(t, chain)
+	height := rl.Height()
+
+	registrar.handOffChain(genesisconfig.TestChainID)
+
+	// The old chain is halted
+	<-chain.Chain.(*mockChain).done
+
+	// The new chain continues the same ledger, and remains the system channel
+	newChain := registrar.GetChain(genesisconfig.TestChainID)
+	assert.NotEqual(t, chain, newChain)
+	assert.Equal(t, height, newChain.Height())
+	assert.Equal(t, newChain, registrar.systemChannel)
+	assert.IsType(t, &msgprocessor.SystemChannel{}, newChain.Processor)
+	assert.Equal(t, 1, registrar.ChannelsCount())
+
+	// The new chain is not halted: Close the channel to prove that.
+	close(newChain.Chain.(*mockChain).queue)
+}
+
+func TestBlockWriterHandedOff(t *testing.T) {
+	rl := NewRAMLedger(10)
+	bw := newBlockWriter(genesisBlock, nil, &mockBlockWriterSupport{
+		Validator:   &mockconfigtx.Validator{},
+		LocalSigner: mockCrypto(),
+		ReadWriter:  rl,
+	})
+	bw.handedOff = true
+
+	bw.WriteBlock(bw.CreateNextBlock([]*cb.Envelope{makeNormalTx(genesisconfig.TestChainID, 1)}), nil)
+	bw.WriteConfigBlock(bw.CreateNextBlock([]*cb.Envelope{makeConfigTx(genesisconfig.TestChainID, 2)}), nil)
+
+	bw.committingBlock.Lock()
+	bw.committingBlock.Unlock()
+	assert.Equal(t, uint64(1), rl.Height(), "no block should have been written once handed off")
+}
@@ -98,10 +98,13 @@ type Registrar struct {
 	ledgerFactory      blockledger.Factory
 	signer             crypto.LocalSigner
 	blockcutterMetrics *blockcutter.Metrics
+	adaptiveCutting    *blockcutter.AdaptiveConfig
 	systemChannelID    string
 	systemChannel      *ChainSupport
 	templator          msgprocessor.ChannelConfigTemplator
 	callbacks          []channelconfig.BundleActor
+	channelPuller      ChannelPuller
+	joining            map[string]*joiningChannel
 }
 
 // ConfigBlock retrieves the last configuration block from the given ledger.
@@ -129,6 +132,7 @@ func NewRegistrar(ledgerFactory blockledger.Factory,
 	signer crypto.LocalSigner, metricsProvider metrics.Provider, callbacks ...channelconfig.BundleActor) *Registrar {
 	r := &Registrar{
 		chains:             make(map[string]*ChainSupport),
+		joining:            make(map[string]*joiningChannel),
 		ledgerFactory:      ledgerFactory,
 		signer:             signer,
 		blockcutterMetrics: blockcutter.NewMetrics(metricsProvider),
@@ -138,6 +142,13 @@ func NewRegistrar(ledgerFactory blockledger.Factory,
 	return r
 }
 
+// EnableAdaptiveBlockCutting makes the chains started from then on pick the batch timeout and the
+// preferred maximum size of their blocks according to the rate at which transactions are ordered,
+// within the bounds of the given config. It is meant to be called before Initialize.
+func (r *Registrar) EnableAdaptiveBlockCutting(config blockcutter.AdaptiveConfig) {
+	r.adaptiveCutting = &config
+}
+
 func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	r.consenters = consenters
 	existingChains := r.ledgerFactory.ChainIDs()
@@ -198,7 +209,7 @@ func (r *Registrar) Initialize(consenters map[string]consensus.Consenter) {
 	}
 
 	if r.systemChannelID == "" {
-		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+		logger.Infof("No system channel found, channels are joined and removed through the channel participation API")
 	}
 }
 
@@ -218,6 +229,9 @@ func (r *Registrar) BroadcastChannelSupport(msg *cb.Envelope) (*cb.ChannelHeader
 
 	cs := r.GetChain(chdr.ChannelId)
 	if cs == nil {
+		if r.systemChannel == nil {
+			return nil, false, nil, errors.New("channel creation request not allowed because the orderer system channel is not defined")
+		}
 		cs = r.systemChannel
 	}
 
@@ -331,6 +345,28 @@ func (r *Registrar) ChannelsCount() int {
 	return len(r.chains)
 }
 
+// HaltChains halts all the chains in parallel, and returns once they are all halted.
+// It is called when the orderer shuts down, so that the consenters may hand off
+// their duties gracefully, e.g. Raft leaders may transfer their leadership.
+func (r *Registrar) HaltChains() {
+	r.lock.RLock()
+	chains := make([]*ChainSupport, 0, len(r.chains))
+	for _, cs := range r.chains {
+		chains = append(chains, cs)
+	}
+	r.lock.RUnlock()
+
+	var wg sync.WaitGroup
+	wg.Add(len(chains))
+	for _, cs := range chains {
+		go func(cs *ChainSupport) {
+			defer wg.Done()
+			cs.Halt()
+		}(cs)
+	}
+	wg.Wait()
+}
+
 // NewChannelConfig produces a new template channel configuration based on the system channel's current config.
 func (r *Registrar) NewChannelConfig(envConfigUpdate *cb.Envelope) (channelconfig.Resources, error) {
 	return r.templator.NewChannelConfig(envConfigUpdate)
@@ -8,6 +8,7 @@ package multichannel
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/crypto"
@@ -101,16 +102,16 @@ func TestGetConfigTxFailure(t *testing.T) {
 	assert.Panics(t, func() { configTx(rl) }, "Should have panicked because of bad last config metadata")
 }
 
-// This test checks to make sure the orderer refuses to come up if it cannot find a system channel
+// This test checks to make sure the orderer comes up without a system channel
 func TestNoSystemChain(t *testing.T) {
 	lf := ramledger.New(10)
 
 	consenters := make(map[string]consensus.Consenter)
 	consenters[conf.Orderer.OrdererType] = &mockConsenter{}
 
-	assert.Panics(t, func() {
-		NewRegistrar(lf, mockCrypto(), &disabled.Provider{}).Initialize(consenters)
-	}, "Should have panicked when starting without a system chain")
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	assert.NotPanics(t, func() { registrar.Initialize(consenters) }, "Should not have panicked when starting without a system chain")
+	assert.Empty(t, registrar.SystemChannelID())
 }
 
 // This test checks to make sure that the orderer refuses to come up if there are multiple system channels
@@ -376,3 +377,58 @@ func TestBroadcastChannelSupportRejection(t *testing.T) {
 	_, _, _, err := registrar.BroadcastChannelSupport(configTx)
 	assert.Error(t, err, "Messages of type HeaderType_CONFIG should return an error.")
 }
+
+func TestHaltChains(t *testing.T) {
+	lf, _ := NewRAMLedgerAndFactory(10)
+	consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+	registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+	registrar.Initialize(consenters)
+
+	chain := registrar.GetChain(genesisconfig.TestChainID)
+	assert.NotNil(t, chain)
+
+	registrar.HaltChains()
+
+	// The chain is halted
+	<-chain.Chain.(*mockChain).done
+}
+
+func TestAdaptiveBlockCutting(t *testing.T) {
+	adaptiveConfig := blockcutter.AdaptiveConfig{
+		MinBatchTimeout:      time.Millisecond,
+		MinPreferredMaxBytes: 1024,
+		HighLoadRate:         1000000,
+		RateWindow:           time.Second,
+	}
+
+	t.Run("solo", func(t *testing.T) {
+		lf, _ := NewRAMLedgerAndFactory(10)
+		consenters := map[string]consensus.Consenter{conf.Orderer.OrdererType: &mockConsenter{}}
+		registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+		registrar.EnableAdaptiveBlockCutting(adaptiveConfig)
+		registrar.Initialize(consenters)
+
+		chain := registrar.GetChain(genesisconfig.TestChainID)
+		chain.BlockCutter().Ordered(makeNormalTx(genesisconfig.TestChainID, 1))
+		assert.InDelta(t, time.Millisecond, blockcutter.BatchTimeout(chain.BlockCutter(), chain.SharedConfig()), float64(time.Millisecond))
+	})
+
+	t.Run("kafka", func(t *testing.T) {
+		kafkaConf := *conf
+		ordererConf := *conf.Orderer
+		ordererConf.OrdererType = "kafka"
+		kafkaConf.Orderer = &ordererConf
+
+		lf := ramledger.New(10)
+		rl, _ := lf.GetOrCreate(genesisconfig.TestChainID)
+		rl.Append(encoder.New(&kafkaConf).GenesisBlockForChannel(genesisconfig.TestChainID))
+		consenters := map[string]consensus.Consenter{"kafka": &mockConsenter{}}
+		registrar := NewRegistrar(lf, mockCrypto(), &disabled.Provider{})
+		registrar.EnableAdaptiveBlockCutting(adaptiveConfig)
+		registrar.Initialize(consenters)
+
+		chain := registrar.GetChain(genesisconfig.TestChainID)
+		chain.BlockCutter().Ordered(makeNormalTx(genesisconfig.TestChainID, 1))
+		assert.Equal(t, conf.Orderer.BatchTimeout, blockcutter.BatchTimeout(chain.BlockCutter(), chain.SharedConfig()))
+	})
+}
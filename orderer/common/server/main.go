@@ -38,7 +38,9 @@ import (
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
+	"github.com/hyperledger/fabric/orderer/common/channelparticipation"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
@@ -93,10 +95,11 @@ func Main() {
 // Start provides a layer of abstraction for benchmark test
 func Start(cmd string, conf *localconfig.TopLevel) {
 	bootstrapBlock := extractBootstrapBlock(conf)
-	clusterType := isClusterType(bootstrapBlock)
 	signer := localmsp.NewSigner()
 
 	lf, _ := createLedgerFactory(conf)
+	clusterBootBlock := selectClusterBootBlock(bootstrapBlock, extractSysChanLastConfig(lf, bootstrapBlock))
+	clusterType := isClusterType(clusterBootBlock)
 
 	clusterDialer := &cluster.PredicateDialer{}
 	clusterClientConfig := initializeClusterClientConfig(conf)
@@ -154,9 +157,24 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
 	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS)
 
+	if clusterType {
+		opsSystem.RegisterHandler("/raft/leadership", etcdraft.NewLeadershipHandler(manager))
+	}
+
+	if conf.ChannelParticipation.Enabled {
+		channelParticipation := channelparticipation.NewHTTPHandler(manager, int64(conf.ChannelParticipation.MaxRequestBodySize))
+		opsSystem.RegisterHandler(channelparticipation.URLBaseV1Channels, channelParticipation)
+		opsSystem.RegisterHandler(channelparticipation.URLBaseV1Channels+"/", channelParticipation)
+	} else if bootstrapBlock == nil {
+		logger.Warning("The orderer has no system channel and the channel participation API is disabled, it cannot be joined to channels")
+	}
+
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
 	go handleSignals(addPlatformSignals(map[os.Signal]func(){
 		syscall.SIGTERM: func() {
+			// Halt the chains while the servers still run, so that Raft
+			// leaders can transfer their leadership before going away
+			manager.HaltChains()
 			grpcServer.Stop()
 			if clusterGRPCServer != grpcServer {
 				clusterGRPCServer.Stop()
@@ -193,15 +211,17 @@ func createReplicator(
 		logger:        logger,
 	}
 
-	systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
-	if err != nil {
-		logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
-	}
-
-	// System channel is not verified because we trust the bootstrap block
-	// and use backward hash chain verification.
 	verifiersByChannel := vl.loadVerifiers()
-	verifiersByChannel[systemChannelName] = &cluster.NoopBlockVerifier{}
+	if bootstrapBlock != nil {
+		systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
+		if err != nil {
+			logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
+		}
+
+		// System channel is not verified because we trust the bootstrap block
+		// and use backward hash chain verification.
+		verifiersByChannel[systemChannelName] = &cluster.NoopBlockVerifier{}
+	}
 
 	vr := &cluster.VerificationRegistry{
 		Logger:             logger,
@@ -219,6 +239,7 @@ func createReplicator(
 		secOpts:           secOpts,
 		conf:              conf,
 		lf:                ledgerFactory,
+		blockLedgers:      lf,
 		signer:            signer,
 	}
 }
@@ -469,6 +490,8 @@ func extractBootstrapBlock(conf *localconfig.TopLevel) *cb.Block {
 		bootstrapBlock = encoder.New(genesisconfig.Load(conf.General.GenesisProfile)).GenesisBlockForChannel(conf.General.SystemChannel)
 	case "file":
 		bootstrapBlock = file.New(conf.General.GenesisFile).GenesisBlock()
+	case "none":
+		logger.Info("Starting without a system channel")
 	default:
 		logger.Panic("Unknown genesis method:", conf.General.GenesisMethod)
 	}
@@ -491,24 +514,83 @@ func initializeBootstrapChannel(genesisBlock *cb.Block, lf blockledger.Factory)
 	}
 }
 
-func isClusterType(genesisBlock *cb.Block) bool {
-	if genesisBlock.Data == nil || len(genesisBlock.Data.Data) == 0 {
-		logger.Fatalf("Empty genesis block")
+// extractSysChanLastConfig returns the last config block in the ledger of the system channel,
+// or nil if the orderer has no system channel or is about to be bootstrapped.
+func extractSysChanLastConfig(lf blockledger.Factory, bootstrapBlock *cb.Block) *cb.Block {
+	if bootstrapBlock == nil {
+		return nil
+	}
+	systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
+	if err != nil {
+		logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
+	}
+	exists := false
+	for _, chainID := range lf.ChainIDs() {
+		if chainID == systemChannelName {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		logger.Infof("The ledger of the system channel %s does not exist yet", systemChannelName)
+		return nil
+	}
+	systemChannelLedger, err := lf.GetOrCreate(systemChannelName)
+	if err != nil {
+		logger.Panicf("Failed getting system channel ledger: %v", err)
+	}
+	if systemChannelLedger.Height() == 0 {
+		return nil
+	}
+	lastConfigBlock := multichannel.ConfigBlock(systemChannelLedger)
+	logger.Infof("System channel: name=%s, height=%d, last config block number=%d",
+		systemChannelName, systemChannelLedger.Height(), lastConfigBlock.Header.Number)
+	return lastConfigBlock
+}
+
+// selectClusterBootBlock returns the block the consensus type and the capabilities of the
+// system channel are read from: its last config block in the ledger, falling back to the
+// bootstrap block only if the ledger does not exist yet.
+func selectClusterBootBlock(bootstrapBlock, sysChanLastConfig *cb.Block) *cb.Block {
+	if sysChanLastConfig == nil {
+		logger.Debug("Selected bootstrap block, because system channel last config block is nil")
+		return bootstrapBlock
+	}
+	if sysChanLastConfig.Header.Number > bootstrapBlock.Header.Number {
+		logger.Infof("Cluster boot block is system channel last config block; Blocks Header.Number system-channel=%d, bootstrap=%d",
+			sysChanLastConfig.Header.Number, bootstrapBlock.Header.Number)
+		return sysChanLastConfig
+	}
+	logger.Infof("Cluster boot block is bootstrap (genesis) block; Blocks Header.Number system-channel=%d, bootstrap=%d",
+		sysChanLastConfig.Header.Number, bootstrapBlock.Header.Number)
+	return bootstrapBlock
+}
+
+func isClusterType(configBlock *cb.Block) bool {
+	// An orderer without a system channel may be joined to channels
+	// of any consensus type, hence it needs to be ready to run Raft chains.
+	if configBlock == nil {
+		return true
+	}
+	if configBlock.Data == nil || len(configBlock.Data.Data) == 0 {
+		logger.Fatalf("Empty config block")
 	}
 	env := &cb.Envelope{}
-	if err := proto.Unmarshal(genesisBlock.Data.Data[0], env); err != nil {
-		logger.Fatalf("Failed to unmarshal the genesis block's envelope: %v", err)
+	if err := proto.Unmarshal(configBlock.Data.Data[0], env); err != nil {
+		logger.Fatalf("Failed to unmarshal the config block's envelope: %v", err)
 	}
 	bundle, err := channelconfig.NewBundleFromEnvelope(env)
 	if err != nil {
-		logger.Fatalf("Failed creating bundle from the genesis block: %v", err)
+		logger.Fatalf("Failed creating bundle from the config block: %v", err)
 	}
 	ordConf, exists := bundle.OrdererConfig()
 	if !exists {
-		logger.Fatalf("Orderer config doesn't exist in bundle derived from genesis block")
+		logger.Fatalf("Orderer config doesn't exist in bundle derived from config block")
 	}
 	_, exists = clusterTypes[ordConf.ConsensusType()]
-	return exists
+	// An orderer which permits a Kafka to Raft migration needs to be ready to
+	// run Raft chains, as its channels may be migrated while it is running.
+	return exists || ordConf.Capabilities().Kafka2RaftMigration()
 }
 
 func initializeGrpcServer(conf *localconfig.TopLevel, serverConfig comm.ServerConfig) *comm.GRPCServer {
@@ -548,7 +630,9 @@ func initializeMultichannelRegistrar(
 ) *multichannel.Registrar {
 	genesisBlock := extractBootstrapBlock(conf)
 	// Are we bootstrapping?
-	if len(lf.ChainIDs()) == 0 {
+	if genesisBlock == nil {
+		logger.Info("Not bootstrapping because the orderer has no system channel")
+	} else if len(lf.ChainIDs()) == 0 {
 		initializeBootstrapChannel(genesisBlock, lf)
 	} else {
 		logger.Info("Not bootstrapping because of existing chains")
@@ -557,6 +641,15 @@ func initializeMultichannelRegistrar(
 	consenters := make(map[string]consensus.Consenter)
 
 	registrar := multichannel.NewRegistrar(lf, signer, metricsProvider, callbacks...)
+	if conf.BlockCutter.Adaptive.Enabled {
+		logger.Infof("Adaptive block cutting is enabled")
+		registrar.EnableAdaptiveBlockCutting(blockcutter.AdaptiveConfig{
+			MinBatchTimeout:      conf.BlockCutter.Adaptive.MinBatchTimeout,
+			MinPreferredMaxBytes: conf.BlockCutter.Adaptive.MinPreferredMaxBytes,
+			HighLoadRate:         conf.BlockCutter.Adaptive.HighLoadRate,
+			RateWindow:           conf.BlockCutter.Adaptive.RateWindow,
+		})
+	}
 
 	consenters["solo"] = solo.New()
 	var kafkaMetrics *kafka.Metrics
@@ -564,10 +657,16 @@ func initializeMultichannelRegistrar(
 	// Note, we pass a 'nil' channel here, we could pass a channel that
 	// closes if we wished to cleanup this routine on exit.
 	go kafkaMetrics.PollGoMetricsUntilStop(time.Minute, nil)
-	if isClusterType(bootstrapBlock) {
+	// The system channel may have been migrated to Raft, or may permit a migration, through a
+	// config update since it was bootstrapped, hence its last config block decides
+	if isClusterType(selectClusterBootBlock(bootstrapBlock, extractSysChanLastConfig(lf, bootstrapBlock))) {
 		initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, ri, srvConf, srv, registrar)
 	}
 	registrar.Initialize(consenters)
+	if bootstrapBlock != nil && registrar.SystemChannelID() == "" {
+		logger.Panicf("No system chain found.  If bootstrapping, does your system channel contain a consortiums group definition?")
+	}
+	registrar.SetChannelPuller(ri)
 	return registrar
 }
 
@@ -587,28 +686,11 @@ func initializeEtcdraftConsenter(
 		replicationRefreshInterval = defaultReplicationBackgroundRefreshInterval
 	}
 
-	systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
-	if err != nil {
-		ri.logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
-	}
-	systemLedger, err := lf.GetOrCreate(systemChannelName)
-	if err != nil {
-		ri.logger.Panicf("Failed obtaining system channel (%s) ledger: %v", systemChannelName, err)
-	}
-	getConfigBlock := func() *cb.Block {
-		return multichannel.ConfigBlock(systemLedger)
-	}
-
-	exponentialSleep := exponentialDurationSeries(replicationBackgroundInitialRefreshInterval, replicationRefreshInterval)
-	ticker := newTicker(exponentialSleep)
-
 	icr := &inactiveChainReplicator{
-		logger:                            logger,
-		scheduleChan:                      ticker.C,
-		quitChan:                          make(chan struct{}),
-		replicator:                        ri,
-		chains2CreationCallbacks:          make(map[string]chainCreation),
-		retrieveLastSysChannelConfigBlock: getConfigBlock,
+		logger:                   logger,
+		quitChan:                 make(chan struct{}),
+		replicator:               ri,
+		chains2CreationCallbacks: make(map[string]chainCreation),
 	}
 
 	// Use the inactiveChainReplicator as a channel lister, since it has knowledge
@@ -617,7 +699,26 @@ func initializeEtcdraftConsenter(
 	// the channels in the system.
 	ri.channelLister = icr
 
-	go icr.run()
+	// Inactive chains are replicated with the help of the system channel, without one
+	// they are only tracked, and are to be joined again once the orderer is added to them.
+	if bootstrapBlock != nil {
+		systemChannelName, err := utils.GetChainIDFromBlock(bootstrapBlock)
+		if err != nil {
+			ri.logger.Panicf("Failed extracting system channel name from bootstrap block: %v", err)
+		}
+		systemLedger, err := lf.GetOrCreate(systemChannelName)
+		if err != nil {
+			ri.logger.Panicf("Failed obtaining system channel (%s) ledger: %v", systemChannelName, err)
+		}
+		icr.retrieveLastSysChannelConfigBlock = func() *cb.Block {
+			return multichannel.ConfigBlock(systemLedger)
+		}
+
+		exponentialSleep := exponentialDurationSeries(replicationBackgroundInitialRefreshInterval, replicationRefreshInterval)
+		icr.scheduleChan = newTicker(exponentialSleep).C
+
+		go icr.run()
+	}
 	raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr)
 	consenters["etcdraft"] = raftConsenter
 }
@@ -15,7 +15,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	deliver_mocks "github.com/hyperledger/fabric/common/deliver/mock"
@@ -38,6 +40,7 @@ import (
 	server_mocks "github.com/hyperledger/fabric/orderer/common/server/mocks"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -609,6 +612,105 @@ func TestInitializeEtcdraftConsenter(t *testing.T) {
 	assert.NotNil(t, consenters["etcdraft"])
 }
 
+func TestInitializeWithoutSystemChannel(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+
+	conf := genesisConfig(t)
+	conf.General.GenesisMethod = "none"
+	assert.Nil(t, extractBootstrapBlock(conf))
+	assert.True(t, isClusterType(nil), "Expected an orderer without a system channel to be ready to run Raft chains")
+
+	consenters := make(map[string]consensus.Consenter)
+	rlf := ramledger.New(10)
+
+	ca, _ := tlsgen.NewCA()
+	crt, _ := ca.NewServerCertKeyPair("127.0.0.1")
+
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	assert.NoError(t, err)
+
+	ri := &replicationInitiator{}
+	initializeEtcdraftConsenter(consenters,
+		&localconfig.TopLevel{},
+		rlf,
+		&cluster.PredicateDialer{},
+		nil, ri,
+		comm.ServerConfig{
+			SecOpts: &comm.SecureOptions{
+				Certificate: crt.Cert,
+				Key:         crt.Key,
+				UseTLS:      true,
+			},
+		}, srv, &multichannel.Registrar{})
+	assert.NotNil(t, consenters["etcdraft"])
+	assert.NotNil(t, ri.channelLister)
+	assert.Empty(t, rlf.ChainIDs(), "Expected no system channel ledger to be created")
+}
+
+func TestIsClusterTypeFromSystemChannelLastConfig(t *testing.T) {
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+
+	genesisBlock := encoder.New(genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile)).GenesisBlockForChannel("system")
+	assert.False(t, isClusterType(genesisBlock))
+
+	// bootstrapping: the ledger of the system channel does not exist yet
+	rlf := ramledger.New(10)
+	assert.Nil(t, extractSysChanLastConfig(rlf, genesisBlock))
+	assert.Equal(t, genesisBlock, selectClusterBootBlock(genesisBlock, nil))
+	assert.Nil(t, extractSysChanLastConfig(rlf, nil))
+
+	// a config update enables the Kafka to Raft migration on the system channel
+	initializeBootstrapChannel(genesisBlock, rlf)
+	systemLedger, err := rlf.GetOrCreate("system")
+	assert.NoError(t, err)
+	configBlock := configBlockWithOrdererCapabilities(t, genesisBlock, capabilities.OrdererV1_1, capabilities.OrdererV2_0)
+	assert.NoError(t, systemLedger.Append(configBlock))
+	block := common.NewBlock(2, configBlock.Header.Hash())
+	block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+		Value: utils.MarshalOrPanic(&common.LastConfig{Index: 1}),
+	})
+	assert.NoError(t, systemLedger.Append(block))
+
+	lastConfig := extractSysChanLastConfig(rlf, genesisBlock)
+	assert.Equal(t, uint64(1), lastConfig.Header.Number)
+	clusterBootBlock := selectClusterBootBlock(genesisBlock, lastConfig)
+	assert.Equal(t, configBlock, clusterBootBlock)
+	assert.True(t, isClusterType(clusterBootBlock), "Expected the orderer to be ready to run Raft chains once the migration is permitted")
+	assert.False(t, isClusterType(genesisBlock))
+}
+
+// configBlockWithOrdererCapabilities returns the config block following the genesis block
+// of a channel, with the given orderer capabilities
+func configBlockWithOrdererCapabilities(t *testing.T, genesisBlock *common.Block, caps ...string) *common.Block {
+	env, err := utils.ExtractEnvelope(genesisBlock, 0)
+	assert.NoError(t, err)
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	assert.NoError(t, err)
+	configEnv := &common.ConfigEnvelope{}
+	assert.NoError(t, proto.Unmarshal(payload.Data, configEnv))
+
+	capabilitiesValue := &common.Capabilities{Capabilities: map[string]*common.Capability{}}
+	for _, c := range caps {
+		capabilitiesValue.Capabilities[c] = &common.Capability{}
+	}
+	configEnv.Config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.CapabilitiesKey] = &common.ConfigValue{
+		Value: utils.MarshalOrPanic(capabilitiesValue),
+	}
+	configEnv.Config.Sequence++
+	payload.Data = utils.MarshalOrPanic(configEnv)
+	env.Payload = utils.MarshalOrPanic(payload)
+
+	block := common.NewBlock(genesisBlock.Header.Number+1, genesisBlock.Header.Hash())
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	block.Header.DataHash = block.Data.Hash()
+	block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = utils.MarshalOrPanic(&common.Metadata{
+		Value: utils.MarshalOrPanic(&common.LastConfig{Index: block.Header.Number}),
+	})
+	return block
+}
+
 func genesisConfig(t *testing.T) *localconfig.TopLevel {
 	t.Helper()
 	localMSPDir, _ := configtest.GetDevMspDir()
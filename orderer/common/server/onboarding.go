@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package server
 
 import (
+	"bytes"
+	"fmt"
 	"sync"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -33,6 +36,7 @@ type replicationInitiator struct {
 	secOpts           *comm.SecureOptions
 	conf              *localconfig.TopLevel
 	lf                cluster.LedgerFactory
+	blockLedgers      blockledger.Factory
 	signer            crypto.LocalSigner
 }
 
@@ -116,6 +120,54 @@ func (ri *replicationInitiator) ReplicateChains(lastConfigBlock *common.Block, c
 	return replicator.ReplicateChains()
 }
 
+// PullChannel pulls the blocks of the given channel from the orderers of the given config block,
+// up to and including it. The blocks are not verified with the channel config, instead we
+// trust the config block the orderer is joined with and use backward hash chain verification.
+func (ri *replicationInitiator) PullChannel(channel string, configBlock *common.Block) error {
+	verifierRetriever := &cluster.VerificationRegistry{
+		Logger:             ri.logger,
+		VerifiersByChannel: map[string]cluster.BlockVerifier{channel: &cluster.NoopBlockVerifier{}},
+	}
+	pullerConfig := cluster.PullerConfigFromTopLevelConfig(channel, ri.conf, ri.secOpts.Key, ri.secOpts.Certificate, ri.signer)
+	puller, err := cluster.BlockPullerFromConfigBlock(pullerConfig, configBlock, verifierRetriever)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating block puller from config block")
+	}
+	puller.MaxPullBlockRetries = uint64(ri.conf.General.Cluster.ReplicationMaxRetries)
+	puller.RetryTimeout = ri.conf.General.Cluster.ReplicationRetryTimeout
+	defer puller.Close()
+
+	ledger, err := ri.blockLedgers.GetOrCreate(channel)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating ledger")
+	}
+
+	var prevHash []byte
+	if ledger.Height() > 0 {
+		prevHash = blockledger.GetBlock(ledger, ledger.Height()-1).Header.Hash()
+	}
+	for seq := ledger.Height(); seq <= configBlock.Header.Number; seq++ {
+		block := puller.PullBlock(seq)
+		if block == nil {
+			return errors.Errorf("failed pulling block %d of channel %s", seq, channel)
+		}
+		if seq > 0 && !bytes.Equal(block.Header.PreviousHash, prevHash) {
+			return errors.Errorf("block header mismatch on sequence %d, expected %x, got %x", seq, prevHash, block.Header.PreviousHash)
+		}
+		prevHash = block.Header.Hash()
+		if seq == configBlock.Header.Number && !bytes.Equal(prevHash, configBlock.Header.Hash()) {
+			return errors.Errorf("block %d pulled from the orderers of channel %s differs from the config block joined with", seq, channel)
+		}
+		if err := ledger.Append(block); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed appending block %d", seq))
+		}
+		ri.logger.Debugf("Committed block %d for channel %s", seq, channel)
+	}
+
+	ri.logger.Infof("Pulled %d blocks of channel %s", configBlock.Header.Number+1, channel)
+	return nil
+}
+
 type ledgerFactory struct {
 	blockledger.Factory
 	onBlockCommit cluster.BlockCommitFunc
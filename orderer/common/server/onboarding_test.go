@@ -1035,3 +1035,98 @@ func TestVerifierLoader(t *testing.T) {
 		})
 	}
 }
+
+func TestPullChannel(t *testing.T) {
+	t.Parallel()
+
+	caCert := loadPEM("ca.crt", t)
+	key := loadPEM("server.key", t)
+	cert := loadPEM("server.crt", t)
+
+	deliverServer := newServerNode(t, key, cert)
+	defer deliverServer.srv.Stop()
+
+	applicationChannelBlockBytes, err := ioutil.ReadFile(filepath.Join("testdata", "genesis.block"))
+	assert.NoError(t, err)
+
+	// The channel consists of a genesis block, a normal block and a config block
+	genesisBlock := &common.Block{}
+	assert.NoError(t, proto.Unmarshal(applicationChannelBlockBytes, genesisBlock))
+	genesisBlock.Header.Number = 0
+	genesisBlock.Header.PreviousHash = nil
+	injectOrdererEndpoint(t, genesisBlock, deliverServer.srv.Address())
+
+	normalBlock := &common.Block{
+		Header: &common.BlockHeader{
+			Number:       1,
+			PreviousHash: genesisBlock.Header.Hash(),
+		},
+		Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(&common.Envelope{
+			Payload: utils.MarshalOrPanic(&common.Payload{
+				Header: &common.Header{
+					ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{Type: int32(common.HeaderType_ENDORSER_TRANSACTION)}),
+				},
+			}),
+		})}},
+		Metadata: &common.BlockMetadata{Metadata: [][]byte{{}, {}, {}, {}}},
+	}
+	normalBlock.Header.DataHash = normalBlock.Data.Hash()
+
+	configBlock := proto.Clone(genesisBlock).(*common.Block)
+	configBlock.Header.Number = 2
+	configBlock.Header.PreviousHash = normalBlock.Header.Hash()
+
+	ri := &replicationInitiator{
+		logger: flogging.MustGetLogger("testOnboarding"),
+		conf: &localconfig.TopLevel{
+			General: localconfig.General{
+				Cluster: localconfig.Cluster{
+					ReplicationPullTimeout:  time.Hour,
+					DialTimeout:             time.Hour,
+					RPCTimeout:              time.Hour,
+					ReplicationRetryTimeout: time.Millisecond,
+					ReplicationBufferSize:   1024 * 1024,
+					ReplicationMaxRetries:   5,
+				},
+			},
+		},
+		secOpts: &comm.SecureOptions{
+			Certificate:   cert,
+			Key:           key,
+			UseTLS:        true,
+			ServerRootCAs: [][]byte{caCert},
+		},
+		blockLedgers: ramledger.New(10),
+	}
+
+	deliver := func(blocks ...*common.Block) {
+		// The first block answers the probe for the height of the channel
+		for _, block := range append([]*common.Block{configBlock}, blocks...) {
+			deliverServer.blockResponses <- &orderer.DeliverResponse{
+				Type: &orderer.DeliverResponse_Block{Block: block},
+			}
+		}
+	}
+
+	t.Run("mismatching config block", func(t *testing.T) {
+		deliver(genesisBlock, normalBlock, configBlock)
+		defer func() { deliverServer.blockResponses <- nil }()
+
+		joinBlock := proto.Clone(configBlock).(*common.Block)
+		joinBlock.Header.PreviousHash = []byte{1, 2, 3}
+		err := ri.PullChannel("mismatch", joinBlock)
+		assert.EqualError(t, err, "block 2 pulled from the orderers of channel mismatch differs from the config block joined with")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		deliver(genesisBlock, normalBlock, configBlock)
+		defer func() { deliverServer.blockResponses <- nil }()
+
+		err := ri.PullChannel("testchainid", configBlock)
+		assert.NoError(t, err)
+
+		ledger, err := ri.blockLedgers.GetOrCreate("testchainid")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(3), ledger.Height())
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/pem"
 	"fmt"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/protos/common"
@@ -83,6 +85,19 @@ type Options struct {
 	MaxInflightMsgs int
 
 	RaftMetadata *etcdraft.RaftMetadata
+
+	// MigrationInit is set when the chain starts right at the block which migrated
+	// it from Kafka, in which case the consenters bootstrap a new Raft cluster
+	// rather than join an existing one, even though the ledger is not empty.
+	MigrationInit bool
+
+	// PreferredLeader is the host:port of the consenter which should lead the
+	// channel, if any. It is updated by config blocks which carry etcdraft options.
+	PreferredLeader string
+
+	// TransferLeadershipOnHalt makes a leader hand its leadership over to a follower
+	// when the chain is halted, so that the others do not wait for an election timeout.
+	TransferLeadershipOnHalt bool
 }
 
 type submit struct {
@@ -106,11 +121,13 @@ type Chain struct {
 	doneC    chan struct{}         // Closes when the chain halts
 	startC   chan struct{}         // Closes when the node is started
 	snapC    chan *raftpb.Snapshot // Signal to catch up with snapshot
+	promoteC chan uint64           // Signals the leader that a learner has caught up and may be promoted
 
 	errorCLock sync.RWMutex
 	errorC     chan struct{} // returned by Errored()
 
 	raftMetadataLock     sync.RWMutex
+	transferLock         sync.Mutex // serializes leadership transfers
 	confChangeInProgress *raftpb.ConfChange
 	justElected          bool // this is true when node has just been elected
 	configInflight       bool // this is true when there is config block or ConfChange in flight
@@ -176,6 +193,7 @@ func NewChain(
 		doneC:            make(chan struct{}),
 		startC:           make(chan struct{}),
 		snapC:            make(chan *raftpb.Snapshot),
+		promoteC:         make(chan uint64, 1),
 		errorC:           make(chan struct{}),
 		observeC:         observeC,
 		support:          support,
@@ -229,7 +247,8 @@ func (c *Chain) Start() {
 		return
 	}
 
-	c.node.start(c.fresh, c.support.Height() > 1)
+	isJoin := c.support.Height() > 1 && !c.opts.MigrationInit
+	c.node.start(c.fresh, isJoin)
 	close(c.startC)
 	close(c.errorC)
 
@@ -317,6 +336,12 @@ func (c *Chain) Halt() {
 		return
 	}
 
+	if c.opts.TransferLeadershipOnHalt && c.isRunning() == nil && c.node.Status().RaftState == raft.StateLeader {
+		if _, err := c.TransferLeadership(raft.None); err != nil {
+			c.logger.Warnf("Failed to transfer leadership before halting: %s", err)
+		}
+	}
+
 	select {
 	case c.haltC <- struct{}{}:
 	case <-c.doneC:
@@ -325,6 +350,92 @@ func (c *Chain) Halt() {
 	<-c.doneC
 }
 
+// TransferLeadership transfers the leadership of this node, which must be the leader,
+// to the given node, or to the most caught up of the active followers if none is given.
+// It waits until the new leader is elected, and returns it.
+func (c *Chain) TransferLeadership(transferee uint64) (uint64, error) {
+	if err := c.isRunning(); err != nil {
+		return raft.None, err
+	}
+
+	c.transferLock.Lock()
+	defer c.transferLock.Unlock()
+
+	transferee, err := c.node.transferee(transferee)
+	if err != nil {
+		return raft.None, err
+	}
+	return c.node.transferLeadership(transferee)
+}
+
+// LeadershipStatus describes the leadership of a Raft cluster as seen by one of its nodes.
+type LeadershipStatus struct {
+	RaftID          uint64            `json:"raft_id"`
+	Leader          uint64            `json:"leader"`
+	PreferredLeader uint64            `json:"preferred_leader,omitempty"`
+	Consenters      map[uint64]string `json:"consenters"`
+	Learners        []uint64          `json:"learners,omitempty"`
+}
+
+// LeadershipStatus returns the leadership status of the Raft cluster as seen by this node.
+func (c *Chain) LeadershipStatus() (*LeadershipStatus, error) {
+	if err := c.isRunning(); err != nil {
+		return nil, err
+	}
+
+	status := &LeadershipStatus{
+		RaftID:          c.raftID,
+		Leader:          c.node.Status().Lead,
+		PreferredLeader: c.preferredLeaderID(),
+		Consenters:      map[uint64]string{},
+	}
+
+	c.raftMetadataLock.RLock()
+	defer c.raftMetadataLock.RUnlock()
+	for id, consenter := range c.opts.RaftMetadata.Consenters {
+		status.Consenters[id] = consenterEndpoint(consenter)
+	}
+	status.Learners = Learners(c.opts.RaftMetadata.Consenters)
+	return status, nil
+}
+
+// preferredLeaderID returns the Raft ID of the preferred leader of the channel,
+// or raft.None if there is none or it is not among the consenters.
+func (c *Chain) preferredLeaderID() uint64 {
+	c.raftMetadataLock.RLock()
+	defer c.raftMetadataLock.RUnlock()
+
+	if c.opts.PreferredLeader == "" {
+		return raft.None
+	}
+	for id, consenter := range c.opts.RaftMetadata.Consenters {
+		if consenterEndpoint(consenter) == c.opts.PreferredLeader {
+			return id
+		}
+	}
+	return raft.None
+}
+
+// RemoveData removes the write-ahead log and the snapshots of the chain, once it is halted.
+// It is called when the orderer is removed from the channel.
+func (c *Chain) RemoveData() error {
+	select {
+	case <-c.doneC:
+	default:
+		return errors.Errorf("chain is not halted")
+	}
+
+	if err := os.RemoveAll(c.opts.WALDir); err != nil {
+		return errors.Wrapf(err, "failed to remove WAL dir %s", c.opts.WALDir)
+	}
+	if err := os.RemoveAll(c.opts.SnapDir); err != nil {
+		return errors.Wrapf(err, "failed to remove snapshot dir %s", c.opts.SnapDir)
+	}
+
+	c.logger.Infof("Removed WAL dir %s and snapshot dir %s", c.opts.WALDir, c.opts.SnapDir)
+	return nil
+}
+
 func (c *Chain) isRunning() error {
 	select {
 	case <-c.startC:
@@ -352,6 +463,14 @@ func (c *Chain) Step(req *orderer.StepRequest, sender uint64) error {
 		return fmt.Errorf("failed to unmarshal StepRequest payload to Raft Message: %s", err)
 	}
 
+	if stepMsg.Type == raftpb.MsgSnap && NodeExists(c.raftID, stepMsg.Snapshot.Metadata.ConfState.Learners) {
+		// etcd/raft refuses to restore a snapshot which lists a node that does not know
+		// it is a learner as a learner, which is the case of a learner joining the channel,
+		// hence make it aware of it beforehand. This is a no-op for a node which already
+		// is a learner.
+		c.node.ApplyConfChange(raftpb.ConfChange{Type: raftpb.ConfChangeAddLearnerNode, NodeID: c.raftID})
+	}
+
 	if err := c.node.Step(context.TODO(), *stepMsg); err != nil {
 		return fmt.Errorf("failed to process Raft Step message: %s", err)
 	}
@@ -409,7 +528,7 @@ func (c *Chain) serveRequest() {
 	start := func() {
 		if !ticking {
 			ticking = true
-			timer.Reset(c.support.SharedConfig().BatchTimeout())
+			timer.Reset(blockcutter.BatchTimeout(c.support.BlockCutter(), c.support.SharedConfig()))
 		}
 	}
 
@@ -561,6 +680,26 @@ func (c *Chain) serveRequest() {
 			c.logger.Debugf("Batch timer expired, creating block")
 			c.propose(bc, batch) // we are certain this is normal block, no need to block
 
+		case learner := <-c.promoteC:
+			if soft.Lead != c.raftID || c.justElected || c.configInflight {
+				// the learner is signaled again once pending changes are settled
+				break
+			}
+
+			cc := c.promotion(learner)
+			if cc == nil {
+				break
+			}
+			if err := c.node.ProposeConfChange(context.TODO(), *cc); err != nil {
+				c.logger.Warnf("Failed to propose promotion of learner %d: %s", learner, err)
+				break
+			}
+
+			c.logger.Infof("Proposed promotion of learner %d to voting member", learner)
+			c.confChangeInProgress = cc
+			c.configInflight = true
+			submitC = nil // stop accepting new envelopes until the learner is promoted
+
 		case sn := <-c.snapC:
 			if sn.Metadata.Index <= c.appliedIndex {
 				c.logger.Debugf("Skip snapshot taken at index %d, because it is behind current applied index %d", sn.Metadata.Index, c.appliedIndex)
@@ -577,6 +716,10 @@ func (c *Chain) serveRequest() {
 					sn.Metadata.Term, sn.Metadata.Index, err)
 			}
 
+			for _, id := range c.confState.Nodes {
+				c.promoteLearner(id)
+			}
+
 		case <-c.doneC:
 			select {
 			case <-c.errorC: // avoid closing closed channel
@@ -733,7 +876,11 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 				continue
 			}
 
-			c.confState = *c.node.ApplyConfChange(cc)
+			confState := c.node.ApplyConfChange(cc)
+			if cc.Type == raftpb.ConfChangeAddNode {
+				c.promoteLearner(cc.NodeID)
+			}
+			c.confState = c.withLearners(*confState)
 
 			// This ConfChange was introduced by a previously committed config block,
 			// we can now unblock submitC to accept envelopes.
@@ -813,7 +960,7 @@ func (c *Chain) remotePeers() ([]cluster.RemoteNode, error) {
 		}
 		nodes = append(nodes, cluster.RemoteNode{
 			ID:            raftID,
-			Endpoint:      fmt.Sprintf("%s:%d", consenter.Host, consenter.Port),
+			Endpoint:      consenterEndpoint(consenter),
 			ServerTLSCert: serverCertAsDER,
 			ClientTLSCert: clientCertAsDER,
 		})
@@ -821,6 +968,10 @@ func (c *Chain) remotePeers() ([]cluster.RemoteNode, error) {
 	return nodes, nil
 }
 
+func consenterEndpoint(consenter *etcdraft.Consenter) string {
+	return fmt.Sprintf("%s:%d", consenter.Host, consenter.Port)
+}
+
 func (c *Chain) pemToDER(pemBytes []byte, id uint64, certType string) ([]byte, error) {
 	bl, _ := pem.Decode(pemBytes)
 	if bl == nil {
@@ -846,6 +997,15 @@ func (c *Chain) checkConsentersSet(configValue *common.ConfigValue) error {
 		return errors.New("update of more than one consenters at a time is not supported")
 	}
 
+	if preferred := updatedMetadata.Options.GetPreferredLeader(); preferred != "" {
+		for _, consenter := range updatedMetadata.Consenters {
+			if consenterEndpoint(consenter) == preferred {
+				return nil
+			}
+		}
+		return errors.Errorf("preferred leader %s is not among the consenters", preferred)
+	}
+
 	return nil
 }
 
@@ -863,6 +1023,13 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 	confChange := changes.UpdateRaftMetadataAndConfChange(raftMetadata)
 	raftMetadata.RaftIndex = index
 
+	if metadata != nil && metadata.Options.GetPreferredLeader() != c.opts.PreferredLeader {
+		c.logger.Infof("Preferred leader changed: %q -> %q", c.opts.PreferredLeader, metadata.Options.GetPreferredLeader())
+		c.raftMetadataLock.Lock()
+		c.opts.PreferredLeader = metadata.Options.GetPreferredLeader()
+		c.raftMetadataLock.Unlock()
+	}
+
 	raftMetadataBytes := utils.MarshalOrPanic(raftMetadata)
 	// write block with metadata
 	c.support.WriteConfigBlock(block, raftMetadataBytes)
@@ -886,6 +1053,62 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 	}
 }
 
+// promotion returns the ConfChange which promotes the given learner to a voting member,
+// or nil if it is not a learner anymore.
+func (c *Chain) promotion(learner uint64) *raftpb.ConfChange {
+	c.raftMetadataLock.RLock()
+	defer c.raftMetadataLock.RUnlock()
+
+	if consenter, exists := c.opts.RaftMetadata.Consenters[learner]; !exists || !consenter.Learner {
+		return nil
+	}
+	return &raftpb.ConfChange{
+		ID:     c.opts.RaftMetadata.ConfChangeCounts,
+		NodeID: learner,
+		Type:   raftpb.ConfChangeAddNode,
+	}
+}
+
+// promoteLearner clears the learner flag of the given consenter, which has become a voting member.
+func (c *Chain) promoteLearner(id uint64) {
+	c.raftMetadataLock.Lock()
+	defer c.raftMetadataLock.Unlock()
+
+	consenter, exists := c.opts.RaftMetadata.Consenters[id]
+	if !exists || !consenter.Learner {
+		return
+	}
+	promoted := proto.Clone(consenter).(*etcdraft.Consenter)
+	promoted.Learner = false
+	c.opts.RaftMetadata.Consenters[id] = promoted
+	c.logger.Infof("Learner %d has been promoted to voting member", id)
+}
+
+// withLearners moves the learners out of the voting members of the given Raft configuration state.
+// etcd/raft lists learners among the voting members of the ConfState returned by ApplyConfChange,
+// which would make them voters once restored from a snapshot the ConfState is persisted within.
+func (c *Chain) withLearners(confState raftpb.ConfState) raftpb.ConfState {
+	c.raftMetadataLock.RLock()
+	defer c.raftMetadataLock.RUnlock()
+
+	cs := raftpb.ConfState{}
+	for _, id := range RaftMembers(&confState) {
+		if consenter, exists := c.opts.RaftMetadata.Consenters[id]; exists && consenter.Learner {
+			cs.Learners = append(cs.Learners, id)
+		} else {
+			cs.Nodes = append(cs.Nodes, id)
+		}
+	}
+	return cs
+}
+
+// learners returns the Raft IDs of the consenters which are yet to be promoted to voting members.
+func (c *Chain) learners() []uint64 {
+	c.raftMetadataLock.RLock()
+	defer c.raftMetadataLock.RUnlock()
+	return Learners(c.opts.RaftMetadata.Consenters)
+}
+
 // getInFlightConfChange returns ConfChange in-flight if any.
 // It either returns confChangeInProgress if it is not nil, or
 // attempts to read ConfChange from last committed block.
@@ -920,7 +1143,7 @@ func (c *Chain) getInFlightConfChange() *raftpb.ConfChange {
 	// extracting current Raft configuration state
 	confState := c.node.ApplyConfChange(raftpb.ConfChange{})
 
-	if len(confState.Nodes) == len(raftMetadata.Consenters) {
+	if len(RaftMembers(confState)) == len(raftMetadata.Consenters) {
 		// since configuration change could only add one node or
 		// remove one node at a time, if raft nodes state size
 		// equal to membership stored in block metadata field,
@@ -362,6 +362,16 @@ var _ = Describe("Chain", func() {
 				Eventually(errorC).Should(BeClosed())
 			})
 
+			It("removes its WAL and snapshots once halted", func() {
+				Expect(chain.RemoveData()).To(MatchError("chain is not halted"))
+				Expect(walDir).To(BeADirectory())
+
+				chain.Halt()
+				Expect(chain.RemoveData()).To(Succeed())
+				Expect(walDir).NotTo(BeAnExistingFile())
+				Expect(snapDir).NotTo(BeAnExistingFile())
+			})
+
 			Describe("Config updates", func() {
 				var (
 					configEnv *common.Envelope
@@ -1295,6 +1305,30 @@ var _ = Describe("Chain", func() {
 						},
 					}
 				}
+				addLearnerConfigValue = func() map[string]*common.ConfigValue {
+					metadata := &raftprotos.Metadata{}
+					for _, consenter := range raftMetadata.Consenters {
+						metadata.Consenters = append(metadata.Consenters, consenter)
+					}
+
+					newConsenter := &raftprotos.Consenter{
+						Host:          "localhost",
+						Port:          7050,
+						ServerTlsCert: serverTLSCert(tlsCA),
+						ClientTlsCert: clientTLSCert(tlsCA),
+						Learner:       true,
+					}
+					metadata.Consenters = append(metadata.Consenters, newConsenter)
+
+					return map[string]*common.ConfigValue{
+						"ConsensusType": {
+							Version: 1,
+							Value: marshalOrPanic(&orderer.ConsensusType{
+								Metadata: marshalOrPanic(metadata),
+							}),
+						},
+					}
+				}
 				removeConsenterConfigValue = func(id uint64) map[string]*common.ConfigValue {
 					newRaftMetadata := proto.Clone(raftMetadata).(*raftprotos.RaftMetadata)
 					delete(newRaftMetadata.Consenters, id)
@@ -1426,6 +1460,82 @@ var _ = Describe("Chain", func() {
 					})
 				})
 
+				It("adding learner node to the cluster and promoting it once caught up", func() {
+					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, addLearnerConfigValue()))
+					c1.cutter.CutNext = true
+
+					By("sending config transaction")
+					err := c1.Configure(configEnv, 0)
+					Expect(err).ToNot(HaveOccurred())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(1))
+					})
+
+					_, raftmetabytes := c1.support.WriteConfigBlockArgsForCall(0)
+					meta := &common.Metadata{Value: raftmetabytes}
+					raftmeta, err := etcdraft.ReadRaftMetadata(meta, nil)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(raftmeta.Consenters[4].Learner).To(BeTrue())
+
+					By("joining the cluster as a learner, which cannot lead")
+					Eventually(func() error {
+						_, err := c1.TransferLeadership(4)
+						return err
+					}, defaultTimeout).Should(MatchError("node 4 is a learner and cannot lead"))
+
+					status, err := c1.LeadershipStatus()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.Learners).To(Equal([]uint64{4}))
+
+					c4 := newChain(timeout, channelID, dataDir, 4, raftmeta)
+					c4.init()
+
+					c4.support.WriteBlock(c1.support.WriteBlockArgsForCall(0))
+					c4.support.WriteConfigBlock(c1.support.WriteConfigBlockArgsForCall(0))
+
+					network.addChain(c4)
+					c4.Start()
+
+					Eventually(func() <-chan raft.SoftState {
+						c1.clock.Increment(interval)
+						return c4.observe
+					}, defaultTimeout).Should(Receive(Equal(raft.SoftState{Lead: 1, RaftState: raft.StateFollower})))
+
+					Eventually(c4.support.WriteBlockCallCount, defaultTimeout).Should(Equal(1))
+					Eventually(c4.support.WriteConfigBlockCallCount, defaultTimeout).Should(Equal(1))
+
+					By("promoting the learner once it has caught up with the leader")
+					network.exec(func(c *chain) {
+						Eventually(func() []uint64 {
+							c1.clock.Increment(interval)
+							status, err := c.LeadershipStatus()
+							Expect(err).NotTo(HaveOccurred())
+							return status.Learners
+						}, defaultTimeout).Should(BeEmpty())
+					})
+
+					By("submitting new transaction to the promoted node")
+					c1.cutter.CutNext = true
+					err = c4.Order(env, 0)
+					Expect(err).ToNot(HaveOccurred())
+
+					network.exec(func(c *chain) {
+						Eventually(c.support.WriteBlockCallCount, defaultTimeout).Should(Equal(2))
+					})
+
+					_, raftmetabytes = c4.support.WriteBlockArgsForCall(1)
+					raftmeta, err = etcdraft.ReadRaftMetadata(&common.Metadata{Value: raftmetabytes}, nil)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(raftmeta.Consenters[4].Learner).To(BeFalse())
+
+					By("transferring leadership to the promoted node")
+					leader, err := c1.TransferLeadership(4)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(leader).To(Equal(uint64(4)))
+					network.leader = 4
+				})
+
 				It("adding node to the cluster of 2/3 available nodes", func() {
 					// Scenario: disconnect one of existing nodes from the replica set
 					// add new node, reconnect the old one and choose newly added as a
@@ -2211,6 +2321,115 @@ var _ = Describe("Chain", func() {
 				})
 			})
 
+			Context("leadership transfer", func() {
+				It("transfers leadership to the given node", func() {
+					leader, err := c1.TransferLeadership(3)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(leader).To(Equal(uint64(3)))
+					Eventually(c3.observe, LongEventualTimeout).Should(Receive(Equal(raft.SoftState{Lead: 3, RaftState: raft.StateLeader})))
+					network.leader = 3
+
+					By("ordering envelope on new leader")
+					c3.cutter.CutNext = true
+					err = c3.Order(env, 0)
+					Expect(err).ToNot(HaveOccurred())
+					network.exec(
+						func(c *chain) {
+							Eventually(c.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+						})
+				})
+
+				It("transfers leadership to the most caught up follower", func() {
+					network.disconnect(2)
+
+					c1.cutter.CutNext = true
+					err := c1.Order(env, 0)
+					Expect(err).ToNot(HaveOccurred())
+					Eventually(c3.support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+
+					leader, err := c1.TransferLeadership(0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(leader).To(Equal(uint64(3)))
+					network.leader = 3
+				})
+
+				It("fails to transfer leadership from a follower", func() {
+					_, err := c2.TransferLeadership(0)
+					Expect(err).To(MatchError("node 2 is not the Raft leader, the leader is 1"))
+				})
+
+				It("fails to transfer leadership to a node which is not a member", func() {
+					_, err := c1.TransferLeadership(4)
+					Expect(err).To(MatchError("node 4 is not a member of the Raft cluster"))
+				})
+
+				It("rejects a preferred leader which is not among the consenters", func() {
+					metadata := &raftprotos.Metadata{Options: &raftprotos.Options{PreferredLeader: "localhost:7053"}}
+					for _, consenter := range raftMetadata.Consenters {
+						metadata.Consenters = append(metadata.Consenters, consenter)
+					}
+
+					value := map[string]*common.ConfigValue{
+						"ConsensusType": {
+							Version: 1,
+							Value: marshalOrPanic(&orderer.ConsensusType{
+								Metadata: marshalOrPanic(metadata),
+							}),
+						},
+					}
+					configEnv := newConfigEnv(channelID, common.HeaderType_CONFIG, newConfigUpdateEnv(channelID, value))
+					err := c1.Configure(configEnv, 0)
+					Expect(err).To(MatchError("preferred leader localhost:7053 is not among the consenters"))
+				})
+
+				It("reports the leadership status", func() {
+					status, err := c2.LeadershipStatus()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status).To(Equal(&etcdraft.LeadershipStatus{
+						RaftID: 2,
+						Leader: 1,
+						Consenters: map[uint64]string{
+							1: "localhost:7051",
+							2: "localhost:7051",
+							3: "localhost:7051",
+						},
+					}))
+				})
+
+				Context("when the leader transfers leadership on halt", func() {
+					BeforeEach(func() {
+						c1.opts.TransferLeadershipOnHalt = true
+					})
+
+					It("hands over leadership to a follower", func() {
+						c1.Halt()
+						Eventually(c2.observe, LongEventualTimeout).Should(Receive(Equal(raft.SoftState{Lead: 2, RaftState: raft.StateLeader})))
+						network.leader = 2
+					})
+				})
+
+				Context("when the channel has a preferred leader", func() {
+					BeforeEach(func() {
+						network.exec(func(c *chain) {
+							c.opts.RaftMetadata.Consenters[3].Port = 7053
+							c.opts.PreferredLeader = "localhost:7053"
+						})
+					})
+
+					It("hands over leadership to the preferred leader", func() {
+						Eventually(func() <-chan raft.SoftState {
+							c1.clock.Increment(interval)
+							return c3.observe
+						}, LongEventualTimeout).Should(Receive(Equal(raft.SoftState{Lead: 3, RaftState: raft.StateLeader})))
+						network.leader = 3
+
+						status, err := c3.LeadershipStatus()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(status.PreferredLeader).To(Equal(uint64(3)))
+					})
+				})
+			})
+
 			Context("failover", func() {
 				It("follower should step up as leader upon failover", func() {
 					network.stop(1)
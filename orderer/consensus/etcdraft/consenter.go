@@ -125,6 +125,17 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		return nil, errors.New("etcdraft options have not been provided")
 	}
 
+	// In case the chain was just migrated from Kafka, the last block was cut by Kafka,
+	// hence its metadata is not Raft metadata and the cluster is bootstrapped from the config.
+	migrationInit, err := isMigrationInit(support)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to detect consensus-type migration")
+	}
+	if migrationInit {
+		c.Logger.Infof("Channel %s was migrated to etcdraft at block %d, starting a new Raft cluster", support.ChainID(), support.Height()-1)
+		metadata = nil
+	}
+
 	// determine raft replica set mapping for each node to its id
 	// for newly started chain we need to read and initialize raft
 	// metadata by creating mapping between conseter and its id.
@@ -161,8 +172,12 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		MaxInflightMsgs: int(m.Options.MaxInflightMsgs),
 		MaxSizePerMsg:   m.Options.MaxSizePerMsg,
 		SnapInterval:    m.Options.SnapshotInterval,
+		PreferredLeader: m.Options.PreferredLeader,
+
+		RaftMetadata:  raftMetadata,
+		MigrationInit: migrationInit,
 
-		RaftMetadata: raftMetadata,
+		TransferLeadershipOnHalt: true,
 
 		WALDir:  path.Join(c.EtcdRaftConfig.WALDir, support.ChainID()),
 		SnapDir: path.Join(c.EtcdRaftConfig.SnapDir, support.ChainID()),
@@ -192,6 +207,12 @@ func ReadRaftMetadata(blockMetadata *common.Metadata, configMetadata *etcdraft.M
 
 	// need to read consenters from the configuration
 	for _, consenter := range configMetadata.Consenters {
+		if consenter.Learner {
+			// the consenters of a new channel bootstrap the Raft cluster together,
+			// hence all of them start as voting members
+			consenter = proto.Clone(consenter).(*etcdraft.Consenter)
+			consenter.Learner = false
+		}
 		m.Consenters[m.NextConsenterId] = consenter
 		m.NextConsenterId++
 	}
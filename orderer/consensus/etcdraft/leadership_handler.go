@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// LeadershipManager reports on and transfers the leadership of the Raft cluster of a channel.
+// It is implemented by Chain.
+type LeadershipManager interface {
+	// LeadershipStatus returns the leadership status of the Raft cluster as seen by this node.
+	LeadershipStatus() (*LeadershipStatus, error)
+	// TransferLeadership transfers the leadership of this node to the given node, or to the
+	// most caught up of the active followers if none is given, and returns the new leader.
+	TransferLeadership(transferee uint64) (uint64, error)
+}
+
+// TransferRequest is the request to transfer the leadership of a channel
+type TransferRequest struct {
+	Channel string `json:"channel"`
+	// Transferee is the Raft ID of the node to transfer the leadership to.
+	// The most caught up of the active followers is picked if it is not set.
+	Transferee uint64 `json:"transferee,omitempty"`
+}
+
+// TransferResponse is the response to a successful leadership transfer
+type TransferResponse struct {
+	Channel string `json:"channel"`
+	Leader  uint64 `json:"leader"`
+}
+
+// ErrorResponse is the response to a failed request
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewLeadershipHandler constructs a LeadershipHandler
+func NewLeadershipHandler(chains ChainGetter) *LeadershipHandler {
+	return &LeadershipHandler{
+		Chains: chains,
+		Logger: flogging.MustGetLogger("orderer.consensus.etcdraft.leadership"),
+	}
+}
+
+// LeadershipHandler serves the leadership status of the Raft cluster of a channel on GET,
+// and transfers the leadership of a channel away from this node on POST, e.g. before the
+// node is taken down for maintenance.
+type LeadershipHandler struct {
+	Chains ChainGetter
+	Logger *flogging.FabricLogger
+}
+
+func (h *LeadershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		channel := req.URL.Query().Get("channel")
+		lm, code, err := h.leadershipManager(channel)
+		if err != nil {
+			h.sendResponse(resp, code, err)
+			return
+		}
+		status, err := lm.LeadershipStatus()
+		if err != nil {
+			h.sendResponse(resp, http.StatusServiceUnavailable, err)
+			return
+		}
+		h.sendResponse(resp, http.StatusOK, status)
+
+	case http.MethodPost:
+		var transferReq TransferRequest
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&transferReq); err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, err)
+			return
+		}
+		req.Body.Close()
+
+		lm, code, err := h.leadershipManager(transferReq.Channel)
+		if err != nil {
+			h.sendResponse(resp, code, err)
+			return
+		}
+		leader, err := lm.TransferLeadership(transferReq.Transferee)
+		if err != nil {
+			h.sendResponse(resp, http.StatusConflict, err)
+			return
+		}
+		h.Logger.Infow("transferred leadership", "channel", transferReq.Channel, "leader", leader)
+		h.sendResponse(resp, http.StatusOK, &TransferResponse{Channel: transferReq.Channel, Leader: leader})
+
+	default:
+		err := fmt.Errorf("invalid request method: %s", req.Method)
+		h.sendResponse(resp, http.StatusBadRequest, err)
+	}
+}
+
+func (h *LeadershipHandler) leadershipManager(channel string) (LeadershipManager, int, error) {
+	if channel == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("channel must be provided")
+	}
+	cs := h.Chains.GetChain(channel)
+	if cs == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("channel [%s] not found", channel)
+	}
+	lm, ok := cs.Chain.(LeadershipManager)
+	if !ok {
+		return nil, http.StatusBadRequest, fmt.Errorf("channel [%s] is not of type etcdraft", channel)
+	}
+	return lm, http.StatusOK, nil
+}
+
+func (h *LeadershipHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
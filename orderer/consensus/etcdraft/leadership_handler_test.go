@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/consensus"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type leadershipChain struct {
+	consensus.Chain
+	transferee uint64
+	err        error
+}
+
+func (lc *leadershipChain) LeadershipStatus() (*etcdraft.LeadershipStatus, error) {
+	return &etcdraft.LeadershipStatus{RaftID: 1, Leader: 1, Consenters: map[uint64]string{1: "orderer1:7050", 2: "orderer2:7050"}}, lc.err
+}
+
+func (lc *leadershipChain) TransferLeadership(transferee uint64) (uint64, error) {
+	lc.transferee = transferee
+	if lc.err != nil {
+		return 0, lc.err
+	}
+	return 2, nil
+}
+
+func newLeadershipHandler(lc *leadershipChain) *etcdraft.LeadershipHandler {
+	chainGetter := &mocks.ChainGetter{}
+	chainGetter.On("GetChain", "raft-channel").Return(&multichannel.ChainSupport{Chain: lc})
+	chainGetter.On("GetChain", "solo-channel").Return(&multichannel.ChainSupport{})
+	chainGetter.On("GetChain", "missing-channel").Return(nil)
+	return etcdraft.NewLeadershipHandler(chainGetter)
+}
+
+func serveLeadership(h http.Handler, method, target, body string) (int, map[string]interface{}) {
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(method, target, strings.NewReader(body)))
+	payload := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		panic(err)
+	}
+	return resp.Code, payload
+}
+
+func TestLeadershipHandlerStatus(t *testing.T) {
+	h := newLeadershipHandler(&leadershipChain{})
+
+	code, payload := serveLeadership(h, http.MethodGet, "/raft/leadership?channel=raft-channel", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]interface{}{
+		"raft_id":    float64(1),
+		"leader":     float64(1),
+		"consenters": map[string]interface{}{"1": "orderer1:7050", "2": "orderer2:7050"},
+	}, payload)
+
+	code, payload = serveLeadership(h, http.MethodGet, "/raft/leadership", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "channel must be provided", payload["error"])
+
+	code, payload = serveLeadership(h, http.MethodGet, "/raft/leadership?channel=missing-channel", "")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "channel [missing-channel] not found", payload["error"])
+
+	code, payload = serveLeadership(h, http.MethodGet, "/raft/leadership?channel=solo-channel", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "channel [solo-channel] is not of type etcdraft", payload["error"])
+
+	h = newLeadershipHandler(&leadershipChain{err: errors.New("chain is stopped")})
+	code, payload = serveLeadership(h, http.MethodGet, "/raft/leadership?channel=raft-channel", "")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "chain is stopped", payload["error"])
+}
+
+func TestLeadershipHandlerTransfer(t *testing.T) {
+	lc := &leadershipChain{}
+	h := newLeadershipHandler(lc)
+
+	code, payload := serveLeadership(h, http.MethodPost, "/raft/leadership", `{"channel": "raft-channel", "transferee": 2}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, map[string]interface{}{"channel": "raft-channel", "leader": float64(2)}, payload)
+	assert.Equal(t, uint64(2), lc.transferee)
+
+	code, _ = serveLeadership(h, http.MethodPost, "/raft/leadership", `{"channel": "raft-channel"}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, uint64(0), lc.transferee)
+
+	code, payload = serveLeadership(h, http.MethodPost, "/raft/leadership", `{"channel": "missing-channel"}`)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "channel [missing-channel] not found", payload["error"])
+
+	code, _ = serveLeadership(h, http.MethodPost, "/raft/leadership", `not json`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, payload = serveLeadership(h, http.MethodDelete, "/raft/leadership", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalid request method: DELETE", payload["error"])
+
+	lc.err = errors.New("node 1 is not the Raft leader, the leader is 2")
+	code, payload = serveLeadership(h, http.MethodPost, "/raft/leadership", `{"channel": "raft-channel"}`)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "node 1 is not the Raft leader, the leader is 2", payload["error"])
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package etcdraft
 
 import (
+	"context"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -16,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 )
 
 type node struct {
@@ -34,6 +37,10 @@ type node struct {
 
 	metadata *etcdraft.RaftMetadata
 
+	// leaderChangeSubscription holds a func(uint64) which is
+	// invoked with the new leader upon every leader change.
+	leaderChangeSubscription atomic.Value
+
 	raft.Node
 }
 
@@ -57,7 +64,11 @@ func (n *node) start(fresh, join bool) {
 }
 
 func (n *node) run() {
+	n.leaderChangeSubscription.Store(func(uint64) {})
+
 	ticker := n.clock.NewTicker(n.tickInterval)
+	var lead uint64
+	var ticks int
 
 	if s := n.storage.Snapshot(); !raft.IsEmptySnap(s) {
 		n.chain.snapC <- &s
@@ -68,6 +79,15 @@ func (n *node) run() {
 		case <-ticker.C():
 			n.Tick()
 
+			// check once per election timeout whether leadership
+			// should be handed over to the preferred leader, and
+			// whether a learner may be promoted
+			ticks++
+			if lead == n.config.ID && ticks%n.config.ElectionTick == 0 {
+				n.maybeTransferToPreferredLeader()
+				n.maybePromoteLearner()
+			}
+
 		case rd := <-n.Ready():
 			if err := n.storage.Store(rd.Entries, rd.HardState, rd.Snapshot); err != nil {
 				n.logger.Panicf("Failed to persist etcd/raft data: %s", err)
@@ -77,6 +97,14 @@ func (n *node) run() {
 				n.chain.snapC <- &rd.Snapshot
 			}
 
+			if rd.SoftState != nil {
+				newLeader := atomic.LoadUint64(&rd.SoftState.Lead) // etcdraft requires atomic access
+				if newLeader != lead {
+					lead = newLeader
+					n.leaderChangeSubscription.Load().(func(uint64))(newLeader)
+				}
+			}
+
 			n.chain.applyC <- apply{rd.CommittedEntries, rd.SoftState}
 			n.Advance()
 
@@ -128,3 +156,125 @@ func (n *node) lastIndex() uint64 {
 	i, _ := n.storage.ram.LastIndex()
 	return i
 }
+
+// transferee picks the follower which should take over the leadership of this node,
+// which must be the leader: the given candidate if there is one, otherwise the most
+// caught up of the recently active followers.
+func (n *node) transferee(candidate uint64) (uint64, error) {
+	status := n.Status()
+	if status.RaftState != raft.StateLeader {
+		return raft.None, errors.Errorf("node %d is not the Raft leader, the leader is %d", n.config.ID, status.Lead)
+	}
+
+	if candidate != raft.None {
+		pr, exists := status.Progress[candidate]
+		switch {
+		case !exists:
+			return raft.None, errors.Errorf("node %d is not a member of the Raft cluster", candidate)
+		case candidate == status.ID:
+			return raft.None, errors.Errorf("node %d is already the Raft leader", candidate)
+		case pr.IsLearner:
+			return raft.None, errors.Errorf("node %d is a learner and cannot lead", candidate)
+		}
+		return candidate, nil
+	}
+
+	var transferee, match uint64
+	for id, pr := range status.Progress {
+		if id == status.ID || pr.IsLearner || !pr.RecentActive || pr.Paused {
+			continue
+		}
+		if transferee == raft.None || pr.Match > match || (pr.Match == match && id < transferee) {
+			transferee, match = id, pr.Match
+		}
+	}
+	if transferee == raft.None {
+		return raft.None, errors.New("no follower is recently active, leadership cannot be transferred")
+	}
+	return transferee, nil
+}
+
+// transferLeadership transfers the leadership of this node to the given transferee,
+// and waits until a new leader is elected or an election timeout elapses, in which
+// case the transfer is aborted. It returns the new leader.
+func (n *node) transferLeadership(transferee uint64) (uint64, error) {
+	notifyC := make(chan uint64, 1)
+	n.leaderChangeSubscription.Store(func(newLeader uint64) {
+		select {
+		case notifyC <- newLeader:
+		default:
+		}
+	})
+	defer n.leaderChangeSubscription.Store(func(uint64) {})
+
+	timeout := time.Duration(n.config.ElectionTick) * n.tickInterval
+	timer := n.clock.NewTimer(timeout)
+	defer timer.Stop()
+
+	n.logger.Infof("Transferring leadership to %d", transferee)
+	n.TransferLeadership(context.TODO(), n.config.ID, transferee)
+
+	for {
+		select {
+		case newLeader := <-notifyC:
+			// the leader steps down before the new one is known
+			if newLeader == raft.None {
+				continue
+			}
+			n.logger.Infof("Leadership transferred from %d to %d", n.config.ID, newLeader)
+			return newLeader, nil
+		case <-timer.C():
+			return raft.None, errors.Errorf("leadership was not transferred to %d within %s", transferee, timeout)
+		case <-n.chain.doneC:
+			return raft.None, errors.New("chain is stopped")
+		}
+	}
+}
+
+// maybeTransferToPreferredLeader hands the leadership of this node over to the preferred
+// leader of the channel, if it is another node which is active and has caught up.
+// It does not wait for the transfer to complete, as it is called by the run loop.
+func (n *node) maybeTransferToPreferredLeader() {
+	preferred := n.chain.preferredLeaderID()
+	if preferred == raft.None || preferred == n.config.ID {
+		return
+	}
+
+	status := n.Status()
+	if status.RaftState != raft.StateLeader || status.LeadTransferee != raft.None {
+		return
+	}
+	pr, exists := status.Progress[preferred]
+	if !exists || pr.IsLearner || !pr.RecentActive || pr.Match < n.lastIndex() {
+		return
+	}
+
+	n.logger.Infof("Transferring leadership to preferred leader %d", preferred)
+	n.TransferLeadership(context.TODO(), n.config.ID, preferred)
+}
+
+// maybePromoteLearner signals the chain to promote a learner which has caught up with
+// this node, which must be the leader, to a voting member of the Raft cluster.
+func (n *node) maybePromoteLearner() {
+	learners := n.chain.learners()
+	if len(learners) == 0 {
+		return
+	}
+
+	status := n.Status()
+	if status.RaftState != raft.StateLeader {
+		return
+	}
+	for _, id := range learners {
+		pr, exists := status.Progress[id]
+		if !exists || !pr.IsLearner || !pr.RecentActive || pr.Match < n.lastIndex() {
+			continue
+		}
+
+		select {
+		case n.chain.promoteC <- id:
+		default: // a promotion is already signaled
+		}
+		return
+	}
+}
@@ -9,6 +9,7 @@ package etcdraft
 import (
 	"bytes"
 	"encoding/pem"
+	"sort"
 
 	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
@@ -54,6 +55,10 @@ func (mc *MembershipChanges) UpdateRaftMetadataAndConfChange(raftMetadata *etcdr
 			NodeID: nodeID,
 			Type:   raftpb.ConfChangeAddNode,
 		}
+		if mc.AddedNodes[0].Learner {
+			// learners join as non-voting members, and are promoted by the leader once caught up
+			confChange.Type = raftpb.ConfChangeAddLearnerNode
+		}
 		raftMetadata.ConfChangeCounts++
 		return confChange
 	}
@@ -104,6 +109,30 @@ func lastConfigBlockFromSupport(support consensus.ConsenterSupport) (*common.Blo
 	return lastConfigBlock, nil
 }
 
+// isMigrationInit returns whether the chain is started right at the config block which migrated
+// it from Kafka to Raft. Such a block is the last config block of the chain and leaves the channel
+// in the MIG_STATE_CONTEXT (standard channel) or MIG_STATE_COMMIT (system channel) migration state,
+// which no config block cut by Raft may keep.
+func isMigrationInit(support consensus.ConsenterSupport) (bool, error) {
+	state := support.SharedConfig().ConsensusMigrationState()
+	if state != orderer.ConsensusType_MIG_STATE_CONTEXT && state != orderer.ConsensusType_MIG_STATE_COMMIT {
+		return false, nil
+	}
+	if support.Height() <= 1 {
+		return false, nil
+	}
+	lastBlockSeq := support.Height() - 1
+	lastBlock := support.Block(lastBlockSeq)
+	if lastBlock == nil {
+		return false, errors.Errorf("unable to retrieve block %d", lastBlockSeq)
+	}
+	lastConfigIndex, err := utils.GetLastConfigIndexFromBlock(lastBlock)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return lastConfigIndex == lastBlockSeq, nil
+}
+
 // newBlockPuller creates a new block puller
 func newBlockPuller(support consensus.ConsenterSupport,
 	baseDialer *cluster.PredicateDialer,
@@ -379,21 +408,25 @@ func ConfChange(raftMetadata *etcdraft.RaftMetadata, confState *raftpb.ConfState
 	raftConfChange := &raftpb.ConfChange{}
 
 	raftConfChange.ID = raftMetadata.ConfChangeCounts
+	members := RaftMembers(confState)
 	// need to compute conf changes to propose
-	if len(confState.Nodes) < len(raftMetadata.Consenters) {
+	if len(members) < len(raftMetadata.Consenters) {
 		// adding new node
 		raftConfChange.Type = raftpb.ConfChangeAddNode
-		for consenterID := range raftMetadata.Consenters {
-			if NodeExists(consenterID, confState.Nodes) {
+		for consenterID, consenter := range raftMetadata.Consenters {
+			if NodeExists(consenterID, members) {
 				continue
 			}
 			raftConfChange.NodeID = consenterID
+			if consenter.Learner {
+				raftConfChange.Type = raftpb.ConfChangeAddLearnerNode
+			}
 		}
 	} else {
 		// removing node
 		raftConfChange.Type = raftpb.ConfChangeRemoveNode
 		consentersIDs := SliceOfConsentersIDs(raftMetadata.Consenters)
-		for _, nodeID := range confState.Nodes {
+		for _, nodeID := range members {
 			if NodeExists(nodeID, consentersIDs) {
 				continue
 			}
@@ -403,3 +436,22 @@ func ConfChange(raftMetadata *etcdraft.RaftMetadata, confState *raftpb.ConfState
 
 	return raftConfChange
 }
+
+// RaftMembers returns the ids of both the voting and the learner members of the Raft cluster
+func RaftMembers(confState *raftpb.ConfState) []uint64 {
+	members := make([]uint64, 0, len(confState.Nodes)+len(confState.Learners))
+	members = append(members, confState.Nodes...)
+	return append(members, confState.Learners...)
+}
+
+// Learners returns the ids of the consenters which are yet to be promoted to voting members
+func Learners(consenters map[uint64]*etcdraft.Consenter) []uint64 {
+	var learners []uint64
+	for id, consenter := range consenters {
+		if consenter.Learner {
+			learners = append(learners, id)
+		}
+	}
+	sort.Slice(learners, func(i, j int) bool { return learners[i] < learners[j] })
+	return learners
+}
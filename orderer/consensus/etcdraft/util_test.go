@@ -12,14 +12,18 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/coreos/etcd/raft/raftpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/orderer/mocks/common/multichannel"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -262,3 +266,105 @@ func TestNewBlockPuller(t *testing.T) {
 		})
 	}
 }
+
+func TestIsMigrationInit(t *testing.T) {
+	blockWithLastConfig := func(number, lastConfig uint64) *common.Block {
+		return &common.Block{
+			Header: &common.BlockHeader{Number: number},
+			Metadata: &common.BlockMetadata{
+				Metadata: [][]byte{{}, utils.MarshalOrPanic(&common.Metadata{
+					Value: utils.MarshalOrPanic(&common.LastConfig{Index: lastConfig}),
+				})},
+			},
+		}
+	}
+
+	for _, testCase := range []struct {
+		name           string
+		state          orderer.ConsensusType_MigrationState
+		height         uint64
+		lastBlock      *common.Block
+		expectedResult bool
+		expectedError  string
+	}{
+		{
+			name:   "Not migrating",
+			state:  orderer.ConsensusType_MIG_STATE_NONE,
+			height: 10,
+		},
+		{
+			name:   "New channel",
+			state:  orderer.ConsensusType_MIG_STATE_CONTEXT,
+			height: 1,
+		},
+		{
+			name:          "Last block cannot be retrieved",
+			state:         orderer.ConsensusType_MIG_STATE_CONTEXT,
+			height:        10,
+			expectedError: "unable to retrieve block 9",
+		},
+		{
+			name:      "Blocks cut since migration",
+			state:     orderer.ConsensusType_MIG_STATE_COMMIT,
+			height:    10,
+			lastBlock: blockWithLastConfig(9, 7),
+		},
+		{
+			name:           "Standard channel at migration block",
+			state:          orderer.ConsensusType_MIG_STATE_CONTEXT,
+			height:         10,
+			lastBlock:      blockWithLastConfig(9, 9),
+			expectedResult: true,
+		},
+		{
+			name:           "System channel at migration block",
+			state:          orderer.ConsensusType_MIG_STATE_COMMIT,
+			height:         10,
+			lastBlock:      blockWithLastConfig(9, 9),
+			expectedResult: true,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			cs := &multichannel.ConsenterSupport{
+				SharedConfigVal: &mockconfig.Orderer{ConsensusTypeMigrationStateVal: testCase.state},
+				HeightVal:       testCase.height,
+				BlockByIndex:    map[uint64]*common.Block{testCase.height - 1: testCase.lastBlock},
+			}
+
+			result, err := isMigrationInit(cs)
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedResult, result)
+		})
+	}
+}
+
+func TestLearnerConfChange(t *testing.T) {
+	raftMetadata := &etcdraft.RaftMetadata{
+		Consenters: map[uint64]*etcdraft.Consenter{
+			1: {ClientTlsCert: []byte("cert1")},
+			2: {ClientTlsCert: []byte("cert2")},
+		},
+		NextConsenterId:  3,
+		ConfChangeCounts: 1,
+	}
+	learner := &etcdraft.Consenter{ClientTlsCert: []byte("cert3"), Learner: true}
+
+	// A consenter flagged as learner is added as a non-voting member
+	changes := ComputeMembershipChanges(raftMetadata.Consenters, []*etcdraft.Consenter{raftMetadata.Consenters[1], raftMetadata.Consenters[2], learner})
+	confChange := changes.UpdateRaftMetadataAndConfChange(raftMetadata)
+	assert.Equal(t, &raftpb.ConfChange{ID: 1, NodeID: 3, Type: raftpb.ConfChangeAddLearnerNode}, confChange)
+	assert.Equal(t, []uint64{3}, Learners(raftMetadata.Consenters))
+
+	// The in-flight addition of a learner is resumed as such
+	confChange = ConfChange(raftMetadata, &raftpb.ConfState{Nodes: []uint64{1, 2}})
+	assert.Equal(t, &raftpb.ConfChange{ID: 2, NodeID: 3, Type: raftpb.ConfChangeAddLearnerNode}, confChange)
+
+	// Learners are members of the cluster, hence removals are computed against them as well
+	delete(raftMetadata.Consenters, 2)
+	confChange = ConfChange(raftMetadata, &raftpb.ConfState{Nodes: []uint64{1, 2}, Learners: []uint64{3}})
+	assert.Equal(t, &raftpb.ConfChange{ID: 2, NodeID: 2, Type: raftpb.ConfChangeRemoveNode}, confChange)
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/common/blockcutter"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	cb "github.com/hyperledger/fabric/protos/common"
 )
@@ -130,8 +131,9 @@ func (ch *chain) main() {
 					timer = nil
 				case timer == nil && pending:
 					// Timer is not already running and there are messages pending, so start it
-					timer = time.After(ch.support.SharedConfig().BatchTimeout())
-					logger.Debugf("Just began %s batch timer", ch.support.SharedConfig().BatchTimeout().String())
+					batchTimeout := blockcutter.BatchTimeout(ch.support.BlockCutter(), ch.support.SharedConfig())
+					timer = time.After(batchTimeout)
+					logger.Debugf("Just began %s batch timer", batchTimeout.String())
 				default:
 					// Do nothing when:
 					// 1. Timer is already running and there are messages pending
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnjoin(t *testing.T) {
+	InitMSP()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		args        []string
+		response    *pb.ProposalResponse
+		endorserErr error
+		expectedErr string
+	}{
+		{
+			name:     "success",
+			args:     []string{"-c", mockChannel},
+			response: &pb.ProposalResponse{Response: &pb.Response{Status: 200}},
+		},
+		{
+			name:        "missing channel ID",
+			expectedErr: "Must supply channel ID",
+		},
+		{
+			name:        "bad proposal response",
+			args:        []string{"-c", mockChannel},
+			response:    &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "peer is not joined to channel [mockChannel]"}},
+			expectedErr: "proposal failed (err: bad proposal response 500: peer is not joined to channel [mockChannel])",
+		},
+		{
+			name:        "endorser error",
+			args:        []string{"-c", mockChannel},
+			endorserErr: errors.New("connection refused"),
+			expectedErr: "proposal failed (err: connection refused)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resetFlags()
+			mockCF := &ChannelCmdFactory{
+				EndorserClient: common.GetMockEndorserClient(test.response, test.endorserErr),
+				Signer:         signer,
+			}
+
+			cmd := unjoinCmd(mockCF)
+			AddFlags(cmd)
+			cmd.SetArgs(test.args)
+
+			err := cmd.Execute()
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -113,3 +113,35 @@ func (m *mockAdminClient) SetLogSpec(ctx context.Context, in *cb.Envelope, opts
 	response := &pb.LogSpecResponse{LogSpec: "info"}
 	return response, m.err
 }
+
+func (m *mockAdminClient) AddStateIndex(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.StateIndexResponse, error) {
+	op := &pb.AdminOperation{}
+	pl := &cb.Payload{}
+	proto.Unmarshal(env.Payload, pl)
+	proto.Unmarshal(pl.Data, op)
+	response := &pb.StateIndexResponse{
+		Indexes: []*pb.StateIndex{{
+			DesignDoc:   "indexOwnerDoc",
+			Name:        "indexOwner",
+			Definition:  string(op.GetStateIndexReq().IndexDefinition),
+			BuildStatus: pb.StateIndex_BUILDING,
+		}},
+	}
+	return response, m.err
+}
+
+func (m *mockAdminClient) ListStateIndexes(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.StateIndexResponse, error) {
+	response := &pb.StateIndexResponse{
+		Indexes: []*pb.StateIndex{{
+			DesignDoc:   "indexOwnerDoc",
+			Name:        "indexOwner",
+			Definition:  `{"fields":["owner"]}`,
+			BuildStatus: pb.StateIndex_READY,
+		}},
+	}
+	return response, m.err
+}
+
+func (m *mockAdminClient) DropStateIndex(ctx context.Context, env *cb.Envelope, opts ...grpc.CallOption) (*pb.StateIndexResponse, error) {
+	return &pb.StateIndexResponse{}, m.err
+}
@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func addCmd(cf *IndexCmdFactory) *cobra.Command {
+	var indexAddCmd = &cobra.Command{
+		Use:   "add",
+		Short: "Adds an index to a deployed chaincode.",
+		Long: `Adds an index to the state database of a deployed chaincode on a channel. The index definition file has the same ` +
+			`format as the index files packaged in META-INF/statedb/couchdb/indexes. The index is built in the background.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return add(cf, cmd, args)
+		},
+	}
+	addTargetFlags(indexAddCmd)
+	indexAddCmd.Flags().StringVarP(&indexFile, "file", "f", "", "Path to the JSON file that contains the index definition")
+	return indexAddCmd
+}
+
+func add(cf *IndexCmdFactory, cmd *cobra.Command, args []string) error {
+	if err := checkIndexCmdParams(args); err != nil {
+		return err
+	}
+	if indexFile == "" {
+		return errors.New("must supply index definition file")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	indexDefinition, err := ioutil.ReadFile(indexFile)
+	if err != nil {
+		return errors.Wrapf(err, "error reading index definition file [%s]", indexFile)
+	}
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+	env := cf.wrapWithEnvelope(wrapStateIndexRequest(&pb.StateIndexRequest{
+		ChannelId:       channelID,
+		Chaincode:       chaincodeName,
+		Collection:      collectionName,
+		IndexDefinition: indexDefinition,
+	}))
+	resp, err := cf.AdminClient.AddStateIndex(context.Background(), env)
+	if err != nil {
+		return err
+	}
+	for _, stateIndex := range resp.Indexes {
+		fmt.Fprintf(cmd.OutOrStdout(), "Added index [%s] in design document [%s], status: %s\n",
+			stateIndex.Name, stateIndex.DesignDoc, buildStatus(stateIndex))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package index
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/peer/common"
+	common2 "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+type envelopeWrapper func(msg proto.Message) *common2.Envelope
+
+// IndexCmdFactory holds the clients used by IndexCmd
+type IndexCmdFactory struct {
+	AdminClient      pb.AdminClient
+	wrapWithEnvelope envelopeWrapper
+}
+
+// InitCmdFactory init the IndexCmdFactory with default admin client
+func InitCmdFactory() (*IndexCmdFactory, error) {
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.Errorf("failed obtaining default signer: %v", err)
+	}
+
+	localSigner := crypto.NewSignatureHeaderCreator(signer)
+	wrapEnv := func(msg proto.Message) *common2.Envelope {
+		env, err := utils.CreateSignedEnvelope(common2.HeaderType_PEER_ADMIN_OPERATION, "", localSigner, msg, 0, 0)
+		if err != nil {
+			logger.Panicf("Failed signing: %v", err)
+		}
+		return env
+	}
+
+	return &IndexCmdFactory{
+		AdminClient:      adminClient,
+		wrapWithEnvelope: wrapEnv,
+	}, nil
+}
+
+// wrapStateIndexRequest wraps the given request in an admin operation
+func wrapStateIndexRequest(request *pb.StateIndexRequest) *pb.AdminOperation {
+	return &pb.AdminOperation{
+		Content: &pb.AdminOperation_StateIndexReq{
+			StateIndexReq: request,
+		},
+	}
+}
+
+func checkIndexCmdParams(args []string) error {
+	if len(args) != 0 {
+		return errors.Errorf("trailing args detected")
+	}
+	if channelID == "" {
+		return errors.New("must supply channel ID")
+	}
+	if chaincodeName == "" {
+		return errors.New("must supply chaincode name")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package index
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func dropCmd(cf *IndexCmdFactory) *cobra.Command {
+	var indexDropCmd = &cobra.Command{
+		Use:   "drop",
+		Short: "Drops an index of a deployed chaincode.",
+		Long: `Drops an index from the state database of a deployed chaincode on a channel. Note that an index that is ` +
+			`packaged with the chaincode is created again when the chaincode is upgraded.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return drop(cf, cmd, args)
+		},
+	}
+	addTargetFlags(indexDropCmd)
+	flags := indexDropCmd.Flags()
+	flags.StringVarP(&designDoc, "ddoc", "", "", "Design document that contains the index")
+	flags.StringVarP(&indexName, "index", "", "", "Name of the index")
+	return indexDropCmd
+}
+
+func drop(cf *IndexCmdFactory, cmd *cobra.Command, args []string) error {
+	if err := checkIndexCmdParams(args); err != nil {
+		return err
+	}
+	if designDoc == "" || indexName == "" {
+		return errors.New("must supply design document and index name")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+	env := cf.wrapWithEnvelope(wrapStateIndexRequest(&pb.StateIndexRequest{
+		ChannelId:  channelID,
+		Chaincode:  chaincodeName,
+		Collection: collectionName,
+		DesignDoc:  designDoc,
+		IndexName:  indexName,
+	}))
+	if _, err := cf.AdminClient.DropStateIndex(context.Background(), env); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Dropped index [%s] in design document [%s]\n", indexName, designDoc)
+	return nil
+}
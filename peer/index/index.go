@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package index
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	indexFuncName = "index"
+	indexCmdDes   = "Manage the CouchDB indexes of deployed chaincodes: add|list|drop."
+)
+
+var logger = flogging.MustGetLogger("cli.index")
+
+var (
+	channelID      string
+	chaincodeName  string
+	collectionName string
+	indexFile      string
+	designDoc      string
+	indexName      string
+)
+
+// Cmd returns the cobra command for Index
+func Cmd(cf *IndexCmdFactory) *cobra.Command {
+	indexCmd.AddCommand(addCmd(cf))
+	indexCmd.AddCommand(listCmd(cf))
+	indexCmd.AddCommand(dropCmd(cf))
+
+	return indexCmd
+}
+
+var indexCmd = &cobra.Command{
+	Use:              indexFuncName,
+	Short:            fmt.Sprint(indexCmdDes),
+	Long:             fmt.Sprint(indexCmdDes),
+	PersistentPreRun: common.InitCmd,
+}
+
+func addTargetFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "C", "", "The channel on which the chaincode is deployed")
+	flags.StringVarP(&chaincodeName, "name", "n", "", "Name of the chaincode")
+	flags.StringVarP(&collectionName, "collection", "", "", "Name of the private data collection. The indexes of the public state are managed if not provided")
+}
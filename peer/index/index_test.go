@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package index
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	common2 "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockCmdFactory(err error) *IndexCmdFactory {
+	return &IndexCmdFactory{
+		AdminClient: common.GetMockAdminClient(err),
+		wrapWithEnvelope: func(msg proto.Message) *common2.Envelope {
+			pl := &common2.Payload{
+				Data: utils.MarshalOrPanic(msg),
+			}
+			return &common2.Envelope{
+				Payload: utils.MarshalOrPanic(pl),
+			}
+		},
+	}
+}
+
+func resetFlags() {
+	channelID = ""
+	chaincodeName = ""
+	collectionName = ""
+	indexFile = ""
+	designDoc = ""
+	indexName = ""
+}
+
+func execute(cmd *cobra.Command, args ...string) (string, error) {
+	resetFlags()
+	out := &bytes.Buffer{}
+	cmd.SetOutput(out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestAdd(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "index")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	indexFilePath := filepath.Join(testDir, "indexOwner.json")
+	require.NoError(t, ioutil.WriteFile(indexFilePath, []byte(`{"index":{"fields":["owner"]}}`), 0644))
+
+	out, err := execute(addCmd(newMockCmdFactory(nil)), "-C", "mychannel", "-n", "marbles", "-f", indexFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "Added index [indexOwner] in design document [indexOwnerDoc], status: BUILDING (0%)\n", out)
+
+	_, err = execute(addCmd(newMockCmdFactory(nil)), "-n", "marbles", "-f", indexFilePath)
+	assert.EqualError(t, err, "must supply channel ID")
+	_, err = execute(addCmd(newMockCmdFactory(nil)), "-C", "mychannel", "-f", indexFilePath)
+	assert.EqualError(t, err, "must supply chaincode name")
+	_, err = execute(addCmd(newMockCmdFactory(nil)), "-C", "mychannel", "-n", "marbles")
+	assert.EqualError(t, err, "must supply index definition file")
+	_, err = execute(addCmd(newMockCmdFactory(nil)), "-C", "mychannel", "-n", "marbles", "-f", indexFilePath, "extra")
+	assert.EqualError(t, err, "trailing args detected")
+	_, err = execute(addCmd(newMockCmdFactory(nil)), "-C", "mychannel", "-n", "marbles", "-f", filepath.Join(testDir, "missing.json"))
+	assert.Contains(t, err.Error(), "error reading index definition file")
+	_, err = execute(addCmd(newMockCmdFactory(errors.New("access denied"))), "-C", "mychannel", "-n", "marbles", "-f", indexFilePath)
+	assert.EqualError(t, err, "access denied")
+}
+
+func TestList(t *testing.T) {
+	out, err := execute(listCmd(newMockCmdFactory(nil)), "-C", "mychannel", "-n", "marbles", "--collection", "collectionMarbles")
+	assert.NoError(t, err)
+	assert.Equal(t, "Indexes of chaincode [marbles] on channel [mychannel]:\n"+
+		`Design document: indexOwnerDoc, Name: indexOwner, Status: READY, Definition: {"fields":["owner"]}`+"\n", out)
+
+	_, err = execute(listCmd(newMockCmdFactory(nil)), "-C", "mychannel")
+	assert.EqualError(t, err, "must supply chaincode name")
+	_, err = execute(listCmd(newMockCmdFactory(errors.New("access denied"))), "-C", "mychannel", "-n", "marbles")
+	assert.EqualError(t, err, "access denied")
+}
+
+func TestDrop(t *testing.T) {
+	out, err := execute(dropCmd(newMockCmdFactory(nil)), "-C", "mychannel", "-n", "marbles", "--ddoc", "indexOwnerDoc", "--index", "indexOwner")
+	assert.NoError(t, err)
+	assert.Equal(t, "Dropped index [indexOwner] in design document [indexOwnerDoc]\n", out)
+
+	_, err = execute(dropCmd(newMockCmdFactory(nil)), "-C", "mychannel", "-n", "marbles", "--ddoc", "indexOwnerDoc")
+	assert.EqualError(t, err, "must supply design document and index name")
+	_, err = execute(dropCmd(newMockCmdFactory(errors.New("access denied"))), "-C", "mychannel", "-n", "marbles", "--ddoc", "indexOwnerDoc", "--index", "indexOwner")
+	assert.EqualError(t, err, "access denied")
+}
+
+func TestCmd(t *testing.T) {
+	cmd := Cmd(nil)
+	var names []string
+	for _, c := range cmd.Commands() {
+		names = append(names, c.Name())
+	}
+	assert.Equal(t, []string{"add", "drop", "list"}, names)
+}
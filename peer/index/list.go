@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package index
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/cobra"
+)
+
+func listCmd(cf *IndexCmdFactory) *cobra.Command {
+	var indexListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "Lists the indexes of a deployed chaincode.",
+		Long:  `Lists the indexes in the state database of a deployed chaincode on a channel along with their build status.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list(cf, cmd, args)
+		},
+	}
+	addTargetFlags(indexListCmd)
+	return indexListCmd
+}
+
+func list(cf *IndexCmdFactory, cmd *cobra.Command, args []string) error {
+	if err := checkIndexCmdParams(args); err != nil {
+		return err
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+	env := cf.wrapWithEnvelope(wrapStateIndexRequest(&pb.StateIndexRequest{
+		ChannelId:  channelID,
+		Chaincode:  chaincodeName,
+		Collection: collectionName,
+	}))
+	resp, err := cf.AdminClient.ListStateIndexes(context.Background(), env)
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Indexes of chaincode [%s] on channel [%s]:\n", chaincodeName, channelID)
+	for _, stateIndex := range resp.Indexes {
+		fmt.Fprintf(out, "Design document: %s, Name: %s, Status: %s, Definition: %s\n",
+			stateIndex.DesignDoc, stateIndex.Name, buildStatus(stateIndex), stateIndex.Definition)
+	}
+	return nil
+}
+
+func buildStatus(stateIndex *pb.StateIndex) string {
+	if stateIndex.BuildStatus == pb.StateIndex_BUILDING {
+		return fmt.Sprintf("%s (%d%%)", stateIndex.BuildStatus, stateIndex.BuildProgress)
+	}
+	return stateIndex.BuildStatus.String()
+}
@@ -26,7 +26,7 @@ import (
 
 const (
 	nodeFuncName = "node"
-	nodeCmdDes   = "Operate a peer node: start|status."
+	nodeCmdDes   = "Operate a peer node: start|status|snapshot|state|verify-ledger|rebuild-dbs|rollback|unjoin."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -35,6 +35,12 @@ var logger = flogging.MustGetLogger("nodeCmd")
 func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(startCmd())
 	nodeCmd.AddCommand(statusCmd())
+	nodeCmd.AddCommand(snapshotCmd())
+	nodeCmd.AddCommand(stateCmd())
+	nodeCmd.AddCommand(verifyLedgerCmd())
+	nodeCmd.AddCommand(rebuildDBsCmd())
+	nodeCmd.AddCommand(rollbackCmd())
+	nodeCmd.AddCommand(unjoinCmd())
 
 	return nodeCmd
 }
@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var rebuildDBsChannelID string
+
+func rebuildDBsCmd() *cobra.Command {
+	flags := nodeRebuildDBsCmd.Flags()
+	flags.StringVarP(&rebuildDBsChannelID, "channelID", "c", "", "Channel whose databases are rebuilt.")
+	return nodeRebuildDBsCmd
+}
+
+var nodeRebuildDBsCmd = &cobra.Command{
+	Use:   "rebuild-dbs",
+	Short: "Rebuilds the databases of a channel from the block store.",
+	Long: `Drops the state database, the history database, and the bookkeeping of a channel and regenerates them by ` +
+		`recommitting the blocks in the block store. The progress of the rebuild is logged. If the rebuild gets ` +
+		`interrupted, executing this command again (or starting the peer) resumes the rebuild. The peer must be stopped ` +
+		`when executing this command.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		if rebuildDBsChannelID == "" {
+			return errors.New("must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		initLedgerMgmtForOfflineCmd()
+		defer ledgermgmt.Close()
+		height, err := ledgermgmt.RebuildDBs(rebuildDBsChannelID)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error while rebuilding the databases for channel [%s]", rebuildDBsChannelID))
+		}
+		fmt.Printf("Rebuilt the databases for channel [%s] up to the height [%d]\n", rebuildDBsChannelID, height)
+		return nil
+	},
+}
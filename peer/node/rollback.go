@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rollbackChannelID   string
+	rollbackBlockNumber uint64
+)
+
+func rollbackCmd() *cobra.Command {
+	flags := nodeRollbackCmd.Flags()
+	flags.StringVarP(&rollbackChannelID, "channelID", "c", "", "Channel whose ledger is rolled back.")
+	flags.Uint64VarP(&rollbackBlockNumber, "blockNumber", "b", 0, "Block number to which the ledger is rolled back.")
+	return nodeRollbackCmd
+}
+
+var nodeRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Rolls back the ledger of a channel to the specified block number.",
+	Long: `Removes the blocks above the specified block number from the block store of a channel. The state database, ` +
+		`the history database, and the bookkeeping of the channel are rebuilt from the retained blocks when the peer is ` +
+		`started. The removed blocks are pulled again from the other peers of the channel. The peer must be stopped ` +
+		`when executing this command.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		if rollbackChannelID == "" {
+			return errors.New("must supply channel ID")
+		}
+		if !cmd.Flags().Changed("blockNumber") {
+			return errors.New("must supply block number")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		initLedgerMgmtForOfflineCmd()
+		defer ledgermgmt.Close()
+		if err := ledgermgmt.RollbackLedger(rollbackChannelID, rollbackBlockNumber); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error while rolling back the ledger for channel [%s]", rollbackChannelID))
+		}
+		fmt.Printf("Rolled back the ledger for channel [%s] to the block [%d]\n", rollbackChannelID, rollbackBlockNumber)
+		return nil
+	},
+}
@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackCmdArgsValidation(t *testing.T) {
+	cmd := rollbackCmd()
+	defer func() { rollbackChannelID = "" }()
+
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "must supply block number")
+
+	cmd.SetArgs([]string{"-c", "mychannel", "-b", "5", "extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected")
+}
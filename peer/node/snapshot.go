@@ -0,0 +1,120 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/car"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/scc/lscc"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotChannelID string
+	snapshotPath      string
+)
+
+func snapshotCmd() *cobra.Command {
+	nodeSnapshotCmd.AddCommand(snapshotGenerateCmd)
+	nodeSnapshotCmd.AddCommand(snapshotImportCmd)
+
+	generateFlags := snapshotGenerateCmd.Flags()
+	generateFlags.StringVarP(&snapshotChannelID, "channelID", "c", "", "Channel for which the snapshot is generated.")
+	generateFlags.StringVarP(&snapshotPath, "snapshotPath", "s", "", "Directory in which the snapshot is generated. The directory should not exist.")
+
+	importFlags := snapshotImportCmd.Flags()
+	importFlags.StringVarP(&snapshotPath, "snapshotPath", "s", "", "Directory that contains the snapshot to import.")
+	return nodeSnapshotCmd
+}
+
+var nodeSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Generates or imports a ledger snapshot.",
+	Long:  `Generates a snapshot of a channel ledger or creates a channel ledger from a snapshot. The peer must be stopped when executing these commands.`,
+}
+
+var snapshotGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generates a snapshot of a channel ledger.",
+	Long:  `Generates a snapshot of a channel ledger at its current height. The peer must be stopped when executing this command.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		if snapshotChannelID == "" {
+			return errors.New("must supply channel ID")
+		}
+		if snapshotPath == "" {
+			return errors.New("must supply snapshot path")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		initLedgerMgmtForOfflineCmd()
+		defer ledgermgmt.Close()
+		if err := ledgermgmt.GenerateSnapshot(snapshotChannelID, snapshotPath); err != nil {
+			return err
+		}
+		fmt.Printf("Generated snapshot for channel [%s] in [%s]\n", snapshotChannelID, snapshotPath)
+		return nil
+	},
+}
+
+var snapshotImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Creates a channel ledger from a snapshot.",
+	Long: `Creates a channel ledger from a snapshot generated on another peer. The peer joins the channel when started next time ` +
+		`and fetches the blocks committed after the snapshot. The peer must be stopped when executing this command.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		if snapshotPath == "" {
+			return errors.New("must supply snapshot path")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		initLedgerMgmtForOfflineCmd()
+		defer ledgermgmt.Close()
+		l, err := ledgermgmt.CreateLedgerFromSnapshot(snapshotPath)
+		if err != nil {
+			return err
+		}
+		bcInfo, err := l.GetBlockchainInfo()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created ledger from snapshot [%s] at height [%d]\n", snapshotPath, bcInfo.Height)
+		return nil
+	},
+}
+
+// initLedgerMgmtForOfflineCmd initializes the ledger management for the commands that operate on
+// the ledgers directly while the peer is stopped
+func initLedgerMgmtForOfflineCmd() {
+	ledgermgmt.Initialize(
+		&ledgermgmt.Initializer{
+			CustomTxProcessors: peer.ConfigTxProcessors,
+			PlatformRegistry: platforms.NewRegistry(
+				&golang.Platform{},
+				&node.Platform{},
+				&java.Platform{},
+				&car.Platform{},
+			),
+			DeployedChaincodeInfoProvider: &lscc.DeployedCCInfoProvider{},
+			MetricsProvider:               &disabled.Provider{},
+		},
+	)
+}
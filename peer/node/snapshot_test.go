@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotCmdArgsValidation(t *testing.T) {
+	cmd := snapshotCmd()
+	defer func() {
+		snapshotChannelID = ""
+		snapshotPath = ""
+	}()
+
+	cmd.SetArgs([]string{"generate", "-s", "/tmp/snapshot"})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+
+	snapshotPath = ""
+	cmd.SetArgs([]string{"generate", "-c", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "must supply snapshot path")
+
+	snapshotChannelID = ""
+	snapshotPath = ""
+	cmd.SetArgs([]string{"import"})
+	assert.EqualError(t, cmd.Execute(), "must supply snapshot path")
+
+	cmd.SetArgs([]string{"import", "-s", "/tmp/snapshot", "extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected")
+}
@@ -58,6 +58,7 @@ import (
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/httpadmin"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
@@ -185,6 +186,7 @@ func serve(args []string) error {
 			HealthCheckRegistry:           opsSystem,
 		},
 	)
+	opsSystem.RegisterHandler("/storage", httpadmin.NewStorageHandler(&storageMaintainer{transientStores: peer.TransientStoreFactory}))
 
 	// Parameter overrides must be processed before any parameters are
 	// cached. Failures to cache cause the server to terminate immediately.
@@ -817,7 +819,7 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server, metricsPro
 		}()
 	}
 
-	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy))
+	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy, ledgermgmt.GetStateIndexManager))
 }
 
 // secureDialOpts is the callback function for secure dial options for gossip service
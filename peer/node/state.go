@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateChannelID string
+	stateNamespace string
+	stateFile      string
+)
+
+// stateRecord is the JSON representation of a key in the state of a namespace. The export
+// writes one record per line. The value and the metadata entries are base64 encoded
+type stateRecord struct {
+	Key      string            `json:"key"`
+	Value    []byte            `json:"value"`
+	Version  stateVersion      `json:"version"`
+	Metadata map[string][]byte `json:"metadata,omitempty"`
+}
+
+type stateVersion struct {
+	BlockNum uint64 `json:"block_num"`
+	TxNum    uint64 `json:"tx_num"`
+}
+
+func stateCmd() *cobra.Command {
+	nodeStateCmd.AddCommand(stateExportCmd)
+	nodeStateCmd.AddCommand(stateImportCmd)
+
+	exportFlags := stateExportCmd.Flags()
+	exportFlags.StringVarP(&stateChannelID, "channelID", "c", "", "Channel whose state is exported.")
+	exportFlags.StringVarP(&stateNamespace, "namespace", "n", "", "Namespace, i.e. the chaincode name, whose state is exported.")
+	exportFlags.StringVarP(&stateFile, "output", "o", "", "File to which the state is written. The state is written to the standard output if not supplied.")
+
+	importFlags := stateImportCmd.Flags()
+	importFlags.StringVarP(&stateChannelID, "channelID", "c", "", "Channel into whose state the records are imported.")
+	importFlags.StringVarP(&stateNamespace, "namespace", "n", "", "Namespace, i.e. the chaincode name, into whose state the records are imported.")
+	importFlags.StringVarP(&stateFile, "file", "f", "", "File that contains the records to import, as written by the export command.")
+	return nodeStateCmd
+}
+
+var nodeStateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Exports or imports the state of a namespace.",
+	Long: `Exports the world state of a namespace of a channel to a file with one JSON record per line, ` +
+		`or imports such a file into the world state of a namespace. The peer must be stopped when executing these commands.`,
+}
+
+var stateExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the state of a namespace.",
+	Long: `Exports the keys of the world state of a namespace of a channel, along with their values, versions and metadata, ` +
+		`with one JSON record per line. The private data collections of the namespace are not exported. ` +
+		`The peer must be stopped when executing this command.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateStateCmdArgs(args); err != nil {
+			return err
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		out := io.Writer(os.Stdout)
+		if stateFile != "" {
+			f, err := os.OpenFile(stateFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+			if err != nil {
+				return errors.Wrapf(err, "error while creating file [%s]", stateFile)
+			}
+			defer f.Close()
+			out = f
+		}
+		initLedgerMgmtForOfflineCmd()
+		defer ledgermgmt.Close()
+		w := bufio.NewWriter(out)
+		process, numRecords := writeStateRecords(w)
+		if err := ledgermgmt.ExportNamespaceState(stateChannelID, stateNamespace, process); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if stateFile != "" {
+			fmt.Printf("Exported [%d] keys of namespace [%s] of channel [%s] to [%s]\n", *numRecords, stateNamespace, stateChannelID, stateFile)
+		}
+		return nil
+	},
+}
+
+var stateImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports records into the state of a namespace.",
+	Long: `Imports the records written by the export command into the world state of a namespace of a channel. ` +
+		`The records retain their versions. Existing keys are overwritten and the keys that are not in the file are left as is. ` +
+		`The records are written to the state database directly rather than committed in a block, hence the import must be ` +
+		`performed on all the peers of the channel in order for them to have the same state. ` +
+		`The imported keys are not recorded in the history of the keys, and the state of the system chaincodes cannot be imported. ` +
+		`The peer must be stopped when executing this command.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateStateCmdArgs(args); err != nil {
+			return err
+		}
+		if stateFile == "" {
+			return errors.New("must supply file")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		f, err := os.Open(stateFile)
+		if err != nil {
+			return errors.Wrapf(err, "error while opening file [%s]", stateFile)
+		}
+		defer f.Close()
+		initLedgerMgmtForOfflineCmd()
+		defer ledgermgmt.Close()
+		numImported, err := ledgermgmt.ImportNamespaceState(stateChannelID, stateNamespace, readStateRecords(bufio.NewReader(f)))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Imported [%d] keys into namespace [%s] of channel [%s]\n", numImported, stateNamespace, stateChannelID)
+		return nil
+	},
+}
+
+func validateStateCmdArgs(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("trailing args detected")
+	}
+	if stateChannelID == "" {
+		return errors.New("must supply channel ID")
+	}
+	if stateNamespace == "" {
+		return errors.New("must supply namespace")
+	}
+	return nil
+}
+
+// writeStateRecords returns a function that writes the given records to the writer, one JSON record
+// per line, along with the count of the records written so far
+func writeStateRecords(w io.Writer) (func(*ledger.StateRecord) error, *uint64) {
+	encoder := json.NewEncoder(w)
+	numRecords := uint64(0)
+	return func(record *ledger.StateRecord) error {
+		err := encoder.Encode(&stateRecord{
+			Key:      record.Key,
+			Value:    record.Value,
+			Version:  stateVersion{BlockNum: record.BlockNum, TxNum: record.TxNum},
+			Metadata: record.Metadata,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error while writing key [%s]", record.Key)
+		}
+		numRecords++
+		return nil
+	}, &numRecords
+}
+
+// readStateRecords returns a function that reads the next JSON record from the reader. The function
+// returns nil when there are no more records
+func readStateRecords(r io.Reader) func() (*ledger.StateRecord, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	numRecords := 0
+	return func() (*ledger.StateRecord, error) {
+		record := &stateRecord{}
+		if err := decoder.Decode(record); err != nil {
+			if err == io.EOF {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "error while reading record [%d]", numRecords+1)
+		}
+		numRecords++
+		return &ledger.StateRecord{
+			Key:      record.Key,
+			Value:    record.Value,
+			BlockNum: record.Version.BlockNum,
+			TxNum:    record.Version.TxNum,
+			Metadata: record.Metadata,
+		}, nil
+	}
+}
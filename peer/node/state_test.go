@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateCmdArgsValidation(t *testing.T) {
+	cmd := stateCmd()
+	defer func() {
+		stateChannelID = ""
+		stateNamespace = ""
+		stateFile = ""
+	}()
+
+	cmd.SetArgs([]string{"export", "-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+
+	stateNamespace = ""
+	cmd.SetArgs([]string{"export", "-c", "mychannel"})
+	assert.EqualError(t, cmd.Execute(), "must supply namespace")
+
+	stateChannelID = ""
+	cmd.SetArgs([]string{"import", "-c", "mychannel", "-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "must supply file")
+
+	cmd.SetArgs([]string{"import", "-c", "mychannel", "-n", "mycc", "-f", "state.json", "extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected")
+}
+
+func TestWriteAndReadStateRecords(t *testing.T) {
+	records := []*ledger.StateRecord{
+		{Key: "key1", Value: []byte("value1"), BlockNum: 1, TxNum: 2},
+		{Key: "key2", Value: []byte{0x00, 0xff}, BlockNum: 3, Metadata: map[string][]byte{"entry1": []byte("metadata1")}},
+	}
+	buf := &bytes.Buffer{}
+	process, numRecords := writeStateRecords(buf)
+	for _, record := range records {
+		require.NoError(t, process(record))
+	}
+	assert.Equal(t, uint64(2), *numRecords)
+	assert.Equal(t,
+		`{"key":"key1","value":"dmFsdWUx","version":{"block_num":1,"tx_num":2}}`+"\n"+
+			`{"key":"key2","value":"AP8=","version":{"block_num":3,"tx_num":0},"metadata":{"entry1":"bWV0YWRhdGEx"}}`+"\n",
+		buf.String(),
+	)
+
+	next := readStateRecords(buf)
+	for _, record := range records {
+		readRecord, err := next()
+		require.NoError(t, err)
+		assert.Equal(t, record, readRecord)
+	}
+	readRecord, err := next()
+	assert.NoError(t, err)
+	assert.Nil(t, readRecord)
+}
+
+func TestReadStateRecordsErrors(t *testing.T) {
+	next := readStateRecords(strings.NewReader(`{"key":"key1","value":"dmFsdWUx"}` + "\n" + `{"key":"key2","value":"not base64"}`))
+	_, err := next()
+	assert.NoError(t, err)
+	_, err = next()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while reading record [2]")
+	assert.Contains(t, err.Error(), "illegal base64 data")
+
+	next = readStateRecords(strings.NewReader(`{"key":"key1","val":"dmFsdWUx"}`))
+	_, err = next()
+	assert.EqualError(t, err, `error while reading record [1]: json: unknown field "val"`)
+}
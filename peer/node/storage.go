@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+)
+
+// transientStoreName is the name under which the disk usage of the transient store of a channel is reported
+const transientStoreName = "transient"
+
+// storageMaintainer implements the interface httpadmin.StorageMaintainer over the stores of the ledgers
+// and the transient stores of the channels
+type storageMaintainer struct {
+	transientStores leveldbhelper.StoreMaintainer
+}
+
+func (m *storageMaintainer) Channels() ([]string, error) {
+	return ledgermgmt.GetLedgerIDs()
+}
+
+func (m *storageMaintainer) DiskUsage(channel string) (map[string]uint64, error) {
+	usage, err := ledgermgmt.GetStoreDiskUsage(channel)
+	if err != nil {
+		return nil, err
+	}
+	transientUsage, err := m.transientStores.DiskUsage(channel)
+	if err != nil {
+		return nil, err
+	}
+	usage[transientStoreName] = transientUsage
+	return usage, nil
+}
+
+func (m *storageMaintainer) Compact(channel string, store string) error {
+	if store == transientStoreName {
+		return m.transientStores.Compact(channel)
+	}
+	return ledgermgmt.CompactStore(channel, store)
+}
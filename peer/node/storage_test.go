@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubTransientStores struct {
+	compacted []string
+}
+
+func (s *stubTransientStores) DiskUsage(channel string) (uint64, error) {
+	return 42, nil
+}
+
+func (s *stubTransientStores) Compact(channel string) error {
+	s.compacted = append(s.compacted, channel)
+	return nil
+}
+
+func TestStorageMaintainer(t *testing.T) {
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+	_, gb := testutil.NewBlockGenerator(t, "testchannel", false)
+	l, err := ledgermgmt.CreateLedger(gb)
+	require.NoError(t, err)
+	defer l.Close()
+
+	transientStores := &stubTransientStores{}
+	maintainer := &storageMaintainer{transientStores: transientStores}
+	channels, err := maintainer.Channels()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"testchannel"}, channels)
+
+	require.NoError(t, maintainer.Compact("testchannel", ledger.BlockIndexStore))
+	require.NoError(t, maintainer.Compact("testchannel", transientStoreName))
+	assert.Equal(t, []string{"testchannel"}, transientStores.compacted)
+	usage, err := maintainer.DiskUsage("testchannel")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), usage[transientStoreName])
+	assert.True(t, usage[ledger.BlockIndexStore] > 0)
+	assert.Contains(t, usage, ledger.StateStore)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var unjoinChannelID string
+
+func unjoinCmd() *cobra.Command {
+	flags := nodeUnjoinCmd.Flags()
+	flags.StringVarP(&unjoinChannelID, "channelID", "c", "", "Channel to unjoin.")
+	return nodeUnjoinCmd
+}
+
+var nodeUnjoinCmd = &cobra.Command{
+	Use:   "unjoin",
+	Short: "Unjoins the peer from a channel.",
+	Long: `Removes the ledger of a channel and all the data of the channel (the block store, the private data store, ` +
+		`the state database, the history database, the bookkeeping, and the transient store) from the peer. ` +
+		`The block files of the channel that have been archived to the cold storage are not removed. The peer must be ` +
+		`stopped when executing this command. A running peer is unjoined from a channel with 'peer channel unjoin'.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		if unjoinChannelID == "" {
+			return errors.New("must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		initLedgerMgmtForOfflineCmd()
+		defer ledgermgmt.Close()
+		// the transient store is dropped first, so that the unjoin can be executed again if the removal of the ledger fails
+		if err := dropTransientStore(unjoinChannelID); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error while dropping the transient store for channel [%s]", unjoinChannelID))
+		}
+		if err := ledgermgmt.RemoveLedger(unjoinChannelID); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error while removing the ledger for channel [%s]", unjoinChannelID))
+		}
+		fmt.Printf("Unjoined the peer from channel [%s]\n", unjoinChannelID)
+		return nil
+	},
+}
+
+func dropTransientStore(channelID string) error {
+	provider := transientstore.NewStoreProvider()
+	defer provider.Close()
+	return provider.Drop(channelID)
+}
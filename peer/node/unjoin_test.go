@@ -0,0 +1,24 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnjoinCmdArgsValidation(t *testing.T) {
+	cmd := unjoinCmd()
+	defer func() { unjoinChannelID = "" }()
+
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+
+	cmd.SetArgs([]string{"-c", "mychannel", "extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected")
+}
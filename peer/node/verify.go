@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var verifyChannelID string
+
+func verifyLedgerCmd() *cobra.Command {
+	flags := nodeVerifyLedgerCmd.Flags()
+	flags.StringVarP(&verifyChannelID, "channelID", "c", "", "Channel whose ledger is verified. The ledgers of all the channels are verified, if not specified.")
+	return nodeVerifyLedgerCmd
+}
+
+var nodeVerifyLedgerCmd = &cobra.Command{
+	Use:   "verify-ledger",
+	Short: "Verifies the integrity of the channel ledgers.",
+	Long: `Verifies the hash chain and the data hashes of the blocks in the block files, cross-checks the block index ` +
+		`with the block files, and checks that the state database and the history database are not ahead of the block ` +
+		`store. The detected corruptions are reported with the block file and the offset. A database that is behind the ` +
+		`block store is reported as a warning, as it is brought up to date when the peer is started. The peer must be ` +
+		`stopped when executing this command.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		initLedgerMgmtForOfflineCmd()
+		defer ledgermgmt.Close()
+		return verifyLedgers(verifyChannelID)
+	},
+}
+
+func verifyLedgers(channelID string) error {
+	ledgerIDs := []string{channelID}
+	if channelID == "" {
+		var err error
+		if ledgerIDs, err = ledgermgmt.GetLedgerIDs(); err != nil {
+			return err
+		}
+	}
+	var corruptedLedgerIDs []string
+	for _, ledgerID := range ledgerIDs {
+		report, err := ledgermgmt.VerifyLedger(ledgerID)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("error while verifying the ledger for channel [%s]", ledgerID))
+		}
+		fmt.Printf("Channel [%s]: block store height [%d], state database height [%d], history database height [%d]\n",
+			ledgerID, report.BlockStoreHeight, report.StateDBHeight, report.HistoryDBHeight)
+		for _, corruption := range report.Corruptions {
+			fmt.Printf("  corruption: %s\n", corruption)
+		}
+		for _, warning := range report.Warnings {
+			fmt.Printf("  warning: %s\n", warning)
+		}
+		if len(report.Corruptions) != 0 {
+			corruptedLedgerIDs = append(corruptedLedgerIDs, ledgerID)
+		}
+	}
+	if len(corruptedLedgerIDs) != 0 {
+		return errors.Errorf("corruptions detected in the ledgers for channels %v", corruptedLedgerIDs)
+	}
+	fmt.Printf("Verified %d ledger(s), no corruption detected\n", len(ledgerIDs))
+	return nil
+}
@@ -0,0 +1,21 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyLedgerCmdArgsValidation(t *testing.T) {
+	cmd := verifyLedgerCmd()
+	defer func() { verifyChannelID = "" }()
+
+	cmd.SetArgs([]string{"-c", "mychannel", "extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected")
+}
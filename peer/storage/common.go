@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/httpadmin"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// StorageCmdFactory holds the client used by StorageCmd to reach the operations service of the peer
+type StorageCmdFactory struct {
+	Client *http.Client
+	// StorageURL is the URL of the storage endpoint of the operations service
+	StorageURL string
+}
+
+// InitCmdFactory init the StorageCmdFactory with a client for the operations service
+// configured in core.yaml, as overridden by the command line flags
+func InitCmdFactory() (*StorageCmdFactory, error) {
+	address := operationsAddress
+	if address == "" {
+		address = viper.GetString("operations.listenAddress")
+	}
+	if address == "" {
+		return nil, errors.New("must supply the address of the operations service")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	scheme := "http"
+	if viper.GetBool("operations.tls.enabled") {
+		scheme = "https"
+		tlsConfig, err := clientTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	}
+
+	return &StorageCmdFactory{
+		Client:     client,
+		StorageURL: fmt.Sprintf("%s://%s/storage", scheme, address),
+	}, nil
+}
+
+func clientTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if caFile != "" {
+		caPEM, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read CA certificate [%s]", caFile)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no CA certificate found in [%s]", caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// readResponse decodes the body of the response into the payload if the status code is the expected
+// one, and otherwise returns the error reported by the operations service
+func readResponse(resp *http.Response, expectedCode int, payload interface{}) error {
+	defer resp.Body.Close()
+	if resp.StatusCode != expectedCode {
+		errResp := &httpadmin.ErrorResponse{}
+		if err := json.NewDecoder(resp.Body).Decode(errResp); err != nil || errResp.Error == "" {
+			return errors.Errorf("operations service returned status [%s]", resp.Status)
+		}
+		return errors.Errorf("operations service returned status [%s]: %s", resp.Status, errResp.Error)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(payload); err != nil {
+		return errors.Wrap(err, "failed to decode the response of the operations service")
+	}
+	return nil
+}
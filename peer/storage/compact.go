@@ -0,0 +1,70 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/core/ledger/httpadmin"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func compactCmd(cf *StorageCmdFactory) *cobra.Command {
+	var storageCompactCmd = &cobra.Command{
+		Use:   "compact",
+		Short: "Starts the compaction of a store of a channel.",
+		Long: `Starts the compaction of a store (state, history, index, pvtdata, or transient) of a channel while the peer keeps running. ` +
+			`The compaction runs in the background and is reported as in progress by the usage command until it completes.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return compact(cf, cmd, args)
+		},
+	}
+	flags := storageCompactCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "C", "", "The channel whose store is compacted")
+	flags.StringVarP(&storeName, "store", "", "", "The store to compact: state, history, index, pvtdata, or transient")
+	addOperationsFlags(storageCompactCmd)
+	return storageCompactCmd
+}
+
+func compact(cf *StorageCmdFactory, cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		return errors.Errorf("trailing args detected")
+	}
+	if channelID == "" {
+		return errors.New("must supply channel ID")
+	}
+	if storeName == "" {
+		return errors.New("must supply store")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+	reqBytes, err := json.Marshal(&httpadmin.CompactRequest{Channel: channelID, Store: storeName})
+	if err != nil {
+		return err
+	}
+	resp, err := cf.Client.Post(cf.StorageURL, "application/json", bytes.NewReader(reqBytes))
+	if err != nil {
+		return errors.Wrap(err, "failed to reach the operations service")
+	}
+	if err := readResponse(resp, http.StatusAccepted, &httpadmin.CompactRequest{}); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Started the compaction of store [%s] of channel [%s]\n", storeName, channelID)
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	storageFuncName = "storage"
+	storageCmdDes   = "Report the disk usage of, and compact, the stores of the channels of a running peer: usage|compact."
+)
+
+var (
+	channelID         string
+	storeName         string
+	operationsAddress string
+	caFile            string
+	certFile          string
+	keyFile           string
+)
+
+// Cmd returns the cobra command for Storage
+func Cmd(cf *StorageCmdFactory) *cobra.Command {
+	storageCmd.AddCommand(usageCmd(cf))
+	storageCmd.AddCommand(compactCmd(cf))
+
+	return storageCmd
+}
+
+var storageCmd = &cobra.Command{
+	Use:              storageFuncName,
+	Short:            fmt.Sprint(storageCmdDes),
+	Long:             fmt.Sprint(storageCmdDes),
+	PersistentPreRun: common.InitCmd,
+}
+
+func addOperationsFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVarP(&operationsAddress, "operationsAddress", "", "", "The address of the operations service of the peer. Defaults to operations.listenAddress of core.yaml")
+	flags.StringVarP(&caFile, "cafile", "", "", "Path to the PEM encoded CA certificate of the TLS certificate of the operations service, used when operations.tls.enabled is true")
+	flags.StringVarP(&certFile, "certfile", "", "", "Path to the PEM encoded client certificate, used when the operations service requires client authentication")
+	flags.StringVarP(&keyFile, "keyfile", "", "", "Path to the PEM encoded private key of the client certificate")
+}
@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/httpadmin"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubMaintainer struct {
+	mutex     sync.Mutex
+	compacted []httpadmin.CompactRequest
+}
+
+func (m *stubMaintainer) Channels() ([]string, error) {
+	return []string{"ch1", "ch2"}, nil
+}
+
+func (m *stubMaintainer) DiskUsage(channel string) (map[string]uint64, error) {
+	if channel == "ch1" {
+		return map[string]uint64{"state": 100, "history": 50, "transient": 0}, nil
+	}
+	return map[string]uint64{"state": 10, "history": 0, "transient": 0}, nil
+}
+
+func (m *stubMaintainer) Compact(channel string, store string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.compacted = append(m.compacted, httpadmin.CompactRequest{Channel: channel, Store: store})
+	return nil
+}
+
+func newTestCmdFactory(t *testing.T) (*StorageCmdFactory, *stubMaintainer, func()) {
+	maintainer := &stubMaintainer{}
+	server := httptest.NewServer(httpadmin.NewStorageHandler(maintainer))
+	cf := &StorageCmdFactory{
+		Client:     server.Client(),
+		StorageURL: server.URL + "/storage",
+	}
+	return cf, maintainer, server.Close
+}
+
+func resetFlags() {
+	channelID = ""
+	storeName = ""
+	operationsAddress = ""
+	caFile = ""
+	certFile = ""
+	keyFile = ""
+}
+
+func execute(cmd *cobra.Command, args ...string) (string, error) {
+	resetFlags()
+	out := &bytes.Buffer{}
+	cmd.SetOutput(out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestUsage(t *testing.T) {
+	cf, _, cleanup := newTestCmdFactory(t)
+	defer cleanup()
+
+	out, err := execute(usageCmd(cf))
+	require.NoError(t, err)
+	assert.Equal(t, "Channel [ch1]: 150 bytes\n"+
+		"  history: 50 bytes\n"+
+		"  state: 100 bytes\n"+
+		"  transient: 0 bytes\n"+
+		"Channel [ch2]: 10 bytes\n"+
+		"  history: 0 bytes\n"+
+		"  state: 10 bytes\n"+
+		"  transient: 0 bytes\n", out)
+
+	out, err = execute(usageCmd(cf), "-C", "ch2")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Channel [ch2]: 10 bytes\n")
+	assert.NotContains(t, out, "ch1")
+
+	_, err = execute(usageCmd(cf), "-C", "ch3")
+	assert.EqualError(t, err, "operations service returned status [404 Not Found]: channel [ch3] not found")
+
+	_, err = execute(usageCmd(cf), "extra")
+	assert.EqualError(t, err, "trailing args detected")
+}
+
+func TestCompact(t *testing.T) {
+	cf, maintainer, cleanup := newTestCmdFactory(t)
+	defer cleanup()
+
+	out, err := execute(compactCmd(cf), "-C", "ch1", "--store", "state")
+	require.NoError(t, err)
+	assert.Equal(t, "Started the compaction of store [state] of channel [ch1]\n", out)
+	compacted := func() []httpadmin.CompactRequest {
+		maintainer.mutex.Lock()
+		defer maintainer.mutex.Unlock()
+		return maintainer.compacted
+	}
+	for deadline := time.Now().Add(5 * time.Second); len(compacted()) == 0 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, []httpadmin.CompactRequest{{Channel: "ch1", Store: "state"}}, compacted())
+
+	_, err = execute(compactCmd(cf), "-C", "ch1", "--store", "blocks")
+	assert.EqualError(t, err, "operations service returned status [400 Bad Request]: store [blocks] not found, the stores are [history state transient]")
+
+	_, err = execute(compactCmd(cf), "--store", "state")
+	assert.EqualError(t, err, "must supply channel ID")
+	_, err = execute(compactCmd(cf), "-C", "ch1")
+	assert.EqualError(t, err, "must supply store")
+}
+
+func TestInitCmdFactory(t *testing.T) {
+	defer viper.Reset()
+	resetFlags()
+
+	viper.Set("operations.listenAddress", "127.0.0.1:9443")
+	cf, err := InitCmdFactory()
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:9443/storage", cf.StorageURL)
+
+	operationsAddress = "peer0:9443"
+	viper.Set("operations.tls.enabled", true)
+	cf, err = InitCmdFactory()
+	require.NoError(t, err)
+	assert.Equal(t, "https://peer0:9443/storage", cf.StorageURL)
+
+	caFile = "non-existing-file"
+	_, err = InitCmdFactory()
+	assert.Contains(t, err.Error(), "failed to read CA certificate [non-existing-file]")
+
+	resetFlags()
+	viper.Set("operations.listenAddress", "")
+	_, err = InitCmdFactory()
+	assert.EqualError(t, err, "must supply the address of the operations service")
+}
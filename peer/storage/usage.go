@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/ledger/httpadmin"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func usageCmd(cf *StorageCmdFactory) *cobra.Command {
+	var storageUsageCmd = &cobra.Command{
+		Use:   "usage",
+		Short: "Reports the disk usage of the stores of the channels.",
+		Long:  `Reports the approximate disk usage of the state, history, block index, pvt data, and transient stores of a channel, or of all the channels if no channel is provided.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return usage(cf, cmd, args)
+		},
+	}
+	storageUsageCmd.Flags().StringVarP(&channelID, "channelID", "C", "", "The channel whose disk usage is reported. All the channels are reported if not provided")
+	addOperationsFlags(storageUsageCmd)
+	return storageUsageCmd
+}
+
+func usage(cf *StorageCmdFactory, cmd *cobra.Command, args []string) error {
+	if len(args) != 0 {
+		return errors.Errorf("trailing args detected")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+	target := cf.StorageURL
+	if channelID != "" {
+		target += "?channel=" + url.QueryEscape(channelID)
+	}
+	resp, err := cf.Client.Get(target)
+	if err != nil {
+		return errors.Wrap(err, "failed to reach the operations service")
+	}
+	diskUsage := &httpadmin.DiskUsage{}
+	if err := readResponse(resp, http.StatusOK, diskUsage); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	for _, channelUsage := range diskUsage.Channels {
+		fmt.Fprintf(out, "Channel [%s]: %d bytes\n", channelUsage.Channel, channelUsage.Total)
+		var stores []string
+		for store := range channelUsage.Stores {
+			stores = append(stores, store)
+		}
+		sort.Strings(stores)
+		for _, store := range stores {
+			fmt.Fprintf(out, "  %s: %d bytes%s\n", store, channelUsage.Stores[store], compactionStatus(channelUsage, store))
+		}
+	}
+	return nil
+}
+
+func compactionStatus(channelUsage *httpadmin.ChannelDiskUsage, store string) string {
+	for _, s := range channelUsage.Compacting {
+		if s == store {
+			return " (compacting)"
+		}
+	}
+	return ""
+}
@@ -51,3 +51,17 @@ func (ms *QueryReadsMerkleSummary) Equal(anotherMS *QueryReadsMerkleSummary) boo
 	}
 	return true
 }
+
+// SetRawReads sets the 'readsInfo' field to raw KVReads performed by the query
+func (iqi *IndexQueryInfo) SetRawReads(kvReads []*KVRead) {
+	iqi.ReadsInfo = &IndexQueryInfo_RawReads{
+		RawReads: &QueryReads{
+			KvReads: kvReads,
+		},
+	}
+}
+
+// SetMerkelSummary sets the 'readsInfo' field to merkle summary of the raw KVReads of query results
+func (iqi *IndexQueryInfo) SetMerkelSummary(merkleSummary *QueryReadsMerkleSummary) {
+	iqi.ReadsInfo = &IndexQueryInfo_ReadsMerkleHashes{merkleSummary}
+}
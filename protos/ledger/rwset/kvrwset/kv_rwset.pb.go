@@ -25,6 +25,8 @@ type KVRWSet struct {
 	RangeQueriesInfo     []*RangeQueryInfo  `protobuf:"bytes,2,rep,name=range_queries_info,json=rangeQueriesInfo,proto3" json:"range_queries_info,omitempty"`
 	Writes               []*KVWrite         `protobuf:"bytes,3,rep,name=writes,proto3" json:"writes,omitempty"`
 	MetadataWrites       []*KVMetadataWrite `protobuf:"bytes,4,rep,name=metadata_writes,json=metadataWrites,proto3" json:"metadata_writes,omitempty"`
+	IndexQueriesInfo     []*IndexQueryInfo  `protobuf:"bytes,5,rep,name=index_queries_info,json=indexQueriesInfo,proto3" json:"index_queries_info,omitempty"`
+	RichQueriesInfo      []*RichQueryInfo   `protobuf:"bytes,6,rep,name=rich_queries_info,json=richQueriesInfo,proto3" json:"rich_queries_info,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -34,7 +36,7 @@ func (m *KVRWSet) Reset()         { *m = KVRWSet{} }
 func (m *KVRWSet) String() string { return proto.CompactTextString(m) }
 func (*KVRWSet) ProtoMessage()    {}
 func (*KVRWSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{0}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{0}
 }
 func (m *KVRWSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVRWSet.Unmarshal(m, b)
@@ -82,6 +84,20 @@ func (m *KVRWSet) GetMetadataWrites() []*KVMetadataWrite {
 	return nil
 }
 
+func (m *KVRWSet) GetIndexQueriesInfo() []*IndexQueryInfo {
+	if m != nil {
+		return m.IndexQueriesInfo
+	}
+	return nil
+}
+
+func (m *KVRWSet) GetRichQueriesInfo() []*RichQueryInfo {
+	if m != nil {
+		return m.RichQueriesInfo
+	}
+	return nil
+}
+
 // HashedRWSet encapsulates hashed representation of a private read-write set for KV or Document data model
 type HashedRWSet struct {
 	HashedReads          []*KVReadHash          `protobuf:"bytes,1,rep,name=hashed_reads,json=hashedReads,proto3" json:"hashed_reads,omitempty"`
@@ -96,7 +112,7 @@ func (m *HashedRWSet) Reset()         { *m = HashedRWSet{} }
 func (m *HashedRWSet) String() string { return proto.CompactTextString(m) }
 func (*HashedRWSet) ProtoMessage()    {}
 func (*HashedRWSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{1}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{1}
 }
 func (m *HashedRWSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HashedRWSet.Unmarshal(m, b)
@@ -151,7 +167,7 @@ func (m *KVRead) Reset()         { *m = KVRead{} }
 func (m *KVRead) String() string { return proto.CompactTextString(m) }
 func (*KVRead) ProtoMessage()    {}
 func (*KVRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{2}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{2}
 }
 func (m *KVRead) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVRead.Unmarshal(m, b)
@@ -199,7 +215,7 @@ func (m *KVWrite) Reset()         { *m = KVWrite{} }
 func (m *KVWrite) String() string { return proto.CompactTextString(m) }
 func (*KVWrite) ProtoMessage()    {}
 func (*KVWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{3}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{3}
 }
 func (m *KVWrite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVWrite.Unmarshal(m, b)
@@ -253,7 +269,7 @@ func (m *KVMetadataWrite) Reset()         { *m = KVMetadataWrite{} }
 func (m *KVMetadataWrite) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataWrite) ProtoMessage()    {}
 func (*KVMetadataWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{4}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{4}
 }
 func (m *KVMetadataWrite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataWrite.Unmarshal(m, b)
@@ -302,7 +318,7 @@ func (m *KVReadHash) Reset()         { *m = KVReadHash{} }
 func (m *KVReadHash) String() string { return proto.CompactTextString(m) }
 func (*KVReadHash) ProtoMessage()    {}
 func (*KVReadHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{5}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{5}
 }
 func (m *KVReadHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVReadHash.Unmarshal(m, b)
@@ -350,7 +366,7 @@ func (m *KVWriteHash) Reset()         { *m = KVWriteHash{} }
 func (m *KVWriteHash) String() string { return proto.CompactTextString(m) }
 func (*KVWriteHash) ProtoMessage()    {}
 func (*KVWriteHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{6}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{6}
 }
 func (m *KVWriteHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVWriteHash.Unmarshal(m, b)
@@ -404,7 +420,7 @@ func (m *KVMetadataWriteHash) Reset()         { *m = KVMetadataWriteHash{} }
 func (m *KVMetadataWriteHash) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataWriteHash) ProtoMessage()    {}
 func (*KVMetadataWriteHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{7}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{7}
 }
 func (m *KVMetadataWriteHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataWriteHash.Unmarshal(m, b)
@@ -451,7 +467,7 @@ func (m *KVMetadataEntry) Reset()         { *m = KVMetadataEntry{} }
 func (m *KVMetadataEntry) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataEntry) ProtoMessage()    {}
 func (*KVMetadataEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{8}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{8}
 }
 func (m *KVMetadataEntry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataEntry.Unmarshal(m, b)
@@ -501,7 +517,7 @@ func (m *Version) Reset()         { *m = Version{} }
 func (m *Version) String() string { return proto.CompactTextString(m) }
 func (*Version) ProtoMessage()    {}
 func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{9}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{9}
 }
 func (m *Version) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Version.Unmarshal(m, b)
@@ -558,7 +574,7 @@ func (m *RangeQueryInfo) Reset()         { *m = RangeQueryInfo{} }
 func (m *RangeQueryInfo) String() string { return proto.CompactTextString(m) }
 func (*RangeQueryInfo) ProtoMessage()    {}
 func (*RangeQueryInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{10}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{10}
 }
 func (m *RangeQueryInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RangeQueryInfo.Unmarshal(m, b)
@@ -710,6 +726,238 @@ func _RangeQueryInfo_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// IndexQueryInfo encapsulates the details of a query performed by a transaction during simulation on a
+// secondary index of the state database. Similar to the RangeQueryInfo, this helps protect transactions
+// from phantom reads. The start_key and the end_key delimit the range of the keys of the index entries
+// that were scanned. The end_key is the key of the last entry returned if the iterator was not exhausted,
+// in which case the entry itself is a part of the range. An empty end_key refers to the end of the index
+type IndexQueryInfo struct {
+	IndexName    string `protobuf:"bytes,1,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	StartKey     []byte `protobuf:"bytes,2,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey       []byte `protobuf:"bytes,3,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	ItrExhausted bool   `protobuf:"varint,4,opt,name=itr_exhausted,json=itrExhausted,proto3" json:"itr_exhausted,omitempty"`
+	// Types that are valid to be assigned to ReadsInfo:
+	//	*IndexQueryInfo_RawReads
+	//	*IndexQueryInfo_ReadsMerkleHashes
+	ReadsInfo            isIndexQueryInfo_ReadsInfo `protobuf_oneof:"reads_info"`
+	Fields               []string                   `protobuf:"bytes,7,rep,name=fields,proto3" json:"fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *IndexQueryInfo) Reset()         { *m = IndexQueryInfo{} }
+func (m *IndexQueryInfo) String() string { return proto.CompactTextString(m) }
+func (*IndexQueryInfo) ProtoMessage()    {}
+func (*IndexQueryInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{11}
+}
+func (m *IndexQueryInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IndexQueryInfo.Unmarshal(m, b)
+}
+func (m *IndexQueryInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IndexQueryInfo.Marshal(b, m, deterministic)
+}
+func (dst *IndexQueryInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexQueryInfo.Merge(dst, src)
+}
+func (m *IndexQueryInfo) XXX_Size() int {
+	return xxx_messageInfo_IndexQueryInfo.Size(m)
+}
+func (m *IndexQueryInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexQueryInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IndexQueryInfo proto.InternalMessageInfo
+
+func (m *IndexQueryInfo) GetIndexName() string {
+	if m != nil {
+		return m.IndexName
+	}
+	return ""
+}
+
+func (m *IndexQueryInfo) GetStartKey() []byte {
+	if m != nil {
+		return m.StartKey
+	}
+	return nil
+}
+
+func (m *IndexQueryInfo) GetEndKey() []byte {
+	if m != nil {
+		return m.EndKey
+	}
+	return nil
+}
+
+func (m *IndexQueryInfo) GetItrExhausted() bool {
+	if m != nil {
+		return m.ItrExhausted
+	}
+	return false
+}
+
+type isIndexQueryInfo_ReadsInfo interface {
+	isIndexQueryInfo_ReadsInfo()
+}
+
+type IndexQueryInfo_RawReads struct {
+	RawReads *QueryReads `protobuf:"bytes,5,opt,name=raw_reads,json=rawReads,proto3,oneof"`
+}
+
+type IndexQueryInfo_ReadsMerkleHashes struct {
+	ReadsMerkleHashes *QueryReadsMerkleSummary `protobuf:"bytes,6,opt,name=reads_merkle_hashes,json=readsMerkleHashes,proto3,oneof"`
+}
+
+func (*IndexQueryInfo_RawReads) isIndexQueryInfo_ReadsInfo() {}
+
+func (*IndexQueryInfo_ReadsMerkleHashes) isIndexQueryInfo_ReadsInfo() {}
+
+func (m *IndexQueryInfo) GetReadsInfo() isIndexQueryInfo_ReadsInfo {
+	if m != nil {
+		return m.ReadsInfo
+	}
+	return nil
+}
+
+func (m *IndexQueryInfo) GetRawReads() *QueryReads {
+	if x, ok := m.GetReadsInfo().(*IndexQueryInfo_RawReads); ok {
+		return x.RawReads
+	}
+	return nil
+}
+
+func (m *IndexQueryInfo) GetReadsMerkleHashes() *QueryReadsMerkleSummary {
+	if x, ok := m.GetReadsInfo().(*IndexQueryInfo_ReadsMerkleHashes); ok {
+		return x.ReadsMerkleHashes
+	}
+	return nil
+}
+
+func (m *IndexQueryInfo) GetFields() []string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*IndexQueryInfo) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _IndexQueryInfo_OneofMarshaler, _IndexQueryInfo_OneofUnmarshaler, _IndexQueryInfo_OneofSizer, []interface{}{
+		(*IndexQueryInfo_RawReads)(nil),
+		(*IndexQueryInfo_ReadsMerkleHashes)(nil),
+	}
+}
+
+func _IndexQueryInfo_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*IndexQueryInfo)
+	// reads_info
+	switch x := m.ReadsInfo.(type) {
+	case *IndexQueryInfo_RawReads:
+		b.EncodeVarint(5<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RawReads); err != nil {
+			return err
+		}
+	case *IndexQueryInfo_ReadsMerkleHashes:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ReadsMerkleHashes); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("IndexQueryInfo.ReadsInfo has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _IndexQueryInfo_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*IndexQueryInfo)
+	switch tag {
+	case 5: // reads_info.raw_reads
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(QueryReads)
+		err := b.DecodeMessage(msg)
+		m.ReadsInfo = &IndexQueryInfo_RawReads{msg}
+		return true, err
+	case 6: // reads_info.reads_merkle_hashes
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(QueryReadsMerkleSummary)
+		err := b.DecodeMessage(msg)
+		m.ReadsInfo = &IndexQueryInfo_ReadsMerkleHashes{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _IndexQueryInfo_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*IndexQueryInfo)
+	// reads_info
+	switch x := m.ReadsInfo.(type) {
+	case *IndexQueryInfo_RawReads:
+		s := proto.Size(x.RawReads)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *IndexQueryInfo_ReadsMerkleHashes:
+		s := proto.Size(x.ReadsMerkleHashes)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+// RichQueryInfo encapsulates the details of a rich query performed by a transaction during simulation.
+// It is recorded only if the phantom read protection for rich queries is enabled on the endorsing peer.
+// The results of the query are recorded as reads in the read set. During validation, the transaction is
+// invalidated if a preceding transaction in the block writes a state that matches the query
+type RichQueryInfo struct {
+	Query                string   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RichQueryInfo) Reset()         { *m = RichQueryInfo{} }
+func (m *RichQueryInfo) String() string { return proto.CompactTextString(m) }
+func (*RichQueryInfo) ProtoMessage()    {}
+func (*RichQueryInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{12}
+}
+func (m *RichQueryInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RichQueryInfo.Unmarshal(m, b)
+}
+func (m *RichQueryInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RichQueryInfo.Marshal(b, m, deterministic)
+}
+func (dst *RichQueryInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RichQueryInfo.Merge(dst, src)
+}
+func (m *RichQueryInfo) XXX_Size() int {
+	return xxx_messageInfo_RichQueryInfo.Size(m)
+}
+func (m *RichQueryInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_RichQueryInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RichQueryInfo proto.InternalMessageInfo
+
+func (m *RichQueryInfo) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
 // QueryReads encapsulates the KVReads for the items read by a transaction as a result of a query execution
 type QueryReads struct {
 	KvReads              []*KVRead `protobuf:"bytes,1,rep,name=kv_reads,json=kvReads,proto3" json:"kv_reads,omitempty"`
@@ -722,7 +970,7 @@ func (m *QueryReads) Reset()         { *m = QueryReads{} }
 func (m *QueryReads) String() string { return proto.CompactTextString(m) }
 func (*QueryReads) ProtoMessage()    {}
 func (*QueryReads) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{11}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{13}
 }
 func (m *QueryReads) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryReads.Unmarshal(m, b)
@@ -767,7 +1015,7 @@ func (m *QueryReadsMerkleSummary) Reset()         { *m = QueryReadsMerkleSummary
 func (m *QueryReadsMerkleSummary) String() string { return proto.CompactTextString(m) }
 func (*QueryReadsMerkleSummary) ProtoMessage()    {}
 func (*QueryReadsMerkleSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{12}
+	return fileDescriptor_kv_rwset_62b9211ce139df42, []int{14}
 }
 func (m *QueryReadsMerkleSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryReadsMerkleSummary.Unmarshal(m, b)
@@ -820,61 +1068,70 @@ func init() {
 	proto.RegisterType((*KVMetadataEntry)(nil), "kvrwset.KVMetadataEntry")
 	proto.RegisterType((*Version)(nil), "kvrwset.Version")
 	proto.RegisterType((*RangeQueryInfo)(nil), "kvrwset.RangeQueryInfo")
+	proto.RegisterType((*IndexQueryInfo)(nil), "kvrwset.IndexQueryInfo")
+	proto.RegisterType((*RichQueryInfo)(nil), "kvrwset.RichQueryInfo")
 	proto.RegisterType((*QueryReads)(nil), "kvrwset.QueryReads")
 	proto.RegisterType((*QueryReadsMerkleSummary)(nil), "kvrwset.QueryReadsMerkleSummary")
 }
 
 func init() {
-	proto.RegisterFile("ledger/rwset/kvrwset/kv_rwset.proto", fileDescriptor_kv_rwset_b744a14a894993b5)
-}
-
-var fileDescriptor_kv_rwset_b744a14a894993b5 = []byte{
-	// 740 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdf, 0x6b, 0xdb, 0x48,
-	0x10, 0x8e, 0x7f, 0xcb, 0x63, 0x3b, 0xf1, 0x6d, 0x72, 0x44, 0xc7, 0xdd, 0x81, 0x51, 0x38, 0x30,
-	0x79, 0xb0, 0xc1, 0x07, 0xc7, 0x85, 0xe3, 0x1e, 0x5a, 0xe2, 0x92, 0x92, 0x26, 0xd0, 0x0d, 0x24,
-	0xd0, 0x17, 0xb1, 0x8e, 0x26, 0xb6, 0xb0, 0x25, 0xa5, 0xab, 0x95, 0x6d, 0x3d, 0x95, 0xfe, 0x75,
-	0xfd, 0x47, 0xfa, 0x87, 0x94, 0x9d, 0x95, 0x63, 0xc5, 0x75, 0x0c, 0xed, 0x93, 0xb5, 0xf3, 0xcd,
-	0x37, 0x3b, 0xdf, 0x37, 0xde, 0x5d, 0x38, 0x99, 0xa1, 0x37, 0x46, 0xd9, 0x97, 0x8b, 0x18, 0x55,
-	0x7f, 0x3a, 0x5f, 0xfd, 0xba, 0xf4, 0xd1, 0x7b, 0x94, 0x91, 0x8a, 0x58, 0x2d, 0x8b, 0x3b, 0x5f,
-	0x0b, 0x50, 0xbb, 0xbc, 0xe5, 0x77, 0x37, 0xa8, 0xd8, 0x5f, 0x50, 0x91, 0x28, 0xbc, 0xd8, 0x2e,
-	0x74, 0x4a, 0xdd, 0xc6, 0xe0, 0xa0, 0x97, 0x25, 0xf5, 0x2e, 0x6f, 0x39, 0x0a, 0x8f, 0x1b, 0x94,
-	0x0d, 0x81, 0x49, 0x11, 0x8e, 0xd1, 0xfd, 0x98, 0xa0, 0xf4, 0x31, 0x76, 0xfd, 0xf0, 0x21, 0xb2,
-	0x8b, 0xc4, 0x39, 0x7e, 0xe2, 0x70, 0x9d, 0xf2, 0x3e, 0x41, 0x99, 0xbe, 0x0d, 0x1f, 0x22, 0xde,
-	0x96, 0xab, 0xb5, 0x8f, 0xb1, 0x8e, 0xb0, 0x2e, 0x54, 0x17, 0xd2, 0x57, 0x18, 0xdb, 0x25, 0xa2,
-	0xb6, 0x73, 0xdb, 0xdd, 0x69, 0x80, 0x67, 0x38, 0x7b, 0x05, 0x07, 0x01, 0x2a, 0xe1, 0x09, 0x25,
-	0xdc, 0x8c, 0x52, 0x26, 0x8a, 0x9d, 0xa3, 0x5c, 0x65, 0x19, 0x86, 0xba, 0x1f, 0xe4, 0x97, 0xb1,
-	0xf3, 0xa5, 0x00, 0x8d, 0x0b, 0x11, 0x4f, 0xd0, 0x33, 0x52, 0xff, 0x81, 0xe6, 0x84, 0x96, 0x6e,
-	0x5e, 0xf1, 0xe1, 0x86, 0x62, 0xcd, 0xe0, 0x0d, 0x93, 0xc8, 0x49, 0xfb, 0x19, 0xb4, 0x32, 0x5e,
-	0xd6, 0x88, 0x91, 0x7d, 0xb4, 0xd9, 0x3b, 0x31, 0xb3, 0x2d, 0x4c, 0x0b, 0x6c, 0xf8, 0xbd, 0x0a,
-	0x23, 0xfc, 0x8f, 0x97, 0x54, 0x50, 0x91, 0x4d, 0x25, 0x6f, 0xa0, 0x6a, 0x9a, 0x63, 0x6d, 0x28,
-	0x4d, 0x31, 0xb5, 0x0b, 0x9d, 0x42, 0xb7, 0xce, 0xf5, 0x27, 0x3b, 0x85, 0xda, 0x1c, 0x65, 0xec,
-	0x47, 0xa1, 0x5d, 0xec, 0x14, 0x9e, 0x79, 0x7a, 0x6b, 0xe2, 0x7c, 0x95, 0xe0, 0x5c, 0xeb, 0xb9,
-	0x53, 0xcd, 0x2d, 0x85, 0x7e, 0x87, 0xba, 0x1f, 0xbb, 0x1e, 0xce, 0x50, 0x21, 0x95, 0xb2, 0xb8,
-	0xe5, 0xc7, 0xe7, 0xb4, 0x66, 0x47, 0x50, 0x99, 0x8b, 0x59, 0x82, 0x76, 0xa9, 0x53, 0xe8, 0x36,
-	0xb9, 0x59, 0x38, 0x77, 0x70, 0xb0, 0xd1, 0xfe, 0x96, 0xba, 0x03, 0xa8, 0x61, 0xa8, 0xf4, 0x5f,
-	0x20, 0x33, 0x6e, 0xdb, 0x04, 0x87, 0xa1, 0x92, 0x29, 0x5f, 0x25, 0x3a, 0x37, 0x00, 0xeb, 0x69,
-	0xb0, 0xdf, 0xc0, 0x9a, 0x62, 0xea, 0x6a, 0x67, 0xa9, 0x70, 0x93, 0xd7, 0xa6, 0x98, 0x12, 0xf4,
-	0x23, 0xea, 0x3d, 0x68, 0xe4, 0x26, 0xb5, 0xab, 0xea, 0x4e, 0x2b, 0xfe, 0x04, 0x20, 0xf5, 0x86,
-	0x69, 0xfc, 0xa8, 0x53, 0x44, 0x73, 0x1d, 0x0f, 0x0e, 0xb7, 0x8c, 0x74, 0xd7, 0x6e, 0x3f, 0x63,
-	0xd0, 0x7f, 0x79, 0xe7, 0x09, 0x63, 0x0c, 0xca, 0xa1, 0x08, 0x30, 0xb3, 0x9e, 0xbe, 0xd7, 0x63,
-	0x2b, 0xe6, 0xc7, 0xf6, 0x3f, 0xd4, 0x32, 0x73, 0xb4, 0xd2, 0xd1, 0x2c, 0xba, 0x9f, 0xba, 0x61,
-	0x12, 0x10, 0xb3, 0xcc, 0x2d, 0x0a, 0x5c, 0x27, 0x01, 0xfb, 0x15, 0xaa, 0x6a, 0x49, 0x48, 0x91,
-	0x90, 0x8a, 0x5a, 0x5e, 0x27, 0x81, 0xf3, 0xb9, 0x08, 0xfb, 0xcf, 0x4f, 0xba, 0x2e, 0x13, 0x2b,
-	0x21, 0x95, 0xbb, 0x9e, 0xbd, 0x45, 0x81, 0x4b, 0x4c, 0xd9, 0xb1, 0xd6, 0xe7, 0x11, 0x54, 0x24,
-	0xa8, 0x8a, 0xa1, 0xa7, 0x81, 0x13, 0x68, 0xf9, 0x4a, 0xba, 0xb8, 0x9c, 0x88, 0x24, 0x56, 0xe8,
-	0x91, 0x99, 0x16, 0x6f, 0xfa, 0x4a, 0x0e, 0x57, 0x31, 0x36, 0x80, 0xba, 0x14, 0x8b, 0xec, 0xc8,
-	0x96, 0x69, 0xc6, 0xeb, 0x23, 0x4b, 0x1d, 0xd0, 0x29, 0xbd, 0xd8, 0xe3, 0x96, 0x14, 0x0b, 0x73,
-	0x62, 0x39, 0x1c, 0x52, 0xbe, 0x1b, 0xa0, 0x9c, 0xce, 0xcc, 0xa4, 0x30, 0xb6, 0x2b, 0xc4, 0xee,
-	0x6c, 0x61, 0x5f, 0x51, 0xde, 0x4d, 0x12, 0x04, 0x42, 0xa6, 0x17, 0x7b, 0xfc, 0x17, 0xb9, 0x8e,
-	0xd2, 0x15, 0x12, 0xbf, 0x6e, 0x02, 0x98, 0x9a, 0xfa, 0xe6, 0x73, 0xfe, 0x05, 0x58, 0xb3, 0xd9,
-	0x29, 0x58, 0xfa, 0xae, 0xdd, 0x75, 0x8f, 0xd6, 0xa6, 0x73, 0xca, 0x75, 0x3e, 0xc1, 0xf1, 0x0b,
-	0xfb, 0xea, 0x7f, 0x56, 0x20, 0x96, 0xae, 0x87, 0x63, 0x89, 0x66, 0x8e, 0x2d, 0x5e, 0x0f, 0xc4,
-	0xf2, 0x9c, 0x02, 0xda, 0x64, 0x0d, 0xcf, 0x70, 0x8e, 0x33, 0x72, 0xb2, 0xc5, 0xad, 0x40, 0x2c,
-	0xdf, 0xe9, 0x35, 0xeb, 0x42, 0xfb, 0x09, 0x5c, 0xe9, 0xd5, 0x57, 0x4d, 0x93, 0xef, 0xaf, 0x72,
-	0x32, 0x21, 0x11, 0x0c, 0x22, 0x39, 0xee, 0x4d, 0xd2, 0x47, 0x94, 0xe6, 0xd9, 0xe8, 0x3d, 0x88,
-	0x91, 0xf4, 0xef, 0xcd, 0x33, 0x11, 0xf7, 0xb2, 0xa0, 0x69, 0x3f, 0x93, 0xf1, 0xe1, 0x6c, 0xec,
-	0xab, 0x49, 0x32, 0xea, 0xdd, 0x47, 0x41, 0x3f, 0x47, 0xed, 0x1b, 0x6a, 0xdf, 0x50, 0xfb, 0xdb,
-	0x9e, 0xa1, 0x51, 0x95, 0xc0, 0xbf, 0xbf, 0x05, 0x00, 0x00, 0xff, 0xff, 0xe3, 0xe1, 0xb5, 0x07,
-	0xa5, 0x06, 0x00, 0x00,
+	proto.RegisterFile("ledger/rwset/kvrwset/kv_rwset.proto", fileDescriptor_kv_rwset_62b9211ce139df42)
+}
+
+var fileDescriptor_kv_rwset_62b9211ce139df42 = []byte{
+	// 856 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdd, 0x6a, 0xe3, 0x46,
+	0x14, 0x5e, 0xff, 0xcb, 0x27, 0x76, 0x9c, 0x9d, 0x6c, 0x37, 0x2a, 0x6d, 0xc1, 0x68, 0x59, 0x30,
+	0x7b, 0x61, 0x43, 0x0a, 0xa5, 0x4b, 0xe9, 0x45, 0x97, 0x4d, 0xc9, 0x92, 0x6e, 0xa0, 0x13, 0x48,
+	0xa0, 0x37, 0x62, 0x62, 0x9d, 0xd8, 0x83, 0x2d, 0x69, 0x3b, 0x1a, 0x39, 0xf6, 0x55, 0xe9, 0x5b,
+	0xf5, 0x0d, 0xfa, 0x38, 0x7d, 0x85, 0x32, 0x67, 0x46, 0xb1, 0xec, 0x3a, 0x2e, 0x6d, 0xae, 0xac,
+	0xf3, 0xf3, 0x9d, 0x39, 0xf3, 0x7d, 0x47, 0xc7, 0x82, 0x57, 0x73, 0x8c, 0x26, 0xa8, 0x46, 0xea,
+	0x3e, 0x43, 0x3d, 0x9a, 0x2d, 0x8a, 0xdf, 0x90, 0x1e, 0x86, 0x9f, 0x54, 0xaa, 0x53, 0xd6, 0x72,
+	0xfe, 0xe0, 0xaf, 0x2a, 0xb4, 0x2e, 0xae, 0xf9, 0xcd, 0x15, 0x6a, 0xf6, 0x1a, 0x1a, 0x0a, 0x45,
+	0x94, 0xf9, 0x95, 0x7e, 0x6d, 0x70, 0x70, 0xda, 0x1b, 0xba, 0xa4, 0xe1, 0xc5, 0x35, 0x47, 0x11,
+	0x71, 0x1b, 0x65, 0x67, 0xc0, 0x94, 0x48, 0x26, 0x18, 0xfe, 0x9a, 0xa3, 0x92, 0x98, 0x85, 0x32,
+	0xb9, 0x4b, 0xfd, 0x2a, 0x61, 0x4e, 0x1e, 0x30, 0xdc, 0xa4, 0xfc, 0x9c, 0xa3, 0x5a, 0x7d, 0x48,
+	0xee, 0x52, 0x7e, 0xa4, 0x0a, 0x5b, 0x62, 0x66, 0x3c, 0x6c, 0x00, 0xcd, 0x7b, 0x25, 0x35, 0x66,
+	0x7e, 0x8d, 0xa0, 0x47, 0xa5, 0xe3, 0x6e, 0x4c, 0x80, 0xbb, 0x38, 0xfb, 0x01, 0x7a, 0x31, 0x6a,
+	0x11, 0x09, 0x2d, 0x42, 0x07, 0xa9, 0x13, 0xc4, 0x2f, 0x41, 0x3e, 0xba, 0x0c, 0x0b, 0x3d, 0x8c,
+	0xcb, 0x26, 0xf5, 0x2c, 0x93, 0x08, 0x97, 0x9b, 0x3d, 0x37, 0xb6, 0x7a, 0xfe, 0x60, 0x52, 0x4a,
+	0x3d, 0xcb, 0xc2, 0x2e, 0x7a, 0x7e, 0x07, 0xcf, 0x95, 0x1c, 0x4f, 0x37, 0xab, 0x34, 0xa9, 0xca,
+	0xcb, 0xf5, 0xcd, 0xe5, 0x78, 0xba, 0x2e, 0xd2, 0x53, 0xce, 0x74, 0x35, 0x82, 0x3f, 0x2b, 0x70,
+	0x70, 0x2e, 0xb2, 0x29, 0x46, 0x96, 0xf5, 0x6f, 0xa0, 0x33, 0x25, 0x33, 0x2c, 0x93, 0x7f, 0xbc,
+	0x45, 0xbe, 0x41, 0xf0, 0x03, 0x9b, 0xc8, 0x49, 0x86, 0xb7, 0xd0, 0x75, 0x38, 0xc7, 0x89, 0x55,
+	0xe0, 0xc5, 0x36, 0x8d, 0x84, 0x74, 0x47, 0x3c, 0xb0, 0xf1, 0x0f, 0x42, 0xad, 0x06, 0x5f, 0x3e,
+	0x46, 0x28, 0x15, 0xd9, 0x22, 0x35, 0xf8, 0x11, 0x9a, 0xb6, 0x39, 0x76, 0x04, 0xb5, 0x19, 0xae,
+	0xfc, 0x4a, 0xbf, 0x32, 0x68, 0x73, 0xf3, 0xc8, 0xde, 0x40, 0x6b, 0x81, 0x2a, 0x93, 0x69, 0xe2,
+	0x57, 0xfb, 0x95, 0x0d, 0x79, 0xaf, 0xad, 0x9f, 0x17, 0x09, 0xc1, 0xa5, 0x19, 0x41, 0xaa, 0xb9,
+	0xa3, 0xd0, 0x17, 0xd0, 0x96, 0x59, 0x18, 0xe1, 0x1c, 0x35, 0x52, 0x29, 0x8f, 0x7b, 0x32, 0x7b,
+	0x4f, 0x36, 0x7b, 0x01, 0x8d, 0x85, 0x98, 0xe7, 0xe8, 0xd7, 0xfa, 0x95, 0x41, 0x87, 0x5b, 0x23,
+	0xb8, 0x81, 0xde, 0x56, 0xfb, 0x3b, 0xea, 0x9e, 0x42, 0x0b, 0x13, 0xad, 0xe4, 0x03, 0x71, 0xbb,
+	0x86, 0xe9, 0x2c, 0xd1, 0x6a, 0xc5, 0x8b, 0xc4, 0xe0, 0x0a, 0x60, 0xad, 0x06, 0xfb, 0x1c, 0xbc,
+	0x19, 0xae, 0x42, 0xc3, 0x2c, 0x15, 0xee, 0xf0, 0xd6, 0x0c, 0x57, 0x14, 0xfa, 0x2f, 0xb7, 0x8f,
+	0xe0, 0xa0, 0xa4, 0xd4, 0xbe, 0xaa, 0x7b, 0xa9, 0xf8, 0x0a, 0x80, 0x6e, 0x6f, 0x91, 0x96, 0x8f,
+	0x36, 0x79, 0x0c, 0x36, 0x88, 0xe0, 0x78, 0x87, 0xa4, 0xfb, 0x4e, 0xfb, 0x3f, 0x04, 0x7d, 0x07,
+	0xbd, 0xad, 0x18, 0x63, 0x50, 0x4f, 0x44, 0x8c, 0x8e, 0x7a, 0x7a, 0x5e, 0xcb, 0x56, 0x2d, 0xcb,
+	0xf6, 0x3d, 0xb4, 0x1c, 0x39, 0xe6, 0xa6, 0xb7, 0xf3, 0x74, 0x3c, 0x0b, 0x93, 0x3c, 0x26, 0x64,
+	0x9d, 0x7b, 0xe4, 0xb8, 0xcc, 0x63, 0xf6, 0x19, 0x34, 0xf5, 0x92, 0x22, 0x55, 0x8a, 0x34, 0xf4,
+	0xf2, 0x32, 0x8f, 0x83, 0xdf, 0xab, 0x70, 0xb8, 0xb9, 0x74, 0x4c, 0x99, 0x4c, 0x0b, 0xa5, 0xc3,
+	0xb5, 0xf6, 0x1e, 0x39, 0x2e, 0x70, 0xc5, 0x4e, 0xcc, 0xfd, 0x22, 0x0a, 0x55, 0x29, 0xd4, 0xc4,
+	0x24, 0x32, 0x81, 0x57, 0xd0, 0x95, 0x5a, 0x85, 0xb8, 0x9c, 0x8a, 0x3c, 0xd3, 0x18, 0x11, 0x99,
+	0x1e, 0xef, 0x48, 0xad, 0xce, 0x0a, 0x1f, 0x3b, 0x85, 0xb6, 0x12, 0xf7, 0xee, 0x95, 0xad, 0xf7,
+	0x2b, 0x1b, 0xaf, 0x2c, 0x75, 0x40, 0x6f, 0xe9, 0xf9, 0x33, 0xee, 0x29, 0x71, 0x4f, 0xcf, 0x8c,
+	0xc3, 0x31, 0xe5, 0x87, 0x31, 0xaa, 0xd9, 0xdc, 0x2a, 0x85, 0x99, 0xdf, 0x20, 0x74, 0x7f, 0x07,
+	0xfa, 0x23, 0xe5, 0x5d, 0xe5, 0x71, 0x2c, 0xd4, 0xea, 0xfc, 0x19, 0x7f, 0xae, 0xd6, 0x5e, 0x5a,
+	0x21, 0xd9, 0xbb, 0x0e, 0x80, 0xad, 0x69, 0x56, 0x51, 0xf0, 0x47, 0x15, 0x0e, 0x37, 0x97, 0x98,
+	0x99, 0x0b, 0xbb, 0xf9, 0x4a, 0x2a, 0xb4, 0xc9, 0x73, 0x69, 0xa4, 0xd8, 0xa0, 0xc8, 0xca, 0xb1,
+	0x93, 0x22, 0x3b, 0x50, 0x8f, 0x52, 0x54, 0xff, 0x37, 0x8a, 0x1a, 0x4f, 0xa2, 0xa8, 0xf9, 0x04,
+	0x8a, 0xd8, 0x4b, 0x68, 0xde, 0x49, 0x9c, 0x47, 0x99, 0xdf, 0xea, 0xd7, 0x8c, 0xce, 0xd6, 0xda,
+	0xa2, 0xee, 0x35, 0x74, 0x37, 0x16, 0xb7, 0x19, 0x52, 0xb3, 0xe6, 0x8b, 0xc1, 0xb1, 0x46, 0xf0,
+	0x2d, 0xc0, 0xfa, 0x70, 0xf6, 0x06, 0x3c, 0xf3, 0xc7, 0xba, 0xef, 0x4f, 0xb3, 0x35, 0x5b, 0x50,
+	0x6e, 0xf0, 0x1b, 0x9c, 0x3c, 0xd2, 0xb6, 0xd1, 0x28, 0x16, 0xcb, 0x30, 0xc2, 0x89, 0x42, 0xab,
+	0x51, 0x97, 0xb7, 0x63, 0xb1, 0x7c, 0x4f, 0x0e, 0xa3, 0x91, 0x09, 0xcf, 0x71, 0x81, 0x73, 0xd2,
+	0xa8, 0xcb, 0xbd, 0x58, 0x2c, 0x7f, 0x32, 0x36, 0x1b, 0xc0, 0xd1, 0x43, 0xb0, 0xa0, 0xcb, 0x2c,
+	0xf3, 0x0e, 0x3f, 0x2c, 0x72, 0xdc, 0xa8, 0xa4, 0x70, 0x9a, 0xaa, 0xc9, 0x70, 0xba, 0xfa, 0x84,
+	0xca, 0x7e, 0x23, 0x0c, 0xef, 0xc4, 0xad, 0x92, 0x63, 0xfb, 0x4d, 0x90, 0x0d, 0x9d, 0xd3, 0xb6,
+	0xef, 0xae, 0xf1, 0xcb, 0xdb, 0x89, 0xd4, 0xd3, 0xfc, 0x76, 0x38, 0x4e, 0xe3, 0x51, 0x09, 0x3a,
+	0xb2, 0xd0, 0x91, 0x85, 0x8e, 0x76, 0x7d, 0x73, 0xdc, 0x36, 0x29, 0xf8, 0xf5, 0xdf, 0x03, 0x00,
+	0xa4, 0xc5, 0x34, 0x74, 0x92, 0x08, 0x00, 0x00,
 }
@@ -32,7 +32,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_7287b6fe5795d8b9, []int{0}
+	return fileDescriptor_configuration_a751f76c78652a97, []int{0}
 }
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Metadata.Unmarshal(m, b)
@@ -72,6 +72,7 @@ type Consenter struct {
 	Port                 uint32   `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
 	ClientTlsCert        []byte   `protobuf:"bytes,3,opt,name=client_tls_cert,json=clientTlsCert,proto3" json:"client_tls_cert,omitempty"`
 	ServerTlsCert        []byte   `protobuf:"bytes,4,opt,name=server_tls_cert,json=serverTlsCert,proto3" json:"server_tls_cert,omitempty"`
+	Learner              bool     `protobuf:"varint,5,opt,name=learner,proto3" json:"learner,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -81,7 +82,7 @@ func (m *Consenter) Reset()         { *m = Consenter{} }
 func (m *Consenter) String() string { return proto.CompactTextString(m) }
 func (*Consenter) ProtoMessage()    {}
 func (*Consenter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_7287b6fe5795d8b9, []int{1}
+	return fileDescriptor_configuration_a751f76c78652a97, []int{1}
 }
 func (m *Consenter) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Consenter.Unmarshal(m, b)
@@ -129,6 +130,13 @@ func (m *Consenter) GetServerTlsCert() []byte {
 	return nil
 }
 
+func (m *Consenter) GetLearner() bool {
+	if m != nil {
+		return m.Learner
+	}
+	return false
+}
+
 // Options to be specified for all the etcd/raft nodes. These can be modified on a
 // per-channel basis.
 type Options struct {
@@ -138,6 +146,7 @@ type Options struct {
 	MaxInflightMsgs      uint32   `protobuf:"varint,4,opt,name=max_inflight_msgs,json=maxInflightMsgs,proto3" json:"max_inflight_msgs,omitempty"`
 	MaxSizePerMsg        uint64   `protobuf:"varint,5,opt,name=max_size_per_msg,json=maxSizePerMsg,proto3" json:"max_size_per_msg,omitempty"`
 	SnapshotInterval     uint64   `protobuf:"varint,6,opt,name=snapshot_interval,json=snapshotInterval,proto3" json:"snapshot_interval,omitempty"`
+	PreferredLeader      string   `protobuf:"bytes,7,opt,name=preferred_leader,json=preferredLeader,proto3" json:"preferred_leader,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -147,7 +156,7 @@ func (m *Options) Reset()         { *m = Options{} }
 func (m *Options) String() string { return proto.CompactTextString(m) }
 func (*Options) ProtoMessage()    {}
 func (*Options) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_7287b6fe5795d8b9, []int{2}
+	return fileDescriptor_configuration_a751f76c78652a97, []int{2}
 }
 func (m *Options) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Options.Unmarshal(m, b)
@@ -209,6 +218,13 @@ func (m *Options) GetSnapshotInterval() uint64 {
 	return 0
 }
 
+func (m *Options) GetPreferredLeader() string {
+	if m != nil {
+		return m.PreferredLeader
+	}
+	return ""
+}
+
 // RaftMetadata stores data used by the Raft OSNs when
 // coordinating with each other, to be serialized into
 // block meta dta field and used after failres and restarts.
@@ -232,7 +248,7 @@ func (m *RaftMetadata) Reset()         { *m = RaftMetadata{} }
 func (m *RaftMetadata) String() string { return proto.CompactTextString(m) }
 func (*RaftMetadata) ProtoMessage()    {}
 func (*RaftMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_7287b6fe5795d8b9, []int{3}
+	return fileDescriptor_configuration_a751f76c78652a97, []int{3}
 }
 func (m *RaftMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RaftMetadata.Unmarshal(m, b)
@@ -289,43 +305,45 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("orderer/etcdraft/configuration.proto", fileDescriptor_configuration_7287b6fe5795d8b9)
-}
-
-var fileDescriptor_configuration_7287b6fe5795d8b9 = []byte{
-	// 535 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x4d, 0x6b, 0x1b, 0x3d,
-	0x14, 0x85, 0x99, 0x78, 0xf2, 0xa5, 0x78, 0x5e, 0xdb, 0x7a, 0x37, 0xa6, 0x50, 0x30, 0x6e, 0x9b,
-	0xba, 0x49, 0x99, 0x81, 0x84, 0x42, 0xe9, 0xb2, 0xa6, 0x05, 0x2f, 0x4c, 0x8b, 0x9a, 0x55, 0x37,
-	0x42, 0xd6, 0x5c, 0xcf, 0x08, 0x8f, 0xa5, 0x41, 0x92, 0x8d, 0x9d, 0x6d, 0x7f, 0x4a, 0xff, 0x66,
-	0x17, 0x45, 0x9a, 0x0f, 0xbb, 0x21, 0x3b, 0x71, 0xce, 0x73, 0x2f, 0x47, 0xba, 0x57, 0xe8, 0xb5,
-	0xd2, 0x29, 0x68, 0xd0, 0x09, 0x58, 0x9e, 0x6a, 0xb6, 0xb4, 0x09, 0x57, 0x72, 0x29, 0xb2, 0x8d,
-	0x66, 0x56, 0x28, 0x19, 0x97, 0x5a, 0x59, 0x85, 0x2f, 0x1a, 0x77, 0x5c, 0xa0, 0x8b, 0x39, 0x58,
-	0x96, 0x32, 0xcb, 0xf0, 0x3d, 0x42, 0x5c, 0x49, 0x03, 0xd2, 0x82, 0x36, 0xc3, 0x60, 0xd4, 0x99,
-	0x5c, 0xdd, 0xfd, 0x1f, 0x37, 0x68, 0x3c, 0x6d, 0x3c, 0x72, 0x84, 0xe1, 0x5b, 0x74, 0xae, 0x4a,
-	0xd7, 0xda, 0x0c, 0x4f, 0x46, 0xc1, 0xe4, 0xea, 0x6e, 0x70, 0xa8, 0xf8, 0x56, 0x19, 0xa4, 0x21,
-	0xc6, 0xbf, 0x02, 0x74, 0xd9, 0xb6, 0xc1, 0x18, 0x85, 0xb9, 0x32, 0x76, 0x18, 0x8c, 0x82, 0xc9,
-	0x25, 0xf1, 0x67, 0xa7, 0x95, 0x4a, 0x5b, 0xdf, 0x2b, 0x22, 0xfe, 0x8c, 0xaf, 0x51, 0x8f, 0x17,
-	0x02, 0xa4, 0xa5, 0xb6, 0x30, 0x94, 0x83, 0xb6, 0xc3, 0xce, 0x28, 0x98, 0x74, 0x49, 0x54, 0xc9,
-	0x0f, 0x85, 0x99, 0x42, 0xc5, 0x19, 0xd0, 0x5b, 0xd0, 0x07, 0x2e, 0xac, 0xb8, 0x4a, 0xae, 0xb9,
-	0xf1, 0x9f, 0x00, 0x9d, 0xd7, 0xd1, 0xf0, 0x2b, 0x14, 0x59, 0xc1, 0x57, 0x54, 0xb8, 0x44, 0x5b,
-	0x56, 0xf8, 0x30, 0x21, 0xe9, 0x3a, 0x71, 0x56, 0x6b, 0x0e, 0x82, 0x02, 0xb8, 0xab, 0xa0, 0xce,
-	0xa8, 0xd3, 0x75, 0x1b, 0xf1, 0x41, 0xf0, 0x15, 0x7e, 0x83, 0xfe, 0xcb, 0x81, 0x69, 0xbb, 0x00,
-	0x66, 0x2b, 0xaa, 0xe3, 0xa9, 0xa8, 0x55, 0x3d, 0x76, 0x83, 0x06, 0x6b, 0xb6, 0xa3, 0x42, 0x2e,
-	0x0b, 0x91, 0xe5, 0x96, 0xae, 0x4d, 0x66, 0x7c, 0xcc, 0x88, 0xf4, 0xd6, 0x6c, 0x37, 0xab, 0xf5,
-	0xb9, 0xc9, 0x0c, 0x7e, 0x8b, 0xfa, 0x8e, 0x35, 0xe2, 0x11, 0x68, 0x09, 0xda, 0xb1, 0xc3, 0x53,
-	0x9f, 0x2f, 0x5a, 0xb3, 0xdd, 0x0f, 0xf1, 0x08, 0xdf, 0x41, 0xcf, 0x4d, 0x86, 0x6f, 0xd1, 0xc0,
-	0x48, 0x56, 0x9a, 0x5c, 0xd9, 0xc3, 0x4d, 0xce, 0x3c, 0xd9, 0x6f, 0x8c, 0xe6, 0x36, 0xe3, 0xdf,
-	0x27, 0xa8, 0x4b, 0xd8, 0xd2, 0xb6, 0x73, 0xff, 0xfa, 0xcc, 0xdc, 0xaf, 0x0f, 0x53, 0x3c, 0x66,
-	0x0f, 0x4b, 0x60, 0xbe, 0x48, 0xab, 0xf7, 0xff, 0xac, 0xc2, 0x0d, 0x1a, 0x48, 0xd8, 0x59, 0xda,
-	0x4a, 0x54, 0xa4, 0xfe, 0xa9, 0x42, 0xd2, 0x73, 0x46, 0x5b, 0x3b, 0x4b, 0xf1, 0x7b, 0x84, 0xdd,
-	0x62, 0x52, 0x9e, 0x33, 0x99, 0x01, 0xe5, 0x6a, 0x23, 0xad, 0xf1, 0x2f, 0x16, 0x92, 0xbe, 0x73,
-	0xa6, 0xde, 0x98, 0x7a, 0x1d, 0xbf, 0x44, 0xc8, 0x45, 0xa1, 0x42, 0xa6, 0xb0, 0xf3, 0xaf, 0x15,
-	0x92, 0x4b, 0xa7, 0xcc, 0x9c, 0xf0, 0x82, 0xa0, 0xde, 0x93, 0x5c, 0xb8, 0x8f, 0x3a, 0x2b, 0xd8,
-	0xd7, 0xd3, 0x74, 0x47, 0xfc, 0x0e, 0x9d, 0x6e, 0x59, 0xb1, 0x81, 0x7a, 0x4d, 0x9f, 0x5d, 0xec,
-	0x8a, 0xf8, 0x74, 0xf2, 0x31, 0xf8, 0x9c, 0xa1, 0x58, 0xe9, 0x2c, 0xce, 0xf7, 0x25, 0xe8, 0x02,
-	0xd2, 0x0c, 0x74, 0xbc, 0x64, 0x0b, 0x2d, 0x78, 0xf5, 0x85, 0x4c, 0x5c, 0x7f, 0xb4, 0xb6, 0xcd,
-	0xcf, 0x0f, 0x99, 0xb0, 0xf9, 0x66, 0x11, 0x73, 0xb5, 0x4e, 0x8e, 0xca, 0x92, 0xaa, 0x2c, 0xa9,
-	0xca, 0x92, 0xa7, 0xff, 0x73, 0x71, 0xe6, 0x8d, 0xfb, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x56,
-	0xd3, 0xd9, 0x86, 0xba, 0x03, 0x00, 0x00,
+	proto.RegisterFile("orderer/etcdraft/configuration.proto", fileDescriptor_configuration_a751f76c78652a97)
+}
+
+var fileDescriptor_configuration_a751f76c78652a97 = []byte{
+	// 569 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x5f, 0x6b, 0xdb, 0x3c,
+	0x14, 0xc6, 0x71, 0xe2, 0x36, 0xad, 0x9a, 0xbc, 0x49, 0xf4, 0xde, 0x98, 0xc1, 0x20, 0x64, 0x5b,
+	0x97, 0xb6, 0xc3, 0x81, 0x96, 0xc1, 0xd8, 0xe5, 0xc2, 0x06, 0x81, 0x95, 0x0d, 0xad, 0x57, 0xbb,
+	0x11, 0x8a, 0x7d, 0x62, 0x8b, 0x3a, 0x92, 0x39, 0x52, 0x4a, 0xda, 0xef, 0xb2, 0xab, 0x5d, 0xec,
+	0x6b, 0x0e, 0xc9, 0x7f, 0xd2, 0x95, 0xde, 0xc9, 0xcf, 0xf3, 0x3b, 0xe2, 0x1c, 0xf9, 0x39, 0xe4,
+	0xb5, 0xc6, 0x14, 0x10, 0x70, 0x0e, 0x36, 0x49, 0x51, 0xac, 0xed, 0x3c, 0xd1, 0x6a, 0x2d, 0xb3,
+	0x2d, 0x0a, 0x2b, 0xb5, 0x8a, 0x4b, 0xd4, 0x56, 0xd3, 0xa3, 0xc6, 0x9d, 0x16, 0xe4, 0xe8, 0x1a,
+	0xac, 0x48, 0x85, 0x15, 0xf4, 0x8a, 0x90, 0x44, 0x2b, 0x03, 0xca, 0x02, 0x9a, 0x28, 0x98, 0x74,
+	0x67, 0x27, 0x97, 0xff, 0xc7, 0x0d, 0x1a, 0x2f, 0x1a, 0x8f, 0x3d, 0xc2, 0xe8, 0x05, 0xe9, 0xe9,
+	0xd2, 0x5d, 0x6d, 0xa2, 0xce, 0x24, 0x98, 0x9d, 0x5c, 0x8e, 0xf7, 0x15, 0xdf, 0x2a, 0x83, 0x35,
+	0xc4, 0xf4, 0x57, 0x40, 0x8e, 0xdb, 0x6b, 0x28, 0x25, 0x61, 0xae, 0x8d, 0x8d, 0x82, 0x49, 0x30,
+	0x3b, 0x66, 0xfe, 0xec, 0xb4, 0x52, 0xa3, 0xf5, 0x77, 0x0d, 0x98, 0x3f, 0xd3, 0x53, 0x32, 0x4c,
+	0x0a, 0x09, 0xca, 0x72, 0x5b, 0x18, 0x9e, 0x00, 0xda, 0xa8, 0x3b, 0x09, 0x66, 0x7d, 0x36, 0xa8,
+	0xe4, 0x9b, 0xc2, 0x2c, 0xa0, 0xe2, 0x0c, 0xe0, 0x1d, 0xe0, 0x9e, 0x0b, 0x2b, 0xae, 0x92, 0x1b,
+	0x2e, 0x22, 0xbd, 0x02, 0x04, 0x2a, 0xc0, 0xe8, 0x60, 0x12, 0xcc, 0x8e, 0x58, 0xf3, 0x39, 0xfd,
+	0xd3, 0x21, 0xbd, 0xba, 0x69, 0xfa, 0x8a, 0x0c, 0xac, 0x4c, 0x6e, 0xb9, 0x74, 0xbd, 0xde, 0x89,
+	0xc2, 0xb7, 0x19, 0xb2, 0xbe, 0x13, 0x97, 0xb5, 0xe6, 0x20, 0x28, 0x20, 0x71, 0x15, 0xdc, 0x19,
+	0x75, 0xdf, 0xfd, 0x46, 0xbc, 0x91, 0xc9, 0x2d, 0x7d, 0x43, 0xfe, 0xcb, 0x41, 0xa0, 0x5d, 0x81,
+	0xb0, 0x15, 0xd5, 0xf5, 0xd4, 0xa0, 0x55, 0x3d, 0x76, 0x4e, 0xc6, 0x1b, 0xb1, 0xe3, 0x52, 0xad,
+	0x0b, 0x99, 0xe5, 0x96, 0x6f, 0x4c, 0x66, 0xfc, 0x00, 0x03, 0x36, 0xdc, 0x88, 0xdd, 0xb2, 0xd6,
+	0xaf, 0x4d, 0x66, 0xe8, 0x5b, 0x32, 0x72, 0xac, 0x91, 0x0f, 0xc0, 0x4b, 0x40, 0xc7, 0xfa, 0x59,
+	0x42, 0x36, 0xd8, 0x88, 0xdd, 0x0f, 0xf9, 0x00, 0xdf, 0x01, 0xaf, 0x4d, 0x46, 0x2f, 0xc8, 0xd8,
+	0x28, 0x51, 0x9a, 0x5c, 0xdb, 0xfd, 0x24, 0x87, 0x9e, 0x1c, 0x35, 0x46, 0x3b, 0xcd, 0x19, 0x19,
+	0x95, 0x08, 0x6b, 0x40, 0x84, 0x94, 0x17, 0x20, 0x52, 0xc0, 0xa8, 0xe7, 0x7f, 0xce, 0xb0, 0xd5,
+	0xbf, 0x7a, 0x79, 0xfa, 0xbb, 0x43, 0xfa, 0x4c, 0xac, 0x6d, 0x1b, 0x9e, 0x2f, 0xcf, 0x84, 0xe7,
+	0x74, 0x1f, 0x85, 0xc7, 0xec, 0x3e, 0x49, 0xe6, 0xb3, 0xb2, 0x78, 0xff, 0x4f, 0x9e, 0xce, 0xc9,
+	0x58, 0xc1, 0xce, 0xf2, 0x56, 0xe2, 0x32, 0xf5, 0xaf, 0x1a, 0xb2, 0xa1, 0x33, 0xda, 0xda, 0x65,
+	0x4a, 0xdf, 0x11, 0xea, 0xd2, 0xcd, 0x93, 0x5c, 0xa8, 0x0c, 0x78, 0xa2, 0xb7, 0xca, 0x1a, 0xff,
+	0xb8, 0x21, 0x1b, 0x39, 0x67, 0xe1, 0x8d, 0x85, 0xd7, 0xe9, 0x4b, 0x42, 0x5c, 0x2b, 0x5c, 0xaa,
+	0x14, 0x76, 0xfe, 0x61, 0x43, 0x76, 0xec, 0x94, 0xa5, 0x13, 0x5e, 0x30, 0x32, 0x7c, 0xd2, 0x17,
+	0x1d, 0x91, 0xee, 0x2d, 0xdc, 0xd7, 0x3f, 0xde, 0x1d, 0xe9, 0x19, 0x39, 0xb8, 0x13, 0xc5, 0x16,
+	0xea, 0xac, 0x3f, 0xbb, 0x1d, 0x15, 0xf1, 0xb1, 0xf3, 0x21, 0xf8, 0x94, 0x91, 0x58, 0x63, 0x16,
+	0xe7, 0xf7, 0x25, 0x60, 0x01, 0x69, 0x06, 0x18, 0xaf, 0xc5, 0x0a, 0x65, 0x52, 0xed, 0xa1, 0x89,
+	0xeb, 0x6d, 0x6d, 0xaf, 0xf9, 0xf9, 0x3e, 0x93, 0x36, 0xdf, 0xae, 0xe2, 0x44, 0x6f, 0xe6, 0x8f,
+	0xca, 0xe6, 0x55, 0xd9, 0xbc, 0x2a, 0x9b, 0x3f, 0x5d, 0xf2, 0xd5, 0xa1, 0x37, 0xae, 0xfe, 0x0e,
+	0x00, 0xfe, 0xbf, 0x6b, 0x52, 0xff, 0x03, 0x00, 0x00,
 }
@@ -57,7 +57,30 @@ func (x ServerStatus_StatusCode) String() string {
 	return proto.EnumName(ServerStatus_StatusCode_name, int32(x))
 }
 func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{0, 0}
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{0, 0}
+}
+
+type StateIndex_BuildStatus int32
+
+const (
+	StateIndex_READY    StateIndex_BuildStatus = 0
+	StateIndex_BUILDING StateIndex_BuildStatus = 1
+)
+
+var StateIndex_BuildStatus_name = map[int32]string{
+	0: "READY",
+	1: "BUILDING",
+}
+var StateIndex_BuildStatus_value = map[string]int32{
+	"READY":    0,
+	"BUILDING": 1,
+}
+
+func (x StateIndex_BuildStatus) String() string {
+	return proto.EnumName(StateIndex_BuildStatus_name, int32(x))
+}
+func (StateIndex_BuildStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{6, 0}
 }
 
 type ServerStatus struct {
@@ -71,7 +94,7 @@ func (m *ServerStatus) Reset()         { *m = ServerStatus{} }
 func (m *ServerStatus) String() string { return proto.CompactTextString(m) }
 func (*ServerStatus) ProtoMessage()    {}
 func (*ServerStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{0}
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{0}
 }
 func (m *ServerStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServerStatus.Unmarshal(m, b)
@@ -110,7 +133,7 @@ func (m *LogLevelRequest) Reset()         { *m = LogLevelRequest{} }
 func (m *LogLevelRequest) String() string { return proto.CompactTextString(m) }
 func (*LogLevelRequest) ProtoMessage()    {}
 func (*LogLevelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{1}
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{1}
 }
 func (m *LogLevelRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelRequest.Unmarshal(m, b)
@@ -156,7 +179,7 @@ func (m *LogLevelResponse) Reset()         { *m = LogLevelResponse{} }
 func (m *LogLevelResponse) String() string { return proto.CompactTextString(m) }
 func (*LogLevelResponse) ProtoMessage()    {}
 func (*LogLevelResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{2}
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{2}
 }
 func (m *LogLevelResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelResponse.Unmarshal(m, b)
@@ -201,7 +224,7 @@ func (m *LogSpecRequest) Reset()         { *m = LogSpecRequest{} }
 func (m *LogSpecRequest) String() string { return proto.CompactTextString(m) }
 func (*LogSpecRequest) ProtoMessage()    {}
 func (*LogSpecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{3}
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{3}
 }
 func (m *LogSpecRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogSpecRequest.Unmarshal(m, b)
@@ -240,7 +263,7 @@ func (m *LogSpecResponse) Reset()         { *m = LogSpecResponse{} }
 func (m *LogSpecResponse) String() string { return proto.CompactTextString(m) }
 func (*LogSpecResponse) ProtoMessage()    {}
 func (*LogSpecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{4}
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{4}
 }
 func (m *LogSpecResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogSpecResponse.Unmarshal(m, b)
@@ -274,10 +297,204 @@ func (m *LogSpecResponse) GetError() string {
 	return ""
 }
 
+// StateIndexRequest identifies the indexes of the state database of a channel
+// that are managed on the public data of a chaincode or, if the collection is
+// set, on the private data of the collection of the chaincode
+type StateIndexRequest struct {
+	ChannelId  string `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Chaincode  string `protobuf:"bytes,2,opt,name=chaincode,proto3" json:"chaincode,omitempty"`
+	Collection string `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	// index_definition is the definition of the index to add, in the format of
+	// the index files packaged with a chaincode
+	IndexDefinition []byte `protobuf:"bytes,4,opt,name=index_definition,json=indexDefinition,proto3" json:"index_definition,omitempty"`
+	// design_doc and index_name identify the index to drop
+	DesignDoc            string   `protobuf:"bytes,5,opt,name=design_doc,json=designDoc,proto3" json:"design_doc,omitempty"`
+	IndexName            string   `protobuf:"bytes,6,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateIndexRequest) Reset()         { *m = StateIndexRequest{} }
+func (m *StateIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*StateIndexRequest) ProtoMessage()    {}
+func (*StateIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{5}
+}
+func (m *StateIndexRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateIndexRequest.Unmarshal(m, b)
+}
+func (m *StateIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateIndexRequest.Marshal(b, m, deterministic)
+}
+func (dst *StateIndexRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateIndexRequest.Merge(dst, src)
+}
+func (m *StateIndexRequest) XXX_Size() int {
+	return xxx_messageInfo_StateIndexRequest.Size(m)
+}
+func (m *StateIndexRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateIndexRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateIndexRequest proto.InternalMessageInfo
+
+func (m *StateIndexRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *StateIndexRequest) GetChaincode() string {
+	if m != nil {
+		return m.Chaincode
+	}
+	return ""
+}
+
+func (m *StateIndexRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *StateIndexRequest) GetIndexDefinition() []byte {
+	if m != nil {
+		return m.IndexDefinition
+	}
+	return nil
+}
+
+func (m *StateIndexRequest) GetDesignDoc() string {
+	if m != nil {
+		return m.DesignDoc
+	}
+	return ""
+}
+
+func (m *StateIndexRequest) GetIndexName() string {
+	if m != nil {
+		return m.IndexName
+	}
+	return ""
+}
+
+type StateIndex struct {
+	DesignDoc   string                 `protobuf:"bytes,1,opt,name=design_doc,json=designDoc,proto3" json:"design_doc,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Definition  string                 `protobuf:"bytes,3,opt,name=definition,proto3" json:"definition,omitempty"`
+	BuildStatus StateIndex_BuildStatus `protobuf:"varint,4,opt,name=build_status,json=buildStatus,proto3,enum=protos.StateIndex_BuildStatus" json:"build_status,omitempty"`
+	// build_progress is the percentage of the build that is complete, while building
+	BuildProgress        int32    `protobuf:"varint,5,opt,name=build_progress,json=buildProgress,proto3" json:"build_progress,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateIndex) Reset()         { *m = StateIndex{} }
+func (m *StateIndex) String() string { return proto.CompactTextString(m) }
+func (*StateIndex) ProtoMessage()    {}
+func (*StateIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{6}
+}
+func (m *StateIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateIndex.Unmarshal(m, b)
+}
+func (m *StateIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateIndex.Marshal(b, m, deterministic)
+}
+func (dst *StateIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateIndex.Merge(dst, src)
+}
+func (m *StateIndex) XXX_Size() int {
+	return xxx_messageInfo_StateIndex.Size(m)
+}
+func (m *StateIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateIndex proto.InternalMessageInfo
+
+func (m *StateIndex) GetDesignDoc() string {
+	if m != nil {
+		return m.DesignDoc
+	}
+	return ""
+}
+
+func (m *StateIndex) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StateIndex) GetDefinition() string {
+	if m != nil {
+		return m.Definition
+	}
+	return ""
+}
+
+func (m *StateIndex) GetBuildStatus() StateIndex_BuildStatus {
+	if m != nil {
+		return m.BuildStatus
+	}
+	return StateIndex_READY
+}
+
+func (m *StateIndex) GetBuildProgress() int32 {
+	if m != nil {
+		return m.BuildProgress
+	}
+	return 0
+}
+
+type StateIndexResponse struct {
+	Indexes              []*StateIndex `protobuf:"bytes,1,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *StateIndexResponse) Reset()         { *m = StateIndexResponse{} }
+func (m *StateIndexResponse) String() string { return proto.CompactTextString(m) }
+func (*StateIndexResponse) ProtoMessage()    {}
+func (*StateIndexResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{7}
+}
+func (m *StateIndexResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateIndexResponse.Unmarshal(m, b)
+}
+func (m *StateIndexResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateIndexResponse.Marshal(b, m, deterministic)
+}
+func (dst *StateIndexResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateIndexResponse.Merge(dst, src)
+}
+func (m *StateIndexResponse) XXX_Size() int {
+	return xxx_messageInfo_StateIndexResponse.Size(m)
+}
+func (m *StateIndexResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateIndexResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateIndexResponse proto.InternalMessageInfo
+
+func (m *StateIndexResponse) GetIndexes() []*StateIndex {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
 type AdminOperation struct {
 	// Types that are valid to be assigned to Content:
 	//	*AdminOperation_LogReq
 	//	*AdminOperation_LogSpecReq
+	//	*AdminOperation_StateIndexReq
 	Content              isAdminOperation_Content `protobuf_oneof:"content"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
@@ -288,7 +505,7 @@ func (m *AdminOperation) Reset()         { *m = AdminOperation{} }
 func (m *AdminOperation) String() string { return proto.CompactTextString(m) }
 func (*AdminOperation) ProtoMessage()    {}
 func (*AdminOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{5}
+	return fileDescriptor_admin_4a0b98d39b68f962, []int{8}
 }
 func (m *AdminOperation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AdminOperation.Unmarshal(m, b)
@@ -320,10 +537,16 @@ type AdminOperation_LogSpecReq struct {
 	LogSpecReq *LogSpecRequest `protobuf:"bytes,2,opt,name=logSpecReq,proto3,oneof"`
 }
 
+type AdminOperation_StateIndexReq struct {
+	StateIndexReq *StateIndexRequest `protobuf:"bytes,3,opt,name=stateIndexReq,proto3,oneof"`
+}
+
 func (*AdminOperation_LogReq) isAdminOperation_Content() {}
 
 func (*AdminOperation_LogSpecReq) isAdminOperation_Content() {}
 
+func (*AdminOperation_StateIndexReq) isAdminOperation_Content() {}
+
 func (m *AdminOperation) GetContent() isAdminOperation_Content {
 	if m != nil {
 		return m.Content
@@ -345,11 +568,19 @@ func (m *AdminOperation) GetLogSpecReq() *LogSpecRequest {
 	return nil
 }
 
+func (m *AdminOperation) GetStateIndexReq() *StateIndexRequest {
+	if x, ok := m.GetContent().(*AdminOperation_StateIndexReq); ok {
+		return x.StateIndexReq
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AdminOperation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AdminOperation_OneofMarshaler, _AdminOperation_OneofUnmarshaler, _AdminOperation_OneofSizer, []interface{}{
 		(*AdminOperation_LogReq)(nil),
 		(*AdminOperation_LogSpecReq)(nil),
+		(*AdminOperation_StateIndexReq)(nil),
 	}
 }
 
@@ -367,6 +598,11 @@ func _AdminOperation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LogSpecReq); err != nil {
 			return err
 		}
+	case *AdminOperation_StateIndexReq:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.StateIndexReq); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AdminOperation.Content has unexpected type %T", x)
@@ -393,6 +629,14 @@ func _AdminOperation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Content = &AdminOperation_LogSpecReq{msg}
 		return true, err
+	case 3: // content.stateIndexReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(StateIndexRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_StateIndexReq{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -412,6 +656,11 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AdminOperation_StateIndexReq:
+		s := proto.Size(x.StateIndexReq)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -425,8 +674,12 @@ func init() {
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
 	proto.RegisterType((*LogSpecRequest)(nil), "protos.LogSpecRequest")
 	proto.RegisterType((*LogSpecResponse)(nil), "protos.LogSpecResponse")
+	proto.RegisterType((*StateIndexRequest)(nil), "protos.StateIndexRequest")
+	proto.RegisterType((*StateIndex)(nil), "protos.StateIndex")
+	proto.RegisterType((*StateIndexResponse)(nil), "protos.StateIndexResponse")
 	proto.RegisterType((*AdminOperation)(nil), "protos.AdminOperation")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
+	proto.RegisterEnum("protos.StateIndex_BuildStatus", StateIndex_BuildStatus_name, StateIndex_BuildStatus_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -448,6 +701,9 @@ type AdminClient interface {
 	RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
 	GetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
 	SetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
+	AddStateIndex(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*StateIndexResponse, error)
+	ListStateIndexes(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*StateIndexResponse, error)
+	DropStateIndex(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*StateIndexResponse, error)
 }
 
 type adminClient struct {
@@ -521,6 +777,33 @@ func (c *adminClient) SetLogSpec(ctx context.Context, in *common.Envelope, opts
 	return out, nil
 }
 
+func (c *adminClient) AddStateIndex(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*StateIndexResponse, error) {
+	out := new(StateIndexResponse)
+	err := c.cc.Invoke(ctx, "/protos.Admin/AddStateIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListStateIndexes(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*StateIndexResponse, error) {
+	out := new(StateIndexResponse)
+	err := c.cc.Invoke(ctx, "/protos.Admin/ListStateIndexes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DropStateIndex(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*StateIndexResponse, error) {
+	out := new(StateIndexResponse)
+	err := c.cc.Invoke(ctx, "/protos.Admin/DropStateIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServer is the server API for Admin service.
 type AdminServer interface {
 	GetStatus(context.Context, *common.Envelope) (*ServerStatus, error)
@@ -530,6 +813,9 @@ type AdminServer interface {
 	RevertLogLevels(context.Context, *common.Envelope) (*empty.Empty, error)
 	GetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
 	SetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
+	AddStateIndex(context.Context, *common.Envelope) (*StateIndexResponse, error)
+	ListStateIndexes(context.Context, *common.Envelope) (*StateIndexResponse, error)
+	DropStateIndex(context.Context, *common.Envelope) (*StateIndexResponse, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -662,6 +948,60 @@ func _Admin_SetLogSpec_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_AddStateIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AddStateIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/AddStateIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AddStateIndex(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListStateIndexes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListStateIndexes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/ListStateIndexes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListStateIndexes(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DropStateIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DropStateIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/DropStateIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DropStateIndex(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -694,48 +1034,78 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetLogSpec",
 			Handler:    _Admin_SetLogSpec_Handler,
 		},
+		{
+			MethodName: "AddStateIndex",
+			Handler:    _Admin_AddStateIndex_Handler,
+		},
+		{
+			MethodName: "ListStateIndexes",
+			Handler:    _Admin_ListStateIndexes_Handler,
+		},
+		{
+			MethodName: "DropStateIndex",
+			Handler:    _Admin_DropStateIndex_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
 }
 
-func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_b2904393863b6bc5) }
-
-var fileDescriptor_admin_b2904393863b6bc5 = []byte{
-	// 555 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x6e, 0x37, 0xda, 0x92, 0xd3, 0xd1, 0x05, 0x33, 0x6d, 0xa5, 0x13, 0x02, 0xe5, 0x0a, 0x84,
-	0x94, 0x88, 0x22, 0x34, 0x76, 0xc1, 0x45, 0x4b, 0x43, 0x87, 0xe8, 0xd2, 0x2a, 0x59, 0x85, 0x40,
-	0x42, 0x55, 0x9a, 0x9e, 0x79, 0x15, 0x6e, 0x9c, 0x39, 0x6e, 0xa5, 0xbd, 0x01, 0xcf, 0xc1, 0x23,
-	0xf0, 0x84, 0x28, 0x76, 0xc2, 0x2a, 0x56, 0x2e, 0xd8, 0xae, 0xec, 0x73, 0xfc, 0x7d, 0xdf, 0xf9,
-	0xb3, 0x0d, 0x66, 0x82, 0x28, 0x9c, 0x70, 0xb6, 0x98, 0xc7, 0x76, 0x22, 0xb8, 0xe4, 0xa4, 0xaa,
-	0x96, 0xb4, 0x75, 0x48, 0x39, 0xa7, 0x0c, 0x1d, 0x65, 0x4e, 0x97, 0xe7, 0x0e, 0x2e, 0x12, 0x79,
-	0xa5, 0x41, 0xad, 0x47, 0x11, 0x5f, 0x2c, 0x78, 0xec, 0xe8, 0x45, 0x3b, 0xad, 0x9f, 0x65, 0xd8,
-	0x09, 0x50, 0xac, 0x50, 0x04, 0x32, 0x94, 0xcb, 0x94, 0x1c, 0x41, 0x35, 0x55, 0xbb, 0x66, 0xf9,
-	0x59, 0xf9, 0x79, 0xa3, 0xfd, 0x54, 0x03, 0x53, 0x7b, 0x1d, 0x65, 0xeb, 0xe5, 0x3d, 0x9f, 0xa1,
-	0x9f, 0xc3, 0xad, 0x2f, 0x00, 0xd7, 0x5e, 0xf2, 0x00, 0x8c, 0xb1, 0xd7, 0x73, 0x3f, 0x7c, 0xf4,
-	0xdc, 0x9e, 0x59, 0x22, 0x75, 0xa8, 0x05, 0x67, 0x1d, 0xff, 0xcc, 0xed, 0x99, 0x65, 0x6d, 0x0c,
-	0x47, 0x23, 0xb7, 0x67, 0x6e, 0x11, 0x80, 0xea, 0xa8, 0x33, 0x0e, 0xdc, 0x9e, 0xb9, 0x4d, 0x0c,
-	0xa8, 0xb8, 0xbe, 0x3f, 0xf4, 0xcd, 0x7b, 0x19, 0x66, 0xec, 0x7d, 0xf2, 0x86, 0x9f, 0x3d, 0xb3,
-	0x62, 0x9d, 0xc2, 0xee, 0x80, 0xd3, 0x01, 0xae, 0x90, 0xf9, 0x78, 0xb9, 0xc4, 0x54, 0x92, 0x27,
-	0x00, 0x8c, 0xd3, 0xc9, 0x82, 0xcf, 0x96, 0x0c, 0x55, 0xaa, 0x86, 0x6f, 0x30, 0x4e, 0x4f, 0x95,
-	0x83, 0x1c, 0x42, 0x66, 0x4c, 0x58, 0x46, 0x69, 0x6e, 0xa9, 0xd3, 0xfb, 0x2c, 0x97, 0xb0, 0x3c,
-	0x30, 0xaf, 0xe5, 0xd2, 0x84, 0xc7, 0x29, 0xde, 0x49, 0xef, 0x25, 0x34, 0x06, 0x9c, 0x06, 0x09,
-	0x46, 0x45, 0x76, 0x8f, 0x21, 0x3b, 0x9d, 0xa4, 0x09, 0x46, 0xb9, 0x56, 0x8d, 0x69, 0x84, 0xd5,
-	0x55, 0xb5, 0x68, 0x70, 0x1e, 0xfb, 0xdf, 0x68, 0xb2, 0x07, 0x15, 0x14, 0x82, 0x8b, 0x3c, 0xa6,
-	0x36, 0xac, 0x1f, 0x65, 0x68, 0x74, 0xb2, 0xf1, 0x0f, 0x13, 0x14, 0xa1, 0x9c, 0xf3, 0x98, 0xbc,
-	0x82, 0x2a, 0xe3, 0xd4, 0xc7, 0x4b, 0xa5, 0x50, 0x6f, 0x1f, 0x14, 0x63, 0xfb, 0xab, 0x71, 0x27,
-	0x25, 0x3f, 0x07, 0x92, 0xb7, 0xaa, 0xe4, 0x3c, 0x6d, 0x15, 0xa0, 0xde, 0xde, 0x5f, 0xa3, 0xad,
-	0x15, 0x74, 0x52, 0xf2, 0xd7, 0xb0, 0x5d, 0x03, 0x6a, 0x11, 0x8f, 0x25, 0xc6, 0xb2, 0xfd, 0x6b,
-	0x1b, 0x2a, 0x2a, 0x15, 0xf2, 0x06, 0x8c, 0x3e, 0xca, 0xfc, 0x16, 0x99, 0x76, 0x7e, 0xcb, 0xdc,
-	0x78, 0x85, 0x8c, 0x27, 0xd8, 0xda, 0xdb, 0x74, 0x8f, 0xac, 0x12, 0x39, 0x82, 0x7a, 0x20, 0x43,
-	0x21, 0xb5, 0xfb, 0x3f, 0x88, 0x1d, 0x78, 0xd8, 0x47, 0xa9, 0xe7, 0x53, 0x14, 0xb9, 0x81, 0xde,
-	0xbc, 0xd9, 0x08, 0xdd, 0x76, 0x2d, 0x11, 0xdc, 0x51, 0xe2, 0x1d, 0xec, 0xfa, 0xb8, 0x42, 0x21,
-	0x8b, 0xb3, 0x4d, 0xb5, 0xef, 0xdb, 0xfa, 0x5d, 0xda, 0xc5, 0xbb, 0xb4, 0xdd, 0xec, 0x5d, 0x5a,
-	0x25, 0x72, 0x0c, 0xd0, 0x47, 0x99, 0x37, 0x7b, 0x03, 0xf3, 0xe0, 0xc6, 0x3c, 0xfe, 0x44, 0x3e,
-	0x06, 0x08, 0x6e, 0x47, 0xed, 0x7e, 0x03, 0x8b, 0x0b, 0x6a, 0x5f, 0x5c, 0x25, 0x28, 0x18, 0xce,
-	0x28, 0x0a, 0xfb, 0x3c, 0x9c, 0x8a, 0x79, 0x54, 0x50, 0xb2, 0x0f, 0xa6, 0xbb, 0xa3, 0xe6, 0x3a,
-	0x0a, 0xa3, 0xef, 0x21, 0xc5, 0xaf, 0x2f, 0xe8, 0x5c, 0x5e, 0x2c, 0xa7, 0x59, 0x18, 0x67, 0x8d,
-	0xe8, 0x68, 0xa2, 0xfe, 0x71, 0x52, 0x27, 0x23, 0x4e, 0xf5, 0x6f, 0xf4, 0xfa, 0x77, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xb0, 0x77, 0x16, 0x8f, 0xa8, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_4a0b98d39b68f962) }
+
+var fileDescriptor_admin_4a0b98d39b68f962 = []byte{
+	// 843 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0x8e, 0x77, 0x93, 0x6c, 0x73, 0x92, 0xcd, 0xba, 0x43, 0xd5, 0xa6, 0x5b, 0x28, 0x95, 0x25,
+	0xd0, 0x56, 0x20, 0x47, 0x04, 0xa1, 0xd2, 0x0b, 0x04, 0x09, 0x36, 0xdb, 0x88, 0x34, 0x1b, 0x4d,
+	0xba, 0x42, 0x45, 0x42, 0x91, 0x63, 0x9f, 0xf5, 0x5a, 0x38, 0x1e, 0x77, 0x3c, 0x59, 0xd1, 0xd7,
+	0xe1, 0x75, 0x78, 0x02, 0x1e, 0x83, 0x4b, 0xee, 0xd0, 0xfc, 0x38, 0x31, 0xbb, 0xe9, 0x45, 0xbb,
+	0x57, 0xe3, 0xf9, 0xce, 0xf9, 0xbe, 0xf3, 0x33, 0x67, 0xc6, 0x60, 0xe7, 0x88, 0xbc, 0x1f, 0x44,
+	0xab, 0x24, 0x73, 0x73, 0xce, 0x04, 0x23, 0x4d, 0xb5, 0x14, 0xc7, 0x8f, 0x62, 0xc6, 0xe2, 0x14,
+	0xfb, 0x6a, 0xbb, 0x5c, 0x5f, 0xf4, 0x71, 0x95, 0x8b, 0xb7, 0xda, 0xe9, 0xf8, 0xa3, 0x90, 0xad,
+	0x56, 0x2c, 0xeb, 0xeb, 0x45, 0x83, 0xce, 0x9f, 0x16, 0x74, 0xe6, 0xc8, 0xaf, 0x90, 0xcf, 0x45,
+	0x20, 0xd6, 0x05, 0x79, 0x06, 0xcd, 0x42, 0x7d, 0xf5, 0xac, 0x27, 0xd6, 0x49, 0x77, 0xf0, 0xa9,
+	0x76, 0x2c, 0xdc, 0xaa, 0x97, 0xab, 0x97, 0x1f, 0x59, 0x84, 0xd4, 0xb8, 0x3b, 0xaf, 0x01, 0xb6,
+	0x28, 0x39, 0x84, 0xd6, 0xf9, 0xd4, 0xf3, 0x7f, 0x1a, 0x4f, 0x7d, 0xcf, 0xae, 0x91, 0x36, 0x1c,
+	0xcc, 0x5f, 0x0d, 0xe9, 0x2b, 0xdf, 0xb3, 0x2d, 0xbd, 0x39, 0x9b, 0xcd, 0x7c, 0xcf, 0xde, 0x23,
+	0x00, 0xcd, 0xd9, 0xf0, 0x7c, 0xee, 0x7b, 0xf6, 0x3e, 0x69, 0x41, 0xc3, 0xa7, 0xf4, 0x8c, 0xda,
+	0x75, 0xe9, 0x73, 0x3e, 0xfd, 0x79, 0x7a, 0xf6, 0xcb, 0xd4, 0x6e, 0x38, 0x2f, 0xe1, 0x68, 0xc2,
+	0xe2, 0x09, 0x5e, 0x61, 0x4a, 0xf1, 0xcd, 0x1a, 0x0b, 0x41, 0x3e, 0x01, 0x48, 0x59, 0xbc, 0x58,
+	0xb1, 0x68, 0x9d, 0xa2, 0x4a, 0xb5, 0x45, 0x5b, 0x29, 0x8b, 0x5f, 0x2a, 0x80, 0x3c, 0x02, 0xb9,
+	0x59, 0xa4, 0x92, 0xd2, 0xdb, 0x53, 0xd6, 0x3b, 0xa9, 0x91, 0x70, 0xa6, 0x60, 0x6f, 0xe5, 0x8a,
+	0x9c, 0x65, 0x05, 0xde, 0x4a, 0xef, 0x0b, 0xe8, 0x4e, 0x58, 0x3c, 0xcf, 0x31, 0x2c, 0xb3, 0x7b,
+	0x08, 0xd2, 0xba, 0x28, 0x72, 0x0c, 0x8d, 0xd6, 0x41, 0xaa, 0x3d, 0x9c, 0x91, 0xaa, 0x45, 0x3b,
+	0x9b, 0xd8, 0xef, 0xf6, 0x26, 0xf7, 0xa0, 0x81, 0x9c, 0x33, 0x6e, 0x62, 0xea, 0x8d, 0xf3, 0xb7,
+	0x05, 0x77, 0x65, 0xaf, 0x71, 0x9c, 0x45, 0xf8, 0x47, 0xa5, 0x25, 0xe1, 0x65, 0x90, 0x65, 0x98,
+	0x2e, 0x92, 0xa8, 0x2c, 0xc1, 0x20, 0xe3, 0x88, 0x7c, 0x0c, 0x72, 0x93, 0x64, 0x21, 0x8b, 0xd0,
+	0xc8, 0x6d, 0x01, 0xf2, 0x18, 0x20, 0x64, 0x69, 0x8a, 0xa1, 0x48, 0x58, 0xd6, 0xdb, 0x57, 0xe6,
+	0x0a, 0x42, 0x9e, 0x82, 0x9d, 0xc8, 0x60, 0x8b, 0x08, 0x2f, 0x92, 0x2c, 0x51, 0x5e, 0xf5, 0x27,
+	0xd6, 0x49, 0x87, 0x1e, 0x29, 0xdc, 0xdb, 0xc0, 0x32, 0x8f, 0x08, 0x8b, 0x24, 0xce, 0x16, 0x11,
+	0x0b, 0x7b, 0x0d, 0x1d, 0x49, 0x23, 0x1e, 0x0b, 0xa5, 0x59, 0x2b, 0x65, 0xc1, 0x0a, 0x7b, 0x4d,
+	0x6d, 0x56, 0xc8, 0x34, 0x58, 0xa1, 0xf3, 0x8f, 0x05, 0xb0, 0xad, 0xed, 0x9a, 0x98, 0x75, 0x5d,
+	0x8c, 0x40, 0x5d, 0xc9, 0xe8, 0x7a, 0xd4, 0xb7, 0x2c, 0xa5, 0x92, 0xa4, 0x29, 0x65, 0x8b, 0x90,
+	0x21, 0x74, 0x96, 0xeb, 0x24, 0x8d, 0x16, 0x66, 0xce, 0xeb, 0x6a, 0xce, 0x1f, 0x6f, 0xe6, 0x7c,
+	0x13, 0xdc, 0x1d, 0x49, 0x37, 0x3d, 0xd4, 0xb4, 0xbd, 0xdc, 0x6e, 0xc8, 0x67, 0xd0, 0xd5, 0x12,
+	0x39, 0x67, 0x31, 0xc7, 0xa2, 0x50, 0x65, 0x36, 0xe8, 0xa1, 0x42, 0x67, 0x06, 0x74, 0x3e, 0x87,
+	0x76, 0x45, 0x42, 0x8e, 0x37, 0xf5, 0x87, 0xde, 0x6b, 0xbb, 0x46, 0x3a, 0x70, 0x67, 0x74, 0x3e,
+	0x9e, 0x78, 0xe3, 0xe9, 0xa9, 0x6d, 0x39, 0x23, 0x20, 0xd5, 0xe3, 0x34, 0x63, 0xf1, 0x25, 0x1c,
+	0xa8, 0xb6, 0xa0, 0xbc, 0x8a, 0xfb, 0x27, 0xed, 0x01, 0xb9, 0x99, 0x22, 0x2d, 0x5d, 0x9c, 0xbf,
+	0x2c, 0xe8, 0x0e, 0xe5, 0x93, 0x70, 0x96, 0x23, 0x0f, 0x54, 0xa1, 0x5f, 0x41, 0x33, 0x65, 0x31,
+	0xc5, 0x37, 0xaa, 0x6f, 0xed, 0xc1, 0x83, 0x92, 0x7f, 0xed, 0x32, 0xbd, 0xa8, 0x51, 0xe3, 0x48,
+	0xbe, 0x05, 0x30, 0xa3, 0x27, 0x69, 0x7b, 0x8a, 0x76, 0xbf, 0x42, 0xab, 0x0c, 0xf9, 0x8b, 0x1a,
+	0xad, 0xf8, 0x92, 0x21, 0x1c, 0x16, 0xd5, 0x91, 0x54, 0x8d, 0x6f, 0x0f, 0x1e, 0xee, 0xc8, 0x79,
+	0xc3, 0xff, 0x3f, 0x63, 0xd4, 0x82, 0x83, 0x90, 0x65, 0x02, 0x33, 0x31, 0xf8, 0xb7, 0x0e, 0x0d,
+	0x55, 0x0d, 0xf9, 0x06, 0x5a, 0xa7, 0x28, 0x4c, 0x07, 0x6d, 0xd7, 0x3c, 0x5e, 0x7e, 0x76, 0x85,
+	0x29, 0xcb, 0xf1, 0xf8, 0xde, 0xae, 0xe7, 0xc9, 0xa9, 0x91, 0x67, 0xd0, 0x9e, 0x8b, 0x80, 0x0b,
+	0x0d, 0xbf, 0x07, 0x71, 0x08, 0x77, 0x4f, 0x51, 0xe8, 0x6b, 0x5f, 0xf6, 0x69, 0x07, 0xbd, 0x77,
+	0xb3, 0x97, 0xfa, 0xd8, 0xb4, 0xc4, 0xfc, 0x96, 0x12, 0xdf, 0xc1, 0x11, 0xc5, 0x2b, 0xe4, 0xa2,
+	0xb4, 0xed, 0xaa, 0xfd, 0xbe, 0xab, 0x9f, 0x7b, 0xb7, 0x7c, 0xee, 0x5d, 0x5f, 0x3e, 0xf7, 0x4e,
+	0x8d, 0x3c, 0x07, 0x38, 0x45, 0x61, 0xce, 0x6b, 0x07, 0xf3, 0xc1, 0x8d, 0x23, 0xdd, 0x44, 0x7e,
+	0x0e, 0x30, 0xff, 0x40, 0xea, 0xf7, 0x70, 0x38, 0x8c, 0xa2, 0xca, 0xe5, 0xbd, 0xc9, 0x3e, 0xde,
+	0x35, 0x0e, 0x1b, 0x81, 0x11, 0xd8, 0x93, 0xa4, 0x10, 0x5b, 0x1b, 0x16, 0xef, 0xad, 0xf1, 0x03,
+	0x74, 0x3d, 0xce, 0xf2, 0x0f, 0xcf, 0x62, 0xf4, 0x1b, 0x38, 0x8c, 0xc7, 0xee, 0xe5, 0xdb, 0x1c,
+	0x79, 0x8a, 0x51, 0x8c, 0xdc, 0xbd, 0x08, 0x96, 0x3c, 0x09, 0x4b, 0x56, 0x8e, 0xc8, 0x47, 0x1d,
+	0x35, 0x9e, 0xb3, 0x20, 0xfc, 0x3d, 0x88, 0xf1, 0xd7, 0xa7, 0x71, 0x22, 0x2e, 0xd7, 0x4b, 0x19,
+	0xa9, 0x5f, 0x21, 0xf6, 0x35, 0x51, 0xff, 0x8f, 0x8b, 0xbe, 0x24, 0x2e, 0xf5, 0xbf, 0xfa, 0xeb,
+	0xff, 0x06, 0x00, 0xff, 0x43, 0x36, 0xaa, 0xc6, 0x07, 0x00, 0x00,
 }
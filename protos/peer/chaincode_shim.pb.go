@@ -49,6 +49,8 @@ const (
 	ChaincodeMessage_GET_STATE_METADATA    ChaincodeMessage_Type = 20
 	ChaincodeMessage_PUT_STATE_METADATA    ChaincodeMessage_Type = 21
 	ChaincodeMessage_GET_PRIVATE_DATA_HASH ChaincodeMessage_Type = 22
+	ChaincodeMessage_GET_STATE_BY_INDEX    ChaincodeMessage_Type = 23
+	ChaincodeMessage_DEL_STATE_BY_RANGE    ChaincodeMessage_Type = 24
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -74,6 +76,8 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	20: "GET_STATE_METADATA",
 	21: "PUT_STATE_METADATA",
 	22: "GET_PRIVATE_DATA_HASH",
+	23: "GET_STATE_BY_INDEX",
+	24: "DEL_STATE_BY_RANGE",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
 	"UNDEFINED":             0,
@@ -98,13 +102,15 @@ var ChaincodeMessage_Type_value = map[string]int32{
 	"GET_STATE_METADATA":    20,
 	"PUT_STATE_METADATA":    21,
 	"GET_PRIVATE_DATA_HASH": 22,
+	"GET_STATE_BY_INDEX":    23,
+	"DEL_STATE_BY_RANGE":    24,
 }
 
 func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
 func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0, 0}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{0, 0}
 }
 
 type ChaincodeMessage struct {
@@ -128,7 +134,7 @@ func (m *ChaincodeMessage) Reset()         { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()    {}
 func (*ChaincodeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{0}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{0}
 }
 func (m *ChaincodeMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeMessage.Unmarshal(m, b)
@@ -212,7 +218,7 @@ func (m *GetState) Reset()         { *m = GetState{} }
 func (m *GetState) String() string { return proto.CompactTextString(m) }
 func (*GetState) ProtoMessage()    {}
 func (*GetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{1}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{1}
 }
 func (m *GetState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetState.Unmarshal(m, b)
@@ -258,7 +264,7 @@ func (m *GetStateMetadata) Reset()         { *m = GetStateMetadata{} }
 func (m *GetStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*GetStateMetadata) ProtoMessage()    {}
 func (*GetStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{2}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{2}
 }
 func (m *GetStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateMetadata.Unmarshal(m, b)
@@ -309,7 +315,7 @@ func (m *PutState) Reset()         { *m = PutState{} }
 func (m *PutState) String() string { return proto.CompactTextString(m) }
 func (*PutState) ProtoMessage()    {}
 func (*PutState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{3}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{3}
 }
 func (m *PutState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutState.Unmarshal(m, b)
@@ -363,7 +369,7 @@ func (m *PutStateMetadata) Reset()         { *m = PutStateMetadata{} }
 func (m *PutStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*PutStateMetadata) ProtoMessage()    {}
 func (*PutStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{4}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{4}
 }
 func (m *PutStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutStateMetadata.Unmarshal(m, b)
@@ -420,7 +426,7 @@ func (m *DelState) Reset()         { *m = DelState{} }
 func (m *DelState) String() string { return proto.CompactTextString(m) }
 func (*DelState) ProtoMessage()    {}
 func (*DelState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{5}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{5}
 }
 func (m *DelState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelState.Unmarshal(m, b)
@@ -454,6 +460,55 @@ func (m *DelState) GetCollection() string {
 	return ""
 }
 
+// DelStateByRange is the payload of a ChaincodeMessage. It contains a start
+// key and an end key of the range of keys to be deleted from the public state.
+// The start key is included in the range and the end key is excluded.
+type DelStateByRange struct {
+	StartKey             string   `protobuf:"bytes,1,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey               string   `protobuf:"bytes,2,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DelStateByRange) Reset()         { *m = DelStateByRange{} }
+func (m *DelStateByRange) String() string { return proto.CompactTextString(m) }
+func (*DelStateByRange) ProtoMessage()    {}
+func (*DelStateByRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{6}
+}
+func (m *DelStateByRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DelStateByRange.Unmarshal(m, b)
+}
+func (m *DelStateByRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DelStateByRange.Marshal(b, m, deterministic)
+}
+func (dst *DelStateByRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelStateByRange.Merge(dst, src)
+}
+func (m *DelStateByRange) XXX_Size() int {
+	return xxx_messageInfo_DelStateByRange.Size(m)
+}
+func (m *DelStateByRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelStateByRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DelStateByRange proto.InternalMessageInfo
+
+func (m *DelStateByRange) GetStartKey() string {
+	if m != nil {
+		return m.StartKey
+	}
+	return ""
+}
+
+func (m *DelStateByRange) GetEndKey() string {
+	if m != nil {
+		return m.EndKey
+	}
+	return ""
+}
+
 // GetStateByRange is the payload of a ChaincodeMessage. It contains a start key and
 // a end key required to execute range query. If the collection is specified,
 // the range query needs to be executed on the private data. The metadata hold
@@ -472,7 +527,7 @@ func (m *GetStateByRange) Reset()         { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()    {}
 func (*GetStateByRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{6}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{7}
 }
 func (m *GetStateByRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateByRange.Unmarshal(m, b)
@@ -537,7 +592,7 @@ func (m *GetQueryResult) Reset()         { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()    {}
 func (*GetQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{7}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{8}
 }
 func (m *GetQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetQueryResult.Unmarshal(m, b)
@@ -578,6 +633,55 @@ func (m *GetQueryResult) GetMetadata() []byte {
 	return nil
 }
 
+// GetStateByIndex is the payload of a ChaincodeMessage. It contains the name of
+// a secondary index of the state database and the JSON encoded array of the values
+// of the leading fields of the index that the results are required to match.
+type GetStateByIndex struct {
+	IndexName            string   `protobuf:"bytes,1,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	Values               []byte   `protobuf:"bytes,2,opt,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateByIndex) Reset()         { *m = GetStateByIndex{} }
+func (m *GetStateByIndex) String() string { return proto.CompactTextString(m) }
+func (*GetStateByIndex) ProtoMessage()    {}
+func (*GetStateByIndex) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{9}
+}
+func (m *GetStateByIndex) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateByIndex.Unmarshal(m, b)
+}
+func (m *GetStateByIndex) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateByIndex.Marshal(b, m, deterministic)
+}
+func (dst *GetStateByIndex) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateByIndex.Merge(dst, src)
+}
+func (m *GetStateByIndex) XXX_Size() int {
+	return xxx_messageInfo_GetStateByIndex.Size(m)
+}
+func (m *GetStateByIndex) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateByIndex.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateByIndex proto.InternalMessageInfo
+
+func (m *GetStateByIndex) GetIndexName() string {
+	if m != nil {
+		return m.IndexName
+	}
+	return ""
+}
+
+func (m *GetStateByIndex) GetValues() []byte {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 // QueryMetadata is the metadata of a GetStateByRange and GetQueryResult.
 // It contains a pageSize which denotes the number of records to be fetched
 // and a bookmark.
@@ -593,7 +697,7 @@ func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
 func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryMetadata) ProtoMessage()    {}
 func (*QueryMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{8}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{10}
 }
 func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
@@ -630,7 +734,21 @@ func (m *QueryMetadata) GetBookmark() string {
 // GetHistoryForKey is the payload of a ChaincodeMessage. It contains a key
 // for which the historical values need to be retrieved.
 type GetHistoryForKey struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// collection is set if the history of a private data key is retrieved
+	Collection string `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	// start_block_num and start_tx_num give the height (inclusive) from which
+	// the history is retrieved
+	StartBlockNum uint64 `protobuf:"varint,3,opt,name=start_block_num,json=startBlockNum,proto3" json:"start_block_num,omitempty"`
+	StartTxNum    uint64 `protobuf:"varint,4,opt,name=start_tx_num,json=startTxNum,proto3" json:"start_tx_num,omitempty"`
+	// end_block_num and end_tx_num give the height (exclusive) up to which the
+	// history is retrieved. The history is not bounded at the end if both are zero
+	EndBlockNum uint64 `protobuf:"varint,5,opt,name=end_block_num,json=endBlockNum,proto3" json:"end_block_num,omitempty"`
+	EndTxNum    uint64 `protobuf:"varint,6,opt,name=end_tx_num,json=endTxNum,proto3" json:"end_tx_num,omitempty"`
+	// descending is set if the history is retrieved newest-first
+	Descending bool `protobuf:"varint,7,opt,name=descending,proto3" json:"descending,omitempty"`
+	// metadata is a marshalled QueryMetadata used for the pagination of the history
+	Metadata             []byte   `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -640,7 +758,7 @@ func (m *GetHistoryForKey) Reset()         { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()    {}
 func (*GetHistoryForKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{9}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{11}
 }
 func (m *GetHistoryForKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryForKey.Unmarshal(m, b)
@@ -667,6 +785,55 @@ func (m *GetHistoryForKey) GetKey() string {
 	return ""
 }
 
+func (m *GetHistoryForKey) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *GetHistoryForKey) GetStartBlockNum() uint64 {
+	if m != nil {
+		return m.StartBlockNum
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetStartTxNum() uint64 {
+	if m != nil {
+		return m.StartTxNum
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetEndBlockNum() uint64 {
+	if m != nil {
+		return m.EndBlockNum
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetEndTxNum() uint64 {
+	if m != nil {
+		return m.EndTxNum
+	}
+	return 0
+}
+
+func (m *GetHistoryForKey) GetDescending() bool {
+	if m != nil {
+		return m.Descending
+	}
+	return false
+}
+
+func (m *GetHistoryForKey) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 type QueryStateNext struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -678,7 +845,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{10}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{12}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -716,7 +883,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{11}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{13}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -755,7 +922,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{12}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{14}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -783,7 +950,7 @@ func (m *QueryResultBytes) GetResultBytes() []byte {
 }
 
 // QueryResponse is returned by the peer as a result of a GetStateByRange,
-// GetQueryResult, and GetHistoryForKey. It holds a bunch of records in
+// GetQueryResult, GetStateByIndex, and GetHistoryForKey. It holds a bunch of records in
 // results field, a flag to denote whether more results need to be fetched from
 // the peer in has_more field, transaction id in id field, and a QueryResponseMetadata
 // in metadata field.
@@ -801,7 +968,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{13}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{15}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -863,7 +1030,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{14}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{16}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -909,7 +1076,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{15}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{17}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -954,7 +1121,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_b04d3028f86b65a2, []int{16}
+	return fileDescriptor_chaincode_shim_db91a351f46763c2, []int{18}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -988,8 +1155,10 @@ func init() {
 	proto.RegisterType((*PutState)(nil), "protos.PutState")
 	proto.RegisterType((*PutStateMetadata)(nil), "protos.PutStateMetadata")
 	proto.RegisterType((*DelState)(nil), "protos.DelState")
+	proto.RegisterType((*DelStateByRange)(nil), "protos.DelStateByRange")
 	proto.RegisterType((*GetStateByRange)(nil), "protos.GetStateByRange")
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
+	proto.RegisterType((*GetStateByIndex)(nil), "protos.GetStateByIndex")
 	proto.RegisterType((*QueryMetadata)(nil), "protos.QueryMetadata")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
 	proto.RegisterType((*QueryStateNext)(nil), "protos.QueryStateNext")
@@ -1107,74 +1276,84 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 }
 
 func init() {
-	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_b04d3028f86b65a2)
+	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_db91a351f46763c2)
 }
 
-var fileDescriptor_chaincode_shim_b04d3028f86b65a2 = []byte{
-	// 1027 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_chaincode_shim_db91a351f46763c2 = []byte{
+	// 1191 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x0e, 0x2e, 0x66, 0x26, 0x2d, 0x65, 0x7a, 0xa0,
-	0x17, 0x68, 0x68, 0x0f, 0x3d, 0x74, 0x26, 0x83, 0x61, 0x8d, 0x19, 0xdb, 0x40, 0x56, 0xb2, 0x27,
-	0xee, 0x45, 0x23, 0xa4, 0xb5, 0xd0, 0x58, 0x68, 0x55, 0x69, 0x49, 0x43, 0x6f, 0xbd, 0xf6, 0xd8,
-	0x3f, 0xae, 0x7f, 0x4f, 0x67, 0xf5, 0xcb, 0x80, 0xeb, 0xa4, 0x93, 0x13, 0xfa, 0xde, 0xfb, 0xf6,
-	0x7b, 0xbf, 0xf6, 0x21, 0xc1, 0xa9, 0xcf, 0x58, 0xd0, 0x35, 0x17, 0x86, 0xe3, 0x99, 0xdc, 0x62,
-	0x7a, 0xb8, 0x70, 0x96, 0x1d, 0x3f, 0xe0, 0x82, 0xe3, 0xfd, 0xe8, 0x27, 0x6c, 0x34, 0x76, 0x28,
-	0xec, 0x03, 0xf3, 0x44, 0xcc, 0x69, 0x1c, 0x47, 0x3e, 0x3f, 0xe0, 0x3e, 0x0f, 0x0d, 0x37, 0x31,
-	0x7e, 0x63, 0x73, 0x6e, 0xbb, 0xac, 0x1b, 0xa1, 0xf9, 0xea, 0xbe, 0x2b, 0x9c, 0x25, 0x0b, 0x85,
-	0xb1, 0xf4, 0x63, 0x42, 0xeb, 0x9f, 0x22, 0xa0, 0x41, 0xaa, 0x77, 0xcd, 0xc2, 0xd0, 0xb0, 0x19,
-	0x7e, 0x03, 0x05, 0xb1, 0xf6, 0x59, 0x3d, 0xd7, 0xcc, 0xb5, 0xab, 0xbd, 0xd7, 0x31, 0x35, 0xec,
-	0xec, 0xf2, 0x3a, 0xda, 0xda, 0x67, 0x34, 0xa2, 0xe2, 0x9f, 0xa1, 0x9c, 0x49, 0xd7, 0xf7, 0x9a,
-	0xb9, 0x76, 0xa5, 0xd7, 0xe8, 0xc4, 0xc1, 0x3b, 0x69, 0xf0, 0x8e, 0x96, 0x32, 0xe8, 0x23, 0x19,
-	0xd7, 0xa1, 0xe4, 0x1b, 0x6b, 0x97, 0x1b, 0x56, 0x3d, 0xdf, 0xcc, 0xb5, 0x0f, 0x68, 0x0a, 0x31,
-	0x86, 0x82, 0xf8, 0xe8, 0x58, 0xf5, 0x42, 0x33, 0xd7, 0x2e, 0xd3, 0xe8, 0x19, 0xf7, 0x40, 0x49,
-	0x4b, 0xac, 0x17, 0xa3, 0x30, 0x27, 0x69, 0x7a, 0xaa, 0x63, 0x7b, 0xcc, 0x9a, 0x25, 0x5e, 0x9a,
-	0xf1, 0xf0, 0x5b, 0x38, 0xda, 0x69, 0x59, 0x7d, 0x7f, 0xfb, 0x68, 0x56, 0x19, 0x91, 0x5e, 0x5a,
-	0x35, 0xb7, 0x30, 0x7e, 0x0d, 0x60, 0x2e, 0x0c, 0xcf, 0x63, 0xae, 0xee, 0x58, 0xf5, 0x52, 0x94,
-	0x4e, 0x39, 0xb1, 0x8c, 0xad, 0xd6, 0xdf, 0x79, 0x28, 0xc8, 0x56, 0xe0, 0x43, 0x28, 0xdf, 0x4c,
-	0x86, 0xe4, 0x7c, 0x3c, 0x21, 0x43, 0xf4, 0x02, 0x1f, 0x80, 0x42, 0xc9, 0x68, 0xac, 0x6a, 0x84,
-	0xa2, 0x1c, 0xae, 0x02, 0xa4, 0x88, 0x0c, 0xd1, 0x1e, 0x56, 0xa0, 0x30, 0x9e, 0x8c, 0x35, 0x94,
-	0xc7, 0x65, 0x28, 0x52, 0xd2, 0x1f, 0xde, 0xa1, 0x02, 0x3e, 0x82, 0x8a, 0x46, 0xfb, 0x13, 0xb5,
-	0x3f, 0xd0, 0xc6, 0xd3, 0x09, 0x2a, 0x4a, 0xc9, 0xc1, 0xf4, 0x7a, 0x76, 0x45, 0x34, 0x32, 0x44,
-	0xfb, 0x92, 0x4a, 0x28, 0x9d, 0x52, 0x54, 0x92, 0x9e, 0x11, 0xd1, 0x74, 0x55, 0xeb, 0x6b, 0x04,
-	0x29, 0x12, 0xce, 0x6e, 0x52, 0x58, 0x96, 0x70, 0x48, 0xae, 0x12, 0x08, 0xf8, 0x15, 0xa0, 0xf1,
-	0xe4, 0x76, 0x7a, 0x49, 0xf4, 0xc1, 0x45, 0x7f, 0x3c, 0x19, 0x4c, 0x87, 0x04, 0x55, 0xe2, 0x04,
-	0xd5, 0xd9, 0x74, 0xa2, 0x12, 0x74, 0x88, 0x4f, 0x00, 0x67, 0x82, 0xfa, 0xd9, 0x9d, 0x4e, 0xfb,
-	0x93, 0x11, 0x41, 0x55, 0x79, 0x56, 0xda, 0xdf, 0xdd, 0x10, 0x7a, 0xa7, 0x53, 0xa2, 0xde, 0x5c,
-	0x69, 0xe8, 0x48, 0x5a, 0x63, 0x4b, 0xcc, 0x9f, 0x90, 0xf7, 0x1a, 0x42, 0xb8, 0x06, 0x2f, 0x37,
-	0xad, 0x83, 0xab, 0xa9, 0x4a, 0xd0, 0x4b, 0x99, 0xcd, 0x25, 0x21, 0xb3, 0xfe, 0xd5, 0xf8, 0x96,
-	0x20, 0x8c, 0xbf, 0x82, 0x63, 0xa9, 0x78, 0x31, 0x56, 0xb5, 0x29, 0xbd, 0xd3, 0xcf, 0xa7, 0x54,
-	0xbf, 0x24, 0x77, 0xe8, 0x78, 0x3b, 0x85, 0x6b, 0xa2, 0xf5, 0x87, 0x7d, 0xad, 0x8f, 0x5e, 0x49,
-	0x7b, 0x56, 0xdc, 0xa3, 0xbd, 0x86, 0x4f, 0xa1, 0x26, 0xf9, 0x33, 0x3a, 0xbe, 0x95, 0x1e, 0x69,
-	0xd5, 0x2f, 0xfa, 0xea, 0x05, 0x3a, 0x69, 0xfd, 0x02, 0xca, 0x88, 0x09, 0x55, 0x18, 0x82, 0x61,
-	0x04, 0xf9, 0x07, 0xb6, 0x8e, 0xae, 0x73, 0x99, 0xca, 0x47, 0xfc, 0x35, 0x80, 0xc9, 0x5d, 0x97,
-	0x99, 0xc2, 0xe1, 0x5e, 0x74, 0x5f, 0xcb, 0x74, 0xc3, 0xd2, 0x1a, 0x02, 0x4a, 0x4f, 0x5f, 0x33,
-	0x61, 0x58, 0x86, 0x30, 0xbe, 0x40, 0x85, 0x82, 0x32, 0x5b, 0x3d, 0x9b, 0xc3, 0x2b, 0x28, 0x7e,
-	0x30, 0xdc, 0x15, 0x8b, 0x0e, 0x1e, 0xd0, 0x18, 0xec, 0x68, 0xe6, 0x9f, 0x68, 0xfe, 0x0e, 0x28,
-	0xd5, 0xfc, 0xdf, 0x99, 0x3d, 0x51, 0xc1, 0x6f, 0x40, 0x59, 0x26, 0xa7, 0xa3, 0xf5, 0xaa, 0xf4,
-	0x6a, 0xd9, 0x1a, 0x6d, 0x4a, 0xd3, 0x8c, 0x26, 0x1b, 0x3a, 0x64, 0xee, 0x97, 0x36, 0xf4, 0xcf,
-	0x1c, 0x1c, 0xa5, 0x1d, 0x3d, 0x5b, 0x53, 0xc3, 0xb3, 0x19, 0x6e, 0x80, 0x12, 0x0a, 0x23, 0x10,
-	0x97, 0x99, 0x54, 0x86, 0xf1, 0x09, 0xec, 0x33, 0xcf, 0x92, 0x9e, 0x58, 0x2b, 0x41, 0x9f, 0x2d,
-	0xac, 0xb1, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8e, 0x98, 0x78, 0xb7, 0x62, 0xc1, 0x9a,
-	0xb2, 0x70, 0xe5, 0x0a, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc2, 0xc7, 0xe0, 0x73, 0xb5, 0x6c, 0xc5,
-	0xc8, 0xef, 0xc4, 0x18, 0xc1, 0x61, 0x14, 0x20, 0x9b, 0x4d, 0x03, 0x14, 0xdf, 0xb0, 0x99, 0xea,
-	0xfc, 0x11, 0xff, 0x9f, 0x16, 0x69, 0x86, 0xa5, 0x6f, 0xce, 0xf9, 0xc3, 0xd2, 0x08, 0x1e, 0x92,
-	0x30, 0x19, 0x6e, 0x7d, 0x17, 0xdd, 0xc0, 0x0b, 0x27, 0x14, 0x3c, 0x58, 0x9f, 0xf3, 0x40, 0x16,
-	0xff, 0xa4, 0xed, 0xad, 0x26, 0x54, 0xa3, 0x70, 0x51, 0x5f, 0x27, 0xec, 0xa3, 0xc0, 0x55, 0xd8,
-	0x73, 0xac, 0x84, 0xb2, 0xe7, 0x58, 0xad, 0x6f, 0xe1, 0xe8, 0x91, 0x31, 0x70, 0x79, 0xc8, 0x9e,
-	0x50, 0x7e, 0x02, 0xb4, 0xd1, 0x94, 0xb3, 0xb5, 0x60, 0x21, 0x6e, 0x42, 0x25, 0x78, 0x84, 0x11,
-	0xf9, 0x80, 0x6e, 0x9a, 0x5a, 0x7f, 0xe5, 0x92, 0x52, 0x29, 0x0b, 0x7d, 0xee, 0x85, 0x0c, 0xf7,
-	0xa0, 0x14, 0x13, 0x24, 0x3f, 0xdf, 0xae, 0xf4, 0xea, 0xe9, 0x9d, 0xda, 0x95, 0xa7, 0x29, 0x11,
-	0x9f, 0x82, 0xb2, 0x30, 0x42, 0x7d, 0xc9, 0x83, 0x78, 0x0f, 0x14, 0x5a, 0x5a, 0x18, 0xe1, 0x35,
-	0x0f, 0xd2, 0x34, 0xf3, 0x69, 0x9a, 0x9f, 0x1c, 0xad, 0x0d, 0xb5, 0xad, 0x5c, 0xb2, 0xf6, 0xf7,
-	0xa0, 0x76, 0xcf, 0x84, 0xb9, 0x60, 0x96, 0x1e, 0x30, 0x93, 0x07, 0x56, 0xa8, 0x9b, 0x7c, 0xe5,
-	0x89, 0x64, 0x16, 0xc7, 0x89, 0x93, 0xc6, 0xbe, 0x81, 0x74, 0x7d, 0x72, 0x2c, 0x6f, 0xe1, 0x70,
-	0x7b, 0xf7, 0xea, 0x50, 0x92, 0x59, 0x3c, 0xce, 0x25, 0x85, 0xff, 0xbd, 0xdf, 0xad, 0x73, 0x38,
-	0xde, 0xde, 0xb0, 0xf8, 0x26, 0x76, 0xa1, 0xc4, 0x3c, 0x11, 0x38, 0x2c, 0xed, 0xdd, 0x33, 0xfb,
-	0x98, 0xb2, 0x7a, 0xef, 0x37, 0xde, 0xdb, 0xea, 0xca, 0xf7, 0x79, 0x20, 0xf0, 0x10, 0x14, 0xca,
-	0x6c, 0x27, 0x14, 0x2c, 0xc0, 0xf5, 0xe7, 0xde, 0xda, 0x8d, 0x67, 0x3d, 0xad, 0x17, 0xed, 0xdc,
-	0x0f, 0xb9, 0xb3, 0x29, 0xb4, 0x78, 0x60, 0x77, 0x16, 0x6b, 0x9f, 0x05, 0x2e, 0xb3, 0x6c, 0x16,
-	0x74, 0xee, 0x8d, 0x79, 0xe0, 0x98, 0xe9, 0x39, 0xf9, 0xa1, 0xf1, 0xeb, 0xf7, 0xb6, 0x23, 0x16,
-	0xab, 0x79, 0xc7, 0xe4, 0xcb, 0xee, 0x06, 0xb5, 0x1b, 0x53, 0xe3, 0x0f, 0x8e, 0xb0, 0x2b, 0xa9,
-	0xf3, 0xf8, 0xeb, 0xe5, 0xc7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x3e, 0x82, 0x3d, 0x52, 0xe1,
-	0x08, 0x00, 0x00,
+	0x14, 0x0e, 0x06, 0x1b, 0xf1, 0xb0, 0x61, 0xb3, 0x8e, 0x1d, 0x42, 0x9b, 0x94, 0x72, 0xe8, 0xb8,
+	0x17, 0x68, 0x68, 0x0f, 0x3d, 0x74, 0x26, 0x83, 0x61, 0x8d, 0x35, 0xb6, 0x05, 0x59, 0xc9, 0x99,
+	0xb8, 0x17, 0x8d, 0x90, 0xd6, 0xa0, 0x31, 0x48, 0xaa, 0xb4, 0xa4, 0xa6, 0xb7, 0x5e, 0x7a, 0xe8,
+	0xf4, 0xaf, 0xea, 0x5f, 0xd6, 0xd9, 0xd5, 0x0f, 0x7e, 0xb8, 0x4e, 0x26, 0x39, 0xa1, 0xef, 0x7b,
+	0xdf, 0x7e, 0xfb, 0xf6, 0xed, 0xdb, 0x65, 0xe1, 0x45, 0xc0, 0x58, 0xd8, 0xb6, 0xa7, 0x96, 0xeb,
+	0xd9, 0xbe, 0xc3, 0xcc, 0x68, 0xea, 0xce, 0x5b, 0x41, 0xe8, 0x73, 0x1f, 0xef, 0xc9, 0x9f, 0xa8,
+	0x5e, 0xdf, 0x92, 0xb0, 0x0f, 0xcc, 0xe3, 0xb1, 0xa6, 0x7e, 0x28, 0x63, 0x41, 0xe8, 0x07, 0x7e,
+	0x64, 0xcd, 0x12, 0xf2, 0x9b, 0x89, 0xef, 0x4f, 0x66, 0xac, 0x2d, 0xd1, 0x78, 0x71, 0xdb, 0xe6,
+	0xee, 0x9c, 0x45, 0xdc, 0x9a, 0x07, 0xb1, 0xa0, 0xf9, 0xd7, 0x1e, 0xa0, 0x5e, 0xea, 0x77, 0xc5,
+	0xa2, 0xc8, 0x9a, 0x30, 0xfc, 0x1a, 0x0a, 0x7c, 0x19, 0xb0, 0x5a, 0xae, 0x91, 0x3b, 0xa9, 0x74,
+	0x5e, 0xc6, 0xd2, 0xa8, 0xb5, 0xad, 0x6b, 0x19, 0xcb, 0x80, 0x51, 0x29, 0xc5, 0x3f, 0x43, 0x29,
+	0xb3, 0xae, 0xed, 0x34, 0x72, 0x27, 0xe5, 0x4e, 0xbd, 0x15, 0x4f, 0xde, 0x4a, 0x27, 0x6f, 0x19,
+	0xa9, 0x82, 0xae, 0xc4, 0xb8, 0x06, 0xc5, 0xc0, 0x5a, 0xce, 0x7c, 0xcb, 0xa9, 0xe5, 0x1b, 0xb9,
+	0x93, 0x7d, 0x9a, 0x42, 0x8c, 0xa1, 0xc0, 0xef, 0x5d, 0xa7, 0x56, 0x68, 0xe4, 0x4e, 0x4a, 0x54,
+	0x7e, 0xe3, 0x0e, 0x28, 0xe9, 0x12, 0x6b, 0xbb, 0x72, 0x9a, 0xe3, 0x34, 0x3d, 0xdd, 0x9d, 0x78,
+	0xcc, 0x19, 0x25, 0x51, 0x9a, 0xe9, 0xf0, 0x1b, 0xa8, 0x6e, 0x95, 0xac, 0xb6, 0xb7, 0x39, 0x34,
+	0x5b, 0x19, 0x11, 0x51, 0x5a, 0xb1, 0x37, 0x30, 0x7e, 0x09, 0x60, 0x4f, 0x2d, 0xcf, 0x63, 0x33,
+	0xd3, 0x75, 0x6a, 0x45, 0x99, 0x4e, 0x29, 0x61, 0x54, 0xa7, 0xf9, 0x6f, 0x1e, 0x0a, 0xa2, 0x14,
+	0xf8, 0x00, 0x4a, 0xd7, 0x5a, 0x9f, 0x9c, 0xa9, 0x1a, 0xe9, 0xa3, 0x27, 0x78, 0x1f, 0x14, 0x4a,
+	0x06, 0xaa, 0x6e, 0x10, 0x8a, 0x72, 0xb8, 0x02, 0x90, 0x22, 0xd2, 0x47, 0x3b, 0x58, 0x81, 0x82,
+	0xaa, 0xa9, 0x06, 0xca, 0xe3, 0x12, 0xec, 0x52, 0xd2, 0xed, 0xdf, 0xa0, 0x02, 0xae, 0x42, 0xd9,
+	0xa0, 0x5d, 0x4d, 0xef, 0xf6, 0x0c, 0x75, 0xa8, 0xa1, 0x5d, 0x61, 0xd9, 0x1b, 0x5e, 0x8d, 0x2e,
+	0x89, 0x41, 0xfa, 0x68, 0x4f, 0x48, 0x09, 0xa5, 0x43, 0x8a, 0x8a, 0x22, 0x32, 0x20, 0x86, 0xa9,
+	0x1b, 0x5d, 0x83, 0x20, 0x45, 0xc0, 0xd1, 0x75, 0x0a, 0x4b, 0x02, 0xf6, 0xc9, 0x65, 0x02, 0x01,
+	0x3f, 0x03, 0xa4, 0x6a, 0xef, 0x86, 0x17, 0xc4, 0xec, 0x9d, 0x77, 0x55, 0xad, 0x37, 0xec, 0x13,
+	0x54, 0x8e, 0x13, 0xd4, 0x47, 0x43, 0x4d, 0x27, 0xe8, 0x00, 0x1f, 0x03, 0xce, 0x0c, 0xcd, 0xd3,
+	0x1b, 0x93, 0x76, 0xb5, 0x01, 0x41, 0x15, 0x31, 0x56, 0xf0, 0x6f, 0xaf, 0x09, 0xbd, 0x31, 0x29,
+	0xd1, 0xaf, 0x2f, 0x0d, 0x54, 0x15, 0x6c, 0xcc, 0xc4, 0x7a, 0x8d, 0xbc, 0x37, 0x10, 0xc2, 0x47,
+	0xf0, 0x74, 0x9d, 0xed, 0x5d, 0x0e, 0x75, 0x82, 0x9e, 0x8a, 0x6c, 0x2e, 0x08, 0x19, 0x75, 0x2f,
+	0xd5, 0x77, 0x04, 0x61, 0xfc, 0x1c, 0x0e, 0x85, 0xe3, 0xb9, 0xaa, 0x1b, 0x43, 0x7a, 0x63, 0x9e,
+	0x0d, 0xa9, 0x79, 0x41, 0x6e, 0xd0, 0xe1, 0x66, 0x0a, 0x57, 0xc4, 0xe8, 0xf6, 0xbb, 0x46, 0x17,
+	0x3d, 0x13, 0xfc, 0xe8, 0xfa, 0x01, 0x7f, 0x84, 0x5f, 0xc0, 0x91, 0xd0, 0x8f, 0xa8, 0xfa, 0x4e,
+	0x44, 0x04, 0x6b, 0x9e, 0x77, 0xf5, 0x73, 0x74, 0xfc, 0x60, 0x35, 0xaa, 0xd6, 0x27, 0xef, 0xd1,
+	0x73, 0xc1, 0x67, 0x85, 0x59, 0xad, 0xb2, 0xd6, 0xfc, 0x05, 0x94, 0x01, 0xe3, 0x3a, 0xb7, 0x38,
+	0xc3, 0x08, 0xf2, 0x77, 0x6c, 0x29, 0xdb, 0xbf, 0x44, 0xc5, 0x27, 0x7e, 0x05, 0x60, 0xfb, 0xb3,
+	0x19, 0xb3, 0xb9, 0xeb, 0x7b, 0xb2, 0xbf, 0x4b, 0x74, 0x8d, 0x69, 0xf6, 0x01, 0xa5, 0xa3, 0xaf,
+	0x18, 0xb7, 0x1c, 0x8b, 0x5b, 0x5f, 0xe0, 0x42, 0x41, 0x19, 0x2d, 0x1e, 0xcd, 0xe1, 0x19, 0xec,
+	0x7e, 0xb0, 0x66, 0x0b, 0x26, 0x07, 0xee, 0xd3, 0x18, 0x6c, 0x79, 0xe6, 0x1f, 0x78, 0xfe, 0x0e,
+	0x68, 0xb4, 0xf8, 0xcc, 0xcc, 0x1e, 0xb8, 0xe0, 0xd7, 0xa0, 0xcc, 0x93, 0xd1, 0xf2, 0x38, 0x96,
+	0x3b, 0x47, 0xd9, 0xb1, 0x5b, 0xb7, 0xa6, 0x99, 0x4c, 0x14, 0xb4, 0xcf, 0x66, 0x5f, 0x5a, 0xd0,
+	0x01, 0x54, 0xd3, 0xd1, 0xa7, 0x4b, 0x6a, 0x79, 0x13, 0x86, 0xbf, 0x82, 0x52, 0xc4, 0xad, 0x90,
+	0x9b, 0x2b, 0x2b, 0x45, 0x12, 0x17, 0x6c, 0x89, 0x9f, 0x43, 0x91, 0x79, 0x8e, 0x0c, 0xc5, 0x66,
+	0x7b, 0xcc, 0x73, 0x2e, 0xd8, 0xb2, 0xf9, 0x67, 0x0e, 0xaa, 0xe9, 0xd6, 0xa4, 0x4e, 0x75, 0xc8,
+	0x06, 0x3e, 0x30, 0x3a, 0x86, 0x64, 0xe4, 0xa6, 0xcf, 0x27, 0x2b, 0x54, 0xdf, 0xaa, 0xd0, 0xfe,
+	0x5a, 0x29, 0xc6, 0x50, 0x19, 0x30, 0xfe, 0x76, 0xc1, 0xc2, 0x25, 0x65, 0xd1, 0x62, 0xc6, 0xc5,
+	0x5e, 0xfe, 0x26, 0x60, 0x32, 0x7d, 0x0c, 0x3e, 0x55, 0x94, 0x8d, 0x39, 0xf2, 0x5b, 0x73, 0x9c,
+	0xaf, 0x2f, 0x53, 0xf5, 0x1c, 0x76, 0x2f, 0xae, 0x2d, 0x57, 0x7c, 0x98, 0x9e, 0x35, 0x67, 0xc9,
+	0x4c, 0x25, 0xc9, 0x68, 0xd6, 0x9c, 0x89, 0x95, 0xca, 0x16, 0x8a, 0x92, 0x86, 0x4a, 0x50, 0x73,
+	0x00, 0x07, 0x32, 0xd5, 0xac, 0x5d, 0xea, 0xa0, 0x04, 0xd6, 0x84, 0xe9, 0xee, 0x1f, 0xb1, 0xcb,
+	0x2e, 0xcd, 0xb0, 0x88, 0x8d, 0x7d, 0xff, 0x6e, 0x6e, 0x85, 0x77, 0x49, 0xc2, 0x19, 0x6e, 0xfe,
+	0xb3, 0x23, 0x4f, 0xc5, 0xb9, 0x1b, 0x71, 0x3f, 0x5c, 0x9e, 0xf9, 0xa1, 0xa8, 0xe3, 0x67, 0xb7,
+	0x02, 0xfe, 0x0e, 0xaa, 0xf1, 0xbe, 0x8f, 0x67, 0xbe, 0x7d, 0x67, 0x7a, 0x8b, 0xb9, 0x5c, 0x7c,
+	0x81, 0x1e, 0x48, 0xfa, 0x54, 0xb0, 0xda, 0x62, 0x8e, 0x1b, 0xb0, 0x1f, 0xeb, 0xf8, 0xbd, 0x14,
+	0x15, 0xa4, 0x08, 0x24, 0x67, 0xdc, 0x0b, 0x45, 0x13, 0x0e, 0x44, 0x93, 0xac, 0x7c, 0x76, 0xa5,
+	0xa4, 0xcc, 0x3c, 0x27, 0x73, 0xf9, 0x1a, 0x40, 0x68, 0x12, 0x8f, 0x3d, 0x29, 0x50, 0x98, 0xe7,
+	0xc4, 0x0e, 0xaf, 0x00, 0x1c, 0x16, 0xd9, 0xcc, 0x73, 0x5c, 0x6f, 0x22, 0xff, 0x09, 0x14, 0xba,
+	0xc6, 0x6c, 0xec, 0x90, 0xb2, 0xb5, 0x43, 0x0d, 0xa8, 0xc8, 0xba, 0xca, 0x3d, 0xd2, 0xd8, 0x3d,
+	0xc7, 0x15, 0xd8, 0x71, 0x9d, 0xa4, 0x14, 0x3b, 0xae, 0xd3, 0xfc, 0x16, 0xaa, 0x2b, 0x45, 0x6f,
+	0xe6, 0x47, 0xec, 0x81, 0xe4, 0x27, 0x40, 0x6b, 0x7d, 0x74, 0xba, 0xe4, 0x2c, 0xc2, 0x0d, 0x28,
+	0x87, 0x2b, 0x28, 0xc5, 0xfb, 0x74, 0x9d, 0x6a, 0xfe, 0x9d, 0x4b, 0xf6, 0x94, 0xb2, 0x28, 0xf0,
+	0xbd, 0x88, 0xe1, 0x0e, 0x14, 0x63, 0x81, 0xd0, 0xe7, 0x4f, 0xca, 0x9d, 0x5a, 0x7a, 0x9e, 0xb7,
+	0xed, 0x69, 0x2a, 0xc4, 0x2f, 0x40, 0x99, 0x5a, 0x91, 0x39, 0xf7, 0xc3, 0xf8, 0x0e, 0x52, 0x68,
+	0x71, 0x6a, 0x45, 0x57, 0x7e, 0x98, 0xa6, 0x99, 0x4f, 0xd3, 0xfc, 0xe8, 0x69, 0x98, 0xc0, 0xd1,
+	0x46, 0x2e, 0x59, 0x9f, 0x75, 0xe0, 0xe8, 0x96, 0x71, 0x7b, 0xca, 0x1c, 0x33, 0x64, 0xb6, 0x1f,
+	0x3a, 0x91, 0x69, 0xfb, 0x0b, 0x8f, 0x27, 0x4d, 0x77, 0x98, 0x04, 0x69, 0x1c, 0xeb, 0x89, 0xd0,
+	0x47, 0xfb, 0xef, 0x0d, 0x1c, 0x6c, 0xde, 0x7b, 0x35, 0x28, 0x8a, 0x2c, 0x56, 0xfd, 0x97, 0xc2,
+	0xff, 0xbf, 0x5b, 0x9b, 0x67, 0x70, 0xb8, 0x79, 0xbb, 0xc5, 0x87, 0xb7, 0x2d, 0xee, 0x1a, 0x1e,
+	0xba, 0x2c, 0xad, 0xdd, 0x23, 0x77, 0x61, 0xaa, 0xea, 0xbc, 0x5f, 0x7b, 0x63, 0xe9, 0x8b, 0x20,
+	0xf0, 0x43, 0x8e, 0xfb, 0xa0, 0x50, 0x36, 0x71, 0x23, 0xce, 0x42, 0x5c, 0x7b, 0xec, 0x85, 0x55,
+	0x7f, 0x34, 0xd2, 0x7c, 0x72, 0x92, 0xfb, 0x21, 0x77, 0x3a, 0x84, 0xa6, 0x1f, 0x4e, 0x5a, 0xd3,
+	0x65, 0xc0, 0xc2, 0x19, 0x73, 0x26, 0x2c, 0x6c, 0xdd, 0x5a, 0xe3, 0xd0, 0xb5, 0xd3, 0x71, 0xe2,
+	0x51, 0xf8, 0xeb, 0xf7, 0x13, 0x97, 0x4f, 0x17, 0xe3, 0x96, 0xed, 0xcf, 0xdb, 0x6b, 0xd2, 0x76,
+	0x2c, 0x8d, 0x1f, 0x87, 0x51, 0x5b, 0x48, 0xc7, 0xf1, 0x4b, 0xf3, 0xc7, 0xff, 0x06, 0x00, 0x75,
+	0x94, 0xa5, 0x92, 0x8d, 0x0a, 0x00, 0x00,
 }
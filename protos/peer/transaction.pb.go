@@ -115,7 +115,7 @@ func (x TxValidationCode) String() string {
 	return proto.EnumName(TxValidationCode_name, int32(x))
 }
 func (TxValidationCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4bcf0942bc1b716b, []int{0}
+	return fileDescriptor_transaction_2424326b6caba6b5, []int{0}
 }
 
 // Reserved entries in the key-level metadata map
@@ -123,20 +123,23 @@ type MetaDataKeys int32
 
 const (
 	MetaDataKeys_VALIDATION_PARAMETER MetaDataKeys = 0
+	MetaDataKeys_BLOCK_TO_LIVE        MetaDataKeys = 1
 )
 
 var MetaDataKeys_name = map[int32]string{
 	0: "VALIDATION_PARAMETER",
+	1: "BLOCK_TO_LIVE",
 }
 var MetaDataKeys_value = map[string]int32{
 	"VALIDATION_PARAMETER": 0,
+	"BLOCK_TO_LIVE":        1,
 }
 
 func (x MetaDataKeys) String() string {
 	return proto.EnumName(MetaDataKeys_name, int32(x))
 }
 func (MetaDataKeys) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4bcf0942bc1b716b, []int{1}
+	return fileDescriptor_transaction_2424326b6caba6b5, []int{1}
 }
 
 // This message is necessary to facilitate the verification of the signature
@@ -159,7 +162,7 @@ func (m *SignedTransaction) Reset()         { *m = SignedTransaction{} }
 func (m *SignedTransaction) String() string { return proto.CompactTextString(m) }
 func (*SignedTransaction) ProtoMessage()    {}
 func (*SignedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4bcf0942bc1b716b, []int{0}
+	return fileDescriptor_transaction_2424326b6caba6b5, []int{0}
 }
 func (m *SignedTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedTransaction.Unmarshal(m, b)
@@ -213,7 +216,7 @@ func (m *ProcessedTransaction) Reset()         { *m = ProcessedTransaction{} }
 func (m *ProcessedTransaction) String() string { return proto.CompactTextString(m) }
 func (*ProcessedTransaction) ProtoMessage()    {}
 func (*ProcessedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4bcf0942bc1b716b, []int{1}
+	return fileDescriptor_transaction_2424326b6caba6b5, []int{1}
 }
 func (m *ProcessedTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProcessedTransaction.Unmarshal(m, b)
@@ -276,7 +279,7 @@ func (m *TokenEndorserTransaction) Reset()         { *m = TokenEndorserTransacti
 func (m *TokenEndorserTransaction) String() string { return proto.CompactTextString(m) }
 func (*TokenEndorserTransaction) ProtoMessage()    {}
 func (*TokenEndorserTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4bcf0942bc1b716b, []int{2}
+	return fileDescriptor_transaction_2424326b6caba6b5, []int{2}
 }
 func (m *TokenEndorserTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TokenEndorserTransaction.Unmarshal(m, b)
@@ -335,7 +338,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4bcf0942bc1b716b, []int{3}
+	return fileDescriptor_transaction_2424326b6caba6b5, []int{3}
 }
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Transaction.Unmarshal(m, b)
@@ -379,7 +382,7 @@ func (m *TransactionAction) Reset()         { *m = TransactionAction{} }
 func (m *TransactionAction) String() string { return proto.CompactTextString(m) }
 func (*TransactionAction) ProtoMessage()    {}
 func (*TransactionAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4bcf0942bc1b716b, []int{4}
+	return fileDescriptor_transaction_2424326b6caba6b5, []int{4}
 }
 func (m *TransactionAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TransactionAction.Unmarshal(m, b)
@@ -438,7 +441,7 @@ func (m *ChaincodeActionPayload) Reset()         { *m = ChaincodeActionPayload{}
 func (m *ChaincodeActionPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeActionPayload) ProtoMessage()    {}
 func (*ChaincodeActionPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4bcf0942bc1b716b, []int{5}
+	return fileDescriptor_transaction_2424326b6caba6b5, []int{5}
 }
 func (m *ChaincodeActionPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeActionPayload.Unmarshal(m, b)
@@ -491,7 +494,7 @@ func (m *ChaincodeEndorsedAction) Reset()         { *m = ChaincodeEndorsedAction
 func (m *ChaincodeEndorsedAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeEndorsedAction) ProtoMessage()    {}
 func (*ChaincodeEndorsedAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_transaction_4bcf0942bc1b716b, []int{6}
+	return fileDescriptor_transaction_2424326b6caba6b5, []int{6}
 }
 func (m *ChaincodeEndorsedAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeEndorsedAction.Unmarshal(m, b)
@@ -537,65 +540,68 @@ func init() {
 	proto.RegisterEnum("protos.MetaDataKeys", MetaDataKeys_name, MetaDataKeys_value)
 }
 
-func init() { proto.RegisterFile("peer/transaction.proto", fileDescriptor_transaction_4bcf0942bc1b716b) }
+func init() {
+	proto.RegisterFile("peer/transaction.proto", fileDescriptor_transaction_2424326b6caba6b5)
+}
 
-var fileDescriptor_transaction_4bcf0942bc1b716b = []byte{
-	// 907 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_transaction_2424326b6caba6b5 = []byte{
+	// 918 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0x5d, 0x6f, 0xe3, 0x44,
-	0x14, 0xdd, 0x74, 0x69, 0x4b, 0x6f, 0xbf, 0x26, 0xd3, 0x36, 0x4d, 0xa3, 0x0a, 0x56, 0x79, 0x40,
-	0xcb, 0x22, 0x25, 0xd2, 0xee, 0x03, 0x12, 0x42, 0x48, 0x13, 0x7b, 0xda, 0x58, 0xeb, 0xcc, 0x58,
-	0xe3, 0x49, 0x9a, 0xf2, 0xc0, 0xc8, 0x4d, 0x86, 0x34, 0xda, 0xd4, 0x8e, 0x6c, 0xef, 0x8a, 0xbe,
-	0xf2, 0x03, 0xe0, 0x85, 0x9f, 0xc0, 0xef, 0x04, 0x34, 0xfe, 0x48, 0x9c, 0x16, 0x78, 0xa9, 0x3b,
-	0xe7, 0x9c, 0x7b, 0xef, 0xb9, 0xf7, 0x4e, 0x6c, 0x68, 0x2c, 0xb5, 0x8e, 0xbb, 0x69, 0x1c, 0x84,
-	0x49, 0x30, 0x49, 0xe7, 0x51, 0xd8, 0x59, 0xc6, 0x51, 0x1a, 0xe1, 0x9d, 0xec, 0x91, 0xb4, 0x2e,
-	0x33, 0x7e, 0x19, 0x47, 0xcb, 0x28, 0x09, 0x16, 0x2a, 0xd6, 0xc9, 0x32, 0x0a, 0x13, 0x9d, 0xab,
-	0x5a, 0x27, 0x93, 0xe8, 0xe1, 0x21, 0x0a, 0xbb, 0xf9, 0xa3, 0x00, 0xcf, 0xd3, 0xe8, 0x83, 0x0e,
-	0x9f, 0xe7, 0x6c, 0xff, 0x04, 0x75, 0x7f, 0x3e, 0x0b, 0xf5, 0x54, 0xae, 0x29, 0xfc, 0x0d, 0xd4,
-	0x2b, 0x4a, 0x75, 0xf7, 0x98, 0xea, 0xa4, 0x59, 0x7b, 0x55, 0x7b, 0x7d, 0x20, 0x50, 0x85, 0xe8,
-	0x19, 0x1c, 0x5f, 0xc2, 0x5e, 0x32, 0x9f, 0x85, 0x41, 0xfa, 0x31, 0xd6, 0xcd, 0xad, 0x4c, 0xb4,
-	0x06, 0xda, 0xbf, 0xd6, 0xe0, 0xd4, 0x8b, 0xa3, 0x89, 0x4e, 0x92, 0xcd, 0x1a, 0x3d, 0x38, 0xa9,
-	0xa4, 0xa2, 0xe1, 0x27, 0xbd, 0x88, 0x96, 0x3a, 0xab, 0xb2, 0xff, 0x16, 0x75, 0x0a, 0xf7, 0x25,
-	0x2e, 0xfe, 0x4d, 0x8c, 0xbf, 0x82, 0xa3, 0x4f, 0xc1, 0x62, 0x3e, 0x0d, 0x0c, 0x6a, 0x45, 0xd3,
-	0xbc, 0xfe, 0xb6, 0x78, 0x82, 0xb6, 0xff, 0xac, 0x41, 0x53, 0x9a, 0x01, 0xd0, 0x70, 0x1a, 0xc5,
-	0x89, 0x8e, 0xab, 0x46, 0xfa, 0x80, 0xab, 0xcd, 0xe6, 0x8f, 0xc2, 0xc7, 0x45, 0x3e, 0xa5, 0xa4,
-	0x53, 0x09, 0x20, 0xd9, 0x5f, 0x51, 0x9d, 0x50, 0x0e, 0xe1, 0x1f, 0xa0, 0x9e, 0x8d, 0x59, 0x55,
-	0xa8, 0xcc, 0xd1, 0xfe, 0xdb, 0x7a, 0x27, 0xab, 0x5f, 0x49, 0x23, 0x50, 0xfa, 0x04, 0x69, 0xf7,
-	0x60, 0xbf, 0x6a, 0xec, 0x1d, 0xec, 0xe6, 0xff, 0x99, 0xd9, 0xbf, 0xfc, 0x7f, 0x37, 0xa5, 0xb2,
-	0x4d, 0xa1, 0xfe, 0x8c, 0xc5, 0x0d, 0xd8, 0xb9, 0xd7, 0xc1, 0x54, 0xc7, 0xc5, 0x12, 0x8b, 0x13,
-	0x6e, 0xc2, 0xee, 0x32, 0x78, 0x5c, 0x44, 0xc1, 0xb4, 0x58, 0x5c, 0x79, 0x6c, 0xff, 0x5e, 0x83,
-	0x86, 0x75, 0x1f, 0xcc, 0xc3, 0x49, 0x34, 0xd5, 0x79, 0x16, 0x2f, 0xa7, 0xf0, 0xf7, 0xd0, 0x9a,
-	0x94, 0x8c, 0x5a, 0x5d, 0xc2, 0x32, 0x4f, 0x5e, 0xa0, 0xb9, 0x52, 0x78, 0x85, 0xa0, 0x8c, 0xfe,
-	0x16, 0x76, 0x36, 0x06, 0xf3, 0x65, 0xd9, 0xd3, 0xaa, 0x5a, 0xb1, 0xa3, 0x69, 0xd1, 0x59, 0x21,
-	0x6f, 0xff, 0x56, 0x83, 0xf3, 0xff, 0xd0, 0xe0, 0xef, 0xe0, 0xe2, 0xd9, 0xaf, 0xe1, 0x89, 0xa3,
-	0xf3, 0x52, 0x20, 0x0a, 0x7e, 0x6d, 0xe8, 0x40, 0xe7, 0xd9, 0x1e, 0x74, 0x98, 0x26, 0xcd, 0xad,
-	0x6c, 0xd4, 0x27, 0xa5, 0x2d, 0xba, 0xe6, 0xc4, 0x86, 0xf0, 0xcd, 0x1f, 0xdb, 0x80, 0xe4, 0x2f,
-	0xa3, 0x8d, 0x9b, 0x86, 0xf7, 0x60, 0x7b, 0x44, 0x5c, 0xc7, 0x46, 0x2f, 0x30, 0x82, 0x03, 0xe6,
-	0xb8, 0x8a, 0xb2, 0x11, 0x75, 0xb9, 0x47, 0x51, 0x0d, 0x1f, 0xc3, 0x7e, 0x8f, 0xd8, 0xca, 0x23,
-	0xb7, 0x2e, 0x27, 0x36, 0xda, 0xc2, 0x67, 0x50, 0x37, 0x80, 0xc5, 0x07, 0x03, 0xce, 0x54, 0x9f,
-	0x12, 0x9b, 0x0a, 0xf4, 0x12, 0x5f, 0xc0, 0x59, 0x06, 0x0b, 0x4a, 0x24, 0x17, 0xca, 0x77, 0xae,
-	0x19, 0x91, 0x43, 0x41, 0xd1, 0x67, 0xf8, 0x15, 0x5c, 0x3a, 0x2c, 0xab, 0xa0, 0x28, 0xb3, 0xb9,
-	0xf0, 0xa9, 0x50, 0x52, 0x10, 0xe6, 0x13, 0x4b, 0x3a, 0x9c, 0xa1, 0x6d, 0xfc, 0x05, 0xb4, 0x4a,
-	0x85, 0xc5, 0xd9, 0x95, 0x73, 0xbd, 0xc1, 0xef, 0xe0, 0x16, 0x34, 0x86, 0xcc, 0x1f, 0x7a, 0x1e,
-	0x17, 0x92, 0xda, 0x4a, 0x8e, 0x57, 0x7e, 0x76, 0x4b, 0x3f, 0x9e, 0xe0, 0x1e, 0xf7, 0x89, 0xab,
-	0xe4, 0xd8, 0xb1, 0xd1, 0xe7, 0x18, 0xc3, 0x91, 0x3d, 0xf4, 0x5c, 0xc7, 0x22, 0x92, 0xe6, 0xd8,
-	0x9e, 0x29, 0x53, 0x18, 0x18, 0x50, 0x26, 0x95, 0xc7, 0x5d, 0xc7, 0xba, 0x55, 0x57, 0xc4, 0x71,
-	0x8d, 0x51, 0xc0, 0x0d, 0xc0, 0x83, 0x91, 0x65, 0x29, 0x41, 0x49, 0x6e, 0xc4, 0x75, 0x2c, 0x89,
-	0xf6, 0x4d, 0x6f, 0x5e, 0x9f, 0x30, 0xc9, 0x07, 0x4f, 0xa8, 0x03, 0x7c, 0x02, 0xc7, 0x43, 0xf6,
-	0x9e, 0xf1, 0x1b, 0x66, 0x5c, 0xc9, 0x5b, 0x8f, 0xa2, 0x43, 0x63, 0x57, 0x12, 0x71, 0x4d, 0xa5,
-	0xb2, 0xfa, 0xc4, 0x61, 0x8a, 0x71, 0xa9, 0xae, 0xf8, 0x90, 0xd9, 0xe8, 0x08, 0x9f, 0x02, 0x1a,
-	0x10, 0xe1, 0xf7, 0x33, 0xa7, 0x8a, 0x0a, 0xc1, 0x05, 0x3a, 0x2e, 0xe7, 0x2e, 0xc7, 0x45, 0xcb,
-	0xc8, 0xb4, 0x45, 0xc7, 0x9e, 0x23, 0xa8, 0x9d, 0x27, 0xb1, 0xb8, 0x4d, 0x51, 0xdd, 0xb4, 0xb0,
-	0x3a, 0xaa, 0x11, 0x15, 0xbe, 0xc3, 0xd9, 0xda, 0x0f, 0xc6, 0x4d, 0x38, 0x35, 0xd3, 0xc8, 0xd7,
-	0xa2, 0xe8, 0x58, 0x52, 0x66, 0x24, 0xe8, 0xc4, 0x34, 0x97, 0x2d, 0xa8, 0x4f, 0x18, 0xa3, 0x6e,
-	0xb9, 0xb8, 0xd3, 0x32, 0x42, 0x50, 0xdf, 0xe3, 0xcc, 0xa7, 0xab, 0xc9, 0x9e, 0xe1, 0x43, 0xd8,
-	0xcb, 0x98, 0x1b, 0x9f, 0x4a, 0xd4, 0x30, 0xce, 0x1d, 0xd7, 0xa5, 0xd7, 0xc4, 0x55, 0x37, 0xc2,
-	0x91, 0xd4, 0xa0, 0xe7, 0x19, 0x5a, 0xac, 0x6e, 0x85, 0x36, 0x31, 0x86, 0x43, 0xd3, 0x74, 0x86,
-	0x13, 0x49, 0x6d, 0xf4, 0x57, 0x0d, 0x5f, 0xc0, 0x69, 0xa9, 0xe4, 0xb2, 0x4f, 0x85, 0x99, 0xa5,
-	0xcf, 0x19, 0xfa, 0xbb, 0xf6, 0xe6, 0x35, 0x1c, 0x0c, 0x74, 0x1a, 0xd8, 0x41, 0x1a, 0xbc, 0xd7,
-	0x8f, 0x89, 0xf1, 0x54, 0x84, 0x9a, 0xf6, 0x3c, 0x22, 0xc8, 0x80, 0x4a, 0x2a, 0xd0, 0x8b, 0xde,
-	0x04, 0xda, 0x51, 0x3c, 0xeb, 0xdc, 0x3f, 0x2e, 0x75, 0xbc, 0xd0, 0xd3, 0x99, 0x8e, 0x3b, 0x3f,
-	0x07, 0x77, 0xf1, 0x7c, 0x52, 0xde, 0x7d, 0xf3, 0x99, 0xe9, 0xe1, 0xca, 0xeb, 0xc4, 0x0b, 0x26,
-	0x1f, 0x82, 0x99, 0xfe, 0xf1, 0xeb, 0xd9, 0x3c, 0xbd, 0xff, 0x78, 0x67, 0x5e, 0xd2, 0xdd, 0x4a,
-	0x78, 0x37, 0x0f, 0xef, 0xe6, 0xe1, 0x5d, 0x13, 0x7e, 0x97, 0x7f, 0xb3, 0xde, 0xfd, 0x13, 0x00,
-	0x00, 0xff, 0xff, 0x51, 0x37, 0x7d, 0xf2, 0xd4, 0x06, 0x00, 0x00,
+	0x14, 0x5d, 0xef, 0xd2, 0x96, 0xde, 0x7e, 0x4d, 0xa6, 0x6d, 0x9a, 0x56, 0x15, 0xac, 0xf2, 0x80,
+	0x60, 0x91, 0x12, 0x69, 0xf7, 0x01, 0x09, 0x10, 0xd2, 0xc4, 0x9e, 0x36, 0x56, 0x9d, 0x19, 0x6b,
+	0x3c, 0x49, 0x5b, 0x1e, 0x18, 0xb9, 0xc9, 0x90, 0x46, 0xdb, 0xda, 0x91, 0xed, 0x5d, 0xd1, 0x57,
+	0x7e, 0x00, 0xbc, 0xf0, 0x13, 0xf8, 0x9d, 0x80, 0xc6, 0x1f, 0x89, 0x93, 0x02, 0x2f, 0x71, 0xe6,
+	0x9c, 0x73, 0xef, 0x3d, 0xf7, 0x5e, 0x7f, 0x40, 0x73, 0xae, 0x75, 0xd2, 0xcd, 0x92, 0x30, 0x4a,
+	0xc3, 0x71, 0x36, 0x8b, 0xa3, 0xce, 0x3c, 0x89, 0xb3, 0x18, 0x6f, 0xe6, 0x97, 0xf4, 0xec, 0x3c,
+	0xe7, 0xe7, 0x49, 0x3c, 0x8f, 0xd3, 0xf0, 0x41, 0x25, 0x3a, 0x9d, 0xc7, 0x51, 0xaa, 0x0b, 0xd5,
+	0xd9, 0xe1, 0x38, 0x7e, 0x7c, 0x8c, 0xa3, 0x6e, 0x71, 0x29, 0xc1, 0x93, 0x2c, 0x7e, 0xaf, 0xa3,
+	0xe7, 0x39, 0xdb, 0x3f, 0x41, 0x23, 0x98, 0x4d, 0x23, 0x3d, 0x91, 0x4b, 0x0a, 0x7f, 0x0d, 0x8d,
+	0x9a, 0x52, 0xdd, 0x3d, 0x65, 0x3a, 0x6d, 0x59, 0xaf, 0xad, 0x2f, 0x77, 0x05, 0xaa, 0x11, 0x3d,
+	0x83, 0xe3, 0x73, 0xd8, 0x4e, 0x67, 0xd3, 0x28, 0xcc, 0x3e, 0x24, 0xba, 0xf5, 0x32, 0x17, 0x2d,
+	0x81, 0xf6, 0xaf, 0x16, 0x1c, 0xf9, 0x49, 0x3c, 0xd6, 0x69, 0xba, 0x5a, 0xa3, 0x07, 0x87, 0xb5,
+	0x54, 0x34, 0xfa, 0xa8, 0x1f, 0xe2, 0xb9, 0xce, 0xab, 0xec, 0xbc, 0x45, 0x9d, 0xd2, 0x7d, 0x85,
+	0x8b, 0x7f, 0x13, 0xe3, 0x2f, 0x60, 0xff, 0x63, 0xf8, 0x30, 0x9b, 0x84, 0x06, 0xb5, 0xe3, 0x49,
+	0x51, 0x7f, 0x43, 0xac, 0xa1, 0xed, 0x3f, 0x2d, 0x68, 0x49, 0x33, 0x00, 0x1a, 0x4d, 0xe2, 0x24,
+	0xd5, 0x49, 0xdd, 0x48, 0x1f, 0x70, 0xbd, 0xd9, 0xe2, 0x52, 0xfa, 0x38, 0x2d, 0xa6, 0x94, 0x76,
+	0x6a, 0x01, 0x24, 0xff, 0x15, 0x8d, 0x6c, 0x1d, 0xc2, 0x3f, 0x40, 0x23, 0x1f, 0xb3, 0xaa, 0x51,
+	0xb9, 0xa3, 0x9d, 0xb7, 0x8d, 0x4e, 0x5e, 0xbf, 0x96, 0x46, 0xa0, 0x6c, 0x0d, 0x69, 0xf7, 0x60,
+	0xa7, 0x6e, 0xec, 0x1d, 0x6c, 0x15, 0xff, 0xcc, 0xec, 0x5f, 0xfd, 0xbf, 0x9b, 0x4a, 0xd9, 0xa6,
+	0xd0, 0x78, 0xc6, 0xe2, 0x26, 0x6c, 0xde, 0xeb, 0x70, 0xa2, 0x93, 0x72, 0x89, 0xe5, 0x09, 0xb7,
+	0x60, 0x6b, 0x1e, 0x3e, 0x3d, 0xc4, 0xe1, 0xa4, 0x5c, 0x5c, 0x75, 0x6c, 0xff, 0x6e, 0x41, 0xd3,
+	0xbe, 0x0f, 0x67, 0xd1, 0x38, 0x9e, 0xe8, 0x22, 0x8b, 0x5f, 0x50, 0xf8, 0x7b, 0x38, 0x1b, 0x57,
+	0x8c, 0x5a, 0xdc, 0x84, 0x55, 0x9e, 0xa2, 0x40, 0x6b, 0xa1, 0xf0, 0x4b, 0x41, 0x15, 0xfd, 0x0d,
+	0x6c, 0xae, 0x0c, 0xe6, 0xf3, 0xaa, 0xa7, 0x45, 0xb5, 0x72, 0x47, 0x93, 0xb2, 0xb3, 0x52, 0xde,
+	0xfe, 0xcd, 0x82, 0x93, 0xff, 0xd0, 0xe0, 0x6f, 0xe1, 0xf4, 0xd9, 0xd3, 0xb0, 0xe6, 0xe8, 0xa4,
+	0x12, 0x88, 0x92, 0x5f, 0x1a, 0xda, 0xd5, 0x45, 0xb6, 0x47, 0x1d, 0x65, 0x69, 0xeb, 0x65, 0x3e,
+	0xea, 0xc3, 0xca, 0x16, 0x5d, 0x72, 0x62, 0x45, 0xf8, 0xe6, 0x8f, 0x0d, 0x40, 0xf2, 0x97, 0xd1,
+	0xca, 0x9d, 0x86, 0xb7, 0x61, 0x63, 0x44, 0x3c, 0xd7, 0x41, 0x2f, 0x30, 0x82, 0x5d, 0xe6, 0x7a,
+	0x8a, 0xb2, 0x11, 0xf5, 0xb8, 0x4f, 0x91, 0x85, 0x0f, 0x60, 0xa7, 0x47, 0x1c, 0xe5, 0x93, 0x5b,
+	0x8f, 0x13, 0x07, 0xbd, 0xc4, 0xc7, 0xd0, 0x30, 0x80, 0xcd, 0x07, 0x03, 0xce, 0x54, 0x9f, 0x12,
+	0x87, 0x0a, 0xf4, 0x0a, 0x9f, 0xc2, 0x71, 0x0e, 0x0b, 0x4a, 0x24, 0x17, 0x2a, 0x70, 0x2f, 0x19,
+	0x91, 0x43, 0x41, 0xd1, 0x27, 0xf8, 0x35, 0x9c, 0xbb, 0x2c, 0xaf, 0xa0, 0x28, 0x73, 0xb8, 0x08,
+	0xa8, 0x50, 0x52, 0x10, 0x16, 0x10, 0x5b, 0xba, 0x9c, 0xa1, 0x0d, 0xfc, 0x19, 0x9c, 0x55, 0x0a,
+	0x9b, 0xb3, 0x0b, 0xf7, 0x72, 0x85, 0xdf, 0xc4, 0x67, 0xd0, 0x1c, 0xb2, 0x60, 0xe8, 0xfb, 0x5c,
+	0x48, 0xea, 0x28, 0x79, 0xb3, 0xf0, 0xb3, 0x55, 0xf9, 0xf1, 0x05, 0xf7, 0x79, 0x40, 0x3c, 0x25,
+	0x6f, 0x5c, 0x07, 0x7d, 0x8a, 0x31, 0xec, 0x3b, 0x43, 0xdf, 0x73, 0x6d, 0x22, 0x69, 0x81, 0x6d,
+	0x9b, 0x32, 0xa5, 0x81, 0x01, 0x65, 0x52, 0xf9, 0xdc, 0x73, 0xed, 0x5b, 0x75, 0x41, 0x5c, 0xcf,
+	0x18, 0x05, 0xdc, 0x04, 0x3c, 0x18, 0xd9, 0xb6, 0x12, 0x94, 0x14, 0x46, 0x3c, 0xd7, 0x96, 0x68,
+	0xc7, 0xf4, 0xe6, 0xf7, 0x09, 0x93, 0x7c, 0xb0, 0x46, 0xed, 0xe2, 0x43, 0x38, 0x18, 0xb2, 0x2b,
+	0xc6, 0xaf, 0x99, 0x71, 0x25, 0x6f, 0x7d, 0x8a, 0xf6, 0x8c, 0x5d, 0x49, 0xc4, 0x25, 0x95, 0xca,
+	0xee, 0x13, 0x97, 0x29, 0xc6, 0xa5, 0xba, 0xe0, 0x43, 0xe6, 0xa0, 0x7d, 0x7c, 0x04, 0x68, 0x40,
+	0x44, 0xd0, 0xcf, 0x9d, 0x2a, 0x2a, 0x04, 0x17, 0xe8, 0xa0, 0x9a, 0xbb, 0xbc, 0x29, 0x5b, 0x46,
+	0xa6, 0x2d, 0x7a, 0xe3, 0xbb, 0x82, 0x3a, 0x45, 0x12, 0x9b, 0x3b, 0x14, 0x35, 0x4c, 0x0b, 0x8b,
+	0xa3, 0x1a, 0x51, 0x11, 0xb8, 0x9c, 0x2d, 0xfd, 0x60, 0xdc, 0x82, 0x23, 0x33, 0x8d, 0x62, 0x2d,
+	0x8a, 0xde, 0x48, 0xca, 0x8c, 0x04, 0x1d, 0x9a, 0xe6, 0xf2, 0x05, 0xf5, 0x09, 0x63, 0xd4, 0xab,
+	0x16, 0x77, 0x54, 0x45, 0x08, 0x1a, 0xf8, 0x9c, 0x05, 0x74, 0x31, 0xd9, 0x63, 0xbc, 0x07, 0xdb,
+	0x39, 0x73, 0x1d, 0x50, 0x89, 0x9a, 0xc6, 0xb9, 0xeb, 0x79, 0xf4, 0x92, 0x78, 0xea, 0x5a, 0xb8,
+	0x92, 0x1a, 0xf4, 0x24, 0x47, 0xcb, 0xd5, 0x2d, 0xd0, 0x16, 0xc6, 0xb0, 0x67, 0x9a, 0xce, 0x71,
+	0x22, 0xa9, 0x83, 0xfe, 0xb2, 0xf0, 0x29, 0x1c, 0x55, 0x4a, 0x2e, 0xfb, 0x54, 0x98, 0x59, 0x06,
+	0x9c, 0xa1, 0xbf, 0xad, 0x37, 0xdf, 0xc1, 0xee, 0x40, 0x67, 0xa1, 0x13, 0x66, 0xe1, 0x95, 0x7e,
+	0x4a, 0x8d, 0xa7, 0x32, 0xd4, 0xb4, 0xe7, 0x13, 0x41, 0x06, 0x54, 0x52, 0x81, 0x5e, 0xe0, 0x06,
+	0xec, 0xf5, 0x3c, 0x6e, 0x5f, 0x29, 0xc9, 0x95, 0xe7, 0x8e, 0x28, 0xb2, 0x7a, 0x63, 0x68, 0xc7,
+	0xc9, 0xb4, 0x73, 0xff, 0x34, 0xd7, 0xc9, 0x83, 0x9e, 0x4c, 0x75, 0xd2, 0xf9, 0x39, 0xbc, 0x4b,
+	0x66, 0xe3, 0xea, 0x71, 0x30, 0x5f, 0x9e, 0x1e, 0xae, 0xbd, 0x61, 0xfc, 0x70, 0xfc, 0x3e, 0x9c,
+	0xea, 0x1f, 0xbf, 0x9a, 0xce, 0xb2, 0xfb, 0x0f, 0x77, 0xe6, 0xbd, 0xdd, 0xad, 0x85, 0x77, 0x8b,
+	0xf0, 0x6e, 0x11, 0xde, 0x35, 0xe1, 0x77, 0xc5, 0x67, 0xec, 0xdd, 0x3f, 0x03, 0x00, 0x93, 0x89,
+	0x7c, 0x05, 0xe7, 0x06, 0x00, 0x00,
 }
@@ -34,7 +34,7 @@ cat docs/wrappers/peer_chaincode_postscript.md >> $DOC
 DOC=docs/source/commands/peerchannel.md
 cat docs/wrappers/peer_channel_preamble.md > $DOC
 
-for x in "peer channel" "peer channel create" "peer channel fetch" "peer channel getinfo" "peer channel join" "peer channel list" "peer channel signconfigtx" "peer channel update"; do
+for x in "peer channel" "peer channel create" "peer channel fetch" "peer channel getinfo" "peer channel join" "peer channel list" "peer channel signconfigtx" "peer channel unjoin" "peer channel update"; do
   echo "" >> $DOC
   echo "##" $x >> $DOC
   echo "\`\`\`" >> $DOC
@@ -57,10 +57,36 @@ for x in "peer logging" "peer logging getlevel" "peer logging revertlevels" "pee
 done
 cat docs/wrappers/peer_logging_postscript.md >> $DOC
 
+DOC=docs/source/commands/peerindex.md
+cat docs/wrappers/peer_index_preamble.md > $DOC
+
+for x in "peer index" "peer index add" "peer index list" "peer index drop"; do
+  echo "" >> $DOC
+  echo "##" $x >> $DOC
+  echo "\`\`\`" >> $DOC
+  .build/bin/${x} --help 1>> $DOC 2>/dev/null
+  echo "\`\`\`" >> $DOC
+  echo "" >> $DOC
+done
+cat docs/wrappers/peer_index_postscript.md >> $DOC
+
+DOC=docs/source/commands/peerstorage.md
+cat docs/wrappers/peer_storage_preamble.md > $DOC
+
+for x in "peer storage" "peer storage usage" "peer storage compact"; do
+  echo "" >> $DOC
+  echo "##" $x >> $DOC
+  echo "\`\`\`" >> $DOC
+  .build/bin/${x} --help 1>> $DOC 2>/dev/null
+  echo "\`\`\`" >> $DOC
+  echo "" >> $DOC
+done
+cat docs/wrappers/peer_storage_postscript.md >> $DOC
+
 DOC=docs/source/commands/peernode.md
 cat docs/wrappers/peer_node_preamble.md > $DOC
 
-for x in "peer node start" "peer node status"; do
+for x in "peer node start" "peer node status" "peer node snapshot generate" "peer node snapshot import" "peer node state export" "peer node state import" "peer node verify-ledger" "peer node rebuild-dbs" "peer node rollback" "peer node unjoin"; do
   echo "" >> $DOC
   echo "##" $x >> $DOC
   echo "\`\`\`" >> $DOC